@@ -0,0 +1,94 @@
+//go:build js && wasm
+
+// Command make-help-wasm is make-help's WebAssembly build. Run in a browser
+// or Node.js with Go's wasm_exec.js, it defines a global makeHelp object:
+//
+//	makeHelp.render(input, options)  // {output} or {error}
+//	makeHelp.renderHTML(input)       // render with format "html"
+//	makeHelp.renderJSON(input)       // render with format "json"
+//
+// input is the text of a Makefile, or an object mapping paths to the text
+// of a Makefile and the files it includes. options is an object with the
+// fields of playground.Options (format, makefile, defaultCategory, ...).
+// Errors are returned rather than thrown, since a panic would stop the Go
+// program. make is never run: discovery is static, as with --no-exec.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"syscall/js"
+
+	"github.com/sdlcforge/make-help/internal/playground"
+	"github.com/sdlcforge/make-help/internal/version"
+)
+
+func main() {
+	js.Global().Set("makeHelp", js.ValueOf(map[string]any{
+		"render":     js.FuncOf(render),
+		"renderHTML": js.FuncOf(renderWithFormat("html")),
+		"renderJSON": js.FuncOf(renderWithFormat("json")),
+		"version":    version.Version,
+	}))
+	// Keep the functions callable
+	select {}
+}
+
+// render implements makeHelp.render(input, options).
+func render(this js.Value, args []js.Value) any {
+	if len(args) == 0 {
+		return map[string]any{"error": "an input is required"}
+	}
+	var options playground.Options
+	if len(args) > 1 && args[1].Type() == js.TypeObject {
+		if err := json.Unmarshal([]byte(stringify(args[1])), &options); err != nil {
+			return map[string]any{"error": fmt.Sprintf("invalid options: %v", err)}
+		}
+	}
+	return renderFiles(args[0], options)
+}
+
+// renderWithFormat returns a function rendering its input in formatName.
+func renderWithFormat(formatName string) func(js.Value, []js.Value) any {
+	return func(this js.Value, args []js.Value) any {
+		if len(args) == 0 {
+			return map[string]any{"error": "an input is required"}
+		}
+		return renderFiles(args[0], playground.Options{Format: formatName})
+	}
+}
+
+// renderFiles renders input with options, returning {output} or {error}.
+func renderFiles(input js.Value, options playground.Options) any {
+	files, err := decodeFiles(input)
+	if err != nil {
+		return map[string]any{"error": err.Error()}
+	}
+	output, err := playground.Render(files, options)
+	if err != nil {
+		return map[string]any{"error": err.Error()}
+	}
+	return map[string]any{"output": output}
+}
+
+// decodeFiles returns the files given as Makefile text or as an object
+// mapping paths to text.
+func decodeFiles(input js.Value) (map[string]string, error) {
+	switch input.Type() {
+	case js.TypeString:
+		return map[string]string{playground.DefaultMakefile: input.String()}, nil
+	case js.TypeObject:
+		var files map[string]string
+		if err := json.Unmarshal([]byte(stringify(input)), &files); err != nil {
+			return nil, fmt.Errorf("invalid files: %v", err)
+		}
+		return files, nil
+	default:
+		return nil, fmt.Errorf("input must be Makefile text or an object mapping paths to text")
+	}
+}
+
+// stringify returns the JSON text of a JavaScript value.
+func stringify(value js.Value) string {
+	return js.Global().Get("JSON").Call("stringify", value).String()
+}
@@ -1,14 +1,34 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/sdlcforge/make-help/internal/cli"
 )
 
+// exitInterrupted is the exit code after SIGINT or SIGTERM stopped a run:
+// 128 + SIGINT, as shells report for a process killed by Ctrl-C.
+const exitInterrupted = 130
+
 func main() {
-	if err := cli.NewRootCmd().Execute(); err != nil {
+	// The first signal cancels the context, stopping make and letting
+	// deferred cleanup of temp files and locks run; a second one exits at once
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
+	err := cli.NewRootCmd().ExecuteContext(ctx)
+	if err != nil && ctx.Err() != nil {
+		fmt.Fprintln(os.Stderr, "Interrupted")
+		os.Exit(exitInterrupted)
+	}
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/sdlcforge/make-help/internal/discovery"
+	mherrors "github.com/sdlcforge/make-help/internal/errors"
+	"github.com/sdlcforge/make-help/internal/fragment"
+	"github.com/sdlcforge/make-help/internal/target"
+)
+
+// runAddFragment installs the documented Makefile fragment named by
+// --add-fragment as make/<name>.mk and includes it from the Makefile.
+// An existing fragment file is never overwritten.
+func runAddFragment(config *Config) error {
+	makefilePath, err := discovery.ResolveMakefilePath(config.MakefilePath)
+	if err != nil {
+		return fmt.Errorf("failed to resolve Makefile path: %w", err)
+	}
+	if err := discovery.ValidateMakefileExists(makefilePath); err != nil {
+		return err
+	}
+	config.MakefilePath = makefilePath
+
+	content, err := fragment.Content(config.AddFragment)
+	if err != nil {
+		return err
+	}
+
+	// Keep other make-help processes from modifying the project meanwhile
+	if !config.DryRun {
+		release, err := lockProject(config, makefilePath)
+		if err != nil {
+			return err
+		}
+		defer release()
+	}
+
+	// Use the suffix of an existing make/* include pattern so no new include is needed
+	suffix, err := target.IncludeSuffix(makefilePath)
+	if err != nil {
+		return err
+	}
+	fragmentFile := filepath.Join(filepath.Dir(makefilePath), "make", config.AddFragment+suffix)
+	if _, err := os.Stat(fragmentFile); err == nil {
+		return fmt.Errorf("%s already exists", fragmentFile)
+	}
+
+	if config.DryRun {
+		fmt.Println("Dry run mode - no files will be modified")
+		fmt.Println()
+		fmt.Printf("Would create: %s\n", fragmentFile)
+		fmt.Println()
+		fmt.Printf("--- %s ---\n", fragmentFile)
+		fmt.Print(string(content))
+		fmt.Println("--- end ---")
+		return nil
+	}
+
+	tx := beginJournal(config, makefilePath)
+	if err := tx.Track(fragmentFile, makefilePath); err != nil {
+		return err
+	}
+	defer commitJournal(tx)
+
+	if err := os.MkdirAll(filepath.Dir(fragmentFile), 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", filepath.Dir(fragmentFile), err)
+	}
+	if err := target.AtomicWriteFile(fragmentFile, content, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", fragmentFile, err)
+	}
+	if err := target.AddIncludeDirective(makefilePath, fragmentFile); err != nil {
+		return err
+	}
+
+	fmt.Printf("Added %s fragment: %s\n", config.AddFragment, fragmentFile)
+
+	// Fragments are categorized; help for a project whose own targets are not
+	// fails until they get a default category
+	var mixed *mherrors.MixedCategorizationError
+	if _, err := buildHelpModel(config); errors.As(err, &mixed) {
+		fmt.Fprintf(os.Stderr, "Note: the %s fragment's targets are categorized but other targets are not; "+
+			"generate help with --default-category <name> or add !category directives\n", config.AddFragment)
+	}
+
+	return runPostHooks(config, fragmentFile)
+}
@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunAddFragment(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	makefilePath := filepath.Join(tmpDir, "Makefile")
+	require.NoError(t, os.WriteFile(makefilePath, []byte(injectTestMakefile), 0644))
+
+	config := NewConfig()
+	config.MakefilePath = makefilePath
+	config.AddFragment = "docker"
+	require.NoError(t, runAddFragment(config))
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "make", "docker.mk"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "docker-build:")
+	makefile, err := os.ReadFile(makefilePath)
+	require.NoError(t, err)
+	assert.Contains(t, string(makefile), "-include make/*.mk")
+
+	// Fragments are never overwritten
+	err = runAddFragment(config)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already exists")
+}
+
+func TestRunAddFragment_DryRun(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	makefilePath := filepath.Join(tmpDir, "Makefile")
+	require.NoError(t, os.WriteFile(makefilePath, []byte(injectTestMakefile), 0644))
+
+	config := NewConfig()
+	config.MakefilePath = makefilePath
+	config.AddFragment = "go"
+	config.DryRun = true
+	require.NoError(t, runAddFragment(config))
+
+	assert.NoDirExists(t, filepath.Join(tmpDir, "make"))
+	makefile, err := os.ReadFile(makefilePath)
+	require.NoError(t, err)
+	assert.Equal(t, injectTestMakefile, string(makefile))
+}
+
+func TestAddFragmentFlagValidation(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		args      []string
+		errorText string
+	}{
+		{
+			name:      "unknown fragment",
+			args:      []string{"--add-fragment", "rust"},
+			errorText: "unknown fragment: rust (available: docker, go, node)",
+		},
+		{
+			name:      "with lint",
+			args:      []string{"--add-fragment", "go", "--lint"},
+			errorText: "--add-fragment cannot be used with --lint",
+		},
+		{
+			name:      "with output",
+			args:      []string{"--add-fragment", "go", "--output", "-"},
+			errorText: "--add-fragment cannot be used with --output",
+		},
+		{
+			name:      "with remove-help",
+			args:      []string{"--add-fragment", "go", "--remove-help"},
+			errorText: "--remove-help cannot be used with --add-fragment",
+		},
+		{
+			name:      "valid",
+			args:      []string{"--add-fragment", "go", "--makefile-path", "/nonexistent/Makefile"},
+			errorText: "Makefile not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cmd := NewRootCmd()
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorText)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/sdlcforge/make-help/internal/discovery"
+)
+
+// skippedProjectDirs are directories --all-makefiles does not search, in
+// addition to hidden ones.
+var skippedProjectDirs = []string{"node_modules", "vendor"}
+
+// runAllMakefiles generates a help file for every project Makefile found
+// under the working directory. Each project's help file is placed and
+// included relative to its own Makefile, as if make-help were run in its
+// directory. Makefiles that another project's Makefile includes are part of
+// that project and get no help file of their own.
+func runAllMakefiles(config *Config) error {
+	root, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	makefiles, err := findProjectMakefiles(root)
+	if err != nil {
+		return err
+	}
+	if len(makefiles) == 0 {
+		return fmt.Errorf("no Makefiles found in %s", root)
+	}
+
+	discoveryService := newDiscoveryService(config, newMakeExecutor(config))
+	included := make(map[string]bool)
+	for _, makefile := range makefiles {
+		warnShellExpressions(config, makefile)
+		files, err := discoveryService.DiscoverMakefiles(config.runContext(), makefile)
+		if err != nil {
+			return fmt.Errorf("failed to discover Makefile includes of %s: %w", makefile, err)
+		}
+		for _, file := range files {
+			if filepath.Clean(file) != makefile {
+				included[filepath.Clean(file)] = true
+			}
+		}
+	}
+
+	for _, makefile := range makefiles {
+		if included[makefile] {
+			if config.Verbose {
+				fmt.Fprintf(os.Stderr, "Skipping %s: included by another Makefile\n", makefile)
+			}
+			continue
+		}
+
+		// Each project gets its own copy, since generation records the
+		// resolved Makefile path in the config
+		projectConfig := *config
+		projectConfig.MakefilePath = makefile
+		if err := runCreateHelpTarget(&projectConfig); err != nil {
+			return fmt.Errorf("%s: %w", makefile, err)
+		}
+	}
+	return nil
+}
+
+// findProjectMakefiles returns the Makefile of root and of each directory
+// below it, choosing between discovery.DefaultMakefileNames as make does. Parent directories
+// come before their subdirectories. Hidden directories and
+// skippedProjectDirs are not searched.
+func findProjectMakefiles(root string) ([]string, error) {
+	var makefiles []string
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() {
+			return nil
+		}
+		if path != root {
+			name := entry.Name()
+			if strings.HasPrefix(name, ".") || slices.Contains(skippedProjectDirs, name) {
+				return filepath.SkipDir
+			}
+		}
+		for _, name := range discovery.DefaultMakefileNames {
+			candidate := filepath.Join(path, name)
+			if info, err := os.Stat(candidate); err == nil && info.Mode().IsRegular() {
+				makefiles = append(makefiles, candidate)
+				break
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search %s for Makefiles: %w", root, err)
+	}
+	return makefiles, nil
+}
@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindProjectMakefiles(t *testing.T) {
+	t.Parallel()
+	root := t.TempDir()
+	files := []string{
+		"Makefile",
+		"services/api/Makefile",
+		"services/web/GNUmakefile",
+		"services/web/Makefile",
+		"services/worker/makefile",
+		"docs/README.md",
+		".git/Makefile",
+		"node_modules/pkg/Makefile",
+		"vendor/lib/Makefile",
+	}
+	for _, file := range files {
+		path := filepath.Join(root, file)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte("all:\n"), 0644))
+	}
+
+	makefiles, err := findProjectMakefiles(root)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(root, "Makefile"),
+		filepath.Join(root, "services/api/Makefile"),
+		filepath.Join(root, "services/web/GNUmakefile"),
+		filepath.Join(root, "services/worker/makefile"),
+	}, makefiles)
+}
+
+func TestRunAllMakefiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFile := func(name, content string) {
+		path := filepath.Join(tmpDir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	writeFile("Makefile", "## Build everything\nbuild:\n\t@echo build\n\ninclude lib/Makefile\n")
+	writeFile("lib/Makefile", "## Build the library\nlib:\n\t@echo lib\n")
+	writeFile("services/api/Makefile", "## Run the API\nrun:\n\t@echo run\n")
+
+	// --chdir changes the working directory; t.Chdir restores it
+	t.Chdir(filepath.Dir(tmpDir))
+	cmd := NewRootCmd()
+	cmd.SetArgs([]string{"--chdir", filepath.Base(tmpDir), "--all-makefiles", "--help-file-rel-path", "help.mk"})
+	require.NoError(t, cmd.Execute())
+
+	for _, dir := range []string{".", "services/api"} {
+		help, err := os.ReadFile(filepath.Join(tmpDir, dir, "help.mk"))
+		require.NoError(t, err, dir)
+		assert.Contains(t, string(help), "# generated-by: make-help")
+
+		makefile, err := os.ReadFile(filepath.Join(tmpDir, dir, "Makefile"))
+		require.NoError(t, err)
+		assert.Contains(t, string(makefile), "\n-include $(dir $(lastword $(MAKEFILE_LIST)))help.mk\n", dir)
+	}
+
+	api, err := os.ReadFile(filepath.Join(tmpDir, "services/api/help.mk"))
+	require.NoError(t, err)
+	assert.Contains(t, string(api), "run")
+	assert.NotContains(t, string(api), "Build everything")
+
+	// lib/Makefile belongs to the root project, which includes it
+	_, err = os.Stat(filepath.Join(tmpDir, "lib/help.mk"))
+	assert.True(t, os.IsNotExist(err), "lib/help.mk should not be created")
+}
@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sdlcforge/make-help/internal/graph"
+	"github.com/sdlcforge/make-help/internal/target"
+)
+
+// runAnalyze reports the targets with the most dependents, the deepest
+// dependency chains, and the targets nothing depends on.
+func runAnalyze(config *Config) error {
+	inputs, err := loadModelInputs(config)
+	if err != nil {
+		return err
+	}
+
+	helpModel, err := buildHelpModelFromInputs(config, inputs)
+	if err != nil {
+		return err
+	}
+
+	// Skip make-help's generated help targets, and the Makefiles themselves,
+	// which make lists as targets because it can remake them
+	documented := make(map[string]bool)
+	skip := map[string]bool{"help": true, "update-help": true, "help-regen": true}
+	for _, category := range helpModel.Categories {
+		for _, t := range category.Targets {
+			documented[t.Name] = true
+			for _, alias := range t.Aliases {
+				documented[alias] = true
+			}
+			skip["help-"+t.Name] = true
+			skip[target.RequiredVarCheckTarget(t.Name)] = true
+		}
+	}
+	makefileDir := filepath.Dir(inputs.MakefilePath)
+	for _, pf := range inputs.ParsedFiles {
+		skip[pf.Path] = true
+	}
+	var targets []string
+	for _, name := range inputs.Targets.Targets {
+		path := name
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(makefileDir, path)
+		}
+		if !skip[name] && !skip[path] {
+			targets = append(targets, name)
+		}
+	}
+
+	analysis := graph.Analyze(targets, documented, inputs.Targets.Dependencies, config.Top)
+	return writeAnalysis(os.Stdout, analysis, config.Format)
+}
+
+// writeAnalysis writes analysis as text or JSON.
+func writeAnalysis(w io.Writer, analysis *graph.Analysis, format string) error {
+	if format == "json" {
+		data, err := json.MarshalIndent(analysis, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode analysis: %w", err)
+		}
+		_, err = fmt.Fprintf(w, "%s\n", data)
+		return err
+	}
+
+	var sb strings.Builder
+	sb.WriteString("Most dependents:\n")
+	if len(analysis.MostDependents) == 0 {
+		sb.WriteString("  (none)\n")
+	}
+	for _, t := range analysis.MostDependents {
+		fmt.Fprintf(&sb, "  %s: %d%s\n", t.Name, t.Dependents, undocumentedSuffix(t.Documented))
+	}
+
+	sb.WriteString("\nDeepest dependency chains:\n")
+	if len(analysis.DeepestChains) == 0 {
+		sb.WriteString("  (none)\n")
+	}
+	for _, c := range analysis.DeepestChains {
+		fmt.Fprintf(&sb, "  %s: %d%s\n", c.Name, c.Depth, undocumentedSuffix(c.Documented))
+		fmt.Fprintf(&sb, "    %s\n", strings.Join(c.Path, " -> "))
+	}
+
+	sb.WriteString("\nOrphan targets (nothing depends on them):\n")
+	if len(analysis.Orphans) == 0 {
+		sb.WriteString("  (none)\n")
+	}
+	for _, t := range analysis.Orphans {
+		fmt.Fprintf(&sb, "  %s%s\n", t.Name, undocumentedSuffix(t.Documented))
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// undocumentedSuffix marks undocumented targets in the text analysis.
+func undocumentedSuffix(documented bool) string {
+	if documented {
+		return ""
+	}
+	return " (undocumented)"
+}
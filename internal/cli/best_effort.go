@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/sdlcforge/make-help/internal/model"
+	"github.com/sdlcforge/make-help/internal/parser"
+)
+
+// parseMakefiles scans each Makefile for documentation directives. With
+// --best-effort, a Makefile that cannot be parsed is skipped with a
+// warning instead of failing the run.
+func parseMakefiles(config *Config, makefiles []string) ([]*parser.ParsedFile, error) {
+	scanner := parser.NewScanner()
+	var parsedFiles []*parser.ParsedFile
+
+	for _, mf := range makefiles {
+		parsed, err := scanner.ScanFile(mf)
+		if err != nil {
+			if config.BestEffort {
+				fmt.Fprintf(os.Stderr, "Warning: skipping %s: %v\n", mf, err)
+				continue
+			}
+			return nil, fmt.Errorf("failed to parse %s: %w", mf, err)
+		}
+		parsedFiles = append(parsedFiles, parsed)
+	}
+	return parsedFiles, nil
+}
+
+// warnDegradations reports the build errors --best-effort worked around.
+func warnDegradations(builder *model.Builder) {
+	for _, message := range builder.Degradations() {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", message)
+	}
+}
+
+// bestEffortCategoryOrder returns --category-order for helpModel. With
+// --best-effort, categories the model does not have are dropped with a
+// warning, where ordering would otherwise fail on them.
+func bestEffortCategoryOrder(config *Config, helpModel *model.HelpModel) []string {
+	if !config.BestEffort || len(config.CategoryOrder) == 0 {
+		return config.CategoryOrder
+	}
+
+	known := model.GetCategoryNames(helpModel)
+	var order []string
+	for _, name := range config.CategoryOrder {
+		if !slices.Contains(known, name) {
+			fmt.Fprintf(os.Stderr, "Warning: ignoring unknown category %q in --category-order\n", name)
+			continue
+		}
+		order = append(order, name)
+	}
+	return order
+}
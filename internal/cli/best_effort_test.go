@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sdlcforge/make-help/internal/model"
+)
+
+func TestBuildHelpModel_BestEffort(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	makefilePath := filepath.Join(tmpDir, "Makefile")
+	require.NoError(t, os.WriteFile(makefilePath, []byte(`## !category Build
+## Build the project.
+build:
+	@true
+
+## !category _
+## Run the linters.
+lint:
+	@true
+`), 0644))
+
+	config := NewConfig()
+	config.MakefilePath = makefilePath
+	config.CategoryOrder = []string{"Build", "Deploy"}
+	_, err := buildHelpModel(config)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "mixed categorization")
+
+	config = NewConfig()
+	config.MakefilePath = makefilePath
+	config.CategoryOrder = []string{"Uncategorized", "Deploy", "Build"}
+	config.BestEffort = true
+	helpModel, err := buildHelpModel(config)
+	require.NoError(t, err)
+	require.Len(t, helpModel.Categories, 2)
+	assert.Equal(t, model.BestEffortCategoryName, helpModel.Categories[0].Name)
+	assert.Equal(t, "lint", helpModel.Categories[0].Targets[0].Name)
+	assert.Contains(t, helpModel.Categories[0].Documentation[0], "--best-effort")
+	assert.Equal(t, "Build", helpModel.Categories[1].Name)
+}
+
+func TestParseMakefiles_BestEffort(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	makefilePath := filepath.Join(tmpDir, "Makefile")
+	require.NoError(t, os.WriteFile(makefilePath, []byte("## Build it.\nbuild:\n\t@true\n"), 0644))
+	missing := filepath.Join(tmpDir, "missing.mk")
+
+	config := NewConfig()
+	_, err := parseMakefiles(config, []string{makefilePath, missing})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to parse "+missing)
+
+	config.BestEffort = true
+	parsedFiles, err := parseMakefiles(config, []string{makefilePath, missing})
+	require.NoError(t, err)
+	require.Len(t, parsedFiles, 1)
+	assert.Equal(t, makefilePath, parsedFiles[0].Path)
+}
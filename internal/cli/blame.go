@@ -0,0 +1,295 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sdlcforge/make-help/internal/format"
+	"github.com/sdlcforge/make-help/internal/lint"
+	"github.com/sdlcforge/make-help/internal/model"
+	"github.com/sdlcforge/make-help/internal/parser"
+)
+
+// blameLine is the last change git blame attributes to a line.
+type blameLine struct {
+	Author string
+	Time   time.Time
+}
+
+// blameCache runs git blame at most once per file.
+type blameCache struct {
+	files map[string][]blameLine
+}
+
+// newBlameCache returns an empty blameCache.
+func newBlameCache() *blameCache {
+	return &blameCache{files: make(map[string][]blameLine)}
+}
+
+// lines returns the blame of each line of file, indexed from 0.
+func (c *blameCache) lines(file string) ([]blameLine, error) {
+	if lines, ok := c.files[file]; ok {
+		return lines, nil
+	}
+	lines, err := blameFile(file)
+	if err != nil {
+		return nil, err
+	}
+	c.files[file] = lines
+	return lines, nil
+}
+
+// targetHistory collects, for every documented target, when its
+// documentation block and its rule and recipe last changed according to
+// git blame.
+func targetHistory(helpModel *model.HelpModel, cache *blameCache) (map[string]lint.TargetHistory, error) {
+	history := make(map[string]lint.TargetHistory)
+	fileLines := make(map[string][]string)
+
+	for _, category := range helpModel.Categories {
+		for _, target := range category.Targets {
+			if target.SourceFile == "" || target.LineNumber == 0 {
+				continue
+			}
+			blame, err := cache.lines(target.SourceFile)
+			if err != nil {
+				return nil, err
+			}
+			lines, ok := fileLines[target.SourceFile]
+			if !ok {
+				content, err := os.ReadFile(target.SourceFile)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read %s: %w", target.SourceFile, err)
+				}
+				lines = strings.Split(string(content), "\n")
+				fileLines[target.SourceFile] = lines
+			}
+			history[target.Name] = blockHistory(lines, blame, target.LineNumber)
+		}
+	}
+
+	return history, nil
+}
+
+// lastChanges returns who last changed each documented target's rule line
+// and when, for --git-blame.
+func lastChanges(helpModel *model.HelpModel, cache *blameCache) (map[string]format.Change, error) {
+	changes := make(map[string]format.Change)
+	for _, category := range helpModel.Categories {
+		for _, target := range category.Targets {
+			if target.SourceFile == "" || target.LineNumber == 0 {
+				continue
+			}
+			blame, err := cache.lines(target.SourceFile)
+			if err != nil {
+				return nil, err
+			}
+			if target.LineNumber <= len(blame) {
+				line := blame[target.LineNumber-1]
+				changes[target.Name] = format.Change{Author: line.Author, Time: line.Time}
+			}
+		}
+	}
+	return changes, nil
+}
+
+// newTargets returns the targets of helpModel whose rules were added since
+// the given time, for --highlight-new. A target is new when none of the
+// Makefiles defining the model's targets defined it in the last commit made
+// before since; uncommitted targets are new too.
+func newTargets(helpModel *model.HelpModel, since time.Time) (map[string]bool, error) {
+	defined := make(map[string]bool)
+	seen := make(map[string]bool)
+	for _, category := range helpModel.Categories {
+		for _, target := range category.Targets {
+			if target.SourceFile == "" || seen[target.SourceFile] {
+				continue
+			}
+			seen[target.SourceFile] = true
+			content, err := fileBefore(target.SourceFile, since)
+			if err != nil {
+				return nil, err
+			}
+			for name := range definedTargets(content) {
+				defined[name] = true
+			}
+		}
+	}
+
+	added := make(map[string]bool)
+	for _, category := range helpModel.Categories {
+		for _, target := range category.Targets {
+			if target.SourceFile != "" && !defined[target.Name] {
+				added[target.Name] = true
+			}
+		}
+	}
+	return added, nil
+}
+
+// fileBefore returns the content of file in the last commit made before
+// since. It returns nil when the history starts later or the file did not
+// exist yet.
+func fileBefore(file string, since time.Time) ([]byte, error) {
+	dir := filepath.Dir(file)
+	command := exec.Command("git", "rev-list", "-1", fmt.Sprintf("--before=@%d", since.Unix()), "HEAD")
+	command.Dir = dir
+	var stderr bytes.Buffer
+	command.Stderr = &stderr
+	out, err := command.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the git history of %s: %s", file, strings.TrimSpace(stderr.String()))
+	}
+	commit := strings.TrimSpace(string(out))
+	if commit == "" {
+		return nil, nil
+	}
+
+	// "./" makes the path relative to dir rather than the repository root
+	command = exec.Command("git", "show", commit+":./"+filepath.Base(file))
+	command.Dir = dir
+	content, err := command.Output()
+	if err != nil {
+		return nil, nil
+	}
+	return content, nil
+}
+
+// definedTargets returns the names of the targets defined by the rules of
+// Makefile content.
+func definedTargets(content []byte) map[string]bool {
+	names := make(map[string]bool)
+	for _, line := range strings.Split(string(content), "\n") {
+		name := parser.ExtractDoubleColonTargetName(line)
+		if name == "" {
+			name = parser.ExtractTargetName(line)
+		}
+		if name != "" {
+			names[name] = true
+		}
+	}
+	return names
+}
+
+// parseNewWindow parses the --highlight-new window: a number of days
+// ("14d"), of weeks ("2w"), or a Go duration ("36h").
+func parseNewWindow(value string) (time.Duration, error) {
+	var window time.Duration
+	var err error
+	switch {
+	case strings.HasSuffix(value, "d"), strings.HasSuffix(value, "w"):
+		unit := 24 * time.Hour
+		if strings.HasSuffix(value, "w") {
+			unit *= 7
+		}
+		var n int
+		n, err = strconv.Atoi(value[:len(value)-1])
+		window = time.Duration(n) * unit
+	default:
+		window, err = time.ParseDuration(value)
+	}
+	if err != nil || window <= 0 {
+		return 0, fmt.Errorf("invalid --highlight-new window: %s (use e.g. 14d, 2w, or 36h)", value)
+	}
+	return window, nil
+}
+
+// blockHistory returns when the documentation above the rule at ruleLine
+// (1-based) and the rule with its recipe last changed. Lines git blame does
+// not report are ignored.
+func blockHistory(lines []string, blame []blameLine, ruleLine int) lint.TargetHistory {
+	var history lint.TargetHistory
+	latest := func(current *time.Time, index int) {
+		if index < len(blame) && blame[index].Time.After(*current) {
+			*current = blame[index].Time
+		}
+	}
+
+	ruleIndex := ruleLine - 1
+	for i := ruleIndex - 1; i >= 0 && i < len(lines) && strings.HasPrefix(lines[i], "##"); i-- {
+		latest(&history.DocsChanged, i)
+	}
+	latest(&history.RecipeChanged, ruleIndex)
+	for i := ruleIndex + 1; i < len(lines) && strings.HasPrefix(lines[i], "\t"); i++ {
+		latest(&history.RecipeChanged, i)
+	}
+
+	return history
+}
+
+// blameFile runs git blame on file and returns the author and committer
+// time of each line, indexed from 0. Uncommitted lines report the current
+// time and the author "Not Committed Yet".
+func blameFile(file string) ([]blameLine, error) {
+	command := exec.Command("git", "blame", "--porcelain", "--", filepath.Base(file))
+	command.Dir = filepath.Dir(file)
+	var stderr bytes.Buffer
+	command.Stderr = &stderr
+	out, err := command.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the git history of %s: %s", file, strings.TrimSpace(stderr.String()))
+	}
+	return parseBlame(out)
+}
+
+// parseBlame parses git blame --porcelain output into per-line authors and
+// committer times. Each line group starts with "<commit> <orig-line>
+// <final-line>", and commit details such as "author" and "committer-time"
+// are given only the first time a commit appears.
+func parseBlame(out []byte) ([]blameLine, error) {
+	var lines []blameLine
+	commits := make(map[string]*blameLine)
+	var commit string
+	var finalLine int
+
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "\t"):
+			// The line content closes the group
+			for len(lines) < finalLine {
+				lines = append(lines, blameLine{})
+			}
+			if details := commits[commit]; details != nil {
+				lines[finalLine-1] = *details
+			}
+
+		case commits[commit] != nil && strings.HasPrefix(line, "author "):
+			commits[commit].Author = strings.TrimPrefix(line, "author ")
+
+		case commits[commit] != nil && strings.HasPrefix(line, "committer-time "):
+			seconds, err := strconv.ParseInt(strings.TrimPrefix(line, "committer-time "), 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid git blame output: %s", line)
+			}
+			commits[commit].Time = time.Unix(seconds, 0).UTC()
+
+		default:
+			fields := strings.Fields(line)
+			if len(fields) >= 3 && (len(fields[0]) == 40 || len(fields[0]) == 64) { // SHA-1 or SHA-256
+				n, err := strconv.Atoi(fields[2])
+				if err != nil || n < 1 {
+					return nil, fmt.Errorf("invalid git blame output: %s", line)
+				}
+				commit, finalLine = fields[0], n
+				if commits[commit] == nil {
+					commits[commit] = &blameLine{}
+				}
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read git blame output: %w", err)
+	}
+
+	return lines, nil
+}
@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBlame(t *testing.T) {
+	t.Parallel()
+	commitA := strings.Repeat("a", 40)
+	commitB := strings.Repeat("b", 40)
+	out := commitA + " 1 1 2\n" +
+		"author Alice\n" +
+		"author-mail <alice@example.com>\n" +
+		"committer-time 1767225600\n" +
+		"filename Makefile\n" +
+		"\t## Build the app.\n" +
+		commitA + " 2 2\n" +
+		"\tbuild:\n" +
+		commitB + " 3 3 1\n" +
+		"author Bob\n" +
+		"committer-time 1780272000\n" +
+		"previous " + commitA + " Makefile\n" +
+		"filename Makefile\n" +
+		"\t\techo two\n"
+
+	lines, err := parseBlame([]byte(out))
+	require.NoError(t, err)
+	assert.Equal(t, []blameLine{
+		{Author: "Alice", Time: time.Unix(1767225600, 0).UTC()},
+		{Author: "Alice", Time: time.Unix(1767225600, 0).UTC()},
+		{Author: "Bob", Time: time.Unix(1780272000, 0).UTC()},
+	}, lines)
+}
+
+func TestBlockHistory(t *testing.T) {
+	t.Parallel()
+	day := func(d int) time.Time { return time.Date(2026, time.January, d, 0, 0, 0, 0, time.UTC) }
+	lines := []string{
+		"VERSION := 1.0", // 1
+		"## Build the app.",
+		"## !tag build",
+		"build:",
+		"\tgo build ./...",
+		"\tgo vet ./...",
+		"",
+		"clean:",
+	}
+	var blame []blameLine
+	for _, d := range []int{20, 1, 3, 2, 4, 9, 25, 30} {
+		blame = append(blame, blameLine{Author: "Alice", Time: day(d)})
+	}
+
+	history := blockHistory(lines, blame, 4)
+	assert.Equal(t, day(3), history.DocsChanged)
+	assert.Equal(t, day(9), history.RecipeChanged)
+
+	history = blockHistory(lines, blame, 8)
+	assert.True(t, history.DocsChanged.IsZero(), "no documentation above clean")
+	assert.Equal(t, day(30), history.RecipeChanged)
+}
+
+func TestDefinedTargets(t *testing.T) {
+	t.Parallel()
+	content := "VERSION := 1.0\n" +
+		"## Build the app.\n" +
+		"build: deps\n" +
+		"\tgo build ./...\n" +
+		"clean::\n" +
+		"\trm -rf bin\n"
+
+	names := definedTargets([]byte(content))
+	assert.True(t, names["build"])
+	assert.True(t, names["clean"])
+	assert.False(t, names["VERSION"])
+	assert.Empty(t, definedTargets(nil))
+}
+
+func TestParseNewWindow(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"14d", 14 * 24 * time.Hour},
+		{"2w", 14 * 24 * time.Hour},
+		{"36h", 36 * time.Hour},
+	}
+	for _, tt := range tests {
+		window, err := parseNewWindow(tt.value)
+		require.NoError(t, err, tt.value)
+		assert.Equal(t, tt.want, window, tt.value)
+	}
+
+	for _, value := range []string{"", "0d", "-3d", "d", "fortnight"} {
+		_, err := parseNewWindow(value)
+		require.Error(t, err, value)
+		assert.Contains(t, err.Error(), "invalid --highlight-new window")
+	}
+}
@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/sdlcforge/make-help/internal/model"
+)
+
+// categoryInfo describes one category in the --categories report.
+type categoryInfo struct {
+	// Name is the category name; empty for uncategorized targets.
+	Name string `json:"name"`
+
+	// Description is the category's introduction, when it has one.
+	Description string `json:"description,omitempty"`
+
+	// Targets is the number of visible targets in the category.
+	Targets int `json:"targets"`
+
+	// DiscoveryOrder is the 1-based position of the category in the order
+	// the Makefiles first mention the categories.
+	DiscoveryOrder int `json:"discoveryOrder"`
+}
+
+// runCategories lists the categories in help order with their target
+// counts and discovery order, as text or JSON.
+func runCategories(config *Config) error {
+	inputs, err := loadModelInputs(config)
+	if err != nil {
+		return err
+	}
+	helpModel, err := buildHelpModelFromInputs(config, inputs)
+	if err != nil {
+		return err
+	}
+	return writeCategories(os.Stdout, collectCategories(helpModel), config.Format)
+}
+
+// collectCategories summarizes the categories of helpModel in help order.
+// Hidden targets are not counted, and categories holding only hidden
+// targets are left out.
+func collectCategories(helpModel *model.HelpModel) []categoryInfo {
+	categories := []categoryInfo{}
+	var discoveryOrders []int
+	for _, category := range helpModel.Categories {
+		count := 0
+		for _, target := range category.Targets {
+			if !target.Hidden {
+				count++
+			}
+		}
+		if count == 0 {
+			continue
+		}
+		categories = append(categories, categoryInfo{
+			Name:           category.Name,
+			Description:    strings.TrimSpace(strings.Join(category.Documentation, " ")),
+			Targets:        count,
+			DiscoveryOrder: category.DiscoveryOrder,
+		})
+		discoveryOrders = append(discoveryOrders, category.DiscoveryOrder)
+	}
+
+	// Model discovery orders are not consecutive when grouping by file,
+	// so report each category's rank instead
+	slices.Sort(discoveryOrders)
+	for i := range categories {
+		categories[i].DiscoveryOrder = slices.Index(discoveryOrders, categories[i].DiscoveryOrder) + 1
+	}
+	return categories
+}
+
+// writeCategories writes categories as text or JSON.
+func writeCategories(w io.Writer, categories []categoryInfo, format string) error {
+	if format == "json" {
+		data, err := json.MarshalIndent(categories, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode categories: %w", err)
+		}
+		_, err = fmt.Fprintf(w, "%s\n", data)
+		return err
+	}
+
+	var sb strings.Builder
+	for _, category := range categories {
+		name := category.Name
+		if name == model.UncategorizedCategoryName {
+			name = "(uncategorized)"
+		}
+		noun := "targets"
+		if category.Targets == 1 {
+			noun = "target"
+		}
+		fmt.Fprintf(&sb, "%s: %d %s (discovered #%d)\n", name, category.Targets, noun, category.DiscoveryOrder)
+		if category.Description != "" {
+			fmt.Fprintf(&sb, "  %s\n", category.Description)
+		}
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/sdlcforge/make-help/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectCategories(t *testing.T) {
+	t.Parallel()
+	helpModel := &model.HelpModel{
+		Categories: []model.Category{
+			{Name: "Test", DiscoveryOrder: 7, Targets: []model.Target{{Name: "test"}}},
+			{Name: "Build", DiscoveryOrder: 2, Documentation: []string{"Build and", "package."}, Targets: []model.Target{
+				{Name: "build"}, {Name: "dist"}, {Name: "internal", Hidden: true},
+			}},
+			{Name: "Secret", DiscoveryOrder: 4, Targets: []model.Target{{Name: "debug", Hidden: true}}},
+			{Name: model.UncategorizedCategoryName, DiscoveryOrder: 5, Targets: []model.Target{{Name: "clean"}}},
+		},
+	}
+
+	categories := collectCategories(helpModel)
+	assert.Equal(t, []categoryInfo{
+		{Name: "Test", Targets: 1, DiscoveryOrder: 3},
+		{Name: "Build", Description: "Build and package.", Targets: 2, DiscoveryOrder: 1},
+		{Name: "", Targets: 1, DiscoveryOrder: 2},
+	}, categories)
+
+	var text bytes.Buffer
+	require.NoError(t, writeCategories(&text, categories, "text"))
+	assert.Equal(t, `Test: 1 target (discovered #3)
+Build: 2 targets (discovered #1)
+  Build and package.
+(uncategorized): 1 target (discovered #2)
+`, text.String())
+
+	var jsonOut bytes.Buffer
+	require.NoError(t, writeCategories(&jsonOut, categories[:1], "json"))
+	assert.JSONEq(t, `[{"name": "Test", "targets": 1, "discoveryOrder": 3}]`, jsonOut.String())
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/sdlcforge/make-help/internal/discovery"
+)
+
+// staleProbeAge is how old a discovery probe must be for --clean to remove
+// it. It exceeds the make timeout, so probes of running discoveries are kept.
+const staleProbeAge = time.Minute
+
+// newDiscoveryService creates a discovery service running make with
+// executor and writing its temporary files to --workdir, if set, or
+// without running make for --no-exec.
+func newDiscoveryService(config *Config, executor discovery.CommandExecutor) *discovery.Service {
+	service := discovery.NewService(executor, config.Verbose)
+	service.SetWorkDir(config.WorkDir)
+	service.SetNoExec(config.NoExec)
+	return service
+}
+
+// cacheDir returns the directory holding make-help's caches (completion
+// data and fetched remote fragments), or "" when there is no user cache
+// directory.
+func cacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "make-help")
+}
+
+// runClean removes the user cache directory and the discovery probes that
+// interrupted runs left next to the Makefile or in --workdir. The .make-help
+// journal is kept, since --undo needs it.
+func runClean(config *Config) error {
+	makefilePath, err := discovery.ResolveMakefilePath(config.MakefilePath)
+	if err != nil {
+		return fmt.Errorf("failed to resolve Makefile path: %w", err)
+	}
+
+	probeDirs := []string{filepath.Dir(makefilePath)}
+	if config.WorkDir != "" {
+		probeDirs = append(probeDirs, config.WorkDir)
+	}
+	var paths []string
+	for _, dir := range probeDirs {
+		probes, err := filepath.Glob(filepath.Join(dir, discovery.ProbePattern))
+		if err != nil {
+			return fmt.Errorf("failed to search %s for temporary files: %w", dir, err)
+		}
+		for _, probe := range probes {
+			if info, err := os.Stat(probe); err == nil && time.Since(info.ModTime()) >= staleProbeAge {
+				paths = append(paths, probe)
+			}
+		}
+	}
+	if dir := cacheDir(); dir != "" {
+		if _, err := os.Stat(dir); err == nil {
+			paths = append(paths, dir)
+		}
+	}
+
+	if len(paths) == 0 {
+		fmt.Println("Nothing to clean")
+		return nil
+	}
+	for _, path := range paths {
+		if err := os.RemoveAll(path); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", path, err)
+		}
+		fmt.Printf("Removed: %s\n", path)
+	}
+	return nil
+}
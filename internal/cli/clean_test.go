@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sdlcforge/make-help/internal/discovery"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunClean(t *testing.T) {
+	// Not parallel: sets the user cache directory
+	cacheHome := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", cacheHome)
+	t.Setenv("HOME", t.TempDir())
+
+	tmpDir := t.TempDir()
+	workDir := t.TempDir()
+	makefilePath := filepath.Join(tmpDir, "Makefile")
+	require.NoError(t, os.WriteFile(makefilePath, []byte("all:\n"), 0644))
+
+	stale := time.Now().Add(-2 * staleProbeAge)
+	probe := func(dir, name string, modTime time.Time) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte("all:\n"), 0644))
+		require.NoError(t, os.Chtimes(path, modTime, modTime))
+		return path
+	}
+	staleProbe := probe(tmpDir, ".makefile-discovery-1.mk", stale)
+	staleWorkDirProbe := probe(workDir, ".makefile-discovery-2.mk", stale)
+	recentProbe := probe(tmpDir, ".makefile-discovery-3.mk", time.Now())
+	require.NoError(t, os.MkdirAll(filepath.Join(cacheHome, "make-help", "completion"), 0755))
+	journalDir := filepath.Join(tmpDir, ".make-help")
+	require.NoError(t, os.MkdirAll(journalDir, 0755))
+
+	config := NewConfig()
+	config.MakefilePath = makefilePath
+	config.WorkDir = workDir
+	require.NoError(t, runClean(config))
+
+	assert.NoFileExists(t, staleProbe)
+	assert.NoFileExists(t, staleWorkDirProbe)
+	assert.FileExists(t, recentProbe, "a probe of a running discovery is kept")
+	assert.NoDirExists(t, filepath.Join(cacheHome, "make-help"))
+	assert.DirExists(t, journalDir, "the undo journal is kept")
+
+	// Nothing is left the second time
+	require.NoError(t, runClean(config))
+}
+
+func TestNewDiscoveryService_WorkDir(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	workDir := t.TempDir()
+	makefilePath := filepath.Join(tmpDir, "Makefile")
+	require.NoError(t, os.WriteFile(makefilePath, []byte("all:\n"), 0644))
+
+	config := NewConfig()
+	config.WorkDir = workDir
+	makefiles, err := newDiscoveryService(config, discovery.NewDefaultExecutor()).DiscoverMakefiles(config.runContext(), makefilePath)
+	require.NoError(t, err)
+	assert.Equal(t, []string{makefilePath}, makefiles)
+}
@@ -3,7 +3,10 @@ package cli
 import (
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/sdlcforge/make-help/internal/export"
+	"github.com/sdlcforge/make-help/internal/spell"
 	"github.com/spf13/cobra"
 )
 
@@ -27,20 +30,105 @@ func setupFlags(cmd *cobra.Command, config *Config) {
 		"lint", false, "Check documentation quality and report issues")
 	cmd.Flags().BoolVar(&config.Fix,
 		"fix", false, "Automatically fix auto-fixable lint issues (requires --lint)")
+	cmd.Flags().BoolVar(&config.Rename,
+		"rename", false, "Let --fix rename targets to kebab-case (requires --fix)")
+	cmd.Flags().StringVar(&config.Baseline,
+		"baseline", "", "Record current lint warnings in a file, or report only warnings not recorded there (requires --lint)")
+	cmd.Flags().StringVar(&config.Stats,
+		"stats", "", "Print a lint quality report (markdown, json) instead of the warnings (requires --lint)")
+	cmd.Flags().IntVar(&config.Top,
+		"top", 10, "Number of entries listed in the --stats and --analyze reports")
+	cmd.Flags().BoolVar(&config.Spell,
+		"spell", false, "Check documentation spelling (requires --lint)")
+	cmd.Flags().StringVar(&config.SpellLang,
+		"spell-lang", spell.DefaultLanguage, "Dictionary language for --spell ("+strings.Join(spell.Languages(), ", ")+")")
+	cmd.Flags().BoolVar(&config.Freshness,
+		"freshness", false, "Warn when a recipe changed in git long after its documentation (requires --lint)")
+	cmd.Flags().IntVar(&config.FreshnessDays,
+		"freshness-days", 30, "Days a recipe may change after its documentation before --freshness warns")
+	cmd.Flags().BoolVar(&config.NoPlugins,
+		"no-plugins", false, "Do not run lint plugins (make-help-check-* on PATH and lint.plugins from .make-help.json)")
 	cmd.Flags().StringVar(&config.Target,
-		"target", "", "Show detailed help for a specific target (requires --output -)")
+		"target", "", "Show detailed help for a specific target (requires --output -, or --export env)")
+	cmd.Flags().BoolVar(&config.Exact,
+		"exact", false, "Match --target exactly instead of resolving a unique prefix")
+	cmd.Flags().StringVar(&config.InjectFile,
+		"inject", "", "Insert or update rendered help between make-help markers in a file (e.g., README.md)")
+	cmd.Flags().StringVar(&config.Hook,
+		"hook", "", "Run a pre-commit hook (lint, inject-check) on the changed files given as arguments")
+	cmd.Flags().BoolVar(&config.Check,
+		"check", false, "Exit non-zero if the injected help section is stale (requires --inject)")
+	cmd.Flags().StringVar(&config.DumpModel,
+		"dump-model", "", "Write the help model and its builder inputs as JSON to a file (- for stdout)")
+	cmd.Flags().StringVar(&config.Snapshot,
+		"snapshot", "", "Write (update) or check (verify) help output snapshots for regression testing")
+	cmd.Flags().StringVar(&config.SnapshotDir,
+		"snapshot-dir", "testdata", "Directory holding help output snapshots (requires --snapshot)")
+	cmd.Flags().StringVar(&config.RunTarget,
+		"run", "", "Show a documented target's variables, then run it with make (VAR=value arguments are passed through)")
+	cmd.Flags().StringVar(&config.Preview,
+		"preview", "", "Show a documented target's documentation and the commands make -n would run (VAR=value arguments are passed through)")
+	cmd.Flags().BoolVar(&config.Yes,
+		"yes", false, "Run a target marked with !danger without asking for confirmation (requires --run)")
+	cmd.Flags().BoolVar(&config.RecordDuration,
+		"record-duration", false, "Record how long the target took in .make-help-state.json (requires --run)")
+	cmd.Flags().StringVar(&config.Graph,
+		"graph", "", "Write the target dependency graph to stdout (dot, mermaid)")
+	cmd.Flags().BoolVar(&config.DocumentedOnly,
+		"documented-only", false, "Limit the --graph output to documented targets")
+	cmd.Flags().BoolVar(&config.HighlightCycles,
+		"highlight-cycles", false, "Color circular dependencies red in the --graph output")
+	cmd.Flags().BoolVar(&config.Analyze,
+		"analyze", false, "Report the targets with the most dependents, deepest dependency chains, and orphans (text, json)")
+	cmd.Flags().StringVar(&config.Export,
+		"export", "", "Write the documented targets as a task manifest ("+strings.Join(export.Schemas(), ", ")+") to --output or stdout")
+	cmd.Flags().StringSliceVar(&config.Tags,
+		"tag", nil, "Export only targets with this !tag (repeatable; requires --export)")
+	cmd.Flags().StringVar(&config.List,
+		"list", "", "Print target names one per line: documented, all, or phony (for scripts)")
+	cmd.Flags().BoolVar(&config.ListColumns,
+		"list-columns", false, "Add tab-separated category and summary columns to --list (requires --list)")
+	cmd.Flags().BoolVar(&config.Categories,
+		"categories", false, "List categories with their target counts and discovery order (text, json)")
+	cmd.Flags().BoolVar(&config.Vars,
+		"vars", false, "List documented variables with their defaults and the targets using them (text, json, markdown)")
+	cmd.Flags().BoolVar(&config.ValidateOnly,
+		"validate-only", false, "Check that help can be built, without rendering it; exits 1 on errors (text, json)")
+	cmd.Flags().StringVar(&config.AddFragment,
+		"add-fragment", "", "Install a documented Makefile fragment (docker, go, node) into make/ and include it")
+	cmd.Flags().BoolVar(&config.Undo,
+		"undo", false, "Revert the most recent file modification make-help recorded, if the files have not changed since")
+	cmd.Flags().BoolVar(&config.UsageStats,
+		"usage-stats", false, "Show the commands, formats, and flags recorded when .make-help.json sets usage.record (text, json)")
+	cmd.Flags().BoolVar(&config.Clean,
+		"clean", false, "Remove make-help's caches and temporary files left by interrupted runs")
+	cmd.Flags().StringVar(&config.ShellInit,
+		"shell-init", "", "Print shell code defining an mh help function bound to Ctrl-T (zsh, bash)")
 
 	// Input flags
 	cmd.PersistentFlags().StringVar(&config.MakefilePath,
-		"makefile-path", "", "Path to Makefile (defaults to ./Makefile)")
+		"makefile-path", "", "Path to Makefile (defaults to ./GNUmakefile, ./makefile, or ./Makefile, as make)")
+	cmd.PersistentFlags().StringVarP(&config.Chdir,
+		"chdir", "C", "", "Change to this directory before doing anything else, like make -C")
+	cmd.Flags().BoolVar(&config.AllMakefiles,
+		"all-makefiles", false, "Generate a help file for the Makefile of every subdirectory project")
 	cmd.Flags().StringVar(&config.HelpFileRelPath,
 		"help-file-rel-path", "", "Relative path for generated help target file (e.g., help.mk or make/help.mk)")
+	cmd.Flags().StringVar(&config.FromModel,
+		"from-model", "", "Render help from a --dump-model file instead of running make")
+	cmd.Flags().BoolVar(&config.RenderFixture,
+		"render-fixture", false, "Render a built-in synthetic model to stdout, for formatter output tests")
+	_ = cmd.Flags().MarkHidden("render-fixture")
 
 	// Output/formatting flags
 	cmd.Flags().StringVar(&config.Format,
-		"format", "make", "Output format (make, text, html, markdown)")
+		"format", "make", "Output format (make, text, html, markdown, json, ndjson, slack)")
 	cmd.Flags().StringVar(&config.Output,
 		"output", "", "Output destination (file path or - for stdout). Default depends on format.")
+	cmd.Flags().StringVar(&config.OutputDir,
+		"output-dir", "", "Write each --format (comma-separated) to help.<ext> in this directory")
+	cmd.Flags().StringVar(&config.SplitBy,
+		"split-by", "", "Split --output-dir Markdown and HTML help into an index and one page per category (category)")
 	// Note: Color flags are bound to local variables, not config directly,
 	// because they need special processing (mutually exclusive)
 	cmd.PersistentFlags().BoolVar(&forceColor,
@@ -51,6 +139,21 @@ func setupFlags(cmd *cobra.Command, config *Config) {
 		"include-target", []string{}, "Include undocumented target in help (repeatable, comma-separated)")
 	cmd.Flags().BoolVar(&config.IncludeAllPhony,
 		"include-all-phony", false, "Include all .PHONY targets in help output")
+	cmd.Flags().StringSliceVar(&config.ExcludeTargets,
+		"exclude-target", []string{}, "Omit targets matching this glob from help (repeatable, comma-separated)")
+	cmd.Flags().StringSliceVar(&config.ExcludeFiles,
+		"exclude-file", []string{}, "Omit targets from files matching this glob (repeatable, comma-separated)")
+	cmd.Flags().BoolVar(&config.NoRedact,
+		"no-redact", false, "Do not mask secrets in rendered documentation")
+	// StringArray rather than StringSlice: regular expressions may contain commas
+	cmd.Flags().StringArrayVar(&config.RedactPatterns,
+		"redact-pattern", []string{}, "Also mask text matching this regular expression (repeatable)")
+	cmd.Flags().StringSliceVar(&config.OnlyFiles,
+		"only-file", []string{}, "Only document targets from files matching this glob (repeatable, comma-separated)")
+	cmd.Flags().StringVar(&config.EntryPoint,
+		"entry-point", "", "Introduce help with the !file documentation of this file instead of the main Makefile's")
+	cmd.Flags().StringVar(&config.Profile,
+		"profile", "", "Show only targets in this !profile (plus untagged targets)")
 	cmd.Flags().BoolVar(&config.KeepOrderCategories,
 		"keep-order-categories", false, "Preserve category discovery order")
 	cmd.Flags().BoolVar(&config.KeepOrderTargets,
@@ -64,8 +167,14 @@ func setupFlags(cmd *cobra.Command, config *Config) {
 		"category-order", []string{}, "Explicit category order (comma-separated)")
 	cmd.Flags().StringVar(&config.DefaultCategory,
 		"default-category", "", "Default category for uncategorized targets")
+	cmd.Flags().BoolVar(&config.BestEffort,
+		"best-effort", false, "Render help despite parse, categorization, and ordering errors, with warnings")
 	cmd.Flags().StringVar(&config.HelpCategory,
 		"help-category", "Help", "Category name for generated help targets (help, update-help)")
+	cmd.Flags().StringVar(&config.HelpTargetName,
+		"help-target-name", "help", "Name of the generated help target, to keep a hand-written help target")
+	cmd.Flags().BoolVar(&config.ReplaceExistingHelp,
+		"replace-existing-help", false, "Comment out a hand-written help target so the generated one replaces it")
 
 	// Dynamic/static mode flags (mutually exclusive, processed manually like color flags)
 	var dynamicFlag bool
@@ -78,10 +187,89 @@ func setupFlags(cmd *cobra.Command, config *Config) {
 		"no-dynamic-warning", false, "Suppress fallback warning in dynamic mode (requires --dynamic)")
 	cmd.Flags().StringVar(&config.UpdateOpts,
 		"update-opts", "", "Override options for the generated update-help target")
+	cmd.Flags().BoolVar(&config.Provenance,
+		"provenance", false, "Add a footer with the make-help version, source commit, and time to Markdown and HTML output")
+	cmd.Flags().BoolVar(&config.NoProvenance,
+		"no-provenance", false, "Omit the generation footer even when .make-help.json enables it")
+	cmd.Flags().BoolVar(&config.ResolveRemote,
+		"resolve-remote", false, "Fetch include files annotated with '## !source <url>' and include their documentation")
+	cmd.Flags().BoolVar(&config.ScrubEnv,
+		"scrub-env", false, "Run make with a minimal environment (PATH, HOME, locale, and --env-allow)")
+	cmd.Flags().StringSliceVar(&config.EnvAllow,
+		"env-allow", []string{}, "Environment variable to keep with --scrub-env or --sandbox (repeatable, comma-separated)")
+	cmd.Flags().BoolVar(&config.Sandbox,
+		"sandbox", false, "Run make without network access and with --scrub-env (Linux only)")
+	cmd.Flags().BoolVar(&config.NoExec,
+		"no-exec", false, "Read the Makefiles without running make; only literal includes, rules, and variables are found")
+	cmd.Flags().BoolVar(&config.NoShellWarning,
+		"no-shell-warning", false, "Do not list the $(shell ...) expressions make will run before running it")
+	cmd.Flags().BoolVar(&config.NoHooks,
+		"no-hooks", false, "Do not run the hooks.post commands from .make-help.json after writing files")
+	cmd.Flags().StringVar(&config.Daemon,
+		"daemon", "", "Serve Parse, Render, and Lint JSON-RPC requests on a unix socket, caching models until their Makefiles change")
+	cmd.Flags().BoolVar(&config.Container,
+		"container", false, "Show source paths as they are on the host when running in a container (see --path-map)")
+	cmd.Flags().StringArrayVar(&config.PathMaps,
+		"path-map", []string{}, "Map a container directory to a host directory, as host:container, for --container (repeatable; default: .:/workspace)")
+	cmd.Flags().StringVar(&config.PostProcess,
+		"post-process", "", "Pipe rendered help through a shell command before writing it (not for the generated help file)")
+	cmd.Flags().BoolVar(&config.RegenTarget,
+		"regen-target", false, "Add a help-regen target that regenerates the help file when a Makefile is newer")
+	cmd.Flags().BoolVar(&config.NoScript,
+		"no-script", false, "Omit inline JavaScript (copy buttons) from HTML output")
+	cmd.Flags().StringVar(&config.HTMLRaw,
+		"html-raw", "", "How HTML in documentation is rendered in HTML output: escape, strip, allow (default: escape)")
+	cmd.Flags().StringVar(&config.HTMLLinkRel,
+		"html-link-rel", "", "rel attribute for documentation links in HTML output (e.g. \"noopener noreferrer\")")
+	cmd.Flags().BoolVar(&config.HTMLLinkTargetBlank,
+		"html-link-target-blank", false, "Open documentation links in HTML output in a new tab")
+	cmd.Flags().StringVar(&config.HTMLNonce,
+		"html-nonce", "", "CSP nonce for the inline style and script elements of HTML output")
+	cmd.Flags().BoolVar(&config.TOC,
+		"toc", false, "Add a table of contents to Markdown output")
+	cmd.Flags().BoolVar(&config.SlackBlocks,
+		"slack-blocks", false, "Write Slack output as Block Kit JSON instead of mrkdwn text")
+	cmd.Flags().StringVar(&config.GroupBy,
+		"group-by", "category", "Group targets by category or by source file (category, file)")
+	cmd.Flags().BoolVar(&config.Compact,
+		"compact", false, "List only target names and aliases in text output")
+	cmd.Flags().BoolVar(&config.Long,
+		"long", false, "Show full target documentation instead of summaries in text output")
+	cmd.Flags().IntVar(&config.MaxTargetsPerCategory,
+		"max-targets-per-category", 0, "List at most N targets per category in text and make help (0 = no limit)")
+	cmd.Flags().StringVar(&config.FullHelpTarget,
+		"full-help-target", "", "Suggest running this target in the line for targets left out by --max-targets-per-category")
+	_ = cmd.Flags().MarkHidden("full-help-target")
+	cmd.Flags().IntVar(&config.SummaryWidth,
+		"summary-width", 0, "Truncate summaries in text and make help to N characters at a word boundary (0 = no limit)")
+	cmd.Flags().BoolVar(&config.ConsolidateVars,
+		"consolidate-vars", false, "List variables documented by several targets once, in a Variables section with the targets using them")
+	cmd.Flags().BoolVar(&config.ShowStats,
+		"show-stats", false, "End help with a count of targets, categories, and hidden undocumented targets")
+	cmd.Flags().BoolVar(&config.NoIncludedFiles,
+		"no-included-files", false, "Omit the documentation of included files")
+	cmd.Flags().StringVar(&config.IncludedFilesPosition,
+		"included-files-position", "top", "Place included file documentation before or after the targets (top, bottom)")
+	cmd.Flags().IntVar(&config.FileDocsDepth,
+		"file-docs-depth", 0, "Show at most N documentation lines per included file (0 = no limit)")
+	cmd.Flags().IntVar(&config.PageSize,
+		"page-size", 0, "Render at most N targets per page in JSON and HTML output (0 = no paging)")
+	cmd.Flags().IntVar(&config.Page,
+		"page", 1, "Page of targets to render (requires --page-size)")
+	cmd.Flags().BoolVar(&config.GitBlame,
+		"git-blame", false, "Show who last changed each target, and when, in detailed and HTML help")
+	cmd.Flags().StringVar(&config.HighlightNew,
+		"highlight-new", "", "Mark targets added to git within a window (e.g. 14d, 2w) as new")
+	cmd.Flags().StringVar(&config.MDLayout,
+		"md-layout", "list", "Markdown target layout (list, table)")
 
 	// Misc flags
 	cmd.PersistentFlags().BoolVarP(&config.Verbose,
 		"verbose", "v", false, "Enable verbose output for debugging")
+	cmd.Flags().DurationVar(&config.LockTimeout,
+		"lock-timeout", 30*time.Second, "How long to wait for another make-help modifying the same project")
+	cmd.PersistentFlags().StringVar(&config.WorkDir,
+		"workdir", "", "Directory for temporary files (defaults to the Makefile's directory)")
 
 }
 
@@ -166,7 +354,7 @@ func ParseCommandLineFromHelpFile(cmdLine string, config *Config) error {
 	cmd.SetArgs(args)
 
 	// Check for disallowed mode flags before parsing
-	disallowedFlags := []string{"--remove-help", "--dry-run", "--lint", "--fix", "--target"}
+	disallowedFlags := []string{"--remove-help", "--dry-run", "--lint", "--fix", "--rename", "--target"}
 	for _, arg := range args {
 		for _, disallowed := range disallowedFlags {
 			if arg == disallowed || strings.HasPrefix(arg, disallowed+"=") {
@@ -0,0 +1,220 @@
+package cli
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sdlcforge/make-help/internal/discovery"
+	"github.com/sdlcforge/make-help/internal/fragment"
+	"github.com/sdlcforge/make-help/internal/parser"
+	"github.com/spf13/cobra"
+)
+
+// formatCompletions lists the output formats with shell completion descriptions.
+var formatCompletions = []cobra.Completion{
+	cobra.CompletionWithDesc("make", "help.mk with a help target (default)"),
+	cobra.CompletionWithDesc("text", "terminal help"),
+	cobra.CompletionWithDesc("html", "standalone HTML page"),
+	cobra.CompletionWithDesc("markdown", "Markdown document"),
+	cobra.CompletionWithDesc("json", "JSON document"),
+	cobra.CompletionWithDesc("ndjson", "one JSON object per target"),
+	cobra.CompletionWithDesc("slack", "Slack mrkdwn digest"),
+}
+
+// registerFlagCompletions adds shell completion of flag values. Target and
+// category names come from the Makefiles, read through a cache so that
+// completion stays fast (see completionData).
+func registerFlagCompletions(cmd *cobra.Command, config *Config) {
+	fixed := func(values ...cobra.Completion) cobra.CompletionFunc {
+		return cobra.FixedCompletions(values, cobra.ShellCompDirectiveNoFileComp)
+	}
+
+	_ = cmd.RegisterFlagCompletionFunc("format",
+		func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+			// --output-dir takes a comma-separated list
+			return completeListItem(toComplete, formatCompletions), cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+		})
+	_ = cmd.RegisterFlagCompletionFunc("group-by", fixed("category", "file"))
+	_ = cmd.RegisterFlagCompletionFunc("md-layout", fixed("list", "table"))
+	_ = cmd.RegisterFlagCompletionFunc("html-raw", fixed("escape", "strip", "allow"))
+	_ = cmd.RegisterFlagCompletionFunc("snapshot", fixed("update", "verify"))
+	_ = cmd.RegisterFlagCompletionFunc("hook", fixed(hookLint, hookInjectCheck))
+	_ = cmd.RegisterFlagCompletionFunc("add-fragment", fixed(fragment.Names()...))
+	_ = cmd.RegisterFlagCompletionFunc("shell-init", fixed(shellInitShells...))
+	_ = cmd.RegisterFlagCompletionFunc("list", fixed(listScopes...))
+	_ = cmd.MarkPersistentFlagDirname("chdir")
+
+	completeTargets := func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		data := completionData(cmd.Context(), config)
+		if data == nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return data.Targets, cobra.ShellCompDirectiveNoFileComp
+	}
+	_ = cmd.RegisterFlagCompletionFunc("target", completeTargets)
+	_ = cmd.RegisterFlagCompletionFunc("run", completeTargets)
+
+	_ = cmd.RegisterFlagCompletionFunc("category-order",
+		func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+			data := completionData(cmd.Context(), config)
+			if data == nil {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			return completeListItem(toComplete, data.Categories), cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+		})
+}
+
+// completeListItem completes the last item of a comma-separated list,
+// offering the values not already listed, each prefixed with the items
+// before it.
+func completeListItem(toComplete string, values []cobra.Completion) []cobra.Completion {
+	prefix := ""
+	var listed []string
+	if i := strings.LastIndex(toComplete, ","); i >= 0 {
+		prefix = toComplete[:i+1]
+		listed = strings.Split(toComplete[:i], ",")
+	}
+
+	var completions []cobra.Completion
+	for _, value := range values {
+		name, _, _ := strings.Cut(value, "\t")
+		if !slices.Contains(listed, name) {
+			completions = append(completions, prefix+value)
+		}
+	}
+	return completions
+}
+
+// completionCache holds the names offered by completion for one Makefile,
+// along with the modification times it was computed from.
+type completionCache struct {
+	// ModTimes maps each discovered Makefile to its modification time.
+	ModTimes map[string]time.Time `json:"modTimes"`
+
+	// Targets lists the target names defined in the Makefiles.
+	Targets []string `json:"targets"`
+
+	// Categories lists the !category names, after categories.rename.
+	Categories []string `json:"categories"`
+}
+
+// completionCacheDir returns the directory holding completion caches, or ""
+// when there is no user cache directory.
+func completionCacheDir() string {
+	dir := cacheDir()
+	if dir == "" {
+		return ""
+	}
+	return filepath.Join(dir, "completion")
+}
+
+// completionData returns the target and category names for the Makefile at
+// config.MakefilePath (default: the Makefile make reads), or nil if they cannot be
+// determined. Discovery runs make, so results are cached per Makefile and
+// reused until one of the discovered Makefiles changes.
+func completionData(ctx context.Context, config *Config) *completionCache {
+	makefilePath, err := discovery.ResolveMakefilePath(config.MakefilePath)
+	if err != nil || discovery.ValidateMakefileExists(makefilePath) != nil {
+		return nil
+	}
+
+	var cachePath string
+	if dir := completionCacheDir(); dir != "" {
+		key := sha256.Sum256([]byte(makefilePath))
+		cachePath = filepath.Join(dir, hex.EncodeToString(key[:])+".json")
+		if cached := readCompletionCache(cachePath); cached != nil {
+			return cached
+		}
+	}
+
+	data, err := loadCompletionData(ctx, config, makefilePath)
+	if err != nil {
+		return nil
+	}
+	if cachePath != "" {
+		if encoded, err := json.Marshal(data); err == nil {
+			if os.MkdirAll(filepath.Dir(cachePath), 0755) == nil {
+				_ = os.WriteFile(cachePath, encoded, 0644)
+			}
+		}
+	}
+	return data
+}
+
+// readCompletionCache returns the cache at path, or nil if it is missing,
+// unreadable, or older than any of the Makefiles it was computed from.
+func readCompletionCache(path string) *completionCache {
+	encoded, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var cached completionCache
+	if err := json.Unmarshal(encoded, &cached); err != nil || len(cached.ModTimes) == 0 {
+		return nil
+	}
+	for file, modTime := range cached.ModTimes {
+		info, err := os.Stat(file)
+		if err != nil || !info.ModTime().Equal(modTime) {
+			return nil
+		}
+	}
+	return &cached
+}
+
+// loadCompletionData discovers and parses the Makefiles for completion,
+// running make as configured by config. Only the parser is used; targets
+// are not run through make -p.
+func loadCompletionData(ctx context.Context, config *Config, makefilePath string) (*completionCache, error) {
+	makefiles, err := newDiscoveryService(config, newMakeExecutor(config)).DiscoverMakefiles(ctx, makefilePath)
+	if err != nil {
+		return nil, err
+	}
+	projectConfig, err := loadProjectConfig(makefilePath)
+	if err != nil {
+		return nil, err
+	}
+	makefiles = projectConfig.Ignore.SkipMakefiles(makefiles, makefilePath, false)
+
+	data := &completionCache{ModTimes: make(map[string]time.Time)}
+	scanner := parser.NewScanner()
+	for _, mf := range makefiles {
+		info, err := os.Stat(mf)
+		if err != nil {
+			return nil, err
+		}
+		data.ModTimes[mf] = info.ModTime()
+
+		parsed, err := scanner.ScanFile(mf)
+		if err != nil {
+			return nil, err
+		}
+		for name := range parsed.TargetMap {
+			// Skip special targets (.PHONY) and pattern rules
+			if !strings.HasPrefix(name, ".") && !strings.Contains(name, "%") && !slices.Contains(data.Targets, name) {
+				data.Targets = append(data.Targets, name)
+			}
+		}
+		for _, d := range parsed.Directives {
+			if d.Type != parser.DirectiveCategory || d.Value == "_" {
+				continue
+			}
+			category := d.Value
+			if renamed, ok := projectConfig.Categories.Rename[category]; ok {
+				category = renamed
+			}
+			if !slices.Contains(data.Categories, category) {
+				data.Categories = append(data.Categories, category)
+			}
+		}
+	}
+	slices.Sort(data.Targets)
+	slices.Sort(data.Categories)
+	return data, nil
+}
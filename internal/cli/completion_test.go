@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompleteListItem(t *testing.T) {
+	t.Parallel()
+	values := []cobra.Completion{"Build", "Test\tRun tests", "Deploy"}
+
+	assert.Equal(t, []cobra.Completion{"Build", "Test\tRun tests", "Deploy"}, completeListItem("", values))
+	assert.Equal(t, []cobra.Completion{"Build,Test\tRun tests", "Build,Deploy"}, completeListItem("Build,", values))
+	assert.Equal(t, []cobra.Completion{"Build,Deploy,Test\tRun tests"}, completeListItem("Build,Deploy,T", values))
+}
+
+func TestLoadCompletionData(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	makefilePath := filepath.Join(tmpDir, "Makefile")
+	require.NoError(t, os.WriteFile(makefilePath, []byte(`.PHONY: build test
+## !category Build
+## Build the project.
+build:
+	@echo build
+
+## !category Bld
+## Build the docs.
+docs:
+	@echo docs
+
+## !category Test
+## Run tests.
+test:
+	@echo test
+
+%.o: %.c
+	cc -c $<
+`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, ".make-help.json"),
+		[]byte(`{"categories": {"rename": {"Bld": "Build"}}}`), 0644))
+
+	data, err := loadCompletionData(context.Background(), NewConfig(), makefilePath)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"build", "docs", "test"}, data.Targets)
+	assert.Equal(t, []string{"Build", "Test"}, data.Categories)
+	assert.Contains(t, data.ModTimes, makefilePath)
+}
+
+func TestReadCompletionCache(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	makefilePath := filepath.Join(tmpDir, "Makefile")
+	require.NoError(t, os.WriteFile(makefilePath, []byte("build:\n"), 0644))
+	info, err := os.Stat(makefilePath)
+	require.NoError(t, err)
+
+	cachePath := filepath.Join(tmpDir, "cache.json")
+	encoded, err := json.Marshal(completionCache{
+		ModTimes: map[string]time.Time{makefilePath: info.ModTime()},
+		Targets:  []string{"build"},
+	})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(cachePath, encoded, 0644))
+
+	cached := readCompletionCache(cachePath)
+	require.NotNil(t, cached)
+	assert.Equal(t, []string{"build"}, cached.Targets)
+
+	// A changed Makefile invalidates the cache
+	later := info.ModTime().Add(time.Minute)
+	require.NoError(t, os.Chtimes(makefilePath, later, later))
+	assert.Nil(t, readCompletionCache(cachePath))
+
+	assert.Nil(t, readCompletionCache(filepath.Join(tmpDir, "missing.json")))
+}
@@ -1,5 +1,13 @@
 package cli
 
+import (
+	"context"
+	"time"
+
+	"github.com/sdlcforge/make-help/internal/format"
+	"github.com/sdlcforge/make-help/internal/pathmap"
+)
+
 // ColorMode represents the color output mode for the CLI.
 type ColorMode int
 
@@ -61,15 +69,28 @@ type Config struct {
 	// Global options
 
 	// MakefilePath is the path to the main Makefile (resolved to absolute path).
-	// If empty, defaults to "Makefile" in the current working directory.
+	// If empty, defaults to the Makefile make reads in the current working
+	// directory: GNUmakefile, makefile, or Makefile.
 	MakefilePath string
 
+	// Chdir is the directory to change to before doing anything else, as
+	// with make -C. Relative paths in other flags are resolved from it.
+	Chdir string
+
 	// ColorMode determines when to use colored output.
 	ColorMode ColorMode
 
 	// Verbose enables verbose output for debugging file discovery and parsing.
 	Verbose bool
 
+	// LockTimeout is how long an operation that modifies files waits for
+	// another make-help process modifying the same project to finish.
+	LockTimeout time.Duration
+
+	// WorkDir is where discovery writes its temporary Makefile probe.
+	// Empty places it next to the Makefile.
+	WorkDir string
+
 	// Help generation options
 
 	// KeepOrderCategories preserves category discovery order instead of alphabetical.
@@ -85,20 +106,43 @@ type Config struct {
 	// Categories not in this list are appended alphabetically.
 	CategoryOrder []string
 
+	// GroupBy selects how help output groups targets.
+	// Valid values: "category" (by !category) and "file" (by source Makefile).
+	GroupBy string
+
 	// DefaultCategory is the category name for uncategorized targets.
 	// Required when mixing categorized and uncategorized targets.
 	DefaultCategory string
 
+	// BestEffort renders help despite errors that would otherwise stop the
+	// run: unparseable Makefiles are skipped, uncategorized targets mixed
+	// with categorized ones go to an Uncategorized category, and unknown
+	// --category-order entries are dropped, each with a warning.
+	BestEffort bool
+
 	// HelpCategory is the category name for generated help targets (help, update-help).
 	// Defaults to "Help" if not specified.
 	HelpCategory string
 
+	// HelpTargetName is the name of the generated help target. Defaults to
+	// "help"; another name keeps a hand-written help target working.
+	HelpTargetName string
+
+	// ReplaceExistingHelp comments out a hand-written help target, between
+	// make-help markers, so the generated one replaces it.
+	ReplaceExistingHelp bool
+
 	// Add-target options
 
 	// HelpFileRelPath specifies a relative path for the generated help target file.
 	// Must be a relative path (no leading '/'). If empty, location is determined automatically.
 	HelpFileRelPath string
 
+	// AllMakefiles generates a help file for the Makefile of the working
+	// directory and of every subdirectory below it, each including its own
+	// help file. Makefiles included by another Makefile are skipped.
+	AllMakefiles bool
+
 	// RemoveHelpTarget indicates whether to remove help target from Makefile.
 	RemoveHelpTarget bool
 
@@ -109,9 +153,43 @@ type Config struct {
 	// IncludeAllPhony includes all .PHONY targets in help output.
 	IncludeAllPhony bool
 
+	// OnlyFiles limits help to targets and docs from files matching these
+	// glob patterns, relative to the Makefile directory.
+	// Populated from --only-file flag (repeatable, comma-separated).
+	OnlyFiles []string
+
+	// ExcludeTargets hides targets whose names match these glob patterns.
+	// Merged with exclude.targets from .make-help.json.
+	ExcludeTargets []string
+
+	// ExcludeFiles hides targets and docs from files matching these glob
+	// patterns ("**" matches any number of directories).
+	// Merged with exclude.files from .make-help.json.
+	ExcludeFiles []string
+
+	// EntryPoint names the file whose !file documentation introduces the
+	// help, relative to the Makefile directory. Empty uses the main Makefile,
+	// or the first included file with a !file block when it has none.
+	EntryPoint string
+
+	// NoRedact disables masking of secrets (tokens, keys, password=
+	// assignments) in rendered documentation.
+	NoRedact bool
+
+	// RedactPatterns are extra regular expressions whose matches are masked.
+	// Merged with redact.patterns from .make-help.json.
+	RedactPatterns []string
+
+	// Profile limits help to targets tagged with this !profile, plus
+	// untagged targets. Empty shows every target.
+	Profile string
+
 	// Target specifies a target name for detailed help view.
 	Target string
 
+	// Exact disables resolving Target as a unique prefix of a target name.
+	Exact bool
+
 	// DryRun shows what would be created/modified without actually making changes.
 	// Valid with CreateHelpTarget or --lint --fix.
 	DryRun bool
@@ -123,8 +201,161 @@ type Config struct {
 	// Only valid with --lint.
 	Fix bool
 
+	// Baseline is a file of known lint warnings. When it does not exist,
+	// lint records the current warnings in it; otherwise the recorded
+	// warnings are not reported. Only valid with --lint.
+	Baseline string
+
+	// Stats prints a documentation quality report ("markdown" or "json")
+	// summarizing lint warnings instead of listing them. Only valid with --lint.
+	Stats string
+
+	// Top limits how many files the --stats report lists, and how many
+	// targets each --analyze ranking lists.
+	Top int
+
+	// Rename lets --fix rename targets that are not kebab-case, updating
+	// their rules, prerequisite lists, and .PHONY lines. Only valid with --fix.
+	Rename bool
+
+	// Spell adds the spelling check to lint, using the SpellLang dictionary
+	// and the project's .make-help-dict. Only valid with --lint.
+	Spell bool
+
+	// SpellLang selects the embedded dictionary used by Spell.
+	SpellLang string
+
+	// Freshness adds the stale documentation check to lint, comparing when
+	// each target's recipe and documentation last changed in git.
+	// Only valid with --lint.
+	Freshness bool
+
+	// FreshnessDays is how many days more recently a recipe may have changed
+	// than its documentation before Freshness reports it.
+	FreshnessDays int
+
+	// NoPlugins skips the lint plugins: make-help-check-* executables on
+	// PATH and the lint.plugins of .make-help.json.
+	NoPlugins bool
+
+	// InjectFile is the document (e.g., README.md) whose make-help marker
+	// section is updated with rendered help. Empty disables inject mode.
+	InjectFile string
+
+	// Hook runs a pre-commit hook ("lint" or "inject-check") against the
+	// changed files given as positional arguments. Empty disables hook mode.
+	Hook string
+
+	// Check reports a stale injected section instead of rewriting it.
+	// Only valid with --inject.
+	Check bool
+
+	// DumpModel is the file ("-" for stdout) that receives a JSON dump of the
+	// builder inputs and help model. Empty disables dump mode.
+	DumpModel string
+
+	// FromModel is a file written by --dump-model. When set, help is rendered
+	// from the dump instead of running make and parsing Makefiles.
+	FromModel string
+
+	// RenderFixture renders the built-in fixture model (format.FixtureModel)
+	// to stdout instead of reading a Makefile. Hidden; used to check formatter
+	// output stability across versions.
+	RenderFixture bool
+
+	// Snapshot writes ("update") or checks ("verify") canonical text,
+	// markdown, and json renders in SnapshotDir. Empty disables snapshot mode.
+	Snapshot string
+
+	// SnapshotDir is the directory holding help output snapshots.
+	SnapshotDir string
+
+	// RunTarget is a documented target (or alias) to run through make after
+	// showing its documentation. Empty disables run mode.
+	RunTarget string
+
+	// Preview is a documented target (or alias) whose make -n commands are
+	// shown with its documentation instead of running it. Empty disables
+	// preview mode.
+	Preview string
+
+	// RecordDuration saves how long a --run target took to the local run
+	// state file, so terminal help can show its last run time.
+	RecordDuration bool
+
+	// Yes runs a --run target marked with !danger without asking for
+	// confirmation.
+	Yes bool
+
+	// Graph writes the target dependency graph to stdout in this format
+	// ("dot" or "mermaid"). Empty disables graph mode.
+	Graph string
+
+	// DocumentedOnly limits the --graph output to documented targets.
+	DocumentedOnly bool
+
+	// HighlightCycles colors circular dependencies red in --graph output.
+	HighlightCycles bool
+
+	// Analyze reports the targets with the most dependents, the deepest
+	// dependency chains, and orphan targets instead of generating help.
+	Analyze bool
+
+	// Export writes the documented targets as a task manifest in this
+	// schema (see export.Schemas) to --output, or stdout. Empty disables
+	// export mode.
+	Export string
+
+	// Tags limits --export to targets with at least one of these !tag
+	// labels. Empty exports every target.
+	Tags []string
+
+	// List prints target names one per line instead of generating help:
+	// "documented" targets in help order, or "all" or "phony" targets make
+	// knows, sorted by name. Empty disables list mode.
+	List string
+
+	// ListColumns adds tab-separated category and summary columns to
+	// --list output.
+	ListColumns bool
+
+	// Categories lists the categories with their descriptions, target
+	// counts, and discovery order instead of generating help.
+	Categories bool
+
+	// Vars lists every documented variable with the targets using it, its
+	// Makefile default, and whether it is required, instead of generating
+	// help.
+	Vars bool
+
+	// ValidateOnly discovers, parses, and builds the help model, and runs
+	// the error-level lint checks, reporting the problems found instead of
+	// rendering help.
+	ValidateOnly bool
+
+	// AddFragment installs the named documented Makefile fragment
+	// (docker, go, node) into the make/ directory and includes it.
+	AddFragment string
+
+	// Undo reverts the most recent file modification recorded in the
+	// .make-help journal next to the Makefile.
+	Undo bool
+
+	// UsageStats prints the usage recorded in .make-help-usage.json instead
+	// of generating help.
+	UsageStats bool
+
+	// Clean removes the caches and leftover temporary files make-help owns
+	// instead of generating help.
+	Clean bool
+
+	// ShellInit prints the shell integration (the mh function and its
+	// Ctrl-T binding) for this shell ("zsh" or "bash") instead of
+	// generating help. Empty disables it.
+	ShellInit string
+
 	// Format specifies the output format type.
-	// Valid values: "make", "text", "html", "markdown" (and aliases mk, txt, md)
+	// Valid values: "make", "text", "html", "markdown", "json", "ndjson", "slack" (and aliases mk, txt, md)
 	Format string
 
 	// Output specifies the output destination.
@@ -132,6 +363,19 @@ type Config struct {
 	// Output is empty by default; resolved to format-specific default in PreRunE
 	Output string
 
+	// OutputDir renders every format listed in --format (comma-separated)
+	// into this directory, one help.<ext> file per format.
+	OutputDir string
+
+	// OutputFormats holds the normalized --format list when OutputDir is set.
+	// Populated during flag validation.
+	OutputFormats []string
+
+	// SplitBy splits the Markdown and HTML help written by OutputDir into
+	// pages. "category" writes an index page and one page per category;
+	// empty writes a single help.<ext> file.
+	SplitBy string
+
 	// DynamicMode controls whether generated help targets execute make-help dynamically
 	// or embed static text. Auto-detected from package.json when not explicitly set.
 	DynamicMode DynamicMode
@@ -144,6 +388,151 @@ type Config struct {
 	// If empty, the update-help target mirrors the original invocation options.
 	UpdateOpts string
 
+	// Provenance adds a generation footer (version, commit, time) to Markdown
+	// and HTML output; NoProvenance omits it even when .make-help.json
+	// enables it.
+	Provenance   bool
+	NoProvenance bool
+
+	// ResolveRemote fetches the files named by "## !source <url>" include
+	// annotations so their documentation is included.
+	ResolveRemote bool
+
+	// ScrubEnv runs make for discovery with only the variables in
+	// discovery.DefaultEnvAllowList and EnvAllow in its environment.
+	ScrubEnv bool
+
+	// EnvAllow lists further environment variables make keeps with
+	// ScrubEnv or Sandbox.
+	EnvAllow []string
+
+	// Sandbox runs make for discovery without network access, in Linux
+	// user and network namespaces, and implies ScrubEnv.
+	Sandbox bool
+
+	// NoExec reads the Makefiles without running make, finding only the
+	// includes, rules, and variables written literally.
+	NoExec bool
+
+	// NoShellWarning suppresses the warning listing the $(shell ...)
+	// expressions make will run while reading the Makefiles.
+	NoShellWarning bool
+
+	// NoHooks skips the hooks.post commands from .make-help.json.
+	NoHooks bool
+
+	// Container shows the source paths of help rendered inside a container
+	// as they are on the host, using PathMaps.
+	Container bool
+
+	// PathMaps lists host:container directory mappings for Container.
+	// Empty uses pathmap.DefaultSpec.
+	PathMaps []string
+
+	// Daemon is the unix socket to serve the Parse, Render, and Lint RPCs
+	// on. Empty disables daemon mode.
+	Daemon string
+
+	// PostProcess is a shell command rendered help is piped through before
+	// it is written. Empty disables post-processing.
+	PostProcess string
+
+	// RegenTarget adds a help-regen target and a file rule to the generated
+	// help file that re-run make-help when a discovered Makefile is newer.
+	RegenTarget bool
+
+	// NoScript omits inline JavaScript (copy-to-clipboard buttons) from HTML output.
+	// Only valid with --format html.
+	NoScript bool
+
+	// HTMLRaw selects how HTML written in documentation is rendered in HTML
+	// output: "escape", "strip", or "allow". Empty uses html.rawHTML from
+	// .make-help.json, then "escape". Only valid with --format html.
+	HTMLRaw string
+
+	// HTMLLinkRel is the rel attribute of documentation links in HTML output.
+	// Empty uses html.linkRel from .make-help.json. Only valid with --format html.
+	HTMLLinkRel string
+
+	// HTMLLinkTargetBlank opens documentation links in a new tab.
+	// Only valid with --format html.
+	HTMLLinkTargetBlank bool
+
+	// HTMLNonce is the CSP nonce of the inline <style> and <script> elements.
+	// Empty uses html.nonce from .make-help.json. Only valid with --format html.
+	HTMLNonce string
+
+	// TOC adds a linked table of contents to Markdown output.
+	// Only valid with --format markdown.
+	TOC bool
+
+	// SlackBlocks writes Slack output as Block Kit section blocks.
+	// Only valid with --format slack.
+	SlackBlocks bool
+
+	// Compact lists only target names and aliases, several per line.
+	// Only valid with --format text; mutually exclusive with Long.
+	Compact bool
+
+	// Long shows each target's full documentation instead of its summary.
+	// Only valid with --format text.
+	Long bool
+
+	// MaxTargetsPerCategory limits how many targets terminal help (text and
+	// make formats) lists per category. Zero lists every target.
+	MaxTargetsPerCategory int
+
+	// FullHelpTarget is the target suggested in the "(+N more)" line of
+	// MaxTargetsPerCategory, e.g. "help-full". Hidden; set by the help
+	// target of the generated file, which has that target.
+	FullHelpTarget string
+
+	// SummaryWidth truncates summaries in terminal help (text and make
+	// formats) to at most this many characters. Zero disables truncation.
+	SummaryWidth int
+
+	// ConsolidateVars lists variables documented by more than one target
+	// once, in a Variables section naming the targets that use them (text,
+	// make, markdown, and html formats).
+	ConsolidateVars bool
+
+	// ShowStats ends help with a target inventory line ("12 targets in 4
+	// categories, 3 undocumented hidden"), or adds a stats object to JSON.
+	ShowStats bool
+
+	// NoIncludedFiles omits the documentation of included files (text,
+	// make, markdown, html, and json formats).
+	NoIncludedFiles bool
+
+	// IncludedFilesPosition places the documentation of included files
+	// before the targets ("top") or after them ("bottom").
+	IncludedFilesPosition string
+
+	// FileDocsDepth limits how many documentation lines are shown per
+	// included file. Zero shows every line.
+	FileDocsDepth int
+
+	// PageSize splits JSON and HTML output into pages of at most this many
+	// targets. Zero renders every target.
+	PageSize int
+
+	// Page is the 1-based page rendered when PageSize is set.
+	Page int
+
+	// GitBlame shows who last changed each target's rule line, and when,
+	// in detailed and HTML help, using git blame.
+	GitBlame bool
+
+	// HighlightNew is the --highlight-new window, e.g. "14d": targets whose
+	// rules were added to git within it get a "new" badge. Empty disables
+	// the badge.
+	HighlightNew string
+
+	// MDLayout controls how Markdown output lists targets.
+	// Valid values: "list" (bullet list per category) and "table" (one table per category).
+	// Only "list" is valid with formats other than markdown.
+	MDLayout string
+
 	// Derived state (computed at runtime)
 
 	// UseColor is the resolved color setting based on ColorMode and terminal detection.
@@ -152,14 +541,80 @@ type Config struct {
 	// CommandLine stores the raw command line to be recorded in generated help files.
 	// Captured from os.Args in PreRunE.
 	CommandLine string
+
+	// undocumented counts the undocumented .PHONY targets left out of the
+	// help model, shown with --show-stats.
+	undocumented int
+
+	// lastRuns holds recorded run durations shown in terminal help.
+	// Loaded only for text output to stdout; see showLastRuns.
+	lastRuns map[string]time.Duration
+
+	// lastChanges holds the --git-blame changes of each target, resolved
+	// once per run; see resolveLastChanges.
+	lastChanges map[string]format.Change
+
+	// newTargets holds the --highlight-new targets, resolved once per run;
+	// see resolveNewTargets.
+	newTargets map[string]bool
+
+	// pathMap rewrites displayed source paths; set from PathMaps in PreRunE
+	// with Container, nil otherwise.
+	pathMap pathmap.Map
+
+	// lineWidth is the terminal width compact help fits its columns to.
+	// Zero (e.g., when writing to a file) uses the formatter default.
+	lineWidth int
+
+	// ctx is the context of the running command, canceled on SIGINT or
+	// SIGTERM; see runContext.
+	ctx context.Context
+
+	// heldLock is the project lock file this process holds, passed to post
+	// hooks so a make-help they run does not wait for it; see lockProject.
+	heldLock string
+
+	// shellWarned holds the Makefiles whose shell expressions were already
+	// listed; see warnShellExpressions.
+	shellWarned map[string]bool
+
+	// hookFiles holds the changed Makefiles given to --hook lint, as
+	// absolute paths; lint then parses only these and the files they
+	// include. Nil parses every Makefile.
+	hookFiles map[string]bool
+}
+
+// runContext returns the context make and other long-running work should
+// stop on. Configs not set up by NewRootCmd, as in tests, never cancel.
+func (c *Config) runContext() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
+}
+
+// formatNames maps the names --format accepts to the canonical format names.
+var formatNames = map[string]string{
+	"make": "make", "mk": "make",
+	"text": "text", "txt": "text",
+	"html":     "html",
+	"markdown": "markdown", "md": "markdown",
+	"json":   "json",
+	"ndjson": "ndjson",
+	"slack":  "slack",
 }
 
 // NewConfig creates a new Config with default values.
 func NewConfig() *Config {
 	return &Config{
-		ColorMode:     ColorAuto,
-		CategoryOrder: []string{},
-		HelpCategory:  "Help",
-		Format:        "make",
+		ColorMode:             ColorAuto,
+		CategoryOrder:         []string{},
+		HelpCategory:          "Help",
+		HelpTargetName:        "help",
+		Format:                "make",
+		MDLayout:              "list",
+		GroupBy:               "category",
+		IncludedFilesPosition: "top",
+		SnapshotDir:           "testdata",
 	}
 }
@@ -1,16 +1,19 @@
 package cli
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/sdlcforge/make-help/internal/discovery"
 	"github.com/sdlcforge/make-help/internal/model"
 	"github.com/sdlcforge/make-help/internal/ordering"
-	"github.com/sdlcforge/make-help/internal/parser"
-	"github.com/sdlcforge/make-help/internal/summary"
+	"github.com/sdlcforge/make-help/internal/remote"
 	"github.com/sdlcforge/make-help/internal/target"
 )
 
@@ -56,6 +59,15 @@ func runCreateHelpTarget(config *Config) error {
 		return err
 	}
 
+	// Keep other make-help processes from modifying the project meanwhile
+	if !config.DryRun {
+		release, err := lockProject(config, makefilePath)
+		if err != nil {
+			return err
+		}
+		defer release()
+	}
+
 	config.MakefilePath = makefilePath
 
 	if config.Verbose {
@@ -63,34 +75,44 @@ func runCreateHelpTarget(config *Config) error {
 	}
 
 	// 2. Validate Makefile syntax
-	executor := discovery.NewDefaultExecutor()
-	if err := target.ValidateMakefile(executor, makefilePath); err != nil {
-		return fmt.Errorf("makefile validation failed: %w", err)
+	warnShellExpressions(config, makefilePath)
+	executor := newMakeExecutor(config)
+	if !config.NoExec {
+		if err := target.ValidateMakefile(config.runContext(), executor, makefilePath); err != nil {
+			return fmt.Errorf("makefile validation failed: %w", err)
+		}
 	}
 
 	// 3. Discover files and targets
-	discoveryService := discovery.NewService(executor, config.Verbose)
+	discoveryService := newDiscoveryService(config, executor)
 
-	makefiles, err := discoveryService.DiscoverMakefiles(makefilePath)
+	makefiles, err := discoveryService.DiscoverMakefiles(config.runContext(), makefilePath)
 	if err != nil {
 		return fmt.Errorf("failed to discover Makefile includes: %w", err)
 	}
 
-	targetsResult, err := discoveryService.DiscoverTargets(makefilePath)
+	projectConfig, err := loadProjectConfig(makefilePath)
+	if err != nil {
+		return err
+	}
+	parseableMakefiles := projectConfig.Ignore.SkipMakefiles(makefiles, makefilePath, config.Verbose)
+
+	targetsResult, err := discoveryService.DiscoverTargets(config.runContext(), makefilePath)
 	if err != nil {
 		return fmt.Errorf("failed to discover targets: %w", err)
 	}
 
 	// 4. Parse and build model to get documented targets
-	scanner := parser.NewScanner()
-	var parsedFiles []*parser.ParsedFile
+	parsedFiles, err := parseMakefiles(config, parseableMakefiles)
+	if err != nil {
+		return err
+	}
 
-	for _, mf := range makefiles {
-		parsed, err := scanner.ScanFile(mf)
+	if config.ResolveRemote {
+		parsedFiles, err = resolveRemoteIncludes(config, makefilePath, makefiles, parsedFiles, projectConfig.Ignore, remote.NewFetcher())
 		if err != nil {
-			return fmt.Errorf("failed to parse %s: %w", mf, err)
+			return err
 		}
-		parsedFiles = append(parsedFiles, parsed)
 	}
 
 	if config.Verbose {
@@ -104,37 +126,42 @@ func runCreateHelpTarget(config *Config) error {
 		PhonyTargets:    targetsResult.IsPhony,
 		Dependencies:    targetsResult.Dependencies,
 		HasRecipe:       targetsResult.HasRecipe,
+		Profile:         config.Profile,
+		GroupByFile:     config.GroupBy == "file",
+		BaseDir:         filepath.Dir(makefilePath),
+		OnlyFiles:       config.OnlyFiles,
+		EntryPoint:      config.EntryPoint,
+		ExcludeTargets:  slices.Concat(config.ExcludeTargets, projectConfig.Exclude.Targets),
+		ExcludeFiles:    slices.Concat(config.ExcludeFiles, projectConfig.Exclude.Files),
+		Ignore:          projectConfig.Ignore,
+		CategoryRename:  projectConfig.Categories.Rename,
+		BestEffort:      config.BestEffort,
 	}
 	builder := model.NewBuilder(builderConfig)
 	helpModel, err := builder.Build(parsedFiles)
 	if err != nil {
 		return err
 	}
+	warnDegradations(builder)
+	config.undocumented = countUndocumented(config, builder.Undocumented())
 
 	// 5. Apply ordering rules to the model
 	orderingService := ordering.NewService(
 		config.KeepOrderCategories,
 		config.KeepOrderTargets,
 		config.KeepOrderFiles,
-		config.CategoryOrder,
+		bestEffortCategoryOrder(config, helpModel),
 	)
 	if err := orderingService.ApplyOrdering(helpModel); err != nil {
 		return fmt.Errorf("failed to apply ordering: %w", err)
 	}
 
-	// 6. Extract summaries for all targets
-	extractor := summary.NewExtractor()
-	for i := range helpModel.Categories {
-		for j := range helpModel.Categories[i].Targets {
-			target := &helpModel.Categories[i].Targets[j]
-			summaryText := extractor.ExtractPlainText(target.Documentation)
-			if summaryText != "" {
-				target.Summary = []string{summaryText}
-			} else {
-				target.Summary = []string{}
-			}
-		}
+	// Mask secrets before the help text is embedded in the generated file
+	redactor, err := newRedactor(config, projectConfig)
+	if err != nil {
+		return err
 	}
+	redactor.RedactModel(helpModel)
 
 	// 7. Collect documented target names
 	var documentedTargets []string
@@ -209,6 +236,12 @@ func runCreateHelpTarget(config *Config) error {
 		fmt.Fprintf(os.Stderr, "Total makefiles discovered: %d, after filtering help files: %d\n", len(makefiles), len(filteredMakefiles))
 	}
 
+	// Keep or replace a help target make-help did not generate
+	existingHelp, err := resolveExistingHelpTarget(config, filteredMakefiles)
+	if err != nil {
+		return err
+	}
+
 	// 10. Resolve dynamic mode
 	dynamicMode := false
 	switch config.DynamicMode {
@@ -231,22 +264,43 @@ func runCreateHelpTarget(config *Config) error {
 	// 11. Generate help file content
 	// Use the raw command line (always captured from os.Args in PreRunE)
 	genConfig := &target.GeneratorConfig{
-		UseColor:            config.UseColor,
-		Makefiles:           filteredMakefiles,
-		HelpModel:           helpModel,
-		MakefileDir:         filepath.Dir(makefilePath),
-		HelpFilename:        filepath.Base(targetFile),
-		KeepOrderCategories: config.KeepOrderCategories,
-		KeepOrderTargets:    config.KeepOrderTargets,
-		CategoryOrder:       config.CategoryOrder,
-		DefaultCategory:     config.DefaultCategory,
-		HelpCategory:        config.HelpCategory,
-		IncludeTargets:      parseIncludeTargets(config.IncludeTargets),
-		IncludeAllPhony:     config.IncludeAllPhony,
-		CommandLine:         config.CommandLine,
-		DynamicMode:         dynamicMode,
-		NoDynamicWarning:    config.NoDynamicWarning,
-		UpdateOpts:          config.UpdateOpts,
+		UseColor:              config.UseColor,
+		Makefiles:             filteredMakefiles,
+		HelpModel:             helpModel,
+		MakefileDir:           filepath.Dir(makefilePath),
+		HelpFileDir:           filepath.Dir(targetFile),
+		HelpFilename:          filepath.Base(targetFile),
+		KeepOrderCategories:   config.KeepOrderCategories,
+		KeepOrderTargets:      config.KeepOrderTargets,
+		CategoryOrder:         config.CategoryOrder,
+		DefaultCategory:       config.DefaultCategory,
+		HelpCategory:          config.HelpCategory,
+		HelpTargetName:        config.HelpTargetName,
+		IncludeTargets:        parseIncludeTargets(config.IncludeTargets),
+		IncludeAllPhony:       config.IncludeAllPhony,
+		Profile:               config.Profile,
+		GroupBy:               config.GroupBy,
+		OnlyFiles:             config.OnlyFiles,
+		EntryPoint:            config.EntryPoint,
+		ExcludeTargets:        config.ExcludeTargets,
+		ExcludeFiles:          config.ExcludeFiles,
+		NoRedact:              config.NoRedact,
+		RedactPatterns:        config.RedactPatterns,
+		CommandLine:           config.CommandLine,
+		MaxTargetsPerCategory: config.MaxTargetsPerCategory,
+		SummaryWidth:          config.SummaryWidth,
+		ConsolidateVariables:  config.ConsolidateVars,
+		ShowStats:             config.ShowStats,
+		Undocumented:          config.undocumented,
+		NoIncludedFiles:       config.NoIncludedFiles,
+		IncludedFilesPosition: config.IncludedFilesPosition,
+		FileDocsDepth:         config.FileDocsDepth,
+		DynamicMode:           dynamicMode,
+		NoDynamicWarning:      config.NoDynamicWarning,
+		UpdateOpts:            config.UpdateOpts,
+		RegenTarget:           config.RegenTarget,
+		ResolveRemote:         config.ResolveRemote,
+		BestEffort:            config.BestEffort,
 	}
 	content, err := target.GenerateHelpFile(genConfig)
 	if err != nil {
@@ -255,19 +309,45 @@ func runCreateHelpTarget(config *Config) error {
 
 	// 11. Handle dry-run mode
 	if config.DryRun {
-		return printDryRunOutput(makefilePath, targetFile, needsInclude, content)
+		return printDryRunOutput(makefilePath, targetFile, needsInclude, existingHelp, content, config.UseColor)
+	}
+
+	// Record the files about to change for --undo
+	tx := beginJournal(config, makefilePath)
+	if err := tx.Track(targetFile, makefilePath); err != nil {
+		return err
+	}
+	if existingHelp != nil {
+		if err := tx.Track(existingHelp.File); err != nil {
+			return err
+		}
 	}
+	defer commitJournal(tx)
 
-	// 12. Write file atomically
+	// 12. Comment out the hand-written help target being replaced, before
+	// writing the help file so the Makefile is not newer than it
+	var written []string
+	if existingHelp != nil {
+		if err := target.ReplaceHelpTarget(existingHelp.File, existingHelp.Line); err != nil {
+			return err
+		}
+		if config.Verbose {
+			fmt.Fprintf(os.Stderr, "Commented out help target at: %s:%d\n", existingHelp.File, existingHelp.Line)
+		}
+		written = append(written, existingHelp.File)
+	}
+
+	// 13. Write file atomically
 	if err := target.AtomicWriteFile(targetFile, []byte(content), 0644); err != nil {
 		return fmt.Errorf("failed to write help target file %s: %w", targetFile, err)
 	}
+	written = append(written, targetFile)
 
 	if config.Verbose {
 		fmt.Fprintf(os.Stderr, "Created help target file: %s\n", targetFile)
 	}
 
-	// 13. Add include directive if needed
+	// 14. Add include directive if needed
 	if needsInclude {
 		if err := target.AddIncludeDirective(makefilePath, targetFile); err != nil {
 			return err
@@ -275,53 +355,88 @@ func runCreateHelpTarget(config *Config) error {
 		if config.Verbose {
 			fmt.Fprintf(os.Stderr, "Added include directive to: %s\n", makefilePath)
 		}
+		if !slices.Contains(written, makefilePath) {
+			written = append(written, makefilePath)
+		}
 	}
 
 	fmt.Printf("Successfully created help target: %s\n", targetFile)
-	return nil
+
+	// 15. Run post hooks on the written files
+	return runPostHooks(config, written...)
 }
 
-// printDryRunOutput displays what would be created/modified in dry-run mode.
-func printDryRunOutput(makefilePath, targetFile string, needsInclude bool, content string) error {
+// printDryRunOutput lists the files dry-run mode would create or modify,
+// followed by a unified diff of each change, colored when useColor is set.
+// existingHelp, when set, is the hand-written help target that would be
+// commented out.
+func printDryRunOutput(makefilePath, targetFile string, needsInclude bool, existingHelp *handWrittenHelpTarget, content string, useColor bool) error {
 	fmt.Println("Dry run mode - no files will be modified")
 	fmt.Println()
-	fmt.Printf("Would create: %s\n", targetFile)
-	if needsInclude {
-		fmt.Printf("Would append to: %s\n", makefilePath)
+
+	oldName := "/dev/null"
+	existing, err := os.ReadFile(targetFile)
+	switch {
+	case err == nil && string(existing) == content:
+		fmt.Printf("Unchanged: %s\n", targetFile)
+	case err == nil:
+		oldName = targetFile
+		fmt.Printf("Would update: %s\n", targetFile)
+	case errors.Is(err, fs.ErrNotExist):
+		fmt.Printf("Would create: %s\n", targetFile)
+	default:
+		return fmt.Errorf("failed to read %s: %w", targetFile, err)
+	}
+	diffs := unifiedDiff(oldName, targetFile, string(existing), content, useColor)
+
+	// Both edits can apply to the Makefile, so each file is diffed once
+	// with all of its changes
+	var edited []string
+	current := make(map[string][]byte)
+	updated := make(map[string][]byte)
+	read := func(path string) error {
+		if _, ok := current[path]; ok {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		edited = append(edited, path)
+		current[path], updated[path] = data, data
+		return nil
 	}
-	fmt.Println()
-	fmt.Printf("--- %s ---\n", targetFile)
-	fmt.Print(content)
-	fmt.Println("--- end ---")
 
-	if needsInclude {
-		// Compute relative path for include directive
-		makefileDir := filepath.Dir(makefilePath)
-		relPath, err := filepath.Rel(makefileDir, targetFile)
+	if existingHelp != nil {
+		if err := read(existingHelp.File); err != nil {
+			return err
+		}
+		replaced, err := target.CommentOutHelpTarget(updated[existingHelp.File], existingHelp.Line)
 		if err != nil {
-			// Fallback to just the filename if we can't compute relative path
-			relPath = filepath.Base(targetFile)
+			return fmt.Errorf("failed to replace help target in %s: %w", existingHelp.File, err)
 		}
+		updated[existingHelp.File] = replaced
+		fmt.Printf("Would comment out help target: %s:%d\n", existingHelp.File, existingHelp.Line)
+	}
 
-		// Determine include directive based on file location
-		var includeDirective string
-		if filepath.Dir(relPath) == "make" {
-			// For files in make/, use pattern include
-			suffix := filepath.Ext(targetFile)
-			if suffix == "" {
-				suffix = ".mk"
-			}
-			includeDirective = fmt.Sprintf("\n-include make/*%s\n", suffix)
-		} else {
-			// For files outside make/, use self-referential include
-			includeDirective = fmt.Sprintf("\n-include $(dir $(lastword $(MAKEFILE_LIST)))%s\n", relPath)
+	if needsInclude {
+		if err := read(makefilePath); err != nil {
+			return err
 		}
+		included := target.WithIncludeDirective(updated[makefilePath], makefilePath, targetFile)
+		if !bytes.Equal(included, updated[makefilePath]) {
+			updated[makefilePath] = included
+			fmt.Printf("Would append to: %s\n", makefilePath)
+		}
+	}
 
-		fmt.Println()
-		fmt.Printf("--- Append to %s ---\n", makefilePath)
-		fmt.Print(includeDirective)
-		fmt.Println("--- end ---")
+	for _, path := range edited {
+		diffs += unifiedDiff(path, path, string(current[path]), string(updated[path]), useColor)
 	}
 
+	if diffs != "" {
+		fmt.Println()
+		fmt.Print(diffs)
+	}
 	return nil
 }
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/sdlcforge/make-help/internal/target"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -56,10 +57,11 @@ test:
 	// Verify it shows what would be created
 	assert.Contains(t, output, "Would create:")
 
-	// Verify content preview is shown (new static format)
-	assert.Contains(t, output, ".PHONY: help")
+	// Verify the new file is shown as a unified diff (new static format)
+	assert.Contains(t, output, "--- /dev/null\n")
+	assert.Contains(t, output, "@@ -0,0 +1,")
+	assert.Contains(t, output, "+.PHONY: help\n")
 	assert.Contains(t, output, "@printf") // New static format uses printf statements
-	assert.Contains(t, output, "--- end ---")
 
 	// Verify no files were actually created
 	// Check that make/help.mk was NOT created (should create make/help.mk in dry-run)
@@ -115,8 +117,8 @@ build:
 
 	// Verify it would append include directive
 	assert.Contains(t, output, "Would append to:")
-	assert.Contains(t, output, "Append to")
-	assert.Contains(t, output, "-include $(dir $(lastword $(MAKEFILE_LIST)))custom-help.mk")
+	assert.Contains(t, output, "+++ "+makefilePath+"\n")
+	assert.Contains(t, output, " build:\n \t@echo building\n+\n+"+target.IncludeMarker+"\n+-include $(dir $(lastword $(MAKEFILE_LIST)))custom-help.mk\n")
 
 	// Verify no files were actually created
 	customHelpPath := filepath.Join(tmpDir, "custom-help.mk")
@@ -272,23 +274,72 @@ build:
 func TestPrintDryRunOutput(t *testing.T) {
 	tmpDir := t.TempDir()
 	makefilePath := filepath.Join(tmpDir, "Makefile")
+	require.NoError(t, os.WriteFile(makefilePath, []byte("build:\n\t@true\n"), 0644))
 	targetFile := filepath.Join(tmpDir, "help.mk")
-	content := "test content"
+	existingFile := filepath.Join(tmpDir, "existing.mk")
+	require.NoError(t, os.WriteFile(existingFile, []byte("one\ntwo\nthree\n"), 0644))
+	handWritten := filepath.Join(tmpDir, "handwritten", "Makefile")
+	require.NoError(t, os.MkdirAll(filepath.Dir(handWritten), 0755))
+	require.NoError(t, os.WriteFile(handWritten, []byte("## Show help\nhelp:\n\t@echo help\n"), 0644))
+	handWrittenTarget := filepath.Join(tmpDir, "handwritten", "help.mk")
 
 	tests := []struct {
 		name         string
+		makefilePath string
+		targetFile   string
+		content      string
 		needsInclude bool
-		wantAppend   bool
+		existingHelp *handWrittenHelpTarget
+		useColor     bool
+		want         string
 	}{
 		{
-			name:         "with include directive",
+			name:         "new file with include directive",
+			targetFile:   targetFile,
+			content:      "test content\n",
 			needsInclude: true,
-			wantAppend:   true,
+			want: "Would create: " + targetFile + "\n" +
+				"Would append to: " + makefilePath + "\n\n" +
+				"--- /dev/null\n+++ " + targetFile + "\n@@ -0,0 +1 @@\n+test content\n" +
+				"--- " + makefilePath + "\n+++ " + makefilePath + "\n@@ -1,2 +1,5 @@\n build:\n \t@true\n+\n" +
+				"+" + target.IncludeMarker + "\n+-include $(dir $(lastword $(MAKEFILE_LIST)))help.mk\n",
 		},
 		{
-			name:         "without include directive",
-			needsInclude: false,
-			wantAppend:   false,
+			name:       "new file without include directive",
+			targetFile: targetFile,
+			content:    "test content\n",
+			want:       "Would create: " + targetFile + "\n\n--- /dev/null\n+++ " + targetFile + "\n@@ -0,0 +1 @@\n+test content\n",
+		},
+		{
+			name:       "existing file",
+			targetFile: existingFile,
+			content:    "one\n2\nthree\n",
+			useColor:   true,
+			want: "Would update: " + existingFile + "\n\n" +
+				"\033[1m--- " + existingFile + "\033[0m\n\033[1m+++ " + existingFile + "\033[0m\n" +
+				"\033[36m@@ -1,3 +1,3 @@\033[0m\n one\n\033[31m-two\033[0m\n\033[32m+2\033[0m\n three\n",
+		},
+		{
+			name:         "replaced help target and include directive",
+			makefilePath: handWritten,
+			targetFile:   handWrittenTarget,
+			content:      "test content\n",
+			needsInclude: true,
+			existingHelp: &handWrittenHelpTarget{File: handWritten, Line: 2},
+			want: "Would create: " + handWrittenTarget + "\n" +
+				"Would comment out help target: " + handWritten + ":2\n" +
+				"Would append to: " + handWritten + "\n\n" +
+				"--- /dev/null\n+++ " + handWrittenTarget + "\n@@ -0,0 +1 @@\n+test content\n" +
+				"--- " + handWritten + "\n+++ " + handWritten + "\n@@ -1,3 +1,8 @@\n" +
+				"-## Show help\n-help:\n-\t@echo help\n" +
+				"+" + target.ReplacedHelpBeginMarker + "\n+# ## Show help\n+# help:\n+# \t@echo help\n+" + target.ReplacedHelpEndMarker + "\n" +
+				"+\n+" + target.IncludeMarker + "\n+-include $(dir $(lastword $(MAKEFILE_LIST)))help.mk\n",
+		},
+		{
+			name:       "unchanged file",
+			targetFile: existingFile,
+			content:    "one\ntwo\nthree\n",
+			want:       "Unchanged: " + existingFile + "\n",
 		},
 	}
 
@@ -299,28 +350,20 @@ func TestPrintDryRunOutput(t *testing.T) {
 			r, w, _ := os.Pipe()
 			os.Stdout = w
 
-			err := printDryRunOutput(makefilePath, targetFile, tt.needsInclude, content)
+			mf := tt.makefilePath
+			if mf == "" {
+				mf = makefilePath
+			}
+			err := printDryRunOutput(mf, tt.targetFile, tt.needsInclude, tt.existingHelp, tt.content, tt.useColor)
 
 			_ = w.Close()
 			os.Stdout = oldStdout
 
 			var buf bytes.Buffer
 			_, _ = buf.ReadFrom(r)
-			output := buf.String()
 
 			assert.NoError(t, err)
-			assert.Contains(t, output, "Dry run mode - no files will be modified")
-			assert.Contains(t, output, "Would create:")
-			assert.Contains(t, output, targetFile)
-			assert.Contains(t, output, "test content")
-
-			if tt.wantAppend {
-				assert.Contains(t, output, "Would append to:")
-				assert.Contains(t, output, "Append to")
-				assert.Contains(t, output, "-include $(dir $(lastword $(MAKEFILE_LIST)))")
-			} else {
-				assert.NotContains(t, output, "Would append to:")
-			}
+			assert.Equal(t, "Dry run mode - no files will be modified\n\n"+tt.want, buf.String())
 		})
 	}
 }
@@ -366,18 +409,18 @@ build:
 	assert.Contains(t, output, "Dry run mode - no files will be modified")
 
 	// Should contain file markers
-	containsStartMarker := false
-	containsEndMarker := false
+	containsOldHeader := false
+	containsNewHeader := false
 	for _, line := range lines {
-		if strings.HasPrefix(line, "---") && strings.Contains(line, "make/01-help.mk") {
-			containsStartMarker = true
+		if line == "--- /dev/null" {
+			containsOldHeader = true
 		}
-		if line == "--- end ---" {
-			containsEndMarker = true
+		if strings.HasPrefix(line, "+++ ") && strings.HasSuffix(line, "make/01-help.mk") {
+			containsNewHeader = true
 		}
 	}
-	assert.True(t, containsStartMarker, "Should contain start marker for file content")
-	assert.True(t, containsEndMarker, "Should contain end marker")
+	assert.True(t, containsOldHeader, "Should contain the old file header of the diff")
+	assert.True(t, containsNewHeader, "Should contain the new file header of the diff")
 }
 
 func TestFilterOutHelpFiles(t *testing.T) {
@@ -0,0 +1,329 @@
+package cli
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/sdlcforge/make-help/internal/discovery"
+	"github.com/sdlcforge/make-help/internal/projectconfig"
+)
+
+// daemonServiceName is the name the --daemon RPCs are registered under:
+// clients call "MakeHelp.Parse", "MakeHelp.Render", and "MakeHelp.Lint".
+const daemonServiceName = "MakeHelp"
+
+// runDaemon serves the Parse, Render, and Lint RPCs as JSON-RPC 1.0 over the
+// unix socket config.Daemon until the command is interrupted. Each
+// connection carries a stream of requests; the model inputs of every
+// Makefile are cached until one of the files they were read from changes.
+func runDaemon(config *Config) error {
+	if err := removeStaleSocket(config.Daemon); err != nil {
+		return err
+	}
+	listener, err := net.Listen("unix", config.Daemon)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", config.Daemon, err)
+	}
+	// Closing the listener also removes the socket
+	defer listener.Close()
+
+	server := rpc.NewServer()
+	if err := server.RegisterName(daemonServiceName, newDaemonService(config)); err != nil {
+		return fmt.Errorf("failed to register daemon service: %w", err)
+	}
+
+	ctx := config.runContext()
+	go func() {
+		<-ctx.Done()
+		listener.Close()
+	}()
+
+	fmt.Fprintf(os.Stderr, "make-help daemon listening on %s\n", config.Daemon)
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("failed to accept connection: %w", err)
+		}
+		go server.ServeCodec(jsonrpc.NewServerCodec(conn))
+	}
+}
+
+// removeStaleSocket removes a socket left at path by a daemon that did not
+// shut down. It fails if a daemon is still listening there, or if path is
+// some other kind of file.
+func removeStaleSocket(path string) error {
+	info, err := os.Lstat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to check %s: %w", path, err)
+	}
+	if info.Mode()&fs.ModeSocket == 0 {
+		return fmt.Errorf("%s exists and is not a socket", path)
+	}
+	if conn, err := net.Dial("unix", path); err == nil {
+		conn.Close()
+		return fmt.Errorf("a daemon is already listening on %s", path)
+	}
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("failed to remove stale socket %s: %w", path, err)
+	}
+	return nil
+}
+
+// DaemonArgs are the parameters of the daemon RPCs.
+type DaemonArgs struct {
+	// Makefile is the path of the main Makefile. Relative paths are
+	// relative to the daemon's working directory; empty is its Makefile.
+	Makefile string `json:"makefile"`
+
+	// Format is the output format of Render. Empty uses the daemon's
+	// --format, or text.
+	Format string `json:"format,omitempty"`
+}
+
+// ParseReply is the result of MakeHelp.Parse.
+type ParseReply struct {
+	// Model is the help model, as written by --format json.
+	Model json.RawMessage `json:"model"`
+
+	// Makefiles lists the Makefile and the files it includes.
+	Makefiles []string `json:"makefiles"`
+
+	// Cached is true when the Makefiles were not read again.
+	Cached bool `json:"cached"`
+}
+
+// RenderReply is the result of MakeHelp.Render.
+type RenderReply struct {
+	Output string `json:"output"`
+	Cached bool   `json:"cached"`
+}
+
+// LintReply is the result of MakeHelp.Lint.
+type LintReply struct {
+	Warnings []DaemonWarning `json:"warnings"`
+	Cached   bool            `json:"cached"`
+}
+
+// DaemonWarning is a lint warning in a LintReply.
+type DaemonWarning struct {
+	File     string `json:"file"`
+	Line     int    `json:"line,omitempty"`
+	Severity string `json:"severity"`
+	Check    string `json:"check"`
+	Message  string `json:"message"`
+	Fixable  bool   `json:"fixable,omitempty"`
+}
+
+// DaemonService implements the daemon RPCs. Every request is handled with
+// a copy of the daemon's configuration, so flags given to --daemon (such as
+// --default-category or --no-exec) apply to all of them.
+type DaemonService struct {
+	config *Config
+	cache  *daemonCache
+}
+
+// newDaemonService returns the service for the daemon configured by config.
+func newDaemonService(config *Config) *DaemonService {
+	// Nobody is at the terminal to read warnings meant for a user
+	config.NoShellWarning = true
+	return &DaemonService{
+		config: config,
+		cache:  &daemonCache{slots: make(map[string]*daemonSlot)},
+	}
+}
+
+// Parse returns the help model of a Makefile.
+func (s *DaemonService) Parse(args *DaemonArgs, reply *ParseReply) error {
+	config := s.requestConfig(args.Makefile, "json")
+	entry, cached, err := s.cache.load(config)
+	if err != nil {
+		return err
+	}
+	output, err := renderDaemonHelp(config, entry)
+	if err != nil {
+		return err
+	}
+	*reply = ParseReply{Model: json.RawMessage(output), Makefiles: entry.makefiles, Cached: cached}
+	return nil
+}
+
+// Render returns the help of a Makefile in a format.
+func (s *DaemonService) Render(args *DaemonArgs, reply *RenderReply) error {
+	formatName := cmp.Or(args.Format, s.config.Format)
+	if _, ok := formatNames[formatName]; !ok {
+		return fmt.Errorf("invalid format: %s (valid: make, text, html, markdown, json, ndjson, slack)", formatName)
+	}
+	config := s.requestConfig(args.Makefile, formatNames[formatName])
+	entry, cached, err := s.cache.load(config)
+	if err != nil {
+		return err
+	}
+	output, err := renderDaemonHelp(config, entry)
+	if err != nil {
+		return err
+	}
+	*reply = RenderReply{Output: string(output), Cached: cached}
+	return nil
+}
+
+// Lint returns the lint warnings of a Makefile.
+func (s *DaemonService) Lint(args *DaemonArgs, reply *LintReply) error {
+	config := s.requestConfig(args.Makefile, "text")
+	entry, cached, err := s.cache.load(config)
+	if err != nil {
+		return err
+	}
+	config.MakefilePath = entry.inputs.MakefilePath
+	result, _, err := lintParsedFiles(config, entry.makefiles, entry.inputs.ParsedFiles, entry.inputs.Targets)
+	if err != nil {
+		return err
+	}
+	warnings := make([]DaemonWarning, 0, len(result.Warnings))
+	for _, w := range result.Warnings {
+		warnings = append(warnings, DaemonWarning{
+			File:     config.pathMap.Path(w.File),
+			Line:     w.Line,
+			Severity: string(w.Severity),
+			Check:    w.CheckName,
+			Message:  w.Message,
+			Fixable:  w.Fixable,
+		})
+	}
+	*reply = LintReply{Warnings: warnings, Cached: cached}
+	return nil
+}
+
+// requestConfig returns a copy of the daemon configuration for a request
+// on makefilePath rendering formatName.
+func (s *DaemonService) requestConfig(makefilePath, formatName string) *Config {
+	config := *s.config
+	config.MakefilePath = makefilePath
+	config.Format = formatName
+	return &config
+}
+
+// renderDaemonHelp builds the help model from entry and renders it.
+func renderDaemonHelp(config *Config, entry *daemonEntry) ([]byte, error) {
+	config.MakefilePath = entry.inputs.MakefilePath
+	helpModel, err := buildHelpModelFromInputs(config, entry.inputs)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := renderHelp(config, helpModel, &buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// daemonCache holds the model inputs of each Makefile the daemon has read,
+// by resolved Makefile path.
+type daemonCache struct {
+	// mu guards slots only; reading a Makefile holds the lock of its slot,
+	// so requests for other Makefiles are not held up while make runs.
+	mu    sync.Mutex
+	slots map[string]*daemonSlot
+}
+
+// daemonSlot holds the cache entry of one Makefile. Requests for the same
+// Makefile wait on mu, so it is read once when its entry is stale.
+type daemonSlot struct {
+	mu    sync.Mutex
+	entry *daemonEntry
+}
+
+// daemonEntry is a cached Makefile: its model inputs and the state of the
+// files they were read from.
+type daemonEntry struct {
+	inputs    *modelInputs
+	makefiles []string
+	files     map[string]fileStamp
+}
+
+// fileStamp identifies a version of a file. The zero value stands for a
+// missing file.
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// load returns the cache entry of config.MakefilePath, reading the
+// Makefiles again when it is missing or one of its files has changed since
+// it was read. The result reports whether the cached entry was used.
+func (c *daemonCache) load(config *Config) (*daemonEntry, bool, error) {
+	makefilePath, err := discovery.ResolveMakefilePath(config.MakefilePath)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to resolve Makefile path: %w", err)
+	}
+
+	c.mu.Lock()
+	slot, ok := c.slots[makefilePath]
+	if !ok {
+		slot = &daemonSlot{}
+		c.slots[makefilePath] = slot
+	}
+	c.mu.Unlock()
+
+	slot.mu.Lock()
+	defer slot.mu.Unlock()
+	if slot.entry != nil && slot.entry.fresh() {
+		return slot.entry, true, nil
+	}
+	slot.entry = nil
+
+	config.MakefilePath = makefilePath
+	inputs, err := collectModelInputs(config)
+	if err != nil {
+		return nil, false, err
+	}
+	entry := &daemonEntry{inputs: inputs, files: make(map[string]fileStamp)}
+	for _, parsed := range inputs.ParsedFiles {
+		entry.makefiles = append(entry.makefiles, parsed.Path)
+	}
+	// The project files decide which Makefiles are read, and how
+	dir := filepath.Dir(makefilePath)
+	watched := append([]string{
+		filepath.Join(dir, projectconfig.FileName),
+		filepath.Join(dir, projectconfig.IgnoreFileName),
+	}, entry.makefiles...)
+	for _, file := range watched {
+		entry.files[file] = statFile(file)
+	}
+	slot.entry = entry
+	return entry, false, nil
+}
+
+// fresh reports whether none of the entry's files have changed.
+func (e *daemonEntry) fresh() bool {
+	for file, stamp := range e.files {
+		if statFile(file) != stamp {
+			return false
+		}
+	}
+	return true
+}
+
+// statFile returns the current stamp of file.
+func statFile(file string) fileStamp {
+	info, err := os.Stat(file)
+	if err != nil {
+		return fileStamp{}
+	}
+	return fileStamp{modTime: info.ModTime(), size: info.Size()}
+}
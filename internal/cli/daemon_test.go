@@ -0,0 +1,179 @@
+package cli
+
+import (
+	"context"
+	"net"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startTestDaemon runs a daemon on a socket in a temporary directory and
+// returns a client connected to it. The daemon stops when the test ends.
+func startTestDaemon(t *testing.T, config *Config) *rpc.Client {
+	t.Helper()
+	config.Daemon = filepath.Join(t.TempDir(), "daemon.sock")
+	config.Format = "text"
+	ctx, cancel := context.WithCancel(context.Background())
+	config.ctx = ctx
+	done := make(chan error, 1)
+	go func() { done <- runDaemon(config) }()
+	t.Cleanup(func() {
+		cancel()
+		assert.NoError(t, <-done)
+		assert.NoFileExists(t, config.Daemon)
+	})
+
+	var client *rpc.Client
+	require.Eventually(t, func() bool {
+		var err error
+		client, err = jsonrpc.Dial("unix", config.Daemon)
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func TestRunDaemon(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	makefilePath := filepath.Join(tmpDir, "Makefile")
+	require.NoError(t, os.WriteFile(makefilePath, []byte(injectTestMakefile), 0644))
+
+	config := NewConfig()
+	config.NoExec = true
+	client := startTestDaemon(t, config)
+
+	var render RenderReply
+	require.NoError(t, client.Call("MakeHelp.Render", &DaemonArgs{Makefile: makefilePath}, &render))
+	assert.False(t, render.Cached)
+	assert.Contains(t, render.Output, "build: Build the project.")
+
+	require.NoError(t, client.Call("MakeHelp.Render", &DaemonArgs{Makefile: makefilePath, Format: "md"}, &render))
+	assert.True(t, render.Cached)
+	assert.Contains(t, render.Output, "**build**")
+
+	var parse ParseReply
+	require.NoError(t, client.Call("MakeHelp.Parse", &DaemonArgs{Makefile: makefilePath}, &parse))
+	assert.True(t, parse.Cached)
+	assert.Equal(t, []string{makefilePath}, parse.Makefiles)
+	assert.Contains(t, string(parse.Model), `"name":"build"`)
+
+	var lintReply LintReply
+	require.NoError(t, client.Call("MakeHelp.Lint", &DaemonArgs{Makefile: makefilePath}, &lintReply))
+	assert.Empty(t, lintReply.Warnings)
+
+	// A changed Makefile is read again
+	changed := injectTestMakefile + "\n## Deploy the project.\ndeploy:\n\t@echo deploy\n"
+	require.NoError(t, os.WriteFile(makefilePath, []byte(changed), 0644))
+	require.NoError(t, client.Call("MakeHelp.Render", &DaemonArgs{Makefile: makefilePath}, &render))
+	assert.False(t, render.Cached)
+	assert.Contains(t, render.Output, "deploy: Deploy the project.")
+
+	err := client.Call("MakeHelp.Render", &DaemonArgs{Makefile: makefilePath, Format: "pdf"}, &render)
+	assert.ErrorContains(t, err, "invalid format: pdf")
+	err = client.Call("MakeHelp.Render", &DaemonArgs{Makefile: filepath.Join(tmpDir, "missing.mk")}, &render)
+	assert.ErrorContains(t, err, "Makefile not found")
+}
+
+func TestRunDaemon_LintWarnings(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	makefilePath := filepath.Join(tmpDir, "Makefile")
+	require.NoError(t, os.WriteFile(makefilePath, []byte("## !category Build\n## Build the project\nbuild:\n\t@echo build\n"), 0644))
+
+	config := NewConfig()
+	config.NoExec = true
+	client := startTestDaemon(t, config)
+
+	var reply LintReply
+	require.NoError(t, client.Call("MakeHelp.Lint", &DaemonArgs{Makefile: makefilePath}, &reply))
+	require.NotEmpty(t, reply.Warnings)
+	assert.Equal(t, makefilePath, reply.Warnings[0].File)
+	assert.Equal(t, "warning", reply.Warnings[0].Severity)
+	assert.NotEmpty(t, reply.Warnings[0].Check)
+}
+
+func TestDaemonCache_LoadsMakefilesConcurrently(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	started := filepath.Join(tmpDir, "started")
+	release := filepath.Join(tmpDir, "release")
+
+	// Reading the slow Makefile blocks until the test creates release
+	slowDir := filepath.Join(tmpDir, "slow")
+	require.NoError(t, os.Mkdir(slowDir, 0755))
+	slowMakefile := filepath.Join(slowDir, "Makefile")
+	require.NoError(t, os.WriteFile(slowMakefile, []byte(
+		"WAIT := $(shell touch "+started+"; while [ ! -f "+release+" ]; do sleep 0.05; done)\n"+
+			"## Build the project.\nbuild:\n\t@true\n"), 0644))
+	fastDir := filepath.Join(tmpDir, "fast")
+	require.NoError(t, os.Mkdir(fastDir, 0755))
+	fastMakefile := filepath.Join(fastDir, "Makefile")
+	require.NoError(t, os.WriteFile(fastMakefile, []byte(injectTestMakefile), 0644))
+
+	cache := &daemonCache{slots: make(map[string]*daemonSlot)}
+	slowDone := make(chan error, 1)
+	go func() {
+		config := NewConfig()
+		config.MakefilePath = slowMakefile
+		_, _, err := cache.load(config)
+		slowDone <- err
+	}()
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(started)
+		return err == nil
+	}, 10*time.Second, 10*time.Millisecond)
+
+	// Another Makefile is read while make runs for the slow one
+	fastDone := make(chan error, 1)
+	go func() {
+		config := NewConfig()
+		config.MakefilePath = fastMakefile
+		_, _, err := cache.load(config)
+		fastDone <- err
+	}()
+	select {
+	case err := <-fastDone:
+		require.NoError(t, err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("loading a Makefile waited for another Makefile to be read")
+	}
+
+	require.NoError(t, os.WriteFile(release, nil, 0644))
+	require.NoError(t, <-slowDone)
+
+	config := NewConfig()
+	config.MakefilePath = slowMakefile
+	_, cached, err := cache.load(config)
+	require.NoError(t, err)
+	assert.True(t, cached)
+}
+
+func TestRemoveStaleSocket(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+
+	require.NoError(t, removeStaleSocket(filepath.Join(dir, "missing.sock")))
+
+	file := filepath.Join(dir, "file")
+	require.NoError(t, os.WriteFile(file, nil, 0644))
+	assert.ErrorContains(t, removeStaleSocket(file), "is not a socket")
+
+	socket := filepath.Join(dir, "live.sock")
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	assert.ErrorContains(t, removeStaleSocket(socket), "already listening")
+
+	// A socket nobody listens on is removed
+	listener.(*net.UnixListener).SetUnlinkOnClose(false)
+	require.NoError(t, listener.Close())
+	require.NoError(t, removeStaleSocket(socket))
+	assert.NoFileExists(t, socket)
+}
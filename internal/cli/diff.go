@@ -0,0 +1,151 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines shown around each change in
+// a unified diff.
+const diffContext = 3
+
+// ANSI colors for unified diffs on a terminal, as git colors them.
+const (
+	diffHeaderColor  = "\033[1m"
+	diffHunkColor    = "\033[36m"
+	diffRemovedColor = "\033[31m"
+	diffAddedColor   = "\033[32m"
+	diffResetColor   = "\033[0m"
+)
+
+// diffOp is one line of an edit script: kept (' '), removed ('-'), or
+// added ('+'). oldLine and newLine are its 0-based positions in each input.
+type diffOp struct {
+	kind             byte
+	text             string
+	oldLine, newLine int
+}
+
+// splitLines splits s into lines, without a trailing empty line for a
+// final newline. An empty s has no lines.
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// editScript returns a minimal edit script turning a into b, from their
+// longest common subsequence. Removals come before additions.
+func editScript(a, b []string) []diffOp {
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var ops []diffOp
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			ops = append(ops, diffOp{' ', a[i], i, j})
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			ops = append(ops, diffOp{'-', a[i], i, j})
+			i++
+		default:
+			ops = append(ops, diffOp{'+', b[j], i, j})
+			j++
+		}
+	}
+	return ops
+}
+
+// unifiedDiff returns the changes from oldText to newText as a unified diff
+// with diffContext lines of context, labeled oldName and newName (use
+// /dev/null for oldName when the file is new). Returns "" when the texts
+// are equal. With useColor, the diff is colored for a terminal.
+func unifiedDiff(oldName, newName, oldText, newText string, useColor bool) string {
+	ops := editScript(splitLines(oldText), splitLines(newText))
+
+	color := func(code, line string) string {
+		if !useColor {
+			return line
+		}
+		return code + line + diffResetColor
+	}
+
+	// A hunk runs from diffContext lines before a change to diffContext
+	// lines after the last change within 2*diffContext lines of the previous
+	var sb strings.Builder
+	for i := 0; i < len(ops); {
+		if ops[i].kind == ' ' {
+			i++
+			continue
+		}
+		first := max(i-diffContext, 0)
+		last := i
+		for j := i + 1; j < len(ops) && j-last-1 <= 2*diffContext; j++ {
+			if ops[j].kind != ' ' {
+				last = j
+			}
+		}
+		end := min(last+diffContext+1, len(ops))
+
+		if sb.Len() == 0 {
+			sb.WriteString(color(diffHeaderColor, "--- "+oldName) + "\n")
+			sb.WriteString(color(diffHeaderColor, "+++ "+newName) + "\n")
+		}
+
+		var oldCount, newCount int
+		for _, op := range ops[first:end] {
+			if op.kind != '+' {
+				oldCount++
+			}
+			if op.kind != '-' {
+				newCount++
+			}
+		}
+		oldStart, newStart := ops[first].oldLine+1, ops[first].newLine+1
+		if oldCount == 0 {
+			oldStart--
+		}
+		if newCount == 0 {
+			newStart--
+		}
+		sb.WriteString(color(diffHunkColor, "@@ -"+hunkRange(oldStart, oldCount)+" +"+hunkRange(newStart, newCount)+" @@") + "\n")
+
+		for _, op := range ops[first:end] {
+			line := string(op.kind) + op.text
+			switch op.kind {
+			case '-':
+				line = color(diffRemovedColor, line)
+			case '+':
+				line = color(diffAddedColor, line)
+			}
+			sb.WriteString(line + "\n")
+		}
+		i = end
+	}
+	return sb.String()
+}
+
+// hunkRange formats the start and length of a hunk for its header,
+// leaving out a length of 1 as diff -u does.
+func hunkRange(start, count int) string {
+	if count == 1 {
+		return fmt.Sprint(start)
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
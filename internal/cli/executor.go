@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+
+	"github.com/sdlcforge/make-help/internal/discovery"
+)
+
+// envNameRegex matches the environment variable names --env-allow accepts.
+var envNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// newMakeExecutor creates the executor running make, with the environment
+// limited by --scrub-env and network access removed by --sandbox.
+func newMakeExecutor(config *Config) *discovery.DefaultExecutor {
+	executor := discovery.NewDefaultExecutor()
+	if config.ScrubEnv || config.Sandbox {
+		executor.EnvAllowList = append(slices.Clone(discovery.DefaultEnvAllowList), config.EnvAllow...)
+	}
+	executor.Sandbox = config.Sandbox
+	return executor
+}
+
+// warnShellExpressions lists on stderr the $(shell ...) expressions make
+// will run while reading makefilePath and its includes, before make-help
+// runs it. Only interactive runs warn, once per Makefile, and not with
+// --no-exec, --sandbox, or --no-shell-warning, or when run by make, as
+// from a generated help target.
+func warnShellExpressions(config *Config, makefilePath string) {
+	if config.NoExec || config.Sandbox || config.NoShellWarning || os.Getenv("MAKELEVEL") != "" || !IsTerminal(os.Stderr.Fd()) {
+		return
+	}
+	if config.shellWarned == nil {
+		config.shellWarned = make(map[string]bool)
+	}
+	if config.shellWarned[makefilePath] {
+		return
+	}
+	config.shellWarned[makefilePath] = true
+
+	expressions, err := discovery.FindShellExpressions(makefilePath)
+	if err != nil {
+		// make reports unreadable Makefiles itself
+		return
+	}
+	writeShellWarning(os.Stderr, expressions)
+}
+
+// writeShellWarning writes the warning listing expressions, if any.
+func writeShellWarning(w io.Writer, expressions []discovery.ShellExpression) {
+	if len(expressions) == 0 {
+		return
+	}
+	cwd, _ := os.Getwd()
+	fmt.Fprintf(w, "Warning: make will run these shell commands while make-help reads the Makefiles:\n")
+	for _, expression := range expressions {
+		file := expression.File
+		if rel, err := filepath.Rel(cwd, file); err == nil && cwd != "" {
+			file = rel
+		}
+		fmt.Fprintf(w, "  %s:%d: %s\n", file, expression.Line, expression.Expression)
+	}
+	fmt.Fprintf(w, "Use --no-exec to read them without running make, or --sandbox to run it without network access and with a minimal environment\n")
+	fmt.Fprintf(w, "(make can still read your files, such as credentials in your home directory; --no-shell-warning hides this).\n")
+}
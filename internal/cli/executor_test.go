@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sdlcforge/make-help/internal/discovery"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMakeExecutor(t *testing.T) {
+	t.Parallel()
+	config := NewConfig()
+	executor := newMakeExecutor(config)
+	assert.Nil(t, executor.EnvAllowList, "the environment is passed as is by default")
+	assert.False(t, executor.Sandbox)
+
+	config.ScrubEnv = true
+	config.EnvAllow = []string{"GOPATH"}
+	executor = newMakeExecutor(config)
+	assert.Equal(t, append(discovery.DefaultEnvAllowList, "GOPATH"), executor.EnvAllowList)
+	assert.Len(t, discovery.DefaultEnvAllowList, len(executor.EnvAllowList)-1, "the default list is not modified")
+
+	config = NewConfig()
+	config.Sandbox = true
+	executor = newMakeExecutor(config)
+	assert.Equal(t, discovery.DefaultEnvAllowList, executor.EnvAllowList, "--sandbox implies --scrub-env")
+	assert.True(t, executor.Sandbox)
+}
+
+func TestWriteShellWarning(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	writeShellWarning(&buf, nil)
+	assert.Empty(t, buf.String(), "no warning without shell expressions")
+
+	writeShellWarning(&buf, []discovery.ShellExpression{
+		{File: "/project/Makefile", Line: 3, Expression: "$(shell git describe)"},
+		{File: "/project/make/date.mk", Line: 1, Expression: "NOW != date"},
+	})
+	assert.Contains(t, buf.String(), "Warning: make will run these shell commands")
+	assert.Contains(t, buf.String(), "Makefile:3: $(shell git describe)\n")
+	assert.Contains(t, buf.String(), "date.mk:1: NOW != date\n")
+	assert.Contains(t, buf.String(), "--no-exec")
+	assert.Contains(t, buf.String(), "can still read your files", "--sandbox does not hide files from make")
+	assert.NotContains(t, buf.String(), "secrets")
+}
+
+func TestNoExec_DoesNotRunMake(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	makefilePath := filepath.Join(tmpDir, "Makefile")
+	sideEffect := filepath.Join(tmpDir, "ran")
+	require.NoError(t, os.WriteFile(makefilePath, []byte("X := $(shell touch "+sideEffect+")\n\n## Build it\nbuild:\n\t@echo building\n"), 0644))
+
+	config := NewConfig()
+	config.MakefilePath = makefilePath
+	config.NoExec = true
+	config.HelpFileRelPath = "help.mk"
+	require.NoError(t, runCreateHelpTarget(config))
+
+	assert.NoFileExists(t, sideEffect)
+	helpFile, err := os.ReadFile(filepath.Join(tmpDir, "help.mk"))
+	require.NoError(t, err)
+	assert.Contains(t, string(helpFile), "Build it")
+}
@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	mherrors "github.com/sdlcforge/make-help/internal/errors"
+	"github.com/sdlcforge/make-help/internal/target"
+)
+
+// helpTargetNameRegex matches the names --help-target-name accepts.
+var helpTargetNameRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// defaultFallbackHelpTargetName is offered by the prompt as the name of the
+// generated help target when keeping a hand-written one.
+const defaultFallbackHelpTargetName = "make-help"
+
+// handWrittenHelpTarget locates a help target make-help did not generate.
+type handWrittenHelpTarget struct {
+	// File is the Makefile defining the target.
+	File string
+
+	// Line is the 1-based line of its rule.
+	Line int
+}
+
+// resolveExistingHelpTarget checks makefiles for a hand-written target named
+// like the generated help target. With --replace-existing-help it is
+// returned to be commented out. Otherwise, on a terminal the user chooses
+// between replacing it and naming the generated target differently, which
+// updates config.HelpTargetName; without a terminal, a
+// DuplicateHelpTargetError is returned. Returns nil when there is nothing
+// to replace.
+func resolveExistingHelpTarget(config *Config, makefiles []string) (*handWrittenHelpTarget, error) {
+	file, line, err := target.FindHelpTarget(makefiles, config.HelpTargetName)
+	if err != nil {
+		return nil, err
+	}
+	if file == "" {
+		return nil, nil
+	}
+	existing := &handWrittenHelpTarget{File: file, Line: line}
+	location := fmt.Sprintf("%s:%d", file, line)
+
+	if config.ReplaceExistingHelp {
+		return existing, nil
+	}
+	if !IsTerminal(os.Stdin.Fd()) {
+		return nil, mherrors.NewDuplicateHelpTargetError(location)
+	}
+
+	replace, name, err := promptExistingHelpTarget(config.HelpTargetName, location, os.Stdin, os.Stderr)
+	if err != nil {
+		return nil, err
+	}
+	if replace {
+		config.ReplaceExistingHelp = true
+		return existing, nil
+	}
+
+	// The new name must not be taken by a hand-written target either
+	file, line, err = target.FindHelpTarget(makefiles, name)
+	if err != nil {
+		return nil, err
+	}
+	if file != "" {
+		return nil, mherrors.NewDuplicateHelpTargetError(fmt.Sprintf("%s:%d", file, line))
+	}
+	config.HelpTargetName = name
+	return nil, nil
+}
+
+// promptExistingHelpTarget asks whether to replace the hand-written target
+// name at location or to give the generated help target another name.
+// Returns replace, or the new name. Anything other than "r" or "n",
+// including end of input, aborts.
+func promptExistingHelpTarget(name, location string, in io.Reader, out io.Writer) (bool, string, error) {
+	reader := bufio.NewReader(in)
+	fmt.Fprintf(out, "%s already defines a %s target that make-help did not generate.\n", location, name)
+	fmt.Fprintf(out, "  r) Replace it: comment it out and generate %s\n", name)
+	fmt.Fprintf(out, "  n) Keep it: generate the help target under another name\n")
+	fmt.Fprintf(out, "  a) Abort\n")
+	fmt.Fprintf(out, "Choice [r/n/A]: ")
+	line, err := reader.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, "", fmt.Errorf("failed to read choice: %w", err)
+	}
+
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "r":
+		return true, "", nil
+	case "n":
+		for {
+			fmt.Fprintf(out, "Help target name [%s]: ", defaultFallbackHelpTargetName)
+			line, err := reader.ReadString('\n')
+			if err != nil && err != io.EOF {
+				return false, "", fmt.Errorf("failed to read help target name: %w", err)
+			}
+			newName := strings.TrimSpace(line)
+			if newName == "" {
+				newName = defaultFallbackHelpTargetName
+			}
+			if newName != name && helpTargetNameRegex.MatchString(newName) {
+				return false, newName, nil
+			}
+			fmt.Fprintf(out, "invalid help target name %q\n", newName)
+			if err == io.EOF {
+				return false, "", mherrors.NewDuplicateHelpTargetError(location)
+			}
+		}
+	default:
+		return false, "", mherrors.NewDuplicateHelpTargetError(location)
+	}
+}
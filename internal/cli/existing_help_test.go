@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	mherrors "github.com/sdlcforge/make-help/internal/errors"
+	"github.com/sdlcforge/make-help/internal/target"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const handWrittenHelpMakefile = `## Build the project
+build:
+	@echo building
+
+## Show help
+help:
+	@echo "my help"
+`
+
+func TestCreateHelpTarget_HandWrittenHelp(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		args []string
+		// check inspects the Makefile and help.mk after a successful run
+		check func(t *testing.T, makefile, help string)
+	}{
+		{
+			name: "fails without a choice",
+		},
+		{
+			name: "replace existing help",
+			args: []string{"--replace-existing-help"},
+			check: func(t *testing.T, makefile, help string) {
+				assert.Contains(t, makefile, target.ReplacedHelpBeginMarker+"\n# ## Show help\n# help:\n# \t@echo \"my help\"\n"+target.ReplacedHelpEndMarker+"\n")
+				assert.Contains(t, help, "\nhelp:\n")
+			},
+		},
+		{
+			name: "rename generated help",
+			args: []string{"--help-target-name", "usage"},
+			check: func(t *testing.T, makefile, help string) {
+				assert.True(t, strings.HasPrefix(makefile, handWrittenHelpMakefile), "hand-written help should be kept")
+				assert.Contains(t, help, "\nusage:\n")
+				assert.NotContains(t, help, "\nhelp:\n")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tmpDir := t.TempDir()
+			makefilePath := filepath.Join(tmpDir, "Makefile")
+			require.NoError(t, os.WriteFile(makefilePath, []byte(handWrittenHelpMakefile), 0644))
+
+			cmd := NewRootCmd()
+			cmd.SetArgs(append([]string{"--makefile-path", makefilePath, "--help-file-rel-path", "help.mk"}, tt.args...))
+			err := cmd.Execute()
+
+			if tt.check == nil {
+				var duplicate *mherrors.DuplicateHelpTargetError
+				require.True(t, errors.As(err, &duplicate), "expected DuplicateHelpTargetError, got %v", err)
+				assert.Equal(t, makefilePath+":6", duplicate.Location)
+				_, statErr := os.Stat(filepath.Join(tmpDir, "help.mk"))
+				assert.True(t, os.IsNotExist(statErr), "help.mk should not be created")
+				return
+			}
+
+			require.NoError(t, err)
+			makefile, err := os.ReadFile(makefilePath)
+			require.NoError(t, err)
+			help, err := os.ReadFile(filepath.Join(tmpDir, "help.mk"))
+			require.NoError(t, err)
+			tt.check(t, string(makefile), string(help))
+		})
+	}
+}
+
+func TestPromptExistingHelpTarget(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		input       string
+		wantReplace bool
+		wantName    string
+		wantErr     bool
+	}{
+		{name: "replace", input: "r\n", wantReplace: true},
+		{name: "rename with default", input: "n\n\n", wantName: defaultFallbackHelpTargetName},
+		{name: "rename after invalid name", input: "n\nhelp\nshow-help\n", wantName: "show-help"},
+		{name: "abort", input: "\n", wantErr: true},
+		{name: "end of input", input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var out bytes.Buffer
+			replace, name, err := promptExistingHelpTarget("help", "Makefile:6", strings.NewReader(tt.input), &out)
+			assert.Contains(t, out.String(), "Makefile:6 already defines a help target")
+			if tt.wantErr {
+				var duplicate *mherrors.DuplicateHelpTargetError
+				assert.True(t, errors.As(err, &duplicate))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantReplace, replace)
+			assert.Equal(t, tt.wantName, name)
+		})
+	}
+}
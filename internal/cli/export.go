@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/sdlcforge/make-help/internal/export"
+	"github.com/sdlcforge/make-help/internal/target"
+)
+
+// runExport writes the documented targets as a task manifest in the
+// --export schema, to --output or stdout. --profile applies as it does to
+// help, and --tag further limits the targets. The env schema can also be
+// limited to one --target.
+func runExport(config *Config) error {
+	inputs, err := loadModelInputs(config)
+	if err != nil {
+		return err
+	}
+	helpModel, err := buildHelpModelFromInputs(config, inputs)
+	if err != nil {
+		return err
+	}
+	helpModel = export.FilterTags(helpModel, config.Tags)
+
+	if config.Target != "" {
+		var names []string
+		for _, category := range helpModel.Categories {
+			for _, t := range category.Targets {
+				names = append(names, t.Name)
+			}
+		}
+		resolved, err := resolveTargetName(names, config.Target, config.Exact)
+		if err != nil {
+			return err
+		}
+		helpModel = export.FilterTarget(helpModel, resolved)
+	}
+
+	var values map[string]string
+	if inputs.Targets != nil {
+		values = inputs.Targets.VariableValues
+	}
+
+	if config.Output == "" || config.Output == "-" {
+		return export.Render(helpModel, config.Export, values, os.Stdout)
+	}
+
+	var buf bytes.Buffer
+	if err := export.Render(helpModel, config.Export, values, &buf); err != nil {
+		return err
+	}
+	if dir := filepath.Dir(config.Output); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create directory %s: %w", dir, err)
+		}
+	}
+	// The fzf runner is a script meant to be executed directly
+	var perm os.FileMode = 0644
+	if config.Export == export.SchemaFZF {
+		perm = 0755
+	}
+	if err := target.AtomicWriteFile(config.Output, buf.Bytes(), perm); err != nil {
+		return fmt.Errorf("failed to write %s: %w", config.Output, err)
+	}
+	if config.Verbose {
+		fmt.Fprintf(os.Stderr, "Wrote %s export to %s\n", config.Export, config.Output)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/sdlcforge/make-help/internal/graph"
+)
+
+// runGraph writes the target dependency graph to stdout in the --graph
+// format (dot or mermaid).
+func runGraph(config *Config) error {
+	inputs, err := loadModelInputs(config)
+	if err != nil {
+		return err
+	}
+
+	helpModel, err := buildHelpModelFromInputs(config, inputs)
+	if err != nil {
+		return err
+	}
+
+	documented := make(map[string]bool)
+	for _, category := range helpModel.Categories {
+		for _, target := range category.Targets {
+			documented[target.Name] = true
+		}
+	}
+
+	g := graph.Build(documented, inputs.Targets.Dependencies, graph.Options{
+		DocumentedOnly:  config.DocumentedOnly,
+		HighlightCycles: config.HighlightCycles,
+	})
+
+	if config.Verbose {
+		fmt.Fprintf(os.Stderr, "Graph has %d target(s) and %d dependencies\n", len(g.Nodes), len(g.Edges))
+	}
+
+	return graph.Render(g, config.Graph, os.Stdout)
+}
@@ -1,16 +1,24 @@
 package cli
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/sdlcforge/make-help/internal/discovery"
 	"github.com/sdlcforge/make-help/internal/format"
 	"github.com/sdlcforge/make-help/internal/model"
 	"github.com/sdlcforge/make-help/internal/ordering"
 	"github.com/sdlcforge/make-help/internal/parser"
-	"github.com/sdlcforge/make-help/internal/summary"
+	"github.com/sdlcforge/make-help/internal/projectconfig"
+	"github.com/sdlcforge/make-help/internal/remote"
+	"github.com/sdlcforge/make-help/internal/runstate"
+	"github.com/sdlcforge/make-help/internal/target"
 )
 
 // runHelp orchestrates the help generation process.
@@ -21,13 +29,89 @@ import (
 //  4. Ordering - Apply sorting rules
 //  5. Summary - Extract topic sentences
 //  6. Formatting - Render the output
-//  7. Output - Write to stdout
+//  7. Output - Write to stdout, or to config.Output for non-make formats
 func runHelp(config *Config) error {
+	helpModel, err := buildHelpModel(config)
+	if err != nil {
+		return err
+	}
+
+	if config.Output == "-" || config.Output == "" {
+		if showLastRuns(config) {
+			config.lastRuns = runstate.LastDurations(filepath.Dir(config.MakefilePath))
+		}
+		if config.Compact {
+			config.lineWidth = TerminalWidth()
+		}
+		return renderHelp(config, helpModel, os.Stdout)
+	}
+
+	var buf bytes.Buffer
+	if err := renderHelp(config, helpModel, &buf); err != nil {
+		return err
+	}
+
+	if dir := filepath.Dir(config.Output); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create directory %s: %w", dir, err)
+		}
+	}
+	if err := target.AtomicWriteFile(config.Output, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", config.Output, err)
+	}
+
+	fmt.Printf("Successfully wrote %s help to: %s\n", config.Format, config.Output)
+	return runPostHooks(config, config.Output)
+}
+
+// modelInputs holds everything the model builder consumes: the parsed
+// Makefiles and the target metadata reported by make. Capturing these lets a
+// model be rebuilt without invoking make (see --dump-model/--from-model).
+type modelInputs struct {
+	MakefilePath string                           `json:"makefilePath"`
+	ParsedFiles  []*parser.ParsedFile             `json:"parsedFiles"`
+	Targets      *discovery.DiscoverTargetsResult `json:"targets"`
+}
+
+// buildHelpModel runs discovery, parsing, model building, ordering, and
+// summary extraction, returning a model ready for rendering.
+// With --from-model, discovery and parsing are replaced by the saved inputs.
+// config.MakefilePath is updated to the resolved Makefile path.
+func buildHelpModel(config *Config) (*model.HelpModel, error) {
+	inputs, err := loadModelInputs(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildHelpModelFromInputs(config, inputs)
+}
+
+// loadModelInputs returns the builder inputs from the --from-model dump if
+// set, or by discovering and parsing the Makefiles otherwise.
+// config.MakefilePath is updated to the resolved Makefile path.
+func loadModelInputs(config *Config) (*modelInputs, error) {
+	var inputs *modelInputs
+	var err error
+	if config.FromModel != "" {
+		inputs, err = loadModelDump(config.FromModel)
+	} else {
+		inputs, err = collectModelInputs(config)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	config.MakefilePath = inputs.MakefilePath
+	return inputs, nil
+}
+
+// collectModelInputs discovers and parses the Makefiles (steps 1-3 of runHelp).
+func collectModelInputs(config *Config) (*modelInputs, error) {
 	// Recursion detection: if MAKE_HELP_GENERATING is set, we're being called
 	// from within a make process that was spawned by make-help. This indicates
 	// infinite recursion (make-help -> make -p -> auto-regen rule -> make-help).
 	if os.Getenv("MAKE_HELP_GENERATING") == "1" {
-		return fmt.Errorf("recursion detected: make-help was invoked from within a make process spawned by make-help. " +
+		return nil, fmt.Errorf("recursion detected: make-help was invoked from within a make process spawned by make-help. " +
 			"This usually happens when help.mk contains an auto-regeneration rule. " +
 			"Regenerate help.mk with the latest make-help to fix this issue")
 	}
@@ -35,37 +119,43 @@ func runHelp(config *Config) error {
 	// Step 1: Resolve and validate Makefile path
 	makefilePath, err := discovery.ResolveMakefilePath(config.MakefilePath)
 	if err != nil {
-		return fmt.Errorf("failed to resolve Makefile path: %w", err)
+		return nil, fmt.Errorf("failed to resolve Makefile path: %w", err)
 	}
 
 	if err := discovery.ValidateMakefileExists(makefilePath); err != nil {
-		return err
+		return nil, err
 	}
 
-	config.MakefilePath = makefilePath
-
 	if config.Verbose {
 		fmt.Fprintf(os.Stderr, "Using Makefile: %s\n", makefilePath)
 	}
 
 	// Step 2: Discover all Makefiles (main + included)
-	discoveryService := discovery.NewService(discovery.NewDefaultExecutor(), config.Verbose)
+	warnShellExpressions(config, makefilePath)
+	discoveryService := newDiscoveryService(config, newMakeExecutor(config))
 
-	makefiles, err := discoveryService.DiscoverMakefiles(makefilePath)
+	makefiles, err := discoveryService.DiscoverMakefiles(config.runContext(), makefilePath)
 	if err != nil {
-		return fmt.Errorf("failed to discover Makefiles: %w", err)
+		return nil, fmt.Errorf("failed to discover Makefiles: %w", err)
+	}
+
+	projectConfig, err := loadProjectConfig(makefilePath)
+	if err != nil {
+		return nil, err
 	}
+	makefiles = projectConfig.Ignore.SkipMakefiles(makefiles, makefilePath, config.Verbose)
 
 	// Step 3: Parse all Makefiles
-	scanner := parser.NewScanner()
-	var parsedFiles []*parser.ParsedFile
+	parsedFiles, err := parseMakefiles(config, makefiles)
+	if err != nil {
+		return nil, err
+	}
 
-	for _, mf := range makefiles {
-		parsed, err := scanner.ScanFile(mf)
+	if config.ResolveRemote {
+		parsedFiles, err = resolveRemoteIncludes(config, makefilePath, makefiles, parsedFiles, projectConfig.Ignore, remote.NewFetcher())
 		if err != nil {
-			return fmt.Errorf("failed to parse %s: %w", mf, err)
+			return nil, err
 		}
-		parsedFiles = append(parsedFiles, parsed)
 	}
 
 	if config.Verbose {
@@ -73,9 +163,26 @@ func runHelp(config *Config) error {
 	}
 
 	// Step 3.5: Discover targets with .PHONY status
-	targetsResult, err := discoveryService.DiscoverTargets(makefilePath)
+	targetsResult, err := discoveryService.DiscoverTargets(config.runContext(), makefilePath)
 	if err != nil {
-		return fmt.Errorf("failed to discover targets: %w", err)
+		return nil, fmt.Errorf("failed to discover targets: %w", err)
+	}
+
+	return &modelInputs{
+		MakefilePath: makefilePath,
+		ParsedFiles:  parsedFiles,
+		Targets:      targetsResult,
+	}, nil
+}
+
+// buildHelpModelFromInputs builds, orders, and summarizes the help model
+// (steps 4-6 of runHelp).
+func buildHelpModelFromInputs(config *Config, inputs *modelInputs) (*model.HelpModel, error) {
+	targetsResult := inputs.Targets
+
+	projectConfig, err := loadProjectConfig(config.MakefilePath)
+	if err != nil {
+		return nil, err
 	}
 
 	// Step 4: Build the help model with filtering
@@ -87,15 +194,41 @@ func runHelp(config *Config) error {
 		PhonyTargets:    targetsResult.IsPhony,
 		Dependencies:    targetsResult.Dependencies,
 		HasRecipe:       targetsResult.HasRecipe,
+		DefaultGoal:     targetsResult.DefaultGoal,
+		Profile:         config.Profile,
+		GroupByFile:     config.GroupBy == "file",
+		BaseDir:         filepath.Dir(config.MakefilePath),
+		OnlyFiles:       config.OnlyFiles,
+		EntryPoint:      config.EntryPoint,
+		ExcludeTargets:  slices.Concat(config.ExcludeTargets, projectConfig.Exclude.Targets),
+		ExcludeFiles:    slices.Concat(config.ExcludeFiles, projectConfig.Exclude.Files),
+		Ignore:          projectConfig.Ignore,
+		CategoryRename:  projectConfig.Categories.Rename,
+		// JSON consumers and model dumps get every target; consumers filter on the hidden flag.
+		// Hidden targets can still be run by name, and their variables are
+		// still configurable.
+		IncludeHidden: config.Format == "json" || config.Format == "ndjson" || config.DumpModel != "" ||
+			config.RunTarget != "" || config.Vars,
+		BestEffort: config.BestEffort,
 	}
 	builder := model.NewBuilder(builderConfig)
-	helpModel, err := builder.Build(parsedFiles)
+	helpModel, err := builder.Build(inputs.ParsedFiles)
 	if err != nil {
-		return fmt.Errorf("failed to build help model: %w", err)
+		return nil, fmt.Errorf("failed to build help model: %w", err)
 	}
+	warnDegradations(builder)
+	applyHeaderConfig(helpModel, projectConfig.Header)
+	config.undocumented = countUndocumented(config, builder.Undocumented())
 
 	if config.Verbose {
 		fmt.Fprintf(os.Stderr, "Built help model with %d category/categories\n", len(helpModel.Categories))
+		for _, conflict := range builder.DefinitionConflicts() {
+			fmt.Fprintf(os.Stderr, "Warning: target %s has recipes in more than one file:", conflict.Target)
+			for _, d := range conflict.Definitions {
+				fmt.Fprintf(os.Stderr, " %s:%d", d.SourceFile, d.LineNumber)
+			}
+			fmt.Fprintln(os.Stderr)
+		}
 	}
 
 	// Step 5: Apply ordering rules
@@ -103,42 +236,145 @@ func runHelp(config *Config) error {
 		config.KeepOrderCategories,
 		config.KeepOrderTargets,
 		config.KeepOrderFiles,
-		config.CategoryOrder,
+		bestEffortCategoryOrder(config, helpModel),
 	)
 	if err := orderingService.ApplyOrdering(helpModel); err != nil {
-		return fmt.Errorf("failed to apply ordering: %w", err)
+		return nil, fmt.Errorf("failed to apply ordering: %w", err)
 	}
 
-	// Step 6: Extract summaries for all targets
-	extractor := summary.NewExtractor()
-	for i := range helpModel.Categories {
-		for j := range helpModel.Categories[i].Targets {
-			target := &helpModel.Categories[i].Targets[j]
-			summaryText := extractor.ExtractPlainText(target.Documentation)
-			if summaryText != "" {
-				target.Summary = []string{summaryText}
-			} else {
-				target.Summary = []string{}
-			}
+	// Step 6: Mask secrets before anything is rendered
+	redactor, err := newRedactor(config, projectConfig)
+	if err != nil {
+		return nil, err
+	}
+	redactor.RedactModel(helpModel)
+
+	return helpModel, nil
+}
+
+// applyHeaderConfig sets the usage line and examples of helpModel from the
+// project config, where no !usage or !example directive set them.
+func applyHeaderConfig(helpModel *model.HelpModel, header projectconfig.Header) {
+	if helpModel.Usage == "" {
+		helpModel.Usage = header.Usage
+	}
+	if len(helpModel.Examples) == 0 {
+		helpModel.Examples = slices.Clone(header.Examples)
+	}
+}
+
+// countUndocumented returns how many of the undocumented .PHONY targets
+// left out of help are the project's own, not counting the help targets
+// make-help generates.
+func countUndocumented(config *Config, targets []string) int {
+	count := 0
+	for _, name := range targets {
+		if name == config.HelpTargetName || name == "update-help" || strings.HasPrefix(name, "help-") {
+			continue
 		}
+		count++
 	}
+	return count
+}
 
-	// Step 7: Create formatter and render the output
-	formatterConfig := &format.FormatterConfig{
-		UseColor:    config.UseColor,
-		MakefileDir: filepath.Dir(makefilePath),
+// renderHelp renders the help model in the configured format to w.
+func renderHelp(config *Config, helpModel *model.HelpModel, w io.Writer) error {
+	return renderHelpPage(config, helpModel, nil, w)
+}
+
+// renderHelpPage renders page, one of the pages SplitByCategory made of the
+// help model, in the configured format to w. A nil page renders the whole
+// model.
+func renderHelpPage(config *Config, helpModel *model.HelpModel, page *format.SplitPage, w io.Writer) error {
+	var htmlPolicy format.HTMLPolicy
+	if config.Format == "html" {
+		var err error
+		if htmlPolicy, err = resolveHTMLPolicy(config); err != nil {
+			return err
+		}
+	}
+
+	formatterConfig := newFormatterConfig(config)
+	formatterConfig.HTMLPolicy = htmlPolicy
+	changes, err := resolveLastChanges(config, helpModel)
+	if err != nil {
+		return err
+	}
+	formatterConfig.LastChanges = changes
+	if formatterConfig.NewTargets, err = resolveNewTargets(config, helpModel); err != nil {
+		return err
+	}
+	if config.ShowStats {
+		// Count before paging, so the inventory covers every page
+		formatterConfig.Stats = format.NewStats(helpModel, config.undocumented)
+	}
+	if config.Format == "markdown" || config.Format == "html" {
+		var err error
+		if formatterConfig.Provenance, err = resolveProvenance(config); err != nil {
+			return err
+		}
+	}
+	if page != nil {
+		helpModel = page.Model
+		formatterConfig.Navigation = page.Navigation
 	}
+	if config.PageSize > 0 && (config.Format == "json" || config.Format == "html") {
+		var err error
+		if helpModel, formatterConfig.Page, err = format.Paginate(helpModel, config.Page, config.PageSize); err != nil {
+			return err
+		}
+	}
+	// Map source paths last; everything above reads the files themselves
+	helpModel = config.pathMap.Model(helpModel)
 	formatter, err := format.NewFormatter(config.Format, formatterConfig)
 	if err != nil {
 		return fmt.Errorf("failed to create formatter: %w", err)
 	}
 
-	// Step 8: Write to stdout
-	if err := formatter.RenderHelp(helpModel, os.Stdout); err != nil {
+	if config.PostProcess == "" {
+		if err := formatter.RenderHelp(helpModel, w); err != nil {
+			return fmt.Errorf("failed to render help: %w", err)
+		}
+		return nil
+	}
+
+	var rendered bytes.Buffer
+	if err := formatter.RenderHelp(helpModel, &rendered); err != nil {
 		return fmt.Errorf("failed to render help: %w", err)
 	}
+	processed, err := postProcess(config, rendered.Bytes())
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(processed)
+	return err
+}
 
-	return nil
+// newFormatterConfig returns the formatter settings for the configured
+// output flags. The HTML policy is left for the caller to resolve.
+func newFormatterConfig(config *Config) *format.FormatterConfig {
+	fullHelpCommand := ""
+	if config.FullHelpTarget != "" {
+		fullHelpCommand = "make " + config.FullHelpTarget
+	}
+	return &format.FormatterConfig{
+		UseColor:              config.UseColor,
+		MakefileDir:           config.pathMap.Path(filepath.Dir(config.MakefilePath)),
+		NoScript:              config.NoScript,
+		TOC:                   config.TOC,
+		SlackBlocks:           config.SlackBlocks,
+		MarkdownLayout:        config.MDLayout,
+		TextLayout:            textLayout(config),
+		LineWidth:             config.lineWidth,
+		LastRuns:              config.lastRuns,
+		MaxTargetsPerCategory: config.MaxTargetsPerCategory,
+		FullHelpCommand:       fullHelpCommand,
+		SummaryWidth:          config.SummaryWidth,
+		ConsolidateVariables:  config.ConsolidateVars,
+		NoIncludedFiles:       config.NoIncludedFiles,
+		IncludedFilesPosition: config.IncludedFilesPosition,
+		FileDocsDepth:         config.FileDocsDepth,
+	}
 }
 
 // runDetailedHelp displays detailed information for a single target.
@@ -159,26 +395,26 @@ func runDetailedHelp(config *Config) error {
 	config.MakefilePath = makefilePath
 
 	// Step 2: Discover all targets to verify the requested target exists
-	discoveryService := discovery.NewService(discovery.NewDefaultExecutor(), config.Verbose)
-	targetsResult, err := discoveryService.DiscoverTargets(makefilePath)
+	warnShellExpressions(config, makefilePath)
+	discoveryService := newDiscoveryService(config, newMakeExecutor(config))
+	targetsResult, err := discoveryService.DiscoverTargets(config.runContext(), makefilePath)
 	if err != nil {
 		return fmt.Errorf("failed to discover targets: %w", err)
 	}
 
-	// Step 3: Check if target exists
-	targetExists := false
-	for _, t := range targetsResult.Targets {
-		if t == config.Target {
-			targetExists = true
-			break
-		}
+	// Step 3: Resolve the requested name to a target, accepting a unique
+	// prefix unless --exact is set
+	resolved, err := resolveTargetName(targetsResult.Targets, config.Target, config.Exact)
+	if err != nil {
+		return err
 	}
-	if !targetExists {
-		return fmt.Errorf("target '%s' not found", config.Target)
+	if resolved != config.Target && config.Verbose {
+		fmt.Fprintf(os.Stderr, "Resolved '%s' to target '%s'\n", config.Target, resolved)
 	}
+	config.Target = resolved
 
 	// Step 4: Discover and parse all Makefiles to get documentation
-	makefiles, err := discoveryService.DiscoverMakefiles(makefilePath)
+	makefiles, err := discoveryService.DiscoverMakefiles(config.runContext(), makefilePath)
 	if err != nil {
 		return fmt.Errorf("failed to discover Makefiles: %w", err)
 	}
@@ -204,6 +440,9 @@ func runDetailedHelp(config *Config) error {
 		PhonyTargets:    targetsResult.IsPhony,
 		Dependencies:    targetsResult.Dependencies,
 		HasRecipe:       targetsResult.HasRecipe,
+		DefaultGoal:     targetsResult.DefaultGoal,
+		// Asking for a target by name shows it even if it is hidden
+		IncludeHidden: true,
 	}
 	builder := model.NewBuilder(builderConfig)
 	helpModel, err := builder.Build(parsedFiles)
@@ -225,16 +464,46 @@ func runDetailedHelp(config *Config) error {
 		}
 	}
 
+	if foundTarget != nil {
+		projectConfig, err := loadProjectConfig(makefilePath)
+		if err != nil {
+			return err
+		}
+		redactor, err := newRedactor(config, projectConfig)
+		if err != nil {
+			return err
+		}
+		redactor.RedactTarget(foundTarget)
+	}
+
 	// Step 7: Create formatter and render the output
 	formatterConfig := &format.FormatterConfig{
 		UseColor:    config.UseColor,
-		MakefileDir: filepath.Dir(makefilePath),
+		MakefileDir: config.pathMap.Path(filepath.Dir(makefilePath)),
+		NoScript:    config.NoScript,
+	}
+	if showLastRuns(config) {
+		formatterConfig.LastRuns = runstate.LastDurations(filepath.Dir(makefilePath))
+	}
+	if formatterConfig.LastChanges, err = resolveLastChanges(config, helpModel); err != nil {
+		return err
+	}
+	if config.Format == "html" {
+		if formatterConfig.HTMLPolicy, err = resolveHTMLPolicy(config); err != nil {
+			return err
+		}
 	}
 	formatter, err := format.NewFormatter(config.Format, formatterConfig)
 	if err != nil {
 		return fmt.Errorf("failed to create formatter: %w", err)
 	}
 
+	if foundTarget != nil {
+		mapped := *foundTarget
+		mapped.SourceFile = config.pathMap.Path(foundTarget.SourceFile)
+		foundTarget = &mapped
+	}
+
 	if foundTarget != nil && len(foundTarget.Documentation) > 0 {
 		// Target has documentation - use detailed renderer
 		if err := formatter.RenderDetailedTarget(foundTarget, os.Stdout); err != nil {
@@ -256,3 +525,60 @@ func runDetailedHelp(config *Config) error {
 
 	return nil
 }
+
+// showLastRuns reports whether help should include durations recorded by
+// --run --record-duration. They are machine-local, so only terminal text
+// output shows them.
+func showLastRuns(config *Config) bool {
+	return config.Format == "text" && config.FromModel == ""
+}
+
+// resolveLastChanges returns the --git-blame changes of the model's
+// targets, running git blame on the first call only. Without --git-blame
+// it returns nil.
+func resolveLastChanges(config *Config, helpModel *model.HelpModel) (map[string]format.Change, error) {
+	if !config.GitBlame {
+		return nil, nil
+	}
+	if config.lastChanges == nil {
+		changes, err := lastChanges(helpModel, newBlameCache())
+		if err != nil {
+			return nil, err
+		}
+		config.lastChanges = changes
+	}
+	return config.lastChanges, nil
+}
+
+// resolveNewTargets returns the --highlight-new targets of the model,
+// reading the git history on the first call only. Without --highlight-new
+// it returns nil.
+func resolveNewTargets(config *Config, helpModel *model.HelpModel) (map[string]bool, error) {
+	if config.HighlightNew == "" {
+		return nil, nil
+	}
+	if config.newTargets == nil {
+		window, err := parseNewWindow(config.HighlightNew)
+		if err != nil {
+			return nil, err
+		}
+		added, err := newTargets(helpModel, time.Now().Add(-window))
+		if err != nil {
+			return nil, err
+		}
+		config.newTargets = added
+	}
+	return config.newTargets, nil
+}
+
+// textLayout maps the --compact and --long flags to a text formatter layout.
+func textLayout(config *Config) string {
+	switch {
+	case config.Compact:
+		return format.TextLayoutCompact
+	case config.Long:
+		return format.TextLayoutLong
+	default:
+		return ""
+	}
+}
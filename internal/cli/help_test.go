@@ -7,6 +7,9 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/sdlcforge/make-help/internal/pathmap"
+	"github.com/sdlcforge/make-help/internal/projectconfig"
 )
 
 func TestRunDetailedHelp_DocumentedTarget(t *testing.T) {
@@ -69,6 +72,23 @@ func TestRunDetailedHelp_NonexistentTarget(t *testing.T) {
 	assert.Contains(t, err.Error(), "target 'nonexistent_target_xyz' not found")
 }
 
+func TestRunDetailedHelp_PrefixOfSeveralTargets(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	makefilePath := filepath.Join(tmpDir, "Makefile")
+	require.NoError(t, os.WriteFile(makefilePath, []byte(".PHONY: test-unit test-e2e build\n## Run unit tests.\ntest-unit:\n\t@true\n## Run e2e tests.\ntest-e2e:\n\t@true\nbuild:\n\t@true\n"), 0644))
+
+	config := &Config{
+		MakefilePath: makefilePath,
+		Target:       "test",
+		UseColor:     false,
+	}
+
+	err := runDetailedHelp(config)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "target 'test' not found; targets starting with 'test': test-e2e, test-unit")
+}
+
 func TestRunDetailedHelp_InvalidMakefile(t *testing.T) {
 	t.Parallel()
 	config := &Config{
@@ -99,3 +119,74 @@ func TestRunDetailedHelp_WithColor(t *testing.T) {
 	require.NoError(t, err)
 	// Should work with colors enabled
 }
+
+func TestRunHelp_HeaderConfig(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	makefilePath := filepath.Join(tmpDir, "Makefile")
+	makefile := "## !usage make -j8 <target>\n\n" + injectTestMakefile
+	require.NoError(t, os.WriteFile(makefilePath, []byte(makefile), 0644))
+	projectConfig := `{"header": {"usage": "make <target>", "examples": ["make build V=1"]}}`
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, projectconfig.FileName), []byte(projectConfig), 0644))
+	outputPath := filepath.Join(tmpDir, "help.txt")
+
+	config := NewConfig()
+	config.MakefilePath = makefilePath
+	config.Format = "text"
+	config.Output = outputPath
+	config.NoHooks = true
+
+	require.NoError(t, runHelp(config))
+
+	content, err := os.ReadFile(outputPath)
+	require.NoError(t, err)
+	// The !usage directive wins over the config; the examples come from it
+	assert.Contains(t, string(content), "Usage: make -j8 <target>\n\nExamples:\n  make build V=1\n")
+}
+
+func TestRunHelp_FullHelpTarget(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	makefilePath := filepath.Join(tmpDir, "Makefile")
+	makefile := "## Build the app.\nbuild:\n\n## Run the tests.\ntest:\n\n## Lint the code.\nlint:\n"
+	require.NoError(t, os.WriteFile(makefilePath, []byte(makefile), 0644))
+	outputPath := filepath.Join(tmpDir, "help.txt")
+
+	config := NewConfig()
+	config.MakefilePath = makefilePath
+	config.Format = "text"
+	config.Output = outputPath
+	config.NoHooks = true
+	config.MaxTargetsPerCategory = 1
+	config.FullHelpTarget = "help-full"
+
+	require.NoError(t, runHelp(config))
+
+	content, err := os.ReadFile(outputPath)
+	require.NoError(t, err)
+	// Matches the line the static help of the generated file prints
+	assert.Contains(t, string(content), "(+2 more, run make help-full)")
+}
+
+func TestRunHelp_Container(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	makefilePath := filepath.Join(tmpDir, "Makefile")
+	require.NoError(t, os.WriteFile(makefilePath, []byte(injectTestMakefile), 0644))
+	outputPath := filepath.Join(tmpDir, "help.json")
+
+	config := NewConfig()
+	config.MakefilePath = makefilePath
+	config.Format = "json"
+	config.Output = outputPath
+	config.NoHooks = true
+	config.Container = true
+	config.pathMap = pathmap.Map{{Host: "/home/dev/app", Container: tmpDir}}
+
+	require.NoError(t, runHelp(config))
+
+	content, err := os.ReadFile(outputPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), `"sourceFile": "/home/dev/app/Makefile"`)
+	assert.NotContains(t, string(content), tmpDir)
+}
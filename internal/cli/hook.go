@@ -0,0 +1,199 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sdlcforge/make-help/internal/lint"
+)
+
+// Hook names accepted by --hook.
+const (
+	hookLint        = "lint"
+	hookInjectCheck = "inject-check"
+)
+
+// defaultInjectFile is the document checked by --hook inject-check when
+// --inject is not given.
+const defaultInjectFile = "README.md"
+
+// runHook runs a pre-commit hook. changedFiles are the paths passed by the
+// hook framework; when empty, the hook checks everything.
+//
+// Hooks skip discovery entirely when none of the changed files can affect
+// the result, so unrelated commits pay no make invocation cost.
+func runHook(config *Config, changedFiles []string) error {
+	switch config.Hook {
+	case hookLint:
+		return runLintHook(config, changedFiles)
+	case hookInjectCheck:
+		return runInjectCheckHook(config, changedFiles)
+	default:
+		return fmt.Errorf("unknown hook: %s (valid: %s, %s)", config.Hook, hookLint, hookInjectCheck)
+	}
+}
+
+// runLintHook lints the changed Makefiles and the files they include, and
+// reports only warnings in changed files, one compiler-style line per
+// warning.
+func runLintHook(config *Config, changedFiles []string) error {
+	makefiles := filterMakefilePaths(changedFiles)
+	if len(changedFiles) > 0 && len(makefiles) == 0 {
+		return nil
+	}
+
+	affected := make(map[string]bool, len(makefiles))
+	for _, path := range makefiles {
+		affected[absPath(path)] = true
+	}
+	if len(affected) > 0 {
+		config.hookFiles = affected
+	}
+
+	result, _, err := runLintChecks(config)
+	if err != nil {
+		return err
+	}
+
+	cwd, _ := os.Getwd()
+	found := false
+	for _, w := range result.Warnings {
+		if len(affected) > 0 && !affected[absPath(w.File)] {
+			continue
+		}
+		if cwd != "" {
+			if rel, err := filepath.Rel(cwd, w.File); err == nil {
+				w.File = rel
+			}
+		}
+		fmt.Println(lint.FormatWarning(w))
+		found = true
+	}
+
+	if found {
+		return ErrLintWarningsFound
+	}
+	return nil
+}
+
+// runInjectCheckHook verifies the injected help section when a Makefile or
+// the injected document itself has changed.
+func runInjectCheckHook(config *Config, changedFiles []string) error {
+	if config.InjectFile == "" {
+		config.InjectFile = defaultInjectFile
+	}
+
+	if len(changedFiles) > 0 {
+		relevant := len(filterMakefilePaths(changedFiles)) > 0
+		for _, path := range changedFiles {
+			if absPath(path) == absPath(config.InjectFile) {
+				relevant = true
+			}
+		}
+		if !relevant {
+			return nil
+		}
+	}
+
+	config.Check = true
+	return runInject(config)
+}
+
+// hookMakefiles returns the discovered makefiles that --hook lint parses:
+// the changed ones and, transitively, the files their include lines name.
+// Relative include paths are resolved against baseDir, the main Makefile's
+// directory; include paths built from variables are not followed.
+// Discovery order is preserved.
+func hookMakefiles(makefiles []string, changed map[string]bool, baseDir string) []string {
+	keep := make(map[string]bool)
+	var queue []string
+	for _, mf := range makefiles {
+		if changed[absPath(mf)] {
+			keep[mf] = true
+			queue = append(queue, mf)
+		}
+	}
+
+	for len(queue) > 0 {
+		mf := queue[0]
+		queue = queue[1:]
+		for _, pattern := range includePatterns(mf, baseDir) {
+			for _, candidate := range makefiles {
+				if keep[candidate] {
+					continue
+				}
+				if matched, _ := filepath.Match(pattern, absPath(candidate)); matched {
+					keep[candidate] = true
+					queue = append(queue, candidate)
+				}
+			}
+		}
+	}
+
+	var restricted []string
+	for _, mf := range makefiles {
+		if keep[mf] {
+			restricted = append(restricted, mf)
+		}
+	}
+	return restricted
+}
+
+// includePatterns returns the absolute paths, which may be glob patterns,
+// named by the include lines of file. Unreadable files name none.
+func includePatterns(file, baseDir string) []string {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil
+	}
+
+	var patterns []string
+	for _, line := range strings.Split(string(content), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || strings.HasPrefix(line, "\t") {
+			continue
+		}
+		switch fields[0] {
+		case "include", "-include", "sinclude":
+		default:
+			continue
+		}
+		for _, path := range fields[1:] {
+			if strings.Contains(path, "$") {
+				continue
+			}
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(baseDir, path)
+			}
+			patterns = append(patterns, absPath(path))
+		}
+	}
+	return patterns
+}
+
+// filterMakefilePaths returns the paths that look like Makefiles:
+// Makefile, makefile, GNUmakefile, or any *.mk file.
+func filterMakefilePaths(paths []string) []string {
+	var makefiles []string
+	for _, path := range paths {
+		base := filepath.Base(path)
+		switch {
+		case base == "Makefile", base == "makefile", base == "GNUmakefile",
+			strings.HasSuffix(base, ".mk"):
+			makefiles = append(makefiles, path)
+		}
+	}
+	return makefiles
+}
+
+// absPath returns the cleaned absolute form of path, or path itself if it
+// cannot be resolved.
+func absPath(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+	return abs
+}
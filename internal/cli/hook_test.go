@@ -0,0 +1,156 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterMakefilePaths(t *testing.T) {
+	t.Parallel()
+	paths := []string{
+		"Makefile",
+		"sub/makefile",
+		"GNUmakefile",
+		"make/build.mk",
+		"README.md",
+		"main.go",
+		"Makefile.bak",
+	}
+
+	assert.Equal(t,
+		[]string{"Makefile", "sub/makefile", "GNUmakefile", "make/build.mk"},
+		filterMakefilePaths(paths))
+}
+
+func TestRunHook_SkipsUnrelatedFiles(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		hook string
+	}{
+		{name: "lint", hook: hookLint},
+		{name: "inject-check", hook: hookInjectCheck},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			config := NewConfig()
+			config.Hook = tt.hook
+			// A missing Makefile proves discovery never runs
+			config.MakefilePath = "/nonexistent/Makefile"
+
+			err := runHook(config, []string{"main.go", "docs/guide.md"})
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestRunLintHook_ReportsChangedFilesOnly(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	makefilePath := filepath.Join(tmpDir, "Makefile")
+	includedPath := filepath.Join(tmpDir, "other.mk")
+	require.NoError(t, os.WriteFile(makefilePath, []byte("include "+includedPath+`
+
+## Build the project
+build:
+	@echo build
+`), 0644))
+	require.NoError(t, os.WriteFile(includedPath, []byte(`## Run tests
+test:
+	@echo test
+`), 0644))
+
+	config := NewConfig()
+	config.Hook = hookLint
+	config.MakefilePath = makefilePath
+
+	// Only other.mk changed; its missing-punctuation warning is reported
+	err := runHook(config, []string{includedPath})
+	assert.ErrorIs(t, err, ErrLintWarningsFound)
+
+	// Fixing other.mk leaves only warnings in the unchanged Makefile
+	require.NoError(t, os.WriteFile(includedPath, []byte(`## Run tests.
+test:
+	@echo test
+`), 0644))
+	assert.NoError(t, runHook(config, []string{includedPath}))
+}
+
+func TestHookMakefiles(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(tmpDir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		return path
+	}
+	makefile := write("Makefile", "include make/*.mk\n")
+	build := write("make/build.mk", "include $(TOOLS)/tools.mk\n-include make/lib/common.mk\n")
+	deploy := write("make/deploy.mk", "")
+	common := write("make/lib/common.mk", "")
+	tools := write("tools/tools.mk", "")
+	makefiles := []string{makefile, build, deploy, common, tools}
+
+	// Includes are followed transitively; variable include paths are not
+	assert.Equal(t,
+		[]string{build, common},
+		hookMakefiles(makefiles, map[string]bool{build: true}, tmpDir))
+	assert.Equal(t,
+		makefiles[:4],
+		hookMakefiles(makefiles, map[string]bool{makefile: true}, tmpDir))
+	assert.Empty(t, hookMakefiles(makefiles, map[string]bool{filepath.Join(tmpDir, "other.mk"): true}, tmpDir))
+}
+
+func TestHookFlagValidation(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		args      []string
+		errorText string
+	}{
+		{
+			name:      "unknown hook",
+			args:      []string{"--hook", "format"},
+			errorText: "invalid hook: format",
+		},
+		{
+			name:      "hook with lint",
+			args:      []string{"--hook", "lint", "--lint"},
+			errorText: "--hook cannot be used with --lint",
+		},
+		{
+			name:      "lint hook with inject",
+			args:      []string{"--hook", "lint", "--inject", "README.md"},
+			errorText: "--hook lint cannot be used with --inject",
+		},
+		{
+			name:      "hook with output",
+			args:      []string{"--hook", "lint", "--output", "-"},
+			errorText: "--hook cannot be used with --output",
+		},
+		{
+			name:      "hook with remove-help",
+			args:      []string{"--hook", "lint", "--remove-help"},
+			errorText: "--remove-help cannot be used with --hook",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cmd := NewRootCmd()
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorText)
+		})
+	}
+}
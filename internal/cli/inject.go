@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/sdlcforge/make-help/internal/inject"
+	"github.com/sdlcforge/make-help/internal/target"
+)
+
+// ErrInjectStale is a sentinel error returned by --inject --check when the
+// managed section does not match freshly rendered help.
+// Cobra will translate this into exit code 1.
+var ErrInjectStale = errors.New("injected help section is out of date")
+
+// runInject renders help and writes it between the make-help markers in
+// config.InjectFile. With --check, the file is left untouched and
+// ErrInjectStale is returned if it would change.
+func runInject(config *Config) error {
+	helpModel, err := buildHelpModel(config)
+	if err != nil {
+		return err
+	}
+
+	var rendered bytes.Buffer
+	if err := renderHelp(config, helpModel, &rendered); err != nil {
+		return err
+	}
+
+	// Keep other make-help processes from modifying the file meanwhile
+	if !config.Check {
+		release, err := lockProject(config, config.MakefilePath)
+		if err != nil {
+			return err
+		}
+		defer release()
+	}
+
+	perm := os.FileMode(0644)
+	existing, err := os.ReadFile(config.InjectFile)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %w", config.InjectFile, err)
+	}
+	if err == nil {
+		if info, statErr := os.Stat(config.InjectFile); statErr == nil {
+			perm = info.Mode().Perm()
+		}
+	}
+
+	updated, err := inject.Apply(string(existing), rendered.String())
+	if err != nil {
+		return fmt.Errorf("failed to update %s: %w", config.InjectFile, err)
+	}
+
+	if updated == string(existing) {
+		if config.Verbose {
+			fmt.Fprintf(os.Stderr, "%s is up to date\n", config.InjectFile)
+		}
+		return nil
+	}
+
+	if config.Check {
+		fmt.Fprintf(os.Stderr, "%s: help section is stale; run make-help --inject %s\n",
+			config.InjectFile, config.InjectFile)
+		return ErrInjectStale
+	}
+
+	tx := beginJournal(config, config.MakefilePath)
+	if err := tx.Track(config.InjectFile); err != nil {
+		return err
+	}
+	defer commitJournal(tx)
+
+	if err := target.AtomicWriteFile(config.InjectFile, []byte(updated), perm); err != nil {
+		return fmt.Errorf("failed to write %s: %w", config.InjectFile, err)
+	}
+
+	fmt.Printf("Updated help section in: %s\n", config.InjectFile)
+	return runPostHooks(config, config.InjectFile)
+}
@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const injectTestMakefile = `## !category Build
+## Build the project.
+build:
+	@echo build
+`
+
+func TestRunInject(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	makefilePath := filepath.Join(tmpDir, "Makefile")
+	require.NoError(t, os.WriteFile(makefilePath, []byte(injectTestMakefile), 0644))
+
+	readmePath := filepath.Join(tmpDir, "README.md")
+	readme := "# Project\n\n<!-- make-help:start -->\nold\n<!-- make-help:end -->\n\n## License\n"
+	require.NoError(t, os.WriteFile(readmePath, []byte(readme), 0644))
+
+	config := NewConfig()
+	config.MakefilePath = makefilePath
+	config.Format = "markdown"
+	config.MDLayout = "list"
+	config.InjectFile = readmePath
+
+	// Check mode reports the stale section without touching the file
+	config.Check = true
+	err := runInject(config)
+	assert.ErrorIs(t, err, ErrInjectStale)
+	content, err := os.ReadFile(readmePath)
+	require.NoError(t, err)
+	assert.Equal(t, readme, string(content))
+
+	// Inject mode rewrites only the managed section
+	config.Check = false
+	require.NoError(t, runInject(config))
+	content, err = os.ReadFile(readmePath)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(content), "# Project\n\n<!-- make-help:start -->\n# Makefile Help"))
+	assert.Contains(t, string(content), "**build**: Build the project.")
+	assert.True(t, strings.HasSuffix(string(content), "<!-- make-help:end -->\n\n## License\n"))
+
+	// Check mode passes once the section is current
+	config.Check = true
+	assert.NoError(t, runInject(config))
+}
+
+func TestInjectFlagValidation(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		args      []string
+		errorText string
+	}{
+		{
+			name:      "check without inject",
+			args:      []string{"--check"},
+			errorText: "--check requires --inject",
+		},
+		{
+			name:      "inject with output",
+			args:      []string{"--inject", "README.md", "--output", "-"},
+			errorText: "--inject cannot be used with --output",
+		},
+		{
+			name:      "inject with lint",
+			args:      []string{"--inject", "README.md", "--lint"},
+			errorText: "--inject cannot be used with --lint",
+		},
+		{
+			name:      "inject with html format",
+			args:      []string{"--inject", "README.md", "--format", "html"},
+			errorText: "--inject requires --format markdown",
+		},
+		{
+			name:      "inject with remove-help",
+			args:      []string{"--inject", "README.md", "--remove-help"},
+			errorText: "--remove-help cannot be used with --inject",
+		},
+		{
+			name:      "inject with toc",
+			args:      []string{"--inject", "README.md", "--toc", "--makefile-path", "/nonexistent/Makefile"},
+			errorText: "Makefile not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cmd := NewRootCmd()
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorText)
+		})
+	}
+}
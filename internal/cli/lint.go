@@ -5,12 +5,13 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/sdlcforge/make-help/internal/discovery"
 	"github.com/sdlcforge/make-help/internal/lint"
+	"github.com/sdlcforge/make-help/internal/lintload"
 	"github.com/sdlcforge/make-help/internal/model"
 	"github.com/sdlcforge/make-help/internal/parser"
-	"github.com/sdlcforge/make-help/internal/summary"
 )
 
 // ErrLintWarningsFound is a sentinel error returned when lint warnings are found.
@@ -31,139 +32,38 @@ var ErrLintWarningsFound = errors.New("lint warnings found")
 //   1 - Warnings found
 //   2 - Error (invalid flags, file not found, etc.)
 func runLint(config *Config) error {
-	// Check for recursion: prevent make-help from running if we're already in a make-help process
-	if os.Getenv("MAKE_HELP_GENERATING") == "1" {
-		return fmt.Errorf("recursion detected: make-help was invoked from within a make process spawned by make-help")
-	}
-
-	// Step 1: Resolve and validate Makefile path
-	makefilePath, err := discovery.ResolveMakefilePath(config.MakefilePath)
-	if err != nil {
-		return fmt.Errorf("failed to resolve Makefile path: %w", err)
-	}
-
-	if err := discovery.ValidateMakefileExists(makefilePath); err != nil {
-		return err
-	}
-
-	config.MakefilePath = makefilePath
-
-	if config.Verbose {
-		fmt.Fprintf(os.Stderr, "Using Makefile: %s\n", makefilePath)
-	}
-
-	// Step 2: Discover all Makefiles (main + included)
-	discoveryService := discovery.NewService(discovery.NewDefaultExecutor(), config.Verbose)
-
-	makefiles, err := discoveryService.DiscoverMakefiles(makefilePath)
-	if err != nil {
-		return fmt.Errorf("failed to discover Makefiles: %w", err)
-	}
-
-	// Step 3: Parse all Makefiles
-	scanner := parser.NewScanner()
-	var parsedFiles []*parser.ParsedFile
-
-	for _, mf := range makefiles {
-		parsed, err := scanner.ScanFile(mf)
+	// Keep other make-help processes from modifying the Makefiles while
+	// fixes are found and applied
+	if config.Fix && !config.DryRun {
+		makefilePath, err := discovery.ResolveMakefilePath(config.MakefilePath)
 		if err != nil {
-			return fmt.Errorf("failed to parse %s: %w", mf, err)
+			return fmt.Errorf("failed to resolve Makefile path: %w", err)
 		}
-		parsedFiles = append(parsedFiles, parsed)
-	}
-
-	if config.Verbose {
-		fmt.Fprintf(os.Stderr, "Parsed %d Makefile(s)\n", len(parsedFiles))
-	}
-
-	// Step 4: Discover targets with .PHONY status, dependencies, and recipes
-	targetsResult, err := discoveryService.DiscoverTargets(makefilePath)
-	if err != nil {
-		return fmt.Errorf("failed to discover targets: %w", err)
-	}
-
-	// Step 5: Build the help model
-	// For lint mode, we don't want to include undocumented targets
-	builderConfig := &model.BuilderConfig{
-		DefaultCategory: config.DefaultCategory,
-		IncludeTargets:  []string{},
-		IncludeAllPhony: false,
-		PhonyTargets:    targetsResult.IsPhony,
-		Dependencies:    targetsResult.Dependencies,
-		HasRecipe:       targetsResult.HasRecipe,
-	}
-	builder := model.NewBuilder(builderConfig)
-	helpModel, err := builder.Build(parsedFiles)
-	if err != nil {
-		return fmt.Errorf("failed to build help model: %w", err)
-	}
-
-	if config.Verbose {
-		fmt.Fprintf(os.Stderr, "Built help model with %d category/categories\n", len(helpModel.Categories))
-	}
-
-	// Step 6: Extract summaries for all targets
-	extractor := summary.NewExtractor()
-	for i := range helpModel.Categories {
-		for j := range helpModel.Categories[i].Targets {
-			target := &helpModel.Categories[i].Targets[j]
-			summaryText := extractor.ExtractPlainText(target.Documentation)
-			if summaryText != "" {
-				target.Summary = []string{summaryText}
-			} else {
-				target.Summary = []string{}
-			}
+		release, err := lockProject(config, makefilePath)
+		if err != nil {
+			return err
 		}
+		defer release()
 	}
 
-	// Step 7: Build CheckContext
-	documentedTargets := make(map[string]bool)
-	aliases := make(map[string]bool)
-	generatedHelpTargets := make(map[string]bool)
-	targetLocations := make(map[string]lint.TargetLocation)
-
-	// Build target locations from parsed files
-	for _, pf := range parsedFiles {
-		for targetName, lineNum := range pf.TargetMap {
-			targetLocations[targetName] = lint.TargetLocation{
-				File: pf.Path,
-				Line: lineNum,
-			}
-		}
+	result, checks, err := runLintChecks(config)
+	if err != nil {
+		return err
 	}
 
-	// Add the standard generated help targets
-	generatedHelpTargets["help"] = true
-	generatedHelpTargets["update-help"] = true
-
-	for _, category := range helpModel.Categories {
-		for _, target := range category.Targets {
-			documentedTargets[target.Name] = true
-			// Add help-<target> as a generated target
-			generatedHelpTargets["help-"+target.Name] = true
-			for _, alias := range target.Aliases {
-				aliases[alias] = true
-			}
+	// --baseline records the current warnings, or hides the recorded ones
+	if config.Baseline != "" {
+		recorded, err := applyLintBaseline(config, result)
+		if err != nil || recorded {
+			return err
 		}
 	}
 
-	checkCtx := &lint.CheckContext{
-		HelpModel:            helpModel,
-		MakefilePath:         makefilePath,
-		PhonyTargets:         targetsResult.IsPhony,
-		Dependencies:         targetsResult.Dependencies,
-		HasRecipe:            targetsResult.HasRecipe,
-		DocumentedTargets:    documentedTargets,
-		Aliases:              aliases,
-		GeneratedHelpTargets: generatedHelpTargets,
-		TargetLocations:      targetLocations,
-		NotAliasTargets:      builder.NotAliasTargets(),
+	// --stats reports on the warnings instead of listing them
+	if config.Stats != "" {
+		return writeLintStats(os.Stdout, result, config)
 	}
 
-	// Step 8: Run all lint checks
-	checks := lint.AllChecks()
-	result := lint.Lint(checkCtx, checks)
-
 	// Step 9: Apply fixes if --fix is set (before displaying warnings)
 	var fixResult *lint.FixResult
 	fixableCount := 0
@@ -176,8 +76,20 @@ func runLint(config *Config) error {
 	if config.Fix && fixableCount > 0 {
 		fixes := lint.CollectFixes(checks, result.Warnings)
 
-		fixer := &lint.Fixer{DryRun: config.DryRun}
-		var err error
+		if !config.DryRun {
+			tx := beginJournal(config, config.MakefilePath)
+			if err := tx.Track(result.Files...); err != nil {
+				return err
+			}
+			for _, fix := range fixes {
+				if err := tx.Track(fix.File); err != nil {
+					return err
+				}
+			}
+			defer commitJournal(tx)
+		}
+
+		fixer := &lint.Fixer{DryRun: config.DryRun, Makefiles: result.Files}
 		fixResult, err = fixer.ApplyFixes(fixes)
 		if err != nil {
 			return fmt.Errorf("failed to apply fixes: %w", err)
@@ -219,7 +131,9 @@ func runLint(config *Config) error {
 		for _, warning := range warningsToDisplay {
 			// Convert to relative path if possible
 			displayPath := warning.File
-			if cwd != "" {
+			if config.Container {
+				displayPath = config.pathMap.Path(warning.File)
+			} else if cwd != "" {
 				if rel, err := filepath.Rel(cwd, warning.File); err == nil {
 					displayPath = rel
 				}
@@ -256,6 +170,17 @@ func runLint(config *Config) error {
 		} else {
 			fmt.Printf("Found %d warnings\n", count)
 		}
+
+		// Break larger results down by check and file
+		if count > 1 {
+			stats := lint.Summarize(&lint.LintResult{Warnings: warningsToDisplay})
+			if len(stats.ByCheck) > 1 {
+				fmt.Printf("  by check: %s\n", formatCounts(stats.ByCheck))
+			}
+			if len(stats.ByFile) > 1 {
+				fmt.Printf("  by file: %s\n", formatCounts(relativeCounts(stats.ByFile)))
+			}
+		}
 	}
 
 	// Step 12: Report fix results
@@ -284,3 +209,84 @@ func runLint(config *Config) error {
 
 	return nil
 }
+
+// runLintChecks runs discovery, parsing, and model building (steps 1-8 of
+// runLint) and returns the lint result along with the checks that produced it.
+// config.MakefilePath is updated to the resolved Makefile path.
+func runLintChecks(config *Config) (*lint.LintResult, []lint.Check, error) {
+	checkCtx, checks, err := loadLintContext(config)
+	if err != nil {
+		return nil, nil, err
+	}
+	return lint.Lint(checkCtx, checks), checks, nil
+}
+
+// loadLintContext runs discovery, parsing, and model building (steps 1-7 of
+// runLint) and returns the context and checks to lint with.
+// config.MakefilePath is updated to the resolved Makefile path.
+func loadLintContext(config *Config) (*lint.CheckContext, []lint.Check, error) {
+	// Check for recursion: prevent make-help from running if we're already in a make-help process
+	if os.Getenv("MAKE_HELP_GENERATING") == "1" {
+		return nil, nil, fmt.Errorf("recursion detected: make-help was invoked from within a make process spawned by make-help")
+	}
+
+	// Step 1: Resolve and validate Makefile path
+	makefilePath, err := discovery.ResolveMakefilePath(config.MakefilePath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to resolve Makefile path: %w", err)
+	}
+
+	if err := discovery.ValidateMakefileExists(makefilePath); err != nil {
+		return nil, nil, err
+	}
+
+	config.MakefilePath = makefilePath
+
+	if config.Verbose {
+		fmt.Fprintf(os.Stderr, "Using Makefile: %s\n", makefilePath)
+	}
+
+	// Steps 2-7: Discover, parse, and build the lint context
+	warnShellExpressions(config, makefilePath)
+	discoveryService := newDiscoveryService(config, newMakeExecutor(config))
+	return lintload.Load(config.runContext(), discoveryService, makefilePath, lintOptions(config))
+}
+
+// lintParsedFiles builds the help model from discovered and parsed
+// Makefiles and runs the lint checks on it (steps 5-8 of runLintChecks).
+func lintParsedFiles(config *Config, makefiles []string, parsedFiles []*parser.ParsedFile, targetsResult *discovery.DiscoverTargetsResult) (*lint.LintResult, []lint.Check, error) {
+	checkCtx, checks, err := lintload.Context(config.MakefilePath, makefiles, parsedFiles, targetsResult, lintOptions(config))
+	if err != nil {
+		return nil, nil, err
+	}
+	return lint.Lint(checkCtx, checks), checks, nil
+}
+
+// lintOptions returns the lint settings config's flags select.
+func lintOptions(config *Config) *lintload.Options {
+	options := &lintload.Options{
+		DefaultCategory:  config.DefaultCategory,
+		EntryPoint:       config.EntryPoint,
+		CategoryOrder:    config.CategoryOrder,
+		HelpFileRelPath:  config.HelpFileRelPath,
+		NoPlugins:        config.NoPlugins,
+		NoProjectPlugins: config.NoExec || config.Sandbox,
+		Rename:           config.Rename,
+		Verbose:          config.Verbose,
+	}
+	if config.Spell {
+		options.SpellLang = config.SpellLang
+	}
+	if config.Freshness {
+		options.History = func(helpModel *model.HelpModel) (map[string]lint.TargetHistory, error) {
+			return targetHistory(helpModel, newBlameCache())
+		}
+		options.FreshnessThreshold = time.Duration(config.FreshnessDays) * 24 * time.Hour
+	}
+	if config.hookFiles != nil {
+		options.FilterMakefiles = func(makefiles []string) []string {
+			return hookMakefiles(makefiles, config.hookFiles, filepath.Dir(config.MakefilePath))
+		}
+	}
+	return options
+}
@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/sdlcforge/make-help/internal/lint"
+	"github.com/sdlcforge/make-help/internal/target"
+)
+
+// applyLintBaseline handles --baseline. When the baseline file does not
+// exist yet, it records the current warnings in it and returns true, ending
+// the run. Otherwise it removes the recorded warnings from result, so only
+// new ones are reported.
+func applyLintBaseline(config *Config, result *lint.LintResult) (bool, error) {
+	makefileDir := filepath.Dir(config.MakefilePath)
+
+	data, err := os.ReadFile(config.Baseline)
+	if errors.Is(err, fs.ErrNotExist) {
+		data, err := json.MarshalIndent(lint.NewBaseline(result.Warnings, makefileDir), "", "  ")
+		if err != nil {
+			return false, fmt.Errorf("failed to encode lint baseline: %w", err)
+		}
+		data = append(data, '\n')
+		if err := target.AtomicWriteFile(config.Baseline, data, 0644); err != nil {
+			return false, fmt.Errorf("failed to write lint baseline: %w", err)
+		}
+		fmt.Printf("Recorded %d warning(s) in %s\n", len(result.Warnings), config.Baseline)
+		return true, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read lint baseline: %w", err)
+	}
+
+	var baseline lint.Baseline
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return false, fmt.Errorf("failed to parse lint baseline %s: %w", config.Baseline, err)
+	}
+	if err := baseline.Validate(); err != nil {
+		return false, fmt.Errorf("invalid lint baseline %s: %w", config.Baseline, err)
+	}
+
+	remaining := baseline.Filter(result.Warnings, makefileDir)
+	if config.Verbose {
+		fmt.Fprintf(os.Stderr, "Suppressed %d warning(s) recorded in %s\n",
+			len(result.Warnings)-len(remaining), config.Baseline)
+	}
+	result.Warnings = remaining
+	result.HasWarnings = len(remaining) > 0
+	return false, nil
+}
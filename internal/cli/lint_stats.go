@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sdlcforge/make-help/internal/lint"
+)
+
+// Report formats accepted by --stats.
+const (
+	statsMarkdown = "markdown"
+	statsJSON     = "json"
+)
+
+// writeLintStats writes the --stats documentation quality report for result,
+// listing at most config.Top files.
+func writeLintStats(w io.Writer, result *lint.LintResult, config *Config) error {
+	stats := lint.Summarize(result)
+	stats.ByFile = relativeCounts(stats.ByFile)
+	if config.Top > 0 && len(stats.ByFile) > config.Top {
+		stats.ByFile = stats.ByFile[:config.Top]
+	}
+
+	if config.Stats == statsJSON {
+		data, err := json.MarshalIndent(stats, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode lint stats: %w", err)
+		}
+		_, err = fmt.Fprintf(w, "%s\n", data)
+		return err
+	}
+
+	var sb strings.Builder
+	sb.WriteString("# Lint report\n\n")
+	sb.WriteString("| Metric | Value |\n|---|---|\n")
+	fmt.Fprintf(&sb, "| Warnings | %d |\n", stats.Total)
+	fmt.Fprintf(&sb, "| Fixable | %d |\n", stats.Fixable)
+	fmt.Fprintf(&sb, "| Files checked | %d |\n", stats.Files)
+	if stats.Total > 0 {
+		sb.WriteString("\n## Warnings by check\n\n")
+		writeCountTable(&sb, "Check", stats.ByCheck)
+		sb.WriteString("\n## Top files\n\n")
+		writeCountTable(&sb, "File", stats.ByFile)
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// writeCountTable writes counts as a two-column Markdown table.
+func writeCountTable(sb *strings.Builder, heading string, counts []lint.Count) {
+	fmt.Fprintf(sb, "| %s | Warnings |\n|---|---|\n", heading)
+	for _, c := range counts {
+		fmt.Fprintf(sb, "| %s | %d |\n", c.Name, c.Count)
+	}
+}
+
+// formatCounts renders counts on one line, e.g. "naming 3, long-summary 1".
+func formatCounts(counts []lint.Count) string {
+	parts := make([]string, len(counts))
+	for i, c := range counts {
+		parts[i] = fmt.Sprintf("%s %d", c.Name, c.Count)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// relativeCounts rewrites file counts to paths relative to the working
+// directory, as lint output shows them.
+func relativeCounts(counts []lint.Count) []lint.Count {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return counts
+	}
+	result := make([]lint.Count, len(counts))
+	for i, c := range counts {
+		result[i] = c
+		if rel, err := filepath.Rel(cwd, c.Name); err == nil {
+			result[i].Name = rel
+		}
+	}
+	return result
+}
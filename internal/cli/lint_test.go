@@ -1,10 +1,13 @@
 package cli
 
 import (
+	"bytes"
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"testing"
 
+	"github.com/sdlcforge/make-help/internal/lint"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -170,3 +173,115 @@ func TestRunLint_RecursionDetection(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "recursion detected")
 }
+
+func TestWriteLintStats(t *testing.T) {
+	t.Parallel()
+	cwd, err := os.Getwd()
+	require.NoError(t, err)
+	result := &lint.LintResult{
+		Warnings: []lint.Warning{
+			{File: filepath.Join(cwd, "Makefile"), CheckName: "naming", Fixable: true},
+			{File: filepath.Join(cwd, "Makefile"), CheckName: "long-summary"},
+			{File: filepath.Join(cwd, "make", "build.mk"), CheckName: "naming"},
+		},
+		Files: []string{"Makefile", "make/build.mk"},
+	}
+
+	var buf bytes.Buffer
+	config := NewConfig()
+	config.Stats = statsMarkdown
+	config.Top = 1
+	require.NoError(t, writeLintStats(&buf, result, config))
+	assert.Equal(t, `# Lint report
+
+| Metric | Value |
+|---|---|
+| Warnings | 3 |
+| Fixable | 1 |
+| Files checked | 2 |
+
+## Warnings by check
+
+| Check | Warnings |
+|---|---|
+| naming | 2 |
+| long-summary | 1 |
+
+## Top files
+
+| File | Warnings |
+|---|---|
+| Makefile | 2 |
+`, buf.String())
+
+	buf.Reset()
+	config.Stats = statsJSON
+	require.NoError(t, writeLintStats(&buf, result, config))
+	var stats lint.Stats
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &stats))
+	assert.Equal(t, 3, stats.Total)
+	assert.Equal(t, []lint.Count{{Name: "Makefile", Count: 2}}, stats.ByFile)
+}
+
+func TestApplyLintBaseline(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	config := NewConfig()
+	config.MakefilePath = filepath.Join(tmpDir, "Makefile")
+	config.Baseline = filepath.Join(tmpDir, ".make-help-baseline.json")
+
+	known := lint.Warning{File: config.MakefilePath, Line: 3, CheckName: "naming", Message: "known"}
+	recorded, err := applyLintBaseline(config, &lint.LintResult{Warnings: []lint.Warning{known}, HasWarnings: true})
+	require.NoError(t, err)
+	assert.True(t, recorded)
+	assert.FileExists(t, config.Baseline)
+
+	result := &lint.LintResult{
+		Warnings:    []lint.Warning{known, {File: config.MakefilePath, Line: 9, CheckName: "naming", Message: "new"}},
+		HasWarnings: true,
+	}
+	recorded, err = applyLintBaseline(config, result)
+	require.NoError(t, err)
+	assert.False(t, recorded)
+	require.Len(t, result.Warnings, 1)
+	assert.Equal(t, "new", result.Warnings[0].Message)
+
+	require.NoError(t, os.WriteFile(config.Baseline, []byte(`{"version": 2, "warnings": []}`), 0644))
+	_, err = applyLintBaseline(config, &lint.LintResult{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported baseline version 2")
+}
+
+func TestRunLint_Plugins(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	makefilePath := filepath.Join(tmpDir, "Makefile")
+	require.NoError(t, os.WriteFile(makefilePath, []byte("## Build the project.\nbuild:\n\t@echo building\n"), 0644))
+	require.NoError(t, os.Mkdir(filepath.Join(tmpDir, "checks"), 0755))
+	plugin := "#!/bin/sh\ncat > /dev/null\necho '{\"warnings\": [{\"line\": 2, \"message\": \"target build does not reference a ticket\"}]}'\n"
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "checks", "make-help-check-ticket"), []byte(plugin), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, ".make-help.json"), []byte(`{"lint": {"plugins": ["checks/make-help-check-ticket"]}}`), 0644))
+
+	config := NewConfig()
+	config.MakefilePath = makefilePath
+	config.Lint = true
+	result, _, err := runLintChecks(config)
+	require.NoError(t, err)
+	var messages []string
+	for _, w := range result.Warnings {
+		if w.CheckName == "ticket" {
+			messages = append(messages, w.Message)
+		}
+	}
+	assert.Equal(t, []string{"target build does not reference a ticket"}, messages)
+
+	config = NewConfig()
+	config.MakefilePath = makefilePath
+	config.Lint = true
+	config.NoPlugins = true
+	result, _, err = runLintChecks(config)
+	require.NoError(t, err)
+	for _, w := range result.Warnings {
+		assert.NotEqual(t, "ticket", w.CheckName)
+	}
+}
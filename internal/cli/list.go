@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"bufio"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// --list scopes.
+const (
+	listDocumented = "documented"
+	listAll        = "all"
+	listPhony      = "phony"
+)
+
+// listScopes lists the valid --list scopes.
+var listScopes = []string{listDocumented, listAll, listPhony}
+
+// runList prints target names one per line for scripts, without rendering
+// help. Documented targets are listed in help order; all and phony targets
+// in name order. With --list-columns, each name is followed by its
+// category and summary, tab-separated (empty for undocumented targets).
+func runList(config *Config, w io.Writer) error {
+	inputs, err := loadModelInputs(config)
+	if err != nil {
+		return err
+	}
+	helpModel, err := buildHelpModelFromInputs(config, inputs)
+	if err != nil {
+		return err
+	}
+
+	var names []string
+	documented := make(map[string]listEntry)
+	for _, category := range helpModel.Categories {
+		for _, target := range category.Targets {
+			names = append(names, target.Name)
+			documented[target.Name] = listEntry{category: category.Name, summary: strings.Join(target.Summary, " ")}
+		}
+	}
+
+	if config.List != listDocumented {
+		// make lists the Makefiles as targets because it can remake them
+		makefiles := make(map[string]bool)
+		for _, pf := range inputs.ParsedFiles {
+			makefiles[pf.Path] = true
+		}
+		makefileDir := filepath.Dir(inputs.MakefilePath)
+
+		names = nil
+		for _, name := range inputs.Targets.Targets {
+			// Skip special targets (.PHONY), pattern rules, and Makefiles
+			if strings.HasPrefix(name, ".") || strings.Contains(name, "%") ||
+				makefiles[name] || makefiles[filepath.Join(makefileDir, name)] {
+				continue
+			}
+			if config.List == listPhony && !inputs.Targets.IsPhony[name] {
+				continue
+			}
+			if !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+		slices.Sort(names)
+	}
+
+	out := bufio.NewWriter(w)
+	for _, name := range names {
+		out.WriteString(name)
+		if config.ListColumns {
+			entry := documented[name]
+			out.WriteString("\t" + listColumn(entry.category) + "\t" + listColumn(entry.summary))
+		}
+		out.WriteString("\n")
+	}
+	return out.Flush()
+}
+
+// listEntry is the category and summary of a documented target.
+type listEntry struct {
+	category string
+	summary  string
+}
+
+// listColumn keeps a column value on one line and free of tabs, so every
+// line splits into the same number of fields.
+func listColumn(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
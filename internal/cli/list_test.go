@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunList(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Chdir(tmpDir)
+	makefilePath := filepath.Join(tmpDir, "Makefile")
+	require.NoError(t, os.WriteFile(makefilePath, []byte(`.PHONY: test build clean
+## !category Build
+## Build the
+## project.
+build: app
+
+## Run tests.
+test:
+	@echo test
+
+clean:
+	@rm -f app
+
+app:
+	@true
+
+%.o: %.c
+	@true
+`), 0644))
+
+	tests := []struct {
+		name     string
+		list     string
+		columns  bool
+		expected string
+	}{
+		{name: "documented", list: listDocumented, expected: "build\ntest\n"},
+		{name: "all", list: listAll, expected: "app\nbuild\nclean\ntest\n"},
+		{name: "phony", list: listPhony, expected: "build\nclean\ntest\n"},
+		{
+			name:     "documented with columns",
+			list:     listDocumented,
+			columns:  true,
+			expected: "build\tBuild\tBuild the project.\ntest\tBuild\tRun tests.\n",
+		},
+		{
+			name:     "phony with columns",
+			list:     listPhony,
+			columns:  true,
+			expected: "build\tBuild\tBuild the project.\nclean\t\t\ntest\tBuild\tRun tests.\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			config := NewConfig()
+			config.MakefilePath = makefilePath
+			config.List = tt.list
+			config.ListColumns = tt.columns
+
+			var buf bytes.Buffer
+			require.NoError(t, runList(config, &buf))
+			assert.Equal(t, tt.expected, buf.String())
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/sdlcforge/make-help/internal/filelock"
+	"github.com/sdlcforge/make-help/internal/journal"
+)
+
+// lockFileName is the lock file in the journal directory that make-help
+// processes modifying a project take turns holding.
+const lockFileName = "lock"
+
+// heldLockEnv names the lock file held by the make-help that started this
+// process, set for post hooks. A make-help run by a hook works under that
+// lock instead of waiting for it.
+const heldLockEnv = "MAKE_HELP_HELD_LOCK"
+
+// lockProject takes the lock of the project of makefilePath before an
+// operation modifies its files, waiting up to --lock-timeout for another
+// make-help process to finish. Call release when done.
+func lockProject(config *Config, makefilePath string) (release func(), err error) {
+	journalDir, err := journal.Dir(filepath.Dir(makefilePath))
+	if err != nil {
+		return nil, err
+	}
+	path := filepath.Join(journalDir, lockFileName)
+	if os.Getenv(heldLockEnv) == path {
+		return func() {}, nil
+	}
+
+	lock, err := filelock.Acquire(path, 0)
+	if errors.Is(err, filelock.ErrTimeout) && config.LockTimeout > 0 {
+		fmt.Fprintf(os.Stderr, "Waiting for another make-help to finish modifying %s...\n", filepath.Dir(makefilePath))
+		lock, err = filelock.Acquire(path, config.LockTimeout)
+	}
+	if errors.Is(err, filelock.ErrTimeout) {
+		return nil, fmt.Errorf("another make-help is modifying %s; gave up after %s (see --lock-timeout)",
+			filepath.Dir(makefilePath), config.LockTimeout)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	config.heldLock = path
+	return func() {
+		config.heldLock = ""
+		_ = lock.Release()
+	}, nil
+}
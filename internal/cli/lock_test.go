@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sdlcforge/make-help/internal/journal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLockProject(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	makefilePath := filepath.Join(tmpDir, "Makefile")
+
+	config := NewConfig()
+	release, err := lockProject(config, makefilePath)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(tmpDir, journal.DirName, lockFileName), config.heldLock)
+
+	other := NewConfig()
+	other.LockTimeout = 100 * time.Millisecond
+	_, err = lockProject(other, makefilePath)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "another make-help is modifying "+tmpDir)
+
+	release()
+	assert.Empty(t, config.heldLock)
+	release, err = lockProject(other, makefilePath)
+	require.NoError(t, err)
+	release()
+}
+
+func TestRunPostHooks_PassesHeldLock(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	makefilePath := filepath.Join(tmpDir, "Makefile")
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, ".make-help.json"),
+		[]byte(`{"hooks": {"post": ["printenv `+heldLockEnv+` > env.txt; true"]}}`), 0644))
+
+	config := NewConfig()
+	config.MakefilePath = makefilePath
+	release, err := lockProject(config, makefilePath)
+	require.NoError(t, err)
+	defer release()
+
+	require.NoError(t, runPostHooks(config, makefilePath))
+	env, err := os.ReadFile(filepath.Join(tmpDir, "env.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, config.heldLock+"\n", string(env))
+}
@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/sdlcforge/make-help/internal/model"
+	"github.com/sdlcforge/make-help/internal/target"
+)
+
+// modelDumpVersion identifies the layout of modelDump files.
+// loadModelDump rejects dumps written with a different version.
+const modelDumpVersion = 1
+
+// modelDump is the file written by --dump-model and read by --from-model.
+// Inputs are replayed through the builder, so flags such as --include-target
+// and --keep-order-* still apply when re-rendering. Model is the model as built
+// at dump time and is included for inspection only.
+type modelDump struct {
+	Version int              `json:"version"`
+	Inputs  *modelInputs     `json:"inputs"`
+	Model   *model.HelpModel `json:"model"`
+}
+
+// runDumpModel captures the builder inputs and resulting help model as JSON
+// in config.DumpModel ("-" for stdout).
+func runDumpModel(config *Config) error {
+	inputs, err := loadModelInputs(config)
+	if err != nil {
+		return err
+	}
+
+	helpModel, err := buildHelpModelFromInputs(config, inputs)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(modelDump{
+		Version: modelDumpVersion,
+		Inputs:  inputs,
+		Model:   helpModel,
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode model dump: %w", err)
+	}
+	data = append(data, '\n')
+
+	if config.DumpModel == "-" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+
+	if err := target.AtomicWriteFile(config.DumpModel, data, 0644); err != nil {
+		return fmt.Errorf("failed to write model dump: %w", err)
+	}
+
+	if config.Verbose {
+		fmt.Fprintf(os.Stderr, "Wrote model dump to: %s\n", config.DumpModel)
+	}
+	return nil
+}
+
+// loadModelDump reads the builder inputs from a file written by --dump-model.
+func loadModelDump(path string) (*modelInputs, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read model dump: %w", err)
+	}
+
+	var dump modelDump
+	if err := json.Unmarshal(data, &dump); err != nil {
+		return nil, fmt.Errorf("failed to parse model dump %s: %w", path, err)
+	}
+
+	if dump.Version != modelDumpVersion {
+		return nil, fmt.Errorf("unsupported model dump version %d in %s (expected %d)", dump.Version, path, modelDumpVersion)
+	}
+	if dump.Inputs == nil || dump.Inputs.Targets == nil {
+		return nil, fmt.Errorf("model dump %s has no builder inputs", path)
+	}
+
+	return dump.Inputs, nil
+}
@@ -0,0 +1,166 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const modelDumpTestMakefile = `## !category Build
+## Build the project.
+## !var GOOS - Target operating system
+build:
+	@echo build
+
+## Internal helper.
+## !hidden
+internal:
+	@echo internal
+`
+
+func TestDumpModelRoundTrip(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	makefilePath := filepath.Join(tmpDir, "Makefile")
+	require.NoError(t, os.WriteFile(makefilePath, []byte(modelDumpTestMakefile), 0644))
+	dumpPath := filepath.Join(tmpDir, "model.json")
+
+	config := NewConfig()
+	config.MakefilePath = makefilePath
+	config.DumpModel = dumpPath
+	require.NoError(t, runDumpModel(config))
+
+	dumped, err := os.ReadFile(dumpPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(dumped), `"version": 1`)
+	assert.Contains(t, string(dumped), `"makefilePath": "`+makefilePath+`"`)
+
+	render := func(config *Config) string {
+		helpModel, err := buildHelpModel(config)
+		require.NoError(t, err)
+		var buf bytes.Buffer
+		require.NoError(t, renderHelp(config, helpModel, &buf))
+		return buf.String()
+	}
+
+	live := NewConfig()
+	live.MakefilePath = makefilePath
+	live.Format = "html"
+	expected := render(live)
+
+	// Rendering from the dump must not need the Makefile
+	require.NoError(t, os.Remove(makefilePath))
+
+	replay := NewConfig()
+	replay.FromModel = dumpPath
+	replay.Format = "html"
+	assert.Equal(t, expected, render(replay))
+	assert.Equal(t, makefilePath, replay.MakefilePath)
+}
+
+func TestLoadModelDumpErrors(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		content   string
+		errorText string
+	}{
+		{
+			name:      "invalid json",
+			content:   "{",
+			errorText: "failed to parse model dump",
+		},
+		{
+			name:      "unsupported version",
+			content:   `{"version": 99, "inputs": {"targets": {}}}`,
+			errorText: "unsupported model dump version 99",
+		},
+		{
+			name:      "missing inputs",
+			content:   `{"version": 1}`,
+			errorText: "has no builder inputs",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			path := filepath.Join(t.TempDir(), "model.json")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0644))
+
+			_, err := loadModelDump(path)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorText)
+		})
+	}
+
+	_, err := loadModelDump(filepath.Join(t.TempDir(), "missing.json"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to read model dump")
+}
+
+func TestModelDumpFlagValidation(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		args      []string
+		errorText string
+	}{
+		{
+			name:      "dump-model with lint",
+			args:      []string{"--dump-model", "model.json", "--lint"},
+			errorText: "--dump-model cannot be used with --lint",
+		},
+		{
+			name:      "dump-model with target",
+			args:      []string{"--dump-model", "model.json", "--target", "build"},
+			errorText: "--dump-model cannot be used with --target",
+		},
+		{
+			name:      "dump-model with output",
+			args:      []string{"--dump-model", "model.json", "--output", "-"},
+			errorText: "--dump-model cannot be used with --output",
+		},
+		{
+			name:      "dump-model with remove-help",
+			args:      []string{"--dump-model", "model.json", "--remove-help"},
+			errorText: "--remove-help cannot be used with --dump-model",
+		},
+		{
+			name:      "from-model with makefile-path",
+			args:      []string{"--from-model", "model.json", "--makefile-path", "Makefile"},
+			errorText: "--from-model cannot be used with --makefile-path",
+		},
+		{
+			name:      "from-model with lint",
+			args:      []string{"--from-model", "model.json", "--lint"},
+			errorText: "--from-model cannot be used with --lint",
+		},
+		{
+			name:      "from-model generating help file",
+			args:      []string{"--from-model", "model.json"},
+			errorText: "--from-model cannot generate a help target file",
+		},
+		{
+			name:      "from-model with missing dump",
+			args:      []string{"--from-model", "/nonexistent/model.json", "--output", "-"},
+			errorText: "failed to read model dump",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cmd := NewRootCmd()
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorText)
+		})
+	}
+}
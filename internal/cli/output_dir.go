@@ -0,0 +1,139 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/sdlcforge/make-help/internal/format"
+	"github.com/sdlcforge/make-help/internal/model"
+	"github.com/sdlcforge/make-help/internal/target"
+)
+
+// outputDirBaseName is the file name, before the format extension, of each
+// file written by --output-dir.
+const outputDirBaseName = "help"
+
+// rendersFormat reports whether the invocation renders the given (normalized)
+// format: the --format value, any entry of the list used with --output-dir,
+// or any format for --daemon, whose requests choose their own.
+func rendersFormat(config *Config, formatName string) bool {
+	if config.Daemon != "" {
+		return true
+	}
+	if len(config.OutputFormats) > 0 {
+		return slices.Contains(config.OutputFormats, formatName)
+	}
+	return config.Format == formatName
+}
+
+// isMachineReadable reports whether a format gets the model with hidden
+// targets (see buildHelpModelFromInputs).
+func isMachineReadable(formatName string) bool {
+	return formatName == "json" || formatName == "ndjson"
+}
+
+// runOutputDir renders help in every requested format into config.OutputDir.
+// Discovery and parsing run once; each model is then shared read-only by the
+// formatters, which render concurrently. Files are written only after every
+// format rendered successfully.
+func runOutputDir(config *Config) error {
+	inputs, err := loadModelInputs(config)
+	if err != nil {
+		return err
+	}
+
+	// Machine-readable formats keep hidden targets, so at most two models are
+	// built: one for them and one for everything else.
+	models := make(map[bool]*model.HelpModel)
+	for _, formatName := range config.OutputFormats {
+		machineReadable := isMachineReadable(formatName)
+		if _, ok := models[machineReadable]; ok {
+			continue
+		}
+		modelConfig := *config
+		modelConfig.Format = formatName
+		helpModel, err := buildHelpModelFromInputs(&modelConfig, inputs)
+		if err != nil {
+			return err
+		}
+		models[machineReadable] = helpModel
+	}
+
+	rendered := make([][]outputFile, len(config.OutputFormats))
+	errs := make([]error, len(config.OutputFormats))
+	var wg sync.WaitGroup
+	for i, formatName := range config.OutputFormats {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			formatConfig := *config
+			formatConfig.Format = formatName
+			files, err := renderOutputFiles(&formatConfig, models[isMachineReadable(formatName)])
+			if err != nil {
+				errs[i] = fmt.Errorf("%s: %w", formatName, err)
+			}
+			rendered[i] = files
+		}()
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(config.OutputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", config.OutputDir, err)
+	}
+	var written []string
+	for i, formatName := range config.OutputFormats {
+		for _, file := range rendered[i] {
+			path := filepath.Join(config.OutputDir, file.name)
+			if err := target.AtomicWriteFile(path, file.content.Bytes(), 0644); err != nil {
+				return fmt.Errorf("failed to write %s: %w", path, err)
+			}
+			fmt.Printf("Successfully wrote %s help to: %s\n", formatName, path)
+			written = append(written, path)
+		}
+	}
+
+	return runPostHooks(config, written...)
+}
+
+// outputFile is a file rendered for --output-dir.
+type outputFile struct {
+	name    string
+	content bytes.Buffer
+}
+
+// renderOutputFiles renders the help model in config.Format: one
+// help.<ext> file, or with --split-by category an index.<ext> file and one
+// file per category.
+func renderOutputFiles(config *Config, helpModel *model.HelpModel) ([]outputFile, error) {
+	formatter, err := format.NewFormatter(config.Format, nil)
+	if err != nil {
+		return nil, err
+	}
+	ext := formatter.DefaultExtension()
+
+	if config.SplitBy == "" {
+		files := []outputFile{{name: outputDirBaseName + ext}}
+		if err := renderHelp(config, helpModel, &files[0].content); err != nil {
+			return nil, err
+		}
+		return files, nil
+	}
+
+	pages := format.SplitByCategory(helpModel, ext)
+	files := make([]outputFile, len(pages))
+	for i := range pages {
+		files[i].name = pages[i].Name + ext
+		if err := renderHelpPage(config, helpModel, &pages[i], &files[i].content); err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
@@ -0,0 +1,146 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunOutputDir(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	makefilePath := filepath.Join(tmpDir, "Makefile")
+	makefile := "## !category Build\n## Build the project.\nbuild:\n\t@echo build\n\n" +
+		"## !hidden\n## Internal step.\nsync:\n\t@echo sync\n"
+	require.NoError(t, os.WriteFile(makefilePath, []byte(makefile), 0644))
+	outputDir := filepath.Join(tmpDir, "build", "help")
+
+	config := NewConfig()
+	config.MakefilePath = makefilePath
+	config.OutputDir = outputDir
+	config.OutputFormats = []string{"text", "json", "html"}
+	require.NoError(t, runOutputDir(config))
+
+	text, err := os.ReadFile(filepath.Join(outputDir, "help.txt"))
+	require.NoError(t, err)
+	assert.Contains(t, string(text), "build: Build the project.")
+	assert.NotContains(t, string(text), "sync", "hidden targets stay out of text help")
+
+	jsonOutput, err := os.ReadFile(filepath.Join(outputDir, "help.json"))
+	require.NoError(t, err)
+	assert.Contains(t, string(jsonOutput), `"name": "sync"`, "JSON keeps hidden targets, as with --format json")
+
+	assert.FileExists(t, filepath.Join(outputDir, "help.html"))
+}
+
+func TestRunOutputDir_SplitByCategory(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	makefilePath := filepath.Join(tmpDir, "Makefile")
+	makefile := "## !category Build\n## Build the project.\nbuild:\n\t@echo build\n\n" +
+		"## !category Test\n## Run the tests.\ntest:\n\t@echo test\n"
+	require.NoError(t, os.WriteFile(makefilePath, []byte(makefile), 0644))
+	outputDir := filepath.Join(tmpDir, "docs")
+
+	config := NewConfig()
+	config.MakefilePath = makefilePath
+	config.OutputDir = outputDir
+	config.OutputFormats = []string{"markdown", "html"}
+	config.SplitBy = "category"
+	require.NoError(t, runOutputDir(config))
+
+	index, err := os.ReadFile(filepath.Join(outputDir, "index.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(index), "- [Build](build.md) (1 target)")
+	assert.NotContains(t, string(index), "Build the project.", "targets are on the category pages")
+
+	build, err := os.ReadFile(filepath.Join(outputDir, "build.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(build), "Build the project.")
+	assert.Contains(t, string(build), "[Test →](test.md)")
+
+	for _, name := range []string{"index.html", "build.html", "test.html", "test.md"} {
+		assert.FileExists(t, filepath.Join(outputDir, name))
+	}
+	assert.NoFileExists(t, filepath.Join(outputDir, "help.md"))
+}
+
+func TestOutputDirFlagValidation(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		args      []string
+		errorText string
+	}{
+		{
+			name:      "multiple formats without output-dir",
+			args:      []string{"--format", "text,json", "--output", "-"},
+			errorText: "multiple formats require --output-dir",
+		},
+		{
+			name:      "invalid format in list",
+			args:      []string{"--format", "text,yaml", "--output-dir", "out"},
+			errorText: "invalid format: yaml",
+		},
+		{
+			name:      "output-dir with output",
+			args:      []string{"--format", "text", "--output-dir", "out", "--output", "help.txt"},
+			errorText: "--output-dir cannot be used with --output",
+		},
+		{
+			name:      "output-dir with lint",
+			args:      []string{"--lint", "--output-dir", "out"},
+			errorText: "--output-dir cannot be used with --lint",
+		},
+		{
+			name:      "toc without markdown in list",
+			args:      []string{"--format", "text,json", "--output-dir", "out", "--toc"},
+			errorText: "--toc requires --format markdown",
+		},
+		{
+			name:      "toc with markdown in list",
+			args:      []string{"--format", "text,md", "--output-dir", "out", "--toc", "--makefile-path", "/nonexistent/Makefile"},
+			errorText: "Makefile not found",
+		},
+		{
+			name:      "invalid split mode",
+			args:      []string{"--format", "markdown", "--output-dir", "out", "--split-by", "target"},
+			errorText: "invalid split mode: target (valid: category)",
+		},
+		{
+			name:      "split-by without output-dir",
+			args:      []string{"--format", "markdown", "--output", "-", "--split-by", "category"},
+			errorText: "--split-by requires --output-dir",
+		},
+		{
+			name:      "split-by with text in list",
+			args:      []string{"--format", "markdown,text", "--output-dir", "out", "--split-by", "category"},
+			errorText: "--split-by requires --format markdown or html (got text)",
+		},
+		{
+			name:      "split-by with page-size",
+			args:      []string{"--format", "html", "--output-dir", "out", "--split-by", "category", "--page-size", "10"},
+			errorText: "cannot use both --split-by and --page-size flags",
+		},
+		{
+			name:      "remove-help with output-dir",
+			args:      []string{"--remove-help", "--output-dir", "out"},
+			errorText: "--remove-help cannot be used with --output-dir",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cmd := NewRootCmd()
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorText)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// runPostHooks runs the hooks.post commands from .make-help.json after files
+// were written. Each command runs through sh in the Makefile directory with
+// the absolute paths of the written files appended as arguments. The first
+// failing command stops the rest. Nothing runs with --no-hooks. Nor does
+// anything run with --no-exec, --sandbox, or --scrub-env: the commands come
+// from the project, and would run outside the sandbox with the full
+// environment.
+func runPostHooks(config *Config, files ...string) error {
+	if config.NoHooks || config.NoExec || config.Sandbox || config.ScrubEnv || len(files) == 0 {
+		return nil
+	}
+
+	projectConfig, err := loadProjectConfig(config.MakefilePath)
+	if err != nil {
+		return err
+	}
+	if len(projectConfig.Hooks.Post) == 0 {
+		return nil
+	}
+
+	args := make([]string, 0, len(files)+1)
+	args = append(args, "make-help")
+	for _, file := range files {
+		if abs, err := filepath.Abs(file); err == nil {
+			file = abs
+		}
+		args = append(args, file)
+	}
+
+	for _, hook := range projectConfig.Hooks.Post {
+		if config.Verbose {
+			fmt.Fprintf(os.Stderr, "Running post hook: %s\n", hook)
+		}
+		// "$@" expands to the written files, so "git add" becomes "git add <files>"
+		command := exec.Command("sh", append([]string{"-c", hook + ` "$@"`}, args...)...)
+		command.Dir = filepath.Dir(config.MakefilePath)
+		if config.heldLock != "" {
+			command.Env = append(os.Environ(), heldLockEnv+"="+config.heldLock)
+		}
+		command.Stdout = os.Stdout
+		command.Stderr = os.Stderr
+		if err := command.Run(); err != nil {
+			return fmt.Errorf("post hook %q failed: %w", hook, err)
+		}
+	}
+
+	return nil
+}
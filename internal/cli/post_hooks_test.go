@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunPostHooks(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	makefilePath := filepath.Join(tmpDir, "Makefile")
+	require.NoError(t, os.WriteFile(makefilePath, []byte(injectTestMakefile), 0644))
+	projectConfig := `{"hooks": {"post": ["printf '%s\\n' >> hooks.log", "echo second >> hooks.log"]}}`
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, ".make-help.json"), []byte(projectConfig), 0644))
+	docPath := filepath.Join(tmpDir, "docs", "help.md")
+
+	config := NewConfig()
+	config.MakefilePath = makefilePath
+
+	// Hooks run in the Makefile directory with the written files appended
+	require.NoError(t, runPostHooks(config, docPath))
+	content, err := os.ReadFile(filepath.Join(tmpDir, "hooks.log"))
+	require.NoError(t, err)
+	assert.Equal(t, docPath+"\nsecond "+docPath+"\n", string(content))
+
+	// --no-hooks skips them, and so do the modes for untrusted projects
+	skips := map[string]func(*Config){
+		"no-hooks":  func(c *Config) { c.NoHooks = true },
+		"no-exec":   func(c *Config) { c.NoExec = true },
+		"sandbox":   func(c *Config) { c.Sandbox = true },
+		"scrub-env": func(c *Config) { c.ScrubEnv = true },
+	}
+	for name, set := range skips {
+		config := NewConfig()
+		config.MakefilePath = makefilePath
+		set(config)
+		require.NoError(t, runPostHooks(config, docPath))
+		content, err = os.ReadFile(filepath.Join(tmpDir, "hooks.log"))
+		require.NoError(t, err)
+		assert.Equal(t, docPath+"\nsecond "+docPath+"\n", string(content), name)
+	}
+}
+
+func TestRunPostHooks_Failure(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	makefilePath := filepath.Join(tmpDir, "Makefile")
+	require.NoError(t, os.WriteFile(makefilePath, []byte(injectTestMakefile), 0644))
+	projectConfig := `{"hooks": {"post": ["false", "touch ran"]}}`
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, ".make-help.json"), []byte(projectConfig), 0644))
+
+	config := NewConfig()
+	config.MakefilePath = makefilePath
+
+	err := runPostHooks(config, filepath.Join(tmpDir, "help.md"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `post hook "false" failed`)
+	assert.NoFileExists(t, filepath.Join(tmpDir, "ran"))
+}
@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// postProcess pipes help rendered in config.Format through the
+// --post-process command and returns what it prints. The command runs
+// through sh in the Makefile directory, with MAKE_HELP_FORMAT and
+// MAKE_HELP_MAKEFILE set so one command can handle several formats. Its
+// stderr is passed through; a failing command fails the run.
+func postProcess(config *Config, rendered []byte) ([]byte, error) {
+	if config.Verbose {
+		fmt.Fprintf(os.Stderr, "Post-processing %s output: %s\n", config.Format, config.PostProcess)
+	}
+	var stdout bytes.Buffer
+	command := exec.Command("sh", "-c", config.PostProcess)
+	command.Dir = filepath.Dir(config.MakefilePath)
+	command.Env = append(os.Environ(),
+		"MAKE_HELP_FORMAT="+config.Format,
+		"MAKE_HELP_MAKEFILE="+config.MakefilePath,
+	)
+	command.Stdin = bytes.NewReader(rendered)
+	command.Stdout = &stdout
+	command.Stderr = os.Stderr
+	if err := command.Run(); err != nil {
+		return nil, fmt.Errorf("post-process command %q failed: %w", config.PostProcess, err)
+	}
+	return stdout.Bytes(), nil
+}
+
+// rendersHelpOutput reports whether the configured mode writes help rendered
+// by renderHelp: to stdout or --output, into an --inject document, into
+// --output-dir files, or in --daemon replies. Generated help files and other modes do not.
+func rendersHelpOutput(config *Config) bool {
+	otherMode := config.ShellInit != "" || config.Undo || config.Clean || config.Lint ||
+		config.RemoveHelpTarget || config.Hook != "" || config.DumpModel != "" ||
+		config.Snapshot != "" || config.RenderFixture || config.AddFragment != "" ||
+		config.Graph != "" || config.Analyze || config.Export != "" || config.List != "" ||
+		config.Categories || config.Vars || config.ValidateOnly || config.RunTarget != "" ||
+		config.Preview != "" || config.Target != ""
+	if otherMode {
+		return false
+	}
+	return config.Daemon != "" || config.OutputDir != "" || config.InjectFile != "" || config.Output == "-" || config.Format != "make"
+}
@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunHelp_PostProcess(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	makefilePath := filepath.Join(tmpDir, "Makefile")
+	require.NoError(t, os.WriteFile(makefilePath, []byte(injectTestMakefile), 0644))
+	outputPath := filepath.Join(tmpDir, "help.md")
+
+	config := NewConfig()
+	config.MakefilePath = makefilePath
+	config.Format = "markdown"
+	config.MDLayout = "list"
+	config.Output = outputPath
+	config.NoHooks = true
+	config.PostProcess = `printf '<!-- %s -->\n' "$MAKE_HELP_FORMAT"; sed 's/Build the project/Build the ACME project/'; pwd > cwd.txt`
+
+	require.NoError(t, runHelp(config))
+
+	content, err := os.ReadFile(outputPath)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(content), "<!-- markdown -->\n"), string(content))
+	assert.Contains(t, string(content), "Build the ACME project")
+
+	// The command runs in the Makefile directory
+	cwd, err := os.ReadFile(filepath.Join(tmpDir, "cwd.txt"))
+	require.NoError(t, err)
+	resolved, err := filepath.EvalSymlinks(tmpDir)
+	require.NoError(t, err)
+	assert.Equal(t, resolved, strings.TrimSpace(string(cwd)))
+}
+
+func TestRunInject_PostProcess(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	makefilePath := filepath.Join(tmpDir, "Makefile")
+	require.NoError(t, os.WriteFile(makefilePath, []byte(injectTestMakefile), 0644))
+	readmePath := filepath.Join(tmpDir, "README.md")
+	require.NoError(t, os.WriteFile(readmePath, []byte("# Project\n"), 0644))
+
+	config := NewConfig()
+	config.MakefilePath = makefilePath
+	config.Format = "markdown"
+	config.MDLayout = "list"
+	config.InjectFile = readmePath
+	config.NoHooks = true
+	config.PostProcess = "tr a-z A-Z"
+	require.NoError(t, runInject(config))
+
+	// --check compares the post-processed section
+	config.Check = true
+	require.NoError(t, runInject(config))
+	content, err := os.ReadFile(readmePath)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "BUILD THE PROJECT.")
+}
+
+func TestRunHelp_PostProcessFailure(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	makefilePath := filepath.Join(tmpDir, "Makefile")
+	require.NoError(t, os.WriteFile(makefilePath, []byte(injectTestMakefile), 0644))
+	outputPath := filepath.Join(tmpDir, "help.json")
+
+	config := NewConfig()
+	config.MakefilePath = makefilePath
+	config.Format = "json"
+	config.Output = outputPath
+	config.PostProcess = "cat > /dev/null; exit 3"
+
+	err := runHelp(config)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `post-process command "cat > /dev/null; exit 3" failed`)
+	assert.NoFileExists(t, outputPath)
+}
@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/sdlcforge/make-help/internal/model"
+)
+
+// makeMessage matches make's own status lines, such as
+// "make: Nothing to be done for 'build'.", in make -n output.
+var makeMessage = regexp.MustCompile(`^g?make(\[\d+\])?: `)
+
+// runPreview shows what running a documented target would do: its
+// documentation and variables, followed by the commands make -n prints.
+// args holds VAR=value assignments, passed to make as with --run.
+func runPreview(config *Config, args []string) error {
+	assignments, err := parseRunAssignments(args)
+	if err != nil {
+		return err
+	}
+
+	inputs, err := loadModelInputs(config)
+	if err != nil {
+		return err
+	}
+
+	helpModel, err := buildHelpModelFromInputs(config, inputs)
+	if err != nil {
+		return err
+	}
+
+	found, err := findDocumentedTarget(helpModel, inputs, config.Preview)
+	if err != nil {
+		return err
+	}
+
+	if err := validateRunChoices(found.Variables, assignments); err != nil {
+		return err
+	}
+
+	targetArgs := runMakeArgs(found, assignments, args)
+	makeArgs := append([]string{"-n", "--no-print-directory", "-f", config.MakefilePath}, targetArgs...)
+	if config.Verbose {
+		fmt.Fprintf(os.Stderr, "Running: make %s\n", strings.Join(makeArgs, " "))
+	}
+
+	var stdout bytes.Buffer
+	command, err := newMakeExecutor(config).Command(config.runContext(), "make", makeArgs...)
+	if err != nil {
+		return err
+	}
+	command.Dir = filepath.Dir(config.MakefilePath)
+	command.Stdout = &stdout
+	command.Stderr = os.Stderr
+	if err := command.Run(); err != nil {
+		return fmt.Errorf("make -n %s failed: %w", found.Name, err)
+	}
+
+	return writePreview(os.Stdout, found, assignments, targetArgs, previewCommands(stdout.String()))
+}
+
+// previewCommands returns the recipe lines in make -n output, dropping
+// make's status messages and blank lines.
+func previewCommands(output string) []string {
+	var commands []string
+	for _, line := range strings.Split(output, "\n") {
+		if strings.TrimSpace(line) == "" || makeMessage.MatchString(line) {
+			continue
+		}
+		commands = append(commands, line)
+	}
+	return commands
+}
+
+// writePreview writes the target's documentation and variables, a warning
+// for unset required variables, and the commands make would run.
+func writePreview(w io.Writer, target *model.Target, assignments map[string]string, targetArgs []string, commands []string) error {
+	var sb strings.Builder
+	sb.WriteString(target.Name + "\n")
+	for _, line := range target.Documentation {
+		if strings.TrimSpace(line) != "" {
+			fmt.Fprintf(&sb, "  %s\n", strings.TrimSpace(line))
+		}
+	}
+
+	if target.Dangerous {
+		fmt.Fprintf(&sb, "\nDanger: %s (--run asks for confirmation)\n", dangerReason(target))
+	}
+
+	if len(target.Variables) > 0 {
+		sb.WriteString("\nVariables:\n")
+		printRunVariables(&sb, target.Variables, assignments)
+	}
+	if missing := missingRequiredVariables(target.Variables, assignments); len(missing) > 0 {
+		fmt.Fprintf(&sb, "\nwarning: missing required variable(s): %s; --run would stop here\n", strings.Join(missing, ", "))
+	}
+
+	fmt.Fprintf(&sb, "\nCommands (make -n %s):\n", strings.Join(targetArgs, " "))
+	if len(commands) == 0 {
+		sb.WriteString("  (nothing to do)\n")
+	}
+	for _, command := range commands {
+		fmt.Fprintf(&sb, "  %s\n", command)
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
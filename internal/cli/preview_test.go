@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sdlcforge/make-help/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunPreview(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Chdir(tmpDir)
+	makefilePath := filepath.Join(tmpDir, "Makefile")
+	makefile := `noop:
+	@true
+
+## Deploy the app.
+## !var ENV (choices: dev,prod) - Target environment
+deploy:
+	@touch deployed.txt
+
+undocumented:
+	@true
+`
+	require.NoError(t, os.WriteFile(makefilePath, []byte(makefile), 0644))
+
+	config := NewConfig()
+	config.MakefilePath = makefilePath
+	config.Preview = "deploy"
+	require.NoError(t, runPreview(config, []string{"ENV=prod"}))
+	assert.NoFileExists(t, filepath.Join(tmpDir, "deployed.txt"), "preview must not run the recipe")
+
+	err := runPreview(config, []string{"ENV=staging"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid value "staging" for ENV`)
+
+	config.Preview = "undocumented"
+	err = runPreview(config, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "target 'undocumented' is not documented")
+}
+
+func TestPreviewCommands(t *testing.T) {
+	t.Parallel()
+	output := "go build ./...\n\nmake: Nothing to be done for 'x'.\nmake[1]: 'y' is up to date.\ndocker push app\n"
+	assert.Equal(t, []string{"go build ./...", "docker push app"}, previewCommands(output))
+	assert.Empty(t, previewCommands("make: 'build' is up to date.\n"))
+}
+
+func TestWritePreview(t *testing.T) {
+	t.Parallel()
+	target := &model.Target{
+		Name:          "deploy",
+		Documentation: []string{"Deploy the app.", "", "Pushes images first."},
+		Variables: []model.Variable{
+			{Name: "MAKE_HELP_TEST_PREVIEW_ENV", Description: "Target environment"},
+			{Name: "MAKE_HELP_TEST_PREVIEW_TOKEN", Required: true},
+		},
+	}
+	assignments := map[string]string{"MAKE_HELP_TEST_PREVIEW_ENV": "prod"}
+
+	var out bytes.Buffer
+	require.NoError(t, writePreview(&out, target, assignments,
+		[]string{"deploy", "MAKE_HELP_TEST_PREVIEW_ENV=prod"}, []string{"docker push app"}))
+
+	expected := `deploy
+  Deploy the app.
+  Pushes images first.
+
+Variables:
+  MAKE_HELP_TEST_PREVIEW_ENV = prod - Target environment
+  MAKE_HELP_TEST_PREVIEW_TOKEN unset (required)
+
+warning: missing required variable(s): MAKE_HELP_TEST_PREVIEW_TOKEN; --run would stop here
+
+Commands (make -n deploy MAKE_HELP_TEST_PREVIEW_ENV=prod):
+  docker push app
+`
+	assert.Equal(t, expected, out.String())
+}
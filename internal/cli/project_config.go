@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sdlcforge/make-help/internal/format"
+	"github.com/sdlcforge/make-help/internal/projectconfig"
+	"github.com/sdlcforge/make-help/internal/redact"
+	"github.com/sdlcforge/make-help/internal/version"
+)
+
+// loadProjectConfig reads the project config (.make-help.json and
+// .makehelpignore) from the directory of the given Makefile.
+func loadProjectConfig(makefilePath string) (*projectconfig.Config, error) {
+	return projectconfig.Load(filepath.Dir(makefilePath))
+}
+
+// newRedactor creates the secret redactor for the built-in patterns plus the
+// --redact-pattern flags and redact.patterns from .make-help.json.
+// Returns nil (no redaction) when --no-redact is set.
+func newRedactor(config *Config, projectConfig *projectconfig.Config) (*redact.Redactor, error) {
+	if config.NoRedact {
+		return nil, nil
+	}
+	return redact.New(slices.Concat(config.RedactPatterns, projectConfig.Redact.Patterns))
+}
+
+// validateRawHTMLMode checks an --html-raw (or html.rawHTML) value.
+// Empty means the default.
+func validateRawHTMLMode(mode string) error {
+	switch mode {
+	case "", format.RawHTMLEscape, format.RawHTMLStrip, format.RawHTMLAllow:
+		return nil
+	default:
+		return fmt.Errorf("invalid raw HTML mode: %s (valid: escape, strip, allow)", mode)
+	}
+}
+
+// resolveHTMLPolicy merges the --html-* flags with the html settings from
+// .make-help.json; flags take precedence.
+func resolveHTMLPolicy(config *Config) (format.HTMLPolicy, error) {
+	projectConfig, err := loadProjectConfig(config.MakefilePath)
+	if err != nil {
+		return format.HTMLPolicy{}, err
+	}
+	settings := projectConfig.HTML
+	if err := validateRawHTMLMode(settings.RawHTML); err != nil {
+		return format.HTMLPolicy{}, fmt.Errorf("%s: %w", projectconfig.FileName, err)
+	}
+
+	policy := format.HTMLPolicy{
+		RawHTML:         cmp.Or(config.HTMLRaw, settings.RawHTML),
+		LinkRel:         cmp.Or(config.HTMLLinkRel, settings.LinkRel),
+		LinkTargetBlank: config.HTMLLinkTargetBlank || settings.LinkTargetBlank,
+		Nonce:           cmp.Or(config.HTMLNonce, settings.Nonce),
+	}
+	return policy, nil
+}
+
+// resolveProvenance returns the generation footer for Markdown and HTML
+// output, or nil when neither --provenance nor provenance.footer in
+// .make-help.json asks for it (or --no-provenance is set).
+func resolveProvenance(config *Config) (*format.Provenance, error) {
+	if config.NoProvenance {
+		return nil, nil
+	}
+	projectConfig, err := loadProjectConfig(config.MakefilePath)
+	if err != nil {
+		return nil, err
+	}
+	settings := projectConfig.Provenance
+	if !config.Provenance && !settings.Footer {
+		return nil, nil
+	}
+
+	provenance := &format.Provenance{Version: version.Version}
+	if settings.Commit == nil || *settings.Commit {
+		provenance.Commit = sourceCommit(filepath.Dir(config.MakefilePath))
+	}
+	if settings.Timestamp == nil || *settings.Timestamp {
+		provenance.Time = generationTime()
+	}
+	return provenance, nil
+}
+
+// sourceCommit returns the abbreviated HEAD commit of the git repository
+// containing dir, or "" outside a repository.
+func sourceCommit(dir string) string {
+	command := exec.Command("git", "rev-parse", "--short", "HEAD")
+	command.Dir = dir
+	out, err := command.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// generationTime returns the current time, or SOURCE_DATE_EPOCH when set so
+// reproducible builds render identical footers.
+func generationTime() time.Time {
+	if epoch := os.Getenv("SOURCE_DATE_EPOCH"); epoch != "" {
+		if seconds, err := strconv.ParseInt(epoch, 10, 64); err == nil {
+			return time.Unix(seconds, 0).UTC()
+		}
+	}
+	return time.Now().UTC()
+}
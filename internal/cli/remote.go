@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/sdlcforge/make-help/internal/parser"
+	"github.com/sdlcforge/make-help/internal/projectconfig"
+	"github.com/sdlcforge/make-help/internal/remote"
+)
+
+// resolveRemoteIncludes fetches and parses the files named by include lines
+// annotated with !source, so their documentation appears in help output.
+// Includes whose file was already discovered (e.g. it was downloaded before
+// make-help ran) or is matched by .makehelpignore are skipped. Fetched files
+// are scanned for further annotated includes.
+func resolveRemoteIncludes(config *Config, makefilePath string, makefiles []string, parsedFiles []*parser.ParsedFile,
+	ignore *projectconfig.Ignore, fetcher *remote.Fetcher) ([]*parser.ParsedFile, error) {
+	baseDir := filepath.Dir(makefilePath)
+	seen := slices.Clone(makefiles)
+	scanner := parser.NewScanner()
+
+	// parsedFiles grows as remote files are added, so nested includes are resolved too
+	for i := 0; i < len(parsedFiles); i++ {
+		for _, include := range parsedFiles[i].RemoteIncludes {
+			path := include.Path
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(baseDir, path)
+			}
+			if slices.Contains(seen, path) {
+				continue
+			}
+			seen = append(seen, path)
+			if rel, err := filepath.Rel(baseDir, path); err == nil && ignore.MatchFile(rel) {
+				continue
+			}
+
+			data, err := fetcher.Fetch(config.runContext(), include.URL, include.Checksum)
+			if err != nil {
+				return nil, fmt.Errorf("%s:%d: %w", parsedFiles[i].Path, include.LineNumber, err)
+			}
+			if config.Verbose {
+				fmt.Fprintf(os.Stderr, "Fetched %s for %s\n", include.URL, include.Path)
+			}
+
+			parsed, err := scanner.ScanContent(string(data), path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse %s: %w", include.URL, err)
+			}
+			parsedFiles = append(parsedFiles, parsed)
+		}
+	}
+
+	return parsedFiles, nil
+}
@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sdlcforge/make-help/internal/parser"
+	"github.com/sdlcforge/make-help/internal/remote"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveRemoteIncludes(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/docker.mk":
+			w.Write([]byte("## !source " + "https://" + r.Host + "/nested.mk\ninclude nested.mk\n\n## Build the image.\ndocker-build:\n"))
+		case "/nested.mk":
+			w.Write([]byte("## Push the image.\ndocker-push:\n"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	tmpDir := t.TempDir()
+	makefilePath := filepath.Join(tmpDir, "Makefile")
+	localPath := filepath.Join(tmpDir, "local.mk")
+	content := "## !source " + server.URL + "/docker.mk\n-include mk/docker.mk\n" +
+		"## !source " + server.URL + "/missing.mk\ninclude local.mk\n"
+	require.NoError(t, os.WriteFile(makefilePath, []byte(content), 0644))
+
+	main, err := parser.NewScanner().ScanContent(content, makefilePath)
+	require.NoError(t, err)
+
+	fetcher := &remote.Fetcher{Client: server.Client()}
+	config := NewConfig()
+	parsedFiles, err := resolveRemoteIncludes(config, makefilePath, []string{makefilePath, localPath},
+		[]*parser.ParsedFile{main}, nil, fetcher)
+	require.NoError(t, err)
+
+	// local.mk was discovered locally, so its source is never fetched
+	require.Len(t, parsedFiles, 3)
+	assert.Equal(t, filepath.Join(tmpDir, "mk", "docker.mk"), parsedFiles[1].Path)
+	assert.Contains(t, parsedFiles[1].TargetMap, "docker-build")
+	assert.Equal(t, filepath.Join(tmpDir, "nested.mk"), parsedFiles[2].Path)
+	assert.Contains(t, parsedFiles[2].TargetMap, "docker-push")
+}
@@ -26,14 +26,32 @@ func runRemoveHelpTarget(config *Config) error {
 		fmt.Fprintf(os.Stderr, "Using Makefile: %s\n", makefilePath)
 	}
 
+	// Keep other make-help processes from modifying the project meanwhile
+	release, err := lockProject(config, makefilePath)
+	if err != nil {
+		return err
+	}
+	defer release()
+
 	// 2. Create remove service and execute
-	executor := discovery.NewDefaultExecutor()
+	warnShellExpressions(config, makefilePath)
+	executor := newMakeExecutor(config)
 	removeConfig := &target.Config{
 		MakefilePath: makefilePath,
 	}
 	removeService := target.NewRemoveService(removeConfig, executor, config.Verbose)
 
-	if err := removeService.RemoveTarget(); err != nil {
+	files, err := removeService.PlannedFiles()
+	if err != nil {
+		return fmt.Errorf("failed to remove help target: %w", err)
+	}
+	tx := beginJournal(config, makefilePath)
+	if err := tx.Track(files...); err != nil {
+		return err
+	}
+	defer commitJournal(tx)
+
+	if err := removeService.RemoveTarget(config.runContext()); err != nil {
 		return fmt.Errorf("failed to remove help target: %w", err)
 	}
 
@@ -0,0 +1,32 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/sdlcforge/make-help/internal/format"
+)
+
+// runRenderFixture renders format.FixtureModel in the configured format to w.
+// No Makefile, make process, or project config is read, so the output depends
+// only on the make-help version and the formatting flags. Packagers and
+// integration tests compare it across versions to catch formatter changes.
+func runRenderFixture(config *Config, w io.Writer) error {
+	formatterConfig := newFormatterConfig(config)
+	formatterConfig.MakefileDir = format.FixtureMakefileDir
+	formatterConfig.HTMLPolicy = format.HTMLPolicy{
+		RawHTML:         config.HTMLRaw,
+		LinkRel:         config.HTMLLinkRel,
+		LinkTargetBlank: config.HTMLLinkTargetBlank,
+		Nonce:           config.HTMLNonce,
+	}
+
+	formatter, err := format.NewFormatter(config.Format, formatterConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create formatter: %w", err)
+	}
+	if err := formatter.RenderHelp(format.FixtureModel(), w); err != nil {
+		return fmt.Errorf("failed to render fixture: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunRenderFixture(t *testing.T) {
+	t.Parallel()
+	config := NewConfig()
+	config.Format = "text"
+
+	var buf bytes.Buffer
+	require.NoError(t, runRenderFixture(config, &buf))
+
+	// The fixture renders the same as the formatter golden file
+	golden, err := os.ReadFile(filepath.Join("..", "format", "testdata", "fixture", "text.golden"))
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(golden, buf.Bytes()), "fixture output should match text.golden")
+
+	// Formatting flags still apply
+	config.Format = "markdown"
+	config.TOC = true
+	buf.Reset()
+	require.NoError(t, runRenderFixture(config, &buf))
+	assert.Contains(t, buf.String(), "## Contents")
+}
+
+func TestRenderFixtureFlagValidation(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		args      []string
+		errorText string
+	}{
+		{
+			name:      "render-fixture with makefile-path",
+			args:      []string{"--render-fixture", "--makefile-path", "Makefile"},
+			errorText: "--render-fixture cannot be used with --makefile-path",
+		},
+		{
+			name:      "render-fixture with output file",
+			args:      []string{"--render-fixture", "--output", "help.txt"},
+			errorText: "--render-fixture cannot be used with --output",
+		},
+		{
+			name:      "render-fixture with target",
+			args:      []string{"--render-fixture", "--target", "build", "--output", "-"},
+			errorText: "--render-fixture cannot be used with --target",
+		},
+		{
+			name:      "remove-help with render-fixture",
+			args:      []string{"--remove-help", "--render-fixture"},
+			errorText: "--remove-help cannot be used with --render-fixture",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cmd := NewRootCmd()
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorText)
+		})
+	}
+}
@@ -3,8 +3,16 @@ package cli
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
 	"strings"
 
+	"github.com/sdlcforge/make-help/internal/export"
+	"github.com/sdlcforge/make-help/internal/fragment"
+	"github.com/sdlcforge/make-help/internal/graph"
+	"github.com/sdlcforge/make-help/internal/pathmap"
+	"github.com/sdlcforge/make-help/internal/spell"
 	"github.com/sdlcforge/make-help/internal/version"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -37,6 +45,7 @@ Default behavior generates help file. Use flags for other operations:
   --format <type>       Output format (make, text; default: make)
   --output <path>       Output destination (file path or - for stdout)
   --target <name>       Show detailed help for a target (requires --output -)
+  <target>              Same as --target <target> --output -
   --remove-help         Remove help targets
 
 Documentation directives (in ## comments):
@@ -56,29 +65,240 @@ Documentation directives (in ## comments):
 				return err
 			}
 
+			// --shell-init only prints shell code, so it takes no other flags
+			if config.ShellInit != "" {
+				if !slices.Contains(shellInitShells, config.ShellInit) {
+					return fmt.Errorf("invalid shell: %s (valid: %s)", config.ShellInit, strings.Join(shellInitShells, ", "))
+				}
+				var other string
+				cmd.Flags().Visit(func(flag *pflag.Flag) {
+					if other == "" && flag.Name != "shell-init" {
+						other = flag.Name
+					}
+				})
+				if other != "" {
+					return fmt.Errorf("--shell-init cannot be used with --%s", other)
+				}
+				if len(args) > 0 {
+					return fmt.Errorf("--shell-init does not take arguments")
+				}
+				return nil
+			}
+
+			// --chdir works like make -C: everything after it, including
+			// relative paths in other flags, is relative to the new directory
+			if config.Chdir != "" {
+				if err := os.Chdir(config.Chdir); err != nil {
+					return fmt.Errorf("failed to change to directory %s: %w", config.Chdir, err)
+				}
+				if config.Verbose {
+					fmt.Fprintf(os.Stderr, "Changed to directory: %s\n", config.Chdir)
+				}
+			}
+
 			// Capture the raw command line exactly as invoked
 			config.CommandLine = strings.Join(os.Args, " ")
 
+			if config.LockTimeout < 0 {
+				return fmt.Errorf("--lock-timeout cannot be negative")
+			}
+
+			if config.WorkDir != "" {
+				if info, err := os.Stat(config.WorkDir); err != nil || !info.IsDir() {
+					return fmt.Errorf("--workdir %s is not a directory", config.WorkDir)
+				}
+			}
+
+			if len(config.EnvAllow) > 0 && !config.ScrubEnv && !config.Sandbox {
+				return fmt.Errorf("--env-allow requires --scrub-env or --sandbox")
+			}
+			for _, name := range config.EnvAllow {
+				if !envNameRegex.MatchString(name) {
+					return fmt.Errorf("invalid environment variable name for --env-allow: %q", name)
+				}
+			}
+
+			// --clean only needs to know where the Makefile is
+			if config.Clean {
+				var other string
+				cmd.Flags().Visit(func(flag *pflag.Flag) {
+					if other == "" && !slices.Contains([]string{"clean", "makefile-path", "chdir", "workdir", "verbose"}, flag.Name) {
+						other = flag.Name
+					}
+				})
+				if other != "" {
+					return fmt.Errorf("--clean cannot be used with --%s", other)
+				}
+				if len(args) > 0 {
+					return fmt.Errorf("--clean does not take arguments")
+				}
+				return nil
+			}
+
+			// --undo only needs to know where the Makefile is
+			if config.Undo {
+				var other string
+				cmd.Flags().Visit(func(flag *pflag.Flag) {
+					if other == "" && !slices.Contains([]string{"undo", "makefile-path", "chdir", "lock-timeout", "verbose"}, flag.Name) {
+						other = flag.Name
+					}
+				})
+				if other != "" {
+					return fmt.Errorf("--undo cannot be used with --%s", other)
+				}
+				if len(args) > 0 {
+					return fmt.Errorf("--undo does not take arguments")
+				}
+				return nil
+			}
+
+			// --daemon serves requests until stopped; flags that configure
+			// rendering apply to every request
+			if config.Daemon != "" {
+				if len(args) > 0 {
+					return fmt.Errorf("--daemon does not take arguments")
+				}
+			}
+
+			// --usage-stats only reads the usage file next to the Makefile
+			if config.UsageStats {
+				var other string
+				cmd.Flags().Visit(func(flag *pflag.Flag) {
+					if other == "" && !slices.Contains([]string{"usage-stats", "makefile-path", "chdir", "format", "verbose"}, flag.Name) {
+						other = flag.Name
+					}
+				})
+				if other != "" {
+					return fmt.Errorf("--usage-stats cannot be used with --%s", other)
+				}
+				if len(args) > 0 {
+					return fmt.Errorf("--usage-stats does not take arguments")
+				}
+				if cmd.Flags().Changed("format") && formatNames[config.Format] != "text" && formatNames[config.Format] != "json" {
+					return fmt.Errorf("--usage-stats supports --format text or json, not %s", config.Format)
+				}
+				config.Format = "text"
+				if cmd.Flags().Changed("format") {
+					config.Format = formatNames[config.Format]
+				}
+				return nil
+			}
+
+			// A positional argument is shorthand for --target <name> --output -.
+			// --hook, --run, and --preview take their own arguments. The built-in
+			// completion command takes precedence; use --target to show a
+			// target with that name. Empty arguments, as
+			// from an unset shell variable, are ignored.
+			args = slices.DeleteFunc(slices.Clone(args), func(arg string) bool { return arg == "" })
+			if config.Hook == "" && config.RunTarget == "" && config.Preview == "" && len(args) > 0 {
+				if len(args) > 1 {
+					return fmt.Errorf("only one target can be shown at a time, got %d: %s", len(args), strings.Join(args, " "))
+				}
+				if config.Target != "" {
+					return fmt.Errorf("cannot use both --target and a positional target")
+				}
+				config.Target = args[0]
+				if !cmd.Flags().Changed("output") {
+					config.Output = "-"
+				}
+			}
+
+			// Inject mode renders Markdown unless another format is requested
+			if (config.InjectFile != "" || config.Hook == hookInjectCheck) && !cmd.Flags().Changed("format") {
+				config.Format = "markdown"
+			}
+
 			// Normalize and validate format
-			validFormats := map[string]string{
-				"make": "make", "mk": "make",
-				"text": "text", "txt": "text",
-				"html": "html",
-				"markdown": "markdown", "md": "markdown",
-				"json": "json",
+			// --output-dir accepts a comma-separated list of formats
+			var formats []string
+			for _, name := range strings.Split(config.Format, ",") {
+				normalizedFormat, ok := formatNames[strings.TrimSpace(name)]
+				if !ok {
+					return fmt.Errorf("invalid format: %s (valid: make, text, html, markdown, json, ndjson, slack)", name)
+				}
+				if !slices.Contains(formats, normalizedFormat) {
+					formats = append(formats, normalizedFormat)
+				}
 			}
-			normalizedFormat, ok := validFormats[config.Format]
-			if !ok {
-				return fmt.Errorf("invalid format: %s (valid: make, text, html, markdown, json)", config.Format)
+			if len(formats) > 1 && config.OutputDir == "" {
+				return fmt.Errorf("multiple formats require --output-dir")
+			}
+			config.Format = formats[0]
+			if config.OutputDir != "" {
+				config.OutputFormats = formats
 			}
-			config.Format = normalizedFormat
 
-			// Resolve output destination
-			if config.Output == "" {
+			if config.MDLayout != "list" && config.MDLayout != "table" {
+				return fmt.Errorf("invalid markdown layout: %s (valid: list, table)", config.MDLayout)
+			}
+			patternFlags := []struct {
+				patterns []string
+				flagName string
+			}{
+				{config.OnlyFiles, "--only-file"},
+				{config.ExcludeTargets, "--exclude-target"},
+				{config.ExcludeFiles, "--exclude-file"},
+			}
+			for _, flag := range patternFlags {
+				for _, pattern := range flag.patterns {
+					if _, err := filepath.Match(pattern, ""); err != nil {
+						return fmt.Errorf("invalid %s pattern %q: %w", flag.flagName, pattern, err)
+					}
+				}
+			}
+			for _, pattern := range config.RedactPatterns {
+				if _, err := regexp.Compile(pattern); err != nil {
+					return fmt.Errorf("invalid --redact-pattern %q: %w", pattern, err)
+				}
+			}
+			if config.NoRedact && len(config.RedactPatterns) > 0 {
+				return fmt.Errorf("cannot use both --no-redact and --redact-pattern flags")
+			}
+			if config.GroupBy != "category" && config.GroupBy != "file" {
+				return fmt.Errorf("invalid grouping: %s (valid: category, file)", config.GroupBy)
+			}
+			if config.SplitBy != "" && config.SplitBy != "category" {
+				return fmt.Errorf("invalid split mode: %s (valid: category)", config.SplitBy)
+			}
+			if !helpTargetNameRegex.MatchString(config.HelpTargetName) {
+				return fmt.Errorf("invalid help target name: %q (use letters, digits, '.', '_', and '-')", config.HelpTargetName)
+			}
+			if config.MaxTargetsPerCategory < 0 {
+				return fmt.Errorf("--max-targets-per-category must not be negative")
+			}
+			if config.SummaryWidth < 0 {
+				return fmt.Errorf("--summary-width must not be negative")
+			}
+			if config.IncludedFilesPosition != "top" && config.IncludedFilesPosition != "bottom" {
+				return fmt.Errorf("invalid included files position: %s (valid: top, bottom)", config.IncludedFilesPosition)
+			}
+			if config.FileDocsDepth < 0 {
+				return fmt.Errorf("--file-docs-depth must not be negative")
+			}
+			if config.NoIncludedFiles && config.IncludedFilesPosition != "top" {
+				return fmt.Errorf("cannot use both --no-included-files and --included-files-position flags")
+			}
+			if config.NoIncludedFiles && config.FileDocsDepth > 0 {
+				return fmt.Errorf("cannot use both --no-included-files and --file-docs-depth flags")
+			}
+			if config.PageSize < 0 {
+				return fmt.Errorf("--page-size must not be negative")
+			}
+			if config.Page < 1 {
+				return fmt.Errorf("--page must be at least 1")
+			}
+
+			// Resolve output destination; exports go to stdout unless --output
+			// is given, and lists always do
+			if config.Output == "" && config.Export == "" && config.List == "" {
 				config.Output = getDefaultOutput(config.Format)
 			}
 
 			// Phase 2: Mode restrictions (most restrictive first)
+			if err := validateModeConflicts(cmd, config); err != nil {
+				return err
+			}
+
 			// --remove-help: only --verbose and --makefile-path allowed
 			if config.RemoveHelpTarget {
 				if err := validateRemoveHelpFlags(config); err != nil {
@@ -97,18 +317,393 @@ Documentation directives (in ## comments):
 				if config.DryRun && !config.Fix {
 					return fmt.Errorf("--dry-run with --lint requires --fix")
 				}
+				if config.Profile != "" {
+					return fmt.Errorf("--lint cannot be used with --profile (lint checks every target)")
+				}
+				if config.BestEffort {
+					return fmt.Errorf("--lint cannot be used with --best-effort")
+				}
+			}
+
+			// --hook mode validations
+			if config.Hook != "" {
+				if config.Hook != hookLint && config.Hook != hookInjectCheck {
+					return fmt.Errorf("invalid hook: %s (valid: %s, %s)", config.Hook, hookLint, hookInjectCheck)
+				}
+				if config.Lint {
+					return fmt.Errorf("--hook cannot be used with --lint")
+				}
+				if config.Hook == hookLint && config.InjectFile != "" {
+					return fmt.Errorf("--hook lint cannot be used with --inject")
+				}
+				if cmd.Flags().Changed("output") {
+					return fmt.Errorf("--hook cannot be used with --output")
+				}
+				if config.Fix {
+					return fmt.Errorf("--hook cannot be used with --fix")
+				}
+			}
+
+			// --inject mode validations
+			if config.InjectFile != "" {
+				if config.Lint {
+					return fmt.Errorf("--inject cannot be used with --lint")
+				}
+				if config.Target != "" {
+					return fmt.Errorf("--inject cannot be used with --target")
+				}
+				if cmd.Flags().Changed("output") {
+					return fmt.Errorf("--inject cannot be used with --output")
+				}
+				if config.DryRun {
+					return fmt.Errorf("--inject cannot be used with --dry-run (use --check)")
+				}
+				if config.Format != "markdown" {
+					return fmt.Errorf("--inject requires --format markdown")
+				}
+			}
+
+			// --dump-model mode validations
+			if config.DumpModel != "" {
+				if config.Lint {
+					return fmt.Errorf("--dump-model cannot be used with --lint")
+				}
+				if config.Hook != "" {
+					return fmt.Errorf("--dump-model cannot be used with --hook")
+				}
+				if config.InjectFile != "" {
+					return fmt.Errorf("--dump-model cannot be used with --inject")
+				}
+				if config.Target != "" {
+					return fmt.Errorf("--dump-model cannot be used with --target")
+				}
+				if cmd.Flags().Changed("output") {
+					return fmt.Errorf("--dump-model cannot be used with --output")
+				}
+				if config.DryRun {
+					return fmt.Errorf("--dump-model cannot be used with --dry-run")
+				}
+			}
+
+			// --snapshot mode validations
+			if config.Snapshot != "" {
+				if config.Snapshot != snapshotUpdate && config.Snapshot != snapshotVerify {
+					return fmt.Errorf("invalid snapshot mode: %s (valid: %s, %s)", config.Snapshot, snapshotUpdate, snapshotVerify)
+				}
+				if config.Lint {
+					return fmt.Errorf("--snapshot cannot be used with --lint")
+				}
+				if config.Hook != "" {
+					return fmt.Errorf("--snapshot cannot be used with --hook")
+				}
+				if config.InjectFile != "" {
+					return fmt.Errorf("--snapshot cannot be used with --inject")
+				}
+				if config.DumpModel != "" {
+					return fmt.Errorf("--snapshot cannot be used with --dump-model")
+				}
+				if config.Target != "" {
+					return fmt.Errorf("--snapshot cannot be used with --target")
+				}
+				if cmd.Flags().Changed("output") {
+					return fmt.Errorf("--snapshot cannot be used with --output")
+				}
+				if cmd.Flags().Changed("format") {
+					return fmt.Errorf("--snapshot cannot be used with --format (snapshots cover text, markdown, and json)")
+				}
+				if config.DryRun {
+					return fmt.Errorf("--snapshot cannot be used with --dry-run (use --snapshot verify)")
+				}
+			}
+
+			// --from-model validations: the dump replaces make and the Makefile
+			if config.FromModel != "" {
+				if config.Lint {
+					return fmt.Errorf("--from-model cannot be used with --lint")
+				}
+				if config.Hook != "" {
+					return fmt.Errorf("--from-model cannot be used with --hook")
+				}
+				if config.Target != "" {
+					return fmt.Errorf("--from-model cannot be used with --target")
+				}
+				if config.MakefilePath != "" {
+					return fmt.Errorf("--from-model cannot be used with --makefile-path")
+				}
+				if config.ResolveRemote {
+					return fmt.Errorf("--from-model cannot be used with --resolve-remote")
+				}
+				if config.GitBlame {
+					return fmt.Errorf("--from-model cannot be used with --git-blame")
+				}
+				if config.HighlightNew != "" {
+					return fmt.Errorf("--from-model cannot be used with --highlight-new")
+				}
+				if config.DumpModel == "" && config.InjectFile == "" && config.Snapshot == "" &&
+					config.OutputDir == "" && config.Graph == "" && !config.Analyze && config.Export == "" && config.List == "" && !config.Categories && !config.Vars && !config.ValidateOnly && config.Format == "make" && config.Output != "-" {
+					return fmt.Errorf("--from-model cannot generate a help target file (use --format or --output -)")
+				}
+			}
+
+			// --add-fragment validations: only the Makefile location and --dry-run apply
+			if config.AddFragment != "" {
+				if !slices.Contains(fragment.Names(), config.AddFragment) {
+					return fmt.Errorf("unknown fragment: %s (available: %s)", config.AddFragment, strings.Join(fragment.Names(), ", "))
+				}
+			}
+
+			// --graph validations: the graph is written to stdout
+			if config.Graph != "" {
+				if config.Graph != graph.FormatDOT && config.Graph != graph.FormatMermaid {
+					return fmt.Errorf("invalid graph format: %s (valid: %s, %s)", config.Graph, graph.FormatDOT, graph.FormatMermaid)
+				}
+			}
+
+			if len(config.Tags) > 0 && config.Export == "" {
+				return fmt.Errorf("--tag requires --export")
+			}
+
+			// --export validations: the manifest is written to --output or stdout
+			if config.Export != "" {
+				if !slices.Contains(export.Schemas(), config.Export) {
+					return fmt.Errorf("invalid export schema: %s (valid: %s)", config.Export, strings.Join(export.Schemas(), ", "))
+				}
+			}
+
+			if config.ListColumns && config.List == "" {
+				return fmt.Errorf("--list-columns requires --list")
+			}
+
+			// --list validations: names are printed to stdout for scripts
+			if config.List != "" {
+				if !slices.Contains(listScopes, config.List) {
+					return fmt.Errorf("invalid list scope: %s (valid: %s)", config.List, strings.Join(listScopes, ", "))
+				}
+			}
+
+			// --categories validations: the report is written to stdout as text or JSON
+			if config.Categories {
+				if cmd.Flags().Changed("format") && config.Format != "text" && config.Format != "json" {
+					return fmt.Errorf("--categories supports --format text or json, not %s", config.Format)
+				}
+			}
+
+			// --vars validations: the inventory is written to stdout as text, JSON, or Markdown
+			if config.Vars {
+				if cmd.Flags().Changed("format") && config.Format != "text" && config.Format != "json" && config.Format != "markdown" {
+					return fmt.Errorf("--vars supports --format text, json, or markdown, not %s", config.Format)
+				}
+			}
+
+			// --validate-only validations: problems are written to stdout as text or JSON
+			if config.ValidateOnly {
+				if cmd.Flags().Changed("format") && config.Format != "text" && config.Format != "json" {
+					return fmt.Errorf("--validate-only supports --format text or json, not %s", config.Format)
+				}
+			}
+
+			// --analyze validations: the report is written to stdout as text or JSON
+			if config.Analyze {
+				if cmd.Flags().Changed("format") && config.Format != "text" && config.Format != "json" {
+					return fmt.Errorf("--analyze supports --format text or json, not %s", config.Format)
+				}
+			}
+
+			// When outputting to stdout, default to text format unless explicitly
+			// set; the format requirement checks below rely on it
+			if (config.Output == "-" || config.Daemon != "" || config.RenderFixture || config.Analyze || config.Categories || config.Vars || config.ValidateOnly) && !cmd.Flags().Changed("format") {
+				config.Format = "text"
 			}
 
 			// Phase 3: Requirement checks (flag A requires flag B present)
-			if config.Target != "" && config.Output != "-" {
+			if config.Target != "" && config.Output != "-" && config.Export == "" {
 				return fmt.Errorf("--target requires --output - (stdout mode)")
 			}
+			if config.Exact && config.Target == "" {
+				return fmt.Errorf("--exact requires --target")
+			}
 			if config.Fix && !config.Lint {
 				return fmt.Errorf("--fix requires --lint")
 			}
+			if config.Rename && !config.Fix {
+				return fmt.Errorf("--rename requires --fix")
+			}
+			if config.Baseline != "" && !config.Lint {
+				return fmt.Errorf("--baseline requires --lint")
+			}
+			if config.Stats != "" {
+				if !config.Lint {
+					return fmt.Errorf("--stats requires --lint")
+				}
+				if config.Stats != statsMarkdown && config.Stats != statsJSON {
+					return fmt.Errorf("invalid --stats format: %s (valid: %s, %s)", config.Stats, statsMarkdown, statsJSON)
+				}
+				if config.Fix {
+					return fmt.Errorf("--stats cannot be used with --fix")
+				}
+			}
+			if cmd.Flags().Changed("top") {
+				if config.Stats == "" && !config.Analyze {
+					return fmt.Errorf("--top requires --stats or --analyze")
+				}
+				if config.Top <= 0 {
+					return fmt.Errorf("--top must be positive")
+				}
+			}
+			if config.Spell && !config.Lint {
+				return fmt.Errorf("--spell requires --lint")
+			}
+			if cmd.Flags().Changed("spell-lang") && !config.Spell {
+				return fmt.Errorf("--spell-lang requires --spell")
+			}
+			if config.Spell && !slices.Contains(spell.Languages(), config.SpellLang) {
+				return fmt.Errorf("invalid --spell-lang: %s (available: %s)", config.SpellLang, strings.Join(spell.Languages(), ", "))
+			}
+			if config.Freshness && !config.Lint {
+				return fmt.Errorf("--freshness requires --lint")
+			}
+			if cmd.Flags().Changed("freshness-days") {
+				if !config.Freshness {
+					return fmt.Errorf("--freshness-days requires --freshness")
+				}
+				if config.FreshnessDays < 0 {
+					return fmt.Errorf("--freshness-days cannot be negative")
+				}
+			}
+			if config.Check && config.InjectFile == "" {
+				return fmt.Errorf("--check requires --inject")
+			}
+			if cmd.Flags().Changed("snapshot-dir") && config.Snapshot == "" {
+				return fmt.Errorf("--snapshot-dir requires --snapshot")
+			}
+			if config.DocumentedOnly && config.Graph == "" {
+				return fmt.Errorf("--documented-only requires --graph")
+			}
+			if config.HighlightCycles && config.Graph == "" {
+				return fmt.Errorf("--highlight-cycles requires --graph")
+			}
+			if config.RecordDuration && config.RunTarget == "" {
+				return fmt.Errorf("--record-duration requires --run")
+			}
+			if config.Yes && config.RunTarget == "" {
+				return fmt.Errorf("--yes requires --run")
+			}
 			if config.NoDynamicWarning && config.DynamicMode != DynamicForced {
 				return fmt.Errorf("--no-dynamic-warning requires --dynamic")
 			}
+			if len(config.PathMaps) > 0 && !config.Container {
+				return fmt.Errorf("--path-map requires --container")
+			}
+			if config.Container {
+				specs := config.PathMaps
+				if len(specs) == 0 {
+					specs = []string{pathmap.DefaultSpec}
+				}
+				var err error
+				if config.pathMap, err = pathmap.New(specs); err != nil {
+					return err
+				}
+			}
+			if config.PostProcess != "" && !rendersHelpOutput(config) {
+				return fmt.Errorf("--post-process requires rendered help output (--output, --inject, or --output-dir)")
+			}
+			if config.NoScript && !rendersFormat(config, "html") {
+				return fmt.Errorf("--no-script requires --format html")
+			}
+			htmlFlags := []struct {
+				set      bool
+				flagName string
+			}{
+				{config.HTMLRaw != "", "--html-raw"},
+				{config.HTMLLinkRel != "", "--html-link-rel"},
+				{config.HTMLLinkTargetBlank, "--html-link-target-blank"},
+				{config.HTMLNonce != "", "--html-nonce"},
+			}
+			for _, flag := range htmlFlags {
+				if flag.set && !rendersFormat(config, "html") {
+					return fmt.Errorf("%s requires --format html", flag.flagName)
+				}
+			}
+			if err := validateRawHTMLMode(config.HTMLRaw); err != nil {
+				return err
+			}
+			if config.Provenance && config.NoProvenance {
+				return fmt.Errorf("cannot use both --provenance and --no-provenance flags")
+			}
+			if config.Provenance && !rendersFormat(config, "markdown") && !rendersFormat(config, "html") {
+				return fmt.Errorf("--provenance requires --format markdown or html")
+			}
+			if config.SlackBlocks && !rendersFormat(config, "slack") {
+				return fmt.Errorf("--slack-blocks requires --format slack")
+			}
+			if config.TOC && !rendersFormat(config, "markdown") {
+				return fmt.Errorf("--toc requires --format markdown")
+			}
+			if config.MDLayout != "list" && !rendersFormat(config, "markdown") {
+				return fmt.Errorf("--md-layout requires --format markdown")
+			}
+			if config.Compact && config.Long {
+				return fmt.Errorf("cannot use both --compact and --long flags")
+			}
+			if config.Compact && !rendersFormat(config, "text") {
+				return fmt.Errorf("--compact requires --format text")
+			}
+			if config.Long && !rendersFormat(config, "text") {
+				return fmt.Errorf("--long requires --format text")
+			}
+			if config.MaxTargetsPerCategory > 0 && !rendersFormat(config, "text") && !rendersFormat(config, "make") {
+				return fmt.Errorf("--max-targets-per-category requires --format text or make")
+			}
+			if config.SummaryWidth > 0 && !rendersFormat(config, "text") && !rendersFormat(config, "make") {
+				return fmt.Errorf("--summary-width requires --format text or make")
+			}
+			if config.ConsolidateVars && !rendersFormat(config, "text") && !rendersFormat(config, "make") &&
+				!rendersFormat(config, "markdown") && !rendersFormat(config, "html") {
+				return fmt.Errorf("--consolidate-vars requires --format text, make, markdown, or html")
+			}
+			if config.HighlightNew != "" {
+				if _, err := parseNewWindow(config.HighlightNew); err != nil {
+					return err
+				}
+				if !rendersFormat(config, "text") && !rendersFormat(config, "markdown") && !rendersFormat(config, "html") {
+					return fmt.Errorf("--highlight-new requires --format text, markdown, or html")
+				}
+			}
+			if config.ShowStats && !rendersFormat(config, "text") && !rendersFormat(config, "make") &&
+				!rendersFormat(config, "markdown") && !rendersFormat(config, "json") {
+				return fmt.Errorf("--show-stats requires --format text, make, markdown, or json")
+			}
+			if config.NoIncludedFiles && !rendersFormat(config, "text") && !rendersFormat(config, "make") &&
+				!rendersFormat(config, "markdown") && !rendersFormat(config, "html") && !rendersFormat(config, "json") {
+				return fmt.Errorf("--no-included-files requires --format text, make, markdown, html, or json")
+			}
+			if config.IncludedFilesPosition != "top" && !rendersFormat(config, "text") && !rendersFormat(config, "make") &&
+				!rendersFormat(config, "markdown") && !rendersFormat(config, "html") {
+				return fmt.Errorf("--included-files-position requires --format text, make, markdown, or html")
+			}
+			if config.FileDocsDepth > 0 && !rendersFormat(config, "text") && !rendersFormat(config, "make") &&
+				!rendersFormat(config, "markdown") && !rendersFormat(config, "html") {
+				return fmt.Errorf("--file-docs-depth requires --format text, make, markdown, or html")
+			}
+			if config.PageSize > 0 && !rendersFormat(config, "json") && !rendersFormat(config, "html") {
+				return fmt.Errorf("--page-size requires --format json or html")
+			}
+			if config.Page != 1 && config.PageSize == 0 {
+				return fmt.Errorf("--page requires --page-size")
+			}
+			if config.SplitBy != "" {
+				if config.OutputDir == "" {
+					return fmt.Errorf("--split-by requires --output-dir")
+				}
+				for _, formatName := range config.OutputFormats {
+					if formatName != "markdown" && formatName != "html" {
+						return fmt.Errorf("--split-by requires --format markdown or html (got %s)", formatName)
+					}
+				}
+				if config.PageSize > 0 {
+					return fmt.Errorf("cannot use both --split-by and --page-size flags")
+				}
+			}
 
 			// --dry-run is only for file generation (and --lint --fix)
 			if config.DryRun && config.Output == "-" {
@@ -122,8 +717,25 @@ Documentation directives (in ## comments):
 
 			// Phase 4: Scope checks (file-generation-only flags)
 			isFileGenMode := config.Output != "-" &&
+				config.Daemon == "" &&
 				!config.Lint &&
 				!config.RemoveHelpTarget &&
+				config.InjectFile == "" &&
+				config.Hook == "" &&
+				config.DumpModel == "" &&
+				config.Snapshot == "" &&
+				config.RunTarget == "" &&
+				config.Preview == "" &&
+				!config.RenderFixture &&
+				config.OutputDir == "" &&
+				config.AddFragment == "" &&
+				config.Graph == "" &&
+				!config.Analyze &&
+				config.Export == "" &&
+				config.List == "" &&
+				!config.Categories &&
+				!config.Vars &&
+				!config.ValidateOnly &&
 				config.Target == ""
 
 			if err := validateFileGenOnlyFlags(config, isFileGenMode); err != nil {
@@ -133,25 +745,68 @@ Documentation directives (in ## comments):
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			config.ctx = cmd.Context()
+			defer recordUsage(cmd, config)
+
 			// Resolve color mode
 			config.UseColor = ResolveColorMode(config)
 
-			// When outputting to stdout, default to text format unless explicitly set
-			if config.Output == "-" && !cmd.Flags().Changed("format") {
-				config.Format = "text"
-			}
-
 			// Dispatch to appropriate handler
-			if config.Lint {
+			if config.ShellInit != "" {
+				return runShellInit(config, os.Stdout)
+			} else if config.UsageStats {
+				return runUsageStats(config, os.Stdout)
+			} else if config.Undo {
+				return runUndo(config)
+			} else if config.Clean {
+				return runClean(config)
+			} else if config.Daemon != "" {
+				return runDaemon(config)
+			} else if config.Lint {
 				return runLint(config)
 			} else if config.RemoveHelpTarget {
 				return runRemoveHelpTarget(config)
+			} else if config.Hook != "" {
+				return runHook(config, args)
+			} else if config.DumpModel != "" {
+				return runDumpModel(config)
+			} else if config.Snapshot != "" {
+				return runSnapshot(config)
+			} else if config.RenderFixture {
+				return runRenderFixture(config, os.Stdout)
+			} else if config.AddFragment != "" {
+				return runAddFragment(config)
+			} else if config.Graph != "" {
+				return runGraph(config)
+			} else if config.Analyze {
+				return runAnalyze(config)
+			} else if config.Export != "" {
+				return runExport(config)
+			} else if config.List != "" {
+				return runList(config, os.Stdout)
+			} else if config.Categories {
+				return runCategories(config)
+			} else if config.Vars {
+				return runVars(config)
+			} else if config.ValidateOnly {
+				return runValidateOnly(config, os.Stdout)
+			} else if config.OutputDir != "" {
+				return runOutputDir(config)
+			} else if config.RunTarget != "" {
+				return runTarget(config, args)
+			} else if config.Preview != "" {
+				return runPreview(config, args)
+			} else if config.InjectFile != "" {
+				return runInject(config)
 			} else if config.Target != "" {
 				// Detailed target help (requires stdout mode)
 				return runDetailedHelp(config)
-			} else if config.Output == "-" {
-				// Stdout mode (dynamic help output)
+			} else if config.Output == "-" || config.Format != "make" {
+				// Stdout mode (dynamic help output) or a rendered help document
 				return runHelp(config)
+			} else if config.AllMakefiles {
+				// File generation mode, once per project
+				return runAllMakefiles(config)
 			} else {
 				// File generation mode
 				return runCreateHelpTarget(config)
@@ -161,19 +816,68 @@ Documentation directives (in ## comments):
 
 	// Set up flags using shared function
 	setupFlags(rootCmd, config)
+	registerFlagCompletions(rootCmd, config)
 
 	// Annotate flags with their groups for custom help display
 	annotateFlag(rootCmd, "remove-help", modeGroupLabel)
 	annotateFlag(rootCmd, "dry-run", modeGroupLabel)
 	annotateFlag(rootCmd, "lint", modeGroupLabel)
 	annotateFlag(rootCmd, "fix", modeGroupLabel)
+	annotateFlag(rootCmd, "rename", modeGroupLabel)
+	annotateFlag(rootCmd, "baseline", modeGroupLabel)
+	annotateFlag(rootCmd, "stats", modeGroupLabel)
+	annotateFlag(rootCmd, "top", modeGroupLabel)
+	annotateFlag(rootCmd, "spell", modeGroupLabel)
+	annotateFlag(rootCmd, "spell-lang", modeGroupLabel)
+	annotateFlag(rootCmd, "freshness", modeGroupLabel)
+	annotateFlag(rootCmd, "freshness-days", modeGroupLabel)
+	annotateFlag(rootCmd, "no-plugins", modeGroupLabel)
 	annotateFlag(rootCmd, "target", modeGroupLabel)
+	annotateFlag(rootCmd, "exact", modeGroupLabel)
+	annotateFlag(rootCmd, "inject", modeGroupLabel)
+	annotateFlag(rootCmd, "check", modeGroupLabel)
+	annotateFlag(rootCmd, "hook", modeGroupLabel)
+	annotateFlag(rootCmd, "dump-model", modeGroupLabel)
+	annotateFlag(rootCmd, "snapshot", modeGroupLabel)
+	annotateFlag(rootCmd, "snapshot-dir", modeGroupLabel)
+	annotateFlag(rootCmd, "run", modeGroupLabel)
+	annotateFlag(rootCmd, "record-duration", modeGroupLabel)
+	annotateFlag(rootCmd, "yes", modeGroupLabel)
+	annotateFlag(rootCmd, "preview", modeGroupLabel)
+	annotateFlag(rootCmd, "add-fragment", modeGroupLabel)
+	annotateFlag(rootCmd, "undo", modeGroupLabel)
+	annotateFlag(rootCmd, "clean", modeGroupLabel)
+	annotateFlag(rootCmd, "usage-stats", modeGroupLabel)
+	annotateFlag(rootCmd, "shell-init", modeGroupLabel)
+	annotateFlag(rootCmd, "graph", modeGroupLabel)
+	annotateFlag(rootCmd, "documented-only", modeGroupLabel)
+	annotateFlag(rootCmd, "highlight-cycles", modeGroupLabel)
+	annotateFlag(rootCmd, "analyze", modeGroupLabel)
+	annotateFlag(rootCmd, "export", modeGroupLabel)
+	annotateFlag(rootCmd, "tag", modeGroupLabel)
+	annotateFlag(rootCmd, "list", modeGroupLabel)
+	annotateFlag(rootCmd, "list-columns", modeGroupLabel)
+	annotateFlag(rootCmd, "categories", modeGroupLabel)
+	annotateFlag(rootCmd, "vars", modeGroupLabel)
+	annotateFlag(rootCmd, "validate-only", modeGroupLabel)
+	annotateFlag(rootCmd, "daemon", modeGroupLabel)
 
 	annotateFlag(rootCmd, "makefile-path", inputGroupLabel)
+	annotateFlag(rootCmd, "chdir", inputGroupLabel)
 	annotateFlag(rootCmd, "help-file-rel-path", inputGroupLabel)
+	annotateFlag(rootCmd, "all-makefiles", inputGroupLabel)
+	annotateFlag(rootCmd, "from-model", inputGroupLabel)
+	annotateFlag(rootCmd, "resolve-remote", inputGroupLabel)
+	annotateFlag(rootCmd, "scrub-env", inputGroupLabel)
+	annotateFlag(rootCmd, "env-allow", inputGroupLabel)
+	annotateFlag(rootCmd, "sandbox", inputGroupLabel)
+	annotateFlag(rootCmd, "no-exec", inputGroupLabel)
+	annotateFlag(rootCmd, "no-shell-warning", inputGroupLabel)
 
 	annotateFlag(rootCmd, "format", outputGroupLabel)
 	annotateFlag(rootCmd, "output", outputGroupLabel)
+	annotateFlag(rootCmd, "output-dir", outputGroupLabel)
+	annotateFlag(rootCmd, "split-by", outputGroupLabel)
 	annotateFlag(rootCmd, "color", outputGroupLabel)
 	annotateFlag(rootCmd, "no-color", outputGroupLabel)
 	annotateFlag(rootCmd, "include-target", outputGroupLabel)
@@ -184,13 +888,54 @@ Documentation directives (in ## comments):
 	annotateFlag(rootCmd, "keep-order-all", outputGroupLabel)
 	annotateFlag(rootCmd, "category-order", outputGroupLabel)
 	annotateFlag(rootCmd, "default-category", outputGroupLabel)
+	annotateFlag(rootCmd, "best-effort", outputGroupLabel)
 	annotateFlag(rootCmd, "help-category", outputGroupLabel)
+	annotateFlag(rootCmd, "help-target-name", outputGroupLabel)
+	annotateFlag(rootCmd, "replace-existing-help", outputGroupLabel)
 	annotateFlag(rootCmd, "dynamic", outputGroupLabel)
 	annotateFlag(rootCmd, "static", outputGroupLabel)
 	annotateFlag(rootCmd, "no-dynamic-warning", outputGroupLabel)
 	annotateFlag(rootCmd, "update-opts", outputGroupLabel)
+	annotateFlag(rootCmd, "regen-target", outputGroupLabel)
+	annotateFlag(rootCmd, "no-hooks", outputGroupLabel)
+	annotateFlag(rootCmd, "container", outputGroupLabel)
+	annotateFlag(rootCmd, "path-map", outputGroupLabel)
+	annotateFlag(rootCmd, "post-process", outputGroupLabel)
+	annotateFlag(rootCmd, "provenance", outputGroupLabel)
+	annotateFlag(rootCmd, "no-provenance", outputGroupLabel)
+	annotateFlag(rootCmd, "no-script", outputGroupLabel)
+	annotateFlag(rootCmd, "html-raw", outputGroupLabel)
+	annotateFlag(rootCmd, "html-link-rel", outputGroupLabel)
+	annotateFlag(rootCmd, "html-link-target-blank", outputGroupLabel)
+	annotateFlag(rootCmd, "html-nonce", outputGroupLabel)
+	annotateFlag(rootCmd, "toc", outputGroupLabel)
+	annotateFlag(rootCmd, "git-blame", outputGroupLabel)
+	annotateFlag(rootCmd, "highlight-new", outputGroupLabel)
+	annotateFlag(rootCmd, "slack-blocks", outputGroupLabel)
+	annotateFlag(rootCmd, "md-layout", outputGroupLabel)
+	annotateFlag(rootCmd, "max-targets-per-category", outputGroupLabel)
+	annotateFlag(rootCmd, "summary-width", outputGroupLabel)
+	annotateFlag(rootCmd, "show-stats", outputGroupLabel)
+	annotateFlag(rootCmd, "no-included-files", outputGroupLabel)
+	annotateFlag(rootCmd, "included-files-position", outputGroupLabel)
+	annotateFlag(rootCmd, "file-docs-depth", outputGroupLabel)
+	annotateFlag(rootCmd, "consolidate-vars", outputGroupLabel)
+	annotateFlag(rootCmd, "page-size", outputGroupLabel)
+	annotateFlag(rootCmd, "page", outputGroupLabel)
+	annotateFlag(rootCmd, "compact", outputGroupLabel)
+	annotateFlag(rootCmd, "group-by", outputGroupLabel)
+	annotateFlag(rootCmd, "only-file", outputGroupLabel)
+	annotateFlag(rootCmd, "exclude-target", outputGroupLabel)
+	annotateFlag(rootCmd, "exclude-file", outputGroupLabel)
+	annotateFlag(rootCmd, "entry-point", outputGroupLabel)
+	annotateFlag(rootCmd, "no-redact", outputGroupLabel)
+	annotateFlag(rootCmd, "redact-pattern", outputGroupLabel)
+	annotateFlag(rootCmd, "long", outputGroupLabel)
+	annotateFlag(rootCmd, "profile", outputGroupLabel)
 
 	annotateFlag(rootCmd, "verbose", miscGroupLabel)
+	annotateFlag(rootCmd, "lock-timeout", miscGroupLabel)
+	annotateFlag(rootCmd, "workdir", miscGroupLabel)
 
 	// Set custom usage template
 	rootCmd.SetUsageTemplate(usageTemplate)
@@ -198,6 +943,128 @@ Documentation directives (in ## comments):
 	return rootCmd
 }
 
+// modeConflicts lists the flags selecting a mode and the flags each cannot
+// be used with, checked in order by validateModeConflicts.
+var modeConflicts = []struct {
+	mode      string
+	conflicts []string
+}{
+	// --no-exec never runs make, so it cannot run targets or restrict how
+	// make runs
+	{"--no-exec", []string{"--run", "--preview", "--remove-help", "--scrub-env", "--sandbox"}},
+	// --daemon serves requests until stopped, so it cannot also run another
+	// mode
+	{"--daemon", []string{"--lint", "--target", "--output", "--output-dir", "--inject", "--remove-help", "--from-model",
+		"--dump-model", "--snapshot", "--run", "--preview", "--graph", "--export", "--list"}},
+	{"--run", []string{"--lint", "--hook", "--inject", "--dump-model", "--snapshot", "--from-model", "--target",
+		"--profile", "--output", "--format", "--dry-run"}},
+	// --preview runs make -n, which needs the Makefile
+	{"--preview", []string{"--lint", "--hook", "--inject", "--dump-model", "--snapshot", "--run", "--from-model",
+		"--render-fixture", "--output-dir", "--add-fragment", "--graph", "--analyze", "--target", "--profile",
+		"--output", "--format", "--dry-run"}},
+	// --output-dir writes each format to its own file
+	{"--output-dir", []string{"--lint", "--hook", "--inject", "--dump-model", "--snapshot", "--run", "--target",
+		"--render-fixture", "--output", "--dry-run"}},
+	// --add-fragment only takes the Makefile location and --dry-run
+	{"--add-fragment", []string{"--lint", "--hook", "--inject", "--dump-model", "--snapshot", "--run", "--from-model",
+		"--render-fixture", "--output-dir", "--target", "--output", "--format"}},
+	// The rest write a report to stdout, or --export to --output
+	{"--graph", []string{"--lint", "--hook", "--inject", "--dump-model", "--snapshot", "--run", "--render-fixture",
+		"--output-dir", "--add-fragment", "--target", "--output", "--format", "--dry-run"}},
+	{"--export", []string{"--lint", "--hook", "--inject", "--dump-model", "--snapshot", "--run", "--preview",
+		"--render-fixture", "--output-dir", "--add-fragment", "--graph", "--analyze", "--target", "--format", "--dry-run"}},
+	{"--list", []string{"--lint", "--hook", "--inject", "--dump-model", "--snapshot", "--run", "--preview",
+		"--render-fixture", "--output-dir", "--add-fragment", "--graph", "--analyze", "--export", "--categories",
+		"--vars", "--target", "--format", "--output", "--dry-run"}},
+	{"--categories", []string{"--lint", "--hook", "--inject", "--dump-model", "--snapshot", "--run", "--preview",
+		"--render-fixture", "--output-dir", "--add-fragment", "--graph", "--analyze", "--export", "--target",
+		"--output", "--dry-run"}},
+	{"--vars", []string{"--lint", "--hook", "--inject", "--dump-model", "--snapshot", "--run", "--preview",
+		"--render-fixture", "--output-dir", "--add-fragment", "--graph", "--analyze", "--export", "--categories",
+		"--target", "--output", "--dry-run"}},
+	{"--validate-only", []string{"--lint", "--hook", "--inject", "--dump-model", "--snapshot", "--run", "--preview",
+		"--render-fixture", "--output-dir", "--add-fragment", "--graph", "--analyze", "--export", "--list",
+		"--categories", "--vars", "--target", "--best-effort", "--output", "--dry-run"}},
+	{"--analyze", []string{"--lint", "--hook", "--inject", "--dump-model", "--snapshot", "--run", "--render-fixture",
+		"--output-dir", "--add-fragment", "--graph", "--target", "--output", "--dry-run"}},
+	// The fixture replaces make and the Makefile
+	{"--render-fixture", []string{"--lint", "--hook", "--inject", "--dump-model", "--snapshot", "--run",
+		"--from-model", "--target", "--makefile-path", "--output", "--dry-run"}},
+	// --all-makefiles finds each project's Makefile itself and writes the
+	// help file next to it
+	{"--all-makefiles", []string{"--makefile-path", "--from-model", "--output", "--format"}},
+}
+
+// validateModeConflicts checks the modes in modeConflicts against the flags
+// they cannot be used with, reporting the first conflict found.
+func validateModeConflicts(cmd *cobra.Command, config *Config) error {
+	isSet := modeFlagsSet(cmd, config)
+	for _, mode := range modeConflicts {
+		if !isSet[mode.mode] {
+			continue
+		}
+		for _, flagName := range mode.conflicts {
+			if !isSet[flagName] || allowedWithMode(config, mode.mode, flagName) {
+				continue
+			}
+			return fmt.Errorf("%s cannot be used with %s", mode.mode, flagName)
+		}
+	}
+
+	return nil
+}
+
+// modeFlagsSet reports, for every flag named in modeConflicts, whether it
+// is set.
+func modeFlagsSet(cmd *cobra.Command, config *Config) map[string]bool {
+	return map[string]bool{
+		"--add-fragment":   config.AddFragment != "",
+		"--all-makefiles":  config.AllMakefiles,
+		"--analyze":        config.Analyze,
+		"--best-effort":    config.BestEffort,
+		"--categories":     config.Categories,
+		"--daemon":         config.Daemon != "",
+		"--dry-run":        config.DryRun,
+		"--dump-model":     config.DumpModel != "",
+		"--export":         config.Export != "",
+		"--format":         cmd.Flags().Changed("format"),
+		"--from-model":     config.FromModel != "",
+		"--graph":          config.Graph != "",
+		"--hook":           config.Hook != "",
+		"--inject":         config.InjectFile != "",
+		"--lint":           config.Lint,
+		"--list":           config.List != "",
+		"--makefile-path":  config.MakefilePath != "",
+		"--no-exec":        config.NoExec,
+		"--output":         cmd.Flags().Changed("output"),
+		"--output-dir":     config.OutputDir != "",
+		"--preview":        config.Preview != "",
+		"--profile":        config.Profile != "",
+		"--remove-help":    config.RemoveHelpTarget,
+		"--render-fixture": config.RenderFixture,
+		"--run":            config.RunTarget != "",
+		"--sandbox":        config.Sandbox,
+		"--scrub-env":      config.ScrubEnv,
+		"--snapshot":       config.Snapshot != "",
+		"--target":         config.Target != "",
+		"--validate-only":  config.ValidateOnly,
+		"--vars":           config.Vars,
+	}
+}
+
+// allowedWithMode reports the exceptions to modeConflicts that depend on a
+// flag's value: --render-fixture can write to --output -, and --export env
+// can be limited to the variables of one --target.
+func allowedWithMode(config *Config, mode, flagName string) bool {
+	switch {
+	case mode == "--render-fixture" && flagName == "--output":
+		return config.Output == "-"
+	case mode == "--export" && flagName == "--target":
+		return config.Export == export.SchemaEnv
+	}
+	return false
+}
+
 // validateRemoveHelpFlags checks for incompatible flags with --remove-help.
 // It uses a table-driven approach to provide specific error messages for each incompatible flag.
 func validateRemoveHelpFlags(config *Config) error {
@@ -207,21 +1074,68 @@ func validateRemoveHelpFlags(config *Config) error {
 		flagName string
 	}{
 		{config.Target != "", "--target"},
+		{config.Exact, "--exact"},
 		{len(config.IncludeTargets) > 0, "--include-target"},
 		{config.IncludeAllPhony, "--include-all-phony"},
+		{config.Profile != "", "--profile"},
+		{config.MaxTargetsPerCategory != 0, "--max-targets-per-category"},
+		{config.SummaryWidth != 0, "--summary-width"},
+		{config.ConsolidateVars, "--consolidate-vars"},
+		{config.ShowStats, "--show-stats"},
+		{config.HighlightNew != "", "--highlight-new"},
+		{config.NoIncludedFiles, "--no-included-files"},
+		{config.IncludedFilesPosition != "top", "--included-files-position"},
+		{config.FileDocsDepth != 0, "--file-docs-depth"},
+		{config.PageSize != 0, "--page-size"},
+		{config.SplitBy != "", "--split-by"},
+		{config.SlackBlocks, "--slack-blocks"},
+		{config.Page != 1, "--page"},
+		{config.Compact, "--compact"},
+		{config.GroupBy != "category", "--group-by"},
+		{len(config.OnlyFiles) > 0, "--only-file"},
+		{config.EntryPoint != "", "--entry-point"},
+		{len(config.ExcludeTargets) > 0, "--exclude-target"},
+		{len(config.ExcludeFiles) > 0, "--exclude-file"},
+		{config.NoRedact, "--no-redact"},
+		{len(config.RedactPatterns) > 0, "--redact-pattern"},
+		{config.Long, "--long"},
 		{config.DryRun, "--dry-run"},
 		{config.Lint, "--lint"},
+		{config.InjectFile != "", "--inject"},
+		{config.Hook != "", "--hook"},
+		{config.DumpModel != "", "--dump-model"},
+		{config.FromModel != "", "--from-model"},
+		{config.RenderFixture, "--render-fixture"},
+		{config.OutputDir != "", "--output-dir"},
+		{config.AddFragment != "", "--add-fragment"},
+		{config.Graph != "", "--graph"},
+		{config.Analyze, "--analyze"},
+		{config.Export != "", "--export"},
+		{len(config.Tags) > 0, "--tag"},
+		{config.List != "", "--list"},
+		{config.ListColumns, "--list-columns"},
+		{config.Categories, "--categories"},
+		{config.Vars, "--vars"},
+		{config.ValidateOnly, "--validate-only"},
+		{config.Snapshot != "", "--snapshot"},
+		{config.RunTarget != "", "--run"},
+		{config.Preview != "", "--preview"},
 		{config.HelpFileRelPath != "", "--help-file-rel-path"},
+		{config.AllMakefiles, "--all-makefiles"},
 		{config.KeepOrderCategories, "--keep-order-categories"},
 		{config.KeepOrderTargets, "--keep-order-targets"},
 		{config.KeepOrderFiles, "--keep-order-files"},
 		{len(config.CategoryOrder) > 0, "--category-order"},
 		{config.DefaultCategory != "", "--default-category"},
+		{config.BestEffort, "--best-effort"},
 		{config.Format != "make", "--format"},
 		{config.Output != "" && config.Output != getDefaultOutput("make"), "--output"},
 		{config.DynamicMode != DynamicAuto, "--dynamic/--static"},
 		{config.NoDynamicWarning, "--no-dynamic-warning"},
 		{config.UpdateOpts != "", "--update-opts"},
+		{config.RegenTarget, "--regen-target"},
+		{config.NoHooks, "--no-hooks"},
+		{config.ResolveRemote, "--resolve-remote"},
 	}
 
 	for _, flag := range incompatibleFlags {
@@ -247,8 +1161,12 @@ func validateFileGenOnlyFlags(config *Config, isFileGenMode bool) error {
 		{config.DynamicMode != DynamicAuto, "--dynamic/--static"},
 		{config.NoDynamicWarning, "--no-dynamic-warning"},
 		{config.UpdateOpts != "", "--update-opts"},
+		{config.RegenTarget, "--regen-target"},
 		{config.HelpFileRelPath != "", "--help-file-rel-path"},
 		{config.HelpCategory != "Help", "--help-category"},
+		{config.HelpTargetName != "help", "--help-target-name"},
+		{config.ReplaceExistingHelp, "--replace-existing-help"},
+		{config.AllMakefiles, "--all-makefiles"},
 	}
 
 	for _, flag := range fileGenOnlyFlags {
@@ -384,8 +1302,10 @@ func getDefaultOutput(format string) string {
 		return "./make/help.mk"
 	case "text":
 		return "-" // stdout by default for text
-	case "json":
+	case "json", "ndjson":
 		return "-" // stdout by default for programmatic consumption
+	case "slack":
+		return "-" // stdout by default, for piping to a chat bot
 	case "html":
 		return "./make-help.html"
 	case "markdown":
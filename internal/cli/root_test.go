@@ -706,6 +706,52 @@ func TestMutualExclusivityFlags(t *testing.T) {
 	}
 }
 
+func TestModeConflicts(t *testing.T) {
+	t.Parallel()
+	cmd := NewRootCmd()
+	isSet := modeFlagsSet(cmd, NewConfig())
+
+	// Every flag in the table is a real flag that modeFlagsSet knows about
+	for _, mode := range modeConflicts {
+		for _, flagName := range append([]string{mode.mode}, mode.conflicts...) {
+			name := strings.TrimPrefix(flagName, "--")
+			assert.True(t, cmd.Flags().Lookup(name) != nil || cmd.PersistentFlags().Lookup(name) != nil, flagName)
+			_, ok := isSet[flagName]
+			assert.True(t, ok, "modeFlagsSet does not report %s", flagName)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{"run with format", []string{"--run", "build", "--format", "json"}, "--run cannot be used with --format"},
+		{"first conflict of the first mode", []string{"--no-exec", "--preview", "build", "--sandbox"}, "--no-exec cannot be used with --preview"},
+		{"export with target", []string{"--export", "taskfile", "--target", "build"}, "--export cannot be used with --target"},
+		{"env export with target", []string{"--export", "env", "--target", "build"}, ""},
+		{"render fixture to stdout", []string{"--render-fixture", "--output", "-"}, ""},
+		{"render fixture to a file", []string{"--render-fixture", "--output", "help.txt"}, "--render-fixture cannot be used with --output"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cmd := &cobra.Command{Use: "test"}
+			config := NewConfig()
+			setupFlags(cmd, config)
+			require.NoError(t, cmd.ParseFlags(tt.args))
+
+			err := validateModeConflicts(cmd, config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Equal(t, tt.wantErr, err.Error())
+			}
+		})
+	}
+}
+
 func TestRemoveHelpFlagRestrictions(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -742,6 +788,18 @@ func TestRemoveHelpFlagRestrictions(t *testing.T) {
 			expectError:    true,
 			expectedErrMsg: "--remove-help cannot be used with --include-all-phony",
 		},
+		{
+			name:           "remove-help with profile",
+			args:           []string{"--remove-help", "--profile", "ci"},
+			expectError:    true,
+			expectedErrMsg: "--remove-help cannot be used with --profile",
+		},
+		{
+			name:           "remove-help with max-targets-per-category",
+			args:           []string{"--remove-help", "--max-targets-per-category", "10"},
+			expectError:    true,
+			expectedErrMsg: "--remove-help cannot be used with --max-targets-per-category",
+		},
 		{
 			name:           "remove-help with output stdout",
 			args:           []string{"--remove-help", "--output", "-"},
@@ -857,6 +915,21 @@ func TestDynamicStaticFlagValidation(t *testing.T) {
 			args:           []string{"--update-opts", "foo", "--output", "-"},
 			expectedErrMsg: "--update-opts is only valid for file generation mode",
 		},
+		{
+			name:           "resolve-remote with from-model",
+			args:           []string{"--resolve-remote", "--from-model", "model.json", "--output", "-"},
+			expectedErrMsg: "--from-model cannot be used with --resolve-remote",
+		},
+		{
+			name:           "regen-target with stdout mode",
+			args:           []string{"--regen-target", "--output", "-"},
+			expectedErrMsg: "--regen-target is only valid for file generation mode",
+		},
+		{
+			name:           "regen-target with remove-help",
+			args:           []string{"--regen-target", "--remove-help"},
+			expectedErrMsg: "--remove-help cannot be used with --regen-target",
+		},
 		{
 			name:           "help-category with stdout mode",
 			args:           []string{"--help-category", "Custom", "--output", "-"},
@@ -867,6 +940,21 @@ func TestDynamicStaticFlagValidation(t *testing.T) {
 			args:           []string{"--help-file-rel-path", "foo.mk", "--output", "-"},
 			expectedErrMsg: "--help-file-rel-path is only valid for file generation mode",
 		},
+		{
+			name:           "help-target-name with stdout mode",
+			args:           []string{"--help-target-name", "usage", "--output", "-"},
+			expectedErrMsg: "--help-target-name is only valid for file generation mode",
+		},
+		{
+			name:           "invalid help-target-name",
+			args:           []string{"--help-target-name", "my help"},
+			expectedErrMsg: "invalid help target name",
+		},
+		{
+			name:           "replace-existing-help with stdout mode",
+			args:           []string{"--replace-existing-help", "--output", "-"},
+			expectedErrMsg: "--replace-existing-help is only valid for file generation mode",
+		},
 	}
 
 	for _, tt := range tests {
@@ -1344,3 +1432,1610 @@ func TestFlagGroupsFunc(t *testing.T) {
 	assert.Contains(t, output, "--makefile-path")
 	assert.Contains(t, output, "--verbose")
 }
+
+func TestTOCFlagValidation(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		args      []string
+		errorText string
+	}{
+		{
+			name:      "toc with html format",
+			args:      []string{"--toc", "--format", "html", "--output", "-"},
+			errorText: "--toc requires --format markdown",
+		},
+		{
+			name:      "toc with markdown format",
+			args:      []string{"--toc", "--format", "md", "--output", "-", "--makefile-path", "/nonexistent/Makefile"},
+			errorText: "Makefile not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cmd := NewRootCmd()
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorText)
+		})
+	}
+}
+
+func TestProvenanceFlagValidation(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		args      []string
+		errorText string
+	}{
+		{
+			name:      "provenance with text format",
+			args:      []string{"--provenance", "--format", "text", "--output", "-"},
+			errorText: "--provenance requires --format markdown or html",
+		},
+		{
+			name:      "provenance and no-provenance",
+			args:      []string{"--provenance", "--no-provenance", "--format", "html", "--output", "-"},
+			errorText: "cannot use both --provenance and --no-provenance flags",
+		},
+		{
+			name:      "provenance with html format",
+			args:      []string{"--provenance", "--format", "html", "--output", "-", "--makefile-path", "/nonexistent/Makefile"},
+			errorText: "Makefile not found",
+		},
+		{
+			name:      "provenance with markdown in output-dir list",
+			args:      []string{"--provenance", "--format", "text,md", "--output-dir", "out", "--makefile-path", "/nonexistent/Makefile"},
+			errorText: "Makefile not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cmd := NewRootCmd()
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorText)
+		})
+	}
+}
+
+func TestMDLayoutFlagValidation(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		args      []string
+		errorText string
+	}{
+		{
+			name:      "invalid layout",
+			args:      []string{"--md-layout", "grid", "--format", "markdown", "--output", "-"},
+			errorText: "invalid markdown layout: grid",
+		},
+		{
+			name:      "table layout with text format",
+			args:      []string{"--md-layout", "table", "--format", "text", "--output", "-"},
+			errorText: "--md-layout requires --format markdown",
+		},
+		{
+			name:      "table layout with markdown format",
+			args:      []string{"--md-layout", "table", "--format", "markdown", "--output", "-", "--makefile-path", "/nonexistent/Makefile"},
+			errorText: "Makefile not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cmd := NewRootCmd()
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorText)
+		})
+	}
+}
+
+func TestFileFlagValidation(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		args      []string
+		errorText string
+	}{
+		{
+			name:      "invalid grouping",
+			args:      []string{"--group-by", "owner", "--output", "-"},
+			errorText: "invalid grouping: owner (valid: category, file)",
+		},
+		{
+			name:      "invalid only-file pattern",
+			args:      []string{"--only-file", "make/[", "--output", "-"},
+			errorText: `invalid --only-file pattern "make/["`,
+		},
+		{
+			name:      "invalid exclude-target pattern",
+			args:      []string{"--exclude-target", "internal-[", "--output", "-"},
+			errorText: `invalid --exclude-target pattern "internal-["`,
+		},
+		{
+			name:      "invalid redact-pattern",
+			args:      []string{"--redact-pattern", "sk_live_(", "--output", "-"},
+			errorText: `invalid --redact-pattern "sk_live_("`,
+		},
+		{
+			name:      "no-redact with redact-pattern",
+			args:      []string{"--no-redact", "--redact-pattern", "sk_live_\\w+", "--output", "-"},
+			errorText: "cannot use both --no-redact and --redact-pattern flags",
+		},
+		{
+			name:      "remove-help with no-redact",
+			args:      []string{"--remove-help", "--no-redact"},
+			errorText: "--remove-help cannot be used with --no-redact",
+		},
+		{
+			name:      "remove-help with exclude-file",
+			args:      []string{"--remove-help", "--exclude-file", "third_party/**"},
+			errorText: "--remove-help cannot be used with --exclude-file",
+		},
+		{
+			name:      "remove-help with only-file",
+			args:      []string{"--remove-help", "--only-file", "make/docker.mk"},
+			errorText: "--remove-help cannot be used with --only-file",
+		},
+		{
+			name:      "remove-help with entry-point",
+			args:      []string{"--remove-help", "--entry-point", "make/docker.mk"},
+			errorText: "--remove-help cannot be used with --entry-point",
+		},
+		{
+			name:      "remove-help with group-by",
+			args:      []string{"--remove-help", "--group-by", "file"},
+			errorText: "--remove-help cannot be used with --group-by",
+		},
+		{
+			name:      "group by file",
+			args:      []string{"--group-by", "file", "--output", "-", "--makefile-path", "/nonexistent/Makefile"},
+			errorText: "Makefile not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cmd := NewRootCmd()
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorText)
+		})
+	}
+}
+
+func TestTextLayoutFlagValidation(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		args      []string
+		errorText string
+	}{
+		{
+			name:      "compact and long",
+			args:      []string{"--compact", "--long", "--format", "text", "--output", "-"},
+			errorText: "cannot use both --compact and --long flags",
+		},
+		{
+			name:      "compact with make format",
+			args:      []string{"--compact", "--format", "make", "--output", "-"},
+			errorText: "--compact requires --format text",
+		},
+		{
+			name:      "compact with stdout default format",
+			args:      []string{"--compact", "--output", "-", "--makefile-path", "/nonexistent/Makefile"},
+			errorText: "Makefile not found",
+		},
+		{
+			name:      "long with stdout default format",
+			args:      []string{"--long", "--output", "-", "--makefile-path", "/nonexistent/Makefile"},
+			errorText: "Makefile not found",
+		},
+		{
+			name:      "long with markdown format",
+			args:      []string{"--long", "--format", "markdown", "--output", "-"},
+			errorText: "--long requires --format text",
+		},
+		{
+			name:      "remove-help with compact",
+			args:      []string{"--remove-help", "--compact"},
+			errorText: "--remove-help cannot be used with --compact",
+		},
+		{
+			name:      "long with text format",
+			args:      []string{"--long", "--format", "text", "--output", "-", "--makefile-path", "/nonexistent/Makefile"},
+			errorText: "Makefile not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cmd := NewRootCmd()
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorText)
+		})
+	}
+}
+
+func TestSpellFlagValidation(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		args      []string
+		errorText string
+	}{
+		{
+			name:      "spell without lint",
+			args:      []string{"--spell"},
+			errorText: "--spell requires --lint",
+		},
+		{
+			name:      "spell-lang without spell",
+			args:      []string{"--lint", "--spell-lang", "en"},
+			errorText: "--spell-lang requires --spell",
+		},
+		{
+			name:      "unknown language",
+			args:      []string{"--lint", "--spell", "--spell-lang", "xx"},
+			errorText: "invalid --spell-lang: xx (available: en)",
+		},
+		{
+			name:      "spell with lint",
+			args:      []string{"--lint", "--spell", "--makefile-path", "/nonexistent/Makefile"},
+			errorText: "Makefile not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cmd := NewRootCmd()
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorText)
+		})
+	}
+}
+
+func TestFreshnessFlagValidation(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		args      []string
+		errorText string
+	}{
+		{
+			name:      "freshness without lint",
+			args:      []string{"--freshness"},
+			errorText: "--freshness requires --lint",
+		},
+		{
+			name:      "freshness-days without freshness",
+			args:      []string{"--lint", "--freshness-days", "7"},
+			errorText: "--freshness-days requires --freshness",
+		},
+		{
+			name:      "negative freshness-days",
+			args:      []string{"--lint", "--freshness", "--freshness-days", "-1"},
+			errorText: "--freshness-days cannot be negative",
+		},
+		{
+			name:      "freshness with lint",
+			args:      []string{"--lint", "--freshness", "--makefile-path", "/nonexistent/Makefile"},
+			errorText: "Makefile not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cmd := NewRootCmd()
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorText)
+		})
+	}
+}
+
+func TestGitBlameFlagValidation(t *testing.T) {
+	t.Parallel()
+	cmd := NewRootCmd()
+	cmd.SetArgs([]string{"--from-model", "model.json", "--output", "-", "--git-blame"})
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--from-model cannot be used with --git-blame")
+
+	cmd = NewRootCmd()
+	cmd.SetArgs([]string{"--output", "-", "--git-blame", "--makefile-path", "/nonexistent/Makefile"})
+	err = cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Makefile not found")
+}
+
+func TestHighlightNewFlagValidation(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		args      []string
+		errorText string
+	}{
+		{
+			name:      "invalid window",
+			args:      []string{"--format", "text", "--output", "-", "--highlight-new", "soon"},
+			errorText: "invalid --highlight-new window: soon",
+		},
+		{
+			name:      "negative window",
+			args:      []string{"--format", "text", "--output", "-", "--highlight-new", "-14d"},
+			errorText: "invalid --highlight-new window: -14d",
+		},
+		{
+			name:      "unsupported format",
+			args:      []string{"--format", "json", "--output", "-", "--highlight-new", "14d"},
+			errorText: "--highlight-new requires --format text, markdown, or html",
+		},
+		{
+			name:      "stdout default format",
+			args:      []string{"--output", "-", "--highlight-new", "14d", "--makefile-path", "/nonexistent/Makefile"},
+			errorText: "Makefile not found",
+		},
+		{
+			name:      "from model",
+			args:      []string{"--from-model", "model.json", "--format", "text", "--output", "-", "--highlight-new", "14d"},
+			errorText: "--from-model cannot be used with --highlight-new",
+		},
+		{
+			name:      "remove help",
+			args:      []string{"--remove-help", "--highlight-new", "14d"},
+			errorText: "--remove-help cannot be used with --highlight-new",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cmd := NewRootCmd()
+			cmd.SetArgs(tt.args)
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorText)
+		})
+	}
+}
+
+func TestExportFlagValidation(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		args      []string
+		errorText string
+	}{
+		{
+			name:      "unknown schema",
+			args:      []string{"--export", "gradle"},
+			errorText: "invalid export schema: gradle (valid: taskfile, package-scripts, vscode, fzf, env)",
+		},
+		{
+			name:      "export with lint",
+			args:      []string{"--export", "taskfile", "--lint"},
+			errorText: "--export cannot be used with --lint",
+		},
+		{
+			name:      "export with format",
+			args:      []string{"--export", "taskfile", "--format", "json"},
+			errorText: "--export cannot be used with --format",
+		},
+		{
+			name:      "target with a schema other than env",
+			args:      []string{"--export", "taskfile", "--target", "build"},
+			errorText: "--export cannot be used with --target",
+		},
+		{
+			name:      "tag without export",
+			args:      []string{"--tag", "ci"},
+			errorText: "--tag requires --export",
+		},
+		{
+			name:      "export with remove-help",
+			args:      []string{"--remove-help", "--export", "taskfile"},
+			errorText: "--remove-help cannot be used with --export",
+		},
+		{
+			name:      "export to a file",
+			args:      []string{"--export", "package-scripts", "--output", "scripts.json", "--makefile-path", "/nonexistent/Makefile"},
+			errorText: "Makefile not found",
+		},
+		{
+			name:      "env for one target",
+			args:      []string{"--export", "env", "--target", "deploy", "--makefile-path", "/nonexistent/Makefile"},
+			errorText: "Makefile not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cmd := NewRootCmd()
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorText)
+		})
+	}
+}
+
+func TestListFlagValidation(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		args      []string
+		errorText string
+	}{
+		{
+			name:      "unknown scope",
+			args:      []string{"--list", "hidden"},
+			errorText: "invalid list scope: hidden (valid: documented, all, phony)",
+		},
+		{
+			name:      "list with output",
+			args:      []string{"--list", "all", "--output", "targets.txt"},
+			errorText: "--list cannot be used with --output",
+		},
+		{
+			name:      "list-columns without list",
+			args:      []string{"--list-columns"},
+			errorText: "--list-columns requires --list",
+		},
+		{
+			name:      "list with remove-help",
+			args:      []string{"--remove-help", "--list", "all"},
+			errorText: "--remove-help cannot be used with --list",
+		},
+		{
+			name:      "list documented",
+			args:      []string{"--list", "documented", "--list-columns", "--makefile-path", "/nonexistent/Makefile"},
+			errorText: "Makefile not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cmd := NewRootCmd()
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorText)
+		})
+	}
+}
+
+func TestCategoriesFlagValidation(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		args      []string
+		errorText string
+	}{
+		{
+			name:      "unsupported format",
+			args:      []string{"--categories", "--format", "html"},
+			errorText: "--categories supports --format text or json, not html",
+		},
+		{
+			name:      "categories with output",
+			args:      []string{"--categories", "--output", "categories.txt"},
+			errorText: "--categories cannot be used with --output",
+		},
+		{
+			name:      "categories with list",
+			args:      []string{"--categories", "--list", "all"},
+			errorText: "--list cannot be used with --categories",
+		},
+		{
+			name:      "categories with remove-help",
+			args:      []string{"--remove-help", "--categories"},
+			errorText: "--remove-help cannot be used with --categories",
+		},
+		{
+			name:      "categories json",
+			args:      []string{"--categories", "--format", "json", "--makefile-path", "/nonexistent/Makefile"},
+			errorText: "Makefile not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cmd := NewRootCmd()
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorText)
+		})
+	}
+}
+
+func TestVarsFlagValidation(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		args      []string
+		errorText string
+	}{
+		{
+			name:      "unsupported format",
+			args:      []string{"--vars", "--format", "html"},
+			errorText: "--vars supports --format text, json, or markdown, not html",
+		},
+		{
+			name:      "vars with output",
+			args:      []string{"--vars", "--output", "vars.md"},
+			errorText: "--vars cannot be used with --output",
+		},
+		{
+			name:      "vars with categories",
+			args:      []string{"--vars", "--categories"},
+			errorText: "--vars cannot be used with --categories",
+		},
+		{
+			name:      "vars with remove-help",
+			args:      []string{"--remove-help", "--vars"},
+			errorText: "--remove-help cannot be used with --vars",
+		},
+		{
+			name:      "vars markdown",
+			args:      []string{"--vars", "--format", "md", "--makefile-path", "/nonexistent/Makefile"},
+			errorText: "Makefile not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cmd := NewRootCmd()
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorText)
+		})
+	}
+}
+
+func TestValidateOnlyFlagValidation(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		args      []string
+		errorText string
+	}{
+		{
+			name:      "unsupported format",
+			args:      []string{"--validate-only", "--format", "markdown"},
+			errorText: "--validate-only supports --format text or json, not markdown",
+		},
+		{
+			name:      "validate-only with lint",
+			args:      []string{"--validate-only", "--lint"},
+			errorText: "--validate-only cannot be used with --lint",
+		},
+		{
+			name:      "validate-only with output",
+			args:      []string{"--validate-only", "--output", "help.mk"},
+			errorText: "--validate-only cannot be used with --output",
+		},
+		{
+			name:      "validate-only with best-effort",
+			args:      []string{"--validate-only", "--best-effort"},
+			errorText: "--validate-only cannot be used with --best-effort",
+		},
+		{
+			name:      "validate-only with remove-help",
+			args:      []string{"--remove-help", "--validate-only"},
+			errorText: "--remove-help cannot be used with --validate-only",
+		},
+		{
+			name:      "validate-only json",
+			args:      []string{"--validate-only", "--format", "json", "--makefile-path", "/nonexistent/Makefile"},
+			errorText: "Makefile not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cmd := NewRootCmd()
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorText)
+		})
+	}
+}
+
+func TestAllMakefilesFlagValidation(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		args      []string
+		errorText string
+	}{
+		{
+			name:      "all-makefiles with makefile-path",
+			args:      []string{"--all-makefiles", "--makefile-path", "Makefile"},
+			errorText: "--all-makefiles cannot be used with --makefile-path",
+		},
+		{
+			name:      "all-makefiles with output",
+			args:      []string{"--all-makefiles", "--output", "-"},
+			errorText: "--all-makefiles cannot be used with --output",
+		},
+		{
+			name:      "all-makefiles with format",
+			args:      []string{"--all-makefiles", "--format", "markdown"},
+			errorText: "--all-makefiles cannot be used with --format",
+		},
+		{
+			name:      "all-makefiles with lint",
+			args:      []string{"--all-makefiles", "--lint"},
+			errorText: "--all-makefiles is only valid for file generation mode",
+		},
+		{
+			name:      "all-makefiles with remove-help",
+			args:      []string{"--remove-help", "--all-makefiles"},
+			errorText: "--remove-help cannot be used with --all-makefiles",
+		},
+		{
+			name:      "chdir to missing directory",
+			args:      []string{"--chdir", "/nonexistent/project"},
+			errorText: "failed to change to directory /nonexistent/project",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cmd := NewRootCmd()
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorText)
+		})
+	}
+}
+
+func TestShellInitFlagValidation(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		args      []string
+		errorText string
+	}{
+		{
+			name:      "unknown shell",
+			args:      []string{"--shell-init", "fish"},
+			errorText: "invalid shell: fish (valid: zsh, bash)",
+		},
+		{
+			name:      "shell-init with another flag",
+			args:      []string{"--shell-init", "zsh", "--lint"},
+			errorText: "--shell-init cannot be used with --lint",
+		},
+		{
+			name:      "shell-init with a target",
+			args:      []string{"--shell-init", "bash", "build"},
+			errorText: "--shell-init does not take arguments",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cmd := NewRootCmd()
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorText)
+		})
+	}
+}
+
+func TestUndoFlagValidation(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		args      []string
+		errorText string
+	}{
+		{
+			name:      "undo with another flag",
+			args:      []string{"--undo", "--remove-help"},
+			errorText: "--undo cannot be used with --remove-help",
+		},
+		{
+			name:      "undo with a target",
+			args:      []string{"--undo", "build"},
+			errorText: "--undo does not take arguments",
+		},
+		{
+			name:      "negative lock timeout",
+			args:      []string{"--undo", "--lock-timeout", "-1s"},
+			errorText: "--lock-timeout cannot be negative",
+		},
+		{
+			name:      "undo with makefile-path",
+			args:      []string{"--undo", "--makefile-path", "/nonexistent/Makefile"},
+			errorText: "nothing to undo",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cmd := NewRootCmd()
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorText)
+		})
+	}
+}
+
+func TestCleanFlagValidation(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		args      []string
+		errorText string
+	}{
+		{
+			name:      "clean with another flag",
+			args:      []string{"--clean", "--lint"},
+			errorText: "--clean cannot be used with --lint",
+		},
+		{
+			name:      "clean with a target",
+			args:      []string{"--clean", "build"},
+			errorText: "--clean does not take arguments",
+		},
+		{
+			name:      "missing workdir",
+			args:      []string{"--clean", "--workdir", "/nonexistent/workdir"},
+			errorText: "--workdir /nonexistent/workdir is not a directory",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cmd := NewRootCmd()
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorText)
+		})
+	}
+}
+
+func TestEnvAllowFlagValidation(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		args      []string
+		errorText string
+	}{
+		{
+			name:      "env-allow without scrub-env",
+			args:      []string{"--env-allow", "GOPATH"},
+			errorText: "--env-allow requires --scrub-env or --sandbox",
+		},
+		{
+			name:      "invalid variable name",
+			args:      []string{"--scrub-env", "--env-allow", "GO PATH"},
+			errorText: `invalid environment variable name for --env-allow: "GO PATH"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cmd := NewRootCmd()
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorText)
+		})
+	}
+}
+
+func TestNoExecFlagValidation(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		args      []string
+		errorText string
+	}{
+		{
+			name:      "no-exec with run",
+			args:      []string{"--no-exec", "--run", "build"},
+			errorText: "--no-exec cannot be used with --run",
+		},
+		{
+			name:      "no-exec with remove-help",
+			args:      []string{"--no-exec", "--remove-help"},
+			errorText: "--no-exec cannot be used with --remove-help",
+		},
+		{
+			name:      "no-exec with sandbox",
+			args:      []string{"--no-exec", "--sandbox"},
+			errorText: "--no-exec cannot be used with --sandbox",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cmd := NewRootCmd()
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorText)
+		})
+	}
+}
+
+func TestRenameFlagValidation(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		args      []string
+		errorText string
+	}{
+		{
+			name:      "rename without fix",
+			args:      []string{"--lint", "--rename"},
+			errorText: "--rename requires --fix",
+		},
+		{
+			name:      "rename with fix",
+			args:      []string{"--lint", "--fix", "--rename", "--makefile-path", "/nonexistent/Makefile"},
+			errorText: "Makefile not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cmd := NewRootCmd()
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorText)
+		})
+	}
+}
+
+func TestLintReportFlagValidation(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		args      []string
+		errorText string
+	}{
+		{
+			name:      "stats without lint",
+			args:      []string{"--stats", "json"},
+			errorText: "--stats requires --lint",
+		},
+		{
+			name:      "unknown format",
+			args:      []string{"--lint", "--stats", "html"},
+			errorText: "invalid --stats format: html (valid: markdown, json)",
+		},
+		{
+			name:      "stats with fix",
+			args:      []string{"--lint", "--fix", "--stats", "json"},
+			errorText: "--stats cannot be used with --fix",
+		},
+		{
+			name:      "baseline without lint",
+			args:      []string{"--baseline", ".make-help-baseline.json"},
+			errorText: "--baseline requires --lint",
+		},
+		{
+			name:      "top without stats",
+			args:      []string{"--lint", "--top", "5"},
+			errorText: "--top requires --stats or --analyze",
+		},
+		{
+			name:      "top not positive",
+			args:      []string{"--lint", "--stats", "json", "--top", "0"},
+			errorText: "--top must be positive",
+		},
+		{
+			name:      "stats with lint",
+			args:      []string{"--lint", "--stats", "markdown", "--top", "5", "--makefile-path", "/nonexistent/Makefile"},
+			errorText: "Makefile not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cmd := NewRootCmd()
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorText)
+		})
+	}
+}
+
+func TestGraphFlagValidation(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		args      []string
+		errorText string
+	}{
+		{
+			name:      "unknown format",
+			args:      []string{"--graph", "svg"},
+			errorText: "invalid graph format: svg (valid: dot, mermaid)",
+		},
+		{
+			name:      "graph with lint",
+			args:      []string{"--graph", "dot", "--lint"},
+			errorText: "--graph cannot be used with --lint",
+		},
+		{
+			name:      "documented-only without graph",
+			args:      []string{"--documented-only"},
+			errorText: "--documented-only requires --graph",
+		},
+		{
+			name:      "highlight-cycles without graph",
+			args:      []string{"--highlight-cycles"},
+			errorText: "--highlight-cycles requires --graph",
+		},
+		{
+			name:      "analyze with graph",
+			args:      []string{"--analyze", "--graph", "dot"},
+			errorText: "--analyze cannot be used with --graph",
+		},
+		{
+			name:      "analyze with unsupported format",
+			args:      []string{"--analyze", "--format", "markdown"},
+			errorText: "--analyze supports --format text or json, not markdown",
+		},
+		{
+			name:      "analyze with top",
+			args:      []string{"--analyze", "--format", "json", "--top", "5", "--makefile-path", "/nonexistent/Makefile"},
+			errorText: "Makefile not found",
+		},
+		{
+			name:      "graph with options",
+			args:      []string{"--graph", "mermaid", "--documented-only", "--highlight-cycles", "--makefile-path", "/nonexistent/Makefile"},
+			errorText: "Makefile not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cmd := NewRootCmd()
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorText)
+		})
+	}
+}
+
+func TestSummaryWidthFlagValidation(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		args      []string
+		errorText string
+	}{
+		{
+			name:      "negative width",
+			args:      []string{"--summary-width", "-1", "--output", "-"},
+			errorText: "--summary-width must not be negative",
+		},
+		{
+			name:      "width with json format",
+			args:      []string{"--summary-width", "40", "--format", "json", "--output", "-"},
+			errorText: "--summary-width requires --format text or make",
+		},
+		{
+			name:      "width with text format",
+			args:      []string{"--summary-width", "40", "--format", "text", "--output", "-", "--makefile-path", "/nonexistent/Makefile"},
+			errorText: "Makefile not found",
+		},
+		{
+			name:      "remove-help with width",
+			args:      []string{"--remove-help", "--summary-width", "40"},
+			errorText: "--remove-help cannot be used with --summary-width",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cmd := NewRootCmd()
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorText)
+		})
+	}
+}
+
+func TestShowStatsFlagValidation(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		args      []string
+		errorText string
+	}{
+		{
+			name:      "stats with html format",
+			args:      []string{"--show-stats", "--format", "html", "--output", "-"},
+			errorText: "--show-stats requires --format text, make, markdown, or json",
+		},
+		{
+			name:      "stats with json format",
+			args:      []string{"--show-stats", "--format", "json", "--output", "-", "--makefile-path", "/nonexistent/Makefile"},
+			errorText: "Makefile not found",
+		},
+		{
+			name:      "remove-help with stats",
+			args:      []string{"--remove-help", "--show-stats"},
+			errorText: "--remove-help cannot be used with --show-stats",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cmd := NewRootCmd()
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorText)
+		})
+	}
+}
+
+func TestIncludedFilesFlagValidation(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		args      []string
+		errorText string
+	}{
+		{
+			name:      "invalid position",
+			args:      []string{"--included-files-position", "middle", "--output", "-"},
+			errorText: "invalid included files position: middle (valid: top, bottom)",
+		},
+		{
+			name:      "negative depth",
+			args:      []string{"--file-docs-depth", "-1", "--output", "-"},
+			errorText: "--file-docs-depth must not be negative",
+		},
+		{
+			name:      "hidden files with position",
+			args:      []string{"--no-included-files", "--included-files-position", "bottom", "--output", "-"},
+			errorText: "cannot use both --no-included-files and --included-files-position flags",
+		},
+		{
+			name:      "hidden files with depth",
+			args:      []string{"--no-included-files", "--file-docs-depth", "2", "--output", "-"},
+			errorText: "cannot use both --no-included-files and --file-docs-depth flags",
+		},
+		{
+			name:      "hidden files with slack format",
+			args:      []string{"--no-included-files", "--format", "slack", "--output", "-"},
+			errorText: "--no-included-files requires --format text, make, markdown, html, or json",
+		},
+		{
+			name:      "depth with json format",
+			args:      []string{"--file-docs-depth", "2", "--format", "json", "--output", "-"},
+			errorText: "--file-docs-depth requires --format text, make, markdown, or html",
+		},
+		{
+			name:      "position with html format",
+			args:      []string{"--included-files-position", "bottom", "--format", "html", "--output", "-", "--makefile-path", "/nonexistent/Makefile"},
+			errorText: "Makefile not found",
+		},
+		{
+			name:      "remove-help with depth",
+			args:      []string{"--remove-help", "--file-docs-depth", "2"},
+			errorText: "--remove-help cannot be used with --file-docs-depth",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cmd := NewRootCmd()
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorText)
+		})
+	}
+}
+
+func TestMaxTargetsPerCategoryFlagValidation(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		args      []string
+		errorText string
+	}{
+		{
+			name:      "negative limit",
+			args:      []string{"--max-targets-per-category", "-1", "--output", "-"},
+			errorText: "--max-targets-per-category must not be negative",
+		},
+		{
+			name:      "limit with markdown format",
+			args:      []string{"--max-targets-per-category", "10", "--format", "markdown", "--output", "-"},
+			errorText: "--max-targets-per-category requires --format text or make",
+		},
+		{
+			name:      "limit with text format",
+			args:      []string{"--max-targets-per-category", "10", "--format", "text", "--output", "-", "--makefile-path", "/nonexistent/Makefile"},
+			errorText: "Makefile not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cmd := NewRootCmd()
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorText)
+		})
+	}
+}
+
+func TestHTMLPolicyFlagValidation(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		args      []string
+		errorText string
+	}{
+		{
+			name:      "html-raw without format",
+			args:      []string{"--html-raw", "strip", "--output", "-"},
+			errorText: "--html-raw requires --format html",
+		},
+		{
+			name:      "html-nonce with markdown format",
+			args:      []string{"--html-nonce", "abc", "--format", "markdown", "--output", "-"},
+			errorText: "--html-nonce requires --format html",
+		},
+		{
+			name:      "html-link-target-blank without format",
+			args:      []string{"--html-link-target-blank", "--output", "-"},
+			errorText: "--html-link-target-blank requires --format html",
+		},
+		{
+			name:      "invalid html-raw mode",
+			args:      []string{"--html-raw", "sanitize", "--format", "html", "--output", "-"},
+			errorText: "invalid raw HTML mode: sanitize (valid: escape, strip, allow)",
+		},
+		{
+			name:      "valid html policy",
+			args:      []string{"--html-raw", "allow", "--html-link-rel", "noopener", "--html-nonce", "abc", "--format", "html", "--output", "-", "--makefile-path", "/nonexistent/Makefile"},
+			errorText: "Makefile not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cmd := NewRootCmd()
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorText)
+		})
+	}
+}
+
+func TestNoScriptFlagValidation(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		args        []string
+		expectError bool
+		errorText   string
+	}{
+		{
+			name:        "no-script without format",
+			args:        []string{"--no-script", "--output", "-"},
+			expectError: true,
+			errorText:   "--no-script requires --format html",
+		},
+		{
+			name:        "no-script with markdown format",
+			args:        []string{"--no-script", "--format", "markdown", "--output", "-"},
+			expectError: true,
+			errorText:   "--no-script requires --format html",
+		},
+		{
+			name:        "no-script with html format",
+			args:        []string{"--no-script", "--format", "html", "--output", "-", "--makefile-path", "/nonexistent/Makefile"},
+			expectError: true,
+			errorText:   "Makefile not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cmd := NewRootCmd()
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			if tt.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorText)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPageFlagValidation(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		args      []string
+		errorText string
+	}{
+		{
+			name:      "page-size with text format",
+			args:      []string{"--page-size", "50", "--format", "text", "--output", "-"},
+			errorText: "--page-size requires --format json or html",
+		},
+		{
+			name:      "negative page-size",
+			args:      []string{"--page-size", "-1", "--format", "json", "--output", "-"},
+			errorText: "--page-size must not be negative",
+		},
+		{
+			name:      "page without page-size",
+			args:      []string{"--page", "2", "--format", "json", "--output", "-"},
+			errorText: "--page requires --page-size",
+		},
+		{
+			name:      "page zero",
+			args:      []string{"--page", "0", "--page-size", "50", "--format", "json", "--output", "-"},
+			errorText: "--page must be at least 1",
+		},
+		{
+			name:      "page-size with json format",
+			args:      []string{"--page-size", "50", "--page", "2", "--format", "json", "--output", "-", "--makefile-path", "/nonexistent/Makefile"},
+			errorText: "Makefile not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cmd := NewRootCmd()
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorText)
+		})
+	}
+}
+
+func TestSlackBlocksFlagValidation(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		args      []string
+		errorText string
+	}{
+		{
+			name:      "slack-blocks with text format",
+			args:      []string{"--slack-blocks", "--format", "text", "--output", "-"},
+			errorText: "--slack-blocks requires --format slack",
+		},
+		{
+			name:      "slack-blocks with slack format",
+			args:      []string{"--slack-blocks", "--format", "slack", "--makefile-path", "/nonexistent/Makefile"},
+			errorText: "Makefile not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cmd := NewRootCmd()
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorText)
+		})
+	}
+}
+
+func TestUsageStatsFlagValidation(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		args      []string
+		errorText string
+	}{
+		{
+			name:      "usage-stats with lint",
+			args:      []string{"--usage-stats", "--lint"},
+			errorText: "--usage-stats cannot be used with --lint",
+		},
+		{
+			name:      "usage-stats with a target argument",
+			args:      []string{"--usage-stats", "build"},
+			errorText: "--usage-stats does not take arguments",
+		},
+		{
+			name:      "usage-stats with html",
+			args:      []string{"--usage-stats", "--format", "html"},
+			errorText: "--usage-stats supports --format text or json, not html",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cmd := NewRootCmd()
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorText)
+		})
+	}
+}
+
+func TestDaemonFlagValidation(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		args      []string
+		errorText string
+	}{
+		{
+			name:      "daemon with a target argument",
+			args:      []string{"--daemon", "/tmp/make-help.sock", "build"},
+			errorText: "--daemon does not take arguments",
+		},
+		{
+			name:      "daemon with lint",
+			args:      []string{"--daemon", "/tmp/make-help.sock", "--lint"},
+			errorText: "--daemon cannot be used with --lint",
+		},
+		{
+			name:      "daemon with output",
+			args:      []string{"--daemon", "/tmp/make-help.sock", "--output", "-"},
+			errorText: "--daemon cannot be used with --output",
+		},
+		{
+			name:      "daemon with file generation flag",
+			args:      []string{"--daemon", "/tmp/make-help.sock", "--regen-target"},
+			errorText: "--regen-target is only valid for file generation mode",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cmd := NewRootCmd()
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorText)
+		})
+	}
+}
+
+func TestContainerFlagValidation(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		args      []string
+		errorText string
+	}{
+		{
+			name:      "path-map without container",
+			args:      []string{"--path-map", ".:/workspace", "--output", "-"},
+			errorText: "--path-map requires --container",
+		},
+		{
+			name:      "path-map without container path",
+			args:      []string{"--container", "--path-map", "/home/dev/app", "--output", "-"},
+			errorText: "expected host:container",
+		},
+		{
+			name:      "path-map with relative container path",
+			args:      []string{"--container", "--path-map", ".:workspace", "--output", "-"},
+			errorText: "is not absolute",
+		},
+		{
+			name:      "container with path-map",
+			args:      []string{"--container", "--path-map", ".:/workspace", "--output", "-", "--makefile-path", "/nonexistent/Makefile"},
+			errorText: "Makefile not found",
+		},
+		{
+			name:      "container alone",
+			args:      []string{"--container", "--lint", "--makefile-path", "/nonexistent/Makefile"},
+			errorText: "Makefile not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cmd := NewRootCmd()
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorText)
+		})
+	}
+}
+
+func TestPostProcessFlagValidation(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		args      []string
+		errorText string
+	}{
+		{
+			name:      "post-process when generating the help file",
+			args:      []string{"--post-process", "cat"},
+			errorText: "--post-process requires rendered help output",
+		},
+		{
+			name:      "post-process with lint",
+			args:      []string{"--post-process", "cat", "--lint"},
+			errorText: "--post-process requires rendered help output",
+		},
+		{
+			name:      "post-process with target",
+			args:      []string{"--post-process", "cat", "--output", "-", "--target", "build"},
+			errorText: "--post-process requires rendered help output",
+		},
+		{
+			name:      "post-process with stdout",
+			args:      []string{"--post-process", "cat", "--output", "-", "--makefile-path", "/nonexistent/Makefile"},
+			errorText: "Makefile not found",
+		},
+		{
+			name:      "post-process with markdown file",
+			args:      []string{"--post-process", "cat", "--format", "markdown", "--output", "help.md", "--makefile-path", "/nonexistent/Makefile"},
+			errorText: "Makefile not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cmd := NewRootCmd()
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorText)
+		})
+	}
+}
+
+func TestPositionalTargetValidation(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		args      []string
+		errorText string
+	}{
+		{
+			name:      "several positional targets",
+			args:      []string{"build", "test"},
+			errorText: "only one target can be shown at a time, got 2: build test",
+		},
+		{
+			name:      "positional target and --target",
+			args:      []string{"--target", "build", "test"},
+			errorText: "cannot use both --target and a positional target",
+		},
+		{
+			name:      "positional target with file output",
+			args:      []string{"build", "--output", "help.txt"},
+			errorText: "--target requires --output - (stdout mode)",
+		},
+		{
+			name:      "exact without target",
+			args:      []string{"--exact", "--output", "-"},
+			errorText: "--exact requires --target",
+		},
+		{
+			name:      "positional target implies stdout",
+			args:      []string{"build", "--makefile-path", "/nonexistent/Makefile"},
+			errorText: "Makefile not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cmd := NewRootCmd()
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorText)
+		})
+	}
+}
@@ -0,0 +1,350 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sdlcforge/make-help/internal/model"
+	"github.com/sdlcforge/make-help/internal/runstate"
+)
+
+// runTarget looks up a documented target, shows its summary and documented
+// variables, prompts for unset variables when stdin is a terminal, and then
+// runs make with the target and the collected VAR=value assignments.
+// It fails without running make if a required variable is still unset.
+// args holds VAR=value assignments given on the command line.
+func runTarget(config *Config, args []string) error {
+	assignments, err := parseRunAssignments(args)
+	if err != nil {
+		return err
+	}
+
+	inputs, err := loadModelInputs(config)
+	if err != nil {
+		return err
+	}
+
+	helpModel, err := buildHelpModelFromInputs(config, inputs)
+	if err != nil {
+		return err
+	}
+
+	found, err := findDocumentedTarget(helpModel, inputs, config.RunTarget)
+	if err != nil {
+		return err
+	}
+
+	if err := validateRunChoices(found.Variables, assignments); err != nil {
+		return err
+	}
+
+	printRunSummary(os.Stderr, found, assignments)
+	if warning := platformWarning(found, runtime.GOOS); warning != "" {
+		fmt.Fprintln(os.Stderr, warning)
+	}
+
+	if IsTerminal(os.Stdin.Fd()) {
+		if err := promptRunVariables(found.Variables, assignments, os.Stdin, os.Stderr); err != nil {
+			return err
+		}
+	}
+
+	if missing := missingRequiredVariables(found.Variables, assignments); len(missing) > 0 {
+		return fmt.Errorf("missing required variable(s) for %s: %s", found.Name, strings.Join(missing, ", "))
+	}
+
+	if found.Dangerous && !config.Yes {
+		if !IsTerminal(os.Stdin.Fd()) {
+			return fmt.Errorf("target '%s' is marked !danger; pass --yes to run it without confirmation", found.Name)
+		}
+		confirmed, err := confirmDangerousRun(found, os.Stdin, os.Stderr)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			return fmt.Errorf("%s was not run", found.Name)
+		}
+	}
+
+	makeArgs := append([]string{"-f", config.MakefilePath}, runMakeArgs(found, assignments, args)...)
+
+	if config.Verbose {
+		fmt.Fprintf(os.Stderr, "Running: make %s\n", strings.Join(makeArgs, " "))
+	}
+
+	makefileDir := filepath.Dir(config.MakefilePath)
+	command, err := newMakeExecutor(config).Command(config.runContext(), "make", makeArgs...)
+	if err != nil {
+		return err
+	}
+	command.Dir = makefileDir
+	command.Stdin = os.Stdin
+	command.Stdout = os.Stdout
+	command.Stderr = os.Stderr
+	start := time.Now()
+	if err := command.Run(); err != nil {
+		return fmt.Errorf("make %s failed: %w", found.Name, err)
+	}
+
+	// Only successful runs are recorded; failures say little about run time
+	if config.RecordDuration {
+		finished := time.Now()
+		if err := runstate.Record(makefileDir, found.Name, finished, finished.Sub(start)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// findDocumentedTarget returns the documented target with the given name or
+// alias, or an error telling undocumented targets apart from unknown ones.
+func findDocumentedTarget(helpModel *model.HelpModel, inputs *modelInputs, name string) (*model.Target, error) {
+	if found := findTargetByNameOrAlias(helpModel, name); found != nil {
+		return found, nil
+	}
+	for _, target := range inputs.Targets.Targets {
+		if target == name {
+			return nil, fmt.Errorf("target '%s' is not documented; run make %s directly", name, name)
+		}
+	}
+	return nil, fmt.Errorf("target '%s' not found", name)
+}
+
+// runMakeArgs returns the target and VAR=value arguments to pass to make:
+// documented variables in documentation order, then undocumented
+// assignments in command-line order.
+func runMakeArgs(target *model.Target, assignments map[string]string, args []string) []string {
+	makeArgs := []string{target.Name}
+	for _, v := range target.Variables {
+		if value, ok := assignments[v.Name]; ok {
+			makeArgs = append(makeArgs, v.Name+"="+value)
+		}
+	}
+	for _, arg := range args {
+		name, _, _ := strings.Cut(arg, "=")
+		if !hasVariable(target.Variables, name) {
+			makeArgs = append(makeArgs, arg)
+		}
+	}
+	return makeArgs
+}
+
+// parseRunAssignments parses VAR=value arguments into a map.
+func parseRunAssignments(args []string) (map[string]string, error) {
+	assignments := make(map[string]string)
+	for _, arg := range args {
+		name, value, ok := strings.Cut(arg, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid argument %q: expected VAR=value", arg)
+		}
+		assignments[name] = value
+	}
+	return assignments, nil
+}
+
+// findTargetByNameOrAlias returns the target with the given name or alias,
+// or nil if the model has no such target.
+func findTargetByNameOrAlias(helpModel *model.HelpModel, name string) *model.Target {
+	for i := range helpModel.Categories {
+		for j := range helpModel.Categories[i].Targets {
+			target := &helpModel.Categories[i].Targets[j]
+			if target.Name == name {
+				return target
+			}
+			for _, alias := range target.Aliases {
+				if alias == name {
+					return target
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// printRunSummary writes the target's summary and documented variables,
+// marking variables already set on the command line or in the environment.
+func printRunSummary(w io.Writer, target *model.Target, assignments map[string]string) {
+	if len(target.Summary) > 0 {
+		fmt.Fprintf(w, "%s: %s\n", target.Name, target.Summary[0])
+	} else {
+		fmt.Fprintf(w, "%s\n", target.Name)
+	}
+	if target.Dangerous {
+		fmt.Fprintf(w, "  Danger: %s\n", dangerReason(target))
+	}
+	printRunVariables(w, target.Variables, assignments)
+}
+
+// dangerReason returns a !danger target's reason, or "destructive" when
+// none was given.
+func dangerReason(target *model.Target) string {
+	if target.DangerReason == "" {
+		return "destructive"
+	}
+	return target.DangerReason
+}
+
+// confirmDangerousRun asks whether to run a target marked with !danger.
+// Only "y" or "yes" confirms; anything else, including end of input,
+// declines.
+func confirmDangerousRun(target *model.Target, in io.Reader, out io.Writer) (bool, error) {
+	fmt.Fprintf(out, "%s is marked dangerous: %s\nRun it? [y/N]: ", target.Name, dangerReason(target))
+	line, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, fmt.Errorf("failed to read confirmation: %w", err)
+	}
+	answer := strings.ToLower(strings.TrimSpace(line))
+	return answer == "y" || answer == "yes", nil
+}
+
+// printRunVariables writes one line per documented variable with its value
+// from the command line or environment, or whether it is unset.
+func printRunVariables(w io.Writer, variables []model.Variable, assignments map[string]string) {
+	for _, v := range variables {
+		status := "unset"
+		if v.Required {
+			status = "unset (required)"
+		}
+		if value, ok := assignments[v.Name]; ok {
+			status = "= " + value
+		} else if value, ok := os.LookupEnv(v.Name); ok {
+			status = "= " + value + " (from environment)"
+		}
+		fmt.Fprintf(w, "  %s %s", v.Name, status)
+		if len(v.Choices) > 0 {
+			fmt.Fprintf(w, " [%s]", strings.Join(v.Choices, "|"))
+		}
+		if v.Description != "" {
+			fmt.Fprintf(w, " - %s", v.Description)
+		}
+		fmt.Fprintln(w)
+	}
+}
+
+// promptRunVariables asks for each documented variable that is neither
+// assigned on the command line nor set in the environment. Variables with
+// choices are shown as a numbered picker that accepts a number or a value.
+// An empty answer leaves the variable unset.
+func promptRunVariables(variables []model.Variable, assignments map[string]string, in io.Reader, out io.Writer) error {
+	reader := bufio.NewReader(in)
+	for _, v := range variables {
+		if _, ok := assignments[v.Name]; ok {
+			continue
+		}
+		if _, ok := os.LookupEnv(v.Name); ok {
+			continue
+		}
+
+		label := v.Name
+		if v.Required {
+			label += " (required)"
+		}
+		if len(v.Choices) > 0 {
+			fmt.Fprintf(out, "%s:\n", label)
+			for i, choice := range v.Choices {
+				fmt.Fprintf(out, "  %d) %s\n", i+1, choice)
+			}
+			label = fmt.Sprintf("Choose %s [1-%d]", v.Name, len(v.Choices))
+		}
+
+		for {
+			fmt.Fprintf(out, "%s: ", label)
+			line, err := reader.ReadString('\n')
+			if err != nil && err != io.EOF {
+				return fmt.Errorf("failed to read %s: %w", v.Name, err)
+			}
+
+			value := strings.TrimSpace(line)
+			if value != "" && len(v.Choices) > 0 {
+				choice, ok := resolveChoice(v.Choices, value)
+				if !ok {
+					fmt.Fprintf(out, "invalid choice %q\n", value)
+					if err == io.EOF {
+						return nil
+					}
+					continue
+				}
+				value = choice
+			}
+			if value != "" {
+				assignments[v.Name] = value
+			}
+
+			if err == io.EOF {
+				fmt.Fprintln(out)
+				return nil
+			}
+			break
+		}
+	}
+	return nil
+}
+
+// resolveChoice maps a picker answer (a 1-based index or a literal value)
+// to one of choices.
+func resolveChoice(choices []string, answer string) (string, bool) {
+	if index, err := strconv.Atoi(answer); err == nil && index >= 1 && index <= len(choices) {
+		return choices[index-1], true
+	}
+	for _, choice := range choices {
+		if choice == answer {
+			return choice, true
+		}
+	}
+	return "", false
+}
+
+// validateRunChoices returns an error if a variable with documented choices
+// is assigned a value outside them.
+func validateRunChoices(variables []model.Variable, assignments map[string]string) error {
+	for _, v := range variables {
+		value, ok := assignments[v.Name]
+		if !ok || value == "" || len(v.Choices) == 0 || slices.Contains(v.Choices, value) {
+			continue
+		}
+		return fmt.Errorf("invalid value %q for %s (choices: %s)", value, v.Name, strings.Join(v.Choices, ", "))
+	}
+	return nil
+}
+
+// missingRequiredVariables returns the names of required variables that are
+// empty both on the command line and in the environment.
+func missingRequiredVariables(variables []model.Variable, assignments map[string]string) []string {
+	var missing []string
+	for _, v := range variables {
+		if !v.Required || assignments[v.Name] != "" || os.Getenv(v.Name) != "" {
+			continue
+		}
+		missing = append(missing, v.Name)
+	}
+	return missing
+}
+
+// platformWarning returns a warning if the target declares supported
+// platforms (!os) and goos is not among them, or "" otherwise.
+func platformWarning(target *model.Target, goos string) string {
+	if len(target.Platforms) == 0 || slices.Contains(target.Platforms, goos) {
+		return ""
+	}
+	return fmt.Sprintf("warning: %s supports %s; running on %s",
+		target.Name, strings.Join(target.Platforms, ", "), goos)
+}
+
+// hasVariable reports whether variables contains a variable with the given name.
+func hasVariable(variables []model.Variable, name string) bool {
+	for _, v := range variables {
+		if v.Name == name {
+			return true
+		}
+	}
+	return false
+}
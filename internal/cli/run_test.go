@@ -0,0 +1,328 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sdlcforge/make-help/internal/model"
+	"github.com/sdlcforge/make-help/internal/runstate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunTarget(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Chdir(tmpDir)
+	makefilePath := filepath.Join(tmpDir, "Makefile")
+	makefile := `noop:
+	@true
+
+## !alias d
+## Deploy the app.
+## !var ENV - Target environment
+deploy:
+	@echo "$(ENV) $(EXTRA)" > deployed.txt
+
+undocumented:
+	@echo undocumented
+`
+	require.NoError(t, os.WriteFile(makefilePath, []byte(makefile), 0644))
+
+	config := NewConfig()
+	config.MakefilePath = makefilePath
+	config.RunTarget = "d"
+	require.NoError(t, runTarget(config, []string{"ENV=prod", "EXTRA=1"}))
+
+	output, err := os.ReadFile(filepath.Join(tmpDir, "deployed.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "prod 1\n", string(output))
+
+	config = NewConfig()
+	config.MakefilePath = makefilePath
+	config.RunTarget = "undocumented"
+	err = runTarget(config, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "target 'undocumented' is not documented")
+
+	config = NewConfig()
+	config.MakefilePath = makefilePath
+	config.RunTarget = "missing"
+	err = runTarget(config, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "target 'missing' not found")
+}
+
+func TestRunTarget_RequiredVariables(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Chdir(tmpDir)
+	makefilePath := filepath.Join(tmpDir, "Makefile")
+	makefile := `noop:
+	@true
+
+## Deploy the app.
+## !var MAKE_HELP_TEST_TOKEN (required) - API token
+## !var MAKE_HELP_TEST_REGION (required)
+deploy:
+	@touch deployed.txt
+`
+	require.NoError(t, os.WriteFile(makefilePath, []byte(makefile), 0644))
+
+	config := NewConfig()
+	config.MakefilePath = makefilePath
+	config.RunTarget = "deploy"
+	err := runTarget(config, []string{"MAKE_HELP_TEST_REGION="})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "missing required variable(s) for deploy: MAKE_HELP_TEST_TOKEN, MAKE_HELP_TEST_REGION")
+	assert.NoFileExists(t, filepath.Join(tmpDir, "deployed.txt"))
+
+	require.NoError(t, runTarget(config, []string{"MAKE_HELP_TEST_TOKEN=t", "MAKE_HELP_TEST_REGION=r"}))
+	assert.FileExists(t, filepath.Join(tmpDir, "deployed.txt"))
+}
+
+func TestRunTarget_ScrubEnv(t *testing.T) {
+	t.Setenv("MAKE_HELP_TEST_SECRET", "secret")
+	tmpDir := t.TempDir()
+	makefilePath := filepath.Join(tmpDir, "Makefile")
+	require.NoError(t, os.WriteFile(makefilePath, []byte("## Show the secret.\nshow:\n\t@echo \"[$$MAKE_HELP_TEST_SECRET]\" > secret.txt\n"), 0644))
+
+	config := NewConfig()
+	config.MakefilePath = makefilePath
+	config.RunTarget = "show"
+	config.ScrubEnv = true
+	require.NoError(t, runTarget(config, nil))
+
+	output, err := os.ReadFile(filepath.Join(tmpDir, "secret.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "[]\n", string(output), "--scrub-env applies to the run")
+}
+
+func TestRunTarget_RecordDuration(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	makefilePath := filepath.Join(tmpDir, "Makefile")
+	require.NoError(t, os.WriteFile(makefilePath, []byte("## Build it.\nbuild:\n\t@true\n"), 0644))
+
+	config := NewConfig()
+	config.MakefilePath = makefilePath
+	config.RunTarget = "build"
+	require.NoError(t, runTarget(config, nil))
+	assert.NoFileExists(t, runstate.Path(tmpDir), "durations are only recorded on request")
+
+	config.RecordDuration = true
+	require.NoError(t, runTarget(config, nil))
+	state, err := runstate.Load(tmpDir)
+	require.NoError(t, err)
+	assert.Contains(t, state.Targets, "build")
+}
+
+func TestRunTarget_Dangerous(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Chdir(tmpDir)
+	makefilePath := filepath.Join(tmpDir, "Makefile")
+	makefile := `noop:
+	@true
+
+## !danger destroys the production database
+## Drop the database.
+db-drop:
+	@touch dropped.txt
+`
+	require.NoError(t, os.WriteFile(makefilePath, []byte(makefile), 0644))
+
+	// Test stdin is not a terminal, so confirmation cannot be asked
+	config := NewConfig()
+	config.MakefilePath = makefilePath
+	config.RunTarget = "db-drop"
+	err := runTarget(config, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "target 'db-drop' is marked !danger; pass --yes")
+	assert.NoFileExists(t, filepath.Join(tmpDir, "dropped.txt"))
+
+	config.Yes = true
+	require.NoError(t, runTarget(config, nil))
+	assert.FileExists(t, filepath.Join(tmpDir, "dropped.txt"))
+}
+
+func TestConfirmDangerousRun(t *testing.T) {
+	t.Parallel()
+	target := &model.Target{Name: "db-drop", Dangerous: true}
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"y\n", true},
+		{"YES\n", true},
+		{"n\n", false},
+		{"\n", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		var out bytes.Buffer
+		confirmed, err := confirmDangerousRun(target, strings.NewReader(tt.input), &out)
+		require.NoError(t, err)
+		assert.Equal(t, tt.expected, confirmed, "input %q", tt.input)
+		assert.Contains(t, out.String(), "db-drop is marked dangerous: destructive")
+	}
+}
+
+func TestParseRunAssignments(t *testing.T) {
+	t.Parallel()
+	assignments, err := parseRunAssignments([]string{"ENV=prod", "EMPTY=", "URL=a=b"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"ENV": "prod", "EMPTY": "", "URL": "a=b"}, assignments)
+
+	_, err = parseRunAssignments([]string{"deploy"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid argument "deploy": expected VAR=value`)
+
+	_, err = parseRunAssignments([]string{"=value"})
+	require.Error(t, err)
+}
+
+func TestPromptRunVariables(t *testing.T) {
+	t.Parallel()
+	variables := []model.Variable{
+		{Name: "MAKE_HELP_TEST_GIVEN"},
+		{Name: "MAKE_HELP_TEST_ASKED"},
+		{Name: "MAKE_HELP_TEST_SKIPPED"},
+	}
+	assignments := map[string]string{"MAKE_HELP_TEST_GIVEN": "x"}
+
+	var out bytes.Buffer
+	err := promptRunVariables(variables, assignments, strings.NewReader("answer\n\n"), &out)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{"MAKE_HELP_TEST_GIVEN": "x", "MAKE_HELP_TEST_ASKED": "answer"}, assignments)
+	assert.Equal(t, "MAKE_HELP_TEST_ASKED: MAKE_HELP_TEST_SKIPPED: ", out.String())
+}
+
+func TestPromptRunVariables_Choices(t *testing.T) {
+	t.Parallel()
+	variables := []model.Variable{
+		{Name: "MAKE_HELP_TEST_ENV", Choices: []string{"dev", "staging", "prod"}},
+		{Name: "MAKE_HELP_TEST_TIER", Choices: []string{"free", "paid"}},
+	}
+	assignments := map[string]string{}
+
+	var out bytes.Buffer
+	err := promptRunVariables(variables, assignments, strings.NewReader("qa\n2\npaid\n"), &out)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{"MAKE_HELP_TEST_ENV": "staging", "MAKE_HELP_TEST_TIER": "paid"}, assignments)
+	assert.Contains(t, out.String(), "MAKE_HELP_TEST_ENV:\n  1) dev\n  2) staging\n  3) prod\n")
+	assert.Contains(t, out.String(), "invalid choice \"qa\"")
+}
+
+func TestValidateRunChoices(t *testing.T) {
+	t.Parallel()
+	variables := []model.Variable{
+		{Name: "ENV", Choices: []string{"dev", "prod"}},
+		{Name: "DEBUG"},
+	}
+
+	assert.NoError(t, validateRunChoices(variables, map[string]string{"ENV": "prod", "DEBUG": "1"}))
+	assert.NoError(t, validateRunChoices(variables, map[string]string{}))
+
+	err := validateRunChoices(variables, map[string]string{"ENV": "qa"})
+	require.Error(t, err)
+	assert.Equal(t, `invalid value "qa" for ENV (choices: dev, prod)`, err.Error())
+}
+
+func TestPlatformWarning(t *testing.T) {
+	t.Parallel()
+	target := &model.Target{Name: "install", Platforms: []string{"linux", "darwin"}}
+
+	assert.Empty(t, platformWarning(target, "linux"))
+	assert.Equal(t, "warning: install supports linux, darwin; running on windows", platformWarning(target, "windows"))
+	assert.Empty(t, platformWarning(&model.Target{Name: "build"}, "windows"))
+}
+
+func TestRunFlagValidation(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		args      []string
+		errorText string
+	}{
+		{
+			name:      "run with output",
+			args:      []string{"--run", "build", "--output", "-"},
+			errorText: "--run cannot be used with --output",
+		},
+		{
+			name:      "run with lint",
+			args:      []string{"--run", "build", "--lint"},
+			errorText: "--run cannot be used with --lint",
+		},
+		{
+			name:      "run with target",
+			args:      []string{"--run", "build", "--target", "build"},
+			errorText: "--run cannot be used with --target",
+		},
+		{
+			name:      "run with profile",
+			args:      []string{"--run", "build", "--profile", "ci"},
+			errorText: "--run cannot be used with --profile",
+		},
+		{
+			name:      "run with remove-help",
+			args:      []string{"--run", "build", "--remove-help"},
+			errorText: "--remove-help cannot be used with --run",
+		},
+		{
+			name:      "record-duration without run",
+			args:      []string{"--record-duration", "--output", "-"},
+			errorText: "--record-duration requires --run",
+		},
+		{
+			name:      "yes without run",
+			args:      []string{"--yes", "--output", "-"},
+			errorText: "--yes requires --run",
+		},
+		{
+			name:      "run with invalid argument",
+			args:      []string{"--run", "build", "prod"},
+			errorText: `invalid argument "prod"`,
+		},
+		{
+			name:      "preview with run",
+			args:      []string{"--preview", "deploy", "--run", "deploy"},
+			errorText: "--preview cannot be used with --run",
+		},
+		{
+			name:      "preview with output",
+			args:      []string{"--preview", "deploy", "--output", "-"},
+			errorText: "--preview cannot be used with --output",
+		},
+		{
+			name:      "preview with invalid argument",
+			args:      []string{"--preview", "deploy", "prod"},
+			errorText: `invalid argument "prod"`,
+		},
+		{
+			name:      "preview with missing makefile",
+			args:      []string{"--preview", "deploy", "ENV=prod", "--makefile-path", "/nonexistent/Makefile"},
+			errorText: "Makefile not found",
+		},
+		{
+			name:      "run with missing makefile",
+			args:      []string{"--run", "build", "--makefile-path", "/nonexistent/Makefile"},
+			errorText: "Makefile not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cmd := NewRootCmd()
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorText)
+		})
+	}
+}
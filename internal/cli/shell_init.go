@@ -0,0 +1,140 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+)
+
+// shellInitScripts holds the shell integration printed by --shell-init,
+// by shell. Each defines an mh function showing the help of the Makefile
+// in the current directory and binds Ctrl-T to it. Like make and
+// discovery.ResolveMakefilePath, mh looks for GNUmakefile, makefile, and
+// Makefile, and accepts -f/--file for another one.
+var shellInitScripts = map[string]string{
+	"zsh":  zshInit,
+	"bash": bashInit,
+}
+
+// shellInitShells lists the shells --shell-init supports.
+var shellInitShells = []string{"zsh", "bash"}
+
+const zshInit = `# make-help shell integration for zsh.
+# Add to ~/.zshrc: eval "$(make-help --shell-init zsh)"
+
+# _mh_has_makefile succeeds if the current directory has a Makefile make
+# reads by default: GNUmakefile, makefile, or Makefile.
+_mh_has_makefile() {
+  [[ -f GNUmakefile || -f makefile || -f Makefile ]]
+}
+
+# mh shows the compact help of the Makefile in the current directory, or
+# the documentation of the target given as argument. -f/--file selects
+# another Makefile, as with make.
+mh() {
+  local -a args
+  local file=
+  while (( $# )); do
+    case $1 in
+      -f|--file) file=$2; shift 2 || return 1 ;;
+      --file=*) file=${1#--file=}; shift ;;
+      *) args+=("$1"); shift ;;
+    esac
+  done
+  if [[ -n $file ]]; then
+    if [[ ! -f $file ]]; then
+      print -u2 "mh: no such Makefile: $file"
+      return 1
+    fi
+    set -- --makefile-path "$file"
+  elif ! _mh_has_makefile; then
+    print -u2 "mh: no Makefile in $PWD"
+    return 1
+  fi
+  if (( ${#args[@]} )); then
+    command make-help "$@" "${args[@]}"
+  else
+    command make-help "$@" --output - --format text --compact
+  fi
+}
+
+# Ctrl-T runs mh in directories with a Makefile, and the widget it was
+# bound to before elsewhere.
+if [[ -o interactive ]]; then
+  _mh_widget() {
+    if _mh_has_makefile; then
+      zle -I
+      mh
+      zle reset-prompt
+    elif [[ -n $_mh_previous_widget ]]; then
+      zle $_mh_previous_widget
+    fi
+  }
+  zle -N _mh_widget
+  _mh_previous_widget=${${(z)$(bindkey '^T')}[2]}
+  if [[ $_mh_previous_widget == (undefined-key|_mh_widget) ]]; then
+    _mh_previous_widget=
+  fi
+  bindkey '^T' _mh_widget
+fi
+`
+
+const bashInit = `# make-help shell integration for bash.
+# Add to ~/.bashrc: eval "$(make-help --shell-init bash)"
+
+# _mh_has_makefile succeeds if the current directory has a Makefile make
+# reads by default: GNUmakefile, makefile, or Makefile.
+_mh_has_makefile() {
+  [[ -f GNUmakefile || -f makefile || -f Makefile ]]
+}
+
+# mh shows the compact help of the Makefile in the current directory, or
+# the documentation of the target given as argument. -f/--file selects
+# another Makefile, as with make.
+mh() {
+  local -a args
+  local file=
+  while (( $# )); do
+    case $1 in
+      -f|--file) file=$2; shift 2 || return 1 ;;
+      --file=*) file=${1#--file=}; shift ;;
+      *) args+=("$1"); shift ;;
+    esac
+  done
+  if [[ -n $file ]]; then
+    if [[ ! -f $file ]]; then
+      echo "mh: no such Makefile: $file" >&2
+      return 1
+    fi
+    set -- --makefile-path "$file"
+  elif ! _mh_has_makefile; then
+    echo "mh: no Makefile in $PWD" >&2
+    return 1
+  fi
+  if (( ${#args[@]} )); then
+    command make-help "$@" "${args[@]}"
+  else
+    command make-help "$@" --output - --format text --compact
+  fi
+}
+
+# Ctrl-T runs mh in directories with a Makefile. Readline cannot fall back
+# to the previous binding, so elsewhere the key does nothing.
+if [[ $- == *i* ]]; then
+  _mh_widget() {
+    if _mh_has_makefile; then
+      mh
+    fi
+  }
+  bind -x '"\C-t": _mh_widget'
+fi
+`
+
+// runShellInit writes the shell integration for config.ShellInit to w.
+func runShellInit(config *Config, w io.Writer) error {
+	script, ok := shellInitScripts[config.ShellInit]
+	if !ok {
+		return fmt.Errorf("unsupported shell: %s", config.ShellInit)
+	}
+	_, err := io.WriteString(w, script)
+	return err
+}
@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunShellInit(t *testing.T) {
+	t.Parallel()
+	for _, shell := range shellInitShells {
+		var buf bytes.Buffer
+		require.NoError(t, runShellInit(&Config{ShellInit: shell}, &buf))
+		assert.Contains(t, buf.String(), "mh() {")
+		assert.Contains(t, buf.String(), `eval "$(make-help --shell-init `+shell+`)"`)
+	}
+
+	err := runShellInit(&Config{ShellInit: "fish"}, &bytes.Buffer{})
+	assert.EqualError(t, err, "unsupported shell: fish")
+}
+
+func TestShellInit_Bash(t *testing.T) {
+	t.Parallel()
+	bash, err := exec.LookPath("bash")
+	if err != nil {
+		t.Skip("bash not installed")
+	}
+
+	// A stand-in make-help echoes its arguments
+	binDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(binDir, "make-help"), []byte("#!/bin/sh\necho \"make-help $*\"\n"), 0755))
+	projectDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "Makefile"), []byte("build:\n"), 0644))
+	emptyDir := t.TempDir()
+
+	run := func(dir, command string) (string, error) {
+		cmd := exec.Command(bash, "--noprofile", "--norc", "-c", `eval "$(cat)"; `+command)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "PATH="+binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+		cmd.Stdin = strings.NewReader(bashInit)
+		out, err := cmd.CombinedOutput()
+		return string(out), err
+	}
+
+	out, err := run(projectDir, "mh")
+	require.NoError(t, err, out)
+	assert.Equal(t, "make-help --output - --format text --compact\n", out)
+
+	out, err = run(projectDir, "mh build")
+	require.NoError(t, err, out)
+	assert.Equal(t, "make-help build\n", out)
+
+	out, err = run(emptyDir, "mh")
+	require.Error(t, err)
+	assert.Contains(t, out, "mh: no Makefile in")
+
+	// The other names make reads, and -f/--file, are found as well
+	gnuDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(gnuDir, "GNUmakefile"), []byte("build:\n"), 0644))
+	out, err = run(gnuDir, "mh")
+	require.NoError(t, err, out)
+	assert.Equal(t, "make-help --output - --format text --compact\n", out)
+
+	lowerDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(lowerDir, "makefile"), []byte("build:\n"), 0644))
+	out, err = run(lowerDir, "mh build")
+	require.NoError(t, err, out)
+	assert.Equal(t, "make-help build\n", out)
+
+	out, err = run(emptyDir, "mh -f "+filepath.Join(projectDir, "Makefile")+" build")
+	require.NoError(t, err, out)
+	assert.Equal(t, "make-help --makefile-path "+filepath.Join(projectDir, "Makefile")+" build\n", out)
+
+	out, err = run(emptyDir, "mh --file="+filepath.Join(projectDir, "Makefile"))
+	require.NoError(t, err, out)
+	assert.Equal(t, "make-help --makefile-path "+filepath.Join(projectDir, "Makefile")+" --output - --format text --compact\n", out)
+
+	out, err = run(emptyDir, "mh -f missing.mk")
+	require.Error(t, err)
+	assert.Contains(t, out, "mh: no such Makefile: missing.mk")
+}
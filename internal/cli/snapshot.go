@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sdlcforge/make-help/internal/target"
+)
+
+// Snapshot modes accepted by --snapshot.
+const (
+	snapshotUpdate = "update"
+	snapshotVerify = "verify"
+)
+
+// ErrSnapshotMismatch is a sentinel error returned by --snapshot verify when
+// current output differs from the stored snapshots.
+// Cobra will translate this into exit code 1.
+var ErrSnapshotMismatch = errors.New("help output does not match snapshots")
+
+// snapshotFiles lists the canonical renders kept in the snapshot directory.
+var snapshotFiles = []struct {
+	format   string
+	fileName string
+}{
+	{"text", "make-help.txt"},
+	{"markdown", "make-help.md"},
+	{"json", "make-help.json"},
+}
+
+// runSnapshot renders help in each snapshot format and either writes the
+// results to config.SnapshotDir (update) or compares them with the files
+// already there (verify).
+func runSnapshot(config *Config) error {
+	inputs, err := loadModelInputs(config)
+	if err != nil {
+		return err
+	}
+
+	mismatches := 0
+	for _, snap := range snapshotFiles {
+		// Snapshots are canonical: no color, and each format's own defaults
+		snapConfig := *config
+		snapConfig.Format = snap.format
+		snapConfig.UseColor = false
+
+		helpModel, err := buildHelpModelFromInputs(&snapConfig, inputs)
+		if err != nil {
+			return err
+		}
+
+		var rendered bytes.Buffer
+		if err := renderHelp(&snapConfig, helpModel, &rendered); err != nil {
+			return err
+		}
+
+		path := filepath.Join(config.SnapshotDir, snap.fileName)
+
+		if config.Snapshot == snapshotUpdate {
+			if err := os.MkdirAll(config.SnapshotDir, 0755); err != nil {
+				return fmt.Errorf("failed to create directory %s: %w", config.SnapshotDir, err)
+			}
+			if err := target.AtomicWriteFile(path, rendered.Bytes(), 0644); err != nil {
+				return fmt.Errorf("failed to write snapshot: %w", err)
+			}
+			fmt.Printf("Updated snapshot: %s\n", path)
+			continue
+		}
+
+		expected, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			fmt.Fprintf(os.Stderr, "%s: snapshot missing\n", path)
+			mismatches++
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read snapshot: %w", err)
+		}
+
+		if !bytes.Equal(expected, rendered.Bytes()) {
+			fmt.Fprintf(os.Stderr, "%s: snapshot differs from current output\n", path)
+			fmt.Fprint(os.Stderr, diffLines(string(expected), rendered.String()))
+			mismatches++
+		} else if config.Verbose {
+			fmt.Fprintf(os.Stderr, "%s matches\n", path)
+		}
+	}
+
+	if mismatches > 0 {
+		fmt.Fprintf(os.Stderr, "%d snapshot(s) out of date; run make-help --snapshot update\n", mismatches)
+		return ErrSnapshotMismatch
+	}
+
+	return nil
+}
+
+// diffLines returns a minimal line diff of expected and actual, with removed
+// lines prefixed "-" and added lines prefixed "+", each with its line number
+// in the respective input. Unchanged lines are omitted.
+func diffLines(expected, actual string) string {
+	a := strings.Split(strings.TrimSuffix(expected, "\n"), "\n")
+	b := strings.Split(strings.TrimSuffix(actual, "\n"), "\n")
+
+	var sb strings.Builder
+	for _, op := range editScript(a, b) {
+		switch op.kind {
+		case '-':
+			fmt.Fprintf(&sb, "  -%d: %s\n", op.oldLine+1, op.text)
+		case '+':
+			fmt.Fprintf(&sb, "  +%d: %s\n", op.newLine+1, op.text)
+		}
+	}
+	return sb.String()
+}
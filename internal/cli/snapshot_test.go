@@ -0,0 +1,142 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunSnapshot(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	makefilePath := filepath.Join(tmpDir, "Makefile")
+	require.NoError(t, os.WriteFile(makefilePath, []byte(injectTestMakefile), 0644))
+	snapshotDir := filepath.Join(tmpDir, "testdata")
+
+	config := NewConfig()
+	config.MakefilePath = makefilePath
+	config.SnapshotDir = snapshotDir
+
+	// Verify fails before any snapshot exists
+	config.Snapshot = snapshotVerify
+	assert.ErrorIs(t, runSnapshot(config), ErrSnapshotMismatch)
+
+	config.Snapshot = snapshotUpdate
+	require.NoError(t, runSnapshot(config))
+	for _, name := range []string{"make-help.txt", "make-help.md", "make-help.json"} {
+		assert.FileExists(t, filepath.Join(snapshotDir, name))
+	}
+	markdown, err := os.ReadFile(filepath.Join(snapshotDir, "make-help.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(markdown), "**build**: Build the project.")
+
+	config.Snapshot = snapshotVerify
+	assert.NoError(t, runSnapshot(config))
+
+	// A documentation change is reported as a mismatch
+	changed := []byte("## !category Build\n## Build everything.\nbuild:\n\t@echo build\n")
+	require.NoError(t, os.WriteFile(makefilePath, changed, 0644))
+	assert.ErrorIs(t, runSnapshot(config), ErrSnapshotMismatch)
+}
+
+func TestDiffLines(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		expected string
+		actual   string
+		want     string
+	}{
+		{
+			name:     "identical",
+			expected: "a\nb\n",
+			actual:   "a\nb\n",
+			want:     "",
+		},
+		{
+			name:     "changed line",
+			expected: "a\nb\nc\n",
+			actual:   "a\nB\nc\n",
+			want:     "  -2: b\n  +2: B\n",
+		},
+		{
+			name:     "added line",
+			expected: "a\nc\n",
+			actual:   "a\nb\nc\n",
+			want:     "  +2: b\n",
+		},
+		{
+			name:     "removed line",
+			expected: "a\nb\nc\n",
+			actual:   "a\nc\n",
+			want:     "  -2: b\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, diffLines(tt.expected, tt.actual))
+		})
+	}
+}
+
+func TestSnapshotFlagValidation(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		args      []string
+		errorText string
+	}{
+		{
+			name:      "invalid mode",
+			args:      []string{"--snapshot", "refresh"},
+			errorText: "invalid snapshot mode: refresh",
+		},
+		{
+			name:      "snapshot with format",
+			args:      []string{"--snapshot", "verify", "--format", "html"},
+			errorText: "--snapshot cannot be used with --format",
+		},
+		{
+			name:      "snapshot with output",
+			args:      []string{"--snapshot", "verify", "--output", "-"},
+			errorText: "--snapshot cannot be used with --output",
+		},
+		{
+			name:      "snapshot with lint",
+			args:      []string{"--snapshot", "update", "--lint"},
+			errorText: "--snapshot cannot be used with --lint",
+		},
+		{
+			name:      "snapshot with remove-help",
+			args:      []string{"--snapshot", "update", "--remove-help"},
+			errorText: "--remove-help cannot be used with --snapshot",
+		},
+		{
+			name:      "snapshot-dir without snapshot",
+			args:      []string{"--snapshot-dir", "golden"},
+			errorText: "--snapshot-dir requires --snapshot",
+		},
+		{
+			name:      "snapshot with missing makefile",
+			args:      []string{"--snapshot", "verify", "--makefile-path", "/nonexistent/Makefile"},
+			errorText: "Makefile not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cmd := NewRootCmd()
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorText)
+		})
+	}
+}
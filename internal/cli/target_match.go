@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// maxSuggestions is the number of similar target names suggested when a
+// requested target does not exist.
+const maxSuggestions = 5
+
+// resolveTargetName returns the target that name refers to. An exact match
+// wins; otherwise, unless exact is set, a name that starts exactly one target
+// resolves to it, the way git and kubectl resolve partial names. The error
+// for an unknown name lists the targets it is a prefix of, or similar names.
+func resolveTargetName(targets []string, name string, exact bool) (string, error) {
+	if slices.Contains(targets, name) {
+		return name, nil
+	}
+	if exact {
+		return "", fmt.Errorf("target '%s' not found", name)
+	}
+
+	matches := prefixMatches(targets, name)
+	switch len(matches) {
+	case 0:
+	case 1:
+		return matches[0], nil
+	default:
+		return "", fmt.Errorf("target '%s' not found; targets starting with '%s': %s",
+			name, name, strings.Join(matches, ", "))
+	}
+
+	if suggestions := similarTargets(targets, name); len(suggestions) > 0 {
+		return "", fmt.Errorf("target '%s' not found; did you mean: %s?", name, strings.Join(suggestions, ", "))
+	}
+	return "", fmt.Errorf("target '%s' not found", name)
+}
+
+// prefixMatches returns the targets whose names start with prefix, sorted.
+// Special targets such as .PHONY are left out.
+func prefixMatches(targets []string, prefix string) []string {
+	var matches []string
+	for _, t := range targets {
+		if strings.HasPrefix(t, prefix) && !strings.HasPrefix(t, ".") {
+			matches = append(matches, t)
+		}
+	}
+	slices.Sort(matches)
+	return slices.Compact(matches)
+}
+
+// similarTargets returns up to maxSuggestions targets that contain name or
+// are within a few edits of it, closest first.
+func similarTargets(targets []string, name string) []string {
+	type candidate struct {
+		name     string
+		distance int
+	}
+
+	lowerName := strings.ToLower(name)
+	maxDistance := max(1, len(name)/3)
+	var candidates []candidate
+	for _, t := range targets {
+		if strings.HasPrefix(t, ".") || slices.ContainsFunc(candidates, func(c candidate) bool { return c.name == t }) {
+			continue
+		}
+		lower := strings.ToLower(t)
+		distance := editDistance(lowerName, lower)
+		if distance <= maxDistance || strings.Contains(lower, lowerName) {
+			candidates = append(candidates, candidate{name: t, distance: distance})
+		}
+	}
+
+	slices.SortFunc(candidates, func(a, b candidate) int {
+		if a.distance != b.distance {
+			return a.distance - b.distance
+		}
+		return strings.Compare(a.name, b.name)
+	})
+
+	var names []string
+	for _, c := range candidates[:min(len(candidates), maxSuggestions)] {
+		names = append(names, c.name)
+	}
+	return names
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveTargetName(t *testing.T) {
+	t.Parallel()
+	targets := []string{".PHONY", "build", "build-docs", "deploy", "test-unit", "test-e2e", "lint"}
+
+	tests := []struct {
+		name      string
+		input     string
+		exact     bool
+		want      string
+		errorText string
+	}{
+		{name: "exact match", input: "build", want: "build"},
+		{name: "exact match wins over prefix", input: "build", want: "build"},
+		{name: "unique prefix", input: "dep", want: "deploy"},
+		{name: "unique prefix disabled by exact", input: "dep", exact: true, errorText: "target 'dep' not found"},
+		{name: "ambiguous prefix", input: "test", errorText: "targets starting with 'test': test-e2e, test-unit"},
+		{name: "typo", input: "lnt", errorText: "did you mean: lint?"},
+		{name: "substring", input: "docs", errorText: "did you mean: build-docs?"},
+		{name: "no suggestion", input: "zzz", errorText: "target 'zzz' not found"},
+		{name: "special targets are not matched", input: ".P", errorText: "target '.P' not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := resolveTargetName(targets, tt.input, tt.exact)
+			if tt.errorText != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorText)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, 0, editDistance("build", "build"))
+	assert.Equal(t, 1, editDistance("bild", "build"))
+	assert.Equal(t, 2, editDistance("lnit", "lint"))
+	assert.Equal(t, 5, editDistance("", "build"))
+}
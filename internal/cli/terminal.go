@@ -2,6 +2,7 @@ package cli
 
 import (
 	"os"
+	"strconv"
 
 	"golang.org/x/term"
 )
@@ -11,6 +12,20 @@ func IsTerminal(fd uintptr) bool {
 	return term.IsTerminal(int(fd))
 }
 
+// TerminalWidth returns the width of the terminal on stdout in columns.
+// A positive COLUMNS environment variable takes precedence, as with ls.
+// Returns 0 when the width is unknown (e.g., output is piped).
+func TerminalWidth() int {
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+	width, _, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil {
+		return 0
+	}
+	return width
+}
+
 // ResolveColorMode determines whether to use colored output based on the config.
 // It respects the ColorMode setting and checks if stdout is a terminal.
 func ResolveColorMode(config *Config) bool {
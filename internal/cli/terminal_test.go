@@ -0,0 +1,16 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTerminalWidth_Columns(t *testing.T) {
+	t.Setenv("COLUMNS", "120")
+	assert.Equal(t, 120, TerminalWidth())
+
+	// Invalid values fall back to the terminal size, which is unknown under go test
+	t.Setenv("COLUMNS", "wide")
+	assert.GreaterOrEqual(t, TerminalWidth(), 0)
+}
@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/sdlcforge/make-help/internal/discovery"
+	"github.com/sdlcforge/make-help/internal/journal"
+)
+
+// beginJournal starts recording, in the journal next to makefilePath, the
+// files an operation modifies so --undo can revert them. Track each file
+// before modifying it. The journal keeps the number of operations set by
+// undo.history in .make-help.json.
+func beginJournal(config *Config, makefilePath string) *journal.Transaction {
+	tx := journal.Begin(filepath.Dir(makefilePath), config.CommandLine)
+	// An invalid .make-help.json is reported by the operation itself
+	if projectConfig, err := loadProjectConfig(makefilePath); err == nil {
+		tx.SetHistory(projectConfig.Undo.History)
+	}
+	return tx
+}
+
+// commitJournal records the changes tracked by tx. Failing to record them
+// only costs the ability to undo, so it is a warning.
+func commitJournal(tx *journal.Transaction) {
+	if err := tx.Commit(time.Now()); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to record changes for --undo: %v\n", err)
+	}
+}
+
+// runUndo reverts the most recent operation recorded in the journal next
+// to the Makefile.
+func runUndo(config *Config) error {
+	makefilePath, err := discovery.ResolveMakefilePath(config.MakefilePath)
+	if err != nil {
+		return fmt.Errorf("failed to resolve Makefile path: %w", err)
+	}
+	dir := filepath.Dir(makefilePath)
+
+	release, err := lockProject(config, makefilePath)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	op, err := journal.Undo(dir)
+	if errors.Is(err, journal.ErrEmpty) {
+		return fmt.Errorf("nothing to undo: no changes recorded in %s", journal.Path(dir))
+	}
+	if err != nil {
+		return err
+	}
+
+	if config.Verbose {
+		for _, file := range op.Files {
+			if file.Before == "" {
+				fmt.Fprintf(os.Stderr, "Removed: %s\n", file.Path)
+			} else {
+				fmt.Fprintf(os.Stderr, "Restored: %s\n", file.Path)
+			}
+		}
+	}
+	fmt.Printf("Undid %d file change(s) from: %s\n", len(op.Files), op.Command)
+	return nil
+}
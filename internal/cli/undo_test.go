@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUndo_CreateAndRemoveHelp(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	makefilePath := filepath.Join(tmpDir, "Makefile")
+	helpPath := filepath.Join(tmpDir, "help.mk")
+	require.NoError(t, os.WriteFile(makefilePath, []byte(handWrittenHelpMakefile), 0644))
+
+	run := func(args ...string) error {
+		cmd := NewRootCmd()
+		cmd.SetArgs(append([]string{"--makefile-path", makefilePath}, args...))
+		return cmd.Execute()
+	}
+
+	require.NoError(t, run("--help-file-rel-path", "help.mk", "--replace-existing-help"))
+	generated, err := os.ReadFile(makefilePath)
+	require.NoError(t, err)
+	require.NoError(t, run("--remove-help"))
+	assert.NoFileExists(t, helpPath)
+
+	// Undoing the removal brings back the generated help
+	require.NoError(t, run("--undo"))
+	makefile, err := os.ReadFile(makefilePath)
+	require.NoError(t, err)
+	assert.Equal(t, string(generated), string(makefile))
+	assert.FileExists(t, helpPath)
+
+	// Undoing the creation restores the hand-written Makefile
+	require.NoError(t, run("--undo"))
+	makefile, err = os.ReadFile(makefilePath)
+	require.NoError(t, err)
+	assert.Equal(t, handWrittenHelpMakefile, string(makefile))
+	assert.NoFileExists(t, helpPath)
+
+	err = run("--undo")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "nothing to undo")
+}
+
+func TestUndo_FileChangedSince(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	makefilePath := filepath.Join(tmpDir, "Makefile")
+	require.NoError(t, os.WriteFile(makefilePath, []byte("## Build the project\nbuild:\n\t@echo building\n"), 0644))
+
+	config := NewConfig()
+	config.MakefilePath = makefilePath
+	config.HelpFileRelPath = "help.mk"
+	require.NoError(t, runCreateHelpTarget(config))
+
+	f, err := os.OpenFile(makefilePath, os.O_APPEND|os.O_WRONLY, 0644)
+	require.NoError(t, err)
+	_, err = f.WriteString("\ntest:\n\t@true\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	config = NewConfig()
+	config.MakefilePath = makefilePath
+	err = runUndo(config)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot undo: "+makefilePath+" has changed")
+	assert.FileExists(t, filepath.Join(tmpDir, "help.mk"))
+}
@@ -0,0 +1,166 @@
+package cli
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/sdlcforge/make-help/internal/discovery"
+	"github.com/sdlcforge/make-help/internal/projectconfig"
+	"github.com/sdlcforge/make-help/internal/usage"
+)
+
+// recordUsage adds a run to the project's usage stats when
+// .make-help.json turns recording on. The stats file is updated under the
+// project lock, so concurrent runs do not lose each other's counts.
+// Failures only warn: the stats must never break a run.
+func recordUsage(cmd *cobra.Command, config *Config) {
+	if config.ShellInit != "" || config.UsageStats {
+		return
+	}
+	makefilePath, err := discovery.ResolveMakefilePath(config.MakefilePath)
+	if err != nil {
+		return
+	}
+	projectConfig, err := loadProjectConfig(makefilePath)
+	if err != nil || !projectConfig.Usage.Record {
+		return
+	}
+
+	invocation := usage.Invocation{Command: usageCommand(config)}
+	cmd.Flags().Visit(func(flag *pflag.Flag) {
+		invocation.Flags = append(invocation.Flags, "--"+flag.Name)
+	})
+	switch invocation.Command {
+	case "generate", "render", "--inject", "--target", "--snapshot":
+		invocation.Formats = []string{config.Format}
+	case "--output-dir":
+		invocation.Formats = config.OutputFormats
+	}
+	release, err := lockProject(config, makefilePath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: usage not recorded: %v\n", err)
+		return
+	}
+	defer release()
+	if err := usage.Record(filepath.Dir(makefilePath), invocation, time.Now()); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+}
+
+// usageCommand names the command config runs, checking the modes in the
+// order RunE dispatches them: the mode flag, or "render" for help written
+// to stdout or --output and "generate" for the help file.
+func usageCommand(config *Config) string {
+	modes := []struct {
+		isSet    bool
+		flagName string
+	}{
+		{config.Undo, "--undo"},
+		{config.Clean, "--clean"},
+		{config.Daemon != "", "--daemon"},
+		{config.Lint, "--lint"},
+		{config.RemoveHelpTarget, "--remove-help"},
+		{config.Hook != "", "--hook"},
+		{config.DumpModel != "", "--dump-model"},
+		{config.Snapshot != "", "--snapshot"},
+		{config.RenderFixture, "--render-fixture"},
+		{config.AddFragment != "", "--add-fragment"},
+		{config.Graph != "", "--graph"},
+		{config.Analyze, "--analyze"},
+		{config.Export != "", "--export"},
+		{config.List != "", "--list"},
+		{config.Categories, "--categories"},
+		{config.Vars, "--vars"},
+		{config.ValidateOnly, "--validate-only"},
+		{config.OutputDir != "", "--output-dir"},
+		{config.RunTarget != "", "--run"},
+		{config.Preview != "", "--preview"},
+		{config.InjectFile != "", "--inject"},
+		{config.Target != "", "--target"},
+	}
+	for _, mode := range modes {
+		if mode.isSet {
+			return mode.flagName
+		}
+	}
+	if config.Output == "-" || config.Format != "make" {
+		return "render"
+	}
+	return "generate"
+}
+
+// runUsageStats prints the usage recorded next to the Makefile, as text or
+// JSON.
+func runUsageStats(config *Config, w io.Writer) error {
+	makefilePath, err := discovery.ResolveMakefilePath(config.MakefilePath)
+	if err != nil {
+		return fmt.Errorf("failed to resolve Makefile path: %w", err)
+	}
+	dir := filepath.Dir(makefilePath)
+	stats, err := usage.Load(dir)
+	if err != nil {
+		return err
+	}
+
+	if config.Format == "json" {
+		data, err := json.MarshalIndent(stats, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode usage stats: %w", err)
+		}
+		_, err = fmt.Fprintf(w, "%s\n", data)
+		return err
+	}
+
+	if stats.Since.IsZero() {
+		projectConfig, err := loadProjectConfig(makefilePath)
+		if err != nil {
+			return err
+		}
+		if !projectConfig.Usage.Record {
+			_, err = fmt.Fprintf(w, "No usage recorded. Add \"usage\": {\"record\": true} to %s to record it.\n", projectconfig.FileName)
+			return err
+		}
+		_, err = fmt.Fprintln(w, "No usage recorded yet.")
+		return err
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Usage recorded since %s in %s\n", stats.Since.Format(time.DateOnly), usage.FileName)
+	writeUsageCounts(&sb, "Commands", stats.Commands)
+	writeUsageCounts(&sb, "Formats", stats.Formats)
+	writeUsageCounts(&sb, "Flags", stats.Flags)
+	_, err = io.WriteString(w, sb.String())
+	return err
+}
+
+// writeUsageCounts writes a section listing counts, most used first.
+func writeUsageCounts(sb *strings.Builder, title string, counts map[string]usage.Count) {
+	if len(counts) == 0 {
+		return
+	}
+	names := make([]string, 0, len(counts))
+	width := 0
+	for name := range counts {
+		names = append(names, name)
+		width = max(width, len(name))
+	}
+	slices.SortFunc(names, func(a, b string) int {
+		return cmp.Or(cmp.Compare(counts[b].Count, counts[a].Count), strings.Compare(a, b))
+	})
+
+	fmt.Fprintf(sb, "\n%s:\n", title)
+	for _, name := range names {
+		count := counts[name]
+		fmt.Fprintf(sb, "  %-*s %5d  last used %s\n", width, name, count.Count, count.LastUsed.Local().Format(time.DateOnly))
+	}
+}
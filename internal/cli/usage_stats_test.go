@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sdlcforge/make-help/internal/projectconfig"
+	"github.com/sdlcforge/make-help/internal/usage"
+)
+
+func TestRecordUsage(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	makefilePath := filepath.Join(tmpDir, "Makefile")
+	require.NoError(t, os.WriteFile(makefilePath, []byte(injectTestMakefile), 0644))
+
+	// Nothing is recorded until the project opts in
+	cmd := NewRootCmd()
+	cmd.SetArgs([]string{"--makefile-path", makefilePath, "--no-exec", "--dump-model", filepath.Join(tmpDir, "model.json")})
+	require.NoError(t, cmd.Execute())
+	assert.NoFileExists(t, usage.Path(tmpDir))
+
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, projectconfig.FileName), []byte(`{"usage": {"record": true}}`), 0644))
+	cmd = NewRootCmd()
+	cmd.SetArgs([]string{"--makefile-path", makefilePath, "--no-exec", "--dump-model", filepath.Join(tmpDir, "model.json")})
+	require.NoError(t, cmd.Execute())
+
+	stats, err := usage.Load(tmpDir)
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.Commands["--dump-model"].Count)
+	assert.Equal(t, 1, stats.Flags["--makefile-path"].Count)
+	assert.Equal(t, 1, stats.Flags["--no-exec"].Count)
+	assert.Empty(t, stats.Formats)
+}
+
+func TestRecordUsage_TakesProjectLock(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	makefilePath := filepath.Join(tmpDir, "Makefile")
+	require.NoError(t, os.WriteFile(makefilePath, []byte(injectTestMakefile), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, projectconfig.FileName), []byte(`{"usage": {"record": true}}`), 0644))
+
+	holder := NewConfig()
+	release, err := lockProject(holder, makefilePath)
+	require.NoError(t, err)
+
+	// Nothing is recorded while another make-help holds the lock
+	config := NewConfig()
+	config.MakefilePath = makefilePath
+	config.LockTimeout = 0
+	recordUsage(NewRootCmd(), config)
+	assert.NoFileExists(t, usage.Path(tmpDir))
+
+	release()
+	recordUsage(NewRootCmd(), config)
+	stats, err := usage.Load(tmpDir)
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.Commands["generate"].Count)
+}
+
+func TestUsageCommand(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		want   string
+	}{
+		{name: "help file", modify: func(c *Config) {}, want: "generate"},
+		{name: "stdout", modify: func(c *Config) { c.Output = "-" }, want: "render"},
+		{name: "html file", modify: func(c *Config) { c.Format = "html"; c.Output = "help.html" }, want: "render"},
+		{name: "inject", modify: func(c *Config) { c.InjectFile = "README.md"; c.Format = "markdown" }, want: "--inject"},
+		{name: "lint before inject", modify: func(c *Config) { c.Lint = true; c.InjectFile = "README.md" }, want: "--lint"},
+		{name: "output dir", modify: func(c *Config) { c.OutputDir = "docs" }, want: "--output-dir"},
+		{name: "target", modify: func(c *Config) { c.Target = "build"; c.Output = "-" }, want: "--target"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			config := NewConfig()
+			tt.modify(config)
+			assert.Equal(t, tt.want, usageCommand(config))
+		})
+	}
+}
+
+func TestRunUsageStats(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	config := NewConfig()
+	config.MakefilePath = filepath.Join(tmpDir, "Makefile")
+	config.Format = "text"
+
+	var buf bytes.Buffer
+	require.NoError(t, runUsageStats(config, &buf))
+	assert.Equal(t, "No usage recorded. Add \"usage\": {\"record\": true} to .make-help.json to record it.\n", buf.String())
+
+	at := time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, usage.Record(tmpDir, usage.Invocation{Command: "generate", Formats: []string{"make"}}, at))
+	require.NoError(t, usage.Record(tmpDir, usage.Invocation{Command: "generate", Formats: []string{"make"}}, at))
+	require.NoError(t, usage.Record(tmpDir, usage.Invocation{
+		Command: "--inject",
+		Flags:   []string{"--inject"},
+		Formats: []string{"markdown"},
+	}, at))
+
+	buf.Reset()
+	require.NoError(t, runUsageStats(config, &buf))
+	day := at.Local().Format(time.DateOnly)
+	assert.Equal(t, "Usage recorded since "+day+" in .make-help-usage.json\n"+
+		"\nCommands:\n"+
+		"  generate     2  last used "+day+"\n"+
+		"  --inject     1  last used "+day+"\n"+
+		"\nFormats:\n"+
+		"  make         2  last used "+day+"\n"+
+		"  markdown     1  last used "+day+"\n"+
+		"\nFlags:\n"+
+		"  --inject     1  last used "+day+"\n", buf.String())
+
+	config.Format = "json"
+	buf.Reset()
+	require.NoError(t, runUsageStats(config, &buf))
+	assert.Contains(t, buf.String(), `"generate": {`)
+}
@@ -0,0 +1,170 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	mherrors "github.com/sdlcforge/make-help/internal/errors"
+	"github.com/sdlcforge/make-help/internal/lint"
+)
+
+// ErrValidationFailed is a sentinel error returned when --validate-only
+// finds problems.
+var ErrValidationFailed = errors.New("validation failed")
+
+// Validation stages, in the order they run.
+const (
+	stageLoad     = "load"
+	stageBuild    = "build"
+	stageOrdering = "ordering"
+	stageLint     = "lint"
+)
+
+// validationProblem is one problem found by --validate-only.
+type validationProblem struct {
+	// Stage is the step that found the problem: load (discovery and
+	// parsing), build, ordering, or lint.
+	Stage string `json:"stage"`
+
+	// File is the Makefile the problem is in, when known.
+	File string `json:"file,omitempty"`
+
+	// Line is the 1-based line of the problem, when known.
+	Line int `json:"line,omitempty"`
+
+	// Check names the lint check reporting the problem.
+	Check string `json:"check,omitempty"`
+
+	// Message describes the problem.
+	Message string `json:"message"`
+}
+
+// validationReport is the --validate-only result written as JSON.
+type validationReport struct {
+	// Valid is true when no problems were found.
+	Valid bool `json:"valid"`
+
+	// Problems lists the problems found, in stage order.
+	Problems []validationProblem `json:"problems"`
+}
+
+// runValidateOnly builds the help model without rendering it and reports
+// the problems found, as text or JSON. Returns ErrValidationFailed when
+// there are any.
+func runValidateOnly(config *Config, w io.Writer) error {
+	problems, err := validateModel(config)
+	if err != nil {
+		return err
+	}
+	if err := writeValidationProblems(w, problems, config.Format); err != nil {
+		return err
+	}
+	if len(problems) > 0 {
+		return ErrValidationFailed
+	}
+	if config.Verbose {
+		fmt.Fprintf(os.Stderr, "No errors found\n")
+	}
+	return nil
+}
+
+// validateModel runs discovery, parsing, model building (including the
+// categorization and --category-order checks), and the error-level lint
+// checks, stopping at the first stage that fails. The lint checks need the
+// Makefiles, so they are skipped with --from-model. A missing Makefile is
+// returned as an error rather than a problem.
+func validateModel(config *Config) ([]validationProblem, error) {
+	inputs, err := loadModelInputs(config)
+	if err != nil {
+		var notFound *mherrors.MakefileNotFoundError
+		if errors.As(err, &notFound) {
+			return nil, err
+		}
+		return []validationProblem{{Stage: stageLoad, Message: strings.TrimSpace(err.Error())}}, nil
+	}
+
+	if _, err := buildHelpModelFromInputs(config, inputs); err != nil {
+		stage := stageBuild
+		var unknownCategory *mherrors.UnknownCategoryError
+		if errors.As(err, &unknownCategory) {
+			stage = stageOrdering
+		}
+		return []validationProblem{{Stage: stage, File: config.MakefilePath, Message: strings.TrimSpace(err.Error())}}, nil
+	}
+
+	if config.FromModel != "" {
+		return nil, nil
+	}
+	result, _, err := runLintChecks(config)
+	if err != nil {
+		return []validationProblem{{Stage: stageLint, Message: strings.TrimSpace(err.Error())}}, nil
+	}
+	var problems []validationProblem
+	for _, warning := range result.Warnings {
+		if warning.Severity != lint.SeverityError {
+			continue
+		}
+		problems = append(problems, validationProblem{
+			Stage:   stageLint,
+			File:    warning.File,
+			Line:    warning.Line,
+			Check:   warning.CheckName,
+			Message: warning.Message,
+		})
+	}
+	return problems, nil
+}
+
+// writeValidationProblems writes problems as text, one compiler-style
+// "file:line: error: stage: message" line each, or as a JSON report.
+func writeValidationProblems(w io.Writer, problems []validationProblem, format string) error {
+	if format == "json" {
+		report := validationReport{Valid: len(problems) == 0, Problems: problems}
+		if report.Problems == nil {
+			report.Problems = []validationProblem{}
+		}
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode validation report: %w", err)
+		}
+		_, err = fmt.Fprintf(w, "%s\n", data)
+		return err
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		cwd = "" // Fall back to absolute paths if we can't get cwd
+	}
+
+	var sb strings.Builder
+	for _, problem := range problems {
+		location := problem.File
+		if location != "" && cwd != "" {
+			if rel, err := filepath.Rel(cwd, location); err == nil && !strings.HasPrefix(rel, "..") {
+				location = rel
+			}
+		}
+		if location != "" && problem.Line > 0 {
+			location = fmt.Sprintf("%s:%d", location, problem.Line)
+		}
+		if location != "" {
+			sb.WriteString(location + ": ")
+		}
+		message := problem.Message
+		if problem.Check != "" {
+			message += " [" + problem.Check + "]"
+		}
+		fmt.Fprintf(&sb, "error: %s: %s\n", problem.Stage, message)
+	}
+	if len(problems) > 0 {
+		fmt.Fprintf(&sb, "\nFound %d error(s)\n", len(problems))
+	}
+
+	_, err = io.WriteString(w, sb.String())
+	return err
+}
@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateModel(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	makefilePath := filepath.Join(tmpDir, "Makefile")
+	require.NoError(t, os.WriteFile(makefilePath, []byte(`## !category Build
+## Build the project.
+build:
+	@true
+
+## !category Test
+## Run the tests.
+test:
+	@true
+`), 0644))
+
+	config := NewConfig()
+	config.MakefilePath = makefilePath
+	problems, err := validateModel(config)
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+
+	config = NewConfig()
+	config.MakefilePath = makefilePath
+	config.CategoryOrder = []string{"Test", "Deploy"}
+	problems, err = validateModel(config)
+	require.NoError(t, err)
+	require.Len(t, problems, 1)
+	assert.Equal(t, stageOrdering, problems[0].Stage)
+	assert.Equal(t, makefilePath, problems[0].File)
+	assert.Contains(t, problems[0].Message, `unknown category "Deploy"`)
+
+	config = NewConfig()
+	config.MakefilePath = filepath.Join(tmpDir, "missing.mk")
+	_, err = validateModel(config)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Makefile not found")
+}
+
+func TestValidateModel_LintErrors(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	makefilePath := filepath.Join(tmpDir, "Makefile")
+	require.NoError(t, os.WriteFile(makefilePath, []byte("## Build the project.\nbuild: test\n\t@true\n\n## Run the tests.\ntest: build\n\t@true\n"), 0644))
+
+	// A circular dependency is only a warning
+	config := NewConfig()
+	config.MakefilePath = makefilePath
+	problems, err := validateModel(config)
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+
+	// A plugin reporting an error fails validation
+	require.NoError(t, os.Mkdir(filepath.Join(tmpDir, "checks"), 0755))
+	plugin := "#!/bin/sh\ncat > /dev/null\necho '{\"warnings\": [{\"line\": 2, \"severity\": \"error\", \"message\": \"target build does not reference a ticket\"}]}'\n"
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "checks", "make-help-check-ticket"), []byte(plugin), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, ".make-help.json"), []byte(`{"lint": {"plugins": ["checks/make-help-check-ticket"]}}`), 0644))
+
+	config = NewConfig()
+	config.MakefilePath = makefilePath
+	problems, err = validateModel(config)
+	require.NoError(t, err)
+	require.Len(t, problems, 1)
+	assert.Equal(t, validationProblem{Stage: stageLint, File: makefilePath, Line: 2, Check: "ticket",
+		Message: "target build does not reference a ticket"}, problems[0])
+}
+
+func TestWriteValidationProblems(t *testing.T) {
+	t.Parallel()
+	problems := []validationProblem{
+		{Stage: stageLoad, Message: "failed to parse make/build.mk"},
+		{Stage: stageLint, File: "/work/Makefile", Line: 12, Check: "ticket", Message: "target 'build' does not reference a ticket"},
+	}
+
+	var text bytes.Buffer
+	require.NoError(t, writeValidationProblems(&text, problems, "text"))
+	assert.Equal(t, `error: load: failed to parse make/build.mk
+/work/Makefile:12: error: lint: target 'build' does not reference a ticket [ticket]
+
+Found 2 error(s)
+`, text.String())
+
+	var empty bytes.Buffer
+	require.NoError(t, writeValidationProblems(&empty, nil, "text"))
+	assert.Empty(t, empty.String())
+
+	var jsonOut bytes.Buffer
+	require.NoError(t, writeValidationProblems(&jsonOut, nil, "json"))
+	assert.JSONEq(t, `{"valid": true, "problems": []}`, jsonOut.String())
+
+	jsonOut.Reset()
+	require.NoError(t, writeValidationProblems(&jsonOut, problems[1:], "json"))
+	assert.JSONEq(t, `{"valid": false, "problems": [{"stage": "lint", "file": "/work/Makefile", "line": 12,
+		"check": "ticket", "message": "target 'build' does not reference a ticket"}]}`, jsonOut.String())
+}
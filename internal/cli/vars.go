@@ -0,0 +1,150 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/sdlcforge/make-help/internal/model"
+)
+
+// variableInfo describes one documented variable in the --vars inventory.
+type variableInfo struct {
+	// Name is the variable name.
+	Name string `json:"name"`
+
+	// Description is the first non-empty description any target gives.
+	Description string `json:"description,omitempty"`
+
+	// Required is true if any target marks the variable (required).
+	Required bool `json:"required"`
+
+	// Choices lists the allowed values from the first target declaring
+	// them.
+	Choices []string `json:"choices,omitempty"`
+
+	// Default is the value the Makefiles assign, when they assign one.
+	Default *string `json:"default,omitempty"`
+
+	// Targets lists the targets documenting the variable, in help order.
+	Targets []string `json:"targets"`
+}
+
+// runVars lists every documented variable with the targets that use it.
+func runVars(config *Config) error {
+	inputs, err := loadModelInputs(config)
+	if err != nil {
+		return err
+	}
+	helpModel, err := buildHelpModelFromInputs(config, inputs)
+	if err != nil {
+		return err
+	}
+	return writeVariables(os.Stdout, collectVariables(helpModel, inputs.Targets.VariableValues), config.Format)
+}
+
+// collectVariables gathers the variables documented by the targets of
+// helpModel, sorted by name. values holds the values the Makefiles assign.
+func collectVariables(helpModel *model.HelpModel, values map[string]string) []variableInfo {
+	byName := make(map[string]*variableInfo)
+	var names []string
+	for _, category := range helpModel.Categories {
+		for _, target := range category.Targets {
+			for _, variable := range target.Variables {
+				info, exists := byName[variable.Name]
+				if !exists {
+					info = &variableInfo{Name: variable.Name, Targets: []string{}}
+					if value, ok := values[variable.Name]; ok {
+						info.Default = &value
+					}
+					byName[variable.Name] = info
+					names = append(names, variable.Name)
+				}
+				if info.Description == "" {
+					info.Description = variable.Description
+				}
+				if info.Choices == nil {
+					info.Choices = variable.Choices
+				}
+				info.Required = info.Required || variable.Required
+				if !slices.Contains(info.Targets, target.Name) {
+					info.Targets = append(info.Targets, target.Name)
+				}
+			}
+		}
+	}
+
+	slices.Sort(names)
+	variables := make([]variableInfo, 0, len(names))
+	for _, name := range names {
+		variables = append(variables, *byName[name])
+	}
+	return variables
+}
+
+// writeVariables writes variables as text, JSON, or a Markdown table.
+func writeVariables(w io.Writer, variables []variableInfo, format string) error {
+	var sb strings.Builder
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(variables, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode variables: %w", err)
+		}
+		fmt.Fprintf(&sb, "%s\n", data)
+
+	case "markdown":
+		sb.WriteString("| Variable | Required | Default | Used by | Description |\n")
+		sb.WriteString("| --- | --- | --- | --- | --- |\n")
+		for _, v := range variables {
+			required := ""
+			if v.Required {
+				required = "yes"
+			}
+			defaultValue := ""
+			if v.Default != nil {
+				defaultValue = "`" + markdownCell(*v.Default) + "`"
+			}
+			description := v.Description
+			if len(v.Choices) > 0 {
+				description = strings.TrimSpace(description + " (choices: " + strings.Join(v.Choices, ", ") + ")")
+			}
+			fmt.Fprintf(&sb, "| `%s` | %s | %s | %s | %s |\n",
+				v.Name, required, defaultValue, strings.Join(v.Targets, ", "), markdownCell(description))
+		}
+
+	default:
+		if len(variables) == 0 {
+			sb.WriteString("No documented variables.\n")
+		}
+		for _, v := range variables {
+			sb.WriteString(v.Name)
+			if v.Required {
+				sb.WriteString(" (required)")
+			}
+			if v.Description != "" {
+				sb.WriteString(" - " + v.Description)
+			}
+			sb.WriteString("\n")
+			if v.Default != nil {
+				fmt.Fprintf(&sb, "  default: %s\n", *v.Default)
+			}
+			if len(v.Choices) > 0 {
+				fmt.Fprintf(&sb, "  choices: %s\n", strings.Join(v.Choices, ", "))
+			}
+			fmt.Fprintf(&sb, "  used by: %s\n", strings.Join(v.Targets, ", "))
+		}
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// markdownCell keeps a value on one line and escapes the pipes that would
+// end its table cell.
+func markdownCell(s string) string {
+	return strings.ReplaceAll(strings.Join(strings.Fields(s), " "), "|", `\|`)
+}
@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunVars(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	makefilePath := filepath.Join(tmpDir, "Makefile")
+	require.NoError(t, os.WriteFile(makefilePath, []byte(`PORT ?= 8080
+ENV := dev
+
+## Build the project.
+## !var VERBOSE - Print every command
+build:
+	@true
+
+## Deploy the project.
+## !var ENV (required) (choices: dev,prod) - Target | environment
+## !var VERBOSE
+deploy:
+	@true
+
+## !hidden
+## Serve the site.
+## !var PORT - Port to listen on
+serve:
+	@true
+`), 0644))
+
+	config := NewConfig()
+	config.MakefilePath = makefilePath
+	config.Vars = true
+	inputs, err := loadModelInputs(config)
+	require.NoError(t, err)
+	helpModel, err := buildHelpModelFromInputs(config, inputs)
+	require.NoError(t, err)
+	variables := collectVariables(helpModel, inputs.Targets.VariableValues)
+
+	var text bytes.Buffer
+	require.NoError(t, writeVariables(&text, variables, "text"))
+	assert.Equal(t, `ENV (required) - Target | environment
+  default: dev
+  choices: dev, prod
+  used by: deploy
+PORT - Port to listen on
+  default: 8080
+  used by: serve
+VERBOSE - Print every command
+  used by: build, deploy
+`, text.String())
+
+	var markdown bytes.Buffer
+	require.NoError(t, writeVariables(&markdown, variables, "markdown"))
+	assert.Equal(t, "| Variable | Required | Default | Used by | Description |\n"+
+		"| --- | --- | --- | --- | --- |\n"+
+		"| `ENV` | yes | `dev` | deploy | Target \\| environment (choices: dev, prod) |\n"+
+		"| `PORT` |  | `8080` | serve | Port to listen on |\n"+
+		"| `VERBOSE` |  |  | build, deploy | Print every command |\n", markdown.String())
+
+	var jsonOut bytes.Buffer
+	require.NoError(t, writeVariables(&jsonOut, variables[2:], "json"))
+	assert.JSONEq(t, `[{"name": "VERBOSE", "description": "Print every command", "required": false, "targets": ["build", "deploy"]}]`, jsonOut.String())
+}
+
+func TestRunVars_SpaceSeparatedDescription(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	makefilePath := filepath.Join(tmpDir, "Makefile")
+	require.NoError(t, os.WriteFile(makefilePath, []byte(`## Build the project.
+## !var LDFLAGS Linker flags for build
+build:
+	@true
+
+## Release the project.
+## !var LDFLAGS - Linker flags for build
+release:
+	@true
+`), 0644))
+
+	config := NewConfig()
+	config.MakefilePath = makefilePath
+	config.Vars = true
+	inputs, err := loadModelInputs(config)
+	require.NoError(t, err)
+	helpModel, err := buildHelpModelFromInputs(config, inputs)
+	require.NoError(t, err)
+
+	var text bytes.Buffer
+	require.NoError(t, writeVariables(&text, collectVariables(helpModel, inputs.Targets.VariableValues), "text"))
+	assert.Equal(t, `LDFLAGS - Linker flags for build
+  used by: build, release
+`, text.String())
+}
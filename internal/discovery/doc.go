@@ -18,7 +18,7 @@
 // # Target Discovery
 //
 // The DiscoverTargets function extracts target names by:
-//  1. Running make -p -r to get the make database
+//  1. Running make -p -r -q to get the make database
 //  2. Parsing lines matching the pattern ^<name>:
 //  3. Filtering out comments and recipe lines
 //
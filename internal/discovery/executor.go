@@ -3,10 +3,18 @@ package discovery
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"os"
 	"os/exec"
+	"slices"
+	"strings"
+	"time"
 )
 
+// cancelWaitDelay is how long a canceled command may take to exit after
+// being interrupted before it is killed.
+const cancelWaitDelay = 2 * time.Second
+
 // CommandExecutor defines the interface for executing external commands.
 // This interface allows for testability by enabling mock implementations.
 type CommandExecutor interface {
@@ -17,8 +25,26 @@ type CommandExecutor interface {
 	ExecuteContext(ctx context.Context, cmd string, args ...string) (stdout, stderr string, err error)
 }
 
+// DefaultEnvAllowList is the environment a scrubbed command receives: what
+// make and common shells need to find programs, temp space, and locale.
+var DefaultEnvAllowList = []string{
+	"PATH", "HOME", "TMPDIR", "TZ", "LANG", "LC_ALL", "LC_CTYPE", "LC_MESSAGES",
+	// Needed for programs to start on Windows
+	"SYSTEMROOT", "COMSPEC", "PATHEXT",
+}
+
 // DefaultExecutor is the default implementation of CommandExecutor using os/exec.
-type DefaultExecutor struct{}
+type DefaultExecutor struct {
+	// EnvAllowList, when non-nil, limits the environment of commands to
+	// these variables, so a Makefile's $(shell ...) cannot read secrets
+	// from it. Nil passes the whole environment.
+	EnvAllowList []string
+
+	// Sandbox runs commands in a new user and network namespace, without
+	// network access. Only supported on Linux with unprivileged user
+	// namespaces enabled.
+	Sandbox bool
+}
 
 // NewDefaultExecutor creates a new DefaultExecutor instance.
 func NewDefaultExecutor() *DefaultExecutor {
@@ -32,18 +58,67 @@ func (e *DefaultExecutor) Execute(cmd string, args ...string) (string, string, e
 
 // ExecuteContext runs a command with context support for timeout/cancellation.
 // It sets MAKE_HELP_GENERATING=1 in the child process environment to prevent
-// infinite recursion if the Makefile contains auto-regeneration rules.
+// infinite recursion if the Makefile contains auto-regeneration rules, and
+// LC_ALL=C so make's messages, which discovery matches, are not translated.
 func (e *DefaultExecutor) ExecuteContext(ctx context.Context, cmd string, args ...string) (string, string, error) {
-	command := exec.CommandContext(ctx, cmd, args...)
+	command, err := e.Command(ctx, cmd, args...)
+	if err != nil {
+		return "", "", err
+	}
 
 	// Set MAKE_HELP_GENERATING=1 in the child environment to prevent recursion.
 	// This is inherited by any process the child spawns (e.g., if make runs make-help).
-	command.Env = append(os.Environ(), "MAKE_HELP_GENERATING=1")
+	command.Env = append(command.Env, "MAKE_HELP_GENERATING=1", "LC_ALL=C")
 
 	var stdout, stderr bytes.Buffer
 	command.Stdout = &stdout
 	command.Stderr = &stderr
 
-	err := command.Run()
+	err = command.Run()
+	if err != nil && e.Sandbox && command.Process == nil {
+		err = fmt.Errorf("failed to start %s in a sandbox (user namespaces may be disabled): %w", cmd, err)
+	}
 	return stdout.String(), stderr.String(), err
 }
+
+// Command returns a command limited to the executor's environment and
+// sandbox, interrupted when ctx is canceled, for callers that set its
+// directory and standard streams themselves (such as running a target for
+// the user). Unlike ExecuteContext, it does not set MAKE_HELP_GENERATING.
+func (e *DefaultExecutor) Command(ctx context.Context, cmd string, args ...string) (*exec.Cmd, error) {
+	command := exec.CommandContext(ctx, cmd, args...)
+	command.Env = scrubEnv(os.Environ(), e.EnvAllowList)
+
+	if e.Sandbox {
+		attr, err := sandboxAttr()
+		if err != nil {
+			return nil, err
+		}
+		command.SysProcAttr = attr
+	}
+
+	// On cancellation, interrupt make so it can stop its own children, and
+	// kill it if it has not exited soon after
+	command.Cancel = func() error {
+		return interrupt(command.Process)
+	}
+	command.WaitDelay = cancelWaitDelay
+
+	return command, nil
+}
+
+// scrubEnv returns the variables of environ named in allow, or environ
+// itself when allow is nil.
+func scrubEnv(environ, allow []string) []string {
+	if allow == nil {
+		return environ
+	}
+	var scrubbed []string
+	for _, variable := range environ {
+		name, _, _ := strings.Cut(variable, "=")
+		if slices.Contains(allow, name) {
+			scrubbed = append(scrubbed, variable)
+		}
+	}
+	return scrubbed
+}
@@ -8,35 +8,53 @@ import (
 	"strings"
 )
 
+// ProbePattern is the os.CreateTemp pattern of the temporary Makefile probe
+// discoverMakefileList creates. Probes left by a killed make-help match it.
+const ProbePattern = ".makefile-discovery-*.mk"
+
 // discoverMakefileList discovers all Makefiles using the MAKEFILE_LIST variable.
-// It creates a temporary file with the main Makefile content plus a discovery target,
+// It creates a temporary file with the main Makefile content (or, with a work
+// directory, an include of it) plus a discovery target,
 // executes make to get the MAKEFILE_LIST, and returns the list of files.
 //
 // SECURITY: This function uses temporary physical files instead of bash process
 // substitution to prevent command injection vulnerabilities.
-func (s *Service) discoverMakefileList(mainPath string) ([]string, error) {
-	// Read main Makefile content
-	mainContent, err := os.ReadFile(mainPath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read Makefile: %w", err)
+func (s *Service) discoverMakefileList(ctx context.Context, mainPath string) ([]string, error) {
+	dir := filepath.Dir(mainPath)
+
+	// By default the probe is a copy of the main Makefile in its directory,
+	// so $(MAKEFILE_LIST) based paths in it resolve as usual. In a work
+	// directory, the probe includes the main Makefile instead.
+	probeDir := dir
+	var probeContent []byte
+	if s.workDir != "" {
+		absMain, err := filepath.Abs(mainPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve Makefile path: %w", err)
+		}
+		probeDir = s.workDir
+		probeContent = []byte("include " + strings.ReplaceAll(absMain, "$", "$$") + "\n")
+	} else {
+		mainContent, err := os.ReadFile(mainPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read Makefile: %w", err)
+		}
+		probeContent = mainContent
 	}
 
-	// Create temporary file in the same directory as the Makefile
-	// This ensures relative includes work correctly
-	dir := filepath.Dir(mainPath)
-	tmpFile, err := os.CreateTemp(dir, ".makefile-discovery-*.mk")
+	tmpFile, err := os.CreateTemp(probeDir, ProbePattern)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create temp file: %w", err)
 	}
 	tmpName := tmpFile.Name()
 
-	// Clean up temporary file when done
+	// Clean up temporary file when done, including on cancellation
 	defer func() { _ = os.Remove(tmpName) }()
 
-	// Write main content + discovery target
+	// Write probe content + discovery target
 	discoveryTarget := "\n\n.PHONY: _list_makefiles\n_list_makefiles:\n\t@echo $(MAKEFILE_LIST)\n"
 
-	if _, err := tmpFile.Write(mainContent); err != nil {
+	if _, err := tmpFile.Write(probeContent); err != nil {
 		_ = tmpFile.Close()
 		return nil, fmt.Errorf("failed to write temp file: %w", err)
 	}
@@ -50,7 +68,7 @@ func (s *Service) discoverMakefileList(mainPath string) ([]string, error) {
 	}
 
 	// Execute make with timeout to prevent indefinite hangs
-	ctx, cancel := context.WithTimeout(context.Background(), makeDiscoveryTimeout)
+	ctx, cancel := context.WithTimeout(ctx, makeDiscoveryTimeout)
 	defer cancel()
 
 	// Use -s (silent) and --no-print-directory to prevent make from adding
@@ -63,6 +81,9 @@ func (s *Service) discoverMakefileList(mainPath string) ([]string, error) {
 		if ctx.Err() == context.DeadlineExceeded {
 			return nil, fmt.Errorf("make command timed out after 30s")
 		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
 		return nil, fmt.Errorf("failed to discover makefiles: %w\nstderr: %s", err, stderr)
 	}
 
@@ -73,9 +94,17 @@ func (s *Service) discoverMakefileList(mainPath string) ([]string, error) {
 		return nil, fmt.Errorf("no Makefiles found in MAKEFILE_LIST")
 	}
 
-	// The first file in MAKEFILE_LIST will be the temp file, replace it with the original
-	if len(files) > 0 && filepath.Base(files[0]) == filepath.Base(tmpName) {
-		files[0] = mainPath
+	// The first file in MAKEFILE_LIST will be the temp file, replace it with
+	// the original; an including probe is followed by the original already
+	if filepath.Base(files[0]) == filepath.Base(tmpName) {
+		if s.workDir != "" {
+			files = files[1:]
+		} else {
+			files[0] = mainPath
+		}
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no Makefiles found in MAKEFILE_LIST")
 	}
 
 	// Resolve to absolute paths
@@ -0,0 +1,41 @@
+package discovery
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// FuzzParseTargetsFromDatabase checks that any make database output parses
+// without panicking into unique, non-empty target names.
+func FuzzParseTargetsFromDatabase(f *testing.F) {
+	// Seeds are make -p output for the fixture Makefiles
+	seeds, err := filepath.Glob(filepath.Join("testdata", "database", "*.txt"))
+	if err != nil {
+		f.Fatal(err)
+	}
+	for _, seed := range seeds {
+		content, err := os.ReadFile(seed)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(string(content))
+	}
+	f.Add("")
+	f.Add(".DEFAULT_GOAL := all\n# Files\nall: build\n.PHONY: all\nbuild:\n#  recipe to execute (from 'Makefile', line 3):\n\tgo build\n")
+
+	f.Fuzz(func(t *testing.T, output string) {
+		result := parseTargetsFromDatabase(output)
+		seen := make(map[string]bool)
+		for _, name := range result.Targets {
+			if strings.TrimSpace(name) == "" {
+				t.Fatalf("empty target name in %q", result.Targets)
+			}
+			if seen[name] {
+				t.Fatalf("duplicate target %q in %q", name, result.Targets)
+			}
+			seen[name] = true
+		}
+	})
+}
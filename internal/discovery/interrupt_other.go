@@ -0,0 +1,10 @@
+//go:build !windows
+
+package discovery
+
+import "os"
+
+// interrupt asks process to stop, letting make stop its own children.
+func interrupt(process *os.Process) error {
+	return process.Signal(os.Interrupt)
+}
@@ -0,0 +1,9 @@
+package discovery
+
+import "os"
+
+// interrupt kills process: Windows cannot send os.Interrupt to another
+// process.
+func interrupt(process *os.Process) error {
+	return process.Kill()
+}
@@ -8,8 +8,13 @@ import (
 	"github.com/sdlcforge/make-help/internal/errors"
 )
 
+// DefaultMakefileNames lists the names make looks for when no Makefile is
+// given, in order.
+var DefaultMakefileNames = []string{"GNUmakefile", "makefile", "Makefile"}
+
 // ResolveMakefilePath resolves a Makefile path to an absolute path.
-// If the path is empty, it defaults to "Makefile" in the current working directory.
+// If the path is empty, it defaults to the Makefile make would read in the
+// current working directory (see DefaultMakefile).
 // If the path is relative, it is resolved relative to the current working directory.
 func ResolveMakefilePath(path string) (string, error) {
 	// Default to Makefile in current directory
@@ -18,7 +23,7 @@ func ResolveMakefilePath(path string) (string, error) {
 		if err != nil {
 			return "", fmt.Errorf("failed to get current directory: %w", err)
 		}
-		path = filepath.Join(cwd, "Makefile")
+		path = DefaultMakefile(cwd)
 	}
 
 	// Convert to absolute path
@@ -30,6 +35,24 @@ func ResolveMakefilePath(path string) (string, error) {
 	return absPath, nil
 }
 
+// DefaultMakefile returns the path of the Makefile make reads in dir when
+// none is given: the first of DefaultMakefileNames that exists, or
+// dir/Makefile if none does. Names are matched exactly, so "makefile" is not
+// mistaken for "Makefile" on case-insensitive file systems.
+func DefaultMakefile(dir string) string {
+	entries, err := os.ReadDir(dir)
+	if err == nil {
+		for _, name := range DefaultMakefileNames {
+			for _, entry := range entries {
+				if entry.Name() == name && !entry.IsDir() {
+					return filepath.Join(dir, name)
+				}
+			}
+		}
+	}
+	return filepath.Join(dir, "Makefile")
+}
+
 // ValidateMakefileExists checks if a Makefile exists at the given path.
 // Returns MakefileNotFoundError if the file does not exist.
 func ValidateMakefileExists(path string) error {
@@ -0,0 +1,13 @@
+//go:build !linux
+
+package discovery
+
+import (
+	"errors"
+	"syscall"
+)
+
+// sandboxAttr reports that sandboxing needs Linux namespaces.
+func sandboxAttr() (*syscall.SysProcAttr, error) {
+	return nil, errors.New("sandboxing is only supported on Linux")
+}
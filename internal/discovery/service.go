@@ -1,6 +1,7 @@
 package discovery
 
 import (
+	"context"
 	"fmt"
 )
 
@@ -9,6 +10,8 @@ import (
 type Service struct {
 	executor CommandExecutor
 	verbose  bool
+	workDir  string
+	noExec   bool
 }
 
 // NewService creates a new discovery Service with the given executor and verbose flag.
@@ -19,28 +22,49 @@ func NewService(executor CommandExecutor, verbose bool) *Service {
 	}
 }
 
+// SetWorkDir places the temporary Makefile probe used by DiscoverMakefiles
+// in dir instead of next to the main Makefile. Empty restores the default.
+func (s *Service) SetWorkDir(dir string) {
+	s.workDir = dir
+}
+
+// SetNoExec makes DiscoverMakefiles and DiscoverTargets read the Makefiles
+// without running make. Only what is written literally is found; see
+// discoverTargetsStatic.
+func (s *Service) SetNoExec(noExec bool) {
+	s.noExec = noExec
+}
+
 // DiscoverMakefiles discovers all Makefiles using the MAKEFILE_LIST variable.
 // It returns an ordered list of absolute paths to all Makefiles (main and included).
 //
 // The function creates a temporary file with a special target to extract MAKEFILE_LIST,
 // executes make, and parses the output. This approach is secure and avoids shell injection.
-func (s *Service) DiscoverMakefiles(mainPath string) ([]string, error) {
+// Canceling ctx stops make and returns the context's error.
+func (s *Service) DiscoverMakefiles(ctx context.Context, mainPath string) ([]string, error) {
 	if s.verbose {
 		fmt.Printf("Discovering Makefiles starting from: %s\n", mainPath)
 	}
 
-	return s.discoverMakefileList(mainPath)
+	if s.noExec {
+		return discoverMakefilesStatic(mainPath)
+	}
+	return s.discoverMakefileList(ctx, mainPath)
 }
 
 // DiscoverTargets discovers all targets in the given Makefile using make -p.
 // It returns target names and their .PHONY status extracted from the make database output.
 //
 // The function filters out special targets, pattern rules, and built-in targets,
-// returning only user-defined targets.
-func (s *Service) DiscoverTargets(makefilePath string) (*DiscoverTargetsResult, error) {
+// returning only user-defined targets. Canceling ctx stops make and returns
+// the context's error.
+func (s *Service) DiscoverTargets(ctx context.Context, makefilePath string) (*DiscoverTargetsResult, error) {
 	if s.verbose {
 		fmt.Printf("Discovering targets from: %s\n", makefilePath)
 	}
 
-	return s.discoverTargets(makefilePath)
+	if s.noExec {
+		return discoverTargetsStatic(makefilePath)
+	}
+	return s.discoverTargets(ctx, makefilePath)
 }
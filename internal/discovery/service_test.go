@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -137,7 +139,7 @@ func TestDiscoverMakefiles_Verbose(t *testing.T) {
 	service := NewService(executor, true) // verbose mode
 
 	// With real executor, this should succeed
-	makefiles, err := service.DiscoverMakefiles(makefilePath)
+	makefiles, err := service.DiscoverMakefiles(context.Background(), makefilePath)
 	require.NoError(t, err)
 	assert.GreaterOrEqual(t, len(makefiles), 1)
 }
@@ -161,7 +163,7 @@ test:
 `)
 
 	service := NewService(mock, false)
-	result, err := service.DiscoverTargets(makefilePath)
+	result, err := service.DiscoverTargets(context.Background(), makefilePath)
 
 	require.NoError(t, err)
 	assert.Equal(t, []string{"all", "build", "test"}, result.Targets)
@@ -182,7 +184,7 @@ build:
 `)
 
 	service := NewService(mock, true) // verbose mode
-	result, err := service.DiscoverTargets(makefilePath)
+	result, err := service.DiscoverTargets(context.Background(), makefilePath)
 
 	require.NoError(t, err)
 	assert.Equal(t, []string{"all", "build"}, result.Targets)
@@ -216,6 +218,29 @@ func TestDiscoverTargets_Timeout(t *testing.T) {
 	assert.Equal(t, context.DeadlineExceeded, err)
 }
 
+func TestDiscoverTargets_Canceled(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	makefilePath := filepath.Join(tmpDir, "Makefile")
+
+	err := os.WriteFile(makefilePath, []byte("all:\n\t@echo hello\n"), 0644)
+	require.NoError(t, err)
+
+	mock := NewMockCommandExecutor()
+	mock.SetPrefixMatch(true)
+	mock.SetDelay("make -s --no-print-directory -f", 35*time.Second)
+	mock.SetOutput("make -s --no-print-directory -f", "all:")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	service := NewService(mock, false)
+	_, err = service.DiscoverTargets(ctx, makefilePath)
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
 func TestDiscoverTargets_Error(t *testing.T) {
 	t.Parallel()
 	tmpDir := t.TempDir()
@@ -229,7 +254,59 @@ func TestDiscoverTargets_Error(t *testing.T) {
 	mock.SetError("make -f", fmt.Errorf("make failed"))
 
 	service := NewService(mock, false)
-	_, err = service.DiscoverTargets(makefilePath)
+	_, err = service.DiscoverTargets(context.Background(), makefilePath)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to discover targets")
+}
+
+func TestDiscoverTargets_GoalOutOfDate(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	makefilePath := filepath.Join(tmpDir, "Makefile")
+	marker := filepath.Join(tmpDir, "ran")
+
+	// make -q exits with status 1 because the default goal is out of date
+	content := fmt.Sprintf("all: build\n\ttouch %s\n\nbuild:\n\ttouch %s\n", marker, marker)
+	require.NoError(t, os.WriteFile(makefilePath, []byte(content), 0644))
+
+	service := NewService(NewDefaultExecutor(), false)
+	result, err := service.DiscoverTargets(context.Background(), makefilePath)
+
+	require.NoError(t, err)
+	assert.Contains(t, result.Targets, "all")
+	assert.Contains(t, result.Targets, "build")
+	assert.NoFileExists(t, marker, "discovery must not run the default goal's recipes")
+}
+
+func TestDiscoverTargets_BuiltinRulePrerequisite(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	makefilePath := filepath.Join(tmpDir, "Makefile")
+
+	// prog.o needs the built-in %.o: %.c rule, which -r turns off
+	content := "prog: prog.o\n\tcc -o prog prog.o\n\nclean:\n\trm -f prog prog.o\n"
+	require.NoError(t, os.WriteFile(makefilePath, []byte(content), 0644))
+
+	service := NewService(NewDefaultExecutor(), false)
+	result, err := service.DiscoverTargets(context.Background(), makefilePath)
+
+	require.NoError(t, err)
+	assert.Contains(t, result.Targets, "prog")
+	assert.Contains(t, result.Targets, "clean")
+	assert.Equal(t, []string{"prog.o"}, result.Dependencies["prog"])
+}
+
+func TestDiscoverTargets_MissingInclude(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	makefilePath := filepath.Join(tmpDir, "Makefile")
+
+	content := fmt.Sprintf("include %s\n\nall:\n\t@echo hello\n", filepath.Join(tmpDir, "missing.mk"))
+	require.NoError(t, os.WriteFile(makefilePath, []byte(content), 0644))
+
+	service := NewService(NewDefaultExecutor(), false)
+	_, err := service.DiscoverTargets(context.Background(), makefilePath)
 
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to discover targets")
@@ -629,6 +706,31 @@ func TestResolveMakefilePath(t *testing.T) {
 	}
 }
 
+func TestDefaultMakefile(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		files    []string
+		expected string
+	}{
+		{name: "no Makefile", files: nil, expected: "Makefile"},
+		{name: "Makefile", files: []string{"Makefile"}, expected: "Makefile"},
+		{name: "makefile before Makefile", files: []string{"Makefile", "makefile"}, expected: "makefile"},
+		{name: "GNUmakefile first", files: []string{"Makefile", "GNUmakefile"}, expected: "GNUmakefile"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dir := t.TempDir()
+			for _, name := range tt.files {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("all:\n"), 0644))
+			}
+			assert.Equal(t, filepath.Join(dir, tt.expected), DefaultMakefile(dir))
+		})
+	}
+}
+
 func TestValidateMakefileExists(t *testing.T) {
 	t.Parallel()
 	t.Run("file exists", func(t *testing.T) {
@@ -692,6 +794,73 @@ func TestDefaultExecutor_ContextCancellation(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestDefaultExecutor_CancelStopsRunningCommand(t *testing.T) {
+	t.Parallel()
+	executor := NewDefaultExecutor()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, _, err := executor.ExecuteContext(ctx, "sleep", "10")
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestScrubEnv(t *testing.T) {
+	t.Parallel()
+	environ := []string{"PATH=/bin", "SECRET_TOKEN=abc", "HOME=/home/me", "PATHEXTRA=x"}
+
+	assert.Equal(t, environ, scrubEnv(environ, nil))
+	assert.Equal(t, []string{"PATH=/bin", "HOME=/home/me"}, scrubEnv(environ, DefaultEnvAllowList))
+	assert.Empty(t, scrubEnv(environ, []string{}))
+}
+
+func TestDefaultExecutor_EnvAllowList(t *testing.T) {
+	// Not parallel: sets environment variables
+	t.Setenv("MAKE_HELP_TEST_SECRET", "secret")
+	t.Setenv("MAKE_HELP_TEST_KEPT", "kept")
+
+	executor := NewDefaultExecutor()
+	executor.EnvAllowList = append(slices.Clone(DefaultEnvAllowList), "MAKE_HELP_TEST_KEPT")
+
+	stdout, _, err := executor.Execute("sh", "-c", "echo \"$MAKE_HELP_TEST_SECRET|$MAKE_HELP_TEST_KEPT|$MAKE_HELP_GENERATING\"")
+	require.NoError(t, err)
+	assert.Equal(t, "|kept|1\n", stdout)
+}
+
+func TestDefaultExecutor_Locale(t *testing.T) {
+	// Not parallel: sets environment variables
+	t.Setenv("LC_ALL", "de_DE.UTF-8")
+	t.Setenv("LC_MESSAGES", "de_DE.UTF-8")
+
+	// make's messages stay in English, whatever the user's locale
+	executor := NewDefaultExecutor()
+	executor.EnvAllowList = DefaultEnvAllowList
+	stdout, _, err := executor.Execute("sh", "-c", "echo \"$LC_ALL\"")
+	require.NoError(t, err)
+	assert.Equal(t, "C\n", stdout)
+}
+
+func TestDefaultExecutor_Sandbox(t *testing.T) {
+	t.Parallel()
+	if runtime.GOOS != "linux" {
+		t.Skip("sandboxing is only supported on Linux")
+	}
+	executor := NewDefaultExecutor()
+	executor.Sandbox = true
+
+	// Only the loopback interface exists in the new network namespace
+	stdout, _, err := executor.Execute("cat", "/proc/net/dev")
+	if err != nil && strings.Contains(err.Error(), "sandbox") {
+		t.Skipf("user namespaces are unavailable: %v", err)
+	}
+	require.NoError(t, err)
+	for _, line := range strings.Split(strings.TrimSpace(stdout), "\n")[2:] {
+		assert.Equal(t, "lo:", strings.Fields(line)[0])
+	}
+}
+
 func TestDefaultExecutor_CommandError(t *testing.T) {
 	t.Parallel()
 	executor := NewDefaultExecutor()
@@ -709,7 +878,7 @@ func TestDiscoverMakefileList_ReadError(t *testing.T) {
 	service := NewService(mock, false)
 
 	// Try to discover from a non-existent file
-	_, err := service.discoverMakefileList("/nonexistent/path/Makefile")
+	_, err := service.discoverMakefileList(context.Background(), "/nonexistent/path/Makefile")
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to read Makefile")
 }
@@ -727,13 +896,41 @@ func TestDiscoverMakefileList_Success(t *testing.T) {
 	executor := NewDefaultExecutor()
 	service := NewService(executor, false)
 
-	makefiles, err := service.discoverMakefileList(makefilePath)
+	makefiles, err := service.discoverMakefileList(context.Background(), makefilePath)
 	require.NoError(t, err)
 	assert.GreaterOrEqual(t, len(makefiles), 1)
 	// The first file should be the main Makefile
 	assert.Equal(t, makefilePath, makefiles[0])
 }
 
+func TestDiscoverMakefileList_WorkDir(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	workDir := t.TempDir()
+	makefilePath := filepath.Join(tmpDir, "Makefile")
+	extraPath := filepath.Join(tmpDir, "extra.mk")
+
+	// Includes relative to the Makefile's own location must still resolve
+	err := os.WriteFile(makefilePath, []byte("include $(dir $(lastword $(MAKEFILE_LIST)))extra.mk\nall:\n\t@echo hello\n"), 0644)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(extraPath, []byte("extra:\n"), 0644))
+
+	service := NewService(NewDefaultExecutor(), false)
+	service.SetWorkDir(workDir)
+
+	makefiles, err := service.discoverMakefileList(context.Background(), makefilePath)
+	require.NoError(t, err)
+	assert.Equal(t, []string{makefilePath, extraPath}, makefiles)
+
+	// The probe was written to, and removed from, the work directory only
+	entries, err := os.ReadDir(workDir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+	probes, err := filepath.Glob(filepath.Join(tmpDir, ProbePattern))
+	require.NoError(t, err)
+	assert.Empty(t, probes)
+}
+
 func TestDiscoverMakefileList_Verbose(t *testing.T) {
 	t.Parallel()
 	tmpDir := t.TempDir()
@@ -745,7 +942,7 @@ func TestDiscoverMakefileList_Verbose(t *testing.T) {
 	executor := NewDefaultExecutor()
 	service := NewService(executor, true) // verbose mode
 
-	makefiles, err := service.discoverMakefileList(makefilePath)
+	makefiles, err := service.discoverMakefileList(context.Background(), makefilePath)
 	require.NoError(t, err)
 	assert.GreaterOrEqual(t, len(makefiles), 1)
 }
@@ -765,7 +962,7 @@ func TestDiscoverMakefileList_EmptyResult(t *testing.T) {
 	mock.SetOutput("make -s --no-print-directory -f", "") // Empty output
 
 	service := NewService(mock, false)
-	_, err = service.discoverMakefileList(makefilePath)
+	_, err = service.discoverMakefileList(context.Background(), makefilePath)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "no Makefiles found")
 }
@@ -0,0 +1,39 @@
+package discovery
+
+import "github.com/sdlcforge/make-help/internal/discovery/static"
+
+// ShellExpression is shell code make runs while reading a Makefile: a
+// $(shell ...) call or a "!=" assignment outside recipes.
+type ShellExpression = static.ShellExpression
+
+// discoverMakefilesStatic returns mainPath and the files it includes, in
+// the order make reads them, without running make (see
+// static.DiscoverMakefiles).
+func discoverMakefilesStatic(mainPath string) ([]string, error) {
+	return static.DiscoverMakefiles(static.OSFileSystem{}, mainPath)
+}
+
+// discoverTargetsStatic finds the targets of mainPath and the files it
+// includes without running make (see static.DiscoverTargets).
+func discoverTargetsStatic(mainPath string) (*DiscoverTargetsResult, error) {
+	targets, err := static.DiscoverTargets(static.OSFileSystem{}, mainPath)
+	if err != nil {
+		return nil, err
+	}
+	return &DiscoverTargetsResult{
+		Targets:        targets.Targets,
+		IsPhony:        targets.IsPhony,
+		Dependencies:   targets.Dependencies,
+		HasRecipe:      targets.HasRecipe,
+		DefaultGoal:    targets.DefaultGoal,
+		VariableValues: targets.VariableValues,
+	}, nil
+}
+
+// FindShellExpressions returns the shell expressions outside recipes and
+// define blocks in mainPath and the files it includes, as found without
+// running make, in the order make reads them. Expressions in recipes only
+// run when a target is built.
+func FindShellExpressions(mainPath string) ([]ShellExpression, error) {
+	return static.FindShellExpressions(static.OSFileSystem{}, mainPath)
+}
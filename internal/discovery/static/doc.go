@@ -0,0 +1,12 @@
+// Package static discovers Makefiles and targets without running make, for
+// --no-exec and for builds without processes, such as WebAssembly.
+//
+// It follows include directives and finds rules, .PHONY, .DEFAULT_GOAL, and
+// variable assignments written literally; anything computed by make
+// (variables in include paths or target names, conditionals, $(eval ...))
+// is ignored.
+//
+// Files are read through a FileSystem: OSFileSystem reads the disk, and
+// MemoryFileSystem holds Makefile text given by the caller. The package
+// must not depend on os/exec, so that it links into js/wasm builds.
+package static
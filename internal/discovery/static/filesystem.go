@@ -0,0 +1,82 @@
+package static
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+)
+
+// FileSystem is where static discovery reads Makefiles from.
+type FileSystem interface {
+	// ReadFile returns the content of the file at name.
+	ReadFile(name string) ([]byte, error)
+
+	// Glob returns the files matching pattern, as filepath.Glob does.
+	Glob(pattern string) ([]string, error)
+
+	// Abs returns an absolute form of name.
+	Abs(name string) (string, error)
+}
+
+// OSFileSystem reads files from the disk. Relative names are relative to
+// the working directory, as for make.
+type OSFileSystem struct{}
+
+// ReadFile implements FileSystem.
+func (OSFileSystem) ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(name)
+}
+
+// Glob implements FileSystem.
+func (OSFileSystem) Glob(pattern string) ([]string, error) {
+	return filepath.Glob(pattern)
+}
+
+// Abs implements FileSystem.
+func (OSFileSystem) Abs(name string) (string, error) {
+	return filepath.Abs(name)
+}
+
+// MemoryFileSystem holds file contents by slash-separated path. Relative
+// paths are relative to "/", which is also the working directory.
+type MemoryFileSystem map[string]string
+
+// ReadFile implements FileSystem.
+func (m MemoryFileSystem) ReadFile(name string) ([]byte, error) {
+	abs, _ := m.Abs(name)
+	for file, content := range m {
+		if other, _ := m.Abs(file); other == abs {
+			return []byte(content), nil
+		}
+	}
+	return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+}
+
+// Glob implements FileSystem. Matches are returned sorted, in the form of
+// pattern: relative when pattern is relative.
+func (m MemoryFileSystem) Glob(pattern string) ([]string, error) {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, err
+	}
+	absPattern, _ := m.Abs(pattern)
+	var matches []string
+	for file := range m {
+		abs, _ := m.Abs(file)
+		if ok, _ := path.Match(absPattern, abs); !ok {
+			continue
+		}
+		if !path.IsAbs(pattern) {
+			abs = abs[1:]
+		}
+		matches = append(matches, abs)
+	}
+	sort.Strings(matches)
+	return matches, nil
+}
+
+// Abs implements FileSystem.
+func (MemoryFileSystem) Abs(name string) (string, error) {
+	return path.Join("/", name), nil
+}
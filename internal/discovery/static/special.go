@@ -0,0 +1,44 @@
+package static
+
+import "strings"
+
+// IsSpecialTarget returns true if the target is a special or built-in Make
+// target, a pattern rule, or a variable assignment that looks like a target.
+func IsSpecialTarget(name string) bool {
+	// Skip Make's special targets
+	specialTargets := map[string]bool{
+		".SUFFIXES":             true,
+		".DEFAULT":              true,
+		".PRECIOUS":             true,
+		".INTERMEDIATE":         true,
+		".SECONDARY":            true,
+		".SECONDEXPANSION":      true,
+		".DELETE_ON_ERROR":      true,
+		".IGNORE":               true,
+		".LOW_RESOLUTION_TIME":  true,
+		".SILENT":               true,
+		".EXPORT_ALL_VARIABLES": true,
+		".NOTPARALLEL":          true,
+		".ONESHELL":             true,
+		".POSIX":                true,
+		"Makefile":              true,
+		"makefile":              true,
+	}
+
+	// Check if it's a known special target
+	if specialTargets[name] {
+		return true
+	}
+
+	// Skip pattern rules (contain %)
+	if strings.Contains(name, "%") {
+		return true
+	}
+
+	// Skip variable assignments that look like targets (contain =)
+	if strings.Contains(name, "=") {
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,413 @@
+package static
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// includeRegex matches include directives, capturing the file list.
+var includeRegex = regexp.MustCompile(`^(?:-include|sinclude|include)\s+(.+)$`)
+
+// variableRegex matches variable assignments, capturing the name,
+// operator, and unexpanded value.
+var variableRegex = regexp.MustCompile(`^(?:(?:override|export|private)\s+)*([A-Za-z_.][A-Za-z0-9_.-]*)\s*(\?=|\+=|!=|:::=|::=|:=|=)\s*(.*)$`)
+
+// selfDirRefs are the spellings of the directory of the Makefile being read
+// that static discovery resolves in include paths.
+var selfDirRefs = []string{"$(dir $(lastword $(MAKEFILE_LIST)))", "${dir ${lastword ${MAKEFILE_LIST}}}"}
+
+// conditionalDirectives and otherDirectives start lines that are not rules
+// even when they contain a colon.
+var (
+	conditionalDirectives = []string{"ifeq", "ifneq", "ifdef", "ifndef", "else", "endif"}
+	otherDirectives       = []string{"include", "-include", "sinclude", "export", "unexport", "vpath", "undefine"}
+)
+
+// logicalLine is a Makefile line with its continuation lines joined.
+type logicalLine struct {
+	// Text is the line without comments. Recipe lines keep their leading tab.
+	Text string
+
+	// Line is the 1-based number of its first physical line.
+	Line int
+}
+
+// readLogicalLines reads path from fsys, joining backslash continuations,
+// dropping comments outside recipes, and skipping define ... endef blocks,
+// whose content is only read when expanded.
+func readLogicalLines(fsys FileSystem, path string) ([]logicalLine, error) {
+	content, err := fsys.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []logicalLine
+	var current strings.Builder
+	start, lineNum, defineDepth := 0, 0, 0
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lineNum++
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if current.Len() == 0 {
+			start = lineNum
+		} else {
+			text = " " + strings.TrimLeft(text, " \t")
+		}
+		if continued, ok := strings.CutSuffix(text, "\\"); ok {
+			// make joins the lines with a single space
+			current.WriteString(strings.TrimRight(continued, " \t"))
+			continue
+		}
+		current.WriteString(text)
+		text = current.String()
+		current.Reset()
+
+		word := firstWord(text)
+		if defineDepth > 0 {
+			switch word {
+			case "define":
+				defineDepth++
+			case "endef":
+				defineDepth--
+			}
+			continue
+		}
+		if word == "define" {
+			defineDepth++
+			continue
+		}
+		if !strings.HasPrefix(text, "\t") {
+			text = stripComment(text)
+		}
+		if strings.TrimSpace(text) != "" {
+			lines = append(lines, logicalLine{Text: text, Line: start})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// firstWord returns the first whitespace-separated word of text, skipping
+// the override and export modifiers before define.
+func firstWord(text string) string {
+	fields := strings.Fields(text)
+	for len(fields) > 1 && (fields[0] == "override" || fields[0] == "export") {
+		fields = fields[1:]
+	}
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
+
+// stripComment removes a comment starting at the first "#" that is not
+// escaped with a backslash.
+func stripComment(text string) string {
+	for i := 0; i < len(text); i++ {
+		if text[i] == '\\' {
+			i++
+			continue
+		}
+		if text[i] == '#' {
+			return text[:i]
+		}
+	}
+	return text
+}
+
+// DiscoverMakefiles returns mainPath and the files it includes, in the
+// order make reads them. Include paths are resolved against the working
+// directory of fsys, as make does; the directory of the including Makefile
+// written as $(dir $(lastword $(MAKEFILE_LIST))) is resolved too. Paths
+// using other variables and missing files are skipped.
+func DiscoverMakefiles(fsys FileSystem, mainPath string) ([]string, error) {
+	var files []string
+	seen := make(map[string]bool)
+
+	var visit func(path string) error
+	visit = func(path string) error {
+		if seen[path] {
+			return nil
+		}
+		seen[path] = true
+		files = append(files, path)
+
+		lines, err := readLogicalLines(fsys, path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		for _, line := range lines {
+			if strings.HasPrefix(line.Text, "\t") {
+				continue
+			}
+			matches := includeRegex.FindStringSubmatch(strings.TrimSpace(line.Text))
+			if matches == nil {
+				continue
+			}
+			list := matches[1]
+			for _, ref := range selfDirRefs {
+				list = strings.ReplaceAll(list, ref, filepath.Dir(path)+string(filepath.Separator))
+			}
+			for _, pattern := range strings.Fields(list) {
+				if strings.Contains(pattern, "$") {
+					continue
+				}
+				included, err := fsys.Glob(pattern)
+				if err != nil {
+					continue
+				}
+				for _, file := range included {
+					abs, err := fsys.Abs(file)
+					if err != nil {
+						return fmt.Errorf("failed to resolve %s: %w", file, err)
+					}
+					if err := visit(abs); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		return nil
+	}
+
+	abs, err := fsys.Abs(mainPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve Makefile path: %w", err)
+	}
+	if err := visit(abs); err != nil {
+		return nil, err
+	}
+	// Report the main Makefile as given, as make-based discovery does
+	files[0] = mainPath
+	return files, nil
+}
+
+// Targets holds what static discovery finds about targets. The fields mean
+// the same as those of make-based discovery.
+type Targets struct {
+	// Targets contains the target names in the order they are defined.
+	Targets []string
+
+	// IsPhony maps target names to their .PHONY status.
+	IsPhony map[string]bool
+
+	// Dependencies maps target names to their prerequisites.
+	Dependencies map[string][]string
+
+	// HasRecipe maps target names to whether they have a recipe.
+	HasRecipe map[string]bool
+
+	// DefaultGoal is .DEFAULT_GOAL when assigned, otherwise the first target.
+	DefaultGoal string
+
+	// VariableValues maps variables to their unexpanded values.
+	VariableValues map[string]string
+}
+
+// DiscoverTargets finds the targets of mainPath and the files it includes.
+// Rules whose targets or prerequisites use variables are only partly
+// understood: names containing "$" are skipped.
+func DiscoverTargets(fsys FileSystem, mainPath string) (*Targets, error) {
+	makefiles, err := DiscoverMakefiles(fsys, mainPath)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Targets{
+		Targets:        []string{},
+		IsPhony:        make(map[string]bool),
+		Dependencies:   make(map[string][]string),
+		HasRecipe:      make(map[string]bool),
+		VariableValues: make(map[string]string),
+	}
+	seen := make(map[string]bool)
+	var firstGoal string
+
+	for _, makefile := range makefiles {
+		lines, err := readLogicalLines(fsys, makefile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", makefile, err)
+		}
+
+		var ruleTargets []string
+		for _, line := range lines {
+			if strings.HasPrefix(line.Text, "\t") {
+				for _, name := range ruleTargets {
+					result.HasRecipe[name] = true
+				}
+				continue
+			}
+			text := strings.TrimSpace(line.Text)
+			word := firstWord(text)
+			if slices.Contains(conditionalDirectives, word) {
+				// Conditionals may select recipe lines, so the rule goes on
+				continue
+			}
+			ruleTargets = nil
+
+			if matches := variableRegex.FindStringSubmatch(text); matches != nil {
+				recordVariable(result, matches[1], matches[2], matches[3])
+				continue
+			}
+			targetsPart, rest, ok := strings.Cut(text, ":")
+			if !ok || slices.Contains(otherDirectives, word) {
+				continue
+			}
+			rest = strings.TrimPrefix(rest, ":")
+			rest, inlineRecipe, hasInline := strings.Cut(rest, ";")
+			if strings.Contains(rest, "=") {
+				// A target-specific variable ("target: VAR = value")
+				continue
+			}
+			if _, patternPrereqs, isStaticPattern := strings.Cut(rest, ":"); isStaticPattern {
+				rest = patternPrereqs
+			}
+
+			var prereqs []string
+			for _, prereq := range strings.Fields(rest) {
+				if prereq != "|" && !strings.Contains(prereq, "$") && !IsSpecialTarget(prereq) {
+					prereqs = append(prereqs, prereq)
+				}
+			}
+
+			for _, name := range strings.Fields(targetsPart) {
+				if name == ".PHONY" {
+					for _, prereq := range prereqs {
+						result.IsPhony[prereq] = true
+					}
+					continue
+				}
+				if strings.Contains(name, "$") || name == ".DEFAULT_GOAL" || IsSpecialTarget(name) {
+					continue
+				}
+				if !seen[name] {
+					seen[name] = true
+					result.Targets = append(result.Targets, name)
+				}
+				// make's default goal is the first target not starting
+				// with "." (unless it is a path)
+				if firstGoal == "" && (!strings.HasPrefix(name, ".") || strings.Contains(name, "/")) {
+					firstGoal = name
+				}
+				for _, prereq := range prereqs {
+					if !slices.Contains(result.Dependencies[name], prereq) {
+						result.Dependencies[name] = append(result.Dependencies[name], prereq)
+					}
+				}
+				if hasInline && strings.TrimSpace(inlineRecipe) != "" {
+					result.HasRecipe[name] = true
+				}
+				ruleTargets = append(ruleTargets, name)
+			}
+		}
+	}
+
+	if goal, ok := result.VariableValues[".DEFAULT_GOAL"]; ok {
+		result.DefaultGoal = goal
+		delete(result.VariableValues, ".DEFAULT_GOAL")
+	} else {
+		result.DefaultGoal = firstGoal
+	}
+	return result, nil
+}
+
+// recordVariable records an assignment of name with operator op to result.
+// Values computed by the shell ("!=") are not known without make.
+func recordVariable(result *Targets, name, op, value string) {
+	switch op {
+	case "!=":
+		return
+	case "?=":
+		if _, ok := result.VariableValues[name]; ok {
+			return
+		}
+	case "+=":
+		if previous, ok := result.VariableValues[name]; ok && previous != "" {
+			value = previous + " " + value
+		}
+	}
+	result.VariableValues[name] = value
+}
+
+// ShellExpression is shell code make runs while reading a Makefile: a
+// $(shell ...) call or a "!=" assignment outside recipes.
+type ShellExpression struct {
+	// File is the Makefile containing the expression.
+	File string
+
+	// Line is the 1-based line it starts on.
+	Line int
+
+	// Expression is the expression as written.
+	Expression string
+}
+
+// FindShellExpressions returns the shell expressions outside recipes and
+// define blocks in mainPath and the files it includes, in the order make
+// reads them. Expressions in recipes only run when a target is built.
+func FindShellExpressions(fsys FileSystem, mainPath string) ([]ShellExpression, error) {
+	makefiles, err := DiscoverMakefiles(fsys, mainPath)
+	if err != nil {
+		return nil, err
+	}
+	var expressions []ShellExpression
+	for _, makefile := range makefiles {
+		lines, err := readLogicalLines(fsys, makefile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", makefile, err)
+		}
+		for _, line := range lines {
+			if strings.HasPrefix(line.Text, "\t") {
+				continue
+			}
+			text := strings.TrimSpace(line.Text)
+			if matches := variableRegex.FindStringSubmatch(text); matches != nil && matches[2] == "!=" {
+				expressions = append(expressions, ShellExpression{File: makefile, Line: line.Line, Expression: text})
+				continue
+			}
+			for _, call := range shellCalls(text) {
+				expressions = append(expressions, ShellExpression{File: makefile, Line: line.Line, Expression: call})
+			}
+		}
+	}
+	return expressions, nil
+}
+
+// shellCalls returns the $(shell ...) and ${shell ...} calls in text, each
+// up to its matching closing parenthesis or brace. Nested calls are part
+// of the outer one.
+func shellCalls(text string) []string {
+	var calls []string
+	for i := 0; i < len(text); i++ {
+		if !strings.HasPrefix(text[i:], "$(shell") && !strings.HasPrefix(text[i:], "${shell") {
+			continue
+		}
+		open, closing := text[i+1], byte(')')
+		if open == '{' {
+			closing = '}'
+		}
+		depth, end := 0, len(text)
+		for j := i + 1; j < len(text); j++ {
+			if text[j] == open {
+				depth++
+			} else if text[j] == closing {
+				depth--
+				if depth == 0 {
+					end = j + 1
+					break
+				}
+			}
+		}
+		calls = append(calls, text[i:end])
+		i = end - 1
+	}
+	return calls
+}
@@ -0,0 +1,83 @@
+package static
+
+import (
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryFileSystem(t *testing.T) {
+	t.Parallel()
+	fsys := MemoryFileSystem{
+		"Makefile":   "all:\n",
+		"make/a.mk":  "a:\n",
+		"/make/b.mk": "b:\n",
+		"docs/c.md":  "",
+	}
+
+	content, err := fsys.ReadFile("/Makefile")
+	require.NoError(t, err)
+	assert.Equal(t, "all:\n", string(content))
+	content, err = fsys.ReadFile("make/../make/b.mk")
+	require.NoError(t, err)
+	assert.Equal(t, "b:\n", string(content))
+	_, err = fsys.ReadFile("missing.mk")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+
+	matches, err := fsys.Glob("make/*.mk")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"make/a.mk", "make/b.mk"}, matches)
+	matches, err = fsys.Glob("/make/*.mk")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/make/a.mk", "/make/b.mk"}, matches)
+	_, err = fsys.Glob("[")
+	assert.Error(t, err)
+
+	abs, err := fsys.Abs("make/a.mk")
+	require.NoError(t, err)
+	assert.Equal(t, "/make/a.mk", abs)
+}
+
+func TestDiscoverTargets_Memory(t *testing.T) {
+	t.Parallel()
+	fsys := MemoryFileSystem{
+		"Makefile": "include make/*.mk\n" +
+			"-include $(dir $(lastword $(MAKEFILE_LIST)))local.mk\n" +
+			".DEFAULT_GOAL := test\n" +
+			".PHONY: build test\n" +
+			"build: lint\n\tgo build ./...\n" +
+			"test:\n\tgo test ./...\n",
+		"make/lint.mk": "PORT ?= 8080\nlint:\n\tgolangci-lint run\n",
+		"local.mk":     "local: ; @echo local\n",
+		"unused.mk":    "unused:\n",
+	}
+
+	makefiles, err := DiscoverMakefiles(fsys, "Makefile")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Makefile", "/make/lint.mk", "/local.mk"}, makefiles)
+
+	targets, err := DiscoverTargets(fsys, "Makefile")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"build", "test", "lint", "local"}, targets.Targets)
+	assert.Equal(t, map[string]bool{"build": true, "test": true}, targets.IsPhony)
+	assert.Equal(t, []string{"lint"}, targets.Dependencies["build"])
+	assert.True(t, targets.HasRecipe["local"])
+	assert.Equal(t, "test", targets.DefaultGoal)
+	assert.Equal(t, "8080", targets.VariableValues["PORT"])
+}
+
+func TestFindShellExpressions_Memory(t *testing.T) {
+	t.Parallel()
+	fsys := MemoryFileSystem{
+		"Makefile": "VERSION := $(shell git describe)\nDATE != date\nall:\n\techo $(shell pwd)\n",
+	}
+
+	expressions, err := FindShellExpressions(fsys, "Makefile")
+	require.NoError(t, err)
+	assert.Equal(t, []ShellExpression{
+		{File: "Makefile", Line: 1, Expression: "$(shell git describe)"},
+		{File: "Makefile", Line: 2, Expression: "DATE != date"},
+	}, expressions)
+}
@@ -0,0 +1,167 @@
+package discovery
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeStaticProject(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	return dir
+}
+
+func TestDiscoverMakefilesStatic(t *testing.T) {
+	t.Parallel()
+	dir := writeStaticProject(t, map[string]string{
+		"Makefile": "include $(dir $(lastword $(MAKEFILE_LIST)))common.mk\n" +
+			"-include $(dir $(lastword $(MAKEFILE_LIST)))make/*.mk\n" +
+			"-include missing.mk $(GENERATED)\n" +
+			"all:\n\t@echo include not-a-directive.mk\n",
+		"common.mk": "-include ${dir ${lastword ${MAKEFILE_LIST}}}nested.mk\n",
+		"nested.mk": "nested:\n",
+		"make/a.mk": "a:\n",
+		"make/b.mk": "include $(dir $(lastword $(MAKEFILE_LIST)))a.mk # already read\n",
+		"unused.mk": "unused:\n",
+	})
+	makefilePath := filepath.Join(dir, "Makefile")
+
+	files, err := discoverMakefilesStatic(makefilePath)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		makefilePath,
+		filepath.Join(dir, "common.mk"),
+		filepath.Join(dir, "nested.mk"),
+		filepath.Join(dir, "make", "a.mk"),
+		filepath.Join(dir, "make", "b.mk"),
+	}, files)
+}
+
+func TestDiscoverMakefilesStatic_ReadError(t *testing.T) {
+	t.Parallel()
+	_, err := discoverMakefilesStatic("/nonexistent/Makefile")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to read")
+}
+
+func TestDiscoverTargetsStatic(t *testing.T) {
+	t.Parallel()
+	dir := writeStaticProject(t, map[string]string{
+		"Makefile": `# A comment: not a rule
+VERSION := 1.0
+PORT ?= 8080
+PORT ?= 9090
+FLAGS = -a
+FLAGS += -b
+DATE != date
+URL = http://example.com
+.DEFAULT_GOAL := build
+
+.PHONY: build \
+	test
+build: deps | out ; @echo inline
+deps:
+	@echo deps
+out:
+test: build $(OBJS)
+ifdef CI
+	@echo ci
+endif
+test: lint
+lint: PORT = 1
+%.o: %.c
+	cc $<
+$(BIN): deps
+define RECIPE
+fake: rule
+endef
+.env:
+-include $(dir $(lastword $(MAKEFILE_LIST)))more.mk
+`,
+		"more.mk": "more:: deps\n\t@echo more\n",
+	})
+
+	result, err := discoverTargetsStatic(filepath.Join(dir, "Makefile"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"build", "deps", "out", "test", ".env", "more"}, result.Targets)
+	assert.Equal(t, map[string]bool{"build": true, "test": true}, result.IsPhony)
+	assert.Equal(t, map[string][]string{
+		"build": {"deps", "out"},
+		"test":  {"build", "lint"},
+		"more":  {"deps"},
+	}, result.Dependencies)
+	assert.Equal(t, map[string]bool{"build": true, "deps": true, "test": true, "more": true}, result.HasRecipe)
+	assert.Equal(t, "build", result.DefaultGoal)
+	assert.Equal(t, map[string]string{
+		"VERSION": "1.0",
+		"PORT":    "8080",
+		"FLAGS":   "-a -b",
+		"URL":     "http://example.com",
+	}, result.VariableValues)
+}
+
+func TestDiscoverTargetsStatic_DefaultGoal(t *testing.T) {
+	t.Parallel()
+	dir := writeStaticProject(t, map[string]string{
+		"Makefile": ".hidden:\n%.o: %.c\nfirst: second\nsecond:\n",
+	})
+
+	result, err := discoverTargetsStatic(filepath.Join(dir, "Makefile"))
+	require.NoError(t, err)
+	assert.Equal(t, "first", result.DefaultGoal)
+}
+
+func TestService_NoExec(t *testing.T) {
+	t.Parallel()
+	dir := writeStaticProject(t, map[string]string{
+		"Makefile": "include $(dir $(lastword $(MAKEFILE_LIST)))extra.mk\nall:\n",
+		"extra.mk": "extra:\n",
+	})
+	makefilePath := filepath.Join(dir, "Makefile")
+
+	// The mock fails every command, so make must not be run
+	service := NewService(NewMockCommandExecutor(), false)
+	service.SetNoExec(true)
+
+	makefiles, err := service.DiscoverMakefiles(t.Context(), makefilePath)
+	require.NoError(t, err)
+	assert.Equal(t, []string{makefilePath, filepath.Join(dir, "extra.mk")}, makefiles)
+
+	result, err := service.DiscoverTargets(t.Context(), makefilePath)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"all", "extra"}, result.Targets)
+}
+
+func TestFindShellExpressions(t *testing.T) {
+	t.Parallel()
+	dir := writeStaticProject(t, map[string]string{
+		"Makefile": "VERSION := $(shell git describe \\\n\t--tags)\n" +
+			"NOW != date\n" +
+			"# $(shell in a comment)\n" +
+			"FILES = $(wildcard *.go) ${shell ls $(DIR)} $(shell echo (x))\n" +
+			"build:\n\t@echo $(shell date)\n" +
+			"define SCRIPT\n$(shell in a define)\nendef\n" +
+			"include $(dir $(lastword $(MAKEFILE_LIST)))extra.mk\n",
+		"extra.mk": "X := $(shell cat /etc/hostname\n",
+	})
+	makefilePath := filepath.Join(dir, "Makefile")
+
+	expressions, err := FindShellExpressions(makefilePath)
+	require.NoError(t, err)
+	assert.Equal(t, []ShellExpression{
+		{File: makefilePath, Line: 1, Expression: "$(shell git describe --tags)"},
+		{File: makefilePath, Line: 3, Expression: "NOW != date"},
+		{File: makefilePath, Line: 5, Expression: "${shell ls $(DIR)}"},
+		{File: makefilePath, Line: 5, Expression: "$(shell echo (x))"},
+		{File: filepath.Join(dir, "extra.mk"), Line: 1, Expression: "$(shell cat /etc/hostname"},
+	}, expressions)
+}
@@ -2,10 +2,14 @@ package discovery
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os/exec"
 	"regexp"
 	"strings"
 	"time"
+
+	"github.com/sdlcforge/make-help/internal/discovery/static"
 )
 
 // makeDiscoveryTimeout is the maximum time allowed for make commands during discovery.
@@ -25,24 +29,41 @@ type DiscoverTargetsResult struct {
 
 	// HasRecipe maps target names to whether they have a recipe (commands).
 	HasRecipe map[string]bool
+
+	// DefaultGoal is the value of .DEFAULT_GOAL (the target make runs
+	// when invoked without arguments). Empty if make reported none.
+	DefaultGoal string
+
+	// VariableValues maps variables assigned in the Makefiles to their
+	// unexpanded values (e.g., "PORT" -> "8080" for "PORT ?= 8080").
+	// Variables from the environment or the command line are not included.
+	VariableValues map[string]string
 }
 
 // discoverTargets extracts all targets from make -p output.
-// It executes make -p -r to get the database output and parses target names.
-func (s *Service) discoverTargets(makefilePath string) (*DiscoverTargetsResult, error) {
+// It executes make -p -r -q to get the database output and parses target names.
+func (s *Service) discoverTargets(ctx context.Context, makefilePath string) (*DiscoverTargetsResult, error) {
 	// Execute make with timeout to prevent indefinite hangs
-	ctx, cancel := context.WithTimeout(context.Background(), makeDiscoveryTimeout)
+	ctx, cancel := context.WithTimeout(ctx, makeDiscoveryTimeout)
 	defer cancel()
 
 	// Use -s and --no-print-directory to prevent make from adding
 	// extra output when running from within another make.
+	// -q keeps make from running the default goal's recipes while it prints
+	// the database.
 	// Pass MAKE_HELP_GENERATING=1 to prevent auto-regeneration of help.mk
 	// which would cause infinite recursion (make-help -> make -> make-help -> ...)
-	stdout, stderr, err := s.executor.ExecuteContext(ctx, "make", "-s", "--no-print-directory", "-f", makefilePath, "-p", "-r", "MAKE_HELP_GENERATING=1")
+	stdout, stderr, err := s.executor.ExecuteContext(ctx, "make", "-s", "--no-print-directory", "-f", makefilePath, "-p", "-r", "-q", "MAKE_HELP_GENERATING=1")
+	if isGoalCheckFailure(err, stderr) {
+		err = nil
+	}
 	if err != nil {
 		if ctx.Err() == context.DeadlineExceeded {
 			return nil, fmt.Errorf("make command timed out after 30s")
 		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
 		// Empty Makefiles cause "No targets" error - this is acceptable
 		if strings.Contains(stderr, "No targets") {
 			return &DiscoverTargetsResult{
@@ -62,6 +83,25 @@ func (s *Service) discoverTargets(makefilePath string) (*DiscoverTargetsResult,
 	return result, nil
 }
 
+// isGoalCheckFailure reports whether make -p -r -q failed only in checking
+// the default goal, after it printed the whole database: exit status 1 when
+// the goal is out of date, or 2 when -r turned off the built-in rule one of
+// its prerequisites needs ("No rule to make target 'prog.o', needed by
+// 'prog'"). A missing include fails without "needed by" and stays an error.
+func isGoalCheckFailure(err error, stderr string) bool {
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return false
+	}
+	switch exitErr.ExitCode() {
+	case 1:
+		return true
+	case 2:
+		return strings.Contains(stderr, "No rule to make target") && strings.Contains(stderr, ", needed by ")
+	}
+	return false
+}
+
 // parseTargetsFromDatabase extracts target names, .PHONY status, dependencies,
 // and recipe presence from make -p output.
 // It filters out comments, whitespace-prefixed lines, and built-in targets.
@@ -71,6 +111,15 @@ func parseTargetsFromDatabase(output string) *DiscoverTargetsResult {
 	isPhony := make(map[string]bool)
 	dependencies := make(map[string][]string)
 	hasRecipe := make(map[string]bool)
+	variableValues := make(map[string]string)
+	var defaultGoal string
+
+	// Match variable definitions: <name> = <value>, := or ::=
+	// Captures: 1=variable name, 2=unexpanded value
+	variableRegex := regexp.MustCompile(`^([^\s:#=]+) (?:::?)?= ?(.*)$`)
+
+	// Set when the previous line says the next variable comes from a Makefile
+	var inMakefileVariable bool
 
 	// Match target definitions: <target>: [deps...] or <target>:: [deps...]
 	// Captures: 1=target name, 2=everything after the colon(s)
@@ -81,6 +130,22 @@ func parseTargetsFromDatabase(output string) *DiscoverTargetsResult {
 
 	lines := strings.Split(output, "\n")
 	for i, line := range lines {
+		// make prints each variable's origin on the line before it
+		// ("# makefile (from 'Makefile', line 3)")
+		fromMakefile := inMakefileVariable
+		inMakefileVariable = strings.HasPrefix(line, "# makefile")
+		if fromMakefile {
+			if matches := variableRegex.FindStringSubmatch(line); matches != nil {
+				variableValues[matches[1]] = matches[2]
+			}
+		}
+
+		// Parse the default goal variable (".DEFAULT_GOAL := build")
+		if strings.HasPrefix(line, ".DEFAULT_GOAL :=") {
+			defaultGoal = strings.TrimSpace(strings.TrimPrefix(line, ".DEFAULT_GOAL :="))
+			continue
+		}
+
 		// Parse .PHONY declarations
 		if strings.HasPrefix(line, ".PHONY:") {
 			// Extract all targets from .PHONY line
@@ -113,8 +178,9 @@ func parseTargetsFromDatabase(output string) *DiscoverTargetsResult {
 			targetName := matches[1]
 			depsStr := strings.TrimSpace(matches[2])
 
-			// Skip special/built-in targets
-			if isSpecialTarget(targetName) {
+			// Skip special/built-in targets, and variables ("VAR := value"),
+			// whose ":=" the regex reads as a rule colon
+			if isSpecialTarget(targetName) || strings.HasPrefix(depsStr, "=") {
 				currentTarget = ""
 				continue
 			}
@@ -153,49 +219,16 @@ func parseTargetsFromDatabase(output string) *DiscoverTargetsResult {
 	}
 
 	return &DiscoverTargetsResult{
-		Targets:      targets,
-		IsPhony:      isPhony,
-		Dependencies: dependencies,
-		HasRecipe:    hasRecipe,
+		Targets:        targets,
+		IsPhony:        isPhony,
+		Dependencies:   dependencies,
+		HasRecipe:      hasRecipe,
+		DefaultGoal:    defaultGoal,
+		VariableValues: variableValues,
 	}
 }
 
 // isSpecialTarget returns true if the target is a special or built-in Make target.
 func isSpecialTarget(name string) bool {
-	// Skip Make's special targets
-	specialTargets := map[string]bool{
-		".SUFFIXES":      true,
-		".DEFAULT":       true,
-		".PRECIOUS":      true,
-		".INTERMEDIATE":  true,
-		".SECONDARY":     true,
-		".SECONDEXPANSION": true,
-		".DELETE_ON_ERROR": true,
-		".IGNORE":        true,
-		".LOW_RESOLUTION_TIME": true,
-		".SILENT":        true,
-		".EXPORT_ALL_VARIABLES": true,
-		".NOTPARALLEL":   true,
-		".ONESHELL":      true,
-		".POSIX":         true,
-		"Makefile":       true,
-		"makefile":       true,
-	}
-
-	// Check if it's a known special target
-	if specialTargets[name] {
-		return true
-	}
-
-	// Skip pattern rules (contain %)
-	if strings.Contains(name, "%") {
-		return true
-	}
-
-	// Skip variable assignments that look like targets (contain =)
-	if strings.Contains(name, "=") {
-		return true
-	}
-
-	return false
+	return static.IsSpecialTarget(name)
 }
@@ -178,3 +178,60 @@ clean:
 	assert.True(t, result.IsPhony["clean"])
 	assert.False(t, result.IsPhony["build"])
 }
+
+func TestParseTargetsFromDatabase_DefaultGoal(t *testing.T) {
+	t.Parallel()
+	input := `# Make database
+# default
+.DEFAULT_GOAL := all
+all: build
+build:
+	go build
+`
+	result := parseTargetsFromDatabase(input)
+
+	assert.Equal(t, "all", result.DefaultGoal)
+	assert.Equal(t, []string{"all", "build"}, result.Targets)
+}
+
+func TestParseTargetsFromDatabase_SkipsVariables(t *testing.T) {
+	t.Parallel()
+	input := `# Variables
+DIAGRAM_DIR := docs/diagrams
+VERSION ::= 1.0
+# Files
+all: build
+build:
+	go build
+`
+	result := parseTargetsFromDatabase(input)
+
+	assert.Equal(t, []string{"all", "build"}, result.Targets)
+	assert.Equal(t, map[string][]string{"all": {"build"}}, result.Dependencies)
+}
+
+func TestParseTargetsFromDatabase_VariableValues(t *testing.T) {
+	t.Parallel()
+	input := `# Variables
+# environment
+HOME = /home/user
+# makefile (from 'Makefile', line 1)
+PORT = 8080
+# makefile (from 'Makefile', line 2)
+MODE := dev
+# makefile (from 'Makefile', line 3)
+EMPTY =
+# command line
+ENV = prod
+# default
+MAKE_VERSION := 4.3
+# Files
+all: build
+build:
+	go build
+`
+	result := parseTargetsFromDatabase(input)
+
+	assert.Equal(t, map[string]string{"PORT": "8080", "MODE": "dev", "EMPTY": ""}, result.VariableValues)
+	assert.Equal(t, []string{"all", "build"}, result.Targets)
+}
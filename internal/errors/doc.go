@@ -17,8 +17,9 @@
 //   - MakeExecutionError: Returned when a make command fails; includes
 //     the stderr output for debugging
 //
-//   - DuplicateHelpTargetError: Returned when add-target finds an existing
-//     help target
+//   - DuplicateHelpTargetError: Returned when help file generation finds a
+//     hand-written help target and neither replacing it nor renaming the
+//     generated one was chosen
 //
 //   - ValidationError: Returned when Makefile validation fails (e.g.,
 //     syntax errors detected by make -n)
@@ -87,8 +87,8 @@ func NewMakeExecutionError(command, stderr string) *MakeExecutionError {
 	}
 }
 
-// DuplicateHelpTargetError is returned when --create-help-target is run
-// but a help target already exists.
+// DuplicateHelpTargetError is returned when help file generation finds a
+// help target that make-help did not generate.
 type DuplicateHelpTargetError struct {
 	// Location describes where the existing help target was found.
 	Location string
@@ -96,7 +96,7 @@ type DuplicateHelpTargetError struct {
 
 // Error implements the error interface.
 func (e *DuplicateHelpTargetError) Error() string {
-	return fmt.Sprintf("help target already exists in %s\nUse 'make-help --remove-help-target' first to remove it", e.Location)
+	return fmt.Sprintf("help target already exists in %s\nUse --replace-existing-help to comment it out, or --help-target-name to generate help under another name", e.Location)
 }
 
 // NewDuplicateHelpTargetError creates a new DuplicateHelpTargetError.
@@ -58,7 +58,8 @@ func TestDuplicateHelpTargetError(t *testing.T) {
 	err := NewDuplicateHelpTargetError("Makefile:15")
 	assert.Contains(t, err.Error(), "help target already exists")
 	assert.Contains(t, err.Error(), "Makefile:15")
-	assert.Contains(t, err.Error(), "--remove-help-target")
+	assert.Contains(t, err.Error(), "--replace-existing-help")
+	assert.Contains(t, err.Error(), "--help-target-name")
 }
 
 func TestValidationError(t *testing.T) {
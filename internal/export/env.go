@@ -0,0 +1,81 @@
+package export
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+
+	"github.com/sdlcforge/make-help/internal/model"
+)
+
+// envVariable is a documented variable collected for the env template.
+type envVariable struct {
+	model.Variable
+
+	// Targets lists the targets documenting the variable, in help order.
+	Targets []string
+}
+
+// renderEnv writes a .env template with an assignment for every variable
+// the targets document, in the order help first lists them. Each is
+// preceded by comments giving its description, whether it is required,
+// its allowed values, and the targets using it, and is assigned the value
+// the Makefiles give it, or nothing.
+func renderEnv(helpModel *model.HelpModel, defaults map[string]string, buf *bytes.Buffer) {
+	buf.WriteString("# Generated by make-help. Copy to .env and fill in the values.\n")
+
+	var variables []*envVariable
+	byName := make(map[string]*envVariable)
+	for _, target := range targets(helpModel) {
+		for _, v := range target.Variables {
+			variable, exists := byName[v.Name]
+			if !exists {
+				variable = &envVariable{Variable: model.Variable{Name: v.Name}}
+				byName[v.Name] = variable
+				variables = append(variables, variable)
+			}
+			if variable.Description == "" {
+				variable.Description = v.Description
+			}
+			if variable.Choices == nil {
+				variable.Choices = v.Choices
+			}
+			variable.Required = variable.Required || v.Required
+			if !slices.Contains(variable.Targets, target.Name) {
+				variable.Targets = append(variable.Targets, target.Name)
+			}
+		}
+	}
+
+	for _, variable := range variables {
+		buf.WriteString("\n")
+		if variable.Description != "" {
+			buf.WriteString("# " + oneLine(variable.Description) + "\n")
+		}
+		if variable.Required {
+			buf.WriteString("# Required.\n")
+		}
+		if len(variable.Choices) > 0 {
+			buf.WriteString("# One of: " + strings.Join(variable.Choices, ", ") + "\n")
+		}
+		buf.WriteString("# Used by: " + strings.Join(variable.Targets, ", ") + "\n")
+
+		// A default referring to other make variables means nothing to a
+		// .env file, so it is shown but not assigned
+		value := defaults[variable.Name]
+		if strings.Contains(value, "$") {
+			buf.WriteString("# Makefile default: " + oneLine(value) + "\n")
+			value = ""
+		}
+		buf.WriteString(variable.Name + "=" + envValue(value) + "\n")
+	}
+}
+
+// envValue double-quotes value for a .env file when it holds spaces,
+// quotes, or other characters dotenv parsers treat specially.
+func envValue(value string) string {
+	if !strings.ContainsAny(value, " \t#\"'\\") {
+		return value
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
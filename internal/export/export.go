@@ -0,0 +1,227 @@
+// Package export translates the help model into the task manifests of
+// other tools, so teams migrating away from make, or wiring targets into
+// another runner, start from the documented targets instead of a blank file.
+// Every exported task runs the make target it came from.
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/sdlcforge/make-help/internal/model"
+)
+
+// Supported export schemas.
+const (
+	// SchemaTaskfile is a Taskfile.yml (go-task) skeleton.
+	SchemaTaskfile = "taskfile"
+
+	// SchemaPackageScripts is a package.json fragment with npm scripts.
+	SchemaPackageScripts = "package-scripts"
+
+	// SchemaVSCode is a VS Code .vscode/tasks.json.
+	SchemaVSCode = "vscode"
+
+	// SchemaFZF is a shell script picking a target to run with fzf.
+	SchemaFZF = "fzf"
+
+	// SchemaEnv is a commented .env template of the documented variables.
+	SchemaEnv = "env"
+)
+
+// Schemas returns the supported export schemas.
+func Schemas() []string {
+	return []string{SchemaTaskfile, SchemaPackageScripts, SchemaVSCode, SchemaFZF, SchemaEnv}
+}
+
+// Render writes the documented targets of helpModel in the given schema.
+// defaults maps variables to the values the Makefiles assign them; only the
+// env schema uses it.
+func Render(helpModel *model.HelpModel, schema string, defaults map[string]string, w io.Writer) error {
+	var buf bytes.Buffer
+	switch schema {
+	case SchemaTaskfile:
+		renderTaskfile(helpModel, &buf)
+	case SchemaPackageScripts:
+		if err := renderPackageScripts(helpModel, &buf); err != nil {
+			return err
+		}
+	case SchemaVSCode:
+		if err := renderVSCode(helpModel, &buf); err != nil {
+			return err
+		}
+	case SchemaFZF:
+		renderFZF(helpModel, &buf)
+	case SchemaEnv:
+		renderEnv(helpModel, defaults, &buf)
+	default:
+		return fmt.Errorf("unknown export schema: %s (supported: %s)", schema, strings.Join(Schemas(), ", "))
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// FilterTags returns a copy of helpModel keeping only the targets with at
+// least one of tags, dropping categories left empty. Empty tags keeps every
+// target.
+func FilterTags(helpModel *model.HelpModel, tags []string) *model.HelpModel {
+	if len(tags) == 0 {
+		return helpModel
+	}
+	filtered := *helpModel
+	filtered.Categories = nil
+	for _, category := range helpModel.Categories {
+		var kept []model.Target
+		for _, target := range category.Targets {
+			if slices.ContainsFunc(target.Tags, func(tag string) bool { return slices.Contains(tags, tag) }) {
+				kept = append(kept, target)
+			}
+		}
+		if len(kept) > 0 {
+			category.Targets = kept
+			filtered.Categories = append(filtered.Categories, category)
+		}
+	}
+	return &filtered
+}
+
+// FilterTarget returns a copy of helpModel keeping only the target named
+// name, or nil if helpModel has no such target.
+func FilterTarget(helpModel *model.HelpModel, name string) *model.HelpModel {
+	for _, category := range helpModel.Categories {
+		for _, target := range category.Targets {
+			if target.Name == name {
+				filtered := *helpModel
+				category.Targets = []model.Target{target}
+				filtered.Categories = []model.Category{category}
+				return &filtered
+			}
+		}
+	}
+	return nil
+}
+
+// targets returns the documented targets in help order.
+func targets(helpModel *model.HelpModel) []model.Target {
+	var result []model.Target
+	for _, category := range helpModel.Categories {
+		result = append(result, category.Targets...)
+	}
+	return result
+}
+
+// summary returns a target's one-line summary.
+func summary(target model.Target) string {
+	return strings.Join(target.Summary, " ")
+}
+
+// renderTaskfile writes a Taskfile.yml whose tasks run the make targets,
+// with the summary as desc and the full documentation as summary.
+func renderTaskfile(helpModel *model.HelpModel, buf *bytes.Buffer) {
+	buf.WriteString("# Generated by make-help. Each task runs the make target of the same name.\n")
+	buf.WriteString("version: '3'\n")
+	buf.WriteString("\ntasks:\n")
+	for i, target := range targets(helpModel) {
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+		fmt.Fprintf(buf, "  %s:\n", yamlString(target.Name))
+		if s := summary(target); s != "" {
+			fmt.Fprintf(buf, "    desc: %s\n", yamlString(s))
+		}
+		if docs := strings.TrimSpace(strings.Join(target.Documentation, "\n")); docs != "" && docs != summary(target) {
+			fmt.Fprintf(buf, "    summary: %s\n", yamlString(docs))
+		}
+		if len(target.Aliases) > 0 {
+			aliases := make([]string, len(target.Aliases))
+			for j, alias := range target.Aliases {
+				aliases[j] = yamlString(alias)
+			}
+			fmt.Fprintf(buf, "    aliases: [%s]\n", strings.Join(aliases, ", "))
+		}
+		if target.Dangerous {
+			prompt := "Run " + target.Name + "? It is marked destructive."
+			if target.DangerReason != "" {
+				prompt = "Run " + target.Name + "? " + target.DangerReason
+			}
+			fmt.Fprintf(buf, "    prompt: %s\n", yamlString(prompt))
+		}
+		buf.WriteString("    cmds:\n")
+		fmt.Fprintf(buf, "      - %s\n", yamlString("make "+target.Name))
+	}
+}
+
+// yamlString quotes s as a YAML double-quoted scalar. JSON strings are
+// valid YAML double-quoted scalars.
+func yamlString(s string) string {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(s) // strings always encode
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// packageScripts is a package.json fragment. npm has no comments, so the
+// summaries go in "scripts-info", the convention of npm-scripts-info.
+type packageScripts struct {
+	Scripts     orderedMap `json:"scripts"`
+	ScriptsInfo orderedMap `json:"scripts-info,omitempty"`
+}
+
+// renderPackageScripts writes a package.json fragment whose scripts run
+// the make targets, with their summaries in "scripts-info".
+func renderPackageScripts(helpModel *model.HelpModel, buf *bytes.Buffer) error {
+	var manifest packageScripts
+	for _, target := range targets(helpModel) {
+		manifest.Scripts = append(manifest.Scripts, entry{Key: target.Name, Value: "make " + target.Name})
+		if s := summary(target); s != "" {
+			manifest.ScriptsInfo = append(manifest.ScriptsInfo, entry{Key: target.Name, Value: s})
+		}
+	}
+
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(manifest); err != nil {
+		return fmt.Errorf("failed to encode package scripts: %w", err)
+	}
+	return nil
+}
+
+// entry is a key and value of an orderedMap.
+type entry struct {
+	Key   string
+	Value string
+}
+
+// orderedMap is a JSON object whose keys keep their order, so scripts are
+// listed in help order rather than alphabetically.
+type orderedMap []entry
+
+// MarshalJSON encodes the entries as an object in order.
+func (m orderedMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("{")
+	for i, e := range m {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		key, err := json.Marshal(e.Key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(e.Value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteString(":")
+		buf.Write(value)
+	}
+	buf.WriteString("}")
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,284 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/sdlcforge/make-help/internal/model"
+	"github.com/sdlcforge/make-help/internal/parser"
+)
+
+// testModel has a plain target, a target with aliases and documentation
+// beyond its summary, and a destructive target, in help order.
+func testModel() *model.HelpModel {
+	return &model.HelpModel{
+		Categories: []model.Category{
+			{
+				Name: "Go Build",
+				Targets: []model.Target{
+					{Name: "test", Summary: []string{"Run the tests."}, Documentation: []string{"Run the tests."}, Tags: []string{"ci"}},
+					{
+						Name:          "build",
+						Aliases:       []string{"b"},
+						Summary:       []string{"Build the app."},
+						Documentation: []string{"Build the app.", `Writes "bin/app" & checksums.`},
+					},
+				},
+			},
+			{
+				Name: "Deploy",
+				Targets: []model.Target{
+					{Name: "db-reset", Summary: []string{"Reset the database."}, Dangerous: true, DangerReason: "Drops all data."},
+				},
+			},
+		},
+	}
+}
+
+func TestRender_Taskfile(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	if err := Render(testModel(), SchemaTaskfile, nil, &buf); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	expected := `# Generated by make-help. Each task runs the make target of the same name.
+version: '3'
+
+tasks:
+  "test":
+    desc: "Run the tests."
+    cmds:
+      - "make test"
+
+  "build":
+    desc: "Build the app."
+    summary: "Build the app.\nWrites \"bin/app\" & checksums."
+    aliases: ["b"]
+    cmds:
+      - "make build"
+
+  "db-reset":
+    desc: "Reset the database."
+    prompt: "Run db-reset? Drops all data."
+    cmds:
+      - "make db-reset"
+`
+	if buf.String() != expected {
+		t.Errorf("Taskfile mismatch.\ngot:\n%s\nwant:\n%s", buf.String(), expected)
+	}
+}
+
+func TestRender_PackageScripts(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	if err := Render(testModel(), SchemaPackageScripts, nil, &buf); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	// Scripts keep help order rather than being sorted
+	if got := buf.String(); strings.Index(got, `"test"`) > strings.Index(got, `"build"`) {
+		t.Errorf("Scripts should be in help order, got:\n%s", got)
+	}
+
+	var manifest struct {
+		Scripts     map[string]string `json:"scripts"`
+		ScriptsInfo map[string]string `json:"scripts-info"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &manifest); err != nil {
+		t.Fatalf("Output is not valid JSON: %v\n%s", err, buf.String())
+	}
+	if manifest.Scripts["build"] != "make build" || len(manifest.Scripts) != 3 {
+		t.Errorf("Unexpected scripts: %v", manifest.Scripts)
+	}
+	if manifest.ScriptsInfo["db-reset"] != "Reset the database." {
+		t.Errorf("Unexpected scripts-info: %v", manifest.ScriptsInfo)
+	}
+}
+
+func TestRender_UnknownSchema(t *testing.T) {
+	t.Parallel()
+	err := Render(testModel(), "gradle", nil, &bytes.Buffer{})
+	if err == nil || !strings.Contains(err.Error(), "unknown export schema: gradle") {
+		t.Errorf("Expected unknown schema error, got %v", err)
+	}
+}
+
+func TestRender_VSCode(t *testing.T) {
+	t.Parallel()
+	helpModel := testModel()
+	helpModel.Categories = append(helpModel.Categories, model.Category{
+		Name: "Testing",
+		Targets: []model.Target{
+			{Name: "test-race", Summary: []string{"Run the tests."}},
+			{Name: "bench"},
+		},
+	})
+
+	var buf bytes.Buffer
+	if err := Render(helpModel, SchemaVSCode, nil, &buf); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	var file vscodeTasks
+	if err := json.Unmarshal(buf.Bytes(), &file); err != nil {
+		t.Fatalf("Output is not valid JSON: %v\n%s", err, buf.String())
+	}
+	if file.Version != "2.0.0" || len(file.Tasks) != 5 {
+		t.Fatalf("Unexpected tasks file: %+v", file)
+	}
+
+	build := file.Tasks[1]
+	if build.Label != "Build the app." || build.Command != "make" || build.Args[0] != "build" {
+		t.Errorf("Unexpected build task: %+v", build)
+	}
+	if build.Group != "build" || len(build.ProblemMatcher) != 1 || build.ProblemMatcher[0] != "$go" {
+		t.Errorf("Go Build category should hint the build group and $go matcher: %+v", build)
+	}
+
+	// Shared summaries are prefixed with the target name; missing ones fall back to it
+	if got := file.Tasks[0].Label; got != "test: Run the tests." {
+		t.Errorf("Expected a disambiguated label, got %q", got)
+	}
+	if got := file.Tasks[4].Label; got != "bench" {
+		t.Errorf("Expected the target name as label, got %q", got)
+	}
+	if file.Tasks[4].Group != "test" || len(file.Tasks[4].ProblemMatcher) != 0 {
+		t.Errorf("Unexpected bench task: %+v", file.Tasks[4])
+	}
+	if !strings.Contains(buf.String(), `"problemMatcher": []`) {
+		t.Errorf("Tasks without a hint should have an empty problemMatcher list, got:\n%s", buf.String())
+	}
+}
+
+func TestFilterTags(t *testing.T) {
+	t.Parallel()
+	helpModel := testModel()
+
+	if got := FilterTags(helpModel, nil); got != helpModel {
+		t.Error("FilterTags without tags should return the model unchanged")
+	}
+
+	filtered := FilterTags(helpModel, []string{"release", "ci"})
+	if len(filtered.Categories) != 1 || len(filtered.Categories[0].Targets) != 1 || filtered.Categories[0].Targets[0].Name != "test" {
+		t.Errorf("Unexpected filtered model: %+v", filtered.Categories)
+	}
+	if len(helpModel.Categories) != 2 || len(helpModel.Categories[0].Targets) != 2 {
+		t.Error("FilterTags should not modify the original model")
+	}
+}
+
+func TestRender_FZF(t *testing.T) {
+	t.Parallel()
+	helpModel := testModel()
+	helpModel.Categories[0].Targets[0].Summary = []string{"Run the tester's\tsuite."}
+
+	var buf bytes.Buffer
+	if err := Render(helpModel, SchemaFZF, nil, &buf); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	got := buf.String()
+
+	for _, expected := range []string{
+		"#!/bin/sh\n",
+		"  'test\tRun the tester'\\''s suite.' \\\n",
+		"  'build\tBuild the app.' \\\n",
+		"--preview='make-help --output - --color --target {1}'",
+		"exec make \"$target\" \"$@\"\n",
+	} {
+		if !strings.Contains(got, expected) {
+			t.Errorf("Expected %q in script, got:\n%s", expected, got)
+		}
+	}
+}
+
+func TestRender_Env(t *testing.T) {
+	t.Parallel()
+	helpModel := testModel()
+	helpModel.Categories[0].Targets[0].Variables = []model.Variable{
+		{Name: "GOFLAGS", Description: "Flags for go test."},
+	}
+	helpModel.Categories[1].Targets[0].Variables = []model.Variable{
+		{Name: "ENV", Description: "Target environment.", Required: true, Choices: []string{"dev", "prod"}},
+		{Name: "GOFLAGS"},
+		{Name: "DSN", Description: "Database URL."},
+	}
+	defaults := map[string]string{"ENV": "dev", "DSN": "$(HOST):5432", "GOFLAGS": "-v -race"}
+
+	var buf bytes.Buffer
+	if err := Render(helpModel, SchemaEnv, defaults, &buf); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	expected := `# Generated by make-help. Copy to .env and fill in the values.
+
+# Flags for go test.
+# Used by: test, db-reset
+GOFLAGS="-v -race"
+
+# Target environment.
+# Required.
+# One of: dev, prod
+# Used by: db-reset
+ENV=dev
+
+# Database URL.
+# Used by: db-reset
+# Makefile default: $(HOST):5432
+DSN=
+`
+	if buf.String() != expected {
+		t.Errorf("Render() =\n%s\nwant:\n%s", buf.String(), expected)
+	}
+}
+
+func TestRender_EnvSpaceSeparatedVar(t *testing.T) {
+	t.Parallel()
+	content := `## Build the app.
+## !var LDFLAGS Linker flags for build
+build:
+
+## Release the app.
+## !var LDFLAGS - Linker flags for build
+release:
+`
+	parsed, err := parser.NewScanner().ScanContent(content, "Makefile")
+	if err != nil {
+		t.Fatalf("ScanContent() error = %v", err)
+	}
+	helpModel, err := model.NewBuilder(&model.BuilderConfig{}).Build([]*parser.ParsedFile{parsed})
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := Render(helpModel, SchemaEnv, nil, &buf); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	expected := `# Generated by make-help. Copy to .env and fill in the values.
+
+# Linker flags for build
+# Used by: build, release
+LDFLAGS=
+`
+	if buf.String() != expected {
+		t.Errorf("Render() =\n%s\nwant:\n%s", buf.String(), expected)
+	}
+}
+
+func TestFilterTarget(t *testing.T) {
+	t.Parallel()
+	helpModel := testModel()
+
+	filtered := FilterTarget(helpModel, "db-reset")
+	if filtered == nil || len(filtered.Categories) != 1 || filtered.Categories[0].Name != "Deploy" ||
+		len(filtered.Categories[0].Targets) != 1 || filtered.Categories[0].Targets[0].Name != "db-reset" {
+		t.Errorf("Unexpected filtered model: %+v", filtered)
+	}
+	if FilterTarget(helpModel, "missing") != nil {
+		t.Error("FilterTarget should return nil for an unknown target")
+	}
+}
@@ -0,0 +1,46 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/sdlcforge/make-help/internal/model"
+)
+
+// renderFZF writes a POSIX shell script that lists the targets, as
+// "name<TAB>summary" lines, in fzf with a preview of make-help --target
+// for the highlighted one, and runs the selected target with make. The
+// list is embedded, so picking a target does not run make-help first.
+func renderFZF(helpModel *model.HelpModel, buf *bytes.Buffer) {
+	buf.WriteString("#!/bin/sh\n")
+	buf.WriteString("# Generated by make-help. Pick a make target with fzf and run it.\n")
+	buf.WriteString("# Run from the Makefile directory; arguments are passed to make.\n")
+	buf.WriteString("set -e\n\n")
+	buf.WriteString("tab=$(printf '\\t')\n")
+	buf.WriteString("target=$(printf '%s\\n' \\\n")
+	for _, target := range targets(helpModel) {
+		line := target.Name + "\t" + oneLine(summary(target))
+		buf.WriteString("  " + shellQuote(line) + " \\\n")
+	}
+	buf.WriteString("  | fzf --delimiter=\"$tab\" --nth=1 --prompt='make> ' \\\n")
+	buf.WriteString("      --preview='make-help --output - --color --target {1}' --preview-window=right:60%:wrap \\\n")
+	buf.WriteString("  | cut -f1)\n\n")
+	buf.WriteString("[ -n \"$target\" ] || exit 0\n")
+	buf.WriteString("exec make \"$target\" \"$@\"\n")
+}
+
+// oneLine replaces tabs and line breaks in s with spaces, so s stays in
+// its field of a tab-separated line.
+func oneLine(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '\t' || r == '\n' || r == '\r' {
+			return ' '
+		}
+		return r
+	}, s)
+}
+
+// shellQuote quotes s for a POSIX shell, in single quotes.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
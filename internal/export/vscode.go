@@ -0,0 +1,125 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/sdlcforge/make-help/internal/model"
+)
+
+// vscodeTasks is a VS Code tasks.json file.
+type vscodeTasks struct {
+	Version string       `json:"version"`
+	Tasks   []vscodeTask `json:"tasks"`
+}
+
+// vscodeTask is a shell task in tasks.json.
+type vscodeTask struct {
+	Label          string   `json:"label"`
+	Type           string   `json:"type"`
+	Command        string   `json:"command"`
+	Args           []string `json:"args"`
+	Detail         string   `json:"detail"`
+	Group          string   `json:"group,omitempty"`
+	ProblemMatcher []string `json:"problemMatcher"`
+}
+
+// problemMatcherHints maps words in category names to the VS Code problem
+// matchers that usually fit their output. Checked in order.
+var problemMatcherHints = []struct {
+	word    string
+	matcher string
+}{
+	{"go", "$go"},
+	{"golang", "$go"},
+	{"typescript", "$tsc"},
+	{"tsc", "$tsc"},
+	{"eslint", "$eslint-stylish"},
+	{"c", "$gcc"},
+	{"c++", "$gcc"},
+	{"cpp", "$gcc"},
+}
+
+// renderVSCode writes a tasks.json with a shell task per target. Labels are
+// the summaries (the target name when there is none, or when two targets
+// share a summary), and categories named after a language get its problem
+// matcher. An empty matcher list stops VS Code from asking for one.
+func renderVSCode(helpModel *model.HelpModel, buf *bytes.Buffer) error {
+	all := targets(helpModel)
+	summaryCount := make(map[string]int)
+	for _, target := range all {
+		summaryCount[summary(target)]++
+	}
+
+	file := vscodeTasks{Version: "2.0.0", Tasks: []vscodeTask{}}
+	for _, category := range helpModel.Categories {
+		matchers := categoryMatchers(category.Name)
+		group := categoryGroup(category.Name)
+		for _, target := range category.Targets {
+			label := summary(target)
+			switch {
+			case label == "":
+				label = target.Name
+			case summaryCount[label] > 1:
+				label = target.Name + ": " + label
+			}
+			file.Tasks = append(file.Tasks, vscodeTask{
+				Label:          label,
+				Type:           "shell",
+				Command:        "make",
+				Args:           []string{target.Name},
+				Detail:         "make " + target.Name,
+				Group:          group,
+				ProblemMatcher: matchers,
+			})
+		}
+	}
+
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(file); err != nil {
+		return fmt.Errorf("failed to encode VS Code tasks: %w", err)
+	}
+	return nil
+}
+
+// categoryMatchers returns the problem matchers hinted by the words of a
+// category name, or an empty list.
+func categoryMatchers(name string) []string {
+	words := categoryWords(name)
+	matchers := []string{}
+	for _, hint := range problemMatcherHints {
+		if words[hint.word] && !slices.Contains(matchers, hint.matcher) {
+			matchers = append(matchers, hint.matcher)
+		}
+	}
+	return matchers
+}
+
+// categoryGroup returns the VS Code task group ("build" or "test") for
+// categories whose name mentions one, or "".
+func categoryGroup(name string) string {
+	words := categoryWords(name)
+	switch {
+	case words["test"] || words["tests"] || words["testing"]:
+		return "test"
+	case words["build"] || words["builds"] || words["building"]:
+		return "build"
+	}
+	return ""
+}
+
+// categoryWords returns the lowercased words of a category name.
+func categoryWords(name string) map[string]bool {
+	words := make(map[string]bool)
+	for _, word := range strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return r == ' ' || r == '/' || r == ',' || r == '-' || r == '(' || r == ')' || r == '&'
+	}) {
+		words[word] = true
+	}
+	return words
+}
@@ -0,0 +1,9 @@
+// Package filelock provides advisory, exclusive locks on files, so that
+// concurrent make-help processes (parallel CI jobs, a watcher and a manual
+// run) take turns modifying a project instead of overwriting each other's
+// changes.
+//
+// Locks use flock on Unix and LockFileEx on Windows. The operating system
+// releases them when the process exits, so a crashed run never leaves a
+// stale lock behind. On other platforms locking is a no-op.
+package filelock
@@ -0,0 +1,59 @@
+package filelock
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// ErrTimeout is returned by Acquire when another process still holds the
+// lock after the timeout.
+var ErrTimeout = errors.New("timed out waiting for lock")
+
+// errLocked is returned by tryLock when another process holds the lock.
+var errLocked = errors.New("locked")
+
+// pollInterval is how often Acquire retries a held lock.
+const pollInterval = 50 * time.Millisecond
+
+// Lock is an exclusive lock held on a file.
+type Lock struct {
+	file *os.File
+}
+
+// Acquire locks the file at path, creating it if needed. If another process
+// holds the lock, Acquire retries until timeout has passed and then returns
+// ErrTimeout. A timeout of zero tries once.
+func Acquire(path string, timeout time.Duration) (*Lock, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock file: %w", err)
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		err := tryLock(file)
+		if err == nil {
+			return &Lock{file: file}, nil
+		}
+		if !errors.Is(err, errLocked) {
+			_ = file.Close()
+			return nil, fmt.Errorf("failed to lock %s: %w", path, err)
+		}
+		if !time.Now().Before(deadline) {
+			_ = file.Close()
+			return nil, ErrTimeout
+		}
+		time.Sleep(min(pollInterval, time.Until(deadline)))
+	}
+}
+
+// Release unlocks the file. The lock file itself is left in place, since
+// removing it would let another process lock a different file at the same
+// path.
+func (l *Lock) Release() error {
+	unlockErr := unlock(l.file)
+	closeErr := l.file.Close()
+	return errors.Join(unlockErr, closeErr)
+}
@@ -0,0 +1,15 @@
+//go:build !unix && !windows
+
+package filelock
+
+import "os"
+
+// tryLock always succeeds: this platform has no file locking.
+func tryLock(file *os.File) error {
+	return nil
+}
+
+// unlock does nothing.
+func unlock(file *os.File) error {
+	return nil
+}
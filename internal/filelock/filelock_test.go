@@ -0,0 +1,49 @@
+package filelock
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcquire_Exclusive(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "lock")
+
+	lock, err := Acquire(path, 0)
+	require.NoError(t, err)
+
+	_, err = Acquire(path, 100*time.Millisecond)
+	assert.ErrorIs(t, err, ErrTimeout)
+
+	require.NoError(t, lock.Release())
+	lock, err = Acquire(path, 0)
+	require.NoError(t, err)
+	require.NoError(t, lock.Release())
+}
+
+func TestAcquire_WaitsForRelease(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "lock")
+
+	lock, err := Acquire(path, 0)
+	require.NoError(t, err)
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		_ = lock.Release()
+	}()
+
+	waited, err := Acquire(path, 5*time.Second)
+	require.NoError(t, err)
+	require.NoError(t, waited.Release())
+}
+
+func TestAcquire_MissingDirectory(t *testing.T) {
+	t.Parallel()
+	_, err := Acquire(filepath.Join(t.TempDir(), "missing", "lock"), 0)
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrTimeout)
+}
@@ -5,6 +5,8 @@ const (
 	reset         = "\033[0m"
 	boldCyan      = "\033[1;36m"
 	boldGreen     = "\033[1;32m"
+	boldRed       = "\033[1;31m"
+	boldYellow    = "\033[1;33m"
 	yellow        = "\033[0;33m"
 	magenta       = "\033[0;35m"
 	white         = "\033[0;37m"
@@ -28,6 +30,12 @@ type ColorScheme struct {
 	// Documentation colors documentation text
 	Documentation string
 
+	// Danger colors the badge of targets marked with !danger
+	Danger string
+
+	// New colors the badge of recently added targets
+	New string
+
 	// Reset resets color to default
 	Reset string
 }
@@ -46,6 +54,8 @@ func NewColorScheme(useColor bool) *ColorScheme {
 		Alias:         yellow,
 		Variable:      magenta,
 		Documentation: white,
+		Danger:        boldRed,
+		New:           boldYellow,
 		Reset:         reset,
 	}
 }
@@ -6,6 +6,7 @@
 //   - HTML format: Browser-ready HTML with embedded styles
 //   - Markdown format: GitHub-flavored markdown documentation
 //   - JSON format: Structured JSON for programmatic consumption
+//   - NDJSON format: One JSON object per target, for streaming consumers
 //
 // # Architecture
 //
@@ -15,6 +16,7 @@
 //   - HTMLFormatter: Creates styled HTML pages
 //   - MarkdownFormatter: Outputs structured markdown documentation
 //   - JSONFormatter: Produces structured JSON output
+//   - NDJSONFormatter: Streams one compact JSON target per line
 //
 // All formatters implement the Formatter interface:
 //
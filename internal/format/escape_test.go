@@ -1,6 +1,10 @@
 package format
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/sdlcforge/make-help/internal/model"
+)
 
 // TestEscapeForMakefileEcho tests the escapeForMakefileEcho function with all special characters
 func TestEscapeForMakefileEcho(t *testing.T) {
@@ -141,3 +145,53 @@ func TestEscapeForMakefileEcho_SecurityCases(t *testing.T) {
 		})
 	}
 }
+
+// TestBuildRunCommand tests that the run command is safe to paste into a shell
+func TestBuildRunCommand(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		target   model.Target
+		expected string
+	}{
+		{
+			name:     "plain target",
+			target:   model.Target{Name: "build"},
+			expected: "make build",
+		},
+		{
+			name:     "path target",
+			target:   model.Target{Name: "out/app.tar.gz"},
+			expected: "make out/app.tar.gz",
+		},
+		{
+			name:     "path with shell characters",
+			target:   model.Target{Name: "out/my app$(x)"},
+			expected: "make 'out/my app$(x)'",
+		},
+		{
+			name:     "single quote",
+			target:   model.Target{Name: "it's"},
+			expected: `make 'it'\''s'`,
+		},
+		{
+			name:     "variable placeholders",
+			target:   model.Target{Name: "deploy", Variables: []model.Variable{{Name: "ENV"}, {Name: "REGION"}}},
+			expected: "make deploy ENV='<value>' REGION='<value>'",
+		},
+		{
+			name:     "invalid variable name",
+			target:   model.Target{Name: "build", Variables: []model.Variable{{Name: "LDFLAGS Linker flags"}, {Name: "GOOS"}}},
+			expected: "make build GOOS='<value>'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := buildRunCommand(&tt.target); got != tt.expected {
+				t.Errorf("buildRunCommand() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
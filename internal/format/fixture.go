@@ -0,0 +1,107 @@
+package format
+
+import "github.com/sdlcforge/make-help/internal/model"
+
+// FixtureMakefileDir is the directory the fixture model's source files are
+// placed in. Pass it as FormatterConfig.MakefileDir so Source: lines render
+// as relative paths.
+const FixtureMakefileDir = "/fixture"
+
+// FixtureModel returns a synthetic help model that exercises every feature
+// the formatters render: file documentation, categories with introductions,
+// aliases, rich text, required variables with choices, tags, deprecation,
+// danger markers, owners, CI workflows, platforms, and duration estimates.
+//
+// The model is built in code, without make or the filesystem, and does not
+// change between calls, so its rendered output can be compared across
+// versions (see --render-fixture and the golden tests).
+func FixtureModel() *model.HelpModel {
+	makefile := FixtureMakefileDir + "/Makefile"
+	dockerMk := FixtureMakefileDir + "/make/docker.mk"
+
+	return &model.HelpModel{
+		FileDocs: []model.FileDoc{
+			{
+				SourceFile:     makefile,
+				Documentation:  []string{"Example project build system.", "", "Run `make help` to list targets."},
+				DiscoveryOrder: 0,
+				IsEntryPoint:   true,
+			},
+			{
+				SourceFile:     dockerMk,
+				Documentation:  []string{"Container image helpers."},
+				DiscoveryOrder: 1,
+				Owner:          "platform-team",
+			},
+		},
+		HasCategories: true,
+		DefaultGoal:   "build",
+		Categories: []model.Category{
+			{
+				Name:           "Build",
+				DiscoveryOrder: 0,
+				Targets: []model.Target{
+					{
+						Name:           "build",
+						Aliases:        []string{"b"},
+						Documentation:  []string{"Build the **entire** project.", "Compiles sources and runs code generation."},
+						Summary:        []string{"Build the **entire** project."},
+						DiscoveryOrder: 0,
+						SourceFile:     makefile,
+						LineNumber:     12,
+						IsPhony:        true,
+						Tags:           []string{"ci"},
+						Duration:       "~2m",
+					},
+					{
+						Name:               "bundle",
+						Documentation:      []string{"Build the legacy bundle."},
+						Summary:            []string{"Build the legacy bundle."},
+						DiscoveryOrder:     1,
+						SourceFile:         makefile,
+						LineNumber:         20,
+						IsPhony:            true,
+						Deprecated:         true,
+						DeprecationMessage: "Use `make build` instead.",
+					},
+				},
+			},
+			{
+				Name:           "Deploy",
+				Documentation:  []string{"Targets that change shared environments."},
+				DiscoveryOrder: 1,
+				Targets: []model.Target{
+					{
+						Name:          "deploy",
+						Documentation: []string{"Deploy the application. See [the runbook](https://example.com/runbook)."},
+						Summary:       []string{"Deploy the application."},
+						Variables: []model.Variable{
+							{Name: "ENV", Description: "Target environment", Required: true, Choices: []string{"dev", "staging", "prod"}},
+							{Name: "DRY_RUN", Description: "Print actions without applying them"},
+						},
+						DiscoveryOrder: 2,
+						SourceFile:     makefile,
+						LineNumber:     31,
+						IsPhony:        true,
+						Profiles:       []string{"ops"},
+						Dangerous:      true,
+						DangerReason:   "Changes shared environments.",
+						Owner:          "release-team (#releases on Slack)",
+						CIWorkflows:    []string{".github/workflows/deploy.yml"},
+					},
+					{
+						Name:           "image",
+						Documentation:  []string{"Build the container _image_."},
+						Summary:        []string{"Build the container _image_."},
+						DiscoveryOrder: 3,
+						SourceFile:     dockerMk,
+						LineNumber:     4,
+						IsPhony:        true,
+						Platforms:      []string{"linux", "darwin"},
+						Owner:          "platform-team",
+					},
+				},
+			},
+		},
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"path/filepath"
+	"time"
 
 	"github.com/sdlcforge/make-help/internal/model"
 )
@@ -59,6 +60,100 @@ type FormatterConfig struct {
 	// Used to convert absolute paths to relative paths in Source: lines.
 	// If empty, absolute paths are used.
 	MakefileDir string
+
+	// NoScript disables inline JavaScript in HTML output.
+	// Run commands are still rendered, but without copy-to-clipboard buttons.
+	NoScript bool
+
+	// HTMLPolicy controls raw HTML in documentation, link attributes, and the
+	// CSP nonce of inline elements in HTML output.
+	HTMLPolicy HTMLPolicy
+
+	// Provenance adds a generation footer (version, commit, time) to the
+	// Markdown and HTML help pages. Nil omits it.
+	Provenance *Provenance
+
+	// Stats adds a target inventory to text, make, Markdown, and JSON
+	// output. Nil omits it.
+	Stats *Stats
+
+	// Page describes the page of targets being rendered, after Paginate.
+	// JSON output adds page metadata and HTML output a page indicator.
+	// Nil means the output is not paginated.
+	Page *Page
+
+	// Navigation links the page being rendered to the other pages of help
+	// split by category, after SplitByCategory. Markdown and HTML output
+	// render it; nil means the help is not split.
+	Navigation *Navigation
+
+	// SlackBlocks wraps Slack output in a Block Kit {"blocks": [...]}
+	// payload instead of plain mrkdwn.
+	SlackBlocks bool
+
+	// TOC adds a table of contents to Markdown output, linking to each
+	// category and target.
+	TOC bool
+
+	// MarkdownLayout selects how Markdown output lists targets:
+	// "table" renders one table per category; anything else renders bullet lists.
+	MarkdownLayout string
+
+	// TextLayout selects how text output lists targets: "compact" shows only
+	// names and aliases, several per line; "long" shows each target's full
+	// documentation instead of its summary; anything else shows summaries.
+	TextLayout string
+
+	// LineWidth is the terminal width compact text layout fits its columns
+	// to. Zero uses 80 columns.
+	LineWidth int
+
+	// LastRuns maps target names to their last recorded run duration.
+	// Only the text formatter shows them; nil shows nothing.
+	LastRuns map[string]time.Duration
+
+	// LastChanges maps target names to the last change of their rule line,
+	// from --git-blame. Detailed views and HTML show them; nil shows nothing.
+	LastChanges map[string]Change
+
+	// NewTargets holds the names of targets added within the --highlight-new
+	// window. Text, Markdown, and HTML target lists mark them with a "new"
+	// badge; nil marks nothing.
+	NewTargets map[string]bool
+
+	// MaxTargetsPerCategory limits how many targets terminal formats (text,
+	// make) list per category; the rest are summarized in a "(+N more)" line.
+	// Zero lists every target.
+	MaxTargetsPerCategory int
+
+	// SummaryWidth truncates summaries in terminal formats (text, make) to at
+	// most this many characters, cutting at a word boundary and ending with
+	// an ellipsis. Zero leaves summaries untruncated.
+	SummaryWidth int
+
+	// FullHelpCommand is suggested in the "(+N more)" line, e.g. "make help-full".
+	// Empty omits the suggestion.
+	FullHelpCommand string
+
+	// ConsolidateVariables lists variables documented by more than one
+	// target once, in a Variables section naming the targets that use them,
+	// instead of under each target (text, make, Markdown, and HTML help).
+	// Detailed target views still list every variable.
+	ConsolidateVariables bool
+
+	// NoIncludedFiles omits the documentation of included files.
+	NoIncludedFiles bool
+
+	// IncludedFilesPosition places the documentation of included files
+	// "bottom", after the targets and variables; anything else places it
+	// at the top, after the description.
+	IncludedFilesPosition string
+
+	// FileDocsDepth limits how many documentation lines are shown per
+	// included file. HTML output folds the rest into a <details> element;
+	// other formats summarize them in a "(+N more lines)" line. Zero shows
+	// every line.
+	FileDocsDepth int
 }
 
 // Validate checks that the FormatterConfig is valid.
@@ -100,7 +195,7 @@ func makeRelativePath(absolutePath, makefileDir string) string {
 
 // NewFormatter creates a formatter for the specified format type.
 // This is the factory function that replaces direct renderer construction.
-// Supported format types: "make", "mk", "text", "txt", "html", "markdown", "md", "json"
+// Supported format types: "make", "mk", "text", "txt", "html", "markdown", "md", "json", "ndjson", "slack"
 func NewFormatter(formatType string, config *FormatterConfig) (Formatter, error) {
 	// Validate config if provided
 	if config != nil {
@@ -120,7 +215,11 @@ func NewFormatter(formatType string, config *FormatterConfig) (Formatter, error)
 		return NewMarkdownFormatter(config), nil
 	case "json":
 		return NewJSONFormatter(config), nil
+	case "ndjson":
+		return NewNDJSONFormatter(config), nil
+	case "slack":
+		return NewSlackFormatter(config), nil
 	default:
-		return nil, fmt.Errorf("unknown format type: %s (supported: make, text, html, markdown, json)", formatType)
+		return nil, fmt.Errorf("unknown format type: %s (supported: make, text, html, markdown, json, ndjson, slack)", formatType)
 	}
 }
@@ -1,8 +1,11 @@
 package format
 
 import (
+	"bytes"
 	"strings"
 	"testing"
+
+	"github.com/sdlcforge/make-help/internal/model"
 )
 
 // TestNewFormatter tests the formatter factory function
@@ -63,6 +66,12 @@ func TestNewFormatter(t *testing.T) {
 			wantType:   "*format.JSONFormatter",
 			wantErr:    false,
 		},
+		{
+			name:       "ndjson format",
+			formatType: "ndjson",
+			wantType:   "*format.NDJSONFormatter",
+			wantErr:    false,
+		},
 		{
 			name:        "unknown format",
 			formatType:  "invalid",
@@ -159,6 +168,308 @@ func TestFormatterInterface(t *testing.T) {
 }
 
 // TestFormatterContentTypes verifies content types for each formatter
+// TestFormatters_UsageAndExamples tests that every formatter with a header
+// renders the usage line and examples of the model
+func TestFormatters_UsageAndExamples(t *testing.T) {
+	t.Parallel()
+	helpModel := &model.HelpModel{
+		Usage:    "make -j8 <target>",
+		Examples: []string{"make build", "make test RUN=TestParse"},
+	}
+
+	tests := []struct {
+		format string
+		want   []string
+	}{
+		{"text", []string{"Usage: make -j8 <target>\n\nExamples:\n  make build\n  make test RUN=TestParse\n"}},
+		{"make", []string{"Usage: make -j8 <target>", "Examples:", "  make test RUN=TestParse"}},
+		{"markdown", []string{"```\nmake -j8 <target>\n```\n\n### Examples\n\n```\nmake build\nmake test RUN=TestParse\n```\n"}},
+		{"html", []string{"<pre>make -j8 &lt;target&gt;</pre>", "<pre class=\"examples\">make build\nmake test RUN=TestParse</pre>"}},
+		{"json", []string{`"usage": "make -j8 \u003ctarget\u003e"`, `"examples": [`, `"make test RUN=TestParse"`}},
+		{"slack", []string{"Usage: `make -j8 &lt;target&gt;`", "• `make build`"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			t.Parallel()
+			formatter, err := NewFormatter(tt.format, &FormatterConfig{})
+			if err != nil {
+				t.Fatalf("NewFormatter() error = %v", err)
+			}
+			var buf bytes.Buffer
+			if err := formatter.RenderHelp(helpModel, &buf); err != nil {
+				t.Fatalf("RenderHelp() error = %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("output should contain %q, got:\n%s", want, buf.String())
+				}
+			}
+		})
+	}
+}
+
+// TestFormatters_ProjectMetadata tests that document formats use the title,
+// homepage, and repository of the model
+func TestFormatters_ProjectMetadata(t *testing.T) {
+	t.Parallel()
+	helpModel := &model.HelpModel{
+		Title:    "Acme <Build> System",
+		Homepage: "https://acme.example.com",
+		Repo:     "javascript:alert(1)",
+	}
+
+	tests := []struct {
+		format  string
+		want    []string
+		notWant []string
+	}{
+		{
+			format:  "markdown",
+			want:    []string{"# Acme <Build> System\n\n[Homepage](https://acme.example.com)\n\n## Usage"},
+			notWant: []string{"javascript:"},
+		},
+		{
+			format: "html",
+			want: []string{
+				"<title>Acme &lt;Build&gt; System</title>",
+				`<meta property="og:title" content="Acme &lt;Build&gt; System">`,
+				`<meta property="og:url" content="https://acme.example.com">`,
+				"<h1>Acme &lt;Build&gt; System</h1>",
+				`<p class="project-links"><a href="https://acme.example.com">Homepage</a></p>`,
+			},
+			notWant: []string{"Makefile Help", "javascript:"},
+		},
+		{
+			format: "json",
+			want:   []string{`"title": "Acme \u003cBuild\u003e System"`, `"homepage": "https://acme.example.com"`, `"repo": "javascript:alert(1)"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			t.Parallel()
+			formatter, err := NewFormatter(tt.format, &FormatterConfig{})
+			if err != nil {
+				t.Fatalf("NewFormatter() error = %v", err)
+			}
+			var buf bytes.Buffer
+			if err := formatter.RenderHelp(helpModel, &buf); err != nil {
+				t.Fatalf("RenderHelp() error = %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("output should contain %q, got:\n%s", want, buf.String())
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(buf.String(), notWant) {
+					t.Errorf("output should not contain %q, got:\n%s", notWant, buf.String())
+				}
+			}
+		})
+	}
+}
+
+// TestFormatters_FooterDocs tests that every formatter with a document
+// renders the footer after the target listing
+func TestFormatters_FooterDocs(t *testing.T) {
+	t.Parallel()
+	helpModel := &model.HelpModel{
+		Categories: []model.Category{
+			{Targets: []model.Target{{Name: "build", Summary: []string{"Build the project."}}}},
+		},
+		FooterDocs: []string{"Run `make help-<target>` for details.", "", "See [the docs](https://example.com/docs)."},
+	}
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"text", "\nRun `make help-<target>` for details.\n\nSee [the docs](https://example.com/docs).\n"},
+		{"make", "See [the docs](https://example.com/docs)."},
+		{"markdown", "Run `make help-<target>` for details.\n\nSee [the docs](https://example.com/docs).\n"},
+		{"html", "<p>Run <code>make help-&lt;target&gt;</code> for details.</p>\n    <br>\n    <p>See <a href=\"https://example.com/docs\">the docs</a>.</p>"},
+		{"json", `"footer": "Run ` + "`make help-\\u003ctarget\\u003e`" + ` for details.\n\nSee [the docs](https://example.com/docs)."`},
+		{"slack", "Run `make help-&lt;target&gt;` for details.\n\nSee <https://example.com/docs|the docs>."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			t.Parallel()
+			formatter, err := NewFormatter(tt.format, &FormatterConfig{})
+			if err != nil {
+				t.Fatalf("NewFormatter() error = %v", err)
+			}
+			var buf bytes.Buffer
+			if err := formatter.RenderHelp(helpModel, &buf); err != nil {
+				t.Fatalf("RenderHelp() error = %v", err)
+			}
+			output := buf.String()
+			if !strings.Contains(output, tt.want) {
+				t.Errorf("output should contain %q, got:\n%s", tt.want, output)
+			}
+			if strings.Index(output, "build") > strings.Index(output, "for details") {
+				t.Error("footer should come after the target listing")
+			}
+		})
+	}
+}
+
+func TestFormatters_IncludedFilesControls(t *testing.T) {
+	t.Parallel()
+	helpModel := &model.HelpModel{
+		FileDocs: []model.FileDoc{
+			{SourceFile: "build.mk", Documentation: []string{"Build rules.", "Uses the Go toolchain.", "Set GOFLAGS to customize."}},
+		},
+		Categories: []model.Category{
+			{Targets: []model.Target{{Name: "build", Summary: []string{"Build the project."}}}},
+		},
+	}
+
+	tests := []struct {
+		format    string
+		wantDepth string
+	}{
+		{"text", "    Build rules.\n    (+2 more lines)\n"},
+		{"make", "    (+2 more lines)"},
+		{"markdown", "Build rules.\n\n_(+2 more lines)_\n"},
+		{"html", "<details class=\"more-docs\">\n        <summary>(+2 more lines)</summary>\n        <p>Uses the Go toolchain.</p>"},
+	}
+
+	render := func(t *testing.T, formatName string, config *FormatterConfig) string {
+		t.Helper()
+		formatter, err := NewFormatter(formatName, config)
+		if err != nil {
+			t.Fatalf("NewFormatter() error = %v", err)
+		}
+		var buf bytes.Buffer
+		if err := formatter.RenderHelp(helpModel, &buf); err != nil {
+			t.Fatalf("RenderHelp() error = %v", err)
+		}
+		return buf.String()
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			t.Parallel()
+			output := render(t, tt.format, &FormatterConfig{FileDocsDepth: 1})
+			if !strings.Contains(output, tt.wantDepth) {
+				t.Errorf("output should contain %q, got:\n%s", tt.wantDepth, output)
+			}
+
+			output = render(t, tt.format, &FormatterConfig{NoIncludedFiles: true})
+			if strings.Contains(output, "Build rules.") {
+				t.Errorf("output should omit included files, got:\n%s", output)
+			}
+
+			output = render(t, tt.format, &FormatterConfig{})
+			if strings.Index(output, "Build rules.") > strings.Index(output, "Build the project.") {
+				t.Error("included files should come before the targets by default")
+			}
+			output = render(t, tt.format, &FormatterConfig{IncludedFilesPosition: "bottom"})
+			if strings.Index(output, "Build rules.") < strings.Index(output, "Build the project.") {
+				t.Error("included files should come after the targets at the bottom position")
+			}
+		})
+	}
+
+	t.Run("json", func(t *testing.T) {
+		t.Parallel()
+		output := render(t, "json", &FormatterConfig{NoIncludedFiles: true})
+		if strings.Contains(output, "includedFiles") {
+			t.Errorf("output should omit included files, got:\n%s", output)
+		}
+	})
+}
+
+func TestFormatters_Stats(t *testing.T) {
+	t.Parallel()
+	helpModel := &model.HelpModel{
+		Categories: []model.Category{
+			{Name: "Build", Targets: []model.Target{
+				{Name: "build", Summary: []string{"Build the project."}},
+				{Name: "debug", Summary: []string{"Debug the build."}, Hidden: true},
+			}},
+			{Name: "Test", Targets: []model.Target{{Name: "test", Summary: []string{"Run the tests."}}}},
+		},
+	}
+	stats := NewStats(helpModel, 3)
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"text", "\n2 targets in 2 categories, 3 undocumented hidden\n"},
+		{"make", "2 targets in 2 categories, 3 undocumented hidden"},
+		{"markdown", "_2 targets in 2 categories, 3 undocumented hidden_\n"},
+		{"json", "\"stats\": {\n    \"targets\": 2,\n    \"categories\": 2,\n    \"undocumented\": 3\n  }"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			t.Parallel()
+			formatter, err := NewFormatter(tt.format, &FormatterConfig{Stats: stats})
+			if err != nil {
+				t.Fatalf("NewFormatter() error = %v", err)
+			}
+			var buf bytes.Buffer
+			if err := formatter.RenderHelp(helpModel, &buf); err != nil {
+				t.Fatalf("RenderHelp() error = %v", err)
+			}
+			if !strings.Contains(buf.String(), tt.want) {
+				t.Errorf("output should contain %q, got:\n%s", tt.want, buf.String())
+			}
+		})
+	}
+
+	single := NewStats(&model.HelpModel{
+		Categories: []model.Category{{Targets: []model.Target{{Name: "build"}}}},
+	}, 0)
+	if got := single.summaryText(); got != "1 target" {
+		t.Errorf("summaryText() = %q, want %q", got, "1 target")
+	}
+}
+
+func TestFormatters_NewTargets(t *testing.T) {
+	t.Parallel()
+	helpModel := &model.HelpModel{
+		Categories: []model.Category{
+			{Targets: []model.Target{
+				{Name: "build", Summary: []string{"Build the project."}},
+				{Name: "deploy", Summary: []string{"Deploy the project."}},
+			}},
+		},
+	}
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"text", "the project. new\n"},
+		{"markdown", "the project. **new**\n"},
+		{"html", "<span class=\"new\">new</span>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			t.Parallel()
+			formatter, err := NewFormatter(tt.format, &FormatterConfig{NewTargets: map[string]bool{"deploy": true}})
+			if err != nil {
+				t.Fatalf("NewFormatter() error = %v", err)
+			}
+			var buf bytes.Buffer
+			if err := formatter.RenderHelp(helpModel, &buf); err != nil {
+				t.Fatalf("RenderHelp() error = %v", err)
+			}
+			// Both summaries end alike, so only deploy may be marked
+			if got := strings.Count(buf.String(), tt.want); got != 1 {
+				t.Errorf("output should contain %q once, got %d in:\n%s", tt.want, got, buf.String())
+			}
+		})
+	}
+}
+
 func TestFormatterContentTypes(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -0,0 +1,66 @@
+package format
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the golden files in testdata/fixture")
+
+// TestGolden_FixtureModel renders the fixture model in every format, with and
+// without color, and compares the output with the files in testdata/fixture.
+// Run "go test ./internal/format -run TestGolden -update" after an intended
+// output change.
+func TestGolden_FixtureModel(t *testing.T) {
+	formats := []string{"make", "text", "html", "markdown", "json", "ndjson"}
+
+	for _, formatType := range formats {
+		for _, useColor := range []bool{false, true} {
+			name := formatType
+			if useColor {
+				name += "-color"
+			}
+
+			t.Run(name, func(t *testing.T) {
+				formatter, err := NewFormatter(formatType, &FormatterConfig{
+					UseColor:    useColor,
+					MakefileDir: FixtureMakefileDir,
+				})
+				if err != nil {
+					t.Fatalf("NewFormatter() error = %v", err)
+				}
+
+				var buf bytes.Buffer
+				if err := formatter.RenderHelp(FixtureModel(), &buf); err != nil {
+					t.Fatalf("RenderHelp() error = %v", err)
+				}
+				target := &FixtureModel().Categories[1].Targets[0]
+				if err := formatter.RenderDetailedTarget(target, &buf); err != nil {
+					t.Fatalf("RenderDetailedTarget() error = %v", err)
+				}
+
+				goldenPath := filepath.Join("testdata", "fixture", name+".golden")
+				if *updateGolden {
+					if err := os.MkdirAll(filepath.Dir(goldenPath), 0755); err != nil {
+						t.Fatalf("failed to create golden dir: %v", err)
+					}
+					if err := os.WriteFile(goldenPath, buf.Bytes(), 0644); err != nil {
+						t.Fatalf("failed to write golden file: %v", err)
+					}
+					return
+				}
+
+				want, err := os.ReadFile(goldenPath)
+				if err != nil {
+					t.Fatalf("failed to read golden file (run with -update to create it): %v", err)
+				}
+				if !bytes.Equal(buf.Bytes(), want) {
+					t.Errorf("output differs from %s (run with -update if the change is intended)\ngot:\n%s", goldenPath, buf.String())
+				}
+			})
+		}
+	}
+}
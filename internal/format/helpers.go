@@ -2,10 +2,239 @@ package format
 
 import (
 	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/sdlcforge/make-help/internal/model"
 )
 
+// buildRunCommand returns the make invocation for a target, including a
+// NAME=<value> placeholder for each documented variable. The target and
+// the values are shell-quoted, so the command can be pasted into a shell;
+// variables whose names are not valid on a command line are left out.
+// Example: "make deploy ENV='<value>' REGION='<value>'"
+func buildRunCommand(target *model.Target) string {
+	var sb strings.Builder
+	sb.WriteString("make ")
+	sb.WriteString(shellQuote(target.Name))
+	for _, v := range target.Variables {
+		if !variableNameRegex.MatchString(v.Name) {
+			continue
+		}
+		sb.WriteString(" ")
+		sb.WriteString(v.Name)
+		sb.WriteString("=")
+		sb.WriteString(shellQuote("<value>"))
+	}
+	return sb.String()
+}
+
+// variableNameRegex matches the variable names buildRunCommand assigns.
+var variableNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// shellSafeRegex matches words a POSIX shell passes through unchanged.
+var shellSafeRegex = regexp.MustCompile(`^[A-Za-z0-9_@%+=:,./-]+$`)
+
+// shellQuote returns s as a single POSIX shell word: unchanged when it has
+// no special characters, otherwise in single quotes.
+func shellQuote(s string) string {
+	if shellSafeRegex.MatchString(s) {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// formatChoices renders a variable's allowed values as "[a|b|c]".
+// Returns "" when the variable declares no choices.
+func formatChoices(v model.Variable) string {
+	if len(v.Choices) == 0 {
+		return ""
+	}
+	return "[" + strings.Join(v.Choices, "|") + "]"
+}
+
+// sharedVariable is a variable documented by more than one target, listed
+// once in the Variables section when FormatterConfig.ConsolidateVariables
+// is set.
+type sharedVariable struct {
+	model.Variable
+
+	// UsedBy lists the targets documenting the variable, in help order.
+	UsedBy []string
+}
+
+// collectSharedVariables returns the variables documented by more than one
+// target, sorted by name. Each takes the first non-empty description and
+// choices any target gives, and is required if any target requires it.
+// Returns nil unless config.ConsolidateVariables is set.
+func collectSharedVariables(helpModel *model.HelpModel, config *FormatterConfig) []sharedVariable {
+	if !config.ConsolidateVariables {
+		return nil
+	}
+
+	byName := make(map[string]*sharedVariable)
+	var names []string
+	for _, category := range helpModel.Categories {
+		for _, target := range category.Targets {
+			for _, v := range target.Variables {
+				shared, exists := byName[v.Name]
+				if !exists {
+					shared = &sharedVariable{Variable: model.Variable{Name: v.Name}}
+					byName[v.Name] = shared
+					names = append(names, v.Name)
+				}
+				if shared.Description == "" {
+					shared.Description = v.Description
+				}
+				if shared.Choices == nil {
+					shared.Choices = v.Choices
+				}
+				shared.Required = shared.Required || v.Required
+				if !slices.Contains(shared.UsedBy, target.Name) {
+					shared.UsedBy = append(shared.UsedBy, target.Name)
+				}
+			}
+		}
+	}
+
+	slices.Sort(names)
+	var variables []sharedVariable
+	for _, name := range names {
+		if len(byName[name].UsedBy) > 1 {
+			variables = append(variables, *byName[name])
+		}
+	}
+	return variables
+}
+
+// sharedVariableNames returns the set of names in variables.
+func sharedVariableNames(variables []sharedVariable) map[string]bool {
+	names := make(map[string]bool, len(variables))
+	for _, v := range variables {
+		names[v.Name] = true
+	}
+	return names
+}
+
+// ownVariables returns the variables of target listed with it in help
+// output, leaving out those in the shared Variables section.
+func ownVariables(target *model.Target, shared map[string]bool) []model.Variable {
+	if len(shared) == 0 {
+		return target.Variables
+	}
+	var variables []model.Variable
+	for _, v := range target.Variables {
+		if !shared[v.Name] {
+			variables = append(variables, v)
+		}
+	}
+	return variables
+}
+
+// formatDuration renders a target's !duration estimate as "(~5m)".
+// Returns "" when the target has no estimate.
+func formatDuration(target *model.Target) string {
+	if target.Duration == "" {
+		return ""
+	}
+	return "(" + target.Duration + ")"
+}
+
+// dangerBadge marks targets with a !danger directive in target lists.
+const dangerBadge = "⚠ destructive"
+
+// newBadge marks recently added targets (see FormatterConfig.NewTargets) in
+// target lists.
+const newBadge = "new"
+
+// formatDangerReason returns the text shown after "Danger:" in detailed
+// help: the !danger reason, or "destructive" when none was given.
+func formatDangerReason(target *model.Target) string {
+	if target.DangerReason == "" {
+		return "destructive"
+	}
+	return target.DangerReason
+}
+
+// formatPlatforms renders a target's supported platforms as a badge,
+// e.g. "[linux, darwin]". Returns "" when the target declares no platforms.
+func formatPlatforms(target *model.Target) string {
+	if len(target.Platforms) == 0 {
+		return ""
+	}
+	return "[" + strings.Join(target.Platforms, ", ") + "]"
+}
+
+// limitTargets returns the targets to list for a category under the
+// configured per-category limit, and how many were left out.
+func limitTargets(targets []model.Target, config *FormatterConfig) ([]model.Target, int) {
+	limit := config.MaxTargetsPerCategory
+	if limit <= 0 || len(targets) <= limit {
+		return targets, 0
+	}
+	return targets[:limit], len(targets) - limit
+}
+
+// formatMoreTargets renders the line that stands in for targets left out by
+// the per-category limit, e.g. "(+12 more, run make help-full)".
+func formatMoreTargets(omitted int, config *FormatterConfig) string {
+	if config.FullHelpCommand == "" {
+		return fmt.Sprintf("(+%d more)", omitted)
+	}
+	return fmt.Sprintf("(+%d more, run %s)", omitted, config.FullHelpCommand)
+}
+
+// truncateSummary shortens summary to at most width characters, cutting at a
+// word boundary and ending with an ellipsis. Zero width leaves it unchanged.
+func truncateSummary(summary string, width int) string {
+	runes := []rune(summary)
+	if width <= 0 || len(runes) <= width {
+		return summary
+	}
+
+	// Leave room for the ellipsis
+	cut := string(runes[:width-1])
+	if i := strings.LastIndex(cut, " "); i > 0 {
+		cut = cut[:i]
+	}
+	return strings.TrimRight(cut, " ,;:.-") + "…"
+}
+
+// ansiEscape matches the SGR escape sequences used by ColorScheme.
+var ansiEscape = regexp.MustCompile("\033\\[[0-9;]*m")
+
+// visibleWidth returns the number of columns s occupies on a terminal,
+// ignoring ANSI color codes.
+func visibleWidth(s string) int {
+	return utf8.RuneCountInString(ansiEscape.ReplaceAllString(s, ""))
+}
+
+// DefaultUsage is the usage line shown when no !usage directive or project
+// config replaces it.
+const DefaultUsage = "make [<target>...] [<ENV_VAR>=<value>...]"
+
+// usageLine returns the usage line of the help model.
+func usageLine(helpModel *model.HelpModel) string {
+	if helpModel.Usage != "" {
+		return helpModel.Usage
+	}
+	return DefaultUsage
+}
+
+// DefaultTitle is the title of generated documents when no !title directive
+// names the project.
+const DefaultTitle = "Makefile Help"
+
+// helpTitle returns the document title of the help model.
+func helpTitle(helpModel *model.HelpModel) string {
+	if helpModel.Title != "" {
+		return helpModel.Title
+	}
+	return DefaultTitle
+}
+
 // extractEntryPointDocs returns the documentation from the entry point file.
 // Returns nil if no entry point documentation exists.
 func extractEntryPointDocs(fileDocs []model.FileDoc) []string {
@@ -17,6 +246,16 @@ func extractEntryPointDocs(fileDocs []model.FileDoc) []string {
 	return nil
 }
 
+// entryPointOwner returns the owner of the entry point file, or "".
+func entryPointOwner(fileDocs []model.FileDoc) string {
+	for _, fileDoc := range fileDocs {
+		if fileDoc.IsEntryPoint {
+			return fileDoc.Owner
+		}
+	}
+	return ""
+}
+
 // extractIncludedFiles returns all non-entry-point files with documentation.
 func extractIncludedFiles(fileDocs []model.FileDoc) []model.FileDoc {
 	var includedFiles []model.FileDoc
@@ -28,6 +267,40 @@ func extractIncludedFiles(fileDocs []model.FileDoc) []model.FileDoc {
 	return includedFiles
 }
 
+// includedFilesFor returns the included files to document under config, or
+// nil when --no-included-files hides them.
+func includedFilesFor(helpModel *model.HelpModel, config *FormatterConfig) []model.FileDoc {
+	if config.NoIncludedFiles {
+		return nil
+	}
+	return extractIncludedFiles(helpModel.FileDocs)
+}
+
+// includedFilesAtBottom reports whether included files are documented after
+// the targets rather than before them.
+func includedFilesAtBottom(config *FormatterConfig) bool {
+	return config.IncludedFilesPosition == "bottom"
+}
+
+// limitFileDocs returns the documentation lines of an included file to show
+// under the configured depth, and how many were left out.
+func limitFileDocs(lines []string, config *FormatterConfig) ([]string, int) {
+	depth := config.FileDocsDepth
+	if depth <= 0 || len(lines) <= depth {
+		return lines, 0
+	}
+	return lines[:depth], len(lines) - depth
+}
+
+// formatMoreLines renders the line that stands in for file documentation
+// left out by the depth limit, e.g. "(+3 more lines)".
+func formatMoreLines(omitted int) string {
+	if omitted == 1 {
+		return "(+1 more line)"
+	}
+	return fmt.Sprintf("(+%d more lines)", omitted)
+}
+
 // initColorScheme creates a ColorScheme from config, using provided scheme or creating default.
 func initColorScheme(config *FormatterConfig) *ColorScheme {
 	colors := config.ColorScheme
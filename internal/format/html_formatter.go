@@ -4,16 +4,56 @@ import (
 	"fmt"
 	"html"
 	"io"
+	"regexp"
 	"strings"
 
 	"github.com/sdlcforge/make-help/internal/model"
 	"github.com/sdlcforge/make-help/internal/richtext"
 )
 
+// Raw HTML modes for HTMLPolicy.RawHTML.
+const (
+	// RawHTMLEscape shows HTML in documentation as literal text (the default).
+	RawHTMLEscape = "escape"
+	// RawHTMLStrip removes HTML tags from documentation, keeping their text.
+	RawHTMLStrip = "strip"
+	// RawHTMLAllow passes HTML in documentation through unchanged. Only use
+	// it when every included Makefile is trusted.
+	RawHTMLAllow = "allow"
+)
+
+// HTMLPolicy controls how HTML output treats documentation content, so
+// generated pages can meet the content security rules of the site that
+// hosts them.
+type HTMLPolicy struct {
+	// RawHTML selects how HTML written in documentation is handled:
+	// RawHTMLEscape (or empty), RawHTMLStrip, or RawHTMLAllow.
+	// Names, paths, and code spans are always escaped.
+	RawHTML string
+
+	// LinkRel is set as the rel attribute of documentation links
+	// (e.g., "noopener noreferrer"). Empty omits the attribute.
+	LinkRel string
+
+	// LinkTargetBlank opens documentation links in a new tab.
+	LinkTargetBlank bool
+
+	// Nonce is set as the nonce attribute of the inline <style> and <script>
+	// elements, for pages served with a nonce-based Content-Security-Policy.
+	Nonce string
+}
+
+// htmlTag matches an HTML start or end tag, for RawHTMLStrip.
+var htmlTag = regexp.MustCompile(`</?[a-zA-Z][^>]*>`)
+
 // HTMLFormatter generates HTML output for web display or documentation sites.
 type HTMLFormatter struct {
 	config *FormatterConfig
 	parser *richtext.Parser
+
+	// shared names the variables RenderHelp lists in its Variables section
+	// rather than under each target.
+	shared map[string]bool
 }
 
 // NewHTMLFormatter creates a new HTMLFormatter with the given configuration.
@@ -39,81 +79,152 @@ func (f *HTMLFormatter) RenderHelp(helpModel *model.HelpModel, w io.Writer) erro
 	buf.WriteString("<html>\n")
 	buf.WriteString("<head>\n")
 	buf.WriteString("  <meta charset=\"UTF-8\">\n")
-	buf.WriteString("  <title>Makefile Help</title>\n")
+	title := html.EscapeString(helpTitle(helpModel))
+	buf.WriteString("  <title>" + title + "</title>\n")
+	if helpModel.Title != "" {
+		buf.WriteString("  <meta property=\"og:title\" content=\"" + title + "\">\n")
+	}
+	if isValidURL(helpModel.Homepage) {
+		buf.WriteString("  <meta property=\"og:url\" content=\"" + html.EscapeString(helpModel.Homepage) + "\">\n")
+	}
 
 	// Embed CSS (only if color is enabled)
 	if f.config.UseColor {
-		buf.WriteString("  <style>\n")
+		buf.WriteString(f.openInlineTag("style"))
 		buf.WriteString(f.getCSS())
 		buf.WriteString("  </style>\n")
 	}
 
 	buf.WriteString("</head>\n")
 	buf.WriteString("<body>\n")
-	buf.WriteString("  <h1>Makefile Help</h1>\n")
-
-	// Usage section
-	buf.WriteString("  <section class=\"usage\">\n")
-	buf.WriteString("    <h2>Usage</h2>\n")
-	buf.WriteString("    <pre>make [&lt;target&gt;...] [&lt;ENV_VAR&gt;=&lt;value&gt;...]</pre>\n")
-	buf.WriteString("  </section>\n")
+	buf.WriteString("  <h1>" + title + "</h1>\n")
+	f.renderProjectLinks(&buf, helpModel)
+	nav := f.config.Navigation
+	if nav.categoryPage() {
+		buf.WriteString("  <nav class=\"breadcrumbs\">")
+		buf.WriteString(htmlLinks(nav.Breadcrumbs, " › "))
+		buf.WriteString("</nav>\n")
+	}
 
-	// File documentation section
-	if len(helpModel.FileDocs) > 0 {
-		// Render entry point file docs first
-		entryPointDocs := extractEntryPointDocs(helpModel.FileDocs)
-		if entryPointDocs != nil {
-			buf.WriteString("  <section class=\"file-docs\">\n")
-			buf.WriteString("    <h2>Description</h2>\n")
-			buf.WriteString("    <div class=\"description\">\n")
-			for _, line := range entryPointDocs {
-				if line == "" {
-					buf.WriteString("      <br>\n")
-				} else {
-					buf.WriteString("      <p>")
-					buf.WriteString(html.EscapeString(line))
-					buf.WriteString("</p>\n")
+	// Usage section; category pages leave it to the index page
+	if !nav.categoryPage() {
+		buf.WriteString("  <section class=\"usage\">\n")
+		buf.WriteString("    <h2>Usage</h2>\n")
+		buf.WriteString("    <pre>")
+		buf.WriteString(html.EscapeString(usageLine(helpModel)))
+		buf.WriteString("</pre>\n")
+		if len(helpModel.Examples) > 0 {
+			buf.WriteString("    <h3>Examples</h3>\n")
+			buf.WriteString("    <pre class=\"examples\">")
+			for i, example := range helpModel.Examples {
+				if i > 0 {
+					buf.WriteString("\n")
 				}
+				buf.WriteString(html.EscapeString(example))
 			}
-			buf.WriteString("    </div>\n")
-			buf.WriteString("  </section>\n")
+			buf.WriteString("</pre>\n")
 		}
+		buf.WriteString("  </section>\n")
+	}
 
-		// Render included files section
-		includedFiles := extractIncludedFiles(helpModel.FileDocs)
-		if len(includedFiles) > 0 {
-			buf.WriteString("  <section class=\"included-files\">\n")
-			buf.WriteString("    <h2>Included files</h2>\n")
-			for _, fileDoc := range includedFiles {
-				buf.WriteString("    <div class=\"file\">\n")
-				buf.WriteString("      <h3>")
-				buf.WriteString(html.EscapeString(fileDoc.SourceFile))
-				buf.WriteString("</h3>\n")
-				for _, line := range fileDoc.Documentation {
-					if line == "" {
-						buf.WriteString("      <br>\n")
-					} else {
-						buf.WriteString("      <p>")
-						buf.WriteString(html.EscapeString(line))
-						buf.WriteString("</p>\n")
-					}
-				}
-				buf.WriteString("    </div>\n")
-			}
-			buf.WriteString("  </section>\n")
+	// File documentation section: entry point docs first, then included
+	// files unless they are placed after the targets
+	if entryPointDocs := extractEntryPointDocs(helpModel.FileDocs); entryPointDocs != nil {
+		buf.WriteString("  <section class=\"file-docs\">\n")
+		buf.WriteString("    <h2>Description</h2>\n")
+		buf.WriteString("    <div class=\"description\">\n")
+		if owner := entryPointOwner(helpModel.FileDocs); owner != "" {
+			buf.WriteString("      <p class=\"owner\">Owner: ")
+			buf.WriteString(html.EscapeString(owner))
+			buf.WriteString("</p>\n")
 		}
+		f.renderFileDocLines(&buf, entryPointDocs, "      ")
+		buf.WriteString("    </div>\n")
+		buf.WriteString("  </section>\n")
+	}
+	if !includedFilesAtBottom(f.config) {
+		f.renderIncludedFiles(&buf, helpModel)
 	}
 
 	// Targets section
+	sharedVariables := collectSharedVariables(helpModel, f.config)
+	f.shared = sharedVariableNames(sharedVariables)
 	if len(helpModel.Categories) > 0 {
 		buf.WriteString("  <section class=\"targets\">\n")
 		buf.WriteString("    <h2>Targets</h2>\n")
 
+		if page := f.config.Page; page != nil {
+			fmt.Fprintf(&buf, "    <p class=\"page\">Page %d of %d (targets %d&ndash;%d of %d)</p>\n",
+				page.Number, page.TotalPages(), page.first(), page.last(), page.TotalTargets)
+		}
+
 		for _, category := range helpModel.Categories {
 			f.renderCategory(&buf, &category)
 		}
 
 		buf.WriteString("  </section>\n")
+
+		if !f.config.NoScript {
+			f.writeScript(&buf)
+		}
+	}
+
+	// Category pages of split help (index page only)
+	if nav != nil && len(nav.Pages) > 0 {
+		buf.WriteString("  <section class=\"pages\">\n")
+		buf.WriteString("    <h2>Categories</h2>\n")
+		buf.WriteString("    <ul>\n")
+		for _, page := range nav.Pages {
+			buf.WriteString("      <li>")
+			buf.WriteString(htmlLinks([]Link{page.Link}, ""))
+			buf.WriteString(" <span class=\"count\">(")
+			buf.WriteString(page.targetCount())
+			buf.WriteString(")</span></li>\n")
+		}
+		buf.WriteString("    </ul>\n")
+		buf.WriteString("  </section>\n")
+	}
+
+	// Variables section (shared variables only)
+	if len(sharedVariables) > 0 {
+		buf.WriteString("  <section class=\"shared-variables\">\n")
+		buf.WriteString("    <h2>Variables</h2>\n")
+		buf.WriteString("    <ul>\n")
+		for _, v := range sharedVariables {
+			f.renderSharedVariable(&buf, &v)
+		}
+		buf.WriteString("    </ul>\n")
+		buf.WriteString("  </section>\n")
+	}
+
+	if includedFilesAtBottom(f.config) {
+		f.renderIncludedFiles(&buf, helpModel)
+	}
+
+	if len(helpModel.FooterDocs) > 0 {
+		buf.WriteString("  <section class=\"footer-docs\">\n")
+		for _, line := range helpModel.FooterDocs {
+			if line == "" {
+				buf.WriteString("    <br>\n")
+			} else {
+				buf.WriteString("    <p>")
+				buf.WriteString(f.renderRichText(f.parser.Parse(line)))
+				buf.WriteString("</p>\n")
+			}
+		}
+		buf.WriteString("  </section>\n")
+	}
+
+	if nav.categoryPage() {
+		buf.WriteString("  <nav class=\"pager\">")
+		buf.WriteString(htmlLinks(nav.pagerLinks(), " · "))
+		buf.WriteString("</nav>\n")
+	}
+
+	if f.config.Provenance != nil {
+		buf.WriteString("  <footer class=\"provenance\">")
+		buf.WriteString(html.EscapeString(f.config.Provenance.footerText()))
+		buf.WriteString("</footer>\n")
 	}
 
 	buf.WriteString("</body>\n")
@@ -123,6 +234,83 @@ func (f *HTMLFormatter) RenderHelp(helpModel *model.HelpModel, w io.Writer) erro
 	return err
 }
 
+// htmlLinks joins links with sep. Links without an Href are plain text.
+// They point to other pages of the same help, so the --html-link-* attributes
+// for documentation links do not apply.
+func htmlLinks(links []Link, sep string) string {
+	parts := make([]string, 0, len(links))
+	for _, link := range links {
+		if link.Href == "" {
+			parts = append(parts, html.EscapeString(link.Text))
+		} else {
+			parts = append(parts, "<a href=\""+html.EscapeString(link.Href)+"\">"+html.EscapeString(link.Text)+"</a>")
+		}
+	}
+	return strings.Join(parts, sep)
+}
+
+// renderProjectLinks renders links to the homepage and repository of the
+// project, if the model has them. Unsafe URLs are left out.
+func (f *HTMLFormatter) renderProjectLinks(buf *strings.Builder, helpModel *model.HelpModel) {
+	var links []string
+	if isValidURL(helpModel.Homepage) {
+		links = append(links, "<a href=\""+html.EscapeString(helpModel.Homepage)+"\""+f.linkAttributes()+">Homepage</a>")
+	}
+	if isValidURL(helpModel.Repo) {
+		links = append(links, "<a href=\""+html.EscapeString(helpModel.Repo)+"\""+f.linkAttributes()+">Repository</a>")
+	}
+	if len(links) > 0 {
+		buf.WriteString("  <p class=\"project-links\">" + strings.Join(links, " · ") + "</p>\n")
+	}
+}
+
+// renderIncludedFiles renders the documentation of included files. Lines
+// beyond the configured depth are folded into a <details> element.
+func (f *HTMLFormatter) renderIncludedFiles(buf *strings.Builder, helpModel *model.HelpModel) {
+	includedFiles := includedFilesFor(helpModel, f.config)
+	if len(includedFiles) == 0 {
+		return
+	}
+	buf.WriteString("  <section class=\"included-files\">\n")
+	buf.WriteString("    <h2>Included files</h2>\n")
+	for _, fileDoc := range includedFiles {
+		buf.WriteString("    <div class=\"file\">\n")
+		buf.WriteString("      <h3>")
+		buf.WriteString(html.EscapeString(fileDoc.SourceFile))
+		buf.WriteString("</h3>\n")
+		if fileDoc.Owner != "" {
+			buf.WriteString("      <p class=\"owner\">Owner: ")
+			buf.WriteString(html.EscapeString(fileDoc.Owner))
+			buf.WriteString("</p>\n")
+		}
+		lines, omitted := limitFileDocs(fileDoc.Documentation, f.config)
+		f.renderFileDocLines(buf, lines, "      ")
+		if omitted > 0 {
+			buf.WriteString("      <details class=\"more-docs\">\n")
+			fmt.Fprintf(buf, "        <summary>%s</summary>\n", html.EscapeString(formatMoreLines(omitted)))
+			f.renderFileDocLines(buf, fileDoc.Documentation[len(lines):], "        ")
+			buf.WriteString("      </details>\n")
+		}
+		buf.WriteString("    </div>\n")
+	}
+	buf.WriteString("  </section>\n")
+}
+
+// renderFileDocLines renders file documentation lines as paragraphs, with
+// blank lines as breaks.
+func (f *HTMLFormatter) renderFileDocLines(buf *strings.Builder, lines []string, indent string) {
+	for _, line := range lines {
+		buf.WriteString(indent)
+		if line == "" {
+			buf.WriteString("<br>\n")
+		} else {
+			buf.WriteString("<p>")
+			buf.WriteString(f.docText(line))
+			buf.WriteString("</p>\n")
+		}
+	}
+}
+
 // renderCategory renders a single category with its targets in HTML.
 func (f *HTMLFormatter) renderCategory(buf *strings.Builder, category *model.Category) {
 	buf.WriteString("    <div class=\"category\">\n")
@@ -134,6 +322,17 @@ func (f *HTMLFormatter) renderCategory(buf *strings.Builder, category *model.Cat
 		buf.WriteString("</h3>\n")
 	}
 
+	// Section introduction (if any)
+	for _, line := range category.Documentation {
+		if line == "" {
+			buf.WriteString("      <br>\n")
+		} else {
+			buf.WriteString("      <p>")
+			buf.WriteString(f.docText(line))
+			buf.WriteString("</p>\n")
+		}
+	}
+
 	// Render targets as a list
 	buf.WriteString("      <ul>\n")
 	for _, target := range category.Targets {
@@ -143,6 +342,30 @@ func (f *HTMLFormatter) renderCategory(buf *strings.Builder, category *model.Cat
 	buf.WriteString("    </div>\n")
 }
 
+// renderSharedVariable renders a variable of the Variables section with
+// the targets that use it.
+func (f *HTMLFormatter) renderSharedVariable(buf *strings.Builder, v *sharedVariable) {
+	buf.WriteString("      <li><code class=\"variable\">")
+	buf.WriteString(html.EscapeString(v.Name))
+	buf.WriteString("</code>")
+	if v.Required {
+		buf.WriteString(" <em>(required)</em>")
+	}
+	if choices := formatChoices(v.Variable); choices != "" {
+		buf.WriteString(" <code class=\"choices\">")
+		buf.WriteString(html.EscapeString(choices))
+		buf.WriteString("</code>")
+	}
+	if v.Description != "" {
+		buf.WriteString(": ")
+		buf.WriteString(f.docText(v.Description))
+	}
+	buf.WriteString("\n        <div class=\"variables\">Used by: ")
+	buf.WriteString(html.EscapeString(strings.Join(v.UsedBy, ", ")))
+	buf.WriteString("</div>\n")
+	buf.WriteString("      </li>\n")
+}
+
 // renderTarget renders a single target in HTML.
 func (f *HTMLFormatter) renderTarget(buf *strings.Builder, target *model.Target) {
 	buf.WriteString("        <li class=\"target\">\n")
@@ -175,13 +398,61 @@ func (f *HTMLFormatter) renderTarget(buf *strings.Builder, target *model.Target)
 		}
 	}
 
+	// Duration estimate (if any)
+	if duration := formatDuration(target); duration != "" {
+		buf.WriteString(" <span class=\"duration\">")
+		buf.WriteString(html.EscapeString(duration))
+		buf.WriteString("</span>")
+	}
+
+	// Platform badge (if any)
+	if platforms := formatPlatforms(target); platforms != "" {
+		buf.WriteString(" <span class=\"platforms\">")
+		buf.WriteString(html.EscapeString(platforms))
+		buf.WriteString("</span>")
+	}
+
+	// Owner (if any)
+	if target.Owner != "" {
+		buf.WriteString(" <span class=\"owner\">")
+		buf.WriteString(html.EscapeString(target.Owner))
+		buf.WriteString("</span>")
+	}
+
+	// Last change (with --git-blame)
+	if change, ok := f.config.LastChanges[target.Name]; ok {
+		buf.WriteString(" <span class=\"changed\">")
+		buf.WriteString(html.EscapeString(change.text()))
+		buf.WriteString("</span>")
+	}
+
+	// Danger badge (if any)
+	if target.Dangerous {
+		buf.WriteString(" <span class=\"danger\"")
+		if target.DangerReason != "" {
+			buf.WriteString(" title=\"")
+			buf.WriteString(html.EscapeString(target.DangerReason))
+			buf.WriteString("\"")
+		}
+		buf.WriteString(">")
+		buf.WriteString(html.EscapeString(dangerBadge))
+		buf.WriteString("</span>")
+	}
+
+	// New badge (if any)
+	if f.config.NewTargets[target.Name] {
+		buf.WriteString(" <span class=\"new\">")
+		buf.WriteString(newBadge)
+		buf.WriteString("</span>")
+	}
+
 	buf.WriteString("\n")
 
 	// Variables (if any)
-	if len(target.Variables) > 0 {
+	if variables := ownVariables(target, f.shared); len(variables) > 0 {
 		buf.WriteString("          <div class=\"variables\">\n")
 		buf.WriteString("            Variables: ")
-		for i, v := range target.Variables {
+		for i, v := range variables {
 			if i > 0 {
 				buf.WriteString(", ")
 			}
@@ -192,9 +463,35 @@ func (f *HTMLFormatter) renderTarget(buf *strings.Builder, target *model.Target)
 		buf.WriteString("\n          </div>\n")
 	}
 
+	f.renderRunCommand(buf, target, "          ")
+
 	buf.WriteString("        </li>\n")
 }
 
+// renderRunCommand renders the "make <target>" command chip for a target.
+// When scripts are enabled, a copy-to-clipboard button is rendered next to it.
+func (f *HTMLFormatter) renderRunCommand(buf *strings.Builder, target *model.Target, indent string) {
+	command := html.EscapeString(buildRunCommand(target))
+
+	buf.WriteString(indent)
+	buf.WriteString("<div class=\"run-command\"><code>")
+	buf.WriteString(command)
+	buf.WriteString("</code>")
+	if !f.config.NoScript {
+		buf.WriteString(" <button type=\"button\" class=\"copy-button\" data-command=\"")
+		buf.WriteString(command)
+		buf.WriteString("\">Copy</button>")
+	}
+	buf.WriteString("</div>\n")
+}
+
+// writeScript writes the inline copy-to-clipboard script.
+func (f *HTMLFormatter) writeScript(buf *strings.Builder) {
+	buf.WriteString(f.openInlineTag("script"))
+	buf.WriteString(cachedHTMLScript)
+	buf.WriteString("  </script>\n")
+}
+
 // RenderDetailedTarget renders a detailed view of a single target in HTML.
 func (f *HTMLFormatter) RenderDetailedTarget(target *model.Target, w io.Writer) error {
 	if target == nil {
@@ -210,7 +507,7 @@ func (f *HTMLFormatter) RenderDetailedTarget(target *model.Target, w io.Writer)
 	fmt.Fprintf(&buf, "  <title>Target: %s</title>\n", html.EscapeString(target.Name))
 
 	if f.config.UseColor {
-		buf.WriteString("  <style>\n")
+		buf.WriteString(f.openInlineTag("style"))
 		buf.WriteString(f.getCSS())
 		buf.WriteString("  </style>\n")
 	}
@@ -221,6 +518,9 @@ func (f *HTMLFormatter) RenderDetailedTarget(target *model.Target, w io.Writer)
 	buf.WriteString(html.EscapeString(target.Name))
 	buf.WriteString("</h1>\n")
 
+	// Run command
+	f.renderRunCommand(&buf, target, "  ")
+
 	// Aliases
 	if len(target.Aliases) > 0 {
 		buf.WriteString("  <div class=\"aliases\">\n")
@@ -229,6 +529,59 @@ func (f *HTMLFormatter) RenderDetailedTarget(target *model.Target, w io.Writer)
 		buf.WriteString("\n  </div>\n")
 	}
 
+	// Owner
+	if target.Owner != "" {
+		buf.WriteString("  <div class=\"owner\">\n")
+		buf.WriteString("    <strong>Owner:</strong> ")
+		buf.WriteString(html.EscapeString(target.Owner))
+		buf.WriteString("\n  </div>\n")
+	}
+
+	// CI workflows, linked relative to the help file
+	if len(target.CIWorkflows) > 0 {
+		buf.WriteString("  <div class=\"ci\">\n")
+		buf.WriteString("    <strong>CI:</strong> ")
+		for i, workflow := range target.CIWorkflows {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			escaped := html.EscapeString(workflow)
+			buf.WriteString("<a href=\"" + escaped + "\">" + escaped + "</a>")
+		}
+		buf.WriteString("\n  </div>\n")
+	}
+
+	// Last change
+	if change, ok := f.config.LastChanges[target.Name]; ok {
+		buf.WriteString("  <div class=\"changed\">")
+		buf.WriteString(html.EscapeString(change.text()))
+		buf.WriteString("</div>\n")
+	}
+
+	// Danger warning
+	if target.Dangerous {
+		buf.WriteString("  <div class=\"danger\">\n")
+		buf.WriteString("    <strong>Danger:</strong> ")
+		buf.WriteString(html.EscapeString(formatDangerReason(target)))
+		buf.WriteString("\n  </div>\n")
+	}
+
+	// Platforms
+	if len(target.Platforms) > 0 {
+		buf.WriteString("  <div class=\"platforms\">\n")
+		buf.WriteString("    <strong>Platforms:</strong> ")
+		buf.WriteString(html.EscapeString(strings.Join(target.Platforms, ", ")))
+		buf.WriteString("\n  </div>\n")
+	}
+
+	// Duration estimate
+	if target.Duration != "" {
+		buf.WriteString("  <div class=\"duration\">\n")
+		buf.WriteString("    <strong>Duration:</strong> ")
+		buf.WriteString(html.EscapeString(target.Duration))
+		buf.WriteString("\n  </div>\n")
+	}
+
 	// Variables
 	if len(target.Variables) > 0 {
 		buf.WriteString("  <div class=\"variables\">\n")
@@ -238,9 +591,17 @@ func (f *HTMLFormatter) RenderDetailedTarget(target *model.Target, w io.Writer)
 			buf.WriteString("      <li><code class=\"variable\">")
 			buf.WriteString(html.EscapeString(v.Name))
 			buf.WriteString("</code>")
+			if v.Required {
+				buf.WriteString(" <em>(required)</em>")
+			}
+			if choices := formatChoices(v); choices != "" {
+				buf.WriteString(" <code class=\"choices\">")
+				buf.WriteString(html.EscapeString(choices))
+				buf.WriteString("</code>")
+			}
 			if v.Description != "" {
 				buf.WriteString(": ")
-				buf.WriteString(html.EscapeString(v.Description))
+				buf.WriteString(f.docText(v.Description))
 			}
 			buf.WriteString("</li>\n")
 		}
@@ -256,7 +617,7 @@ func (f *HTMLFormatter) RenderDetailedTarget(target *model.Target, w io.Writer)
 				buf.WriteString("    <br>\n")
 			} else {
 				buf.WriteString("    <p>")
-				buf.WriteString(html.EscapeString(line))
+				buf.WriteString(f.docText(line))
 				buf.WriteString("</p>\n")
 			}
 		}
@@ -272,6 +633,10 @@ func (f *HTMLFormatter) RenderDetailedTarget(target *model.Target, w io.Writer)
 		buf.WriteString("\n  </div>\n")
 	}
 
+	if !f.config.NoScript {
+		f.writeScript(&buf)
+	}
+
 	buf.WriteString("</body>\n")
 	buf.WriteString("</html>\n")
 
@@ -290,7 +655,7 @@ func (f *HTMLFormatter) RenderBasicTarget(name string, sourceFile string, lineNu
 	fmt.Fprintf(&buf, "  <title>Target: %s</title>\n", html.EscapeString(name))
 
 	if f.config.UseColor {
-		buf.WriteString("  <style>\n")
+		buf.WriteString(f.openInlineTag("style"))
 		buf.WriteString(f.getCSS())
 		buf.WriteString("  </style>\n")
 	}
@@ -376,11 +741,11 @@ func (f *HTMLFormatter) renderRichText(rt richtext.RichText) string {
 		switch seg.Type {
 		case richtext.SegmentBold:
 			buf.WriteString("<strong>")
-			buf.WriteString(html.EscapeString(seg.Content))
+			buf.WriteString(f.docText(seg.Content))
 			buf.WriteString("</strong>")
 		case richtext.SegmentItalic:
 			buf.WriteString("<em>")
-			buf.WriteString(html.EscapeString(seg.Content))
+			buf.WriteString(f.docText(seg.Content))
 			buf.WriteString("</em>")
 		case richtext.SegmentCode:
 			buf.WriteString("<code>")
@@ -391,20 +756,58 @@ func (f *HTMLFormatter) renderRichText(rt richtext.RichText) string {
 			if isValidURL(seg.URL) {
 				buf.WriteString("<a href=\"")
 				buf.WriteString(html.EscapeString(seg.URL))
-				buf.WriteString("\">")
-				buf.WriteString(html.EscapeString(seg.Content))
+				buf.WriteString("\"")
+				buf.WriteString(f.linkAttributes())
+				buf.WriteString(">")
+				buf.WriteString(f.docText(seg.Content))
 				buf.WriteString("</a>")
 			} else {
 				// Render as plain text if URL is unsafe
-				buf.WriteString(html.EscapeString(seg.Content))
+				buf.WriteString(f.docText(seg.Content))
 			}
 		default:
-			buf.WriteString(html.EscapeString(seg.Content))
+			buf.WriteString(f.docText(seg.Content))
 		}
 	}
 	return buf.String()
 }
 
+// docText renders documentation text according to the raw HTML policy.
+func (f *HTMLFormatter) docText(s string) string {
+	switch f.config.HTMLPolicy.RawHTML {
+	case RawHTMLAllow:
+		return s
+	case RawHTMLStrip:
+		return html.EscapeString(htmlTag.ReplaceAllString(s, ""))
+	default:
+		return html.EscapeString(s)
+	}
+}
+
+// linkAttributes returns the rel and target attributes for documentation
+// links, with a leading space, or "" when the policy sets neither.
+func (f *HTMLFormatter) linkAttributes() string {
+	var attrs strings.Builder
+	if f.config.HTMLPolicy.LinkRel != "" {
+		attrs.WriteString(" rel=\"")
+		attrs.WriteString(html.EscapeString(f.config.HTMLPolicy.LinkRel))
+		attrs.WriteString("\"")
+	}
+	if f.config.HTMLPolicy.LinkTargetBlank {
+		attrs.WriteString(" target=\"_blank\"")
+	}
+	return attrs.String()
+}
+
+// openInlineTag returns the opening line of an inline <style> or <script>
+// element, carrying the policy nonce if one is set.
+func (f *HTMLFormatter) openInlineTag(name string) string {
+	if f.config.HTMLPolicy.Nonce == "" {
+		return "  <" + name + ">\n"
+	}
+	return fmt.Sprintf("  <%s nonce=\"%s\">\n", name, html.EscapeString(f.config.HTMLPolicy.Nonce))
+}
+
 // cachedHTMLCSS contains the embedded CSS stylesheet (cached at package level for performance).
 // The color scheme is inspired by the Flat UI Colors palette (https://flatuicolors.com)
 // to provide consistent, accessible styling with good contrast ratios.
@@ -460,9 +863,31 @@ var cachedHTMLCSS = `    body {
       color: #f39c12;  /* Orange - target aliases (distinctive color for alternative names) */
       font-style: italic;
     }
+    .platforms, .duration, .owner, .ci, .changed {
+      color: #7f8c8d;  /* Asbestos - platforms, duration, owner, CI, and last change (secondary information) */
+      font-size: 0.9em;
+    }
+    .danger {
+      color: #c0392b;  /* Pomegranate - destructive targets (red warns before running) */
+      font-weight: bold;
+    }
+    .new {
+      color: #d35400;  /* Pumpkin - recently added targets */
+      font-weight: bold;
+    }
     .summary {
       color: #555;  /* Dark gray - summary text */
     }
+    .breadcrumbs, .pager, .count {
+      color: #7f8c8d;  /* Asbestos - navigation between split help pages */
+      font-size: 0.9em;
+    }
+    .pager {
+      display: block;
+      margin-top: 2em;
+      border-top: 1px solid #ecf0f1;  /* Clouds - subtle divider */
+      padding-top: 0.5em;
+    }
     .variables {
       color: #7f8c8d;  /* Asbestos - variable section labels (muted gray) */
       font-size: 0.9em;
@@ -497,6 +922,31 @@ var cachedHTMLCSS = `    body {
     .aliases, .variables {
       margin: 0.5em 0;
     }
+    .run-command {
+      margin-left: 1.5em;
+      margin-top: 0.2em;
+      font-size: 0.9em;
+    }
+    .copy-button {
+      border: 1px solid #bdc3c7;  /* Silver - unobtrusive button border */
+      border-radius: 3px;
+      background-color: #ecf0f1;  /* Clouds - subtle button background */
+      color: #34495e;
+      font-size: 0.8em;
+      cursor: pointer;
+    }
+`
+
+// cachedHTMLScript contains the inline copy-to-clipboard script.
+// It is vanilla JavaScript with no external dependencies so generated pages work offline.
+var cachedHTMLScript = `    document.querySelectorAll(".copy-button").forEach(function (button) {
+      button.addEventListener("click", function () {
+        navigator.clipboard.writeText(button.getAttribute("data-command")).then(function () {
+          button.textContent = "Copied!";
+          setTimeout(function () { button.textContent = "Copy"; }, 1500);
+        });
+      });
+    });
 `
 
 // getCSS returns the cached CSS stylesheet.
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/sdlcforge/make-help/internal/model"
 )
@@ -708,3 +709,244 @@ func TestHTMLFormatter_MixedURLs(t *testing.T) {
 		t.Error("Output should contain 'unsafe' text")
 	}
 }
+
+// TestHTMLFormatter_RunCommand tests that each target gets a run command chip with a copy button
+func TestHTMLFormatter_RunCommand(t *testing.T) {
+	t.Parallel()
+	formatter := NewHTMLFormatter(&FormatterConfig{UseColor: false})
+	helpModel := &model.HelpModel{
+		Categories: []model.Category{
+			{
+				Name: model.UncategorizedCategoryName,
+				Targets: []model.Target{
+					{
+						Name:    "deploy",
+						Summary: []string{"Deploy the app."},
+						Variables: []model.Variable{
+							{Name: "ENV", Description: "Target environment"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := formatter.RenderHelp(helpModel, &buf); err != nil {
+		t.Fatalf("RenderHelp() error = %v", err)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, "<div class=\"run-command\"><code>make deploy ENV=&#39;&lt;value&gt;&#39;</code>") {
+		t.Errorf("Output should contain run command chip with variable placeholders, got:\n%s", output)
+	}
+	if !strings.Contains(output, "data-command=\"make deploy ENV=&#39;&lt;value&gt;&#39;\"") {
+		t.Error("Output should contain copy button with escaped command")
+	}
+	if !strings.Contains(output, "<script>") || !strings.Contains(output, "navigator.clipboard.writeText") {
+		t.Error("Output should contain inline copy-to-clipboard script")
+	}
+}
+
+// TestHTMLFormatter_NoScript tests that --no-script omits the copy buttons and script
+func TestHTMLFormatter_Provenance(t *testing.T) {
+	t.Parallel()
+	helpModel := &model.HelpModel{
+		Categories: []model.Category{
+			{Name: model.UncategorizedCategoryName, Targets: []model.Target{{Name: "build"}}},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		provenance *Provenance
+		want       string
+	}{
+		{
+			name:       "version only",
+			provenance: &Provenance{Version: "dev"},
+			want:       "<footer class=\"provenance\">Generated by make-help dev.</footer>\n</body>",
+		},
+		{
+			name:       "commit and time",
+			provenance: &Provenance{Version: "1.2.0", Commit: "1a2b3c4", Time: time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)},
+			want:       "<footer class=\"provenance\">Generated by make-help 1.2.0 from commit 1a2b3c4 on 2026-01-02 15:04 UTC.</footer>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			formatter := NewHTMLFormatter(&FormatterConfig{Provenance: tt.provenance})
+			var buf bytes.Buffer
+			if err := formatter.RenderHelp(helpModel, &buf); err != nil {
+				t.Fatalf("RenderHelp() error = %v", err)
+			}
+			if !strings.Contains(buf.String(), tt.want) {
+				t.Errorf("output missing %q:\n%s", tt.want, buf.String())
+			}
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := NewHTMLFormatter(nil).RenderHelp(helpModel, &buf); err != nil {
+		t.Fatalf("RenderHelp() error = %v", err)
+	}
+	if strings.Contains(buf.String(), "<footer") {
+		t.Error("footer should only be rendered with Provenance set")
+	}
+}
+
+func TestHTMLFormatter_NoScript(t *testing.T) {
+	t.Parallel()
+	formatter := NewHTMLFormatter(&FormatterConfig{UseColor: true, NoScript: true})
+	target := model.Target{
+		Name:          "build",
+		Summary:       []string{"Build it."},
+		Documentation: []string{"Build it."},
+	}
+	helpModel := &model.HelpModel{
+		Categories: []model.Category{
+			{Name: model.UncategorizedCategoryName, Targets: []model.Target{target}},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := formatter.RenderHelp(helpModel, &buf); err != nil {
+		t.Fatalf("RenderHelp() error = %v", err)
+	}
+	output := buf.String()
+	if !strings.Contains(output, "<div class=\"run-command\"><code>make build</code></div>") {
+		t.Error("Output should still contain run command chip without a button")
+	}
+	if strings.Contains(output, "<script>") || strings.Contains(output, "copy-button\"") {
+		t.Error("Output should not contain script or copy buttons when NoScript is set")
+	}
+
+	buf.Reset()
+	if err := formatter.RenderDetailedTarget(&target, &buf); err != nil {
+		t.Fatalf("RenderDetailedTarget() error = %v", err)
+	}
+	if strings.Contains(buf.String(), "<script>") {
+		t.Error("Detailed output should not contain script when NoScript is set")
+	}
+}
+
+func TestHTMLFormatter_RawHTMLPolicy(t *testing.T) {
+	t.Parallel()
+	helpModel := &model.HelpModel{
+		FileDocs: []model.FileDoc{
+			{IsEntryPoint: true, Documentation: []string{"See <b>notes</b> & caveats."}},
+		},
+		Categories: []model.Category{
+			{
+				Name: model.UncategorizedCategoryName,
+				Targets: []model.Target{
+					{Name: "build", Summary: []string{"Build <em>everything</em>."}},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		mode    string
+		want    []string
+		notWant []string
+	}{
+		{
+			mode:    "",
+			want:    []string{"See &lt;b&gt;notes&lt;/b&gt; &amp; caveats.", "Build &lt;em&gt;everything&lt;/em&gt;."},
+			notWant: []string{"<b>notes</b>"},
+		},
+		{
+			mode:    RawHTMLStrip,
+			want:    []string{"See notes &amp; caveats.", "Build everything."},
+			notWant: []string{"<b>", "&lt;b&gt;"},
+		},
+		{
+			mode: RawHTMLAllow,
+			want: []string{"See <b>notes</b> & caveats.", "Build <em>everything</em>."},
+		},
+	}
+	for _, tt := range tests {
+		formatter := NewHTMLFormatter(&FormatterConfig{HTMLPolicy: HTMLPolicy{RawHTML: tt.mode}})
+		var buf bytes.Buffer
+		if err := formatter.RenderHelp(helpModel, &buf); err != nil {
+			t.Fatalf("RenderHelp() error = %v", err)
+		}
+		output := buf.String()
+		for _, want := range tt.want {
+			if !strings.Contains(output, want) {
+				t.Errorf("mode %q: output should contain %q", tt.mode, want)
+			}
+		}
+		for _, notWant := range tt.notWant {
+			if strings.Contains(output, notWant) {
+				t.Errorf("mode %q: output should not contain %q", tt.mode, notWant)
+			}
+		}
+	}
+}
+
+func TestHTMLFormatter_LinkAndNoncePolicy(t *testing.T) {
+	t.Parallel()
+	formatter := NewHTMLFormatter(&FormatterConfig{
+		UseColor: true,
+		HTMLPolicy: HTMLPolicy{
+			LinkRel:         "noopener noreferrer",
+			LinkTargetBlank: true,
+			Nonce:           "r4nd0m",
+		},
+	})
+	helpModel := &model.HelpModel{
+		Categories: []model.Category{
+			{
+				Name: model.UncategorizedCategoryName,
+				Targets: []model.Target{
+					{Name: "docs", Summary: []string{"See [the guide](https://example.com)."}},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := formatter.RenderHelp(helpModel, &buf); err != nil {
+		t.Fatalf("RenderHelp() error = %v", err)
+	}
+	output := buf.String()
+	if !strings.Contains(output, `<a href="https://example.com" rel="noopener noreferrer" target="_blank">the guide</a>`) {
+		t.Error("Output should contain link with rel and target attributes")
+	}
+	if !strings.Contains(output, `<style nonce="r4nd0m">`) {
+		t.Error("Output should contain style element with nonce")
+	}
+	if !strings.Contains(output, `<script nonce="r4nd0m">`) {
+		t.Error("Output should contain script element with nonce")
+	}
+}
+
+func TestHTMLFormatter_RenderHelp_LastChange(t *testing.T) {
+	t.Parallel()
+	formatter := NewHTMLFormatter(&FormatterConfig{
+		LastChanges: map[string]Change{
+			"build": {Author: "O'Brien <ci>", Time: time.Date(2026, time.March, 4, 0, 0, 0, 0, time.UTC)},
+		},
+	})
+	helpModel := &model.HelpModel{
+		Categories: []model.Category{
+			{
+				Name:    model.UncategorizedCategoryName,
+				Targets: []model.Target{{Name: "build", Summary: []string{"Build the app."}}},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := formatter.RenderHelp(helpModel, &buf); err != nil {
+		t.Fatalf("RenderHelp() error = %v", err)
+	}
+	expected := `<span class="changed">Last changed by O&#39;Brien &lt;ci&gt; on 2026-03-04</span>`
+	if !strings.Contains(buf.String(), expected) {
+		t.Errorf("Expected %q in output, got:\n%s", expected, buf.String())
+	}
+}
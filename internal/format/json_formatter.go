@@ -23,40 +23,91 @@ func NewJSONFormatter(config *FormatterConfig) *JSONFormatter {
 	}
 }
 
+// jsonSchemaVersion identifies the shape of jsonHelpOutput so consumers can
+// detect changes. Output without a schemaVersion field is version 1.
+//
+// Version 2 added per-target category, isPhony, isDefault, tags, deprecated,
+// deprecationMessage, hidden, and discoveryOrder.
+const jsonSchemaVersion = 2
+
 // jsonHelpOutput represents the complete help output in JSON format.
 type jsonHelpOutput struct {
-	Usage          string              `json:"usage"`
-	Description    string              `json:"description,omitempty"`
-	IncludedFiles  []jsonIncludedFile  `json:"includedFiles,omitempty"`
-	Categories     []jsonCategory      `json:"categories,omitempty"`
+	SchemaVersion int                `json:"schemaVersion"`
+	Title         string             `json:"title,omitempty"`
+	Homepage      string             `json:"homepage,omitempty"`
+	Repo          string             `json:"repo,omitempty"`
+	Usage         string             `json:"usage"`
+	Examples      []string           `json:"examples,omitempty"`
+	Description   string             `json:"description,omitempty"`
+	Owner         string             `json:"owner,omitempty"`
+	IncludedFiles []jsonIncludedFile `json:"includedFiles,omitempty"`
+	Categories    []jsonCategory     `json:"categories,omitempty"`
+	Footer        string             `json:"footer,omitempty"`
+	Stats         *jsonStats         `json:"stats,omitempty"`
+	Page          *jsonPage          `json:"page,omitempty"`
+}
+
+// jsonStats is the target inventory added with --show-stats.
+type jsonStats struct {
+	Targets      int `json:"targets"`
+	Categories   int `json:"categories"`
+	Undocumented int `json:"undocumented"`
+}
+
+// jsonPage describes a page of paginated output.
+type jsonPage struct {
+	Number       int `json:"number"`
+	Size         int `json:"size"`
+	TotalTargets int `json:"totalTargets"`
+	TotalPages   int `json:"totalPages"`
+	NextPage     int `json:"nextPage,omitempty"`
 }
 
 // jsonIncludedFile represents a single included file.
 type jsonIncludedFile struct {
 	Path        string `json:"path"`
 	Description string `json:"description,omitempty"`
+	Owner       string `json:"owner,omitempty"`
 }
 
 // jsonCategory represents a category with its targets.
 type jsonCategory struct {
-	Name    string       `json:"name"`
-	Targets []jsonTarget `json:"targets"`
+	Name        string       `json:"name"`
+	Description string       `json:"description,omitempty"`
+	Targets     []jsonTarget `json:"targets"`
 }
 
 // jsonTarget represents a target in the help output.
 type jsonTarget struct {
-	Name       string         `json:"name"`
-	Summary    string         `json:"summary,omitempty"`
-	Aliases    []string       `json:"aliases,omitempty"`
-	Variables  []jsonVariable `json:"variables,omitempty"`
-	SourceFile string         `json:"sourceFile,omitempty"`
-	LineNumber int            `json:"lineNumber,omitempty"`
+	Name               string         `json:"name"`
+	Category           string         `json:"category"`
+	Summary            string         `json:"summary,omitempty"`
+	Aliases            []string       `json:"aliases,omitempty"`
+	Variables          []jsonVariable `json:"variables,omitempty"`
+	Tags               []string       `json:"tags,omitempty"`
+	Platforms          []string       `json:"platforms,omitempty"`
+	Duration           string         `json:"duration,omitempty"`
+	Profiles           []string       `json:"profiles,omitempty"`
+	IsPhony            bool           `json:"isPhony"`
+	IsDefault          bool           `json:"isDefault"`
+	Deprecated         bool           `json:"deprecated"`
+	DeprecationMessage string         `json:"deprecationMessage,omitempty"`
+	Hidden             bool           `json:"hidden"`
+	Dangerous          bool           `json:"dangerous"`
+	DangerReason       string         `json:"dangerReason,omitempty"`
+	Owner              string         `json:"owner,omitempty"`
+	CIWorkflows        []string       `json:"ciWorkflows,omitempty"`
+	DiscoveryOrder     int            `json:"discoveryOrder"`
+	SourceFile         string         `json:"sourceFile,omitempty"`
+	LineNumber         int            `json:"lineNumber,omitempty"`
 }
 
 // jsonVariable represents a documented variable.
 type jsonVariable struct {
-	Name        string `json:"name"`
-	Description string `json:"description,omitempty"`
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Required    bool     `json:"required,omitempty"`
+	Choices     []string `json:"choices,omitempty"`
 }
 
 // jsonDetailedTarget represents a detailed target view.
@@ -66,6 +117,10 @@ type jsonDetailedTarget struct {
 	Documentation []string       `json:"documentation,omitempty"`
 	Aliases       []string       `json:"aliases,omitempty"`
 	Variables     []jsonVariable `json:"variables,omitempty"`
+	Dangerous     bool           `json:"dangerous,omitempty"`
+	DangerReason  string         `json:"dangerReason,omitempty"`
+	Owner         string         `json:"owner,omitempty"`
+	CIWorkflows   []string       `json:"ciWorkflows,omitempty"`
 	SourceFile    string         `json:"sourceFile,omitempty"`
 	LineNumber    int            `json:"lineNumber,omitempty"`
 }
@@ -77,6 +132,101 @@ type jsonBasicTarget struct {
 	LineNumber int    `json:"lineNumber,omitempty"`
 }
 
+// newJSONTarget converts a model target to its JSON representation.
+// defaultGoal is the model's DefaultGoal, used to set IsDefault.
+func newJSONTarget(target *model.Target, categoryName, defaultGoal string) jsonTarget {
+	jsonTgt := jsonTarget{
+		Name:               target.Name,
+		Category:           categoryName,
+		Summary:            summaryText(target), // Use plain text for JSON consumers (strips markdown)
+		IsPhony:            target.IsPhony,
+		IsDefault:          defaultGoal != "" && target.Name == defaultGoal,
+		Deprecated:         target.Deprecated,
+		DeprecationMessage: target.DeprecationMessage,
+		Hidden:             target.Hidden,
+		Dangerous:          target.Dangerous,
+		DangerReason:       target.DangerReason,
+		Owner:              target.Owner,
+		CIWorkflows:        target.CIWorkflows,
+		Duration:           target.Duration,
+		DiscoveryOrder:     target.DiscoveryOrder,
+		SourceFile:         target.SourceFile,
+		LineNumber:         target.LineNumber,
+		Variables:          newJSONVariables(target.Variables),
+	}
+
+	// Add aliases if present
+	if len(target.Aliases) > 0 {
+		jsonTgt.Aliases = target.Aliases
+	}
+
+	// Add tags if present
+	if len(target.Tags) > 0 {
+		jsonTgt.Tags = target.Tags
+	}
+
+	// Add supported platforms if present
+	if len(target.Platforms) > 0 {
+		jsonTgt.Platforms = target.Platforms
+	}
+
+	// Add profiles if present
+	if len(target.Profiles) > 0 {
+		jsonTgt.Profiles = target.Profiles
+	}
+
+	return jsonTgt
+}
+
+// newJSONDetailedTarget converts a model target to the detailed JSON view.
+func newJSONDetailedTarget(target *model.Target) jsonDetailedTarget {
+	output := jsonDetailedTarget{
+		Name:          target.Name,
+		Summary:       summaryText(target), // Use plain text for JSON consumers (strips markdown)
+		Documentation: target.Documentation,
+		Variables:     newJSONVariables(target.Variables),
+		Dangerous:     target.Dangerous,
+		DangerReason:  target.DangerReason,
+		Owner:         target.Owner,
+		CIWorkflows:   target.CIWorkflows,
+		SourceFile:    target.SourceFile,
+		LineNumber:    target.LineNumber,
+	}
+
+	// Add aliases if present
+	if len(target.Aliases) > 0 {
+		output.Aliases = target.Aliases
+	}
+
+	return output
+}
+
+// newJSONVariables converts documented variables, returning nil when there are none
+// so the field is omitted.
+func newJSONVariables(variables []model.Variable) []jsonVariable {
+	if len(variables) == 0 {
+		return nil
+	}
+	result := make([]jsonVariable, len(variables))
+	for i, v := range variables {
+		result[i] = jsonVariable{
+			Name:        v.Name,
+			Description: v.Description,
+			Required:    v.Required,
+			Choices:     v.Choices,
+		}
+	}
+	return result
+}
+
+// summaryText returns the plain-text summary (first element of Summary), or "".
+func summaryText(target *model.Target) string {
+	if len(target.Summary) > 0 {
+		return target.Summary[0]
+	}
+	return ""
+}
+
 // RenderHelp generates the complete help output from a HelpModel in JSON format.
 func (f *JSONFormatter) RenderHelp(helpModel *model.HelpModel, w io.Writer) error {
 	if helpModel == nil {
@@ -84,7 +234,13 @@ func (f *JSONFormatter) RenderHelp(helpModel *model.HelpModel, w io.Writer) erro
 	}
 
 	output := jsonHelpOutput{
-		Usage: "make [<target>...] [<ENV_VAR>=<value>...]",
+		SchemaVersion: jsonSchemaVersion,
+		Title:         helpModel.Title,
+		Homepage:      helpModel.Homepage,
+		Repo:          helpModel.Repo,
+		Usage:         usageLine(helpModel),
+		Examples:      helpModel.Examples,
+		Footer:        strings.Join(helpModel.FooterDocs, "\n"),
 	}
 
 	// Extract entry point description and included files
@@ -95,13 +251,15 @@ func (f *JSONFormatter) RenderHelp(helpModel *model.HelpModel, w io.Writer) erro
 			// Join all documentation lines with newlines
 			output.Description = strings.Join(entryPointDocs, "\n")
 		}
+		output.Owner = entryPointOwner(helpModel.FileDocs)
 
-		// Included files
-		includedFiles := extractIncludedFiles(helpModel.FileDocs)
+		// Included files, in full: the depth limit is for readers
+		includedFiles := includedFilesFor(helpModel, f.config)
 		for _, fileDoc := range includedFiles {
 			output.IncludedFiles = append(output.IncludedFiles, jsonIncludedFile{
 				Path:        fileDoc.SourceFile,
 				Description: strings.Join(fileDoc.Documentation, "\n"),
+				Owner:       fileDoc.Owner,
 			})
 		}
 	}
@@ -109,46 +267,36 @@ func (f *JSONFormatter) RenderHelp(helpModel *model.HelpModel, w io.Writer) erro
 	// Convert categories and targets
 	for _, category := range helpModel.Categories {
 		jsonCat := jsonCategory{
-			Name:    category.Name,
-			Targets: make([]jsonTarget, 0, len(category.Targets)),
+			Name:        category.Name,
+			Description: strings.Join(category.Documentation, "\n"),
+			Targets:     make([]jsonTarget, 0, len(category.Targets)),
 		}
 
-		for _, target := range category.Targets {
-			// Extract summary text (first element of Summary slice)
-			summaryText := ""
-			if len(target.Summary) > 0 {
-				summaryText = target.Summary[0]
-			}
-
-			jsonTgt := jsonTarget{
-				Name:       target.Name,
-			Summary:    summaryText, // Use plain text for JSON consumers (strips markdown)
-				SourceFile: target.SourceFile,
-				LineNumber: target.LineNumber,
-			}
-
-			// Add aliases if present
-			if len(target.Aliases) > 0 {
-				jsonTgt.Aliases = target.Aliases
-			}
-
-			// Add variables if present
-			if len(target.Variables) > 0 {
-				jsonTgt.Variables = make([]jsonVariable, len(target.Variables))
-				for i, v := range target.Variables {
-					jsonTgt.Variables[i] = jsonVariable{
-						Name:        v.Name,
-						Description: v.Description,
-					}
-				}
-			}
-
-			jsonCat.Targets = append(jsonCat.Targets, jsonTgt)
+		for i := range category.Targets {
+			jsonCat.Targets = append(jsonCat.Targets, newJSONTarget(&category.Targets[i], category.Name, helpModel.DefaultGoal))
 		}
 
 		output.Categories = append(output.Categories, jsonCat)
 	}
 
+	if stats := f.config.Stats; stats != nil {
+		output.Stats = &jsonStats{
+			Targets:      stats.Targets,
+			Categories:   stats.Categories,
+			Undocumented: stats.Undocumented,
+		}
+	}
+
+	if page := f.config.Page; page != nil {
+		output.Page = &jsonPage{
+			Number:       page.Number,
+			Size:         page.Size,
+			TotalTargets: page.TotalTargets,
+			TotalPages:   page.TotalPages(),
+			NextPage:     page.NextPage(),
+		}
+	}
+
 	// Marshal to JSON with 2-space indentation
 	encoder := json.NewEncoder(w)
 	encoder.SetIndent("", "  ")
@@ -161,35 +309,7 @@ func (f *JSONFormatter) RenderDetailedTarget(target *model.Target, w io.Writer)
 		return errNilTarget("json")
 	}
 
-	// Extract summary text (first element of Summary slice)
-	summaryText := ""
-	if len(target.Summary) > 0 {
-		summaryText = target.Summary[0]
-	}
-
-	output := jsonDetailedTarget{
-		Name:          target.Name,
-		Summary:       summaryText, // Use plain text for JSON consumers (strips markdown)
-		Documentation: target.Documentation,
-		SourceFile:    target.SourceFile,
-		LineNumber:    target.LineNumber,
-	}
-
-	// Add aliases if present
-	if len(target.Aliases) > 0 {
-		output.Aliases = target.Aliases
-	}
-
-	// Add variables if present
-	if len(target.Variables) > 0 {
-		output.Variables = make([]jsonVariable, len(target.Variables))
-		for i, v := range target.Variables {
-			output.Variables[i] = jsonVariable{
-				Name:        v.Name,
-				Description: v.Description,
-			}
-		}
-	}
+	output := newJSONDetailedTarget(target)
 
 	// Marshal to JSON with 2-space indentation
 	encoder := json.NewEncoder(w)
@@ -319,6 +319,76 @@ func TestJSONFormatter_RenderHelp_WithIncludedFiles(t *testing.T) {
 }
 
 // TestJSONFormatter_RenderDetailedTarget tests detailed target rendering
+func TestJSONFormatter_RenderHelp_TargetMetadata(t *testing.T) {
+	t.Parallel()
+	formatter := NewJSONFormatter(nil)
+	helpModel := &model.HelpModel{
+		DefaultGoal: "build",
+		Categories: []model.Category{
+			{
+				Name: "Build",
+				Targets: []model.Target{
+					{
+						Name:           "build",
+						IsPhony:        true,
+						Tags:           []string{"ci"},
+						Platforms:      []string{"linux", "darwin"},
+						DiscoveryOrder: 0,
+					},
+					{
+						Name:               "old-build",
+						Deprecated:         true,
+						DeprecationMessage: "Use build instead.",
+						Hidden:             true,
+						DiscoveryOrder:     1,
+					},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := formatter.RenderHelp(helpModel, &buf); err != nil {
+		t.Fatalf("RenderHelp() error = %v", err)
+	}
+
+	var output jsonHelpOutput
+	if err := json.Unmarshal(buf.Bytes(), &output); err != nil {
+		t.Fatalf("Output is not valid JSON: %v", err)
+	}
+
+	if output.SchemaVersion != jsonSchemaVersion {
+		t.Errorf("SchemaVersion = %d, want %d", output.SchemaVersion, jsonSchemaVersion)
+	}
+
+	build := output.Categories[0].Targets[0]
+	if build.Category != "Build" || !build.IsPhony || !build.IsDefault {
+		t.Errorf("build metadata = %+v, want category Build, phony, default", build)
+	}
+	if len(build.Tags) != 1 || build.Tags[0] != "ci" {
+		t.Errorf("build.Tags = %v, want [ci]", build.Tags)
+	}
+	if len(build.Platforms) != 2 || build.Platforms[0] != "linux" || build.Platforms[1] != "darwin" {
+		t.Errorf("build.Platforms = %v, want [linux darwin]", build.Platforms)
+	}
+
+	oldBuild := output.Categories[0].Targets[1]
+	if oldBuild.IsDefault || !oldBuild.Deprecated || !oldBuild.Hidden {
+		t.Errorf("old-build metadata = %+v, want deprecated, hidden, not default", oldBuild)
+	}
+	if oldBuild.DeprecationMessage != "Use build instead." {
+		t.Errorf("DeprecationMessage = %q, want %q", oldBuild.DeprecationMessage, "Use build instead.")
+	}
+	if oldBuild.DiscoveryOrder != 1 {
+		t.Errorf("DiscoveryOrder = %d, want 1", oldBuild.DiscoveryOrder)
+	}
+
+	// Boolean metadata is always present so consumers need no defaults
+	if !strings.Contains(buf.String(), `"isDefault": false`) {
+		t.Error("isDefault should be emitted even when false")
+	}
+}
+
 func TestJSONFormatter_RenderDetailedTarget(t *testing.T) {
 	t.Parallel()
 	formatter := NewJSONFormatter(&FormatterConfig{UseColor: false})
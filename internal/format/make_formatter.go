@@ -13,6 +13,10 @@ import (
 type MakeFormatter struct {
 	config *FormatterConfig
 	colors *ColorScheme
+
+	// shared names the variables RenderHelpLines lists in its Variables
+	// section rather than under each target.
+	shared map[string]bool
 }
 
 // Compile-time check to ensure MakeFormatter implements LineRenderer interface.
@@ -97,44 +101,31 @@ func (f *MakeFormatter) DefaultExtension() string {
 func (f *MakeFormatter) RenderHelpLines(helpModel *model.HelpModel) ([]string, error) {
 	var lines []string
 
-	// Usage line
-	lines = append(lines, escapeForMakefileEcho("Usage: make [<target>...] [<ENV_VAR>=<value>...]"))
-
-	// File documentation
-	if len(helpModel.FileDocs) > 0 {
-		// Render entry point file docs first
-		entryPointDocs := extractEntryPointDocs(helpModel.FileDocs)
-		if entryPointDocs != nil {
-			lines = append(lines, escapeForMakefileEcho(""))
-			for _, line := range entryPointDocs {
-				lines = append(lines, escapeForMakefileEcho(line))
-			}
+	// Usage line and examples
+	lines = append(lines, escapeForMakefileEcho("Usage: "+usageLine(helpModel)))
+	if len(helpModel.Examples) > 0 {
+		lines = append(lines, escapeForMakefileEcho(""))
+		lines = append(lines, escapeForMakefileEcho("Examples:"))
+		for _, example := range helpModel.Examples {
+			lines = append(lines, escapeForMakefileEcho("  "+example))
 		}
+	}
 
-		// Render included files section
-		includedFiles := extractIncludedFiles(helpModel.FileDocs)
-		if len(includedFiles) > 0 {
-			lines = append(lines, escapeForMakefileEcho(""))
-			lines = append(lines, escapeForMakefileEcho("Included files:"))
-			for _, fileDoc := range includedFiles {
-				// File path
-				relPath := makeRelativePath(fileDoc.SourceFile, f.config.MakefileDir)
-				lines = append(lines, escapeForMakefileEcho("  "+relPath))
-
-				// Documentation (indented)
-				for _, line := range fileDoc.Documentation {
-					if line == "" {
-						lines = append(lines, escapeForMakefileEcho(""))
-					} else {
-						lines = append(lines, escapeForMakefileEcho("    "+line))
-					}
-				}
-				lines = append(lines, escapeForMakefileEcho("")) // Blank line after each file
-			}
+	// File documentation: entry point docs first, then included files
+	// unless they are placed after the targets
+	if entryPointDocs := extractEntryPointDocs(helpModel.FileDocs); entryPointDocs != nil {
+		lines = append(lines, escapeForMakefileEcho(""))
+		for _, line := range entryPointDocs {
+			lines = append(lines, escapeForMakefileEcho(line))
 		}
 	}
+	if !includedFilesAtBottom(f.config) {
+		lines = append(lines, f.renderIncludedFilesLines(helpModel)...)
+	}
 
 	// Targets section
+	sharedVariables := collectSharedVariables(helpModel, f.config)
+	f.shared = sharedVariableNames(sharedVariables)
 	if len(helpModel.Categories) > 0 {
 		lines = append(lines, escapeForMakefileEcho(""))
 		lines = append(lines, escapeForMakefileEcho("Targets:"))
@@ -145,9 +136,65 @@ func (f *MakeFormatter) RenderHelpLines(helpModel *model.HelpModel) ([]string, e
 		}
 	}
 
+	// Variables section (shared variables only)
+	if len(sharedVariables) > 0 {
+		lines = append(lines, escapeForMakefileEcho(""))
+		lines = append(lines, escapeForMakefileEcho("Variables:"))
+		for _, v := range sharedVariables {
+			lines = append(lines, f.renderSharedVariableLines(&v)...)
+		}
+	}
+
+	if includedFilesAtBottom(f.config) {
+		lines = append(lines, f.renderIncludedFilesLines(helpModel)...)
+	}
+
+	// Footer
+	if len(helpModel.FooterDocs) > 0 {
+		lines = append(lines, escapeForMakefileEcho(""))
+		for _, line := range helpModel.FooterDocs {
+			lines = append(lines, escapeForMakefileEcho(line))
+		}
+	}
+
+	if f.config.Stats != nil {
+		lines = append(lines, escapeForMakefileEcho(""))
+		lines = append(lines, escapeForMakefileEcho(f.config.Stats.summaryText()))
+	}
+
 	return lines, nil
 }
 
+// renderIncludedFilesLines renders the documentation of included files for
+// Makefile output, each limited to the configured depth.
+func (f *MakeFormatter) renderIncludedFilesLines(helpModel *model.HelpModel) []string {
+	includedFiles := includedFilesFor(helpModel, f.config)
+	if len(includedFiles) == 0 {
+		return nil
+	}
+	lines := []string{escapeForMakefileEcho(""), escapeForMakefileEcho("Included files:")}
+	for _, fileDoc := range includedFiles {
+		// File path
+		relPath := makeRelativePath(fileDoc.SourceFile, f.config.MakefileDir)
+		lines = append(lines, escapeForMakefileEcho("  "+relPath))
+
+		// Documentation (indented)
+		docLines, omitted := limitFileDocs(fileDoc.Documentation, f.config)
+		for _, line := range docLines {
+			if line == "" {
+				lines = append(lines, escapeForMakefileEcho(""))
+			} else {
+				lines = append(lines, escapeForMakefileEcho("    "+line))
+			}
+		}
+		if omitted > 0 {
+			lines = append(lines, escapeForMakefileEcho("    "+formatMoreLines(omitted)))
+		}
+		lines = append(lines, escapeForMakefileEcho("")) // Blank line after each file
+	}
+	return lines
+}
+
 // renderCategoryLines renders a single category for Makefile output.
 func (f *MakeFormatter) renderCategoryLines(category *model.Category) []string {
 	var lines []string
@@ -159,11 +206,23 @@ func (f *MakeFormatter) renderCategoryLines(category *model.Category) []string {
 		lines = append(lines, escapeForMakefileEcho(categoryLine))
 	}
 
-	// Each target in the category
-	for _, target := range category.Targets {
+	// Section introduction (if any)
+	for _, line := range category.Documentation {
+		if line != "" {
+			line = "  " + line
+		}
+		lines = append(lines, escapeForMakefileEcho(line))
+	}
+
+	// Each target in the category, up to the per-category limit
+	targets, omitted := limitTargets(category.Targets, f.config)
+	for _, target := range targets {
 		targetLines := f.renderTargetLines(&target)
 		lines = append(lines, targetLines...)
 	}
+	if omitted > 0 {
+		lines = append(lines, escapeForMakefileEcho("  "+formatMoreTargets(omitted, f.config)))
+	}
 
 	return lines
 }
@@ -193,18 +252,38 @@ func (f *MakeFormatter) renderTargetLines(target *model.Target) []string {
 	if len(target.Summary) > 0 && target.Summary[0] != "" {
 		buf.WriteString(": ")
 		buf.WriteString(f.colors.Documentation)
-		buf.WriteString(target.Summary[0])
+		buf.WriteString(truncateSummary(target.Summary[0], f.config.SummaryWidth))
+		buf.WriteString(f.colors.Reset)
+	}
+
+	// Duration estimate (if any)
+	if duration := formatDuration(target); duration != "" {
+		buf.WriteString(" ")
+		buf.WriteString(duration)
+	}
+
+	// Platform badge (if any)
+	if platforms := formatPlatforms(target); platforms != "" {
+		buf.WriteString(" ")
+		buf.WriteString(platforms)
+	}
+
+	// Danger badge (if any)
+	if target.Dangerous {
+		buf.WriteString(" ")
+		buf.WriteString(f.colors.Danger)
+		buf.WriteString(dangerBadge)
 		buf.WriteString(f.colors.Reset)
 	}
 
 	lines = append(lines, escapeForMakefileEcho(buf.String()))
 
 	// Variables (if any)
-	if len(target.Variables) > 0 {
+	if variables := ownVariables(target, f.shared); len(variables) > 0 {
 		buf.Reset()
 		buf.WriteString("    Vars: ")
-		varNames := make([]string, len(target.Variables))
-		for i, v := range target.Variables {
+		varNames := make([]string, len(variables))
+		for i, v := range variables {
 			varNames[i] = v.Name
 		}
 		buf.WriteString(f.colors.Variable)
@@ -216,6 +295,33 @@ func (f *MakeFormatter) renderTargetLines(target *model.Target) []string {
 	return lines
 }
 
+// renderSharedVariableLines renders a variable of the Variables section
+// with the targets that use it.
+func (f *MakeFormatter) renderSharedVariableLines(v *sharedVariable) []string {
+	var buf strings.Builder
+	buf.WriteString("  - ")
+	buf.WriteString(f.colors.Variable)
+	buf.WriteString(v.Name)
+	buf.WriteString(f.colors.Reset)
+	if v.Required {
+		buf.WriteString(" (required)")
+	}
+	if choices := formatChoices(v.Variable); choices != "" {
+		buf.WriteString(" ")
+		buf.WriteString(choices)
+	}
+	if v.Description != "" {
+		buf.WriteString(": ")
+		buf.WriteString(f.colors.Documentation)
+		buf.WriteString(v.Description)
+		buf.WriteString(f.colors.Reset)
+	}
+	return []string{
+		escapeForMakefileEcho(buf.String()),
+		escapeForMakefileEcho("    Used by: " + strings.Join(v.UsedBy, ", ")),
+	}
+}
+
 // RenderDetailedTargetLines renders detailed help for a single target suitable for Makefile @printf.
 // This method implements the LineRenderer interface, allowing the generator package
 // to embed help text without depending on the concrete MakeFormatter type.
@@ -232,6 +338,37 @@ func (f *MakeFormatter) RenderDetailedTargetLines(target *model.Target) []string
 		lines = append(lines, escapeForMakefileEcho(aliasLine))
 	}
 
+	// Owner
+	if target.Owner != "" {
+		lines = append(lines, escapeForMakefileEcho("Owner: "+target.Owner))
+	}
+
+	// CI workflows
+	if len(target.CIWorkflows) > 0 {
+		lines = append(lines, escapeForMakefileEcho("CI: "+strings.Join(target.CIWorkflows, ", ")))
+	}
+
+	// Last change
+	if change, ok := f.config.LastChanges[target.Name]; ok {
+		lines = append(lines, escapeForMakefileEcho(change.text()))
+	}
+
+	// Danger warning
+	if target.Dangerous {
+		dangerLine := f.colors.Danger + "Danger: " + formatDangerReason(target) + f.colors.Reset
+		lines = append(lines, escapeForMakefileEcho(dangerLine))
+	}
+
+	// Platforms
+	if len(target.Platforms) > 0 {
+		lines = append(lines, escapeForMakefileEcho("Platforms: "+strings.Join(target.Platforms, ", ")))
+	}
+
+	// Duration estimate
+	if target.Duration != "" {
+		lines = append(lines, escapeForMakefileEcho("Duration: "+target.Duration))
+	}
+
 	// Variables
 	if len(target.Variables) > 0 {
 		varHeader := f.colors.Variable + "Variables:" + f.colors.Reset
@@ -242,6 +379,13 @@ func (f *MakeFormatter) RenderDetailedTargetLines(target *model.Target) []string
 			varBuf.WriteString(f.colors.Variable)
 			varBuf.WriteString(v.Name)
 			varBuf.WriteString(f.colors.Reset)
+			if v.Required {
+				varBuf.WriteString(" (required)")
+			}
+			if choices := formatChoices(v); choices != "" {
+				varBuf.WriteString(" ")
+				varBuf.WriteString(choices)
+			}
 			if v.Description != "" {
 				varBuf.WriteString(": ")
 				varBuf.WriteString(f.colors.Documentation)
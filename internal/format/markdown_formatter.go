@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"unicode"
 
 	"github.com/sdlcforge/make-help/internal/model"
 	"github.com/sdlcforge/make-help/internal/richtext"
@@ -13,6 +14,10 @@ import (
 type MarkdownFormatter struct {
 	config *FormatterConfig
 	parser *richtext.Parser
+
+	// shared names the variables RenderHelp lists in its Variables section
+	// rather than under each target.
+	shared map[string]bool
 }
 
 // NewMarkdownFormatter creates a new MarkdownFormatter with the given configuration.
@@ -43,6 +48,55 @@ func escapeMarkdown(s string) string {
 	return replacer.Replace(s)
 }
 
+// githubSlug converts heading text to the anchor slug GitHub generates for it:
+// lowercase, spaces become hyphens, and punctuation other than '-' and '_' is dropped.
+func githubSlug(text string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r), r == '-', r == '_':
+			sb.WriteRune(r)
+		case r == ' ':
+			sb.WriteRune('-')
+		}
+	}
+	return sb.String()
+}
+
+// anchorSlugger hands out unique anchor slugs in document order, appending
+// -1, -2, ... to repeated slugs the same way GitHub does for duplicate headings.
+type anchorSlugger struct {
+	seen map[string]int
+}
+
+func newAnchorSlugger() *anchorSlugger {
+	return &anchorSlugger{seen: make(map[string]int)}
+}
+
+// slug returns the unique anchor for text and records it as used.
+func (s *anchorSlugger) slug(text string) string {
+	base := githubSlug(text)
+	n := s.seen[base]
+	s.seen[base]++
+	if n > 0 {
+		return fmt.Sprintf("%s-%d", base, n)
+	}
+	return base
+}
+
+// targetAnchor returns the anchor id for a target entry.
+// Targets are prefixed with "target-" so they never collide with category headings.
+func (s *anchorSlugger) targetAnchor(name string) string {
+	return s.slug("target-" + name)
+}
+
+// tocEntry records the anchor of a rendered category and its targets.
+type tocEntry struct {
+	category *model.Category
+	anchor   string
+	targets  []string
+}
+
 // RenderHelp generates the complete help output from a HelpModel in Markdown format.
 func (f *MarkdownFormatter) RenderHelp(helpModel *model.HelpModel, w io.Writer) error {
 	if helpModel == nil {
@@ -51,78 +105,302 @@ func (f *MarkdownFormatter) RenderHelp(helpModel *model.HelpModel, w io.Writer)
 
 	var buf strings.Builder
 
-	// Title
-	buf.WriteString("# Makefile Help\n\n")
-
-	// Usage section
-	buf.WriteString("## Usage\n\n")
-	buf.WriteString("```\n")
-	buf.WriteString("make [<target>...] [<ENV_VAR>=<value>...]\n")
-	buf.WriteString("```\n\n")
-
-	// File documentation section
-	if len(helpModel.FileDocs) > 0 {
-		// Render entry point file docs first
-		entryPointDocs := extractEntryPointDocs(helpModel.FileDocs)
-		if entryPointDocs != nil {
-			buf.WriteString("## Description\n\n")
-			for _, line := range entryPointDocs {
-				buf.WriteString(line)
-				buf.WriteString("\n")
-			}
+	// Title, and links to the project; unsafe URLs are left out as in HTML
+	title := helpTitle(helpModel)
+	buf.WriteString("# ")
+	buf.WriteString(escapeMarkdown(title))
+	buf.WriteString("\n\n")
+	var links []string
+	if isValidURL(helpModel.Homepage) {
+		links = append(links, "[Homepage]("+helpModel.Homepage+")")
+	}
+	if isValidURL(helpModel.Repo) {
+		links = append(links, "[Repository]("+helpModel.Repo+")")
+	}
+	if len(links) > 0 {
+		buf.WriteString(strings.Join(links, " · "))
+		buf.WriteString("\n\n")
+	}
+	if nav := f.config.Navigation; nav.categoryPage() {
+		buf.WriteString(markdownLinks(nav.Breadcrumbs, " › "))
+		buf.WriteString("\n\n")
+	}
+
+	// Headings are registered in document order so anchors match GitHub's slugs.
+	slugger := newAnchorSlugger()
+	slugger.slug(title)
+	if f.config.TOC {
+		slugger.slug("Contents")
+	}
+
+	// The body is rendered separately so the TOC can be written above it
+	// once all anchors are known.
+	var body strings.Builder
+	toc := f.renderBody(&body, helpModel, slugger)
+
+	if f.config.TOC {
+		f.renderTOC(&buf, toc)
+	}
+	buf.WriteString(body.String())
+
+	if nav := f.config.Navigation; nav.categoryPage() {
+		buf.WriteString(markdownLinks(nav.pagerLinks(), " · "))
+		buf.WriteString("\n\n")
+	}
+
+	if f.config.Provenance != nil {
+		fmt.Fprintf(&buf, "---\n\n_%s_\n", escapeMarkdown(f.config.Provenance.footerText()))
+	}
+
+	_, err := w.Write([]byte(buf.String()))
+	return err
+}
+
+// markdownLinks joins links with sep. Links without an Href are plain text.
+func markdownLinks(links []Link, sep string) string {
+	parts := make([]string, 0, len(links))
+	for _, link := range links {
+		if link.Href == "" {
+			parts = append(parts, escapeMarkdown(link.Text))
+		} else {
+			parts = append(parts, "["+escapeMarkdown(link.Text)+"]("+link.Href+")")
+		}
+	}
+	return strings.Join(parts, sep)
+}
+
+// renderTOC renders the table of contents, linking each category and target.
+// Uncategorized targets are listed at the top level.
+func (f *MarkdownFormatter) renderTOC(buf *strings.Builder, toc []tocEntry) {
+	if len(toc) == 0 {
+		return
+	}
+
+	buf.WriteString("## Contents\n\n")
+	for _, entry := range toc {
+		indent := ""
+		if entry.anchor != "" {
+			fmt.Fprintf(buf, "- [%s](#%s)\n", escapeMarkdown(entry.category.Name), entry.anchor)
+			indent = "  "
+		}
+		for i, target := range entry.category.Targets {
+			fmt.Fprintf(buf, "%s- [%s](#%s)\n", indent, escapeMarkdown(target.Name), entry.targets[i])
+		}
+	}
+	buf.WriteString("\n")
+}
+
+// renderBody renders everything below the title and returns the anchors
+// assigned to each category and target.
+func (f *MarkdownFormatter) renderBody(buf *strings.Builder, helpModel *model.HelpModel, slugger *anchorSlugger) []tocEntry {
+	// Usage section; category pages leave it to the index page
+	if !f.config.Navigation.categoryPage() {
+		slugger.slug("Usage")
+		buf.WriteString("## Usage\n\n")
+		buf.WriteString("```\n")
+		buf.WriteString(usageLine(helpModel))
+		buf.WriteString("\n```\n\n")
+	}
+	if len(helpModel.Examples) > 0 {
+		slugger.slug("Examples")
+		buf.WriteString("### Examples\n\n")
+		buf.WriteString("```\n")
+		for _, example := range helpModel.Examples {
+			buf.WriteString(example)
 			buf.WriteString("\n")
 		}
+		buf.WriteString("```\n\n")
+	}
 
-		// Render included files section
-		includedFiles := extractIncludedFiles(helpModel.FileDocs)
-		if len(includedFiles) > 0 {
-			buf.WriteString("## Included files\n\n")
-			for _, fileDoc := range includedFiles {
-				buf.WriteString("### ")
-				buf.WriteString(escapeMarkdown(fileDoc.SourceFile))
-				buf.WriteString("\n\n")
-				for _, line := range fileDoc.Documentation {
-					buf.WriteString(line)
-					buf.WriteString("\n")
-				}
-				buf.WriteString("\n")
-			}
+	// File documentation section: entry point docs first, then included
+	// files unless they are placed after the targets
+	if entryPointDocs := extractEntryPointDocs(helpModel.FileDocs); entryPointDocs != nil {
+		slugger.slug("Description")
+		buf.WriteString("## Description\n\n")
+		for _, line := range entryPointDocs {
+			buf.WriteString(line)
+			buf.WriteString("\n")
 		}
+		buf.WriteString("\n")
+	}
+	if !includedFilesAtBottom(f.config) {
+		f.renderIncludedFiles(buf, helpModel, slugger)
 	}
 
 	// Targets section
+	var toc []tocEntry
+	sharedVariables := collectSharedVariables(helpModel, f.config)
+	f.shared = sharedVariableNames(sharedVariables)
 	if len(helpModel.Categories) > 0 {
+		slugger.slug("Targets")
 		buf.WriteString("## Targets\n\n")
 
-		for _, category := range helpModel.Categories {
-			f.renderCategory(&buf, &category)
+		for i := range helpModel.Categories {
+			toc = append(toc, f.renderCategory(buf, &helpModel.Categories[i], slugger))
 		}
 	}
 
-	_, err := w.Write([]byte(buf.String()))
-	return err
+	// Category pages of split help (index page only)
+	if nav := f.config.Navigation; nav != nil && len(nav.Pages) > 0 {
+		slugger.slug("Categories")
+		buf.WriteString("## Categories\n\n")
+		for _, page := range nav.Pages {
+			fmt.Fprintf(buf, "- [%s](%s) (%s)\n", escapeMarkdown(page.Text), page.Href, page.targetCount())
+		}
+		buf.WriteString("\n")
+	}
+
+	// Variables section (shared variables only)
+	if len(sharedVariables) > 0 {
+		slugger.slug("Variables")
+		buf.WriteString("## Variables\n\n")
+		for _, v := range sharedVariables {
+			f.renderSharedVariable(buf, &v)
+		}
+		buf.WriteString("\n")
+	}
+
+	if includedFilesAtBottom(f.config) {
+		f.renderIncludedFiles(buf, helpModel, slugger)
+	}
+
+	// Footer documentation
+	if len(helpModel.FooterDocs) > 0 {
+		for _, line := range helpModel.FooterDocs {
+			buf.WriteString(line)
+			buf.WriteString("\n")
+		}
+		buf.WriteString("\n")
+	}
+
+	if f.config.Stats != nil {
+		buf.WriteString("_")
+		buf.WriteString(f.config.Stats.summaryText())
+		buf.WriteString("_\n\n")
+	}
+
+	return toc
+}
+
+// renderIncludedFiles renders the documentation of included files, each
+// limited to the configured depth.
+func (f *MarkdownFormatter) renderIncludedFiles(buf *strings.Builder, helpModel *model.HelpModel, slugger *anchorSlugger) {
+	includedFiles := includedFilesFor(helpModel, f.config)
+	if len(includedFiles) == 0 {
+		return
+	}
+	slugger.slug("Included files")
+	buf.WriteString("## Included files\n\n")
+	for _, fileDoc := range includedFiles {
+		slugger.slug(fileDoc.SourceFile)
+		buf.WriteString("### ")
+		buf.WriteString(escapeMarkdown(fileDoc.SourceFile))
+		buf.WriteString("\n\n")
+		lines, omitted := limitFileDocs(fileDoc.Documentation, f.config)
+		for _, line := range lines {
+			buf.WriteString(line)
+			buf.WriteString("\n")
+		}
+		if omitted > 0 {
+			buf.WriteString("\n_")
+			buf.WriteString(formatMoreLines(omitted))
+			buf.WriteString("_\n")
+		}
+		buf.WriteString("\n")
+	}
 }
 
 // renderCategory renders a single category with its targets in Markdown.
-func (f *MarkdownFormatter) renderCategory(buf *strings.Builder, category *model.Category) {
+func (f *MarkdownFormatter) renderCategory(buf *strings.Builder, category *model.Category, slugger *anchorSlugger) tocEntry {
+	entry := tocEntry{category: category}
+
 	// Render category name (if present)
 	if category.Name != model.UncategorizedCategoryName {
+		entry.anchor = slugger.slug(category.Name)
 		buf.WriteString("### ")
 		buf.WriteString(escapeMarkdown(category.Name))
 		buf.WriteString("\n\n")
 	}
 
-	// Render targets as a list
-	for _, target := range category.Targets {
-		f.renderTarget(buf, &target)
+	// Section introduction (if any)
+	if len(category.Documentation) > 0 {
+		for _, line := range category.Documentation {
+			buf.WriteString(line)
+			buf.WriteString("\n")
+		}
+		buf.WriteString("\n")
+	}
+
+	for i := range category.Targets {
+		entry.targets = append(entry.targets, slugger.targetAnchor(category.Targets[i].Name))
+	}
+
+	if f.config.MarkdownLayout == "table" {
+		f.renderTargetTable(buf, category.Targets, entry.targets)
+	} else {
+		// Render targets as a list
+		for i := range category.Targets {
+			f.renderTarget(buf, &category.Targets[i], entry.targets[i])
+		}
 	}
 
 	buf.WriteString("\n")
+	return entry
+}
+
+// escapeTableCell escapes pipe characters so cell content cannot split a table row.
+func escapeTableCell(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
+
+// renderTargetTable renders a category's targets as a Markdown table
+// with Target, Aliases, Description, and Variables columns.
+func (f *MarkdownFormatter) renderTargetTable(buf *strings.Builder, targets []model.Target, anchors []string) {
+	buf.WriteString("| Target | Aliases | Description | Variables |\n")
+	buf.WriteString("| --- | --- | --- | --- |\n")
+
+	for i, target := range targets {
+		// Target
+		buf.WriteString("| <a id=\"")
+		buf.WriteString(anchors[i])
+		buf.WriteString("\"></a>**")
+		buf.WriteString(escapeTableCell(escapeMarkdown(target.Name)))
+		buf.WriteString("** | ")
+
+		// Aliases
+		escapedAliases := make([]string, len(target.Aliases))
+		for j, alias := range target.Aliases {
+			escapedAliases[j] = escapeTableCell(escapeMarkdown(alias))
+		}
+		buf.WriteString(strings.Join(escapedAliases, ", "))
+		buf.WriteString(" | ")
+
+		// Description
+		if len(target.Summary) > 0 && target.Summary[0] != "" {
+			summaryRichText := f.parser.Parse(target.Summary[0])
+			buf.WriteString(escapeTableCell(summaryRichText.Markdown()))
+		}
+		buf.WriteString(" | ")
+
+		// Variables
+		for j, v := range ownVariables(&target, f.shared) {
+			if j > 0 {
+				buf.WriteString(", ")
+			}
+			buf.WriteString("`")
+			buf.WriteString(escapeTableCell(escapeMarkdown(v.Name)))
+			buf.WriteString("`")
+		}
+		buf.WriteString(" |\n")
+	}
 }
 
 // renderTarget renders a single target in Markdown.
-func (f *MarkdownFormatter) renderTarget(buf *strings.Builder, target *model.Target) {
-	buf.WriteString("- **")
+// The anchor is emitted inline so other documents can deep-link to the target.
+func (f *MarkdownFormatter) renderTarget(buf *strings.Builder, target *model.Target, anchor string) {
+	buf.WriteString("- <a id=\"")
+	buf.WriteString(anchor)
+	buf.WriteString("\"></a>**")
 	buf.WriteString(escapeMarkdown(target.Name))
 	buf.WriteString("**")
 
@@ -147,12 +425,39 @@ func (f *MarkdownFormatter) renderTarget(buf *strings.Builder, target *model.Tar
 		}
 	}
 
+	// Duration estimate (if any)
+	if duration := formatDuration(target); duration != "" {
+		buf.WriteString(" ")
+		buf.WriteString(escapeMarkdown(duration))
+	}
+
+	// Platform badge (if any)
+	if platforms := formatPlatforms(target); platforms != "" {
+		buf.WriteString(" `")
+		buf.WriteString(platforms)
+		buf.WriteString("`")
+	}
+
+	// Danger badge (if any)
+	if target.Dangerous {
+		buf.WriteString(" **")
+		buf.WriteString(dangerBadge)
+		buf.WriteString("**")
+	}
+
+	// New badge (if any)
+	if f.config.NewTargets[target.Name] {
+		buf.WriteString(" **")
+		buf.WriteString(newBadge)
+		buf.WriteString("**")
+	}
+
 	buf.WriteString("\n")
 
 	// Variables (if any)
-	if len(target.Variables) > 0 {
+	if variables := ownVariables(target, f.shared); len(variables) > 0 {
 		buf.WriteString("  - Variables: ")
-		for i, v := range target.Variables {
+		for i, v := range variables {
 			if i > 0 {
 				buf.WriteString(", ")
 			}
@@ -164,6 +469,35 @@ func (f *MarkdownFormatter) renderTarget(buf *strings.Builder, target *model.Tar
 	}
 }
 
+// renderSharedVariable renders a variable of the Variables section with
+// the targets that use it.
+func (f *MarkdownFormatter) renderSharedVariable(buf *strings.Builder, v *sharedVariable) {
+	buf.WriteString("- `")
+	buf.WriteString(escapeMarkdown(v.Name))
+	buf.WriteString("`")
+	if v.Required {
+		buf.WriteString(" *(required)*")
+	}
+	if choices := formatChoices(v.Variable); choices != "" {
+		buf.WriteString(" `")
+		buf.WriteString(choices)
+		buf.WriteString("`")
+	}
+	if v.Description != "" {
+		buf.WriteString(": ")
+		buf.WriteString(v.Description)
+	}
+	buf.WriteString("\n")
+
+	usedBy := make([]string, len(v.UsedBy))
+	for i, name := range v.UsedBy {
+		usedBy[i] = escapeMarkdown(name)
+	}
+	buf.WriteString("  - Used by: ")
+	buf.WriteString(strings.Join(usedBy, ", "))
+	buf.WriteString("\n")
+}
+
 // RenderDetailedTarget renders a detailed view of a single target in Markdown.
 func (f *MarkdownFormatter) RenderDetailedTarget(target *model.Target, w io.Writer) error {
 	if target == nil {
@@ -188,6 +522,53 @@ func (f *MarkdownFormatter) RenderDetailedTarget(target *model.Target, w io.Writ
 		buf.WriteString("\n\n")
 	}
 
+	// Owner
+	if target.Owner != "" {
+		buf.WriteString("**Owner:** ")
+		buf.WriteString(escapeMarkdown(target.Owner))
+		buf.WriteString("\n\n")
+	}
+
+	// CI workflows, linked relative to the help file
+	if len(target.CIWorkflows) > 0 {
+		buf.WriteString("**CI:** ")
+		for i, workflow := range target.CIWorkflows {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			fmt.Fprintf(&buf, "[%s](%s)", escapeMarkdown(workflow), workflow)
+		}
+		buf.WriteString("\n\n")
+	}
+
+	// Last change
+	if change, ok := f.config.LastChanges[target.Name]; ok {
+		buf.WriteString("*")
+		buf.WriteString(escapeMarkdown(change.text()))
+		buf.WriteString("*\n\n")
+	}
+
+	// Danger warning
+	if target.Dangerous {
+		buf.WriteString("**Danger:** ")
+		buf.WriteString(escapeMarkdown(formatDangerReason(target)))
+		buf.WriteString("\n\n")
+	}
+
+	// Platforms
+	if len(target.Platforms) > 0 {
+		buf.WriteString("**Platforms:** ")
+		buf.WriteString(escapeMarkdown(strings.Join(target.Platforms, ", ")))
+		buf.WriteString("\n\n")
+	}
+
+	// Duration estimate
+	if target.Duration != "" {
+		buf.WriteString("**Duration:** ")
+		buf.WriteString(escapeMarkdown(target.Duration))
+		buf.WriteString("\n\n")
+	}
+
 	// Variables
 	if len(target.Variables) > 0 {
 		buf.WriteString("**Variables:**\n\n")
@@ -195,6 +576,14 @@ func (f *MarkdownFormatter) RenderDetailedTarget(target *model.Target, w io.Writ
 			buf.WriteString("- `")
 			buf.WriteString(escapeMarkdown(v.Name))
 			buf.WriteString("`")
+			if v.Required {
+				buf.WriteString(" *(required)*")
+			}
+			if choices := formatChoices(v); choices != "" {
+				buf.WriteString(" `")
+				buf.WriteString(choices)
+				buf.WriteString("`")
+			}
 			if v.Description != "" {
 				buf.WriteString(": ")
 				buf.WriteString(v.Description)
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/sdlcforge/make-help/internal/model"
 )
@@ -69,10 +70,10 @@ func TestMarkdownFormatter_RenderHelp_WithTargets(t *testing.T) {
 	if !strings.Contains(output, "## Targets") {
 		t.Error("Output should contain targets section")
 	}
-	if !strings.Contains(output, "- **build**: Build the project.") {
+	if !strings.Contains(output, "- <a id=\"target-build\"></a>**build**: Build the project.") {
 		t.Error("Output should contain build target")
 	}
-	if !strings.Contains(output, "- **test**: Run all tests.") {
+	if !strings.Contains(output, "- <a id=\"target-test\"></a>**test**: Run all tests.") {
 		t.Error("Output should contain test target")
 	}
 }
@@ -257,7 +258,7 @@ func TestMarkdownFormatter_RenderHelp_WithAliases(t *testing.T) {
 	}
 
 	output := buf.String()
-	if !strings.Contains(output, "- **build** _(b, compile)_: Build the project.") {
+	if !strings.Contains(output, "- <a id=\"target-build\"></a>**build** _(b, compile)_: Build the project.") {
 		t.Error("Output should contain target with aliases in italics")
 	}
 }
@@ -476,10 +477,10 @@ func TestMarkdownFormatter_RenderHelp_SpecialCharacters(t *testing.T) {
 	output := buf.String()
 
 	// Test target names are escaped
-	if !strings.Contains(output, `- **build\*test**`) {
+	if !strings.Contains(output, `- <a id="target-buildtest"></a>**build\*test**`) {
 		t.Error("Target name with asterisks should be escaped")
 	}
-	if !strings.Contains(output, `- **test\_underscore**`) {
+	if !strings.Contains(output, `- <a id="target-test_underscore"></a>**test\_underscore**`) {
 		t.Error("Target name with underscores should be escaped")
 	}
 
@@ -652,10 +653,221 @@ func TestMarkdownFormatter_ComplexModel(t *testing.T) {
 		t.Error("Output should contain Test category")
 	}
 	// Targets
-	if !strings.Contains(output, "- **build** _(b)_: Build the project.") {
+	if !strings.Contains(output, "- <a id=\"target-build\"></a>**build** _(b)_: Build the project.") {
 		t.Error("Output should contain build target with alias")
 	}
 	if !strings.Contains(output, "Variables: `GOOS`, `GOARCH`") {
 		t.Error("Output should contain variables")
 	}
 }
+
+func TestGithubSlug(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"Build", "build"},
+		{"Build & Deploy", "build--deploy"},
+		{"docker-build", "docker-build"},
+		{"test_unit", "test_unit"},
+		{"build*test", "buildtest"},
+		{"Setup (local)", "setup-local"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+			if got := githubSlug(tt.input); got != tt.want {
+				t.Errorf("githubSlug(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarkdownFormatter_RenderHelp_TOC(t *testing.T) {
+	t.Parallel()
+	formatter := NewMarkdownFormatter(&FormatterConfig{TOC: true})
+
+	helpModel := &model.HelpModel{
+		HasCategories: true,
+		Categories: []model.Category{
+			{
+				Name: "Build",
+				Targets: []model.Target{
+					{Name: "build", Summary: []string{"Build the project."}},
+				},
+			},
+			{
+				// Collides with the "Usage" heading, so GitHub suffixes it
+				Name: "Usage",
+				Targets: []model.Target{
+					{Name: "docs", Summary: []string{"Build docs."}},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := formatter.RenderHelp(helpModel, &buf); err != nil {
+		t.Fatalf("RenderHelp() error = %v", err)
+	}
+
+	output := buf.String()
+
+	expectedTOC := "## Contents\n\n" +
+		"- [Build](#build)\n" +
+		"  - [build](#target-build)\n" +
+		"- [Usage](#usage-1)\n" +
+		"  - [docs](#target-docs)\n\n"
+	if !strings.Contains(output, expectedTOC) {
+		t.Errorf("Output should contain TOC:\n%s\ngot:\n%s", expectedTOC, output)
+	}
+	if strings.Index(output, "## Contents") > strings.Index(output, "## Usage") {
+		t.Error("TOC should appear before the Usage section")
+	}
+	if !strings.Contains(output, `- <a id="target-docs"></a>**docs**: Build docs.`) {
+		t.Error("Output should contain anchor for docs target")
+	}
+}
+
+func TestMarkdownFormatter_RenderHelp_NoTOCByDefault(t *testing.T) {
+	t.Parallel()
+	formatter := NewMarkdownFormatter(nil)
+
+	helpModel := &model.HelpModel{
+		Categories: []model.Category{
+			{
+				Name:    model.UncategorizedCategoryName,
+				Targets: []model.Target{{Name: "build"}},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := formatter.RenderHelp(helpModel, &buf); err != nil {
+		t.Fatalf("RenderHelp() error = %v", err)
+	}
+
+	if strings.Contains(buf.String(), "## Contents") {
+		t.Error("TOC should only be rendered when enabled")
+	}
+}
+
+func TestMarkdownFormatter_RenderHelp_Provenance(t *testing.T) {
+	t.Parallel()
+	formatter := NewMarkdownFormatter(&FormatterConfig{
+		Provenance: &Provenance{
+			Version: "1.2.0",
+			Commit:  "1a2b3c4",
+			Time:    time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC),
+		},
+	})
+
+	helpModel := &model.HelpModel{
+		Categories: []model.Category{
+			{
+				Name:    model.UncategorizedCategoryName,
+				Targets: []model.Target{{Name: "build"}},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := formatter.RenderHelp(helpModel, &buf); err != nil {
+		t.Fatalf("RenderHelp() error = %v", err)
+	}
+
+	want := "\n\n---\n\n_Generated by make-help 1.2.0 from commit 1a2b3c4 on 2026-01-02 15:04 UTC._\n"
+	if !strings.HasSuffix(buf.String(), want) {
+		t.Errorf("output should end with the provenance footer, got:\n%s", buf.String())
+	}
+}
+
+func TestMarkdownFormatter_RenderHelp_ConsolidateVariables(t *testing.T) {
+	t.Parallel()
+	formatter := NewMarkdownFormatter(&FormatterConfig{ConsolidateVariables: true})
+
+	helpModel := &model.HelpModel{
+		Categories: []model.Category{
+			{
+				Targets: []model.Target{
+					{
+						Name:      "build",
+						Summary:   []string{"Build the project."},
+						Variables: []model.Variable{{Name: "GOOS", Choices: []string{"linux", "darwin"}}, {Name: "CGO"}},
+					},
+					{
+						Name:      "test",
+						Summary:   []string{"Run the tests."},
+						Variables: []model.Variable{{Name: "GOOS", Required: true, Description: "Target OS"}},
+					},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := formatter.RenderHelp(helpModel, &buf); err != nil {
+		t.Fatalf("RenderHelp() error = %v", err)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, "  - Variables: `CGO`\n") || strings.Contains(output, "  - Variables: `GOOS`") {
+		t.Errorf("Targets should only list their own variables, got:\n%s", output)
+	}
+	expected := "## Variables\n\n" +
+		"- `GOOS` *(required)* `[linux|darwin]`: Target OS\n" +
+		"  - Used by: build, test\n"
+	if !strings.Contains(output, expected) {
+		t.Errorf("Output should contain:\n%s\ngot:\n%s", expected, output)
+	}
+}
+
+func TestMarkdownFormatter_RenderHelp_TableLayout(t *testing.T) {
+	t.Parallel()
+	formatter := NewMarkdownFormatter(&FormatterConfig{MarkdownLayout: "table"})
+
+	helpModel := &model.HelpModel{
+		HasCategories: true,
+		Categories: []model.Category{
+			{
+				Name: "Build",
+				Targets: []model.Target{
+					{
+						Name:    "build",
+						Aliases: []string{"b"},
+						Summary: []string{"Build the project."},
+						Variables: []model.Variable{
+							{Name: "GOOS"},
+							{Name: "GOARCH"},
+						},
+					},
+					{
+						Name:    "clean",
+						Summary: []string{"Remove a|b artifacts."},
+					},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := formatter.RenderHelp(helpModel, &buf); err != nil {
+		t.Fatalf("RenderHelp() error = %v", err)
+	}
+
+	output := buf.String()
+
+	expected := "### Build\n\n" +
+		"| Target | Aliases | Description | Variables |\n" +
+		"| --- | --- | --- | --- |\n" +
+		"| <a id=\"target-build\"></a>**build** | b | Build the project. | `GOOS`, `GOARCH` |\n" +
+		"| <a id=\"target-clean\"></a>**clean** |  | Remove a\\|b artifacts. |  |\n"
+	if !strings.Contains(output, expected) {
+		t.Errorf("Output should contain table:\n%s\ngot:\n%s", expected, output)
+	}
+	if strings.Contains(output, "- <a id=") {
+		t.Error("Table layout should not render bullet list entries")
+	}
+}
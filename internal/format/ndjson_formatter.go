@@ -0,0 +1,75 @@
+package format
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/sdlcforge/make-help/internal/model"
+)
+
+// NDJSONFormatter generates newline-delimited JSON: one compact JSON object
+// per target, written as each target is rendered. Unlike JSONFormatter, no
+// enclosing document is built, so consumers (jq, log pipelines) can process
+// very large models as a stream.
+//
+// Each line has the same shape as a target entry in JSON output.
+type NDJSONFormatter struct {
+	config *FormatterConfig
+}
+
+// NewNDJSONFormatter creates a new NDJSONFormatter with the given configuration.
+func NewNDJSONFormatter(config *FormatterConfig) *NDJSONFormatter {
+	config = normalizeConfig(config)
+
+	return &NDJSONFormatter{
+		config: config,
+	}
+}
+
+// RenderHelp writes one JSON object per target, in category order.
+// File documentation, the usage header, and the footer are not included;
+// use --format json for the full document.
+func (f *NDJSONFormatter) RenderHelp(helpModel *model.HelpModel, w io.Writer) error {
+	if helpModel == nil {
+		return errNilHelpModel("ndjson")
+	}
+
+	// json.Encoder writes a trailing newline after each value
+	encoder := json.NewEncoder(w)
+	for _, category := range helpModel.Categories {
+		for i := range category.Targets {
+			if err := encoder.Encode(newJSONTarget(&category.Targets[i], category.Name, helpModel.DefaultGoal)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// RenderDetailedTarget writes a single line with the detailed target view.
+func (f *NDJSONFormatter) RenderDetailedTarget(target *model.Target, w io.Writer) error {
+	if target == nil {
+		return errNilTarget("ndjson")
+	}
+
+	return json.NewEncoder(w).Encode(newJSONDetailedTarget(target))
+}
+
+// RenderBasicTarget writes a single line with minimal target info.
+func (f *NDJSONFormatter) RenderBasicTarget(name string, sourceFile string, lineNumber int, w io.Writer) error {
+	return json.NewEncoder(w).Encode(jsonBasicTarget{
+		Name:       name,
+		SourceFile: sourceFile,
+		LineNumber: lineNumber,
+	})
+}
+
+// ContentType returns the MIME type for NDJSON format.
+func (f *NDJSONFormatter) ContentType() string {
+	return "application/x-ndjson"
+}
+
+// DefaultExtension returns the default file extension for NDJSON format.
+func (f *NDJSONFormatter) DefaultExtension() string {
+	return ".ndjson"
+}
@@ -0,0 +1,106 @@
+package format
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/sdlcforge/make-help/internal/model"
+)
+
+func TestNDJSONFormatter_RenderHelp(t *testing.T) {
+	t.Parallel()
+	formatter := NewNDJSONFormatter(nil)
+	helpModel := &model.HelpModel{
+		DefaultGoal: "build",
+		Categories: []model.Category{
+			{
+				Name: "Build",
+				Targets: []model.Target{
+					{Name: "build", Summary: []string{"Build the project."}, IsPhony: true},
+					{Name: "clean", Summary: []string{"Remove artifacts."}, DiscoveryOrder: 1},
+				},
+			},
+			{
+				Name: "Test",
+				Targets: []model.Target{
+					{Name: "test", Variables: []model.Variable{{Name: "PKG"}}, DiscoveryOrder: 2},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := formatter.RenderHelp(helpModel, &buf); err != nil {
+		t.Fatalf("RenderHelp() error = %v", err)
+	}
+
+	var targets []jsonTarget
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var target jsonTarget
+		if err := json.Unmarshal(scanner.Bytes(), &target); err != nil {
+			t.Fatalf("Line %q is not valid JSON: %v", scanner.Text(), err)
+		}
+		targets = append(targets, target)
+	}
+
+	if len(targets) != 3 {
+		t.Fatalf("Got %d lines, want 3", len(targets))
+	}
+	if targets[0].Name != "build" || targets[0].Category != "Build" || !targets[0].IsDefault || !targets[0].IsPhony {
+		t.Errorf("First line = %+v, want default phony build in Build", targets[0])
+	}
+	if targets[2].Name != "test" || targets[2].Category != "Test" || len(targets[2].Variables) != 1 {
+		t.Errorf("Third line = %+v, want test in Test with one variable", targets[2])
+	}
+}
+
+func TestNDJSONFormatter_RenderDetailedTarget(t *testing.T) {
+	t.Parallel()
+	formatter := NewNDJSONFormatter(nil)
+	target := &model.Target{
+		Name:          "build",
+		Documentation: []string{"Build the project.", "Compiles everything."},
+	}
+
+	var buf bytes.Buffer
+	if err := formatter.RenderDetailedTarget(target, &buf); err != nil {
+		t.Fatalf("RenderDetailedTarget() error = %v", err)
+	}
+
+	output := buf.String()
+	if strings.Count(output, "\n") != 1 || !strings.HasSuffix(output, "\n") {
+		t.Errorf("Output should be exactly one line, got %q", output)
+	}
+	if !strings.Contains(output, `"documentation":["Build the project.","Compiles everything."]`) {
+		t.Errorf("Output should contain compact documentation, got %q", output)
+	}
+}
+
+func TestNDJSONFormatter_NilInputs(t *testing.T) {
+	t.Parallel()
+	formatter := NewNDJSONFormatter(nil)
+	var buf bytes.Buffer
+
+	if err := formatter.RenderHelp(nil, &buf); err == nil {
+		t.Error("RenderHelp(nil) should return an error")
+	}
+	if err := formatter.RenderDetailedTarget(nil, &buf); err == nil {
+		t.Error("RenderDetailedTarget(nil) should return an error")
+	}
+}
+
+func TestNDJSONFormatter_Metadata(t *testing.T) {
+	t.Parallel()
+	formatter := NewNDJSONFormatter(nil)
+
+	if got := formatter.ContentType(); got != "application/x-ndjson" {
+		t.Errorf("ContentType() = %q, want %q", got, "application/x-ndjson")
+	}
+	if got := formatter.DefaultExtension(); got != ".ndjson" {
+		t.Errorf("DefaultExtension() = %q, want %q", got, ".ndjson")
+	}
+}
@@ -0,0 +1,79 @@
+package format
+
+import (
+	"fmt"
+
+	"github.com/sdlcforge/make-help/internal/model"
+)
+
+// Page describes which slice of the targets a paginated help page holds.
+// JSON output reports it so consumers can request the following page;
+// HTML output shows it above the target list.
+type Page struct {
+	// Number is the 1-based page number.
+	Number int
+
+	// Size is the maximum number of targets per page.
+	Size int
+
+	// TotalTargets is the number of targets across all pages.
+	TotalTargets int
+}
+
+// TotalPages returns the number of pages needed for every target.
+func (p *Page) TotalPages() int {
+	if p.TotalTargets == 0 {
+		return 1
+	}
+	return (p.TotalTargets + p.Size - 1) / p.Size
+}
+
+// NextPage returns the number of the following page, or 0 on the last page.
+func (p *Page) NextPage() int {
+	if p.Number >= p.TotalPages() {
+		return 0
+	}
+	return p.Number + 1
+}
+
+// first returns the 1-based position of the page's first target.
+func (p *Page) first() int {
+	return (p.Number-1)*p.Size + 1
+}
+
+// last returns the 1-based position of the page's last target.
+func (p *Page) last() int {
+	return min(p.Number*p.Size, p.TotalTargets)
+}
+
+// Paginate returns a copy of helpModel holding only the targets of page
+// number (1-based) when targets are split into pages of size, in category
+// order. Categories with no targets on the page are dropped; file
+// documentation is kept on every page. helpModel is not modified.
+func Paginate(helpModel *model.HelpModel, number, size int) (*model.HelpModel, *Page, error) {
+	page := &Page{Number: number, Size: size}
+	for _, category := range helpModel.Categories {
+		page.TotalTargets += len(category.Targets)
+	}
+	if number > page.TotalPages() {
+		return nil, nil, fmt.Errorf("page %d is out of range (%d targets make %d page(s) of %d)",
+			number, page.TotalTargets, page.TotalPages(), size)
+	}
+
+	paged := *helpModel
+	paged.Categories = nil
+	start, end := page.first()-1, page.last()
+	position := 0
+	for _, category := range helpModel.Categories {
+		from := max(start-position, 0)
+		to := min(end-position, len(category.Targets))
+		position += len(category.Targets)
+		if from >= to {
+			continue
+		}
+		category.Targets = category.Targets[from:to]
+		paged.Categories = append(paged.Categories, category)
+	}
+
+	return &paged, page, nil
+}
@@ -0,0 +1,106 @@
+package format
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/sdlcforge/make-help/internal/model"
+)
+
+func paginationTestModel() *model.HelpModel {
+	return &model.HelpModel{
+		FileDocs: []model.FileDoc{{SourceFile: "Makefile", Documentation: []string{"Project."}, IsEntryPoint: true}},
+		Categories: []model.Category{
+			{Name: "Build", Targets: []model.Target{{Name: "build"}, {Name: "compile"}, {Name: "link"}}},
+			{Name: "Test", Targets: []model.Target{{Name: "test"}, {Name: "lint"}}},
+		},
+	}
+}
+
+func pageTargetNames(helpModel *model.HelpModel) []string {
+	var names []string
+	for _, category := range helpModel.Categories {
+		for _, target := range category.Targets {
+			names = append(names, category.Name+"/"+target.Name)
+		}
+	}
+	return names
+}
+
+func TestPaginate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		number   int
+		size     int
+		want     []string
+		nextPage int
+	}{
+		{name: "first page", number: 1, size: 2, want: []string{"Build/build", "Build/compile"}, nextPage: 2},
+		{name: "page spanning categories", number: 2, size: 2, want: []string{"Build/link", "Test/test"}, nextPage: 3},
+		{name: "last partial page", number: 3, size: 2, want: []string{"Test/lint"}, nextPage: 0},
+		{name: "single page", number: 1, size: 10, want: []string{"Build/build", "Build/compile", "Build/link", "Test/test", "Test/lint"}, nextPage: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			helpModel := paginationTestModel()
+			paged, page, err := Paginate(helpModel, tt.number, tt.size)
+			if err != nil {
+				t.Fatalf("Paginate() error = %v", err)
+			}
+			if got := pageTargetNames(paged); !slices.Equal(got, tt.want) {
+				t.Errorf("targets = %v, want %v", got, tt.want)
+			}
+			if page.TotalTargets != 5 {
+				t.Errorf("TotalTargets = %d, want 5", page.TotalTargets)
+			}
+			if page.NextPage() != tt.nextPage {
+				t.Errorf("NextPage() = %d, want %d", page.NextPage(), tt.nextPage)
+			}
+			if len(paged.FileDocs) != 1 {
+				t.Errorf("file docs should be kept on every page, got %d", len(paged.FileDocs))
+			}
+			// The input model is not modified
+			if len(pageTargetNames(helpModel)) != 5 {
+				t.Errorf("Paginate() modified the input model")
+			}
+		})
+	}
+}
+
+func TestPaginate_OutOfRange(t *testing.T) {
+	t.Parallel()
+	_, _, err := Paginate(paginationTestModel(), 4, 2)
+	if err == nil || !strings.Contains(err.Error(), "page 4 is out of range") {
+		t.Errorf("expected out of range error, got %v", err)
+	}
+}
+
+func TestPaginate_RenderedPageMetadata(t *testing.T) {
+	t.Parallel()
+	paged, page, err := Paginate(paginationTestModel(), 2, 2)
+	if err != nil {
+		t.Fatalf("Paginate() error = %v", err)
+	}
+
+	var jsonOut strings.Builder
+	if err := NewJSONFormatter(&FormatterConfig{Page: page}).RenderHelp(paged, &jsonOut); err != nil {
+		t.Fatalf("JSON RenderHelp() error = %v", err)
+	}
+	for _, want := range []string{`"number": 2`, `"totalTargets": 5`, `"totalPages": 3`, `"nextPage": 3`} {
+		if !strings.Contains(jsonOut.String(), want) {
+			t.Errorf("JSON output missing %s:\n%s", want, jsonOut.String())
+		}
+	}
+
+	var htmlOut strings.Builder
+	if err := NewHTMLFormatter(&FormatterConfig{Page: page}).RenderHelp(paged, &htmlOut); err != nil {
+		t.Fatalf("HTML RenderHelp() error = %v", err)
+	}
+	if want := `<p class="page">Page 2 of 3 (targets 3&ndash;4 of 5)</p>`; !strings.Contains(htmlOut.String(), want) {
+		t.Errorf("HTML output missing %s", want)
+	}
+}
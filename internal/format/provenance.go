@@ -0,0 +1,58 @@
+package format
+
+import (
+	"strings"
+	"time"
+)
+
+// Provenance identifies how rendered documentation was generated, so a stale
+// published page can be traced back to its source. Markdown and HTML output
+// end with a footer built from it.
+type Provenance struct {
+	// Version is the make-help version.
+	Version string
+
+	// Commit is the source commit the Makefiles were read from.
+	// Empty omits it.
+	Commit string
+
+	// Time is when the output was generated. The zero time omits it.
+	Time time.Time
+}
+
+// footerText returns the footer sentence, e.g.
+// "Generated by make-help 1.2.0 from commit 1a2b3c4 on 2026-01-02 15:04 UTC."
+func (p *Provenance) footerText() string {
+	var sb strings.Builder
+	sb.WriteString("Generated by make-help")
+	if p.Version != "" {
+		sb.WriteString(" ")
+		sb.WriteString(p.Version)
+	}
+	if p.Commit != "" {
+		sb.WriteString(" from commit ")
+		sb.WriteString(p.Commit)
+	}
+	if !p.Time.IsZero() {
+		sb.WriteString(" on ")
+		sb.WriteString(p.Time.UTC().Format("2006-01-02 15:04 UTC"))
+	}
+	sb.WriteString(".")
+	return sb.String()
+}
+
+// Change is the last version control change to a target's rule line, shown
+// with --git-blame.
+type Change struct {
+	// Author is the name of the author of the change.
+	Author string
+
+	// Time is when the change was committed.
+	Time time.Time
+}
+
+// text returns the change as a sentence fragment, e.g.
+// "Last changed by Ada Lovelace on 2026-01-02".
+func (c Change) text() string {
+	return "Last changed by " + c.Author + " on " + c.Time.UTC().Format(time.DateOnly)
+}
@@ -0,0 +1,426 @@
+package format
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sdlcforge/make-help/internal/inject"
+	"github.com/sdlcforge/make-help/internal/model"
+	"github.com/sdlcforge/make-help/internal/richtext"
+)
+
+// Round-trip properties: output read back must reproduce what the model
+// holds, so a formatter change that drops data fails here for some model
+// even when the golden fixture does not exercise it.
+
+// roundTripModels is how many random models each property checks.
+const roundTripModels = 200
+
+// roundTripWords are the words random documentation is built from.
+var roundTripWords = []string{"build", "the", "project", "run", "tests", "deploy", "image", "cache", "all", "docs", "release", "lint"}
+
+// randomName returns a target-like name of lowercase letters, digits, and
+// the punctuation make allows in names.
+func randomName(rng *rand.Rand) string {
+	const first = "abcdefghijklmnopqrstuvwxyz"
+	const rest = "abcdefghijklmnopqrstuvwxyz0123456789._-"
+	name := []byte{first[rng.Intn(len(first))]}
+	for i := rng.Intn(10); i > 0; i-- {
+		name = append(name, rest[rng.Intn(len(rest))])
+	}
+	return string(name)
+}
+
+// randomSentence returns a sentence of roundTripWords, some of them bold,
+// italic, code, or links.
+func randomSentence(rng *rand.Rand) string {
+	words := make([]string, 1+rng.Intn(8))
+	for i := range words {
+		word := roundTripWords[rng.Intn(len(roundTripWords))]
+		switch rng.Intn(8) {
+		case 0:
+			word = "**" + word + "**"
+		case 1:
+			word = "*" + word + "*"
+		case 2:
+			word = "`" + word + "`"
+		case 3:
+			word = "[" + word + "](https://example.com/" + word + ")"
+		}
+		words[i] = word
+	}
+	sentence := strings.Join(words, " ")
+	return strings.ToUpper(sentence[:1]) + sentence[1:] + "."
+}
+
+// randomList returns up to max distinct random names, or nil.
+func randomList(rng *rand.Rand, max int) []string {
+	var list []string
+	seen := make(map[string]bool)
+	for i := rng.Intn(max + 1); i > 0; i-- {
+		name := randomName(rng)
+		if !seen[name] {
+			seen[name] = true
+			list = append(list, name)
+		}
+	}
+	return list
+}
+
+// randomHelpModel returns a help model with random file documentation,
+// categories, and targets. Target names are unique.
+func randomHelpModel(rng *rand.Rand) *model.HelpModel {
+	helpModel := &model.HelpModel{}
+	for i := rng.Intn(3); i >= 0; i-- {
+		fileDoc := model.FileDoc{
+			SourceFile:   fmt.Sprintf("make/%s.mk", randomName(rng)),
+			IsEntryPoint: len(helpModel.FileDocs) == 0,
+		}
+		for j := rng.Intn(3); j > 0; j-- {
+			fileDoc.Documentation = append(fileDoc.Documentation, randomSentence(rng))
+		}
+		if rng.Intn(2) == 0 {
+			fileDoc.Owner = randomName(rng)
+		}
+		helpModel.FileDocs = append(helpModel.FileDocs, fileDoc)
+	}
+
+	names := make(map[string]bool)
+	order := 0
+	for i := rng.Intn(4); i >= 0; i-- {
+		category := model.Category{Name: strings.ToUpper(randomName(rng)[:1]) + randomName(rng)}
+		if i == 0 && rng.Intn(2) == 0 {
+			category.Name = model.UncategorizedCategoryName
+		}
+		if rng.Intn(3) == 0 {
+			category.Documentation = []string{randomSentence(rng)}
+		}
+		for j := rng.Intn(6); j >= 0; j-- {
+			name := randomName(rng)
+			if names[name] {
+				continue
+			}
+			names[name] = true
+			order++
+			category.Targets = append(category.Targets, randomTarget(rng, name, order))
+		}
+		helpModel.Categories = append(helpModel.Categories, category)
+	}
+
+	if rng.Intn(2) == 0 {
+		for name := range names {
+			helpModel.DefaultGoal = name
+			break
+		}
+	}
+	return helpModel
+}
+
+// randomTarget returns a target named name with random documentation and
+// directives.
+func randomTarget(rng *rand.Rand, name string, order int) model.Target {
+	target := model.Target{
+		Name:           name,
+		Aliases:        randomList(rng, 2),
+		DiscoveryOrder: order,
+		SourceFile:     "Makefile",
+		LineNumber:     1 + rng.Intn(500),
+		IsPhony:        rng.Intn(2) == 0,
+		Tags:           randomList(rng, 2),
+		Hidden:         rng.Intn(5) == 0,
+		Profiles:       randomList(rng, 2),
+	}
+	for i := 1 + rng.Intn(3); i > 0; i-- {
+		target.Documentation = append(target.Documentation, randomSentence(rng))
+	}
+	target.Summary = []string{target.Documentation[0]}
+	if rng.Intn(4) == 0 {
+		target.Deprecated = true
+		target.DeprecationMessage = randomSentence(rng)
+	}
+	if rng.Intn(4) == 0 {
+		target.Platforms = []string{"linux", "darwin"}[:1+rng.Intn(2)]
+	}
+	if rng.Intn(4) == 0 {
+		target.Duration = fmt.Sprintf("~%dm", 1+rng.Intn(30))
+	}
+	if rng.Intn(4) == 0 {
+		target.Dangerous = true
+		target.DangerReason = randomSentence(rng)
+	}
+	if rng.Intn(3) == 0 {
+		target.Owner = randomName(rng)
+	}
+	if rng.Intn(4) == 0 {
+		target.CIWorkflows = []string{".github/workflows/" + randomName(rng) + ".yml"}
+	}
+	for i := rng.Intn(3); i > 0; i-- {
+		variable := model.Variable{
+			Name:        strings.ToUpper(randomName(rng)),
+			Description: randomSentence(rng),
+			Required:    rng.Intn(2) == 0,
+		}
+		if rng.Intn(3) == 0 {
+			variable.Choices = randomList(rng, 3)
+		}
+		target.Variables = append(target.Variables, variable)
+	}
+	return target
+}
+
+// jsonView returns the parts of helpModel that JSON help output carries.
+func jsonView(helpModel *model.HelpModel) *model.HelpModel {
+	view := &model.HelpModel{}
+	for _, fileDoc := range helpModel.FileDocs {
+		if fileDoc.IsEntryPoint {
+			if len(fileDoc.Documentation) > 0 || fileDoc.Owner != "" {
+				view.FileDocs = append(view.FileDocs, model.FileDoc{
+					IsEntryPoint:  true,
+					Documentation: fileDoc.Documentation,
+					Owner:         fileDoc.Owner,
+				})
+			}
+			continue
+		}
+		if len(fileDoc.Documentation) > 0 {
+			view.FileDocs = append(view.FileDocs, model.FileDoc{
+				SourceFile:    fileDoc.SourceFile,
+				Documentation: fileDoc.Documentation,
+				Owner:         fileDoc.Owner,
+			})
+		}
+	}
+	for _, category := range helpModel.Categories {
+		viewCategory := model.Category{Name: category.Name, Documentation: category.Documentation}
+		for _, target := range category.Targets {
+			target.Documentation = nil
+			target.ExplicitSummary = ""
+			viewCategory.Targets = append(viewCategory.Targets, target)
+		}
+		view.Categories = append(view.Categories, viewCategory)
+	}
+	view.DefaultGoal = helpModel.DefaultGoal
+	return view
+}
+
+// modelFromJSON reads JSON help output back into a help model.
+func modelFromJSON(t *testing.T, output jsonHelpOutput) *model.HelpModel {
+	t.Helper()
+	helpModel := &model.HelpModel{}
+	if output.Description != "" || output.Owner != "" {
+		helpModel.FileDocs = append(helpModel.FileDocs, model.FileDoc{
+			IsEntryPoint:  true,
+			Documentation: splitLines(output.Description),
+			Owner:         output.Owner,
+		})
+	}
+	for _, file := range output.IncludedFiles {
+		helpModel.FileDocs = append(helpModel.FileDocs, model.FileDoc{
+			SourceFile:    file.Path,
+			Documentation: splitLines(file.Description),
+			Owner:         file.Owner,
+		})
+	}
+	for _, jsonCat := range output.Categories {
+		category := model.Category{Name: jsonCat.Name, Documentation: splitLines(jsonCat.Description)}
+		for _, jsonTgt := range jsonCat.Targets {
+			assert.Equal(t, jsonCat.Name, jsonTgt.Category, "category of %s", jsonTgt.Name)
+			if jsonTgt.IsDefault {
+				helpModel.DefaultGoal = jsonTgt.Name
+			}
+			target := model.Target{
+				Name:               jsonTgt.Name,
+				Aliases:            jsonTgt.Aliases,
+				Tags:               jsonTgt.Tags,
+				Platforms:          jsonTgt.Platforms,
+				Duration:           jsonTgt.Duration,
+				Profiles:           jsonTgt.Profiles,
+				IsPhony:            jsonTgt.IsPhony,
+				Deprecated:         jsonTgt.Deprecated,
+				DeprecationMessage: jsonTgt.DeprecationMessage,
+				Hidden:             jsonTgt.Hidden,
+				Dangerous:          jsonTgt.Dangerous,
+				DangerReason:       jsonTgt.DangerReason,
+				Owner:              jsonTgt.Owner,
+				CIWorkflows:        jsonTgt.CIWorkflows,
+				DiscoveryOrder:     jsonTgt.DiscoveryOrder,
+				SourceFile:         jsonTgt.SourceFile,
+				LineNumber:         jsonTgt.LineNumber,
+			}
+			if jsonTgt.Summary != "" {
+				target.Summary = []string{jsonTgt.Summary}
+			}
+			for _, v := range jsonTgt.Variables {
+				target.Variables = append(target.Variables, model.Variable{
+					Name:        v.Name,
+					Description: v.Description,
+					Required:    v.Required,
+					Choices:     v.Choices,
+				})
+			}
+			category.Targets = append(category.Targets, target)
+		}
+		helpModel.Categories = append(helpModel.Categories, category)
+	}
+	return helpModel
+}
+
+// splitLines splits text joined with newlines, returning nil for "".
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(text, "\n")
+}
+
+func TestRoundTrip_JSON(t *testing.T) {
+	t.Parallel()
+	rng := rand.New(rand.NewSource(1))
+
+	for i := 0; i < roundTripModels; i++ {
+		helpModel := randomHelpModel(rng)
+
+		var buf bytes.Buffer
+		require.NoError(t, NewJSONFormatter(nil).RenderHelp(helpModel, &buf))
+		var output jsonHelpOutput
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &output), buf.String())
+
+		require.Equal(t, jsonView(helpModel), modelFromJSON(t, output), "model %d", i)
+	}
+}
+
+func TestRoundTrip_JSONDetailedTarget(t *testing.T) {
+	t.Parallel()
+	rng := rand.New(rand.NewSource(2))
+
+	for i := 0; i < roundTripModels; i++ {
+		target := randomTarget(rng, randomName(rng), i)
+
+		var buf bytes.Buffer
+		require.NoError(t, NewJSONFormatter(nil).RenderDetailedTarget(&target, &buf))
+		var output jsonDetailedTarget
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &output), buf.String())
+
+		assert.Equal(t, target.Name, output.Name)
+		assert.Equal(t, target.Summary[0], output.Summary)
+		assert.Equal(t, target.Documentation, output.Documentation)
+		assert.Equal(t, target.Aliases, output.Aliases)
+		assert.Equal(t, newJSONVariables(target.Variables), output.Variables)
+	}
+}
+
+// markdownTarget is a target entry read back from Markdown help.
+type markdownTarget struct {
+	Aliases []string
+	Summary string
+}
+
+// markdownUnescaper undoes escapeMarkdown.
+var markdownUnescaper = strings.NewReplacer(`\*`, `*`, `\_`, `_`, "\\`", "`", `\[`, `[`, `\]`, `]`, `\(`, `(`, `\)`, `)`, `\#`, `#`)
+
+// readMarkdownTargets reads the target entries of Markdown help in list or
+// table layout, keyed by name.
+func readMarkdownTargets(t *testing.T, markdown string) map[string]markdownTarget {
+	t.Helper()
+	targets := make(map[string]markdownTarget)
+	for _, line := range strings.Split(markdown, "\n") {
+		var entry string
+		var table bool
+		if rest, ok := strings.CutPrefix(line, "- <a id=\""); ok {
+			entry = rest
+		} else if rest, ok := strings.CutPrefix(line, "| <a id=\""); ok {
+			entry, table = rest, true
+		} else {
+			continue
+		}
+		_, entry, _ = strings.Cut(entry, "</a>**")
+		name, rest, ok := strings.Cut(entry, "**")
+		require.True(t, ok, "unterminated target name in %q", line)
+		name = markdownUnescaper.Replace(name)
+
+		var target markdownTarget
+		if table {
+			cells := strings.Split(strings.ReplaceAll(rest, `\|`, "\x00"), " | ")
+			require.Len(t, cells, 4, "table row %q", line)
+			if cells[1] != "" {
+				for _, alias := range strings.Split(cells[1], ", ") {
+					target.Aliases = append(target.Aliases, markdownUnescaper.Replace(alias))
+				}
+			}
+			target.Summary = strings.ReplaceAll(cells[2], "\x00", "|")
+		} else {
+			if aliases, after, ok := strings.Cut(strings.TrimPrefix(rest, " _("), ")_"); ok && strings.HasPrefix(rest, " _(") {
+				for _, alias := range strings.Split(aliases, ", ") {
+					target.Aliases = append(target.Aliases, markdownUnescaper.Replace(alias))
+				}
+				rest = after
+			}
+			// Badges follow the summary in a fixed order
+			rest = strings.TrimSuffix(rest, " **"+dangerBadge+"**")
+			if i := strings.LastIndex(rest, " `["); i >= 0 && strings.HasSuffix(rest, "]`") {
+				rest = rest[:i]
+			}
+			if i := strings.LastIndex(rest, ` \(`); i >= 0 && strings.HasSuffix(rest, `\)`) {
+				rest = rest[:i]
+			}
+			target.Summary = strings.TrimPrefix(rest, ": ")
+		}
+		targets[name] = target
+	}
+	return targets
+}
+
+// extractInjectedSection returns the content between the inject markers.
+func extractInjectedSection(t *testing.T, doc string) string {
+	t.Helper()
+	_, section, ok := strings.Cut(doc, inject.StartMarker+"\n")
+	require.True(t, ok, "no start marker in %q", doc)
+	section, _, ok = strings.Cut(section, inject.EndMarker)
+	require.True(t, ok, "no end marker in %q", doc)
+	return section
+}
+
+func TestRoundTrip_MarkdownSummaries(t *testing.T) {
+	t.Parallel()
+	parser := richtext.NewParser()
+
+	for _, layout := range []string{"list", "table"} {
+		rng := rand.New(rand.NewSource(3))
+		for i := 0; i < roundTripModels; i++ {
+			helpModel := randomHelpModel(rng)
+
+			var buf bytes.Buffer
+			formatter := NewMarkdownFormatter(&FormatterConfig{MarkdownLayout: layout})
+			require.NoError(t, formatter.RenderHelp(helpModel, &buf))
+
+			// Read it back the way it is published: injected into a README
+			readme, err := inject.Apply("# Project\n\nIntroduction.\n", buf.String())
+			require.NoError(t, err)
+			reapplied, err := inject.Apply(readme, buf.String())
+			require.NoError(t, err)
+			require.Equal(t, readme, reapplied, "injecting the same help twice changed the README")
+			section := extractInjectedSection(t, readme)
+			require.Equal(t, buf.String(), section)
+
+			read := readMarkdownTargets(t, section)
+			for _, category := range helpModel.Categories {
+				for _, target := range category.Targets {
+					entry, ok := read[target.Name]
+					if !assert.True(t, ok, "%s layout, model %d: target %q missing", layout, i, target.Name) {
+						continue
+					}
+					assert.Equal(t, target.Aliases, entry.Aliases, "%s layout, model %d: aliases of %q", layout, i, target.Name)
+					assert.Equal(t, parser.Parse(target.Summary[0]), parser.Parse(entry.Summary),
+						"%s layout, model %d: summary of %q", layout, i, target.Name)
+				}
+			}
+		}
+	}
+}
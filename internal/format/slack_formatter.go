@@ -0,0 +1,306 @@
+package format
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/sdlcforge/make-help/internal/model"
+	"github.com/sdlcforge/make-help/internal/richtext"
+)
+
+// slackSectionLimit is the maximum length of a section block's text.
+const slackSectionLimit = 3000
+
+// SlackFormatter generates a digest of the available targets in Slack's
+// mrkdwn dialect, for bots posting to chat channels. mrkdwn has no headings
+// and no nested formatting, so categories are bold lines and each target is
+// a single bullet. With SlackBlocks set, the digest is wrapped in Block Kit
+// section blocks instead.
+type SlackFormatter struct {
+	config *FormatterConfig
+	parser *richtext.Parser
+}
+
+// NewSlackFormatter creates a new SlackFormatter with the given configuration.
+func NewSlackFormatter(config *FormatterConfig) *SlackFormatter {
+	config = normalizeConfig(config)
+
+	return &SlackFormatter{
+		config: config,
+		parser: richtext.NewParser(),
+	}
+}
+
+// escapeSlack escapes the characters Slack treats as control sequences.
+func escapeSlack(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
+
+// slackCode renders s as inline code. mrkdwn has no way to escape a
+// backtick inside code, so backticks are replaced with quotes.
+func slackCode(s string) string {
+	return "`" + escapeSlack(strings.ReplaceAll(s, "`", "'")) + "`"
+}
+
+// renderRichText converts RichText segments to mrkdwn. Formatting is not
+// nested: the content of each segment is escaped as plain text.
+func (f *SlackFormatter) renderRichText(rt richtext.RichText) string {
+	var buf strings.Builder
+	for _, seg := range rt {
+		switch seg.Type {
+		case richtext.SegmentBold:
+			buf.WriteString("*" + escapeSlack(seg.Content) + "*")
+		case richtext.SegmentItalic:
+			buf.WriteString("_" + escapeSlack(seg.Content) + "_")
+		case richtext.SegmentCode:
+			buf.WriteString(slackCode(seg.Content))
+		case richtext.SegmentLink:
+			if isValidURL(seg.URL) {
+				buf.WriteString("<" + escapeSlack(seg.URL) + "|" + escapeSlack(seg.Content) + ">")
+			} else {
+				buf.WriteString(escapeSlack(seg.Content))
+			}
+		default:
+			buf.WriteString(escapeSlack(seg.Content))
+		}
+	}
+	return buf.String()
+}
+
+// targetLine renders a target as a digest bullet:
+// "• `build` (b) - Build the project."
+func (f *SlackFormatter) targetLine(target *model.Target) string {
+	var sb strings.Builder
+	sb.WriteString("• ")
+	sb.WriteString(slackCode(target.Name))
+	if len(target.Aliases) > 0 {
+		sb.WriteString(" (")
+		sb.WriteString(escapeSlack(strings.Join(target.Aliases, ", ")))
+		sb.WriteString(")")
+	}
+	if len(target.Summary) > 0 && target.Summary[0] != "" {
+		sb.WriteString(" - ")
+		sb.WriteString(f.renderRichText(f.parser.Parse(target.Summary[0])))
+	}
+	if target.Deprecated {
+		sb.WriteString(" _(deprecated)_")
+	}
+	if target.Dangerous {
+		sb.WriteString(" *" + dangerBadge + "*")
+	}
+	return sb.String()
+}
+
+// digestSections returns the digest as a list of mrkdwn sections: the
+// usage line and examples when the Makefile sets them, the project
+// description, one per category, then the footer. The default usage line is
+// left out to keep the digest short.
+func (f *SlackFormatter) digestSections(helpModel *model.HelpModel) []string {
+	var sections []string
+
+	if helpModel.Usage != "" || len(helpModel.Examples) > 0 {
+		lines := []string{"Usage: " + slackCode(usageLine(helpModel))}
+		if len(helpModel.Examples) > 0 {
+			lines = append(lines, "Examples:")
+			for _, example := range helpModel.Examples {
+				lines = append(lines, "• "+slackCode(example))
+			}
+		}
+		sections = append(sections, strings.Join(lines, "\n"))
+	}
+
+	if docs := extractEntryPointDocs(helpModel.FileDocs); docs != nil {
+		var lines []string
+		for _, line := range docs {
+			lines = append(lines, f.renderRichText(f.parser.Parse(line)))
+		}
+		sections = append(sections, strings.Join(lines, "\n"))
+	}
+
+	for _, category := range helpModel.Categories {
+		var lines []string
+		if category.Name != model.UncategorizedCategoryName {
+			lines = append(lines, "*"+escapeSlack(category.Name)+"*")
+		}
+		for i := range category.Targets {
+			lines = append(lines, f.targetLine(&category.Targets[i]))
+		}
+		sections = append(sections, strings.Join(lines, "\n"))
+	}
+
+	if len(helpModel.FooterDocs) > 0 {
+		var lines []string
+		for _, line := range helpModel.FooterDocs {
+			lines = append(lines, f.renderRichText(f.parser.Parse(line)))
+		}
+		sections = append(sections, strings.Join(lines, "\n"))
+	}
+
+	return sections
+}
+
+// RenderHelp generates the target digest.
+func (f *SlackFormatter) RenderHelp(helpModel *model.HelpModel, w io.Writer) error {
+	if helpModel == nil {
+		return errNilHelpModel("slack")
+	}
+
+	sections := f.digestSections(helpModel)
+	if f.config.SlackBlocks {
+		return writeSlackBlocks(w, "Available make targets", sections)
+	}
+
+	var buf strings.Builder
+	buf.WriteString("*Available make targets*\n")
+	for _, section := range sections {
+		buf.WriteString("\n")
+		buf.WriteString(section)
+		buf.WriteString("\n")
+	}
+	_, err := io.WriteString(w, buf.String())
+	return err
+}
+
+// RenderDetailedTarget renders a single target with its documentation and variables.
+func (f *SlackFormatter) RenderDetailedTarget(target *model.Target, w io.Writer) error {
+	if target == nil {
+		return errNilTarget("slack")
+	}
+
+	var lines []string
+	if len(target.Aliases) > 0 {
+		lines = append(lines, "Aliases: "+escapeSlack(strings.Join(target.Aliases, ", ")))
+	}
+	if target.Deprecated {
+		deprecated := "_Deprecated._"
+		if target.DeprecationMessage != "" {
+			deprecated += " " + f.renderRichText(f.parser.Parse(target.DeprecationMessage))
+		}
+		lines = append(lines, deprecated)
+	}
+	if target.Owner != "" {
+		lines = append(lines, "Owner: "+escapeSlack(target.Owner))
+	}
+	if len(target.CIWorkflows) > 0 {
+		lines = append(lines, "CI: "+escapeSlack(strings.Join(target.CIWorkflows, ", ")))
+	}
+	if change, ok := f.config.LastChanges[target.Name]; ok {
+		lines = append(lines, "_"+escapeSlack(change.text())+"_")
+	}
+	if target.Dangerous {
+		lines = append(lines, "*Danger:* "+escapeSlack(formatDangerReason(target)))
+	}
+	for _, line := range target.Documentation {
+		lines = append(lines, f.renderRichText(f.parser.Parse(line)))
+	}
+	for _, v := range target.Variables {
+		line := "• " + slackCode(v.Name)
+		if v.Required {
+			line += " _(required)_"
+		}
+		if choices := formatChoices(v); choices != "" {
+			line += " " + slackCode(choices)
+		}
+		if v.Description != "" {
+			line += " - " + escapeSlack(v.Description)
+		}
+		lines = append(lines, line)
+	}
+	if target.SourceFile != "" {
+		relPath := makeRelativePath(target.SourceFile, f.config.MakefileDir)
+		lines = append(lines, "Source: "+slackCode(fmt.Sprintf("%s:%d", relPath, target.LineNumber)))
+	}
+
+	return f.writeTarget(w, target.Name, strings.Join(lines, "\n"))
+}
+
+// RenderBasicTarget renders minimal info for a target without documentation.
+func (f *SlackFormatter) RenderBasicTarget(name string, sourceFile string, lineNumber int, w io.Writer) error {
+	body := "_No documentation available._"
+	if sourceFile != "" {
+		relPath := makeRelativePath(sourceFile, f.config.MakefileDir)
+		body += "\nSource: " + slackCode(fmt.Sprintf("%s:%d", relPath, lineNumber))
+	}
+	return f.writeTarget(w, name, body)
+}
+
+// writeTarget writes a single-target view as mrkdwn or blocks.
+func (f *SlackFormatter) writeTarget(w io.Writer, name, body string) error {
+	if f.config.SlackBlocks {
+		return writeSlackBlocks(w, "make "+name, []string{body})
+	}
+	_, err := fmt.Fprintf(w, "*make %s*\n%s\n", escapeSlack(name), body)
+	return err
+}
+
+// slackBlock is a Block Kit block; only header and section blocks are used.
+type slackBlock struct {
+	Type string         `json:"type"`
+	Text slackBlockText `json:"text"`
+}
+
+// slackBlockText is a Block Kit text object.
+type slackBlockText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// writeSlackBlocks writes a {"blocks": [...]} message payload with a header
+// block and one section block per section. Sections longer than Slack's
+// limit are split at line boundaries.
+func writeSlackBlocks(w io.Writer, header string, sections []string) error {
+	blocks := []slackBlock{{Type: "header", Text: slackBlockText{Type: "plain_text", Text: header}}}
+	for _, section := range sections {
+		for _, chunk := range splitSlackSection(section) {
+			blocks = append(blocks, slackBlock{Type: "section", Text: slackBlockText{Type: "mrkdwn", Text: chunk}})
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(struct {
+		Blocks []slackBlock `json:"blocks"`
+	}{blocks})
+}
+
+// splitSlackSection splits text into chunks within slackSectionLimit,
+// breaking between lines. A single line over the limit is truncated.
+func splitSlackSection(text string) []string {
+	var chunks []string
+	var current strings.Builder
+	for _, line := range strings.Split(text, "\n") {
+		if len(line) > slackSectionLimit {
+			line = strings.ToValidUTF8(line[:slackSectionLimit-len("…")], "") + "…"
+		}
+		if current.Len() > 0 && current.Len()+1+len(line) > slackSectionLimit {
+			chunks = append(chunks, current.String())
+			current.Reset()
+		}
+		if current.Len() > 0 {
+			current.WriteString("\n")
+		}
+		current.WriteString(line)
+	}
+	if current.Len() > 0 {
+		chunks = append(chunks, current.String())
+	}
+	return chunks
+}
+
+// ContentType returns the MIME type for Slack output.
+func (f *SlackFormatter) ContentType() string {
+	if f.config.SlackBlocks {
+		return "application/json"
+	}
+	return "text/plain"
+}
+
+// DefaultExtension returns the default file extension for Slack output.
+func (f *SlackFormatter) DefaultExtension() string {
+	if f.config.SlackBlocks {
+		return ".slack.json"
+	}
+	return ".slack.txt"
+}
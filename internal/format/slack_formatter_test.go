@@ -0,0 +1,131 @@
+package format
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/sdlcforge/make-help/internal/model"
+)
+
+func slackTestModel() *model.HelpModel {
+	return &model.HelpModel{
+		FileDocs: []model.FileDoc{
+			{SourceFile: "Makefile", Documentation: []string{"Tools for **ACME** <internal>."}, IsEntryPoint: true},
+		},
+		Categories: []model.Category{
+			{
+				Name: "Build & Release",
+				Targets: []model.Target{
+					{Name: "build", Aliases: []string{"b"}, Summary: []string{"Build the `app` binary."}},
+					{Name: "old-build", Summary: []string{"Build the old way."}, Deprecated: true},
+				},
+			},
+			{
+				Name: "Docs",
+				Targets: []model.Target{
+					{Name: "docs", Summary: []string{"See [the guide](https://example.com/guide)."}},
+				},
+			},
+		},
+	}
+}
+
+func TestSlackFormatter_RenderHelp(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	if err := NewSlackFormatter(nil).RenderHelp(slackTestModel(), &buf); err != nil {
+		t.Fatalf("RenderHelp() error = %v", err)
+	}
+
+	want := "*Available make targets*\n" +
+		"\n" +
+		"Tools for *ACME* &lt;internal&gt;.\n" +
+		"\n" +
+		"*Build &amp; Release*\n" +
+		"• `build` (b) - Build the `app` binary.\n" +
+		"• `old-build` - Build the old way. _(deprecated)_\n" +
+		"\n" +
+		"*Docs*\n" +
+		"• `docs` - See <https://example.com/guide|the guide>.\n"
+	if buf.String() != want {
+		t.Errorf("RenderHelp() =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
+
+func TestSlackFormatter_RenderHelpBlocks(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	formatter := NewSlackFormatter(&FormatterConfig{SlackBlocks: true})
+	if err := formatter.RenderHelp(slackTestModel(), &buf); err != nil {
+		t.Fatalf("RenderHelp() error = %v", err)
+	}
+
+	var payload struct {
+		Blocks []struct {
+			Type string `json:"type"`
+			Text struct {
+				Type string `json:"type"`
+				Text string `json:"text"`
+			} `json:"text"`
+		} `json:"blocks"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &payload); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+	if len(payload.Blocks) != 4 {
+		t.Fatalf("expected header, description, and 2 category blocks, got %d", len(payload.Blocks))
+	}
+	if payload.Blocks[0].Type != "header" || payload.Blocks[0].Text.Type != "plain_text" {
+		t.Errorf("first block should be a plain_text header, got %+v", payload.Blocks[0])
+	}
+	if payload.Blocks[3].Type != "section" || payload.Blocks[3].Text.Type != "mrkdwn" ||
+		!strings.HasPrefix(payload.Blocks[3].Text.Text, "*Docs*\n") {
+		t.Errorf("unexpected category block: %+v", payload.Blocks[3])
+	}
+	if formatter.DefaultExtension() != ".slack.json" {
+		t.Errorf("DefaultExtension() = %q, want .slack.json", formatter.DefaultExtension())
+	}
+}
+
+func TestSplitSlackSection(t *testing.T) {
+	t.Parallel()
+	line := strings.Repeat("x", 1000)
+	text := strings.Join([]string{line, line, line, line}, "\n")
+
+	chunks := splitSlackSection(text)
+	if len(chunks) != 2 {
+		t.Fatalf("expected 2 chunks, got %d", len(chunks))
+	}
+	for _, chunk := range chunks {
+		if len(chunk) > slackSectionLimit {
+			t.Errorf("chunk of %d bytes exceeds the limit", len(chunk))
+		}
+	}
+	if strings.Join(chunks, "\n") != text {
+		t.Error("chunks should split only between lines")
+	}
+}
+
+func TestSlackFormatter_RenderDetailedTarget(t *testing.T) {
+	t.Parallel()
+	target := &model.Target{
+		Name:          "deploy",
+		Documentation: []string{"Deploy the app."},
+		Variables:     []model.Variable{{Name: "ENV", Description: "Target environment", Required: true}},
+		SourceFile:    "/project/Makefile",
+		LineNumber:    12,
+	}
+
+	var buf bytes.Buffer
+	formatter := NewSlackFormatter(&FormatterConfig{MakefileDir: "/project"})
+	if err := formatter.RenderDetailedTarget(target, &buf); err != nil {
+		t.Fatalf("RenderDetailedTarget() error = %v", err)
+	}
+
+	want := "*make deploy*\nDeploy the app.\n• `ENV` _(required)_ - Target environment\nSource: `Makefile:12`\n"
+	if buf.String() != want {
+		t.Errorf("RenderDetailedTarget() =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
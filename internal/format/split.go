@@ -0,0 +1,149 @@
+package format
+
+import (
+	"github.com/sdlcforge/make-help/internal/model"
+)
+
+// SplitIndexName is the file name, without extension, of the index page of
+// help split by category.
+const SplitIndexName = "index"
+
+// SplitPage is one page of help split by category (--split-by category):
+// the index page, or the page of one category.
+type SplitPage struct {
+	// Name is the file name of the page without its extension:
+	// SplitIndexName, or a slug of the category name.
+	Name string
+
+	// Model holds the content of the page.
+	Model *model.HelpModel
+
+	// Navigation links the page to the other pages.
+	Navigation *Navigation
+}
+
+// Navigation links the pages of help split by category. Markdown and HTML
+// output render it: the index page lists the category pages, and each
+// category page starts with breadcrumbs back to the index and ends with
+// links to its neighbours.
+type Navigation struct {
+	// Breadcrumbs lead from the index to the current page, which is the
+	// last crumb and has no Href. Empty on the index page.
+	Breadcrumbs []Link
+
+	// Previous and Next are the neighbouring category pages, if any.
+	Previous *Link
+	Next     *Link
+
+	// Index links back to the index page from a category page.
+	Index *Link
+
+	// Pages lists the category pages on the index page.
+	Pages []PageLink
+}
+
+// Link is a link to another page of split help.
+type Link struct {
+	Text string
+	Href string
+}
+
+// PageLink is an entry of the index page: a link to a category page and
+// the number of targets it lists.
+type PageLink struct {
+	Link
+	Targets int
+}
+
+// SplitByCategory splits helpModel into an index page followed by one page
+// per category, in category order. The index keeps the title, usage, and
+// file documentation; each category page holds the targets of one
+// category. ext is the file extension pages link to each other with, such
+// as ".md". helpModel is not modified.
+func SplitByCategory(helpModel *model.HelpModel, ext string) []SplitPage {
+	title := helpTitle(helpModel)
+
+	// Reserve the index name so no category page can take it
+	slugger := newAnchorSlugger()
+	slugger.slug(SplitIndexName)
+
+	index := *helpModel
+	index.Categories = nil
+	indexLink := &Link{Text: title, Href: SplitIndexName + ext}
+	pages := []SplitPage{{Name: SplitIndexName, Model: &index, Navigation: &Navigation{}}}
+
+	for _, category := range helpModel.Categories {
+		text := categoryPageTitle(&category)
+		name := slugger.slug(text)
+		if name == "" {
+			name = slugger.slug("category")
+		}
+
+		page := *helpModel
+		page.Categories = []model.Category{category}
+		// The file documentation is on the index page
+		page.FileDocs = nil
+		page.FooterDocs = nil
+		page.Examples = nil
+		pages = append(pages, SplitPage{
+			Name:  name,
+			Model: &page,
+			Navigation: &Navigation{
+				Breadcrumbs: []Link{*indexLink, {Text: text}},
+				Index:       indexLink,
+			},
+		})
+		pages[0].Navigation.Pages = append(pages[0].Navigation.Pages, PageLink{
+			Link:    Link{Text: text, Href: name + ext},
+			Targets: len(category.Targets),
+		})
+	}
+
+	// Category pages link to their neighbours
+	for i := 1; i < len(pages); i++ {
+		if i > 1 {
+			pages[i].Navigation.Previous = &pages[0].Navigation.Pages[i-2].Link
+		}
+		if i < len(pages)-1 {
+			pages[i].Navigation.Next = &pages[0].Navigation.Pages[i].Link
+		}
+	}
+
+	return pages
+}
+
+// categoryPageTitle returns the name a category page is listed under.
+// Uncategorized targets are listed as "Targets".
+func categoryPageTitle(category *model.Category) string {
+	if category.Name == model.UncategorizedCategoryName {
+		return "Targets"
+	}
+	return category.Name
+}
+
+// targetCount returns the index page entry's count, e.g. "3 targets".
+func (p *PageLink) targetCount() string {
+	return plural(p.Targets, "target", "targets")
+}
+
+// categoryPage reports whether n belongs to a category page. It is false
+// for the index page and for help that is not split.
+func (n *Navigation) categoryPage() bool {
+	return n != nil && len(n.Breadcrumbs) > 0
+}
+
+// pagerLinks returns the links at the end of a category page: the
+// previous page, the index, and the next page, where present.
+func (n *Navigation) pagerLinks() []Link {
+	var links []Link
+	if n.Previous != nil {
+		links = append(links, Link{Text: "← " + n.Previous.Text, Href: n.Previous.Href})
+	}
+	if n.Index != nil {
+		links = append(links, *n.Index)
+	}
+	if n.Next != nil {
+		links = append(links, Link{Text: n.Next.Text + " →", Href: n.Next.Href})
+	}
+	return links
+}
@@ -0,0 +1,114 @@
+package format
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/sdlcforge/make-help/internal/model"
+)
+
+func splitTestModel() *model.HelpModel {
+	return &model.HelpModel{
+		Title:    "Demo",
+		FileDocs: []model.FileDoc{{SourceFile: "Makefile", Documentation: []string{"Project."}, IsEntryPoint: true}},
+		Categories: []model.Category{
+			{Name: "Build", Targets: []model.Target{{Name: "build"}, {Name: "compile"}}},
+			{Name: "Index", Targets: []model.Target{{Name: "reindex"}}},
+			{Name: "Test", Targets: []model.Target{{Name: "test"}}},
+		},
+	}
+}
+
+func TestSplitByCategory(t *testing.T) {
+	t.Parallel()
+	helpModel := splitTestModel()
+	pages := SplitByCategory(helpModel, ".md")
+
+	var names []string
+	for _, page := range pages {
+		names = append(names, page.Name)
+	}
+	// A category cannot take the index page's name
+	if want := []string{"index", "build", "index-1", "test"}; !slices.Equal(names, want) {
+		t.Fatalf("page names = %v, want %v", names, want)
+	}
+
+	index := pages[0]
+	if len(index.Model.Categories) != 0 || len(index.Model.FileDocs) != 1 {
+		t.Errorf("index page should keep the file docs and no targets, got %+v", index.Model)
+	}
+	wantPages := []PageLink{
+		{Link: Link{Text: "Build", Href: "build.md"}, Targets: 2},
+		{Link: Link{Text: "Index", Href: "index-1.md"}, Targets: 1},
+		{Link: Link{Text: "Test", Href: "test.md"}, Targets: 1},
+	}
+	if !slices.Equal(index.Navigation.Pages, wantPages) {
+		t.Errorf("index pages = %v, want %v", index.Navigation.Pages, wantPages)
+	}
+
+	build := pages[1]
+	if got := pageTargetNames(build.Model); !slices.Equal(got, []string{"Build/build", "Build/compile"}) {
+		t.Errorf("build page targets = %v", got)
+	}
+	if len(build.Model.FileDocs) != 0 {
+		t.Errorf("file docs should only be on the index page")
+	}
+	wantCrumbs := []Link{{Text: "Demo", Href: "index.md"}, {Text: "Build"}}
+	if !slices.Equal(build.Navigation.Breadcrumbs, wantCrumbs) {
+		t.Errorf("breadcrumbs = %v, want %v", build.Navigation.Breadcrumbs, wantCrumbs)
+	}
+	if build.Navigation.Previous != nil || build.Navigation.Next.Href != "index-1.md" {
+		t.Errorf("first page should link only to the next page, got %+v", build.Navigation)
+	}
+	if last := pages[3].Navigation; last.Previous.Href != "index-1.md" || last.Next != nil {
+		t.Errorf("last page should link only to the previous page, got %+v", last)
+	}
+
+	// The input model is not modified
+	if len(helpModel.Categories) != 3 || len(helpModel.FileDocs) != 1 {
+		t.Errorf("SplitByCategory() modified the input model")
+	}
+}
+
+func TestSplitByCategory_RenderedNavigation(t *testing.T) {
+	t.Parallel()
+	pages := SplitByCategory(splitTestModel(), ".html")
+	index, build := pages[0], pages[1]
+
+	var indexOut strings.Builder
+	if err := NewMarkdownFormatter(&FormatterConfig{Navigation: index.Navigation}).RenderHelp(index.Model, &indexOut); err != nil {
+		t.Fatalf("Markdown RenderHelp() error = %v", err)
+	}
+	for _, want := range []string{"## Usage", "## Categories", "- [Build](build.html) (2 targets)", "- [Test](test.html) (1 target)"} {
+		if !strings.Contains(indexOut.String(), want) {
+			t.Errorf("Markdown index missing %q:\n%s", want, indexOut.String())
+		}
+	}
+
+	var pageOut strings.Builder
+	if err := NewMarkdownFormatter(&FormatterConfig{Navigation: build.Navigation}).RenderHelp(build.Model, &pageOut); err != nil {
+		t.Fatalf("Markdown RenderHelp() error = %v", err)
+	}
+	for _, want := range []string{"[Demo](index.html) › Build\n", "[Demo](index.html) · [Index →](index-1.html)\n"} {
+		if !strings.Contains(pageOut.String(), want) {
+			t.Errorf("Markdown page missing %q:\n%s", want, pageOut.String())
+		}
+	}
+	if strings.Contains(pageOut.String(), "## Usage") {
+		t.Errorf("category pages should leave usage to the index page")
+	}
+
+	var htmlOut strings.Builder
+	if err := NewHTMLFormatter(&FormatterConfig{Navigation: build.Navigation}).RenderHelp(build.Model, &htmlOut); err != nil {
+		t.Fatalf("HTML RenderHelp() error = %v", err)
+	}
+	for _, want := range []string{
+		`<nav class="breadcrumbs"><a href="index.html">Demo</a> › Build</nav>`,
+		`<nav class="pager"><a href="index.html">Demo</a> · <a href="index-1.html">Index →</a></nav>`,
+	} {
+		if !strings.Contains(htmlOut.String(), want) {
+			t.Errorf("HTML page missing %s", want)
+		}
+	}
+}
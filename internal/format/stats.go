@@ -0,0 +1,63 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sdlcforge/make-help/internal/model"
+)
+
+// Stats is the target inventory shown with --show-stats: text, make, and
+// Markdown output end with a summary line built from it, and JSON output
+// adds it as a "stats" object.
+type Stats struct {
+	// Targets is the number of listed targets, not counting !hidden ones.
+	Targets int
+
+	// Categories is the number of named categories.
+	Categories int
+
+	// Undocumented is the number of .PHONY targets left out of help because
+	// they have no documentation.
+	Undocumented int
+}
+
+// NewStats counts the targets and categories of helpModel. undocumented is
+// the number of undocumented .PHONY targets the model left out.
+func NewStats(helpModel *model.HelpModel, undocumented int) *Stats {
+	stats := &Stats{Undocumented: undocumented}
+	for _, category := range helpModel.Categories {
+		if category.Name != model.UncategorizedCategoryName {
+			stats.Categories++
+		}
+		for _, target := range category.Targets {
+			if !target.Hidden {
+				stats.Targets++
+			}
+		}
+	}
+	return stats
+}
+
+// summaryText returns the summary line, e.g.
+// "12 targets in 4 categories, 3 undocumented hidden".
+func (s *Stats) summaryText() string {
+	var sb strings.Builder
+	sb.WriteString(plural(s.Targets, "target", "targets"))
+	if s.Categories > 0 {
+		sb.WriteString(" in ")
+		sb.WriteString(plural(s.Categories, "category", "categories"))
+	}
+	if s.Undocumented > 0 {
+		fmt.Fprintf(&sb, ", %d undocumented hidden", s.Undocumented)
+	}
+	return sb.String()
+}
+
+// plural returns n followed by the singular or plural noun.
+func plural(n int, singular, pluralForm string) string {
+	if n == 1 {
+		return "1 " + singular
+	}
+	return fmt.Sprintf("%d %s", n, pluralForm)
+}
@@ -8,11 +8,25 @@ import (
 	"github.com/sdlcforge/make-help/internal/model"
 )
 
+// Text layouts selectable through FormatterConfig.TextLayout.
+const (
+	TextLayoutCompact = "compact"
+	TextLayoutLong    = "long"
+)
+
+// defaultLineWidth is the line width compact layout fills when
+// FormatterConfig.LineWidth is not set.
+const defaultLineWidth = 80
+
 // TextFormatter generates plain text output suitable for terminal display or text files.
 // The output uses ANSI color codes when color is enabled.
 type TextFormatter struct {
 	config *FormatterConfig
 	colors *ColorScheme
+
+	// shared names the variables RenderHelp lists in its Variables section
+	// rather than under each target.
+	shared map[string]bool
 }
 
 // NewTextFormatter creates a new TextFormatter with the given configuration.
@@ -27,10 +41,11 @@ func NewTextFormatter(config *FormatterConfig) *TextFormatter {
 
 // RenderHelp generates the complete help output from a HelpModel.
 // The output includes:
-//   - Usage line
+//   - Usage line and examples (if any)
 //   - Entry point file documentation (if any)
 //   - Included files section (if any non-entry files have docs)
 //   - Targets section with categories (if applicable)
+//   - Footer documentation (if any)
 func (f *TextFormatter) RenderHelp(helpModel *model.HelpModel, w io.Writer) error {
 	if helpModel == nil {
 		return errNilHelpModel("text")
@@ -38,48 +53,35 @@ func (f *TextFormatter) RenderHelp(helpModel *model.HelpModel, w io.Writer) erro
 
 	var buf strings.Builder
 
-	// Usage line
-	buf.WriteString("Usage: make [<target>...] [<ENV_VAR>=<value>...]\n")
-
-	// File documentation
-	if len(helpModel.FileDocs) > 0 {
-		// Render entry point file docs first
-		entryPointDocs := extractEntryPointDocs(helpModel.FileDocs)
-		if entryPointDocs != nil {
+	// Usage line and examples
+	buf.WriteString("Usage: ")
+	buf.WriteString(usageLine(helpModel))
+	buf.WriteString("\n")
+	if len(helpModel.Examples) > 0 {
+		buf.WriteString("\nExamples:\n")
+		for _, example := range helpModel.Examples {
+			buf.WriteString("  ")
+			buf.WriteString(example)
 			buf.WriteString("\n")
-			for _, line := range entryPointDocs {
-				buf.WriteString(line)
-				buf.WriteString("\n")
-			}
 		}
+	}
 
-		// Render included files section
-		includedFiles := extractIncludedFiles(helpModel.FileDocs)
-		if len(includedFiles) > 0 {
-			buf.WriteString("\nIncluded files:\n")
-			for _, fileDoc := range includedFiles {
-				// File path
-				buf.WriteString("  ")
-				relPath := makeRelativePath(fileDoc.SourceFile, f.config.MakefileDir)
-				buf.WriteString(relPath)
-				buf.WriteString("\n")
-
-				// Documentation (indented)
-				for _, line := range fileDoc.Documentation {
-					if line == "" {
-						buf.WriteString("\n")
-					} else {
-						buf.WriteString("    ")
-						buf.WriteString(line)
-						buf.WriteString("\n")
-					}
-				}
-				buf.WriteString("\n") // Blank line after each file
-			}
+	// File documentation: entry point docs first, then included files
+	// unless they are placed after the targets
+	if entryPointDocs := extractEntryPointDocs(helpModel.FileDocs); entryPointDocs != nil {
+		buf.WriteString("\n")
+		for _, line := range entryPointDocs {
+			buf.WriteString(line)
+			buf.WriteString("\n")
 		}
 	}
+	if !includedFilesAtBottom(f.config) {
+		f.renderIncludedFiles(&buf, helpModel)
+	}
 
 	// Targets section
+	sharedVariables := collectSharedVariables(helpModel, f.config)
+	f.shared = sharedVariableNames(sharedVariables)
 	if len(helpModel.Categories) > 0 {
 		buf.WriteString("\nTargets:\n")
 
@@ -88,10 +90,72 @@ func (f *TextFormatter) RenderHelp(helpModel *model.HelpModel, w io.Writer) erro
 		}
 	}
 
+	// Variables section (shared variables only)
+	if len(sharedVariables) > 0 {
+		buf.WriteString("\nVariables:\n")
+		for _, v := range sharedVariables {
+			f.renderSharedVariable(&buf, &v)
+		}
+	}
+
+	if includedFilesAtBottom(f.config) {
+		f.renderIncludedFiles(&buf, helpModel)
+	}
+
+	// Footer
+	if len(helpModel.FooterDocs) > 0 {
+		buf.WriteString("\n")
+		for _, line := range helpModel.FooterDocs {
+			buf.WriteString(line)
+			buf.WriteString("\n")
+		}
+	}
+
+	if f.config.Stats != nil {
+		buf.WriteString("\n")
+		buf.WriteString(f.config.Stats.summaryText())
+		buf.WriteString("\n")
+	}
+
 	_, err := w.Write([]byte(buf.String()))
 	return err
 }
 
+// renderIncludedFiles renders the documentation of included files, each
+// limited to the configured depth.
+func (f *TextFormatter) renderIncludedFiles(buf *strings.Builder, helpModel *model.HelpModel) {
+	includedFiles := includedFilesFor(helpModel, f.config)
+	if len(includedFiles) == 0 {
+		return
+	}
+	buf.WriteString("\nIncluded files:\n")
+	for _, fileDoc := range includedFiles {
+		// File path
+		buf.WriteString("  ")
+		relPath := makeRelativePath(fileDoc.SourceFile, f.config.MakefileDir)
+		buf.WriteString(relPath)
+		buf.WriteString("\n")
+
+		// Documentation (indented)
+		lines, omitted := limitFileDocs(fileDoc.Documentation, f.config)
+		for _, line := range lines {
+			if line == "" {
+				buf.WriteString("\n")
+			} else {
+				buf.WriteString("    ")
+				buf.WriteString(line)
+				buf.WriteString("\n")
+			}
+		}
+		if omitted > 0 {
+			buf.WriteString("    ")
+			buf.WriteString(formatMoreLines(omitted))
+			buf.WriteString("\n")
+		}
+		buf.WriteString("\n") // Blank line after each file
+	}
+}
+
 // renderCategory renders a single category with its targets.
 // If the category has a name, it's displayed as a colored header.
 // Each target is rendered with proper indentation.
@@ -106,13 +170,80 @@ func (f *TextFormatter) renderCategory(buf *strings.Builder, category *model.Cat
 		buf.WriteString("\n")
 	}
 
-	// Render each target in the category
-	for _, target := range category.Targets {
-		f.renderTarget(buf, &target)
+	// Section introduction (if any)
+	for _, line := range category.Documentation {
+		if line != "" {
+			buf.WriteString("  ")
+			buf.WriteString(line)
+		}
+		buf.WriteString("\n")
+	}
+
+	// Render each target in the category, up to the per-category limit
+	targets, omitted := limitTargets(category.Targets, f.config)
+	if f.config.TextLayout == TextLayoutCompact {
+		f.renderCompactTargets(buf, targets)
+	} else {
+		for _, target := range targets {
+			f.renderTarget(buf, &target)
+		}
+	}
+	if omitted > 0 {
+		buf.WriteString("  ")
+		buf.WriteString(formatMoreTargets(omitted, f.config))
+		buf.WriteString("\n")
+	}
+}
+
+// renderCompactTargets renders target names and aliases only, in as many
+// columns as fit the line width. Like ls, entries run down each column first.
+// Format:
+//
+//	<target>[ (<alias1>, ...)]  <target>
+//	<target>                    <target>
+func (f *TextFormatter) renderCompactTargets(buf *strings.Builder, targets []model.Target) {
+	if len(targets) == 0 {
+		return
+	}
+
+	entries := make([]string, len(targets))
+	columnWidth := 0
+	for i, target := range targets {
+		entry := f.colors.TargetName + target.Name + f.colors.Reset
+		if len(target.Aliases) > 0 {
+			entry += " (" + f.colors.Alias + strings.Join(target.Aliases, ", ") + f.colors.Reset + ")"
+		}
+		entries[i] = entry
+		columnWidth = max(columnWidth, visibleWidth(entry))
+	}
+
+	lineWidth := f.config.LineWidth
+	if lineWidth <= 0 {
+		lineWidth = defaultLineWidth
+	}
+	// Each column is preceded by two spaces: the indent, then the gutter
+	columns := max(1, lineWidth/(columnWidth+2))
+	rows := (len(entries) + columns - 1) / columns
+
+	for row := 0; row < rows; row++ {
+		buf.WriteString("  ")
+		for col := 0; col < columns; col++ {
+			i := col*rows + row
+			if i >= len(entries) {
+				break
+			}
+			buf.WriteString(entries[i])
+			// Pad to the column width unless this is the last entry on the line
+			if next := (col+1)*rows + row; col < columns-1 && next < len(entries) {
+				buf.WriteString(strings.Repeat(" ", columnWidth-visibleWidth(entries[i])+2))
+			}
+		}
+		buf.WriteString("\n")
 	}
 }
 
 // renderTarget renders a single target with its name, aliases, summary, and variables.
+// In long layout the full documentation replaces the summary.
 // Format:
 //   - <target>[ <alias1>, ...]: <summary>
 //     [Vars: <VAR1>, <VAR2>...]
@@ -134,20 +265,67 @@ func (f *TextFormatter) renderTarget(buf *strings.Builder, target *model.Target)
 	}
 
 	// Summary: Use plain text for terminal output (strips markdown formatting)
-	if len(target.Summary) > 0 && target.Summary[0] != "" {
+	long := f.config.TextLayout == TextLayoutLong
+	if !long && len(target.Summary) > 0 && target.Summary[0] != "" {
 		buf.WriteString(": ")
 		buf.WriteString(f.colors.Documentation)
-		buf.WriteString(target.Summary[0])
+		buf.WriteString(truncateSummary(target.Summary[0], f.config.SummaryWidth))
+		buf.WriteString(f.colors.Reset)
+	}
+
+	// Duration estimate and last recorded run (if any)
+	if duration := formatDuration(target); duration != "" {
+		buf.WriteString(" ")
+		buf.WriteString(duration)
+	}
+	if lastRun, ok := f.config.LastRuns[target.Name]; ok {
+		fmt.Fprintf(buf, " (last run: %s)", lastRun)
+	}
+
+	// Platform badge (if any)
+	if platforms := formatPlatforms(target); platforms != "" {
+		buf.WriteString(" ")
+		buf.WriteString(platforms)
+	}
+
+	// Danger badge (if any)
+	if target.Dangerous {
+		buf.WriteString(" ")
+		buf.WriteString(f.colors.Danger)
+		buf.WriteString(dangerBadge)
+		buf.WriteString(f.colors.Reset)
+	}
+
+	// New badge (if any)
+	if f.config.NewTargets[target.Name] {
+		buf.WriteString(" ")
+		buf.WriteString(f.colors.New)
+		buf.WriteString(newBadge)
 		buf.WriteString(f.colors.Reset)
 	}
 
 	buf.WriteString("\n")
 
+	// Full documentation (long layout only)
+	if long {
+		for _, line := range target.Documentation {
+			if line == "" {
+				buf.WriteString("\n")
+				continue
+			}
+			buf.WriteString("    ")
+			buf.WriteString(f.colors.Documentation)
+			buf.WriteString(line)
+			buf.WriteString(f.colors.Reset)
+			buf.WriteString("\n")
+		}
+	}
+
 	// Variables (if any)
-	if len(target.Variables) > 0 {
+	if variables := ownVariables(target, f.shared); len(variables) > 0 {
 		buf.WriteString("    Vars: ")
-		varNames := make([]string, len(target.Variables))
-		for i, v := range target.Variables {
+		varNames := make([]string, len(variables))
+		for i, v := range variables {
 			varNames[i] = v.Name
 		}
 		buf.WriteString(f.colors.Variable)
@@ -157,6 +335,32 @@ func (f *TextFormatter) renderTarget(buf *strings.Builder, target *model.Target)
 	}
 }
 
+// renderSharedVariable renders a variable of the Variables section with
+// the targets that use it.
+func (f *TextFormatter) renderSharedVariable(buf *strings.Builder, v *sharedVariable) {
+	buf.WriteString("  - ")
+	buf.WriteString(f.colors.Variable)
+	buf.WriteString(v.Name)
+	buf.WriteString(f.colors.Reset)
+	if v.Required {
+		buf.WriteString(" (required)")
+	}
+	if choices := formatChoices(v.Variable); choices != "" {
+		buf.WriteString(" ")
+		buf.WriteString(choices)
+	}
+	if v.Description != "" {
+		buf.WriteString(": ")
+		buf.WriteString(f.colors.Documentation)
+		buf.WriteString(v.Description)
+		buf.WriteString(f.colors.Reset)
+	}
+	buf.WriteString("\n")
+	buf.WriteString("    Used by: ")
+	buf.WriteString(strings.Join(v.UsedBy, ", "))
+	buf.WriteString("\n")
+}
+
 // RenderDetailedTarget renders a detailed view of a single target.
 // This is used for the help-<target> functionality.
 // It includes the full documentation, not just the summary.
@@ -183,6 +387,46 @@ func (f *TextFormatter) RenderDetailedTarget(target *model.Target, w io.Writer)
 		buf.WriteString("\n")
 	}
 
+	// Owner
+	if target.Owner != "" {
+		fmt.Fprintf(&buf, "Owner: %s\n", target.Owner)
+	}
+
+	// CI workflows
+	if len(target.CIWorkflows) > 0 {
+		fmt.Fprintf(&buf, "CI: %s\n", strings.Join(target.CIWorkflows, ", "))
+	}
+
+	// Danger warning
+	if target.Dangerous {
+		buf.WriteString(f.colors.Danger)
+		buf.WriteString("Danger: ")
+		buf.WriteString(formatDangerReason(target))
+		buf.WriteString(f.colors.Reset)
+		buf.WriteString("\n")
+	}
+
+	// Platforms
+	if len(target.Platforms) > 0 {
+		buf.WriteString("Platforms: ")
+		buf.WriteString(strings.Join(target.Platforms, ", "))
+		buf.WriteString("\n")
+	}
+
+	// Duration estimate and last recorded run
+	if target.Duration != "" {
+		fmt.Fprintf(&buf, "Duration: %s\n", target.Duration)
+	}
+	if lastRun, ok := f.config.LastRuns[target.Name]; ok {
+		fmt.Fprintf(&buf, "Last run: %s\n", lastRun)
+	}
+
+	// Last change
+	if change, ok := f.config.LastChanges[target.Name]; ok {
+		buf.WriteString(change.text())
+		buf.WriteString("\n")
+	}
+
 	// Variables
 	if len(target.Variables) > 0 {
 		buf.WriteString(f.colors.Variable)
@@ -193,6 +437,13 @@ func (f *TextFormatter) RenderDetailedTarget(target *model.Target, w io.Writer)
 			buf.WriteString(f.colors.Variable)
 			buf.WriteString(v.Name)
 			buf.WriteString(f.colors.Reset)
+			if v.Required {
+				buf.WriteString(" (required)")
+			}
+			if choices := formatChoices(v); choices != "" {
+				buf.WriteString(" ")
+				buf.WriteString(choices)
+			}
 			if v.Description != "" {
 				buf.WriteString(": ")
 				buf.WriteString(f.colors.Documentation)
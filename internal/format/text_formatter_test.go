@@ -2,8 +2,10 @@ package format
 
 import (
 	"bytes"
+	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/sdlcforge/make-help/internal/model"
 )
@@ -391,6 +393,370 @@ func TestTextFormatter_WithVariables(t *testing.T) {
 	}
 }
 
+func TestTextFormatter_RenderDetailedTarget_VariableMarkers(t *testing.T) {
+	t.Parallel()
+	formatter := NewTextFormatter(&FormatterConfig{UseColor: false})
+	target := &model.Target{
+		Name:          "deploy",
+		Documentation: []string{"Deploy."},
+		Variables: []model.Variable{
+			{Name: "TOKEN", Description: "API token", Required: true},
+			{Name: "ENV", Choices: []string{"dev", "prod"}},
+			{Name: "DEBUG"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := formatter.RenderDetailedTarget(target, &buf); err != nil {
+		t.Fatalf("RenderDetailedTarget() error = %v", err)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, "  - TOKEN (required): API token\n") {
+		t.Errorf("Output should mark TOKEN as required, got:\n%s", output)
+	}
+	if !strings.Contains(output, "  - ENV [dev|prod]\n") {
+		t.Errorf("Output should list ENV's choices, got:\n%s", output)
+	}
+	if !strings.Contains(output, "  - DEBUG\n") {
+		t.Errorf("Output should list DEBUG without a marker, got:\n%s", output)
+	}
+}
+
+func TestTextFormatter_Platforms(t *testing.T) {
+	t.Parallel()
+	formatter := NewTextFormatter(&FormatterConfig{UseColor: false})
+	target := model.Target{
+		Name:      "install",
+		Summary:   []string{"Install packages."},
+		Platforms: []string{"linux", "darwin"},
+	}
+	helpModel := &model.HelpModel{
+		Categories: []model.Category{
+			{Name: model.UncategorizedCategoryName, Targets: []model.Target{target}},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := formatter.RenderHelp(helpModel, &buf); err != nil {
+		t.Fatalf("RenderHelp() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "  - install: Install packages. [linux, darwin]\n") {
+		t.Errorf("Summary should include the platform badge, got:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := formatter.RenderDetailedTarget(&target, &buf); err != nil {
+		t.Fatalf("RenderDetailedTarget() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "Platforms: linux, darwin\n") {
+		t.Errorf("Detailed help should list platforms, got:\n%s", buf.String())
+	}
+}
+
+func TestTextFormatter_MaxTargetsPerCategory(t *testing.T) {
+	t.Parallel()
+	helpModel := &model.HelpModel{
+		Categories: []model.Category{
+			{Name: "Build", Targets: []model.Target{
+				{Name: "a", Summary: []string{"Target a."}},
+				{Name: "b", Summary: []string{"Target b."}},
+				{Name: "c", Summary: []string{"Target c."}},
+			}},
+			{Name: "Test", Targets: []model.Target{
+				{Name: "d", Summary: []string{"Target d."}},
+			}},
+		},
+	}
+
+	var buf bytes.Buffer
+	formatter := NewTextFormatter(&FormatterConfig{MaxTargetsPerCategory: 2, FullHelpCommand: "make help-full"})
+	if err := formatter.RenderHelp(helpModel, &buf); err != nil {
+		t.Fatalf("RenderHelp() error = %v", err)
+	}
+	output := buf.String()
+	if strings.Contains(output, "  - c:") {
+		t.Errorf("Targets beyond the limit should be omitted, got:\n%s", output)
+	}
+	if !strings.Contains(output, "  - b: Target b.\n  (+1 more, run make help-full)\n") {
+		t.Errorf("Limited category should end with a footer, got:\n%s", output)
+	}
+	if strings.Count(output, "more") != 1 {
+		t.Errorf("Only categories over the limit should have a footer, got:\n%s", output)
+	}
+
+	buf.Reset()
+	formatter = NewTextFormatter(&FormatterConfig{MaxTargetsPerCategory: 1})
+	if err := formatter.RenderHelp(helpModel, &buf); err != nil {
+		t.Fatalf("RenderHelp() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "  (+2 more)\n") {
+		t.Errorf("Footer should omit the command when none is set, got:\n%s", buf.String())
+	}
+}
+
+func TestTextFormatter_SummaryWidth(t *testing.T) {
+	t.Parallel()
+	helpModel := &model.HelpModel{
+		Categories: []model.Category{
+			{Targets: []model.Target{
+				{Name: "deploy", Summary: []string{"Deploy the application to the production cluster, then run smoke tests."}},
+				{Name: "lint", Summary: []string{"Lint the code."}},
+			}},
+		},
+	}
+
+	var buf bytes.Buffer
+	formatter := NewTextFormatter(&FormatterConfig{SummaryWidth: 30})
+	if err := formatter.RenderHelp(helpModel, &buf); err != nil {
+		t.Fatalf("RenderHelp() error = %v", err)
+	}
+	output := buf.String()
+	if !strings.Contains(output, "  - deploy: Deploy the application to…\n") {
+		t.Errorf("Long summary should be cut at a word boundary, got:\n%s", output)
+	}
+	if !strings.Contains(output, "  - lint: Lint the code.\n") {
+		t.Errorf("Short summary should be unchanged, got:\n%s", output)
+	}
+}
+
+func TestTruncateSummary(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		summary string
+		width   int
+		want    string
+	}{
+		{"Build the project.", 0, "Build the project."},
+		{"Build the project.", 18, "Build the project."},
+		{"Build the project.", 17, "Build the…"},
+		{"Build, then test.", 10, "Build…"},
+		{"Supercalifragilistic", 6, "Super…"},
+		{"Bauen Sie das Ärgernis.", 18, "Bauen Sie das…"},
+	}
+	for _, tt := range tests {
+		if got := truncateSummary(tt.summary, tt.width); got != tt.want {
+			t.Errorf("truncateSummary(%q, %d) = %q, want %q", tt.summary, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestTextFormatter_CompactLayout(t *testing.T) {
+	t.Parallel()
+	targets := []model.Target{
+		{Name: "build", Aliases: []string{"b"}, Summary: []string{"Build the project."}},
+		{Name: "test", Summary: []string{"Run tests."}, Variables: []model.Variable{{Name: "VERBOSE"}}},
+	}
+	for i := 0; i < 10; i++ {
+		targets = append(targets, model.Target{Name: fmt.Sprintf("generate-%d", i)})
+	}
+	helpModel := &model.HelpModel{
+		Categories: []model.Category{{Name: "Build", Targets: targets}},
+	}
+
+	var buf bytes.Buffer
+	formatter := NewTextFormatter(&FormatterConfig{TextLayout: TextLayoutCompact})
+	if err := formatter.RenderHelp(helpModel, &buf); err != nil {
+		t.Fatalf("RenderHelp() error = %v", err)
+	}
+	output := buf.String()
+	// 12 entries, 10 columns wide: 6 columns fit in 80, so 2 rows filled column by column
+	if !strings.Contains(output, "Build:\n  build (b)   generate-0  generate-2") {
+		t.Errorf("Compact layout should list names and aliases in columns, got:\n%s", output)
+	}
+	if !strings.Contains(output, "\n  test        generate-1") {
+		t.Errorf("Compact layout should fill columns top to bottom, got:\n%s", output)
+	}
+	if strings.Contains(output, "Build the project.") || strings.Contains(output, "Vars:") {
+		t.Errorf("Compact layout should omit summaries and variables, got:\n%s", output)
+	}
+	for _, line := range strings.Split(output, "\n") {
+		if len(line) > defaultLineWidth {
+			t.Errorf("Line exceeds %d columns: %q", defaultLineWidth, line)
+		}
+	}
+}
+
+func TestTextFormatter_CompactLayout_LineWidthAndColor(t *testing.T) {
+	t.Parallel()
+	helpModel := &model.HelpModel{
+		Categories: []model.Category{{Name: model.UncategorizedCategoryName, Targets: []model.Target{
+			{Name: "build"}, {Name: "test"}, {Name: "lint"},
+		}}},
+	}
+
+	var buf bytes.Buffer
+	formatter := NewTextFormatter(&FormatterConfig{TextLayout: TextLayoutCompact, LineWidth: 14, UseColor: true})
+	if err := formatter.RenderHelp(helpModel, &buf); err != nil {
+		t.Fatalf("RenderHelp() error = %v", err)
+	}
+
+	// Two 5-column entries fit in 14 columns; color codes must not count
+	colors := NewColorScheme(true)
+	name := func(s string) string { return colors.TargetName + s + colors.Reset }
+	expected := "  " + name("build") + "  " + name("lint") + "\n  " + name("test") + "\n"
+	if !strings.HasSuffix(buf.String(), expected) {
+		t.Errorf("Expected colored columns %q, got %q", expected, buf.String())
+	}
+}
+
+func TestTextFormatter_ConsolidateVariables(t *testing.T) {
+	t.Parallel()
+	helpModel := &model.HelpModel{
+		Categories: []model.Category{{Name: model.UncategorizedCategoryName, Targets: []model.Target{
+			{Name: "build", Summary: []string{"Build it."}, Variables: []model.Variable{
+				{Name: "VERBOSE", Description: "Print every command"}, {Name: "JOBS"},
+			}},
+			{Name: "deploy", Summary: []string{"Deploy it."}, Variables: []model.Variable{
+				{Name: "VERBOSE"}, {Name: "ENV", Required: true},
+			}},
+			{Name: "test", Summary: []string{"Test it."}, Variables: []model.Variable{
+				{Name: "VERBOSE", Description: "Be chatty"},
+			}},
+		}}},
+	}
+
+	var buf bytes.Buffer
+	formatter := NewTextFormatter(&FormatterConfig{ConsolidateVariables: true})
+	if err := formatter.RenderHelp(helpModel, &buf); err != nil {
+		t.Fatalf("RenderHelp() error = %v", err)
+	}
+
+	expected := `
+Targets:
+  - build: Build it.
+    Vars: JOBS
+  - deploy: Deploy it.
+    Vars: ENV
+  - test: Test it.
+
+Variables:
+  - VERBOSE: Print every command
+    Used by: build, deploy, test
+`
+	if !strings.HasSuffix(buf.String(), expected) {
+		t.Errorf("Expected output ending in %q, got %q", expected, buf.String())
+	}
+
+	// Detailed help still lists every variable
+	buf.Reset()
+	if err := formatter.RenderDetailedTarget(&helpModel.Categories[0].Targets[0], &buf); err != nil {
+		t.Fatalf("RenderDetailedTarget() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "VERBOSE") {
+		t.Errorf("Expected detailed help to list VERBOSE, got %q", buf.String())
+	}
+}
+
+func TestTextFormatter_LongLayout(t *testing.T) {
+	t.Parallel()
+	helpModel := &model.HelpModel{
+		Categories: []model.Category{
+			{Name: model.UncategorizedCategoryName, Targets: []model.Target{
+				{
+					Name:          "build",
+					Summary:       []string{"Build the project."},
+					Documentation: []string{"Build the project.", "", "Runs code generation first."},
+					Variables:     []model.Variable{{Name: "DEBUG"}},
+				},
+			}},
+		},
+	}
+
+	var buf bytes.Buffer
+	formatter := NewTextFormatter(&FormatterConfig{TextLayout: TextLayoutLong})
+	if err := formatter.RenderHelp(helpModel, &buf); err != nil {
+		t.Fatalf("RenderHelp() error = %v", err)
+	}
+	expected := "  - build\n    Build the project.\n\n    Runs code generation first.\n    Vars: DEBUG\n"
+	if !strings.Contains(buf.String(), expected) {
+		t.Errorf("Long layout should show full documentation, got:\n%s", buf.String())
+	}
+}
+
+func TestTextFormatter_CategoryDocumentation(t *testing.T) {
+	t.Parallel()
+	helpModel := &model.HelpModel{
+		Categories: []model.Category{
+			{
+				Name:          "mk/docker.mk",
+				Documentation: []string{"Docker helpers.", "", "Requires docker."},
+				Targets:       []model.Target{{Name: "image", Summary: []string{"Build the image."}}},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := NewTextFormatter(nil).RenderHelp(helpModel, &buf); err != nil {
+		t.Fatalf("RenderHelp() error = %v", err)
+	}
+	expected := "mk/docker.mk:\n  Docker helpers.\n\n  Requires docker.\n  - image: Build the image.\n"
+	if !strings.Contains(buf.String(), expected) {
+		t.Errorf("Category documentation should introduce the section, got:\n%s", buf.String())
+	}
+}
+
+func TestTextFormatter_DurationAndLastRun(t *testing.T) {
+	t.Parallel()
+	formatter := NewTextFormatter(&FormatterConfig{
+		UseColor: false,
+		LastRuns: map[string]time.Duration{"deploy": 4*time.Minute + 12*time.Second},
+	})
+	target := model.Target{
+		Name:     "deploy",
+		Summary:  []string{"Deploy the app."},
+		Duration: "~5m",
+	}
+	helpModel := &model.HelpModel{
+		Categories: []model.Category{
+			{Name: model.UncategorizedCategoryName, Targets: []model.Target{target}},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := formatter.RenderHelp(helpModel, &buf); err != nil {
+		t.Fatalf("RenderHelp() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "  - deploy: Deploy the app. (~5m) (last run: 4m12s)\n") {
+		t.Errorf("Summary should include the duration and last run, got:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := formatter.RenderDetailedTarget(&target, &buf); err != nil {
+		t.Fatalf("RenderDetailedTarget() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "Duration: ~5m\nLast run: 4m12s\n") {
+		t.Errorf("Detailed help should show the duration and last run, got:\n%s", buf.String())
+	}
+}
+
+func TestTextFormatter_LastChange(t *testing.T) {
+	t.Parallel()
+	formatter := NewTextFormatter(&FormatterConfig{
+		LastChanges: map[string]Change{
+			"deploy": {Author: "Ada Lovelace", Time: time.Date(2026, time.March, 4, 15, 0, 0, 0, time.UTC)},
+		},
+	})
+	target := model.Target{Name: "deploy", Documentation: []string{"Deploy the app."}}
+
+	var buf bytes.Buffer
+	if err := formatter.RenderDetailedTarget(&target, &buf); err != nil {
+		t.Fatalf("RenderDetailedTarget() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "Last changed by Ada Lovelace on 2026-03-04\n") {
+		t.Errorf("Detailed help should show the last change, got:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	other := model.Target{Name: "build", Documentation: []string{"Build the app."}}
+	if err := formatter.RenderDetailedTarget(&other, &buf); err != nil {
+		t.Fatalf("RenderDetailedTarget() error = %v", err)
+	}
+	if strings.Contains(buf.String(), "Last changed") {
+		t.Errorf("Targets without a recorded change should not show one, got:\n%s", buf.String())
+	}
+}
+
 // TestTextFormatter_WithAliases tests target aliases rendering
 func TestTextFormatter_WithAliases(t *testing.T) {
 	t.Parallel()
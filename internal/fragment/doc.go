@@ -0,0 +1,8 @@
+// Package fragment provides curated, fully documented Makefile fragments
+// that make-help --add-fragment installs into a project's make/ directory.
+//
+// Each fragment carries !file documentation, a !category, and !var entries
+// for the variables it reads, so a new project's help output is complete
+// from the start. Targets are prefixed with the fragment name (docker-build,
+// go-test) to avoid clashing with the project's own targets.
+package fragment
@@ -0,0 +1,34 @@
+package fragment
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"slices"
+	"strings"
+)
+
+//go:embed fragments/*.mk
+var fragments embed.FS
+
+// Names returns the names of the available fragments, sorted.
+func Names() []string {
+	entries, err := fs.ReadDir(fragments, "fragments")
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), ".mk"))
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Content returns the fragment with the given name.
+func Content(name string) ([]byte, error) {
+	if !slices.Contains(Names(), name) {
+		return nil, fmt.Errorf("unknown fragment: %s (available: %s)", name, strings.Join(Names(), ", "))
+	}
+	return fragments.ReadFile("fragments/" + name + ".mk")
+}
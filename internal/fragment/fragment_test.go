@@ -0,0 +1,68 @@
+package fragment
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/sdlcforge/make-help/internal/parser"
+)
+
+func TestNames(t *testing.T) {
+	want := []string{"docker", "go", "node"}
+	if got := Names(); !slices.Equal(got, want) {
+		t.Errorf("Names() = %v, want %v", got, want)
+	}
+}
+
+func TestContent_Unknown(t *testing.T) {
+	_, err := Content("rust")
+	if err == nil || !strings.Contains(err.Error(), "unknown fragment: rust (available: docker, go, node)") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+// TestFragmentsAreDocumented checks that every fragment has file
+// documentation, a category, and documentation for each of its targets.
+func TestFragmentsAreDocumented(t *testing.T) {
+	for _, name := range Names() {
+		t.Run(name, func(t *testing.T) {
+			content, err := Content(name)
+			if err != nil {
+				t.Fatalf("Content(%q) error = %v", name, err)
+			}
+			parsed, err := parser.NewScanner().ScanContent(string(content), name+".mk")
+			if err != nil {
+				t.Fatalf("ScanContent() error = %v", err)
+			}
+
+			types := map[parser.DirectiveType]bool{}
+			for _, d := range parsed.Directives {
+				types[d.Type] = true
+			}
+			for _, want := range []parser.DirectiveType{parser.DirectiveFile, parser.DirectiveCategory, parser.DirectiveVar} {
+				if !types[want] {
+					t.Errorf("missing !%s directive", want)
+				}
+			}
+
+			for target, line := range parsed.TargetMap {
+				if target == ".PHONY" {
+					continue
+				}
+				if !strings.HasPrefix(target, name+"-") {
+					t.Errorf("target %s should be prefixed with %s-", target, name)
+				}
+				documented := false
+				for _, d := range parsed.Directives {
+					if d.Type == parser.DirectiveDoc && d.LineNumber == line-1 {
+						documented = true
+					}
+				}
+				if !documented {
+					t.Errorf("target %s (line %d) is not documented", target, line)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+## !file
+## Docker image tasks: build, push, run, and remove the project's container image.
+## Installed by make-help --add-fragment docker.
+
+DOCKER ?= docker
+IMAGE_NAME ?= $(notdir $(CURDIR))
+IMAGE_TAG ?= latest
+DOCKERFILE ?= Dockerfile
+
+.PHONY: docker-build
+## !category Docker
+## !var DOCKER - Docker CLI to use (default: docker)
+## !var IMAGE_NAME - Image repository name (default: the project directory name)
+## !var IMAGE_TAG - Image tag (default: latest)
+## !var DOCKERFILE - Dockerfile to build from (default: Dockerfile)
+## Builds the container image.
+## The image is tagged $(IMAGE_NAME):$(IMAGE_TAG).
+docker-build:
+	$(DOCKER) build -f $(DOCKERFILE) -t $(IMAGE_NAME):$(IMAGE_TAG) .
+
+.PHONY: docker-push
+## !var IMAGE_NAME - Image repository name, including the registry
+## !var IMAGE_TAG - Image tag to push
+## Pushes the container image to its registry.
+## Run docker-build first; the registry login is not handled here.
+docker-push:
+	$(DOCKER) push $(IMAGE_NAME):$(IMAGE_TAG)
+
+.PHONY: docker-run
+## !var DOCKER_RUN_ARGS - Extra arguments for docker run (e.g. -p 8080:8080)
+## Runs the container image in the foreground and removes it on exit.
+docker-run:
+	$(DOCKER) run --rm -it $(DOCKER_RUN_ARGS) $(IMAGE_NAME):$(IMAGE_TAG)
+
+.PHONY: docker-clean
+## Removes the local container image.
+docker-clean:
+	-$(DOCKER) image rm $(IMAGE_NAME):$(IMAGE_TAG)
@@ -0,0 +1,36 @@
+## !file
+## Go tasks: build, test, vet, and tidy the module in the project root.
+## Installed by make-help --add-fragment go.
+
+GO ?= go
+GO_PACKAGES ?= ./...
+GO_BUILD_FLAGS ?=
+GO_TEST_FLAGS ?= -race
+
+.PHONY: go-build
+## !category Go
+## !var GO - Go toolchain to use (default: go)
+## !var GO_PACKAGES - Packages to build (default: ./...)
+## !var GO_BUILD_FLAGS - Extra flags for go build
+## Builds all Go packages.
+go-build:
+	$(GO) build $(GO_BUILD_FLAGS) $(GO_PACKAGES)
+
+.PHONY: go-test
+## !var GO_PACKAGES - Packages to test (default: ./...)
+## !var GO_TEST_FLAGS - Extra flags for go test (default: -race)
+## Runs the Go tests.
+go-test:
+	$(GO) test $(GO_TEST_FLAGS) $(GO_PACKAGES)
+
+.PHONY: go-vet
+## !var GO_PACKAGES - Packages to check (default: ./...)
+## Runs go vet and checks that the code is gofmt-formatted.
+go-vet:
+	$(GO) vet $(GO_PACKAGES)
+	@test -z "$$(gofmt -l .)" || { gofmt -l .; echo "run gofmt -w ."; exit 1; }
+
+.PHONY: go-tidy
+## Tidies go.mod and go.sum.
+go-tidy:
+	$(GO) mod tidy
@@ -0,0 +1,35 @@
+## !file
+## Node.js tasks: install dependencies and run the package.json scripts.
+## Installed by make-help --add-fragment node.
+
+NPM ?= npm
+
+.PHONY: node-install
+## !category Node
+## !var NPM - Package manager to use: npm, pnpm, or yarn (default: npm)
+## Installs dependencies from the lockfile.
+node-install:
+	$(NPM) ci
+
+.PHONY: node-build
+## !var NPM - Package manager to use (default: npm)
+## Builds the project with the package.json build script.
+node-build:
+	$(NPM) run build
+
+.PHONY: node-test
+## !var NPM - Package manager to use (default: npm)
+## Runs the tests with the package.json test script.
+node-test:
+	$(NPM) test
+
+.PHONY: node-lint
+## !var NPM - Package manager to use (default: npm)
+## Runs the linter with the package.json lint script.
+node-lint:
+	$(NPM) run lint
+
+.PHONY: node-clean
+## Removes node_modules.
+node-clean:
+	rm -rf node_modules
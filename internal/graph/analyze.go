@@ -0,0 +1,138 @@
+package graph
+
+import "sort"
+
+// Analysis ranks targets by their place in the dependency graph, to show
+// which targets are worth documenting first.
+type Analysis struct {
+	// MostDependents lists the targets other targets depend on directly,
+	// most dependents first.
+	MostDependents []TargetCount `json:"mostDependents"`
+
+	// DeepestChains lists the longest dependency chains starting at an
+	// orphan target with dependencies, deepest first.
+	DeepestChains []Chain `json:"deepestChains"`
+
+	// Orphans lists the targets no other target depends on, sorted by name.
+	Orphans []Target `json:"orphans"`
+}
+
+// Target is a target named in an analysis.
+type Target struct {
+	Name       string `json:"name"`
+	Documented bool   `json:"documented"`
+}
+
+// TargetCount is a target and its number of direct dependents.
+type TargetCount struct {
+	Name       string `json:"name"`
+	Documented bool   `json:"documented"`
+	Dependents int    `json:"dependents"`
+}
+
+// Chain is the longest dependency chain below a target.
+type Chain struct {
+	Name       string `json:"name"`
+	Documented bool   `json:"documented"`
+
+	// Depth is the number of dependency edges in Path.
+	Depth int `json:"depth"`
+
+	// Path lists the targets on the chain, starting with Name.
+	Path []string `json:"path"`
+}
+
+// Analyze ranks targets by fan-in and dependency depth. Only targets in
+// targets are reported; dependencies on files make does not know as targets
+// are ignored. MostDependents and DeepestChains list at most top entries
+// (all when top is 0).
+func Analyze(targets []string, documented map[string]bool, dependencies map[string][]string, top int) *Analysis {
+	isTarget := make(map[string]bool, len(targets))
+	for _, name := range targets {
+		isTarget[name] = true
+	}
+
+	dependents := make(map[string]int)
+	for from, deps := range dependencies {
+		if !isTarget[from] {
+			continue
+		}
+		seen := make(map[string]bool)
+		for _, dep := range deps {
+			if isTarget[dep] && !seen[dep] {
+				seen[dep] = true
+				dependents[dep]++
+			}
+		}
+	}
+
+	names := make([]string, 0, len(isTarget))
+	for name := range isTarget {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	analysis := &Analysis{
+		MostDependents: []TargetCount{},
+		DeepestChains:  []Chain{},
+		Orphans:        []Target{},
+	}
+	paths := make(map[string][]string)
+	for _, name := range names {
+		if n := dependents[name]; n > 0 {
+			analysis.MostDependents = append(analysis.MostDependents,
+				TargetCount{Name: name, Documented: documented[name], Dependents: n})
+			continue
+		}
+		analysis.Orphans = append(analysis.Orphans, Target{Name: name, Documented: documented[name]})
+		if path := longestPath(name, isTarget, dependencies, paths, make(map[string]bool)); len(path) > 1 {
+			analysis.DeepestChains = append(analysis.DeepestChains,
+				Chain{Name: name, Documented: documented[name], Depth: len(path) - 1, Path: path})
+		}
+	}
+
+	// Stable sorts keep ties in name order
+	sort.SliceStable(analysis.MostDependents, func(i, j int) bool {
+		return analysis.MostDependents[i].Dependents > analysis.MostDependents[j].Dependents
+	})
+	sort.SliceStable(analysis.DeepestChains, func(i, j int) bool {
+		return analysis.DeepestChains[i].Depth > analysis.DeepestChains[j].Depth
+	})
+	if top > 0 {
+		if len(analysis.MostDependents) > top {
+			analysis.MostDependents = analysis.MostDependents[:top]
+		}
+		if len(analysis.DeepestChains) > top {
+			analysis.DeepestChains = analysis.DeepestChains[:top]
+		}
+	}
+
+	return analysis
+}
+
+// longestPath returns the longest dependency chain from name, memoized in
+// paths. A dependency already on the current chain (a cycle) ends it.
+func longestPath(name string, isTarget map[string]bool, dependencies map[string][]string, paths map[string][]string, onChain map[string]bool) []string {
+	if path, ok := paths[name]; ok {
+		return path
+	}
+	onChain[name] = true
+	defer delete(onChain, name)
+
+	deps := append([]string(nil), dependencies[name]...)
+	sort.Strings(deps)
+
+	var longest []string
+	for _, dep := range deps {
+		if !isTarget[dep] || onChain[dep] {
+			continue
+		}
+		if path := longestPath(dep, isTarget, dependencies, paths, onChain); len(path) > len(longest) {
+			longest = path
+		}
+	}
+
+	path := append([]string{name}, longest...)
+	paths[name] = path
+	return path
+}
@@ -0,0 +1,260 @@
+// Package graph exports the target dependency graph as Graphviz DOT or
+// Mermaid, marking undocumented targets and circular dependencies, and
+// ranks targets by their place in it.
+package graph
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/sdlcforge/make-help/internal/lint"
+)
+
+// Supported output formats.
+const (
+	FormatDOT     = "dot"
+	FormatMermaid = "mermaid"
+)
+
+// Options controls which targets the graph includes and how it is marked.
+type Options struct {
+	// DocumentedOnly limits the graph to documented targets. A dependency
+	// reached only through undocumented targets becomes a dashed edge.
+	DocumentedOnly bool
+
+	// HighlightCycles colors targets and edges on circular dependency
+	// chains red.
+	HighlightCycles bool
+}
+
+// Node is a target in the graph.
+type Node struct {
+	Name       string
+	Documented bool
+	InCycle    bool
+}
+
+// Edge is a dependency from one target on another.
+type Edge struct {
+	From string
+	To   string
+
+	// Indirect is true for dependencies on undocumented targets, or, with
+	// DocumentedOnly, dependencies through undocumented targets.
+	Indirect bool
+
+	// InCycle is true when both targets are on the same circular chain.
+	InCycle bool
+}
+
+// Graph is a target dependency graph, sorted by target name.
+type Graph struct {
+	Nodes []Node
+	Edges []Edge
+}
+
+// Build creates the graph of documented targets and the dependencies make
+// reports between targets.
+func Build(documented map[string]bool, dependencies map[string][]string, opts Options) *Graph {
+	// Cycle membership, by index of the first cycle containing the target
+	cycleOf := make(map[string]int)
+	if opts.HighlightCycles {
+		for i, cycle := range lint.FindCycles(dependencies) {
+			for _, name := range cycle {
+				if _, ok := cycleOf[name]; !ok {
+					cycleOf[name] = i
+				}
+			}
+		}
+	}
+	sameCycle := func(a, b string) bool {
+		ca, okA := cycleOf[a]
+		cb, okB := cycleOf[b]
+		return okA && okB && ca == cb
+	}
+
+	names := make(map[string]bool)
+	for name := range documented {
+		if documented[name] {
+			names[name] = true
+		}
+	}
+
+	edges := make(map[[2]string]bool) // value: indirect
+	addEdge := func(from, to string, indirect bool) {
+		key := [2]string{from, to}
+		if existing, ok := edges[key]; ok && !existing {
+			return // a direct edge wins
+		}
+		edges[key] = indirect
+	}
+
+	if opts.DocumentedOnly {
+		for from := range names {
+			for _, dep := range dependencies[from] {
+				if names[dep] {
+					addEdge(from, dep, false)
+					continue
+				}
+				for _, to := range documentedThrough(dep, names, dependencies) {
+					if to != from {
+						addEdge(from, to, true)
+					}
+				}
+			}
+		}
+	} else {
+		for from, deps := range dependencies {
+			for _, dep := range deps {
+				names[from] = true
+				names[dep] = true
+				addEdge(from, dep, !documented[dep])
+			}
+		}
+	}
+
+	g := &Graph{}
+	for name := range names {
+		_, inCycle := cycleOf[name]
+		g.Nodes = append(g.Nodes, Node{Name: name, Documented: documented[name], InCycle: inCycle})
+	}
+	sort.Slice(g.Nodes, func(i, j int) bool { return g.Nodes[i].Name < g.Nodes[j].Name })
+
+	for key, indirect := range edges {
+		g.Edges = append(g.Edges, Edge{From: key[0], To: key[1], Indirect: indirect, InCycle: sameCycle(key[0], key[1])})
+	}
+	sort.Slice(g.Edges, func(i, j int) bool {
+		if g.Edges[i].From != g.Edges[j].From {
+			return g.Edges[i].From < g.Edges[j].From
+		}
+		return g.Edges[i].To < g.Edges[j].To
+	})
+
+	return g
+}
+
+// documentedThrough returns the documented targets reached from the
+// undocumented target start without passing through another documented one.
+func documentedThrough(start string, documented map[string]bool, dependencies map[string][]string) []string {
+	var found []string
+	seen := map[string]bool{start: true}
+	queue := []string{start}
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		for _, dep := range dependencies[name] {
+			if seen[dep] {
+				continue
+			}
+			seen[dep] = true
+			if documented[dep] {
+				found = append(found, dep)
+			} else {
+				queue = append(queue, dep)
+			}
+		}
+	}
+	return found
+}
+
+// Render writes the graph in the given format (FormatDOT or FormatMermaid).
+func Render(g *Graph, format string, w io.Writer) error {
+	var sb strings.Builder
+	switch format {
+	case FormatDOT:
+		renderDOT(g, &sb)
+	case FormatMermaid:
+		renderMermaid(g, &sb)
+	default:
+		return fmt.Errorf("unknown graph format: %s (supported: %s, %s)", format, FormatDOT, FormatMermaid)
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// renderDOT writes the graph as a Graphviz digraph.
+func renderDOT(g *Graph, sb *strings.Builder) {
+	sb.WriteString("digraph make {\n")
+	sb.WriteString("  rankdir=LR;\n")
+	sb.WriteString("  node [shape=box];\n")
+	for _, n := range g.Nodes {
+		var attrs []string
+		if !n.Documented {
+			attrs = append(attrs, "style=dashed")
+		}
+		if n.InCycle {
+			attrs = append(attrs, "color=red", "fontcolor=red")
+		}
+		fmt.Fprintf(sb, "  %s%s;\n", dotQuote(n.Name), dotAttrs(attrs))
+	}
+	for _, e := range g.Edges {
+		var attrs []string
+		if e.Indirect {
+			attrs = append(attrs, "style=dashed")
+		}
+		if e.InCycle {
+			attrs = append(attrs, "color=red")
+		}
+		fmt.Fprintf(sb, "  %s -> %s%s;\n", dotQuote(e.From), dotQuote(e.To), dotAttrs(attrs))
+	}
+	sb.WriteString("}\n")
+}
+
+// dotQuote returns name as a quoted DOT identifier.
+func dotQuote(name string) string {
+	return `"` + strings.ReplaceAll(strings.ReplaceAll(name, `\`, `\\`), `"`, `\"`) + `"`
+}
+
+// dotAttrs renders an attribute list, e.g. " [style=dashed, color=red]".
+func dotAttrs(attrs []string) string {
+	if len(attrs) == 0 {
+		return ""
+	}
+	return " [" + strings.Join(attrs, ", ") + "]"
+}
+
+// renderMermaid writes the graph as a Mermaid flowchart. Nodes get
+// positional IDs, since target names may contain characters Mermaid
+// does not accept in IDs.
+func renderMermaid(g *Graph, sb *strings.Builder) {
+	sb.WriteString("flowchart LR\n")
+	ids := make(map[string]string, len(g.Nodes))
+	var undocumented, cycle []string
+	for i, n := range g.Nodes {
+		id := fmt.Sprintf("n%d", i)
+		ids[n.Name] = id
+		fmt.Fprintf(sb, "  %s[\"%s\"]\n", id, strings.ReplaceAll(n.Name, `"`, "#quot;"))
+		if !n.Documented {
+			undocumented = append(undocumented, id)
+		}
+		if n.InCycle {
+			cycle = append(cycle, id)
+		}
+	}
+
+	var cycleLinks []string
+	for i, e := range g.Edges {
+		arrow := "-->"
+		if e.Indirect {
+			arrow = "-.->"
+		}
+		fmt.Fprintf(sb, "  %s %s %s\n", ids[e.From], arrow, ids[e.To])
+		if e.InCycle {
+			cycleLinks = append(cycleLinks, fmt.Sprint(i))
+		}
+	}
+
+	if len(undocumented) > 0 {
+		sb.WriteString("  classDef undocumented stroke-dasharray: 5 5\n")
+		fmt.Fprintf(sb, "  class %s undocumented\n", strings.Join(undocumented, ","))
+	}
+	if len(cycle) > 0 {
+		sb.WriteString("  classDef cycle stroke:#d00,color:#d00\n")
+		fmt.Fprintf(sb, "  class %s cycle\n", strings.Join(cycle, ","))
+	}
+	if len(cycleLinks) > 0 {
+		fmt.Fprintf(sb, "  linkStyle %s stroke:#d00\n", strings.Join(cycleLinks, ","))
+	}
+}
@@ -0,0 +1,168 @@
+package graph
+
+import (
+	"strings"
+	"testing"
+)
+
+// testDependencies has a cycle (lint -> fmt -> lint) and an undocumented
+// target (deps) between build and generate.
+var testDependencies = map[string][]string{
+	"all":   {"build", "lint"},
+	"build": {"deps"},
+	"deps":  {"generate", "go.mod"},
+	"lint":  {"fmt"},
+	"fmt":   {"lint"},
+}
+
+var testDocumented = map[string]bool{
+	"all": true, "build": true, "generate": true, "lint": true, "fmt": true,
+}
+
+func TestBuild_AllTargets(t *testing.T) {
+	t.Parallel()
+	g := Build(testDocumented, testDependencies, Options{})
+
+	var names []string
+	for _, n := range g.Nodes {
+		names = append(names, n.Name)
+		if n.InCycle {
+			t.Errorf("node %s marked in cycle without HighlightCycles", n.Name)
+		}
+	}
+	if got := strings.Join(names, " "); got != "all build deps fmt generate go.mod lint" {
+		t.Errorf("Nodes = %s", got)
+	}
+	if len(g.Edges) != 7 {
+		t.Fatalf("Expected 7 edges, got %d: %+v", len(g.Edges), g.Edges)
+	}
+	// build -> deps is a dependency on an undocumented target
+	if e := g.Edges[2]; e.From != "build" || e.To != "deps" || !e.Indirect {
+		t.Errorf("Unexpected edge: %+v", e)
+	}
+}
+
+func TestBuild_DocumentedOnly(t *testing.T) {
+	t.Parallel()
+	g := Build(testDocumented, testDependencies, Options{DocumentedOnly: true, HighlightCycles: true})
+
+	if len(g.Nodes) != 5 {
+		t.Errorf("Expected 5 documented nodes, got %+v", g.Nodes)
+	}
+	want := []Edge{
+		{From: "all", To: "build"},
+		{From: "all", To: "lint"},
+		{From: "build", To: "generate", Indirect: true},
+		{From: "fmt", To: "lint", InCycle: true},
+		{From: "lint", To: "fmt", InCycle: true},
+	}
+	if len(g.Edges) != len(want) {
+		t.Fatalf("Edges = %+v, want %+v", g.Edges, want)
+	}
+	for i := range want {
+		if g.Edges[i] != want[i] {
+			t.Errorf("edge %d = %+v, want %+v", i, g.Edges[i], want[i])
+		}
+	}
+}
+
+func TestRender_DOT(t *testing.T) {
+	t.Parallel()
+	g := Build(testDocumented, testDependencies, Options{DocumentedOnly: true, HighlightCycles: true})
+
+	var sb strings.Builder
+	if err := Render(g, FormatDOT, &sb); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `digraph make {
+  rankdir=LR;
+  node [shape=box];
+  "all";
+  "build";
+  "fmt" [color=red, fontcolor=red];
+  "generate";
+  "lint" [color=red, fontcolor=red];
+  "all" -> "build";
+  "all" -> "lint";
+  "build" -> "generate" [style=dashed];
+  "fmt" -> "lint" [color=red];
+  "lint" -> "fmt" [color=red];
+}
+`
+	if sb.String() != want {
+		t.Errorf("DOT output:\ngot:\n%s\nwant:\n%s", sb.String(), want)
+	}
+}
+
+func TestRender_Mermaid(t *testing.T) {
+	t.Parallel()
+	g := Build(testDocumented, testDependencies, Options{HighlightCycles: true})
+
+	var sb strings.Builder
+	if err := Render(g, FormatMermaid, &sb); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := sb.String()
+	for _, want := range []string{
+		"flowchart LR\n",
+		"  n2[\"deps\"]\n",
+		"  n1 -.-> n2\n",
+		"  class n2,n5 undocumented\n",
+		"  class n3,n6 cycle\n",
+		"  linkStyle 5,6 stroke:#d00\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Mermaid output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestRender_UnknownFormat(t *testing.T) {
+	t.Parallel()
+	if err := Render(&Graph{}, "svg", &strings.Builder{}); err == nil {
+		t.Error("Expected error for unknown format")
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	t.Parallel()
+	targets := []string{"all", "build", "deps", "fmt", "generate", "lint"}
+	analysis := Analyze(targets, testDocumented, testDependencies, 3)
+
+	// go.mod is not a target, and the top limit keeps three entries
+	wantCounts := []TargetCount{
+		{Name: "lint", Documented: true, Dependents: 2},
+		{Name: "build", Documented: true, Dependents: 1},
+		{Name: "deps", Documented: false, Dependents: 1},
+	}
+	if len(analysis.MostDependents) != len(wantCounts) {
+		t.Fatalf("MostDependents = %+v, want %+v", analysis.MostDependents, wantCounts)
+	}
+	for i, want := range wantCounts {
+		if analysis.MostDependents[i] != want {
+			t.Errorf("MostDependents[%d] = %+v, want %+v", i, analysis.MostDependents[i], want)
+		}
+	}
+
+	if len(analysis.DeepestChains) != 1 {
+		t.Fatalf("DeepestChains = %+v, want one chain", analysis.DeepestChains)
+	}
+	chain := analysis.DeepestChains[0]
+	if chain.Name != "all" || chain.Depth != 3 || strings.Join(chain.Path, " ") != "all build deps generate" {
+		t.Errorf("Unexpected chain: %+v", chain)
+	}
+
+	if len(analysis.Orphans) != 1 || analysis.Orphans[0] != (Target{Name: "all", Documented: true}) {
+		t.Errorf("Orphans = %+v, want [all]", analysis.Orphans)
+	}
+}
+
+func TestAnalyze_CycleEndsChain(t *testing.T) {
+	t.Parallel()
+	dependencies := map[string][]string{"ci": {"a"}, "a": {"b"}, "b": {"a"}}
+	analysis := Analyze([]string{"a", "b", "ci"}, nil, dependencies, 0)
+
+	if len(analysis.DeepestChains) != 1 || strings.Join(analysis.DeepestChains[0].Path, " ") != "ci a b" {
+		t.Errorf("DeepestChains = %+v, want ci a b", analysis.DeepestChains)
+	}
+}
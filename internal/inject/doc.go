@@ -0,0 +1,13 @@
+// Package inject maintains a generated help section inside a hand-written
+// document such as README.md.
+//
+// The managed section is delimited by HTML comment markers, which render
+// invisibly on GitHub and most Markdown viewers:
+//
+//	<!-- make-help:start -->
+//	...generated content...
+//	<!-- make-help:end -->
+//
+// Everything outside the markers is preserved byte-for-byte. When the
+// document has no markers yet, a new section is appended to the end.
+package inject
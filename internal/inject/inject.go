@@ -0,0 +1,53 @@
+package inject
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// StartMarker opens the managed section.
+	StartMarker = "<!-- make-help:start -->"
+
+	// EndMarker closes the managed section.
+	EndMarker = "<!-- make-help:end -->"
+)
+
+// Apply returns doc with the managed section replaced by content.
+// If doc contains no markers, the section is appended to the end of doc.
+// Returns an error if the markers are unbalanced, duplicated, or out of order.
+func Apply(doc, content string) (string, error) {
+	section := StartMarker + "\n" + ensureTrailingNewline(content) + EndMarker
+
+	startCount := strings.Count(doc, StartMarker)
+	endCount := strings.Count(doc, EndMarker)
+
+	if startCount == 0 && endCount == 0 {
+		if doc == "" {
+			return section + "\n", nil
+		}
+		// Separate the new section from existing content with a blank line
+		return ensureTrailingNewline(doc) + "\n" + section + "\n", nil
+	}
+
+	if startCount != 1 || endCount != 1 {
+		return "", fmt.Errorf("expected exactly one %s and one %s marker, found %d and %d",
+			StartMarker, EndMarker, startCount, endCount)
+	}
+
+	start := strings.Index(doc, StartMarker)
+	end := strings.Index(doc, EndMarker)
+	if end < start {
+		return "", fmt.Errorf("%s marker appears before %s marker", EndMarker, StartMarker)
+	}
+
+	return doc[:start] + section + doc[end+len(EndMarker):], nil
+}
+
+// ensureTrailingNewline appends a newline to s unless it is empty or already ends with one.
+func ensureTrailingNewline(s string) string {
+	if s == "" || strings.HasSuffix(s, "\n") {
+		return s
+	}
+	return s + "\n"
+}
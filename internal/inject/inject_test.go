@@ -0,0 +1,96 @@
+package inject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApply(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		doc      string
+		content  string
+		expected string
+	}{
+		{
+			name:     "empty document",
+			doc:      "",
+			content:  "help\n",
+			expected: StartMarker + "\nhelp\n" + EndMarker + "\n",
+		},
+		{
+			name:     "append when markers missing",
+			doc:      "# Project\n\nIntro.",
+			content:  "help\n",
+			expected: "# Project\n\nIntro.\n\n" + StartMarker + "\nhelp\n" + EndMarker + "\n",
+		},
+		{
+			name:     "replace existing section",
+			doc:      "# Project\n\n" + StartMarker + "\nold\n" + EndMarker + "\n\n## License\n",
+			content:  "new\n",
+			expected: "# Project\n\n" + StartMarker + "\nnew\n" + EndMarker + "\n\n## License\n",
+		},
+		{
+			name:     "fill empty section",
+			doc:      "before " + StartMarker + EndMarker + " after",
+			content:  "help",
+			expected: "before " + StartMarker + "\nhelp\n" + EndMarker + " after",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result, err := Apply(tt.doc, tt.content)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestApply_Idempotent(t *testing.T) {
+	t.Parallel()
+	first, err := Apply("# Project\n", "help\n")
+	require.NoError(t, err)
+
+	second, err := Apply(first, "help\n")
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+}
+
+func TestApply_InvalidMarkers(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		doc       string
+		errorText string
+	}{
+		{
+			name:      "missing end marker",
+			doc:       StartMarker + "\nold\n",
+			errorText: "found 1 and 0",
+		},
+		{
+			name:      "duplicate start marker",
+			doc:       StartMarker + StartMarker + EndMarker,
+			errorText: "found 2 and 1",
+		},
+		{
+			name:      "end before start",
+			doc:       EndMarker + "\n" + StartMarker,
+			errorText: "appears before",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := Apply(tt.doc, "help\n")
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorText)
+		})
+	}
+}
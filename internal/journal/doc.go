@@ -0,0 +1,16 @@
+// Package journal records the files make-help modifies so that the most
+// recent operation can be reverted with make-help --undo.
+//
+// The journal lives in a .make-help directory next to the Makefile. Each
+// operation is one JSON line in .make-help/journal, listing the files it
+// changed with the SHA-256 hashes of their content before and after. The
+// earlier content is kept in .make-help/objects, named by its hash. An
+// operation is only undone when its files still have the content it left
+// behind, so later edits are never overwritten. Only the most recent
+// operations are kept (DefaultHistory, or the "undo.history" setting of
+// .make-help.json); older ones and the content only they refer to are
+// deleted.
+//
+// Like .make-help-state.json, the directory is local data; it carries its
+// own .gitignore so version control ignores it.
+package journal
@@ -0,0 +1,357 @@
+package journal
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/sdlcforge/make-help/internal/target"
+)
+
+// DirName is the name of the journal directory, created in the Makefile
+// directory.
+const DirName = ".make-help"
+
+// DefaultHistory is how many operations the journal keeps unless
+// SetHistory says otherwise.
+const DefaultHistory = 50
+
+// ErrEmpty is returned by Undo when there is no operation to undo.
+var ErrEmpty = errors.New("nothing to undo")
+
+// FileChange describes how an operation changed one file.
+type FileChange struct {
+	// Path is the absolute path of the file.
+	Path string `json:"path"`
+
+	// Before is the hash of the content before the operation, empty if the
+	// file did not exist.
+	Before string `json:"before,omitempty"`
+
+	// After is the hash of the content after the operation, empty if the
+	// operation deleted the file.
+	After string `json:"after,omitempty"`
+}
+
+// Operation is one recorded make-help invocation that modified files.
+type Operation struct {
+	// At is when the operation finished.
+	At time.Time `json:"at"`
+
+	// Command is the command line that ran the operation.
+	Command string `json:"command"`
+
+	// Files lists the files the operation changed.
+	Files []FileChange `json:"files"`
+}
+
+// ModifiedError is returned by Undo when a file has changed since the
+// operation being undone.
+type ModifiedError struct {
+	// Path is the changed file.
+	Path string
+}
+
+// Error implements the error interface.
+func (e *ModifiedError) Error() string {
+	return fmt.Sprintf("cannot undo: %s has changed since make-help modified it", e.Path)
+}
+
+// Transaction collects the files an operation may modify. Track each file
+// before modifying it, then Commit to record the changes.
+type Transaction struct {
+	dir     string
+	command string
+	paths   []string
+	before  map[string][]byte
+	history int
+}
+
+// Begin starts recording an operation run by command in the journal of
+// the Makefile directory dir.
+func Begin(dir, command string) *Transaction {
+	return &Transaction{
+		dir:     dir,
+		command: command,
+		before:  make(map[string][]byte),
+		history: DefaultHistory,
+	}
+}
+
+// SetHistory sets how many operations the journal keeps, dropping the
+// oldest ones and their saved content on Commit. Zero or less keeps
+// DefaultHistory.
+func (t *Transaction) SetHistory(history int) {
+	if history <= 0 {
+		history = DefaultHistory
+	}
+	t.history = history
+}
+
+// Track snapshots the current content of paths. Paths already tracked keep
+// their first snapshot.
+func (t *Transaction) Track(paths ...string) error {
+	for _, path := range paths {
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return fmt.Errorf("invalid path %s: %w", path, err)
+		}
+		if slices.Contains(t.paths, abs) {
+			continue
+		}
+		content, err := readOptional(abs)
+		if err != nil {
+			return err
+		}
+		t.paths = append(t.paths, abs)
+		t.before[abs] = content
+	}
+	return nil
+}
+
+// Commit records the tracked files whose content changed since they were
+// tracked. Nothing is recorded when none did. Operations beyond the
+// history limit (see SetHistory) are dropped, oldest first.
+func (t *Transaction) Commit(at time.Time) error {
+	op := Operation{At: at.UTC(), Command: t.command}
+	for _, path := range t.paths {
+		after, err := readOptional(path)
+		if err != nil {
+			return err
+		}
+		before := t.before[path]
+		if hashOf(before) == hashOf(after) {
+			continue
+		}
+		if before != nil {
+			if err := t.storeObject(before); err != nil {
+				return err
+			}
+		}
+		op.Files = append(op.Files, FileChange{Path: path, Before: hashOf(before), After: hashOf(after)})
+	}
+	if len(op.Files) == 0 {
+		return nil
+	}
+
+	ops, err := Load(t.dir)
+	if err != nil {
+		return err
+	}
+	ops = append(ops, op)
+	if len(ops) > t.history {
+		ops = ops[len(ops)-t.history:]
+	}
+	return save(t.dir, ops)
+}
+
+// storeObject saves content under its hash.
+func (t *Transaction) storeObject(content []byte) error {
+	journalDir, err := Dir(t.dir)
+	if err != nil {
+		return err
+	}
+	objectsDir := filepath.Join(journalDir, "objects")
+	if err := os.MkdirAll(objectsDir, 0755); err != nil {
+		return fmt.Errorf("failed to create journal directory: %w", err)
+	}
+
+	path := filepath.Join(objectsDir, hashOf(content))
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+	if err := target.AtomicWriteFile(path, content, 0644); err != nil {
+		return fmt.Errorf("failed to write journal object: %w", err)
+	}
+	return nil
+}
+
+// Dir creates the journal directory of the Makefile directory dir, with a
+// .gitignore that ignores it, and returns its path.
+func Dir(dir string) (string, error) {
+	journalDir := filepath.Join(dir, DirName)
+	if err := os.MkdirAll(journalDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create journal directory: %w", err)
+	}
+	ignore := filepath.Join(journalDir, ".gitignore")
+	if _, err := os.Stat(ignore); os.IsNotExist(err) {
+		if err := target.AtomicWriteFile(ignore, []byte("*\n"), 0644); err != nil {
+			return "", fmt.Errorf("failed to write %s: %w", ignore, err)
+		}
+	}
+	return journalDir, nil
+}
+
+// Path returns the journal file path for the Makefile directory dir.
+func Path(dir string) string {
+	return filepath.Join(dir, DirName, "journal")
+}
+
+// Load reads the recorded operations in dir, oldest first. A missing
+// journal yields no operations.
+func Load(dir string) ([]Operation, error) {
+	data, err := os.ReadFile(Path(dir))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read journal: %w", err)
+	}
+
+	var ops []Operation
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, 1024*1024)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var op Operation
+		if err := json.Unmarshal(scanner.Bytes(), &op); err != nil {
+			return nil, fmt.Errorf("failed to parse journal %s:%d: %w", Path(dir), lineNum, err)
+		}
+		ops = append(ops, op)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read journal: %w", err)
+	}
+	return ops, nil
+}
+
+// Undo reverts the most recent operation in dir and removes it from the
+// journal. If any of its files changed since, nothing is reverted and a
+// ModifiedError is returned.
+func Undo(dir string) (*Operation, error) {
+	ops, err := Load(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(ops) == 0 {
+		return nil, ErrEmpty
+	}
+	op := ops[len(ops)-1]
+
+	// Check every file and load the earlier content before reverting any
+	originals := make(map[string][]byte)
+	for _, file := range op.Files {
+		current, err := readOptional(file.Path)
+		if err != nil {
+			return nil, err
+		}
+		if hashOf(current) != file.After {
+			return nil, &ModifiedError{Path: file.Path}
+		}
+		if file.Before == "" {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(dir, DirName, "objects", file.Before))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read earlier content of %s: %w", file.Path, err)
+		}
+		originals[file.Path] = content
+	}
+
+	for _, file := range slices.Backward(op.Files) {
+		if err := restore(file, originals[file.Path]); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := save(dir, ops[:len(ops)-1]); err != nil {
+		return nil, err
+	}
+	return &op, nil
+}
+
+// restore puts file back to its content before the operation: original,
+// or no file when it did not exist.
+func restore(file FileChange, original []byte) error {
+	if file.Before == "" {
+		if err := os.Remove(file.Path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove %s: %w", file.Path, err)
+		}
+		// Drop a directory the operation created for the file
+		_ = os.Remove(filepath.Dir(file.Path))
+		return nil
+	}
+
+	perm := os.FileMode(0644)
+	if info, err := os.Stat(file.Path); err == nil {
+		perm = info.Mode().Perm()
+	}
+	if err := os.MkdirAll(filepath.Dir(file.Path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", filepath.Dir(file.Path), err)
+	}
+	if err := target.AtomicWriteFile(file.Path, original, perm); err != nil {
+		return fmt.Errorf("failed to restore %s: %w", file.Path, err)
+	}
+	return nil
+}
+
+// save writes ops as the journal of dir and deletes the objects no
+// operation refers to anymore.
+func save(dir string, ops []Operation) error {
+	var buf bytes.Buffer
+	referenced := make(map[string]bool)
+	for _, op := range ops {
+		data, err := json.Marshal(op)
+		if err != nil {
+			return fmt.Errorf("failed to encode journal: %w", err)
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+		for _, file := range op.Files {
+			referenced[file.Before] = true
+		}
+	}
+
+	if _, err := Dir(dir); err != nil {
+		return err
+	}
+	if err := target.AtomicWriteFile(Path(dir), buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write journal: %w", err)
+	}
+
+	entries, err := os.ReadDir(filepath.Join(dir, DirName, "objects"))
+	if err != nil {
+		return nil
+	}
+	for _, entry := range entries {
+		if !referenced[entry.Name()] {
+			_ = os.Remove(filepath.Join(dir, DirName, "objects", entry.Name()))
+		}
+	}
+	return nil
+}
+
+// readOptional returns the content of path, or nil if it does not exist.
+func readOptional(path string) ([]byte, error) {
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if content == nil {
+		content = []byte{}
+	}
+	return content, nil
+}
+
+// hashOf returns the hex SHA-256 hash of content, or an empty string for
+// nil content, which stands for a missing file.
+func hashOf(content []byte) string {
+	if content == nil {
+		return ""
+	}
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,183 @@
+package journal
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommitAndUndo(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	modified := filepath.Join(dir, "Makefile")
+	created := filepath.Join(dir, "make", "help.mk")
+	deleted := filepath.Join(dir, "old.mk")
+	unchanged := filepath.Join(dir, "common.mk")
+	require.NoError(t, os.WriteFile(modified, []byte("build:\n"), 0644))
+	require.NoError(t, os.WriteFile(deleted, []byte("old\n"), 0644))
+	require.NoError(t, os.WriteFile(unchanged, []byte("common\n"), 0644))
+
+	tx := Begin(dir, "make-help")
+	require.NoError(t, tx.Track(modified, created, deleted, unchanged))
+	require.NoError(t, os.WriteFile(modified, []byte("build:\n-include make/*.mk\n"), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Dir(created), 0755))
+	require.NoError(t, os.WriteFile(created, []byte("help:\n"), 0644))
+	require.NoError(t, os.Remove(deleted))
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	require.NoError(t, tx.Commit(at))
+
+	ops, err := Load(dir)
+	require.NoError(t, err)
+	require.Len(t, ops, 1)
+	assert.Equal(t, at, ops[0].At)
+	assert.Equal(t, "make-help", ops[0].Command)
+	require.Len(t, ops[0].Files, 3)
+	assert.Equal(t, modified, ops[0].Files[0].Path)
+	assert.Empty(t, ops[0].Files[1].Before)
+	assert.Empty(t, ops[0].Files[2].After)
+	assert.FileExists(t, filepath.Join(dir, DirName, ".gitignore"))
+
+	op, err := Undo(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "make-help", op.Command)
+
+	content, err := os.ReadFile(modified)
+	require.NoError(t, err)
+	assert.Equal(t, "build:\n", string(content))
+	content, err = os.ReadFile(deleted)
+	require.NoError(t, err)
+	assert.Equal(t, "old\n", string(content))
+	assert.NoFileExists(t, created)
+	assert.NoDirExists(t, filepath.Dir(created))
+
+	ops, err = Load(dir)
+	require.NoError(t, err)
+	assert.Empty(t, ops)
+	objects, err := os.ReadDir(filepath.Join(dir, DirName, "objects"))
+	require.NoError(t, err)
+	assert.Empty(t, objects)
+
+	_, err = Undo(dir)
+	assert.ErrorIs(t, err, ErrEmpty)
+}
+
+func TestCommit_History(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	makefile := filepath.Join(dir, "Makefile")
+	require.NoError(t, os.WriteFile(makefile, []byte("v0\n"), 0644))
+
+	for i := 1; i <= 4; i++ {
+		tx := Begin(dir, fmt.Sprintf("make-help %d", i))
+		tx.SetHistory(2)
+		require.NoError(t, tx.Track(makefile))
+		require.NoError(t, os.WriteFile(makefile, []byte(fmt.Sprintf("v%d\n", i)), 0644))
+		require.NoError(t, tx.Commit(time.Now()))
+	}
+
+	ops, err := Load(dir)
+	require.NoError(t, err)
+	require.Len(t, ops, 2)
+	assert.Equal(t, "make-help 3", ops[0].Command)
+	assert.Equal(t, "make-help 4", ops[1].Command)
+
+	// Only the content the kept operations restore is saved
+	objects, err := os.ReadDir(filepath.Join(dir, DirName, "objects"))
+	require.NoError(t, err)
+	var names []string
+	for _, object := range objects {
+		names = append(names, object.Name())
+	}
+	assert.ElementsMatch(t, []string{hashOf([]byte("v2\n")), hashOf([]byte("v3\n"))}, names)
+
+	_, err = Undo(dir)
+	require.NoError(t, err)
+	_, err = Undo(dir)
+	require.NoError(t, err)
+	content, err := os.ReadFile(makefile)
+	require.NoError(t, err)
+	assert.Equal(t, "v2\n", string(content))
+	_, err = Undo(dir)
+	assert.ErrorIs(t, err, ErrEmpty)
+}
+
+func TestCommit_NoChanges(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "Makefile")
+	require.NoError(t, os.WriteFile(path, []byte("build:\n"), 0644))
+
+	tx := Begin(dir, "make-help")
+	require.NoError(t, tx.Track(path))
+	require.NoError(t, tx.Commit(time.Now()))
+
+	assert.NoDirExists(t, filepath.Join(dir, DirName))
+}
+
+func TestUndo_UndoesMostRecentOperation(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "README.md")
+
+	for i, content := range []string{"one\n", "two\n", "three\n"} {
+		tx := Begin(dir, "make-help --inject README.md")
+		require.NoError(t, tx.Track(path))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		require.NoError(t, tx.Commit(time.Now()), i)
+	}
+
+	for _, want := range []string{"two\n", "one\n"} {
+		_, err := Undo(dir)
+		require.NoError(t, err)
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, want, string(content))
+	}
+
+	_, err := Undo(dir)
+	require.NoError(t, err)
+	assert.NoFileExists(t, path)
+}
+
+func TestUndo_FileModifiedSince(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "Makefile")
+	other := filepath.Join(dir, "help.mk")
+	require.NoError(t, os.WriteFile(path, []byte("build:\n"), 0644))
+
+	tx := Begin(dir, "make-help")
+	require.NoError(t, tx.Track(other, path))
+	require.NoError(t, os.WriteFile(other, []byte("help:\n"), 0644))
+	require.NoError(t, os.WriteFile(path, []byte("build:\n-include help.mk\n"), 0644))
+	require.NoError(t, tx.Commit(time.Now()))
+
+	require.NoError(t, os.WriteFile(path, []byte("edited\n"), 0644))
+
+	_, err := Undo(dir)
+	var modifiedErr *ModifiedError
+	require.ErrorAs(t, err, &modifiedErr)
+	assert.Equal(t, path, modifiedErr.Path)
+
+	// Nothing was reverted and the operation is still recorded
+	assert.FileExists(t, other)
+	ops, err := Load(dir)
+	require.NoError(t, err)
+	assert.Len(t, ops, 1)
+}
+
+func TestLoad_InvalidJournal(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, DirName), 0755))
+	require.NoError(t, os.WriteFile(Path(dir), []byte("{\n"), 0644))
+
+	_, err := Load(dir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to parse journal")
+}
@@ -0,0 +1,79 @@
+package lint
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+// BaselineVersion is the format version written to baseline files.
+const BaselineVersion = 1
+
+// Baseline records known lint warnings so later runs only report new ones.
+// Entries are matched by file, check, and message rather than line number,
+// so editing a Makefile does not resurface its recorded warnings.
+type Baseline struct {
+	// Version is the baseline format version (BaselineVersion).
+	Version int `json:"version"`
+
+	// Warnings lists the recorded warnings. A warning reported several
+	// times is listed once per occurrence.
+	Warnings []BaselineEntry `json:"warnings"`
+}
+
+// BaselineEntry is a recorded lint warning.
+type BaselineEntry struct {
+	// File is the file of the warning, relative to the Makefile directory.
+	File string `json:"file"`
+
+	// Check is the name of the check that reported it.
+	Check string `json:"check"`
+
+	// Message is the warning message.
+	Message string `json:"message"`
+}
+
+// NewBaseline records warnings, with file paths relative to dir.
+func NewBaseline(warnings []Warning, dir string) *Baseline {
+	baseline := &Baseline{Version: BaselineVersion, Warnings: []BaselineEntry{}}
+	for _, w := range warnings {
+		baseline.Warnings = append(baseline.Warnings, baselineEntry(w, dir))
+	}
+	return baseline
+}
+
+// Validate checks that the baseline has a supported version.
+func (b *Baseline) Validate() error {
+	if b.Version != BaselineVersion {
+		return fmt.Errorf("unsupported baseline version %d (expected %d)", b.Version, BaselineVersion)
+	}
+	return nil
+}
+
+// Filter returns the warnings not recorded in the baseline, resolving
+// recorded file paths against dir. Each entry suppresses one warning.
+func (b *Baseline) Filter(warnings []Warning, dir string) []Warning {
+	known := make(map[BaselineEntry]int)
+	for _, entry := range b.Warnings {
+		known[entry]++
+	}
+
+	var result []Warning
+	for _, w := range warnings {
+		entry := baselineEntry(w, dir)
+		if known[entry] > 0 {
+			known[entry]--
+			continue
+		}
+		result = append(result, w)
+	}
+	return result
+}
+
+// baselineEntry returns the baseline entry matching w.
+func baselineEntry(w Warning, dir string) BaselineEntry {
+	file := w.File
+	if rel, err := filepath.Rel(dir, w.File); err == nil {
+		file = filepath.ToSlash(rel)
+	}
+	return BaselineEntry{File: file, Check: w.CheckName, Message: w.Message}
+}
@@ -45,4 +45,9 @@ const (
 
 	// FixDelete removes the line entirely.
 	FixDelete
+
+	// FixRename renames the target OldContent to NewContent wherever it is
+	// defined or named as a prerequisite. The Fixer expands it into line
+	// replacements across all Makefiles.
+	FixRename
 )
@@ -2,9 +2,17 @@ package lint
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
+	"time"
+	"unicode"
+
+	"github.com/sdlcforge/make-help/internal/parser"
+	"github.com/sdlcforge/make-help/internal/summary"
 )
 
 // CheckUndocumentedPhony checks for .PHONY targets that lack documentation.
@@ -290,6 +298,100 @@ func CheckMissingVarDescriptions(ctx *CheckContext) []Warning {
 	return warnings
 }
 
+// CheckVarChoices checks that variables declaring "(choices: ...)" list at
+// least one value and no value more than once.
+func CheckVarChoices(ctx *CheckContext) []Warning {
+	var warnings []Warning
+
+	for _, category := range ctx.HelpModel.Categories {
+		for _, target := range category.Targets {
+			for _, variable := range target.Variables {
+				if variable.Choices == nil {
+					continue
+				}
+
+				if len(variable.Choices) == 0 {
+					warnings = append(warnings, Warning{
+						File:      target.SourceFile,
+						Line:      target.LineNumber,
+						Severity:  SeverityWarning,
+						CheckName: "var-choices",
+						Message:   fmt.Sprintf("variable '%s' in target '%s' has an empty choices list", variable.Name, target.Name),
+					})
+					continue
+				}
+
+				seen := make(map[string]int)
+				for _, choice := range variable.Choices {
+					seen[choice]++
+					if seen[choice] == 2 {
+						warnings = append(warnings, Warning{
+							File:      target.SourceFile,
+							Line:      target.LineNumber,
+							Severity:  SeverityWarning,
+							CheckName: "var-choices",
+							Message:   fmt.Sprintf("variable '%s' in target '%s' lists choice '%s' more than once", variable.Name, target.Name, choice),
+						})
+					}
+				}
+			}
+		}
+	}
+
+	return warnings
+}
+
+// CheckVarDescriptions checks that a variable documented by several targets
+// is described the same way by each. Descriptions are compared ignoring case,
+// spacing, and final punctuation; targets without a description are skipped
+// (missing-var-desc reports those). Each target disagreeing with the first
+// description is reported.
+func CheckVarDescriptions(ctx *CheckContext) []Warning {
+	var warnings []Warning
+
+	type firstDescription struct {
+		target string
+		text   string
+	}
+	first := make(map[string]firstDescription)
+
+	for _, category := range ctx.HelpModel.Categories {
+		for _, target := range category.Targets {
+			for _, variable := range target.Variables {
+				text := normalizeVarDescription(variable.Description)
+				if text == "" {
+					continue
+				}
+				seen, ok := first[variable.Name]
+				if !ok {
+					first[variable.Name] = firstDescription{target: target.Name, text: text}
+					continue
+				}
+				if seen.text != text {
+					warnings = append(warnings, Warning{
+						File:      target.SourceFile,
+						Line:      target.LineNumber,
+						Severity:  SeverityWarning,
+						CheckName: "var-description",
+						Message: fmt.Sprintf("variable '%s' in target '%s' is described differently than in target '%s'",
+							variable.Name, target.Name, seen.target),
+					})
+				}
+			}
+		}
+	}
+
+	return warnings
+}
+
+// normalizeVarDescription lowercases a variable description, collapses its
+// whitespace, and drops final punctuation, so cosmetic differences between
+// targets are not reported.
+func normalizeVarDescription(description string) string {
+	text := strings.ToLower(strings.Join(strings.Fields(description), " "))
+	return strings.TrimRight(text, ".!?")
+}
+
 // kebabCasePattern matches valid kebab-case names.
 // Valid format: lowercase letters and numbers separated by hyphens.
 // Examples: build, test, build-all, run-tests, docker-build-image
@@ -297,33 +399,104 @@ var kebabCasePattern = regexp.MustCompile(`^[a-z][a-z0-9]*(-[a-z0-9]+)*$`)
 
 // CheckInconsistentNaming checks that all target names follow kebab-case convention.
 // Kebab-case means lowercase letters and numbers separated by hyphens.
+// Where possible, the warning proposes a kebab-case name in Replacement.
+// No name is proposed for targets naming files, or when the proposed name
+// is already taken by another target or proposed for two targets.
 func CheckInconsistentNaming(ctx *CheckContext) []Warning {
 	var warnings []Warning
 
+	// Count proposals first so two targets are never renamed to the same name
+	proposed := make(map[string]int)
 	for _, category := range ctx.HelpModel.Categories {
 		for _, target := range category.Targets {
 			if !kebabCasePattern.MatchString(target.Name) {
-				warnings = append(warnings, Warning{
-					File:      target.SourceFile,
-					Line:      target.LineNumber,
-					Severity:  SeverityWarning,
-					CheckName: "naming",
-					Message:   fmt.Sprintf("target '%s' does not follow kebab-case naming convention", target.Name),
-					Context:   target.Name,
-				})
+				proposed[kebabCase(target.Name)]++
+			}
+		}
+	}
+
+	for _, category := range ctx.HelpModel.Categories {
+		for _, target := range category.Targets {
+			if kebabCasePattern.MatchString(target.Name) {
+				continue
 			}
+
+			message := fmt.Sprintf("target '%s' does not follow kebab-case naming convention", target.Name)
+			newName := ""
+			if !strings.ContainsAny(target.Name, "/%$") {
+				newName = kebabCase(target.Name)
+			}
+			switch {
+			case newName == "":
+			case targetExists(ctx, newName) || proposed[newName] > 1:
+				message += fmt.Sprintf(" (cannot be renamed to '%s', which is already a target)", newName)
+				newName = ""
+			default:
+				message += fmt.Sprintf(" (rename to '%s')", newName)
+			}
+
+			warnings = append(warnings, Warning{
+				File:        target.SourceFile,
+				Line:        target.LineNumber,
+				Severity:    SeverityWarning,
+				CheckName:   "naming",
+				Message:     message,
+				Context:     target.Name,
+				Replacement: newName,
+			})
 		}
 	}
 
 	return warnings
 }
 
+// targetExists reports whether name is already used by a target or alias.
+func targetExists(ctx *CheckContext, name string) bool {
+	if _, ok := ctx.TargetLocations[name]; ok {
+		return true
+	}
+	return ctx.PhonyTargets[name] || ctx.HasRecipe[name] || ctx.DocumentedTargets[name] || ctx.Aliases[name]
+}
+
+// fixTargetName renames a target to the kebab-case name proposed by
+// CheckInconsistentNaming.
+func fixTargetName(w Warning) *Fix {
+	if w.Replacement == "" {
+		return nil
+	}
+	return &Fix{
+		File:       w.File,
+		Line:       w.Line,
+		Operation:  FixRename,
+		OldContent: w.Context,
+		NewContent: w.Replacement,
+	}
+}
+
 // CheckCircularDependencies detects circular dependency chains in targets.
 // Uses the dependency graph from `make -p` to detect cycles.
 // For example: a → b → c → a creates a circular dependency chain.
 func CheckCircularDependencies(ctx *CheckContext) []Warning {
 	var warnings []Warning
 
+	for _, cycle := range FindCycles(ctx.Dependencies) {
+		cycleStr := strings.Join(cycle, " → ")
+		warnings = append(warnings, Warning{
+			File:      ctx.MakefilePath,
+			Line:      0, // Line number not available from discovery
+			Severity:  SeverityWarning,
+			CheckName: "circular-dependency",
+			Message:   fmt.Sprintf("circular dependency chain detected: %s", cycleStr),
+		})
+	}
+
+	return warnings
+}
+
+// FindCycles detects circular dependency chains in a target dependency graph.
+// Each cycle is returned once, as a path ending with its first target
+// (e.g., [a b a]), ordered by the lexicographically smallest target in it.
+func FindCycles(dependencies map[string][]string) [][]string {
 	// Detect cycles using DFS on the actual dependency graph
 	// Track visited nodes and nodes in current path
 	visited := make(map[string]bool)
@@ -367,21 +540,26 @@ func CheckCircularDependencies(ctx *CheckContext) []Warning {
 		path = append(path, node)
 
 		// Follow all dependencies
-		for _, dep := range ctx.Dependencies[node] {
+		for _, dep := range dependencies[node] {
 			dfs(dep, path)
 		}
 
 		inPath[node] = false
 	}
 
-	// Run DFS from each target with dependencies
-	for targetName := range ctx.Dependencies {
+	// Run DFS from each target with dependencies, in name order so the
+	// same cycles are found on every run
+	targetNames := make([]string, 0, len(dependencies))
+	for targetName := range dependencies {
+		targetNames = append(targetNames, targetName)
+	}
+	sort.Strings(targetNames)
+	for _, targetName := range targetNames {
 		if !visited[targetName] {
 			dfs(targetName, []string{})
 		}
 	}
 
-	// Create warnings for each unique cycle
 	// Sort cycle keys for deterministic output
 	var cycleKeys []string
 	for key := range cycles {
@@ -389,19 +567,11 @@ func CheckCircularDependencies(ctx *CheckContext) []Warning {
 	}
 	sort.Strings(cycleKeys)
 
+	result := make([][]string, 0, len(cycleKeys))
 	for _, key := range cycleKeys {
-		cycle := cycles[key]
-		cycleStr := strings.Join(cycle, " → ")
-		warnings = append(warnings, Warning{
-			File:      ctx.MakefilePath,
-			Line:      0, // Line number not available from discovery
-			Severity:  SeverityWarning,
-			CheckName: "circular-dependency",
-			Message:   fmt.Sprintf("circular dependency chain detected: %s", cycleStr),
-		})
+		result = append(result, cycles[key])
 	}
-
-	return warnings
+	return result
 }
 
 // CheckRedundantDirectives detects redundant or ineffective !notalias and !alias directives.
@@ -506,6 +676,547 @@ func CheckRedundantDirectives(ctx *CheckContext) []Warning {
 	return warnings
 }
 
+// CheckConflictingDefinitions reports targets whose recipe is defined in more
+// than one included file. The warning is placed on the last definition, whose
+// recipe make uses, and lists every location.
+func CheckConflictingDefinitions(ctx *CheckContext) []Warning {
+	var warnings []Warning
+	baseDir := filepath.Dir(ctx.MakefilePath)
+
+	for _, conflict := range ctx.DefinitionConflicts {
+		locations := make([]string, len(conflict.Definitions))
+		for i, d := range conflict.Definitions {
+			file := d.SourceFile
+			if rel, err := filepath.Rel(baseDir, file); err == nil {
+				file = rel
+			}
+			locations[i] = fmt.Sprintf("%s:%d", file, d.LineNumber)
+		}
+
+		message := fmt.Sprintf("target '%s' has recipes in %s; make uses the last one",
+			conflict.Target, strings.Join(locations, ", "))
+		if conflict.MixesColons() {
+			message = fmt.Sprintf("target '%s' mixes single- and double-colon rules in %s; make rejects this",
+				conflict.Target, strings.Join(locations, ", "))
+		}
+
+		last := conflict.Definitions[len(conflict.Definitions)-1]
+		warnings = append(warnings, Warning{
+			File:      last.SourceFile,
+			Line:      last.LineNumber,
+			Severity:  SeverityWarning,
+			CheckName: "conflicting-definition",
+			Message:   message,
+		})
+	}
+
+	return warnings
+}
+
+// CheckCategoryCasing detects category names that differ only in case, such
+// as "build" and "Build", which otherwise become separate categories.
+// The canonical spelling is the one used by the most !category directives,
+// with ties going to the spelling seen first.
+func CheckCategoryCasing(ctx *CheckContext) []Warning {
+	var warnings []Warning
+
+	// Count each spelling, grouped by lowercased name
+	counts := make(map[string]int)
+	var spellings []string
+	for _, d := range ctx.CategoryDirectives {
+		if counts[d.Value] == 0 {
+			spellings = append(spellings, d.Value)
+		}
+		counts[d.Value]++
+	}
+	canonical := make(map[string]string)
+	for _, name := range spellings {
+		key := strings.ToLower(name)
+		if best, ok := canonical[key]; !ok || counts[name] > counts[best] {
+			canonical[key] = name
+		}
+	}
+
+	for _, d := range ctx.CategoryDirectives {
+		want := canonical[strings.ToLower(d.Value)]
+		if d.Value == want {
+			continue
+		}
+		warnings = append(warnings, Warning{
+			File:        d.SourceFile,
+			Line:        d.LineNumber,
+			Severity:    SeverityWarning,
+			CheckName:   "category-case",
+			Message:     fmt.Sprintf("category '%s' differs only in case from '%s'", d.Value, want),
+			Context:     "## !category " + d.Value,
+			Replacement: "## !category " + want,
+			Fixable:     true,
+		})
+	}
+
+	return warnings
+}
+
+// fixCategoryCasing rewrites a !category directive to the canonical spelling.
+func fixCategoryCasing(w Warning) *Fix {
+	if w.Replacement == "" {
+		return nil
+	}
+
+	return &Fix{
+		File:       w.File,
+		Line:       w.Line,
+		Operation:  FixReplace,
+		OldContent: w.Context,
+		NewContent: w.Replacement,
+	}
+}
+
+// CheckSummaryDirectives checks !summary directives: the summary should end
+// with punctuation, and it should say something the first sentence of the
+// documentation does not, otherwise the directive is redundant.
+func CheckSummaryDirectives(ctx *CheckContext) []Warning {
+	var warnings []Warning
+	extractor := summary.NewExtractor()
+
+	for _, category := range ctx.HelpModel.Categories {
+		for _, target := range category.Targets {
+			explicit := strings.TrimSpace(target.ExplicitSummary)
+			if explicit == "" {
+				continue
+			}
+
+			lastChar := explicit[len(explicit)-1]
+			if lastChar != '.' && lastChar != '!' && lastChar != '?' {
+				warnings = append(warnings, Warning{
+					File:      target.SourceFile,
+					Line:      target.LineNumber,
+					Severity:  SeverityWarning,
+					CheckName: "summary-directive",
+					Message:   fmt.Sprintf("!summary for '%s' does not end with punctuation", target.Name),
+					Context:   "## !summary " + explicit,
+				})
+			}
+
+			extracted := extractor.ExtractPlainText(target.Documentation)
+			if similarSentences(explicit, extracted) {
+				warnings = append(warnings, Warning{
+					File:      target.SourceFile,
+					Line:      target.LineNumber,
+					Severity:  SeverityWarning,
+					CheckName: "summary-directive",
+					Message:   fmt.Sprintf("!summary for '%s' repeats the first sentence of its documentation", target.Name),
+					Context:   "## !summary " + explicit,
+				})
+			}
+		}
+	}
+
+	return warnings
+}
+
+// similarSentences reports whether a and b share at least 80% of their
+// words, ignoring case, punctuation, and markdown formatting characters.
+func similarSentences(a, b string) bool {
+	wordsA, wordsB := sentenceWords(a), sentenceWords(b)
+	if len(wordsA) == 0 || len(wordsB) == 0 {
+		return false
+	}
+
+	common := 0
+	for word := range wordsA {
+		if wordsB[word] {
+			common++
+		}
+	}
+	return common*5 >= max(len(wordsA), len(wordsB))*4
+}
+
+// sentenceWords returns the set of lowercased words in s.
+func sentenceWords(s string) map[string]bool {
+	words := make(map[string]bool)
+	for _, word := range strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		words[word] = true
+	}
+	return words
+}
+
+// CheckSpelling reports words in documentation that are not in the
+// dictionary. It only runs when a dictionary is configured (--spell).
+func CheckSpelling(ctx *CheckContext) []Warning {
+	if ctx.Dictionary == nil {
+		return nil
+	}
+
+	var warnings []Warning
+	for _, d := range ctx.ProseDirectives {
+		for _, m := range ctx.Dictionary.Check(d.Value) {
+			message := fmt.Sprintf("possible misspelling '%s'", m.Word)
+			if m.Suggestion != "" {
+				message += fmt.Sprintf(" (did you mean '%s'?)", m.Suggestion)
+			}
+			warnings = append(warnings, Warning{
+				File:      d.SourceFile,
+				Line:      d.LineNumber,
+				Severity:  SeverityWarning,
+				CheckName: "spelling",
+				Message:   message,
+			})
+		}
+	}
+
+	return warnings
+}
+
+// imperativeVerbs lists common verbs used to start target summaries, in
+// their base (imperative) form.
+var imperativeVerbs = map[string]bool{
+	"add": true, "analyze": true, "apply": true, "archive": true, "attach": true,
+	"audit": true, "benchmark": true, "bootstrap": true, "build": true, "bump": true,
+	"bundle": true, "cache": true, "check": true, "clean": true, "clear": true,
+	"collect": true, "compare": true, "compile": true, "compress": true, "configure": true,
+	"connect": true, "convert": true, "copy": true, "create": true, "debug": true,
+	"decrypt": true, "delete": true, "deploy": true, "describe": true, "destroy": true,
+	"disable": true, "display": true, "download": true, "drop": true, "dump": true,
+	"emit": true, "enable": true, "encrypt": true, "ensure": true, "execute": true,
+	"export": true, "extract": true, "fetch": true, "fix": true, "format": true,
+	"generate": true, "import": true, "initialize": true, "inspect": true, "install": true,
+	"invoke": true, "kill": true, "launch": true, "lint": true, "list": true,
+	"load": true, "lock": true, "merge": true, "migrate": true, "minify": true,
+	"monitor": true, "mount": true, "move": true, "notify": true, "open": true,
+	"optimize": true, "package": true, "patch": true, "prepare": true, "print": true,
+	"profile": true, "provision": true, "prune": true, "publish": true, "pull": true,
+	"push": true, "rebuild": true, "refresh": true, "regenerate": true, "release": true,
+	"reload": true, "remove": true, "rename": true, "render": true, "replace": true,
+	"report": true, "reset": true, "restart": true, "restore": true, "resume": true,
+	"run": true, "save": true, "scan": true, "seed": true, "send": true,
+	"serve": true, "set": true, "show": true, "sign": true, "start": true,
+	"stop": true, "sync": true, "tag": true, "tail": true, "test": true,
+	"tidy": true, "trigger": true, "uninstall": true, "update": true, "upgrade": true,
+	"upload": true, "validate": true, "vendor": true, "verify": true, "wait": true,
+	"watch": true, "write": true,
+}
+
+// imperativeForm returns the imperative verb that word is an inflection of
+// (third person "Builds" or gerund "Building" of "build"), or "" if word is
+// not a known verb form or is already imperative.
+func imperativeForm(word string) string {
+	word = strings.ToLower(word)
+	if imperativeVerbs[word] {
+		return ""
+	}
+
+	var candidates []string
+	if base, ok := strings.CutSuffix(word, "ies"); ok {
+		candidates = append(candidates, base+"y")
+	}
+	if base, ok := strings.CutSuffix(word, "es"); ok {
+		candidates = append(candidates, base)
+	}
+	if base, ok := strings.CutSuffix(word, "s"); ok {
+		candidates = append(candidates, base)
+	}
+	if base, ok := strings.CutSuffix(word, "ing"); ok {
+		candidates = append(candidates, base, base+"e")
+		// Doubled final consonant: "running" -> "run"
+		if n := len(base); n >= 2 && base[n-1] == base[n-2] {
+			candidates = append(candidates, base[:n-1])
+		}
+	}
+	for _, candidate := range candidates {
+		if imperativeVerbs[candidate] {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// CheckImperativeMood checks that summaries start with an imperative verb
+// ("Build the project." rather than "Builds the project." or "Building the
+// project."), so help reads consistently. Only verbs in imperativeVerbs are
+// recognized; other first words are not reported. Targets generated by
+// make-help are skipped.
+func CheckImperativeMood(ctx *CheckContext) []Warning {
+	var warnings []Warning
+
+	for _, category := range ctx.HelpModel.Categories {
+		for _, target := range category.Targets {
+			if len(target.Summary) == 0 || ctx.GeneratedHelpTargets[target.Name] {
+				continue
+			}
+			fields := strings.Fields(target.Summary[0])
+			if len(fields) == 0 {
+				continue
+			}
+			word := strings.TrimFunc(fields[0], func(r rune) bool {
+				return !unicode.IsLetter(r)
+			})
+			verb := imperativeForm(word)
+			if verb == "" {
+				continue
+			}
+
+			// Match the capitalization of the summary
+			if first := []rune(word)[0]; unicode.IsUpper(first) {
+				verb = strings.ToUpper(verb[:1]) + verb[1:]
+			}
+			warnings = append(warnings, Warning{
+				File:      target.SourceFile,
+				Line:      target.LineNumber,
+				Severity:  SeverityWarning,
+				CheckName: "imperative-mood",
+				Message:   fmt.Sprintf("summary for '%s' should start with an imperative verb ('%s', not '%s')", target.Name, verb, word),
+				Context:   target.Summary[0],
+			})
+		}
+	}
+
+	return warnings
+}
+
+// CheckCategoryOrder checks that an explicit category order still matches the
+// categories in the Makefiles: every ordered category must exist (or help
+// generation fails), and every category should be ordered (or it is silently
+// appended alphabetically after the ordered ones).
+func CheckCategoryOrder(ctx *CheckContext) []Warning {
+	if len(ctx.CategoryOrder) == 0 {
+		return nil
+	}
+
+	// Categories holding only generated help targets are not part of the order
+	existing := make(map[string]bool)
+	var names []string
+	for _, category := range ctx.HelpModel.Categories {
+		if category.Name == "" || existing[category.Name] {
+			continue
+		}
+		generated := true
+		for _, target := range category.Targets {
+			if !ctx.GeneratedHelpTargets[target.Name] {
+				generated = false
+				break
+			}
+		}
+		if !generated {
+			existing[category.Name] = true
+			names = append(names, category.Name)
+		}
+	}
+
+	var warnings []Warning
+	ordered := make(map[string]bool)
+	for _, name := range ctx.CategoryOrder {
+		ordered[name] = true
+		if !existing[name] {
+			warnings = append(warnings, Warning{
+				File:      ctx.CategoryOrderFile,
+				Severity:  SeverityWarning,
+				CheckName: "category-order",
+				Message:   fmt.Sprintf("--category-order lists '%s', which is no longer a category", name),
+			})
+		}
+	}
+
+	sort.Strings(names)
+	for _, name := range names {
+		if !ordered[name] {
+			warnings = append(warnings, Warning{
+				File:      ctx.CategoryOrderFile,
+				Severity:  SeverityWarning,
+				CheckName: "category-order",
+				Message:   fmt.Sprintf("category '%s' is missing from --category-order (it is listed after the ordered categories)", name),
+			})
+		}
+	}
+
+	return warnings
+}
+
+// CheckMissingOwners checks that targets in the categories matching
+// OwnerCategories name an owner with !owner, directly or through their
+// file's !file block, so questions about them reach the right team.
+func CheckMissingOwners(ctx *CheckContext) []Warning {
+	var warnings []Warning
+
+	for _, category := range ctx.HelpModel.Categories {
+		if !matchesAny(ctx.OwnerCategories, category.Name) {
+			continue
+		}
+		for _, target := range category.Targets {
+			if target.Owner != "" || ctx.GeneratedHelpTargets[target.Name] {
+				continue
+			}
+			warnings = append(warnings, Warning{
+				File:      target.SourceFile,
+				Line:      target.LineNumber,
+				Severity:  SeverityWarning,
+				CheckName: "missing-owner",
+				Message:   fmt.Sprintf("target '%s' in category '%s' has no !owner", target.Name, category.Name),
+			})
+		}
+	}
+
+	return warnings
+}
+
+// CheckMissingCIWorkflows checks that the workflow files named by !ci
+// directives exist, resolving relative paths against the main Makefile
+// directory, so help does not link to workflows that were renamed or removed.
+func CheckMissingCIWorkflows(ctx *CheckContext) []Warning {
+	var warnings []Warning
+	dir := filepath.Dir(ctx.MakefilePath)
+
+	for _, category := range ctx.HelpModel.Categories {
+		for _, target := range category.Targets {
+			for _, workflow := range target.CIWorkflows {
+				path := workflow
+				if !filepath.IsAbs(path) {
+					path = filepath.Join(dir, path)
+				}
+				if _, err := os.Stat(path); err == nil {
+					continue
+				}
+				warnings = append(warnings, Warning{
+					File:      target.SourceFile,
+					Line:      target.LineNumber,
+					Severity:  SeverityWarning,
+					CheckName: "missing-ci-workflow",
+					Message:   fmt.Sprintf("target '%s' links to CI workflow '%s', which does not exist", target.Name, workflow),
+				})
+			}
+		}
+	}
+
+	return warnings
+}
+
+// CheckStaleDocs reports documented targets whose recipe changed more than
+// FreshnessThreshold after their documentation last did, a sign the
+// documentation no longer describes what the target does. It only runs when
+// History is collected (--freshness).
+func CheckStaleDocs(ctx *CheckContext) []Warning {
+	if ctx.History == nil {
+		return nil
+	}
+
+	var warnings []Warning
+	for _, category := range ctx.HelpModel.Categories {
+		for _, target := range category.Targets {
+			history, ok := ctx.History[target.Name]
+			if !ok || history.DocsChanged.IsZero() {
+				continue
+			}
+			lag := history.RecipeChanged.Sub(history.DocsChanged)
+			if lag <= ctx.FreshnessThreshold {
+				continue
+			}
+			warnings = append(warnings, Warning{
+				File:      target.SourceFile,
+				Line:      target.LineNumber,
+				Severity:  SeverityWarning,
+				CheckName: "stale-documentation",
+				Message: fmt.Sprintf("recipe of '%s' changed %d days after its documentation (recipe %s, documentation %s)",
+					target.Name, int(lag.Hours()/24),
+					history.RecipeChanged.Format(time.DateOnly), history.DocsChanged.Format(time.DateOnly)),
+			})
+		}
+	}
+
+	return warnings
+}
+
+// matchesAny reports whether name matches one of the glob patterns.
+// Malformed patterns match nothing.
+func matchesAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if matched, err := filepath.Match(pattern, name); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckDetachedDocs checks for documentation blocks followed by a line that
+// does not define a target. Their documentation is silently dropped, usually
+// because of a missing colon or a variable assignment between the
+// documentation and its target.
+func CheckDetachedDocs(ctx *CheckContext) []Warning {
+	var warnings []Warning
+
+	for file, detached := range ctx.DetachedDocs {
+		for _, d := range detached {
+			warnings = append(warnings, Warning{
+				File:      file,
+				Line:      d.LineNumber,
+				Severity:  SeverityWarning,
+				CheckName: "detached-documentation",
+				Message: fmt.Sprintf("documentation is ignored: line %d is %s, not a target definition",
+					d.FollowingLineNumber, describeLine(d.Line)),
+				Context: d.Line,
+			})
+		}
+	}
+
+	return warnings
+}
+
+// describeLine names the kind of Makefile line that cut documentation off
+// from its target.
+func describeLine(line string) string {
+	trimmed := strings.TrimSpace(line)
+	switch {
+	case parser.ExtractDoubleColonTargetName(line) != "":
+		return "a double-colon rule"
+	case strings.Contains(trimmed, "="):
+		return "a variable assignment"
+	case strings.HasPrefix(line, "\t"):
+		return "a recipe line"
+	default:
+		return fmt.Sprintf("'%s'", trimmed)
+	}
+}
+
+// CheckEntryPoint checks that it is clear which file's !file documentation
+// introduces the help. When the main Makefile has none, the first included
+// file with a !file block is used, so several documented included files
+// make the choice depend on include order unless --entry-point settles it.
+func CheckEntryPoint(ctx *CheckContext) []Warning {
+	if ctx.EntryPoint != "" {
+		return nil
+	}
+
+	var documented []string
+	for _, fileDoc := range ctx.HelpModel.FileDocs {
+		if len(fileDoc.Documentation) == 0 {
+			continue
+		}
+		if filepath.Clean(fileDoc.SourceFile) == filepath.Clean(ctx.MakefilePath) {
+			return nil
+		}
+		documented = append(documented, fileDoc.SourceFile)
+	}
+	if len(documented) < 2 {
+		return nil
+	}
+
+	warning := Warning{
+		File:      documented[0],
+		Severity:  SeverityWarning,
+		CheckName: "entry-point",
+		Message: fmt.Sprintf("the main Makefile has no !file documentation, so '%s' introduces the help ahead of %d other documented file(s); add a !file block to the main Makefile or pass --entry-point",
+			filepath.Base(documented[0]), len(documented)-1),
+	}
+	for _, d := range ctx.ProseDirectives {
+		if d.Type == parser.DirectiveFile && d.SourceFile == documented[0] {
+			warning.Line = d.LineNumber
+			break
+		}
+	}
+	return []Warning{warning}
+}
+
 // AllChecks returns all available lint checks.
 func AllChecks() []Check {
 	return []Check{
@@ -515,8 +1226,55 @@ func AllChecks() []Check {
 		{Name: "long-summary", CheckFunc: CheckLongSummaries, FixFunc: nil},
 		{Name: "empty-doc", CheckFunc: CheckEmptyDocumentation, FixFunc: fixEmptyDocumentation},
 		{Name: "missing-var-desc", CheckFunc: CheckMissingVarDescriptions, FixFunc: nil},
-		{Name: "naming", CheckFunc: CheckInconsistentNaming, FixFunc: nil},
+		{Name: "var-choices", CheckFunc: CheckVarChoices, FixFunc: nil},
+		{Name: "var-description", CheckFunc: CheckVarDescriptions, FixFunc: nil},
+		{Name: "naming", CheckFunc: CheckInconsistentNaming, FixFunc: fixTargetName},
 		{Name: "circular-dependency", CheckFunc: CheckCircularDependencies, FixFunc: nil},
 		{Name: "redundant-notalias", CheckFunc: CheckRedundantDirectives, FixFunc: nil},
+		{Name: "conflicting-definition", CheckFunc: CheckConflictingDefinitions, FixFunc: nil},
+		{Name: "category-case", CheckFunc: CheckCategoryCasing, FixFunc: fixCategoryCasing},
+		{Name: "summary-directive", CheckFunc: CheckSummaryDirectives, FixFunc: nil},
+		{Name: "spelling", CheckFunc: CheckSpelling, FixFunc: nil},
+		{Name: "imperative-mood", CheckFunc: CheckImperativeMood, FixFunc: nil},
+		{Name: "category-order", CheckFunc: CheckCategoryOrder, FixFunc: nil},
+		{Name: "detached-documentation", CheckFunc: CheckDetachedDocs, FixFunc: nil},
+		{Name: "missing-owner", CheckFunc: CheckMissingOwners, FixFunc: nil},
+		{Name: "missing-ci-workflow", CheckFunc: CheckMissingCIWorkflows, FixFunc: nil},
+		{Name: "stale-documentation", CheckFunc: CheckStaleDocs, FixFunc: nil},
+		{Name: "entry-point", CheckFunc: CheckEntryPoint, FixFunc: nil},
+	}
+}
+
+// WithoutRenames returns checks with the fixes that rename targets turned
+// off. A rename touches every rule naming the target, so callers only apply
+// it when asked to.
+func WithoutRenames(checks []Check) []Check {
+	result := make([]Check, len(checks))
+	for i, c := range checks {
+		if c.Name == "naming" {
+			c.FixFunc = nil
+		}
+		result[i] = c
+	}
+	return result
+}
+
+// WithoutChecks returns checks minus the ones named in disabled.
+// Unknown names are an error, so typos do not silently keep a check enabled.
+func WithoutChecks(checks []Check, disabled []string) ([]Check, error) {
+	skip := make(map[string]bool)
+	for _, name := range disabled {
+		if !slices.ContainsFunc(checks, func(c Check) bool { return c.Name == name }) {
+			return nil, fmt.Errorf("unknown lint check: %s", name)
+		}
+		skip[name] = true
+	}
+
+	var result []Check
+	for _, c := range checks {
+		if !skip[c.Name] {
+			result = append(result, c)
+		}
 	}
+	return result, nil
 }
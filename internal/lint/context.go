@@ -0,0 +1,89 @@
+package lint
+
+import (
+	"github.com/sdlcforge/make-help/internal/model"
+	"github.com/sdlcforge/make-help/internal/parser"
+)
+
+// NewCheckContext builds the help model of parsedFiles with config and
+// returns a CheckContext for it, deriving the documented targets, aliases,
+// target locations, and directives the checks read. helpTargetName is the
+// name of the generated help target ("help" if empty). The phony status,
+// dependencies, and recipes come from config. Settings of optional checks
+// (CategoryOrder, OwnerCategories, Dictionary, History) are left to the
+// caller.
+func NewCheckContext(makefilePath string, makefiles []string, parsedFiles []*parser.ParsedFile, config *model.BuilderConfig, helpTargetName string) (*CheckContext, error) {
+	builder := model.NewBuilder(config)
+	helpModel, err := builder.Build(parsedFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	documentedTargets := make(map[string]bool)
+	aliases := make(map[string]bool)
+	generatedHelpTargets := make(map[string]bool)
+	targetLocations := make(map[string]TargetLocation)
+
+	// Build target locations and collect !category and prose directives from parsed files
+	var categoryDirectives, proseDirectives []parser.Directive
+	detachedDocs := make(map[string][]parser.DetachedDoc)
+	for _, pf := range parsedFiles {
+		if len(pf.DetachedDocs) > 0 {
+			detachedDocs[pf.Path] = pf.DetachedDocs
+		}
+		for _, d := range pf.Directives {
+			switch d.Type {
+			case parser.DirectiveCategory:
+				categoryDirectives = append(categoryDirectives, d)
+			case parser.DirectiveDoc, parser.DirectiveFile, parser.DirectiveSummary,
+				parser.DirectiveDeprecated, parser.DirectiveDanger, parser.DirectiveVar:
+				proseDirectives = append(proseDirectives, d)
+			}
+		}
+		for targetName, lineNum := range pf.TargetMap {
+			targetLocations[targetName] = TargetLocation{
+				File: pf.Path,
+				Line: lineNum,
+			}
+		}
+	}
+
+	// Add the standard generated help targets
+	if helpTargetName == "" {
+		helpTargetName = "help"
+	}
+	generatedHelpTargets[helpTargetName] = true
+	generatedHelpTargets[helpTargetName+"-full"] = true
+	generatedHelpTargets["update-help"] = true
+	generatedHelpTargets["help-regen"] = true
+
+	for _, category := range helpModel.Categories {
+		for _, target := range category.Targets {
+			documentedTargets[target.Name] = true
+			// Add help-<target> as a generated target
+			generatedHelpTargets["help-"+target.Name] = true
+			for _, alias := range target.Aliases {
+				aliases[alias] = true
+			}
+		}
+	}
+
+	return &CheckContext{
+		HelpModel:            helpModel,
+		MakefilePath:         makefilePath,
+		Makefiles:            makefiles,
+		PhonyTargets:         config.PhonyTargets,
+		Dependencies:         config.Dependencies,
+		HasRecipe:            config.HasRecipe,
+		DocumentedTargets:    documentedTargets,
+		Aliases:              aliases,
+		GeneratedHelpTargets: generatedHelpTargets,
+		TargetLocations:      targetLocations,
+		NotAliasTargets:      builder.NotAliasTargets(),
+		DefinitionConflicts:  builder.DefinitionConflicts(),
+		CategoryDirectives:   categoryDirectives,
+		ProseDirectives:      proseDirectives,
+		DetachedDocs:         detachedDocs,
+		EntryPoint:           config.EntryPoint,
+	}, nil
+}
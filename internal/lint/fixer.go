@@ -5,25 +5,41 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
+
+	"github.com/sdlcforge/make-help/internal/orderedmap"
 )
 
 // Fixer applies fixes to source files.
 type Fixer struct {
 	// DryRun when true shows what would be fixed without modifying files.
 	DryRun bool
+
+	// Makefiles lists the files FixRename fixes rename targets in.
+	// When empty, only the files defining the renamed targets are searched.
+	Makefiles []string
 }
 
 // FixResult contains the results of applying fixes.
 type FixResult struct {
-	// TotalFixed is the number of fixes successfully applied.
+	// TotalFixed is the number of fixes successfully applied. A FixRename
+	// fix counts once, however many lines it changes.
 	TotalFixed int
 
-	// FilesModified maps file paths to the number of fixes applied.
+	// FilesModified maps the paths of the files changed to the number of
+	// lines changed in each.
 	FilesModified map[string]int
 }
 
+// lineEdit is a line replacement or deletion, with the indices of the fixes
+// passed to ApplyFixes that it carries out.
+type lineEdit struct {
+	Fix
+	fixes []int
+}
+
 // ApplyFixes groups fixes by file and applies them atomically.
 // Fixes are applied in reverse line order to avoid offset invalidation.
 // Returns an error if any fix fails; no partial changes are made per file.
@@ -32,71 +48,138 @@ func (f *Fixer) ApplyFixes(fixes []Fix) (*FixResult, error) {
 		return &FixResult{FilesModified: make(map[string]int)}, nil
 	}
 
-	// Group fixes by file
-	fileFixes := make(map[string][]Fix)
-	for _, fix := range fixes {
-		fileFixes[fix.File] = append(fileFixes[fix.File], fix)
+	edits, err := f.expandRenames(fixes)
+	if err != nil {
+		return nil, err
+	}
+
+	// Group edits by file, in the order of their first edit, so a failure
+	// always leaves the same files fixed
+	fileEdits := orderedmap.New[string, []lineEdit]()
+	for _, edit := range edits {
+		fileEdit, _ := fileEdits.Get(edit.File)
+		fileEdits.Set(edit.File, append(fileEdit, edit))
 	}
 
 	result := &FixResult{
 		FilesModified: make(map[string]int),
 	}
 
-	// Apply fixes file by file
-	for file, fixes := range fileFixes {
-		count, err := f.applyFileFixes(file, fixes)
+	// Apply edits file by file
+	fixed := make([]bool, len(fixes))
+	var applyErr error
+	for file, edits := range fileEdits.All() {
+		applied, err := f.applyFileFixes(file, edits)
 		if err != nil {
-			return result, fmt.Errorf("failed to fix %s: %w", file, err)
+			applyErr = fmt.Errorf("failed to fix %s: %w", file, err)
+			break
+		}
+		if len(applied) == 0 {
+			continue
+		}
+		result.FilesModified[file] = len(applied)
+		for _, edit := range applied {
+			for _, i := range edit.fixes {
+				fixed[i] = true
+			}
+		}
+	}
+
+	// A fix counts once, however many lines it changed
+	for _, ok := range fixed {
+		if ok {
+			result.TotalFixed++
+		}
+	}
+	return result, applyErr
+}
+
+// expandRenames returns the line edits carrying out fixes, replacing
+// FixRename fixes with the line replacements that carry out the renames.
+func (f *Fixer) expandRenames(fixes []Fix) ([]lineEdit, error) {
+	renames := make(map[string]string)
+	renameFix := make(map[string][]int)
+	var edits []lineEdit
+	var definingFiles []string
+	for i, fix := range fixes {
+		if fix.Operation != FixRename {
+			edits = append(edits, lineEdit{Fix: fix, fixes: []int{i}})
+			continue
+		}
+		renames[fix.OldContent] = fix.NewContent
+		renameFix[fix.OldContent] = append(renameFix[fix.OldContent], i)
+		if !slices.Contains(definingFiles, fix.File) {
+			definingFiles = append(definingFiles, fix.File)
 		}
-		result.FilesModified[file] = count
-		result.TotalFixed += count
+	}
+	if len(renames) == 0 {
+		return edits, nil
 	}
 
-	return result, nil
+	files := f.Makefiles
+	if len(files) == 0 {
+		files = definingFiles
+	}
+	renameFixes, err := RenameFixes(files, renames)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rename targets: %w", err)
+	}
+	for _, fix := range renameFixes {
+		// The line carries out the renames of the targets it names
+		edit := lineEdit{Fix: fix}
+		for oldName, newName := range renames {
+			if renameLine(fix.OldContent, map[string]string{oldName: newName}) != fix.OldContent {
+				edit.fixes = append(edit.fixes, renameFix[oldName]...)
+			}
+		}
+		edits = append(edits, edit)
+	}
+	return edits, nil
 }
 
-// applyFileFixes applies all fixes to a single file atomically.
-func (f *Fixer) applyFileFixes(filePath string, fixes []Fix) (int, error) {
+// applyFileFixes applies all edits to a single file atomically and returns
+// the edits applied.
+func (f *Fixer) applyFileFixes(filePath string, edits []lineEdit) ([]lineEdit, error) {
 	// Validate path is absolute
 	absPath, err := filepath.Abs(filePath)
 	if err != nil {
-		return 0, fmt.Errorf("invalid path: %w", err)
+		return nil, fmt.Errorf("invalid path: %w", err)
 	}
 
 	// Read current file content
 	lines, err := readFileLines(absPath)
 	if err != nil {
-		return 0, fmt.Errorf("read failed: %w", err)
+		return nil, fmt.Errorf("read failed: %w", err)
 	}
 
-	// Sort fixes by line number (descending) to avoid offset issues
-	sort.Slice(fixes, func(i, j int) bool {
-		return fixes[i].Line > fixes[j].Line
+	// Sort edits by line number (descending) to avoid offset issues
+	sort.Slice(edits, func(i, j int) bool {
+		return edits[i].Line > edits[j].Line
 	})
 
 	// Track which lines to delete
 	deleteLines := make(map[int]bool)
 
-	// Validate and apply fixes
-	applied := 0
-	for _, fix := range fixes {
-		if err := validateFix(fix, lines); err != nil {
+	// Validate and apply edits
+	var applied []lineEdit
+	for _, edit := range edits {
+		if err := validateFix(edit.Fix, lines); err != nil {
 			// Skip invalid fixes (file may have changed)
 			continue
 		}
 
-		switch fix.Operation {
+		switch edit.Operation {
 		case FixReplace:
-			lines[fix.Line-1] = fix.NewContent
-			applied++
+			lines[edit.Line-1] = edit.NewContent
+			applied = append(applied, edit)
 		case FixDelete:
-			deleteLines[fix.Line-1] = true
-			applied++
+			deleteLines[edit.Line-1] = true
+			applied = append(applied, edit)
 		}
 	}
 
-	if applied == 0 {
-		return 0, nil
+	if len(applied) == 0 {
+		return nil, nil
 	}
 
 	// Filter out deleted lines
@@ -108,13 +191,13 @@ func (f *Fixer) applyFileFixes(filePath string, fixes []Fix) (int, error) {
 	}
 
 	if f.DryRun {
-		// Just return count, don't modify file
+		// Just return the edits, don't modify file
 		return applied, nil
 	}
 
 	// Write atomically
 	if err := writeFileAtomic(absPath, filteredLines); err != nil {
-		return 0, fmt.Errorf("write failed: %w", err)
+		return nil, fmt.Errorf("write failed: %w", err)
 	}
 
 	return applied, nil
@@ -654,3 +654,132 @@ func TestValidateFix(t *testing.T) {
 		})
 	}
 }
+
+func TestKebabCase(t *testing.T) {
+	t.Parallel()
+	tests := map[string]string{
+		"buildAll":      "build-all",
+		"build_all":     "build-all",
+		"Build.All":     "build-all",
+		"runHTTPServer": "run-http-server",
+		"test2Go":       "test2-go",
+		"__all__":       "all",
+		"_1st":          "",
+	}
+	for name, want := range tests {
+		if got := kebabCase(name); got != want {
+			t.Errorf("kebabCase(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestFixer_ApplyFixes_Rename(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	mainFile := filepath.Join(tmpDir, "Makefile")
+	includedFile := filepath.Join(tmpDir, "release.mk")
+	if err := os.WriteFile(mainFile, []byte(`.PHONY: buildAll all
+
+## Build everything.
+buildAll: deps
+	@echo buildAll
+
+all: buildAll ; @echo buildAll
+buildAll: VERSION = buildAll
+ci: ; $(MAKE) buildAll
+
+deploy:
+	$(MAKE) buildAll VERSION=1
+	@$(MAKE) -C sub buildAll
+	cd app && make -j 4 -o deps test buildAll; echo buildAll
+`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(includedFile, []byte("release: buildAll | deps\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	fixer := &Fixer{Makefiles: []string{mainFile, includedFile}}
+	result, err := fixer.ApplyFixes([]Fix{{
+		File:       mainFile,
+		Line:       4,
+		Operation:  FixRename,
+		OldContent: "buildAll",
+		NewContent: "build-all",
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.TotalFixed != 1 {
+		t.Errorf("TotalFixed = %d, want 1", result.TotalFixed)
+	}
+	if result.FilesModified[mainFile] != 7 || result.FilesModified[includedFile] != 1 {
+		t.Errorf("FilesModified = %v, want 7 lines in Makefile and 1 in release.mk", result.FilesModified)
+	}
+
+	got, err := os.ReadFile(mainFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `.PHONY: build-all all
+
+## Build everything.
+build-all: deps
+	@echo buildAll
+
+all: build-all ; @echo buildAll
+build-all: VERSION = buildAll
+ci: ; $(MAKE) build-all
+
+deploy:
+	$(MAKE) build-all VERSION=1
+	@$(MAKE) -C sub buildAll
+	cd app && make -j 4 -o deps test build-all; echo buildAll
+`
+	if string(got) != want {
+		t.Errorf("Makefile content:\ngot:\n%s\nwant:\n%s", string(got), want)
+	}
+
+	got, err = os.ReadFile(includedFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "release: build-all | deps\n" {
+		t.Errorf("release.mk content: got %q", string(got))
+	}
+}
+
+func TestFixer_ApplyFixes_RenameCount(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	makefile := filepath.Join(tmpDir, "Makefile")
+	if err := os.WriteFile(makefile, []byte(`.PHONY: buildAll testAll
+
+## Build everything
+buildAll:
+	@true
+
+testAll: buildAll
+	@true
+`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	fixer := &Fixer{DryRun: true}
+	result, err := fixer.ApplyFixes([]Fix{
+		{File: makefile, Line: 3, Operation: FixReplace, OldContent: "## Build everything", NewContent: "## Build everything."},
+		{File: makefile, Line: 4, Operation: FixRename, OldContent: "buildAll", NewContent: "build-all"},
+		{File: makefile, Line: 7, Operation: FixRename, OldContent: "testAll", NewContent: "test-all"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Each fix counts once, though the renames change three lines
+	if result.TotalFixed != 3 {
+		t.Errorf("TotalFixed = %d, want 3", result.TotalFixed)
+	}
+	if result.FilesModified[makefile] != 4 {
+		t.Errorf("FilesModified[%s] = %d, want 4", makefile, result.FilesModified[makefile])
+	}
+}
@@ -4,8 +4,11 @@ import (
 	"fmt"
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/sdlcforge/make-help/internal/model"
+	"github.com/sdlcforge/make-help/internal/parser"
+	"github.com/sdlcforge/make-help/internal/spell"
 )
 
 // Severity represents the severity level of a lint warning.
@@ -14,6 +17,10 @@ type Severity string
 const (
 	// SeverityWarning indicates a potential issue that should be reviewed.
 	SeverityWarning Severity = "warning"
+
+	// SeverityError indicates a problem that breaks make or the generated
+	// help. --validate-only fails on these.
+	SeverityError Severity = "error"
 )
 
 // Warning represents a single lint issue found during analysis.
@@ -36,6 +43,10 @@ type Warning struct {
 	// Context provides additional context (e.g., the problematic line content).
 	Context string
 
+	// Replacement is the corrected content for the Context line, set by
+	// checks whose fix cannot be derived from Context alone.
+	Replacement string
+
 	// Fixable indicates whether this warning can be automatically fixed.
 	Fixable bool
 }
@@ -46,6 +57,16 @@ type TargetLocation struct {
 	Line int
 }
 
+// TargetHistory records when a target's documentation and recipe last
+// changed, from the version control history of its file.
+type TargetHistory struct {
+	// DocsChanged is when a line of the documentation block last changed.
+	DocsChanged time.Time
+
+	// RecipeChanged is when a line of the rule or its recipe last changed.
+	RecipeChanged time.Time
+}
+
 // CheckContext provides all data needed by lint checks.
 type CheckContext struct {
 	// HelpModel contains the parsed and built help model.
@@ -54,6 +75,9 @@ type CheckContext struct {
 	// MakefilePath is the resolved path to the main Makefile being checked.
 	MakefilePath string
 
+	// Makefiles lists every Makefile being checked, the main one first.
+	Makefiles []string
+
 	// PhonyTargets maps target names to their .PHONY status.
 	PhonyTargets map[string]bool
 
@@ -82,6 +106,51 @@ type CheckContext struct {
 	// NotAliasTargets contains targets marked with !notalias directive.
 	// Used to detect redundant !notalias warnings.
 	NotAliasTargets map[string]bool
+
+	// DefinitionConflicts lists targets with recipes in more than one file.
+	DefinitionConflicts []model.DefinitionConflict
+
+	// CategoryDirectives contains the !category directives from every parsed
+	// file, in discovery order.
+	CategoryDirectives []parser.Directive
+
+	// ProseDirectives contains the directives holding documentation text
+	// (doc lines, !file, !summary, !deprecated, and !var) from every parsed
+	// file, in discovery order.
+	ProseDirectives []parser.Directive
+
+	// Dictionary enables the spelling check. Nil skips it.
+	Dictionary *spell.Dictionary
+
+	// DetachedDocs lists, per file, documentation blocks that are not
+	// followed by a target definition.
+	DetachedDocs map[string][]parser.DetachedDoc
+
+	// CategoryOrder is the explicit category order help is generated with,
+	// from --category-order or the generated help file. Empty skips the
+	// category order check.
+	CategoryOrder []string
+
+	// CategoryOrderFile is the file CategoryOrder was read from, used as the
+	// location of category order warnings.
+	CategoryOrderFile string
+
+	// OwnerCategories lists glob patterns (e.g., "Deploy*") naming the
+	// categories whose targets must have an owner. Empty disables the
+	// missing-owner check.
+	OwnerCategories []string
+
+	// History maps documented target names to when their documentation and
+	// recipe last changed. Nil skips the freshness check (--freshness).
+	History map[string]TargetHistory
+
+	// FreshnessThreshold is how much more recently a recipe may have changed
+	// than its documentation before the freshness check reports it.
+	FreshnessThreshold time.Duration
+
+	// EntryPoint is the --entry-point override of the file introducing the
+	// help. Empty means the entry point was picked by discovery order.
+	EntryPoint string
 }
 
 // CheckFunc is a function that performs a specific lint check.
@@ -95,12 +164,15 @@ type LintResult struct {
 
 	// HasWarnings returns true if any warnings were found.
 	HasWarnings bool
+
+	// Files lists the Makefiles that were checked (CheckContext.Makefiles).
+	Files []string
 }
 
-// checkResult holds warnings from a single check with its fixability info.
+// checkResult holds warnings from a single check with its fix function.
 type checkResult struct {
 	warnings []Warning
-	fixable  bool
+	fix      FixFunc
 }
 
 // Lint runs all registered checks on the provided context in parallel using goroutines
@@ -120,7 +192,7 @@ func Lint(ctx *CheckContext, checks []Check) *LintResult {
 			warnings := c.CheckFunc(ctx)
 			resultsChan <- checkResult{
 				warnings: warnings,
-				fixable:  c.FixFunc != nil,
+				fix:      c.FixFunc,
 			}
 		}(check)
 	}
@@ -134,14 +206,16 @@ func Lint(ctx *CheckContext, checks []Check) *LintResult {
 	// Collect all warnings from the channel
 	var allWarnings []Warning
 	for result := range resultsChan {
-		// Mark warnings as fixable if the check has a FixFunc
+		// Mark warnings as fixable if the check can generate a fix for them
 		for i := range result.warnings {
-			result.warnings[i].Fixable = result.fixable
+			result.warnings[i].Fixable = result.fix != nil && result.fix(result.warnings[i]) != nil
 		}
 		allWarnings = append(allWarnings, result.warnings...)
 	}
 
-	// Sort warnings by file, line number, then check name for consistent output
+	// Sort warnings by file, line number, check name, then message for
+	// consistent output: checks run concurrently, so their warnings arrive
+	// in any order
 	sort.Slice(allWarnings, func(i, j int) bool {
 		if allWarnings[i].File != allWarnings[j].File {
 			return allWarnings[i].File < allWarnings[j].File
@@ -149,12 +223,16 @@ func Lint(ctx *CheckContext, checks []Check) *LintResult {
 		if allWarnings[i].Line != allWarnings[j].Line {
 			return allWarnings[i].Line < allWarnings[j].Line
 		}
-		return allWarnings[i].CheckName < allWarnings[j].CheckName
+		if allWarnings[i].CheckName != allWarnings[j].CheckName {
+			return allWarnings[i].CheckName < allWarnings[j].CheckName
+		}
+		return allWarnings[i].Message < allWarnings[j].Message
 	})
 
 	return &LintResult{
 		Warnings:    allWarnings,
 		HasWarnings: len(allWarnings) > 0,
+		Files:       ctx.Makefiles,
 	}
 }
 
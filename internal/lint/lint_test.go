@@ -1,10 +1,17 @@
 package lint
 
 import (
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/sdlcforge/make-help/internal/model"
+	"github.com/sdlcforge/make-help/internal/parser"
+	"github.com/sdlcforge/make-help/internal/spell"
+	"github.com/sdlcforge/make-help/internal/target"
 )
 
 func TestCheckUndocumentedPhony_NoWarnings(t *testing.T) {
@@ -292,6 +299,140 @@ func TestLint_MultipleChecks(t *testing.T) {
 	}
 }
 
+func TestLint_Deterministic(t *testing.T) {
+	t.Parallel()
+	// Checks run concurrently and read several maps; linting the same
+	// Makefile repeatedly must report the same warnings in the same order
+	content := `## build the project
+build:
+	go build
+
+## run tests
+## !alias t, check-all, verify-all
+test:
+	go test
+
+## Deploy_Release the app
+Deploy_Release:
+	./deploy.sh
+
+## lint the code
+lint: test
+	golangci-lint run
+
+setup:
+	./setup.sh
+
+clean:
+	rm -rf bin
+`
+	scanner := parser.NewScanner()
+	parsed, err := scanner.ScanContent(content, "Makefile")
+	if err != nil {
+		t.Fatalf("ScanContent() error = %v", err)
+	}
+	config := &model.BuilderConfig{
+		PhonyTargets: map[string]bool{"build": true, "test": true, "Deploy_Release": true, "lint": true, "setup": true, "clean": true},
+		Dependencies: map[string][]string{"lint": {"test"}},
+		HasRecipe:    map[string]bool{"build": true, "test": true, "Deploy_Release": true, "lint": true, "setup": true, "clean": true},
+	}
+
+	var first []Warning
+	for i := range 20 {
+		ctx, err := NewCheckContext("Makefile", []string{"Makefile"}, []*parser.ParsedFile{parsed}, config, "")
+		if err != nil {
+			t.Fatalf("NewCheckContext() error = %v", err)
+		}
+		result := Lint(ctx, AllChecks())
+		if i == 0 {
+			first = result.Warnings
+			if len(first) < 5 {
+				t.Fatalf("expected several warnings, got %d", len(first))
+			}
+			continue
+		}
+		if !slices.Equal(first, result.Warnings) {
+			t.Fatalf("run %d reported different warnings:\n%v\nfirst run:\n%v", i, result.Warnings, first)
+		}
+	}
+}
+
+// lintGeneratedHelp lints a Makefile together with the help file generated
+// for it with generatorConfig and returns the warnings.
+func lintGeneratedHelp(t *testing.T, generatorConfig *target.GeneratorConfig) []Warning {
+	t.Helper()
+	content := `## Build the project.
+build:
+	go build
+
+## Run the tests.
+test:
+	go test
+`
+	scanner := parser.NewScanner()
+	parsed, err := scanner.ScanContent(content, "Makefile")
+	if err != nil {
+		t.Fatalf("ScanContent() error = %v", err)
+	}
+	generatorConfig.HelpModel, err = model.NewBuilder(&model.BuilderConfig{}).Build([]*parser.ParsedFile{parsed})
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	helpContent, err := target.GenerateHelpFile(generatorConfig)
+	if err != nil {
+		t.Fatalf("GenerateHelpFile() error = %v", err)
+	}
+	helpParsed, err := scanner.ScanContent(helpContent, "make/help.mk")
+	if err != nil {
+		t.Fatalf("ScanContent() error = %v", err)
+	}
+
+	parsedFiles := []*parser.ParsedFile{parsed, helpParsed}
+	config := &model.BuilderConfig{
+		PhonyTargets: make(map[string]bool),
+		HasRecipe:    make(map[string]bool),
+	}
+	for _, pf := range parsedFiles {
+		for name := range pf.TargetMap {
+			if strings.HasPrefix(name, ".") {
+				continue
+			}
+			config.PhonyTargets[name] = true
+			config.HasRecipe[name] = true
+		}
+	}
+	ctx, err := NewCheckContext("Makefile", []string{"Makefile", "make/help.mk"}, parsedFiles, config, generatorConfig.HelpTargetName)
+	if err != nil {
+		t.Fatalf("NewCheckContext() error = %v", err)
+	}
+	return Lint(ctx, AllChecks()).Warnings
+}
+
+func TestNewCheckContext_GeneratedHelpFile(t *testing.T) {
+	t.Parallel()
+	// The targets make-help generates must not be reported by its own lint
+	tests := []struct {
+		name           string
+		helpTargetName string
+	}{
+		{name: "default help target"},
+		{name: "renamed help target", helpTargetName: "usage"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			warnings := lintGeneratedHelp(t, &target.GeneratorConfig{
+				MaxTargetsPerCategory: 1,
+				HelpTargetName:        tt.helpTargetName,
+			})
+			for _, w := range warnings {
+				t.Errorf("unexpected warning for generated help file: %s", FormatWarning(w))
+			}
+		})
+	}
+}
+
 func TestFormatWarning_WithLine(t *testing.T) {
 	t.Parallel()
 	w := Warning{
@@ -810,6 +951,119 @@ func TestCheckMissingVarDescriptions_WithMissing(t *testing.T) {
 	}
 }
 
+// Tests for CheckVarChoices
+
+func TestCheckVarChoices(t *testing.T) {
+	t.Parallel()
+	ctx := &CheckContext{
+		HelpModel: &model.HelpModel{
+			Categories: []model.Category{
+				{
+					Name: "Deploy",
+					Targets: []model.Target{
+						{
+							Name:       "deploy",
+							SourceFile: "Makefile",
+							LineNumber: 10,
+							Variables: []model.Variable{
+								{Name: "ENV", Choices: []string{"dev", "prod", "dev", "dev"}},
+								{Name: "REGION", Choices: []string{}},
+								{Name: "TIER", Choices: []string{"free", "paid"}},
+								{Name: "TOKEN"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	warnings := CheckVarChoices(ctx)
+	if len(warnings) != 2 {
+		t.Fatalf("Expected 2 warnings, got %d: %+v", len(warnings), warnings)
+	}
+
+	expected := []string{
+		"variable 'ENV' in target 'deploy' lists choice 'dev' more than once",
+		"variable 'REGION' in target 'deploy' has an empty choices list",
+	}
+	for i, w := range warnings {
+		if w.Message != expected[i] {
+			t.Errorf("warnings[%d].Message = %q, want %q", i, w.Message, expected[i])
+		}
+		if w.CheckName != "var-choices" || w.File != "Makefile" || w.Line != 10 {
+			t.Errorf("warnings[%d] = %+v, want var-choices at Makefile:10", i, w)
+		}
+	}
+}
+
+// Tests for CheckVarDescriptions
+
+func TestCheckVarDescriptions(t *testing.T) {
+	t.Parallel()
+	ctx := &CheckContext{
+		HelpModel: &model.HelpModel{
+			Categories: []model.Category{
+				{
+					Name: "Build",
+					Targets: []model.Target{
+						{
+							Name:       "build",
+							SourceFile: "Makefile",
+							LineNumber: 4,
+							Variables: []model.Variable{
+								{Name: "VERBOSE", Description: "Print every command."},
+								{Name: "JOBS", Description: "Parallel jobs"},
+							},
+						},
+						{
+							Name:       "test",
+							SourceFile: "Makefile",
+							LineNumber: 9,
+							Variables: []model.Variable{
+								{Name: "VERBOSE", Description: "print  every command"},
+								{Name: "JOBS"},
+							},
+						},
+					},
+				},
+				{
+					Name: "Deploy",
+					Targets: []model.Target{
+						{
+							Name:       "deploy",
+							SourceFile: "make/deploy.mk",
+							LineNumber: 3,
+							Variables: []model.Variable{
+								{Name: "VERBOSE", Description: "Enable debug logging"},
+								{Name: "JOBS", Description: "Number of parallel jobs"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	warnings := CheckVarDescriptions(ctx)
+	if len(warnings) != 2 {
+		t.Fatalf("Expected 2 warnings, got %d: %+v", len(warnings), warnings)
+	}
+
+	expected := []string{
+		"variable 'VERBOSE' in target 'deploy' is described differently than in target 'build'",
+		"variable 'JOBS' in target 'deploy' is described differently than in target 'build'",
+	}
+	for i, w := range warnings {
+		if w.Message != expected[i] {
+			t.Errorf("warnings[%d].Message = %q, want %q", i, w.Message, expected[i])
+		}
+		if w.CheckName != "var-description" || w.File != "make/deploy.mk" || w.Line != 3 {
+			t.Errorf("warnings[%d] = %+v, want var-description at make/deploy.mk:3", i, w)
+		}
+	}
+}
+
 // Tests for CheckInconsistentNaming
 
 func TestCheckInconsistentNaming_NoWarnings(t *testing.T) {
@@ -1378,3 +1632,643 @@ func TestCheckRedundantDirectives_ValidNotAlias(t *testing.T) {
 		}
 	}
 }
+
+func TestCheckConflictingDefinitions(t *testing.T) {
+	t.Parallel()
+	ctx := &CheckContext{
+		MakefilePath: "/project/Makefile",
+		DefinitionConflicts: []model.DefinitionConflict{
+			{
+				Target: "build",
+				Definitions: []model.RecipeDefinition{
+					{SourceFile: "/project/Makefile", LineNumber: 3},
+					{SourceFile: "/project/make/build.mk", LineNumber: 1},
+				},
+			},
+			{
+				Target: "install",
+				Definitions: []model.RecipeDefinition{
+					{SourceFile: "/project/Makefile", LineNumber: 12, DoubleColon: true},
+					{SourceFile: "/project/make/install.mk", LineNumber: 10},
+				},
+			},
+		},
+	}
+
+	warnings := CheckConflictingDefinitions(ctx)
+	if len(warnings) != 2 {
+		t.Fatalf("Expected 2 warnings, got %d", len(warnings))
+	}
+
+	if warnings[0].File != "/project/make/build.mk" || warnings[0].Line != 1 {
+		t.Errorf("Expected warning at /project/make/build.mk:1, got %s:%d", warnings[0].File, warnings[0].Line)
+	}
+	want := "target 'build' has recipes in Makefile:3, make/build.mk:1; make uses the last one"
+	if warnings[0].Message != want {
+		t.Errorf("Expected message %q, got %q", want, warnings[0].Message)
+	}
+	if !strings.Contains(warnings[1].Message, "mixes single- and double-colon rules") {
+		t.Errorf("Expected mixed-colon message, got %q", warnings[1].Message)
+	}
+	if warnings[0].CheckName != "conflicting-definition" {
+		t.Errorf("Expected check name conflicting-definition, got %s", warnings[0].CheckName)
+	}
+}
+
+func TestCheckConflictingDefinitions_NoConflicts(t *testing.T) {
+	t.Parallel()
+	warnings := CheckConflictingDefinitions(&CheckContext{MakefilePath: "/project/Makefile"})
+	if len(warnings) != 0 {
+		t.Errorf("Expected no warnings, got %d", len(warnings))
+	}
+}
+
+func TestCheckCategoryCasing(t *testing.T) {
+	t.Parallel()
+	ctx := &CheckContext{
+		CategoryDirectives: []parser.Directive{
+			{Type: parser.DirectiveCategory, Value: "build", SourceFile: "Makefile", LineNumber: 1},
+			{Type: parser.DirectiveCategory, Value: "Build", SourceFile: "make/a.mk", LineNumber: 3},
+			{Type: parser.DirectiveCategory, Value: "Build", SourceFile: "make/b.mk", LineNumber: 5},
+			{Type: parser.DirectiveCategory, Value: "BUILD", SourceFile: "make/c.mk", LineNumber: 7},
+			{Type: parser.DirectiveCategory, Value: "Test", SourceFile: "Makefile", LineNumber: 9},
+		},
+	}
+
+	warnings := CheckCategoryCasing(ctx)
+	if len(warnings) != 2 {
+		t.Fatalf("Expected 2 warnings, got %d", len(warnings))
+	}
+	if warnings[0].File != "Makefile" || warnings[0].Line != 1 {
+		t.Errorf("Expected warning at Makefile:1, got %s:%d", warnings[0].File, warnings[0].Line)
+	}
+	if warnings[0].Message != "category 'build' differs only in case from 'Build'" {
+		t.Errorf("Unexpected message: %q", warnings[0].Message)
+	}
+	if warnings[1].Context != "## !category BUILD" || warnings[1].Replacement != "## !category Build" {
+		t.Errorf("Unexpected context/replacement: %q -> %q", warnings[1].Context, warnings[1].Replacement)
+	}
+	if !warnings[1].Fixable {
+		t.Error("Expected warning to be fixable")
+	}
+}
+
+func TestCheckCategoryCasing_TieKeepsFirstSpelling(t *testing.T) {
+	t.Parallel()
+	ctx := &CheckContext{
+		CategoryDirectives: []parser.Directive{
+			{Type: parser.DirectiveCategory, Value: "Deploy", SourceFile: "Makefile", LineNumber: 1},
+			{Type: parser.DirectiveCategory, Value: "deploy", SourceFile: "make/a.mk", LineNumber: 3},
+		},
+	}
+
+	warnings := CheckCategoryCasing(ctx)
+	if len(warnings) != 1 {
+		t.Fatalf("Expected 1 warning, got %d", len(warnings))
+	}
+	if !strings.Contains(warnings[0].Message, "from 'Deploy'") {
+		t.Errorf("Expected first spelling to be canonical, got %q", warnings[0].Message)
+	}
+}
+
+func TestFixCategoryCasing(t *testing.T) {
+	t.Parallel()
+	fix := fixCategoryCasing(Warning{
+		File:        "make/a.mk",
+		Line:        3,
+		Context:     "## !category build",
+		Replacement: "## !category Build",
+	})
+	if fix == nil {
+		t.Fatal("Expected a fix")
+	}
+	if fix.Operation != FixReplace || fix.OldContent != "## !category build" || fix.NewContent != "## !category Build" {
+		t.Errorf("Unexpected fix: %+v", fix)
+	}
+}
+
+func TestCheckSummaryDirectives(t *testing.T) {
+	t.Parallel()
+	ctx := &CheckContext{
+		HelpModel: &model.HelpModel{
+			Categories: []model.Category{
+				{
+					Targets: []model.Target{
+						{
+							Name:            "install",
+							ExplicitSummary: "Install system packages.",
+							Documentation:   []string{"Runs apt or brew, depending on the platform."},
+							SourceFile:      "Makefile",
+							LineNumber:      3,
+						},
+						{
+							Name:            "build",
+							ExplicitSummary: "Build the project",
+							Documentation:   []string{"Compiles every package."},
+							SourceFile:      "Makefile",
+							LineNumber:      7,
+						},
+						{
+							Name:            "test",
+							ExplicitSummary: "Run the tests.",
+							Documentation:   []string{"Run **the** tests. Needs Docker."},
+							SourceFile:      "Makefile",
+							LineNumber:      11,
+						},
+						{
+							Name:          "clean",
+							Documentation: []string{"Clean up"},
+							SourceFile:    "Makefile",
+							LineNumber:    14,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	warnings := CheckSummaryDirectives(ctx)
+	if len(warnings) != 2 {
+		t.Fatalf("Expected 2 warnings, got %d: %+v", len(warnings), warnings)
+	}
+	if warnings[0].Line != 7 || warnings[0].Message != "!summary for 'build' does not end with punctuation" {
+		t.Errorf("Unexpected first warning: %d %q", warnings[0].Line, warnings[0].Message)
+	}
+	if warnings[1].Line != 11 || warnings[1].Message != "!summary for 'test' repeats the first sentence of its documentation" {
+		t.Errorf("Unexpected second warning: %d %q", warnings[1].Line, warnings[1].Message)
+	}
+}
+
+func TestSimilarSentences(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"Run the tests.", "Run the tests.", true},
+		{"Run the unit tests.", "Run **the** unit tests!", true},
+		{"Install system packages.", "Runs apt or brew.", false},
+		{"Build the project.", "", false},
+	}
+	for _, tt := range tests {
+		if got := similarSentences(tt.a, tt.b); got != tt.want {
+			t.Errorf("similarSentences(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestCheckSpelling(t *testing.T) {
+	t.Parallel()
+	dictionary, err := spell.Load("en")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dictionary.Add("frobnicate")
+
+	ctx := &CheckContext{
+		HelpModel: &model.HelpModel{},
+		ProseDirectives: []parser.Directive{
+			{Type: parser.DirectiveDoc, Value: "Build the aplication.", SourceFile: "Makefile", LineNumber: 2},
+			{Type: parser.DirectiveSummary, Value: "Frobnicate the `cnfg` files.", SourceFile: "Makefile", LineNumber: 5},
+			{Type: parser.DirectiveVar, Value: "ENV - Deployment enviroment", SourceFile: "make/deploy.mk", LineNumber: 1},
+		},
+	}
+
+	if warnings := CheckSpelling(ctx); len(warnings) != 0 {
+		t.Errorf("Expected no warnings without a dictionary, got %d", len(warnings))
+	}
+
+	ctx.Dictionary = dictionary
+	warnings := CheckSpelling(ctx)
+	if len(warnings) != 2 {
+		t.Fatalf("Expected 2 warnings, got %d: %+v", len(warnings), warnings)
+	}
+	if warnings[0].Line != 2 || warnings[0].Message != "possible misspelling 'aplication' (did you mean 'application'?)" {
+		t.Errorf("Unexpected first warning: %d %q", warnings[0].Line, warnings[0].Message)
+	}
+	if warnings[1].File != "make/deploy.mk" || warnings[1].Message != "possible misspelling 'enviroment' (did you mean 'environment'?)" {
+		t.Errorf("Unexpected second warning: %s %q", warnings[1].File, warnings[1].Message)
+	}
+}
+
+func TestCheckImperativeMood(t *testing.T) {
+	t.Parallel()
+	ctx := &CheckContext{
+		HelpModel: &model.HelpModel{
+			Categories: []model.Category{
+				{
+					Targets: []model.Target{
+						{Name: "build", Summary: []string{"Builds the project."}, SourceFile: "Makefile", LineNumber: 3},
+						{Name: "run", Summary: []string{"Running the server."}, SourceFile: "Makefile", LineNumber: 6},
+						{Name: "deps", Summary: []string{"**Verifies** dependencies."}, SourceFile: "Makefile", LineNumber: 9},
+						{Name: "deploy", Summary: []string{"Deploy to staging."}, SourceFile: "Makefile", LineNumber: 12},
+						{Name: "docs", Summary: []string{"Documentation for the API."}, SourceFile: "Makefile", LineNumber: 15},
+						{Name: "empty", Summary: []string{}, SourceFile: "Makefile", LineNumber: 18},
+						{Name: "update-help", Summary: []string{"Regenerates help.mk from source Makefiles."}, SourceFile: "help.mk", LineNumber: 3},
+					},
+				},
+			},
+		},
+		GeneratedHelpTargets: map[string]bool{"update-help": true},
+	}
+
+	warnings := CheckImperativeMood(ctx)
+	expected := []string{
+		"summary for 'build' should start with an imperative verb ('Build', not 'Builds')",
+		"summary for 'run' should start with an imperative verb ('Run', not 'Running')",
+		"summary for 'deps' should start with an imperative verb ('Verify', not 'Verifies')",
+	}
+	if len(warnings) != len(expected) {
+		t.Fatalf("Expected %d warnings, got %d: %+v", len(expected), len(warnings), warnings)
+	}
+	for i, w := range warnings {
+		if w.Message != expected[i] {
+			t.Errorf("warning %d: got %q, want %q", i, w.Message, expected[i])
+		}
+		if w.CheckName != "imperative-mood" || w.Fixable {
+			t.Errorf("warning %d: unexpected check %q (fixable %v)", i, w.CheckName, w.Fixable)
+		}
+	}
+}
+
+func TestImperativeForm(t *testing.T) {
+	t.Parallel()
+	tests := map[string]string{
+		"Builds":    "build",
+		"pushes":    "push",
+		"copies":    "copy",
+		"Creating":  "create",
+		"running":   "run",
+		"testing":   "test",
+		"Build":     "",
+		"status":    "",
+		"something": "",
+	}
+	for word, want := range tests {
+		if got := imperativeForm(word); got != want {
+			t.Errorf("imperativeForm(%q) = %q, want %q", word, got, want)
+		}
+	}
+}
+
+func TestWithoutChecks(t *testing.T) {
+	t.Parallel()
+	checks := AllChecks()
+
+	filtered, err := WithoutChecks(checks, []string{"imperative-mood", "spelling"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(filtered) != len(checks)-2 {
+		t.Errorf("Expected %d checks, got %d", len(checks)-2, len(filtered))
+	}
+	for _, c := range filtered {
+		if c.Name == "imperative-mood" || c.Name == "spelling" {
+			t.Errorf("check %q should have been removed", c.Name)
+		}
+	}
+
+	if _, err := WithoutChecks(checks, []string{"no-such-check"}); err == nil {
+		t.Error("Expected error for unknown check name")
+	}
+}
+
+func TestCheckCategoryOrder(t *testing.T) {
+	t.Parallel()
+	ctx := &CheckContext{
+		HelpModel: &model.HelpModel{
+			Categories: []model.Category{
+				{Name: "Build", Targets: []model.Target{{Name: "build"}}},
+				{Name: "Release", Targets: []model.Target{{Name: "release"}}},
+				{Name: "Deploy", Targets: []model.Target{{Name: "deploy"}}},
+				{Name: "Help", Targets: []model.Target{{Name: "update-help"}}},
+			},
+		},
+		GeneratedHelpTargets: map[string]bool{"help": true, "update-help": true},
+		CategoryOrder:        []string{"Build", "Test"},
+		CategoryOrderFile:    "make/help.mk",
+	}
+
+	warnings := CheckCategoryOrder(ctx)
+	expected := []string{
+		"--category-order lists 'Test', which is no longer a category",
+		"category 'Deploy' is missing from --category-order (it is listed after the ordered categories)",
+		"category 'Release' is missing from --category-order (it is listed after the ordered categories)",
+	}
+	if len(warnings) != len(expected) {
+		t.Fatalf("Expected %d warnings, got %d: %+v", len(expected), len(warnings), warnings)
+	}
+	for i, w := range warnings {
+		if w.Message != expected[i] {
+			t.Errorf("warning %d: got %q, want %q", i, w.Message, expected[i])
+		}
+		if w.File != "make/help.mk" {
+			t.Errorf("warning %d: got file %q, want make/help.mk", i, w.File)
+		}
+	}
+
+	ctx.CategoryOrder = nil
+	if warnings := CheckCategoryOrder(ctx); len(warnings) != 0 {
+		t.Errorf("Expected no warnings without a category order, got %+v", warnings)
+	}
+}
+
+func TestCheckDetachedDocs(t *testing.T) {
+	t.Parallel()
+	ctx := &CheckContext{
+		HelpModel: &model.HelpModel{},
+		DetachedDocs: map[string][]parser.DetachedDoc{
+			"Makefile": {
+				{LineNumber: 3, Line: "VERSION := 1.0", FollowingLineNumber: 4},
+				{LineNumber: 8, Line: "build", FollowingLineNumber: 9},
+				{LineNumber: 12, Line: "clean::", FollowingLineNumber: 13},
+			},
+		},
+	}
+
+	warnings := CheckDetachedDocs(ctx)
+	expected := []string{
+		"documentation is ignored: line 4 is a variable assignment, not a target definition",
+		"documentation is ignored: line 9 is 'build', not a target definition",
+		"documentation is ignored: line 13 is a double-colon rule, not a target definition",
+	}
+	if len(warnings) != len(expected) {
+		t.Fatalf("Expected %d warnings, got %d: %+v", len(expected), len(warnings), warnings)
+	}
+	for i, w := range warnings {
+		if w.Message != expected[i] {
+			t.Errorf("warning %d: got %q, want %q", i, w.Message, expected[i])
+		}
+		if w.File != "Makefile" || w.CheckName != "detached-documentation" {
+			t.Errorf("warning %d: unexpected file %q or check %q", i, w.File, w.CheckName)
+		}
+	}
+}
+
+func TestCheckInconsistentNaming_ProposesNames(t *testing.T) {
+	t.Parallel()
+	ctx := &CheckContext{
+		HelpModel: &model.HelpModel{
+			Categories: []model.Category{
+				{
+					Targets: []model.Target{
+						{Name: "buildAll", SourceFile: "Makefile", LineNumber: 2},
+						{Name: "test_all", SourceFile: "Makefile", LineNumber: 5},
+						{Name: "bin/tool", SourceFile: "Makefile", LineNumber: 8},
+					},
+				},
+			},
+		},
+		TargetLocations: map[string]TargetLocation{"test-all": {File: "Makefile", Line: 11}},
+	}
+
+	warnings := CheckInconsistentNaming(ctx)
+	if len(warnings) != 3 {
+		t.Fatalf("Expected 3 warnings, got %d: %+v", len(warnings), warnings)
+	}
+	if warnings[0].Replacement != "build-all" || !strings.HasSuffix(warnings[0].Message, "(rename to 'build-all')") {
+		t.Errorf("Unexpected warning for buildAll: %+v", warnings[0])
+	}
+	if warnings[1].Replacement != "" || !strings.HasSuffix(warnings[1].Message, "(cannot be renamed to 'test-all', which is already a target)") {
+		t.Errorf("Unexpected warning for test_all: %+v", warnings[1])
+	}
+	if warnings[2].Replacement != "" {
+		t.Errorf("Expected no rename for file target, got %q", warnings[2].Replacement)
+	}
+
+	if fixTargetName(warnings[1]) != nil {
+		t.Error("Expected no fix without a proposed name")
+	}
+	fix := fixTargetName(warnings[0])
+	if fix == nil || fix.Operation != FixRename || fix.OldContent != "buildAll" || fix.NewContent != "build-all" {
+		t.Errorf("Unexpected fix: %+v", fix)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	t.Parallel()
+	result := &LintResult{
+		Warnings: []Warning{
+			{File: "Makefile", CheckName: "naming"},
+			{File: "Makefile", CheckName: "summary-punctuation", Fixable: true},
+			{File: "make/build.mk", CheckName: "naming"},
+			{File: "make/build.mk", CheckName: "naming"},
+		},
+		Files: []string{"Makefile", "make/build.mk", "make/test.mk"},
+	}
+
+	stats := Summarize(result)
+	if stats.Total != 4 || stats.Fixable != 1 || stats.Files != 3 {
+		t.Errorf("Unexpected totals: %+v", stats)
+	}
+	wantChecks := []Count{{Name: "naming", Count: 3}, {Name: "summary-punctuation", Count: 1}}
+	if !slices.Equal(stats.ByCheck, wantChecks) {
+		t.Errorf("ByCheck = %+v, want %+v", stats.ByCheck, wantChecks)
+	}
+	wantFiles := []Count{{Name: "Makefile", Count: 2}, {Name: "make/build.mk", Count: 2}}
+	if !slices.Equal(stats.ByFile, wantFiles) {
+		t.Errorf("ByFile = %+v, want %+v", stats.ByFile, wantFiles)
+	}
+}
+
+func TestBaseline_Filter(t *testing.T) {
+	t.Parallel()
+	recorded := []Warning{
+		{File: "/repo/Makefile", Line: 3, CheckName: "naming", Message: "target 'buildAll' does not follow kebab-case naming convention"},
+		{File: "/repo/make/test.mk", Line: 8, CheckName: "long-summary", Message: "summary for 'test' is too long"},
+	}
+	baseline := NewBaseline(recorded, "/repo")
+	if baseline.Version != BaselineVersion || baseline.Warnings[1].File != "make/test.mk" {
+		t.Fatalf("Unexpected baseline: %+v", baseline)
+	}
+	if err := baseline.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	current := []Warning{
+		// Moved down by an edit: still recorded
+		{File: "/repo/Makefile", Line: 10, CheckName: "naming", Message: "target 'buildAll' does not follow kebab-case naming convention"},
+		// Reported twice but recorded once: the second one is new
+		{File: "/repo/make/test.mk", Line: 8, CheckName: "long-summary", Message: "summary for 'test' is too long"},
+		{File: "/repo/make/test.mk", Line: 20, CheckName: "long-summary", Message: "summary for 'test' is too long"},
+		{File: "/repo/Makefile", Line: 12, CheckName: "naming", Message: "target 'test_all' does not follow kebab-case naming convention"},
+	}
+	remaining := baseline.Filter(current, "/repo")
+	if len(remaining) != 2 || remaining[0].Line != 20 || remaining[1].Line != 12 {
+		t.Errorf("Unexpected remaining warnings: %+v", remaining)
+	}
+
+	baseline.Version = 99
+	if err := baseline.Validate(); err == nil {
+		t.Error("Expected error for unsupported version")
+	}
+}
+
+func TestCheckMissingOwners(t *testing.T) {
+	t.Parallel()
+	ctx := &CheckContext{
+		HelpModel: &model.HelpModel{
+			Categories: []model.Category{
+				{
+					Name: "Build",
+					Targets: []model.Target{
+						{Name: "build", SourceFile: "Makefile", LineNumber: 3},
+					},
+				},
+				{
+					Name: "Deploy Staging",
+					Targets: []model.Target{
+						{Name: "deploy", Owner: "release-team", SourceFile: "Makefile", LineNumber: 6},
+						{Name: "rollback", SourceFile: "Makefile", LineNumber: 9},
+					},
+				},
+				{
+					Name: "Release",
+					Targets: []model.Target{
+						{Name: "publish", SourceFile: "release.mk", LineNumber: 2},
+					},
+				},
+			},
+		},
+	}
+
+	if warnings := CheckMissingOwners(ctx); len(warnings) != 0 {
+		t.Errorf("Expected no warnings without OwnerCategories, got %+v", warnings)
+	}
+
+	ctx.OwnerCategories = []string{"Deploy*", "Release", "["}
+	warnings := CheckMissingOwners(ctx)
+	expected := []string{
+		"target 'rollback' in category 'Deploy Staging' has no !owner",
+		"target 'publish' in category 'Release' has no !owner",
+	}
+	if len(warnings) != len(expected) {
+		t.Fatalf("Expected %d warnings, got %d: %+v", len(expected), len(warnings), warnings)
+	}
+	for i, w := range warnings {
+		if w.Message != expected[i] {
+			t.Errorf("warning %d: got %q, want %q", i, w.Message, expected[i])
+		}
+	}
+	if warnings[1].File != "release.mk" || warnings[1].Line != 2 {
+		t.Errorf("Unexpected location: %s:%d", warnings[1].File, warnings[1].Line)
+	}
+}
+
+func TestCheckEntryPoint(t *testing.T) {
+	t.Parallel()
+	ctx := &CheckContext{
+		MakefilePath: "/repo/Makefile",
+		HelpModel: &model.HelpModel{
+			FileDocs: []model.FileDoc{
+				{SourceFile: "/repo/make/build.mk", Documentation: []string{"Build rules."}, IsEntryPoint: true},
+				{SourceFile: "/repo/make/docker.mk", Documentation: []string{"Docker helpers."}},
+			},
+		},
+		ProseDirectives: []parser.Directive{
+			{Type: parser.DirectiveFile, Value: "Build rules.", SourceFile: "/repo/make/build.mk", LineNumber: 3},
+		},
+	}
+
+	warnings := CheckEntryPoint(ctx)
+	if len(warnings) != 1 {
+		t.Fatalf("Expected 1 warning, got %d: %+v", len(warnings), warnings)
+	}
+	if warnings[0].File != "/repo/make/build.mk" || warnings[0].Line != 3 {
+		t.Errorf("Unexpected location: %s:%d", warnings[0].File, warnings[0].Line)
+	}
+	expected := "the main Makefile has no !file documentation, so 'build.mk' introduces the help ahead of 1 other documented file(s); add a !file block to the main Makefile or pass --entry-point"
+	if warnings[0].Message != expected {
+		t.Errorf("got %q, want %q", warnings[0].Message, expected)
+	}
+
+	ctx.EntryPoint = "make/docker.mk"
+	if warnings := CheckEntryPoint(ctx); len(warnings) != 0 {
+		t.Errorf("Expected no warnings with --entry-point, got %+v", warnings)
+	}
+
+	ctx.EntryPoint = ""
+	ctx.HelpModel.FileDocs = append([]model.FileDoc{{SourceFile: "/repo/Makefile", Documentation: []string{"Project."}}}, ctx.HelpModel.FileDocs...)
+	if warnings := CheckEntryPoint(ctx); len(warnings) != 0 {
+		t.Errorf("Expected no warnings with a documented main Makefile, got %+v", warnings)
+	}
+}
+
+func TestCheckMissingCIWorkflows(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	workflows := filepath.Join(dir, ".github", "workflows")
+	if err := os.MkdirAll(workflows, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(workflows, "build.yml"), []byte("name: build\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := &CheckContext{
+		MakefilePath: filepath.Join(dir, "Makefile"),
+		HelpModel: &model.HelpModel{
+			Categories: []model.Category{
+				{
+					Targets: []model.Target{
+						{Name: "build", CIWorkflows: []string{".github/workflows/build.yml"}, SourceFile: "Makefile", LineNumber: 3},
+						{Name: "deploy", CIWorkflows: []string{".github/workflows/build.yml", ".github/workflows/deploy.yml"}, SourceFile: "Makefile", LineNumber: 6},
+						{Name: "clean", SourceFile: "Makefile", LineNumber: 9},
+					},
+				},
+			},
+		},
+	}
+
+	warnings := CheckMissingCIWorkflows(ctx)
+	if len(warnings) != 1 {
+		t.Fatalf("Expected 1 warning, got %d: %+v", len(warnings), warnings)
+	}
+	expected := "target 'deploy' links to CI workflow '.github/workflows/deploy.yml', which does not exist"
+	if warnings[0].Message != expected {
+		t.Errorf("got %q, want %q", warnings[0].Message, expected)
+	}
+	if warnings[0].CheckName != "missing-ci-workflow" || warnings[0].Line != 6 {
+		t.Errorf("Unexpected warning: %+v", warnings[0])
+	}
+}
+
+func TestCheckStaleDocs(t *testing.T) {
+	t.Parallel()
+	day := func(d int) time.Time { return time.Date(2026, time.January, d, 0, 0, 0, 0, time.UTC) }
+	ctx := &CheckContext{
+		HelpModel: &model.HelpModel{
+			Categories: []model.Category{
+				{
+					Targets: []model.Target{
+						{Name: "build", SourceFile: "Makefile", LineNumber: 3},
+						{Name: "test", SourceFile: "Makefile", LineNumber: 7},
+						{Name: "lint", SourceFile: "Makefile", LineNumber: 11},
+					},
+				},
+			},
+		},
+		FreshnessThreshold: 7 * 24 * time.Hour,
+	}
+
+	if warnings := CheckStaleDocs(ctx); len(warnings) != 0 {
+		t.Errorf("Expected no warnings without History, got %+v", warnings)
+	}
+
+	ctx.History = map[string]TargetHistory{
+		"build": {DocsChanged: day(1), RecipeChanged: day(20)},
+		"test":  {DocsChanged: day(1), RecipeChanged: day(8)},
+		"lint":  {DocsChanged: day(20), RecipeChanged: day(1)},
+	}
+	warnings := CheckStaleDocs(ctx)
+	if len(warnings) != 1 {
+		t.Fatalf("Expected 1 warning, got %d: %+v", len(warnings), warnings)
+	}
+	expected := "recipe of 'build' changed 19 days after its documentation (recipe 2026-01-20, documentation 2026-01-01)"
+	if warnings[0].Message != expected {
+		t.Errorf("got %q, want %q", warnings[0].Message, expected)
+	}
+	if warnings[0].CheckName != "stale-documentation" || warnings[0].Line != 3 {
+		t.Errorf("Unexpected warning: %+v", warnings[0])
+	}
+}
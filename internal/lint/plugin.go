@@ -0,0 +1,209 @@
+package lint
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sdlcforge/make-help/internal/format"
+)
+
+// PluginPrefix starts the names of lint plugin executables found on PATH.
+// The rest of the name is the plugin's check name.
+const PluginPrefix = "make-help-check-"
+
+// PluginTimeout is how long a lint plugin may run.
+const PluginTimeout = 30 * time.Second
+
+// pluginProtocolVersion is the version of the document plugins read.
+const pluginProtocolVersion = 1
+
+// pluginInput is the JSON document a plugin reads on stdin.
+type pluginInput struct {
+	ProtocolVersion int `json:"protocolVersion"`
+
+	// Makefile is the absolute path of the main Makefile.
+	Makefile string `json:"makefile"`
+
+	// Makefiles lists every Makefile checked, the main one first.
+	Makefiles []string `json:"makefiles"`
+
+	// PhonyTargets lists the .PHONY targets, sorted.
+	PhonyTargets []string `json:"phonyTargets"`
+
+	// Model is the help model as printed by --format json.
+	Model json.RawMessage `json:"model"`
+}
+
+// pluginOutput is the JSON document a plugin writes on stdout.
+type pluginOutput struct {
+	Warnings []pluginWarning `json:"warnings"`
+}
+
+// pluginWarning is a warning reported by a plugin. File defaults to the
+// main Makefile and Severity to "warning".
+type pluginWarning struct {
+	File     string   `json:"file"`
+	Line     int      `json:"line"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+	Context  string   `json:"context"`
+}
+
+// FindPlugins returns the lint plugin executables in the directories of
+// path, a PATH value, sorted by name. As with command lookup, the first
+// directory holding a name wins.
+func FindPlugins(path string) []string {
+	found := make(map[string]string)
+	for _, dir := range filepath.SplitList(path) {
+		if dir == "" {
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name := entry.Name()
+			if !strings.HasPrefix(name, PluginPrefix) || name == PluginPrefix || found[name] != "" {
+				continue
+			}
+			file := filepath.Join(dir, name)
+			if info, err := os.Stat(file); err != nil || !info.Mode().IsRegular() || info.Mode().Perm()&0111 == 0 {
+				continue
+			}
+			found[name] = file
+		}
+	}
+
+	names := make([]string, 0, len(found))
+	for name := range found {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	plugins := make([]string, len(names))
+	for i, name := range names {
+		plugins[i] = found[name]
+	}
+	return plugins
+}
+
+// PluginName returns the check name of the plugin executable at path: its
+// file name without PluginPrefix.
+func PluginName(path string) string {
+	return strings.TrimPrefix(filepath.Base(path), PluginPrefix)
+}
+
+// PluginCheck returns a check that runs the plugin executable at path in the
+// main Makefile's directory. The plugin reads the help model and the
+// Makefile paths as JSON on stdin and writes {"warnings": [...]} on stdout.
+// A plugin that fails, times out, or writes anything else is reported as an
+// error-level warning on the main Makefile.
+func PluginCheck(path string) Check {
+	name := PluginName(path)
+	return Check{
+		Name: name,
+		CheckFunc: func(ctx *CheckContext) []Warning {
+			warnings, err := runPlugin(path, name, ctx)
+			if err != nil {
+				return []Warning{{
+					File:      ctx.MakefilePath,
+					Severity:  SeverityError,
+					CheckName: name,
+					Message:   fmt.Sprintf("lint plugin %s failed: %v", path, err),
+				}}
+			}
+			return warnings
+		},
+	}
+}
+
+// runPlugin runs the plugin at path on ctx and returns its warnings.
+func runPlugin(path, name string, ctx *CheckContext) ([]Warning, error) {
+	var model bytes.Buffer
+	formatter := format.NewJSONFormatter(&format.FormatterConfig{MakefileDir: filepath.Dir(ctx.MakefilePath)})
+	if err := formatter.RenderHelp(ctx.HelpModel, &model); err != nil {
+		return nil, err
+	}
+
+	input := pluginInput{
+		ProtocolVersion: pluginProtocolVersion,
+		Makefile:        ctx.MakefilePath,
+		Makefiles:       ctx.Makefiles,
+		PhonyTargets:    []string{},
+		Model:           model.Bytes(),
+	}
+	for target, phony := range ctx.PhonyTargets {
+		if phony {
+			input.PhonyTargets = append(input.PhonyTargets, target)
+		}
+	}
+	sort.Strings(input.PhonyTargets)
+	stdin, err := json.Marshal(input)
+	if err != nil {
+		return nil, err
+	}
+
+	runCtx, cancel := context.WithTimeout(context.Background(), PluginTimeout)
+	defer cancel()
+	var stdout, stderr bytes.Buffer
+	command := exec.CommandContext(runCtx, path)
+	command.Dir = filepath.Dir(ctx.MakefilePath)
+	command.Stdin = bytes.NewReader(stdin)
+	command.Stdout = &stdout
+	command.Stderr = &stderr
+	if err := command.Run(); err != nil {
+		if runCtx.Err() != nil {
+			return nil, fmt.Errorf("timed out after %s", PluginTimeout)
+		}
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return nil, fmt.Errorf("%w: %s", err, message)
+		}
+		return nil, err
+	}
+
+	if len(bytes.TrimSpace(stdout.Bytes())) == 0 {
+		return nil, nil
+	}
+	var output pluginOutput
+	decoder := json.NewDecoder(&stdout)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&output); err != nil {
+		return nil, fmt.Errorf("invalid output: %w", err)
+	}
+
+	warnings := make([]Warning, 0, len(output.Warnings))
+	for _, w := range output.Warnings {
+		if w.Message == "" {
+			return nil, fmt.Errorf("invalid output: warning without a message")
+		}
+		switch w.Severity {
+		case "":
+			w.Severity = SeverityWarning
+		case SeverityWarning, SeverityError:
+		default:
+			return nil, fmt.Errorf("invalid output: unknown severity %q", w.Severity)
+		}
+		if w.File == "" {
+			w.File = ctx.MakefilePath
+		} else if !filepath.IsAbs(w.File) {
+			w.File = filepath.Join(filepath.Dir(ctx.MakefilePath), w.File)
+		}
+		warnings = append(warnings, Warning{
+			File:      w.File,
+			Line:      w.Line,
+			Severity:  w.Severity,
+			CheckName: name,
+			Message:   w.Message,
+			Context:   w.Context,
+		})
+	}
+	return warnings, nil
+}
@@ -0,0 +1,131 @@
+package lint
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sdlcforge/make-help/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writePlugin writes an executable shell script named name to dir.
+func writePlugin(t *testing.T, dir, name, script string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0755))
+	return path
+}
+
+// pluginContext returns a CheckContext for a Makefile in a new directory.
+func pluginContext(t *testing.T) *CheckContext {
+	t.Helper()
+	dir := t.TempDir()
+	return &CheckContext{
+		HelpModel: &model.HelpModel{Categories: []model.Category{{
+			Name:    "Build",
+			Targets: []model.Target{{Name: "build", Documentation: []string{"Build it."}, Summary: []string{"Build it."}}},
+		}}},
+		MakefilePath: filepath.Join(dir, "Makefile"),
+		Makefiles:    []string{filepath.Join(dir, "Makefile")},
+		PhonyTargets: map[string]bool{"build": true, "clean": true, "out": false},
+	}
+}
+
+func TestFindPlugins(t *testing.T) {
+	t.Parallel()
+	first, second := t.TempDir(), t.TempDir()
+	ticket := writePlugin(t, first, "make-help-check-ticket", "")
+	writePlugin(t, second, "make-help-check-ticket", "")
+	owner := writePlugin(t, second, "make-help-check-owner", "")
+	require.NoError(t, os.WriteFile(filepath.Join(first, "make-help-check-notexec"), nil, 0644))
+	writePlugin(t, first, "other-tool", "")
+
+	plugins := FindPlugins(first + string(os.PathListSeparator) + filepath.Join(first, "missing") + string(os.PathListSeparator) + second)
+
+	assert.Equal(t, []string{owner, ticket}, plugins)
+	assert.Equal(t, "ticket", PluginName(ticket))
+}
+
+func TestPluginCheck(t *testing.T) {
+	t.Parallel()
+	ctx := pluginContext(t)
+	dir := filepath.Dir(ctx.MakefilePath)
+	plugin := writePlugin(t, t.TempDir(), "make-help-check-ticket", `cat > input.json
+cat <<'EOF'
+{"warnings": [
+  {"line": 3, "message": "target build does not reference a ticket", "context": "build:"},
+  {"file": "other.mk", "severity": "error", "message": "no owner"}
+]}
+EOF
+`)
+
+	warnings := PluginCheck(plugin).CheckFunc(ctx)
+
+	assert.Equal(t, []Warning{
+		{File: ctx.MakefilePath, Line: 3, Severity: SeverityWarning, CheckName: "ticket", Message: "target build does not reference a ticket", Context: "build:"},
+		{File: filepath.Join(dir, "other.mk"), Severity: SeverityError, CheckName: "ticket", Message: "no owner"},
+	}, warnings)
+
+	// The plugin runs in the Makefile directory and reads the model
+	data, err := os.ReadFile(filepath.Join(dir, "input.json"))
+	require.NoError(t, err)
+	var input struct {
+		ProtocolVersion int      `json:"protocolVersion"`
+		Makefile        string   `json:"makefile"`
+		PhonyTargets    []string `json:"phonyTargets"`
+		Model           struct {
+			Categories []struct {
+				Targets []struct {
+					Name string `json:"name"`
+				} `json:"targets"`
+			} `json:"categories"`
+		} `json:"model"`
+	}
+	require.NoError(t, json.Unmarshal(data, &input))
+	assert.Equal(t, 1, input.ProtocolVersion)
+	assert.Equal(t, ctx.MakefilePath, input.Makefile)
+	assert.Equal(t, []string{"build", "clean"}, input.PhonyTargets)
+	require.Len(t, input.Model.Categories, 1)
+	assert.Equal(t, "build", input.Model.Categories[0].Targets[0].Name)
+}
+
+func TestPluginCheck_NoOutput(t *testing.T) {
+	t.Parallel()
+	plugin := writePlugin(t, t.TempDir(), "make-help-check-quiet", "cat > /dev/null\n")
+
+	assert.Empty(t, PluginCheck(plugin).CheckFunc(pluginContext(t)))
+}
+
+func TestPluginCheck_Failures(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		script string
+		want   string
+	}{
+		{"exit status", "echo 'config missing' >&2\nexit 2\n", "exit status 2: config missing"},
+		{"invalid JSON", "echo 'not json'\n", "invalid output"},
+		{"unknown field", `echo '{"warnings": [], "extra": 1}'` + "\n", "invalid output"},
+		{"no message", `echo '{"warnings": [{"line": 1}]}'` + "\n", "warning without a message"},
+		{"bad severity", `echo '{"warnings": [{"message": "x", "severity": "fatal"}]}'` + "\n", `unknown severity "fatal"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := pluginContext(t)
+			plugin := writePlugin(t, t.TempDir(), "make-help-check-broken", "cat > /dev/null\n"+tt.script)
+
+			warnings := PluginCheck(plugin).CheckFunc(ctx)
+
+			require.Len(t, warnings, 1)
+			assert.Equal(t, ctx.MakefilePath, warnings[0].File)
+			assert.Equal(t, SeverityError, warnings[0].Severity)
+			assert.Equal(t, "broken", warnings[0].CheckName)
+			assert.Contains(t, warnings[0].Message, "lint plugin "+plugin+" failed")
+			assert.Contains(t, warnings[0].Message, tt.want)
+		})
+	}
+}
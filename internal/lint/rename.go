@@ -0,0 +1,182 @@
+package lint
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/sdlcforge/make-help/internal/parser"
+)
+
+// ruleWord matches one name in the target or prerequisite list of a rule line.
+var ruleWord = regexp.MustCompile(`[^\s:|&;]+`)
+
+// shellOperator matches the operators separating the commands of a recipe
+// line.
+var shellOperator = regexp.MustCompile(`&&|\|\||[;|&]`)
+
+// shellWord matches one word of a recipe command.
+var shellWord = regexp.MustCompile(`\S+`)
+
+// makeOptionsWithArg are the make options that take the next word as their
+// value, so it is not a goal.
+var makeOptionsWithArg = []string{"-I", "-o", "-W", "--include-dir", "--old-file", "--what-if", "--assume-old", "--assume-new"}
+
+// kebabCase converts a target name to kebab-case: "buildAll", "build_all",
+// and "Build.All" all become "build-all", and "runHTTPServer" becomes
+// "run-http-server". Returns "" when the name cannot be converted to a valid
+// kebab-case name.
+func kebabCase(name string) string {
+	runes := []rune(name)
+	var sb strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			// Start a new word at "aB" and at the last capital of "ABc"
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				sb.WriteRune('-')
+			}
+		}
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			sb.WriteRune(unicode.ToLower(r))
+		} else {
+			sb.WriteRune('-')
+		}
+	}
+
+	parts := strings.FieldsFunc(sb.String(), func(r rune) bool { return r == '-' })
+	converted := strings.Join(parts, "-")
+	if !kebabCasePattern.MatchString(converted) {
+		return ""
+	}
+	return converted
+}
+
+// renameRuleLine renames targets in a rule or .PHONY line, leaving an inline
+// recipe ("; cmd") and target-specific variable values untouched. Returns
+// the line unchanged when it is not a rule line.
+func renameRuleLine(line string, renames map[string]string) string {
+	if !parser.IsTargetLine(line) {
+		return line
+	}
+	if parser.ExtractTargetName(line) == "" && parser.ExtractDoubleColonTargetName(line) == "" {
+		return line
+	}
+
+	// Only the target and prerequisite lists name targets
+	end := len(line)
+	if i := strings.Index(line, ";"); i >= 0 {
+		end = i
+	}
+	names := line[:end]
+	if colon := strings.Index(names, ":"); colon >= 0 && strings.Contains(names[colon:], "=") {
+		// "target: VAR = value" sets a variable; rename the targets only
+		end = colon
+		names = line[:end]
+	}
+
+	renamed := ruleWord.ReplaceAllStringFunc(names, func(word string) string {
+		if newName, ok := renames[word]; ok {
+			return newName
+		}
+		return word
+	})
+	rest := line[end:]
+	if strings.HasPrefix(rest, ";") {
+		rest = ";" + renameRecipe(rest[1:], renames)
+	}
+	return renamed + rest
+}
+
+// renameRecipe renames the goals of the commands of a recipe that run make
+// again on the same Makefile: "$(MAKE) buildAll" becomes
+// "$(MAKE) build-all". Invocations with -C or -f run another Makefile and
+// are left alone, as is every other command.
+func renameRecipe(recipe string, renames map[string]string) string {
+	var sb strings.Builder
+	start := 0
+	for _, op := range shellOperator.FindAllStringIndex(recipe, -1) {
+		sb.WriteString(renameMakeCommand(recipe[start:op[0]], renames))
+		sb.WriteString(recipe[op[0]:op[1]])
+		start = op[1]
+	}
+	sb.WriteString(renameMakeCommand(recipe[start:], renames))
+	return sb.String()
+}
+
+// renameMakeCommand renames the goals of command when it runs make, after
+// any recipe prefix characters and variable assignments.
+func renameMakeCommand(command string, renames map[string]string) string {
+	words := shellWord.FindAllStringIndex(command, -1)
+	i := 0
+	for i < len(words) && strings.Contains(command[words[i][0]:words[i][1]], "=") {
+		i++
+	}
+	if i == len(words) {
+		return command
+	}
+	switch strings.TrimLeft(command[words[i][0]:words[i][1]], "@+-") {
+	case "$(MAKE)", "${MAKE}", "make":
+	default:
+		return command
+	}
+
+	var goals [][]int
+	for i++; i < len(words); i++ {
+		word := command[words[i][0]:words[i][1]]
+		switch {
+		case strings.HasPrefix(word, "-C") || strings.HasPrefix(word, "-f") ||
+			strings.HasPrefix(word, "--directory") || strings.HasPrefix(word, "--file") || strings.HasPrefix(word, "--makefile"):
+			// Another Makefile, whose targets are not renamed
+			return command
+		case slices.Contains(makeOptionsWithArg, word):
+			i++
+		case renames[word] != "":
+			goals = append(goals, words[i])
+		}
+	}
+
+	for j := len(goals) - 1; j >= 0; j-- {
+		goal := goals[j]
+		command = command[:goal[0]] + renames[command[goal[0]:goal[1]]] + command[goal[1]:]
+	}
+	return command
+}
+
+// renameLine renames targets in one line of a Makefile: in a rule or .PHONY
+// line, or in a recipe line running make again.
+func renameLine(line string, renames map[string]string) string {
+	if strings.HasPrefix(line, "\t") {
+		return "\t" + renameRecipe(line[1:], renames)
+	}
+	return renameRuleLine(line, renames)
+}
+
+// RenameFixes returns the fixes that rename targets throughout files: the
+// rules defining them, the prerequisite lists naming them, .PHONY lines,
+// and the goals of recipes running make again on the same Makefile
+// ("$(MAKE) buildAll"). renames maps current names to new names. Other
+// recipe commands and comments are left alone.
+func RenameFixes(files []string, renames map[string]string) ([]Fix, error) {
+	var fixes []Fix
+	for _, file := range files {
+		lines, err := readFileLines(file)
+		if err != nil {
+			return nil, err
+		}
+		for i, line := range lines {
+			if renamed := renameLine(line, renames); renamed != line {
+				fixes = append(fixes, Fix{
+					File:       file,
+					Line:       i + 1,
+					Operation:  FixReplace,
+					OldContent: line,
+					NewContent: renamed,
+				})
+			}
+		}
+	}
+	return fixes, nil
+}
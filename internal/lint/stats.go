@@ -0,0 +1,64 @@
+package lint
+
+import "sort"
+
+// Stats aggregates lint warnings for summaries and quality reports.
+type Stats struct {
+	// Total is the number of warnings.
+	Total int `json:"total"`
+
+	// Fixable is the number of warnings --fix can fix.
+	Fixable int `json:"fixable"`
+
+	// Files is the number of Makefiles checked.
+	Files int `json:"files"`
+
+	// ByCheck counts warnings per check, most frequent first.
+	ByCheck []Count `json:"byCheck"`
+
+	// ByFile counts warnings per file, most frequent first.
+	ByFile []Count `json:"byFile"`
+}
+
+// Count is the number of warnings attributed to a check or file.
+type Count struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// Summarize aggregates the warnings of a lint result.
+func Summarize(result *LintResult) *Stats {
+	stats := &Stats{
+		Total: len(result.Warnings),
+		Files: len(result.Files),
+	}
+
+	byCheck := make(map[string]int)
+	byFile := make(map[string]int)
+	for _, w := range result.Warnings {
+		if w.Fixable {
+			stats.Fixable++
+		}
+		byCheck[w.CheckName]++
+		byFile[w.File]++
+	}
+	stats.ByCheck = sortedCounts(byCheck)
+	stats.ByFile = sortedCounts(byFile)
+
+	return stats
+}
+
+// sortedCounts orders counts by frequency, then by name for ties.
+func sortedCounts(counts map[string]int) []Count {
+	result := make([]Count, 0, len(counts))
+	for name, count := range counts {
+		result = append(result, Count{Name: name, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
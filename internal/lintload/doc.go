@@ -0,0 +1,8 @@
+// Package lintload builds the lint context of a Makefile: it discovers and
+// parses the Makefiles, builds the help model, applies the project's
+// .make-help.json and .makehelpignore, and selects the checks to run.
+//
+// "make-help --lint" and the public pkg/lint API share this code, so a
+// Makefile is linted the same way from the command line and from other Go
+// tools. Settings that come from flags are passed in Options.
+package lintload
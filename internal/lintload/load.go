@@ -0,0 +1,252 @@
+package lintload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sdlcforge/make-help/internal/discovery"
+	"github.com/sdlcforge/make-help/internal/lint"
+	"github.com/sdlcforge/make-help/internal/model"
+	"github.com/sdlcforge/make-help/internal/parser"
+	"github.com/sdlcforge/make-help/internal/projectconfig"
+	"github.com/sdlcforge/make-help/internal/spell"
+	"github.com/sdlcforge/make-help/internal/target"
+	"github.com/spf13/pflag"
+)
+
+// Options configures Load and Context. The zero value matches
+// "make-help --lint" without flags.
+type Options struct {
+	// DefaultCategory and EntryPoint mirror --default-category and
+	// --entry-point.
+	DefaultCategory string
+	EntryPoint      string
+
+	// CategoryOrder mirrors --category-order. When empty, the order recorded
+	// in the generated help file is checked, if any.
+	CategoryOrder []string
+
+	// HelpFileRelPath mirrors --help-file-rel-path, to find the generated
+	// help file.
+	HelpFileRelPath string
+
+	// SpellLang enables the spelling check with the dictionary of the
+	// language (e.g., "en"), as --spell does. Empty skips it.
+	SpellLang string
+
+	// History returns the git history of the targets for the freshness
+	// check, whose threshold is FreshnessThreshold. Nil skips the check.
+	History            func(*model.HelpModel) (map[string]lint.TargetHistory, error)
+	FreshnessThreshold time.Duration
+
+	// NoPlugins skips the lint plugins, as --no-plugins does.
+	// NoProjectPlugins skips only those configured in .make-help.json, which
+	// run the project's code (--no-exec and --sandbox).
+	NoPlugins        bool
+	NoProjectPlugins bool
+
+	// Rename keeps the checks' fixes that rename targets, as --rename does.
+	Rename bool
+
+	// FilterMakefiles, if set, selects the discovered Makefiles to lint
+	// (--hook lints the changed ones).
+	FilterMakefiles func(makefiles []string) []string
+
+	// Verbose reports progress on stderr.
+	Verbose bool
+}
+
+// Load discovers the Makefiles and targets of makefilePath with service,
+// parses the Makefiles, and returns the context and checks to lint them
+// with, as Context does. makefilePath must be resolved and exist.
+func Load(ctx context.Context, service *discovery.Service, makefilePath string, options *Options) (*lint.CheckContext, []lint.Check, error) {
+	if options == nil {
+		options = &Options{}
+	}
+
+	// Discover all Makefiles (main + included)
+	makefiles, err := service.DiscoverMakefiles(ctx, makefilePath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to discover Makefiles: %w", err)
+	}
+
+	projectConfig, err := projectconfig.Load(filepath.Dir(makefilePath))
+	if err != nil {
+		return nil, nil, err
+	}
+	makefiles = projectConfig.Ignore.SkipMakefiles(makefiles, makefilePath, options.Verbose)
+	if options.FilterMakefiles != nil {
+		makefiles = options.FilterMakefiles(makefiles)
+	}
+
+	// Parse all Makefiles
+	scanner := parser.NewScanner()
+	var parsedFiles []*parser.ParsedFile
+
+	for _, mf := range makefiles {
+		parsed, err := scanner.ScanFile(mf)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse %s: %w", mf, err)
+		}
+		parsedFiles = append(parsedFiles, parsed)
+	}
+
+	if options.Verbose {
+		fmt.Fprintf(os.Stderr, "Parsed %d Makefile(s)\n", len(parsedFiles))
+	}
+
+	// Discover targets with .PHONY status, dependencies, and recipes
+	targetsResult, err := service.DiscoverTargets(ctx, makefilePath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to discover targets: %w", err)
+	}
+
+	return Context(makefilePath, makefiles, parsedFiles, targetsResult, options)
+}
+
+// Context builds the help model from discovered and parsed Makefiles and
+// returns the context and checks to lint it with: the built-in checks and
+// the lint plugins, minus lint.disable in .make-help.json.
+func Context(makefilePath string, makefiles []string, parsedFiles []*parser.ParsedFile, targetsResult *discovery.DiscoverTargetsResult, options *Options) (*lint.CheckContext, []lint.Check, error) {
+	if options == nil {
+		options = &Options{}
+	}
+	projectConfig, err := projectconfig.Load(filepath.Dir(makefilePath))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Build the help model
+	// For lint mode, we don't want to include undocumented targets
+	builderConfig := &model.BuilderConfig{
+		DefaultCategory: options.DefaultCategory,
+		IncludeTargets:  []string{},
+		IncludeAllPhony: false,
+		PhonyTargets:    targetsResult.IsPhony,
+		Dependencies:    targetsResult.Dependencies,
+		HasRecipe:       targetsResult.HasRecipe,
+		Ignore:          projectConfig.Ignore,
+		CategoryRename:  projectConfig.Categories.Rename,
+		BaseDir:         filepath.Dir(makefilePath),
+		EntryPoint:      options.EntryPoint,
+		// Hidden targets are still documented and must not be reported as undocumented
+		IncludeHidden: true,
+	}
+	recorded := readRecordedSettings(makefilePath, options)
+	checkCtx, err := lint.NewCheckContext(makefilePath, makefiles, parsedFiles, builderConfig, recorded.helpTargetName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build help model: %w", err)
+	}
+	helpModel := checkCtx.HelpModel
+
+	if options.Verbose {
+		fmt.Fprintf(os.Stderr, "Built help model with %d category/categories\n", len(helpModel.Categories))
+	}
+
+	// Configure the optional checks
+	checkCtx.CategoryOrder, checkCtx.CategoryOrderFile = categoryOrder(makefilePath, options, recorded)
+	checkCtx.OwnerCategories = projectConfig.Lint.RequireOwner
+
+	if options.SpellLang != "" {
+		dictionary, err := loadDictionary(options.SpellLang, makefilePath)
+		if err != nil {
+			return nil, nil, err
+		}
+		checkCtx.Dictionary = dictionary
+	}
+
+	if options.History != nil {
+		history, err := options.History(helpModel)
+		if err != nil {
+			return nil, nil, err
+		}
+		checkCtx.History = history
+		checkCtx.FreshnessThreshold = options.FreshnessThreshold
+	}
+
+	// Select the built-in checks, the plugins, and lint.disable
+	plugins, err := lintPlugins(makefilePath, projectConfig.Lint.Plugins, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	checks, err := lint.WithoutChecks(append(lint.AllChecks(), plugins...), projectConfig.Lint.Disable)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid lint.disable in %s: %w", projectconfig.FileName, err)
+	}
+	if !options.Rename {
+		checks = lint.WithoutRenames(checks)
+	}
+	return checkCtx, checks, nil
+}
+
+// loadDictionary returns the embedded dictionary for lang, extended with the
+// project dictionary next to the Makefile when there is one.
+func loadDictionary(lang, makefilePath string) (*spell.Dictionary, error) {
+	dictionary, err := spell.Load(lang)
+	if err != nil {
+		return nil, err
+	}
+	err = dictionary.AddFile(filepath.Join(filepath.Dir(makefilePath), spell.ProjectDictFile))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	return dictionary, nil
+}
+
+// categoryOrder returns the explicit category order to check and the file
+// it comes from: options.CategoryOrder when given, otherwise the order
+// recorded in an existing generated help file. Returns nil when there is
+// none.
+func categoryOrder(makefilePath string, options *Options, recorded recordedSettings) ([]string, string) {
+	if len(options.CategoryOrder) > 0 {
+		return options.CategoryOrder, makefilePath
+	}
+	if len(recorded.categoryOrder) == 0 {
+		return nil, ""
+	}
+	return recorded.categoryOrder, recorded.helpFile
+}
+
+// recordedSettings holds the options of the command line recorded in a
+// generated help file that lint reads.
+type recordedSettings struct {
+	helpFile       string
+	categoryOrder  []string
+	helpTargetName string
+}
+
+// readRecordedSettings reads the --category-order and --help-target-name
+// recorded in the command line of an existing generated help file, so lint
+// checks the order the help was generated with and does not report the
+// targets make-help wrote itself. Other recorded options are skipped.
+func readRecordedSettings(makefilePath string, options *Options) recordedSettings {
+	helpFile, err := target.FindExistingHelpFile(makefilePath, options.HelpFileRelPath)
+	if err != nil || helpFile == "" {
+		return recordedSettings{}
+	}
+	cmdLine, err := target.ExtractCommandLineFromHelpFile(helpFile)
+	if err != nil || !strings.HasPrefix(cmdLine, "make-help") {
+		return recordedSettings{}
+	}
+
+	settings := recordedSettings{helpFile: helpFile}
+	flags := pflag.NewFlagSet("make-help", pflag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	flags.ParseErrorsAllowlist.UnknownFlags = true
+	flags.StringSliceVar(&settings.categoryOrder, "category-order", nil, "")
+	flags.StringVar(&settings.helpTargetName, "help-target-name", "", "")
+	if err := flags.Parse(strings.Fields(strings.TrimPrefix(cmdLine, "make-help"))); err != nil {
+		if options.Verbose {
+			fmt.Fprintf(os.Stderr, "Warning: failed to parse command line from %s: %v\n", helpFile, err)
+		}
+		return recordedSettings{}
+	}
+	return settings
+}
@@ -0,0 +1,97 @@
+package lintload
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sdlcforge/make-help/internal/discovery"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	makefilePath := filepath.Join(tmpDir, "Makefile")
+	require.NoError(t, os.WriteFile(makefilePath, []byte(".PHONY: build\n## Build the project.\nbuild:\n\t@echo building\n"), 0644))
+
+	service := discovery.NewService(discovery.NewDefaultExecutor(), false)
+	checkCtx, checks, err := Load(context.Background(), service, makefilePath, nil)
+	require.NoError(t, err)
+	assert.True(t, checkCtx.DocumentedTargets["build"])
+	assert.True(t, checkCtx.GeneratedHelpTargets["help"])
+	assert.NotEmpty(t, checks)
+}
+
+func TestCategoryOrder(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	makefilePath := filepath.Join(tmpDir, "Makefile")
+	require.NoError(t, os.WriteFile(makefilePath, []byte("all:\n"), 0644))
+
+	// No help file and no flag: nothing to check
+	order, file := categoryOrder(makefilePath, &Options{}, readRecordedSettings(makefilePath, &Options{}))
+	assert.Empty(t, order)
+	assert.Empty(t, file)
+
+	// The order recorded in the generated help file
+	helpFile := filepath.Join(tmpDir, "make", "help.mk")
+	require.NoError(t, os.MkdirAll(filepath.Dir(helpFile), 0755))
+	require.NoError(t, os.WriteFile(helpFile, []byte(
+		"# generated-by: make-help\n# command: make-help --no-color --summary-width 40 --category-order Build,Test\n"), 0644))
+	recorded := readRecordedSettings(makefilePath, &Options{})
+	order, file = categoryOrder(makefilePath, &Options{}, recorded)
+	assert.Equal(t, []string{"Build", "Test"}, order)
+	assert.Equal(t, helpFile, file)
+
+	// --category-order takes precedence
+	options := &Options{CategoryOrder: []string{"Deploy"}}
+	order, file = categoryOrder(makefilePath, options, recorded)
+	assert.Equal(t, []string{"Deploy"}, order)
+	assert.Equal(t, makefilePath, file)
+}
+
+func TestReadRecordedSettings_HelpTargetName(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	makefilePath := filepath.Join(tmpDir, "Makefile")
+	require.NoError(t, os.WriteFile(makefilePath, []byte("all:\n"), 0644))
+
+	// No help file: the default name
+	assert.Empty(t, readRecordedSettings(makefilePath, &Options{}).helpTargetName)
+
+	// The name recorded in the generated help file
+	helpFile := filepath.Join(tmpDir, "make", "help.mk")
+	require.NoError(t, os.MkdirAll(filepath.Dir(helpFile), 0755))
+	require.NoError(t, os.WriteFile(helpFile, []byte(
+		"# generated-by: make-help\n# command: make-help --no-color --help-target-name usage\n"), 0644))
+	assert.Equal(t, "usage", readRecordedSettings(makefilePath, &Options{}).helpTargetName)
+}
+
+func TestLintPlugins_Errors(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	makefilePath := filepath.Join(tmpDir, "Makefile")
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "make-help-check-naming"), []byte("#!/bin/sh\n"), 0755))
+
+	_, err := lintPlugins(makefilePath, []string{"./make-help-check-naming"}, &Options{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "built-in naming check")
+
+	_, err = lintPlugins(makefilePath, []string{"./missing"}, &Options{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid lint.plugins")
+
+	_, err = lintPlugins(makefilePath, []string{"make-help-check-not-installed"}, &Options{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found on PATH")
+
+	// --no-exec does not run the project's plugins
+	checks, err := lintPlugins(makefilePath, []string{"./missing"}, &Options{NoProjectPlugins: true})
+	require.NoError(t, err)
+	for _, c := range checks {
+		assert.NotEqual(t, "missing", c.Name)
+	}
+}
@@ -0,0 +1,77 @@
+package lintload
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/sdlcforge/make-help/internal/lint"
+	"github.com/sdlcforge/make-help/internal/projectconfig"
+)
+
+// lintPlugins returns the lint plugin checks: the configured plugins, then the
+// make-help-check-* executables on PATH whose names are not taken.
+// Configured plugins come from the project, so options.NoProjectPlugins,
+// set for --no-exec and --sandbox, which promise not to run its code, skips
+// them. Nothing runs with options.NoPlugins.
+func lintPlugins(makefilePath string, configured []string, options *Options) ([]lint.Check, error) {
+	if options.NoPlugins {
+		return nil, nil
+	}
+
+	var paths []string
+	if !options.NoProjectPlugins {
+		for _, plugin := range configured {
+			path, err := resolvePlugin(plugin, filepath.Dir(makefilePath))
+			if err != nil {
+				return nil, fmt.Errorf("invalid lint.plugins in %s: %w", projectconfig.FileName, err)
+			}
+			paths = append(paths, path)
+		}
+	}
+	for _, path := range lint.FindPlugins(os.Getenv("PATH")) {
+		if !slices.ContainsFunc(paths, func(p string) bool { return lint.PluginName(p) == lint.PluginName(path) }) {
+			paths = append(paths, path)
+		}
+	}
+
+	builtin := lint.AllChecks()
+	var checks []lint.Check
+	for _, path := range paths {
+		name := lint.PluginName(path)
+		if slices.ContainsFunc(builtin, func(c lint.Check) bool { return c.Name == name }) {
+			return nil, fmt.Errorf("lint plugin %s has the name of the built-in %s check", path, name)
+		}
+		if slices.ContainsFunc(checks, func(c lint.Check) bool { return c.Name == name }) {
+			return nil, fmt.Errorf("lint plugin %s has the name of another plugin", path)
+		}
+		if options.Verbose {
+			fmt.Fprintf(os.Stderr, "Using lint plugin %s: %s\n", name, path)
+		}
+		checks = append(checks, lint.PluginCheck(path))
+	}
+	return checks, nil
+}
+
+// resolvePlugin returns the path of a configured plugin: relative to dir when
+// it contains a slash, otherwise looked up on PATH.
+func resolvePlugin(plugin, dir string) (string, error) {
+	if !strings.Contains(plugin, "/") {
+		path, err := exec.LookPath(plugin)
+		if err != nil {
+			return "", fmt.Errorf("lint plugin %s not found on PATH", plugin)
+		}
+		return path, nil
+	}
+	path := plugin
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	if _, err := os.Stat(path); err != nil {
+		return "", fmt.Errorf("lint plugin %s not found", plugin)
+	}
+	return path, nil
+}
@@ -1,10 +1,18 @@
 package model
 
 import (
+	"cmp"
+	"fmt"
+	"path"
+	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
+	"unicode"
 
+	"github.com/sdlcforge/make-help/internal/orderedmap"
 	"github.com/sdlcforge/make-help/internal/parser"
+	"github.com/sdlcforge/make-help/internal/projectconfig"
 	"github.com/sdlcforge/make-help/internal/summary"
 )
 
@@ -34,15 +42,76 @@ type BuilderConfig struct {
 	// HasRecipe maps target names to whether they have a recipe.
 	// Used for detecting implicit aliases.
 	HasRecipe map[string]bool
+
+	// DefaultGoal is the make default goal, copied to HelpModel.DefaultGoal.
+	DefaultGoal string
+
+	// IncludeHidden keeps targets marked with !hidden in the model.
+	// Used for machine-readable output and lint, which need every target.
+	IncludeHidden bool
+
+	// Profile keeps only targets tagged with this !profile, plus untagged
+	// targets. Empty keeps every target.
+	Profile string
+
+	// GroupByFile groups targets by source file instead of by !category.
+	// See GroupByFile.
+	GroupByFile bool
+
+	// BaseDir is the directory file group names and OnlyFiles patterns are
+	// relative to, normally the main Makefile's directory.
+	BaseDir string
+
+	// OnlyFiles restricts the model to targets and file documentation from
+	// files matching these glob patterns (see MatchesFilePattern).
+	// Empty keeps every file.
+	OnlyFiles []string
+
+	// ExcludeTargets drops targets whose names match these glob patterns.
+	ExcludeTargets []string
+
+	// ExcludeFiles drops targets and file documentation from files matching
+	// these glob patterns (see MatchesFilePattern).
+	ExcludeFiles []string
+
+	// Ignore holds the .makehelpignore patterns; targets whose names it
+	// matches are left out. Nil ignores nothing.
+	Ignore *projectconfig.Ignore
+
+	// CategoryRename maps !category names to the names used in the model.
+	// Categories renamed to the same name are merged.
+	CategoryRename map[string]string
+
+	// EntryPoint is the path of the file whose !file documentation
+	// introduces the help, relative to BaseDir or absolute. Empty picks the
+	// first documented file in discovery order: the main Makefile when it
+	// has a !file block, else the first included file with one.
+	EntryPoint string
+
+	// BestEffort places the uncategorized targets of a Makefile with mixed
+	// categorization in the BestEffortCategoryName category instead of
+	// failing. The category documentation notes the fallback.
+	BestEffort bool
 }
 
+// BestEffortCategoryName is the category BuilderConfig.BestEffort places
+// uncategorized targets in.
+const BestEffortCategoryName = "Uncategorized"
+
+// bestEffortNote is added to the documentation of the category
+// BuilderConfig.BestEffort places uncategorized targets in.
+const bestEffortNote = "Degraded: these targets have no !category and were placed here by --best-effort."
+
 // Builder constructs a HelpModel from parsed Makefile directives.
 // It aggregates file documentation, groups targets by category,
 // and associates aliases and variables with targets.
 type Builder struct {
-	config      *BuilderConfig
-	extractor   *summary.Extractor
-	notAliasSet map[string]bool // Targets marked with !notalias directive
+	config       *BuilderConfig
+	extractor    *summary.Extractor
+	notAliasSet  map[string]bool // Targets marked with !notalias directive
+	conflicts    []DefinitionConflict
+	degraded     []string
+	undocumented []string
 }
 
 // NewBuilder creates a new Builder with the given configuration.
@@ -71,19 +140,41 @@ func (b *Builder) NotAliasTargets() map[string]bool {
 	return b.notAliasSet
 }
 
+// Degradations describes the errors the last Build worked around because
+// BuilderConfig.BestEffort is set, one message each.
+func (b *Builder) Degradations() []string {
+	return b.degraded
+}
+
+// Undocumented returns the .PHONY targets the last Build left out for lack
+// of documentation, in discovery order. Targets excluded by file or name
+// filters are not included.
+func (b *Builder) Undocumented() []string {
+	return b.undocumented
+}
+
+// DefinitionConflicts returns the targets the last Build found with recipes
+// in more than one file, sorted by name.
+func (b *Builder) DefinitionConflicts() []DefinitionConflict {
+	return b.conflicts
+}
+
 // Build constructs a HelpModel from parsed files.
 // It processes directives in order, groups targets by category,
 // and validates categorization rules.
 func (b *Builder) Build(parsedFiles []*parser.ParsedFile) (*HelpModel, error) {
 	model := &HelpModel{
-		FileDocs:   []FileDoc{},
-		Categories: []Category{},
+		FileDocs:    []FileDoc{},
+		Categories:  []Category{},
+		DefaultGoal: b.config.DefaultGoal,
 	}
 
-	categoryMap := make(map[string]*Category)
-	targetMap := make(map[string]*Target)
-	targetToCategory := make(map[string]string) // target name -> category name
-	fileDocMap := make(map[string]*FileDoc)     // source file path -> FileDoc
+	// Ordered maps keep every aggregation below in discovery order, so the
+	// model (and errors listing its targets) is the same on every run
+	categoryMap := orderedmap.New[string, *Category]()
+	targetMap := orderedmap.New[string, *Target]()
+	targetToCategory := make(map[string]string)      // target name -> category name
+	fileDocMap := orderedmap.New[string, *FileDoc]() // source file path -> FileDoc
 
 	categoryOrder := 0
 	targetOrder := 0
@@ -92,9 +183,21 @@ func (b *Builder) Build(parsedFiles []*parser.ParsedFile) (*HelpModel, error) {
 	for _, file := range parsedFiles {
 		b.processFile(file, model, categoryMap, targetMap, targetToCategory, fileDocMap, &categoryOrder, &targetOrder, &fileOrder)
 	}
+	b.conflicts = findDefinitionConflicts(parsedFiles)
+
+	// Resolve the entry point before filtering, so excluding its file never
+	// promotes another one
+	entryPoint, err := b.entryPoint(parsedFiles, fileDocMap)
+	if err != nil {
+		return nil, err
+	}
 
 	// Convert fileDocMap to slice
-	for _, fileDoc := range fileDocMap {
+	for fileDoc := range fileDocMap.Values() {
+		fileDoc.IsEntryPoint = fileDoc.SourceFile == entryPoint
+		if !b.inOnlyFiles(fileDoc.SourceFile) || b.isExcludedFile(fileDoc.SourceFile) {
+			continue
+		}
 		model.FileDocs = append(model.FileDocs, *fileDoc)
 	}
 	// Sort by discovery order for deterministic output
@@ -106,20 +209,33 @@ func (b *Builder) Build(parsedFiles []*parser.ParsedFile) (*HelpModel, error) {
 	implicitAliases := b.detectImplicitAliases(targetMap)
 
 	// Assign targets to categories with filtering
-	for targetName, target := range targetMap {
+	b.undocumented = nil
+	for targetName, target := range targetMap.All() {
 		// Skip if this target is an implicit alias of another target
-		if _, isAlias := implicitAliases[targetName]; isAlias {
+		if implicitAliases.Has(targetName) {
 			continue
 		}
 
 		// Apply filtering logic
 		shouldInclude := b.shouldIncludeTarget(target)
 		if !shouldInclude {
+			if b.config.PhonyTargets[targetName] && b.inOnlyFiles(target.SourceFile) && !b.isExcluded(target) {
+				b.undocumented = append(b.undocumented, targetName)
+			}
+			continue
+		}
+		if target.Hidden && !b.config.IncludeHidden {
+			continue
+		}
+		if !b.inProfile(target) {
+			continue
+		}
+		if !b.inOnlyFiles(target.SourceFile) || b.isExcluded(target) {
 			continue
 		}
 
 		// Add implicit aliases to this target
-		for aliasName, depName := range implicitAliases {
+		for aliasName, depName := range implicitAliases.All() {
 			if depName == targetName {
 				target.Aliases = append(target.Aliases, aliasName)
 			}
@@ -131,7 +247,10 @@ func (b *Builder) Build(parsedFiles []*parser.ParsedFile) (*HelpModel, error) {
 		categoryName := targetToCategory[targetName]
 
 		// Compute summary from documentation (store as single-element slice)
-		summaryText := b.extractor.ExtractPlainText(target.Documentation)
+		summaryText := target.ExplicitSummary
+		if summaryText == "" {
+			summaryText = b.extractor.ExtractPlainText(target.Documentation)
+		}
 		if summaryText != "" {
 			target.Summary = []string{summaryText}
 		} else {
@@ -139,7 +258,7 @@ func (b *Builder) Build(parsedFiles []*parser.ParsedFile) (*HelpModel, error) {
 		}
 
 		// Get or create category
-		cat, exists := categoryMap[categoryName]
+		cat, exists := categoryMap.Get(categoryName)
 		if !exists {
 			cat = &Category{
 				Name:           categoryName,
@@ -147,20 +266,35 @@ func (b *Builder) Build(parsedFiles []*parser.ParsedFile) (*HelpModel, error) {
 				DiscoveryOrder: categoryOrder,
 			}
 			categoryOrder++
-			categoryMap[categoryName] = cat
+			categoryMap.Set(categoryName, cat)
 		}
 
 		cat.Targets = append(cat.Targets, *target)
 	}
 
-	// Convert category map to slice
-	for _, cat := range categoryMap {
+	// Convert category map to slice, dropping categories whose targets were
+	// all filtered out
+	for cat := range categoryMap.Values() {
+		if len(cat.Targets) == 0 {
+			continue
+		}
 		model.Categories = append(model.Categories, *cat)
 	}
 
+	// Grouping by file replaces categories, so they need not be consistent
+	if b.config.GroupByFile {
+		GroupByFile(model, b.config.BaseDir)
+		return model, nil
+	}
+
 	// Validate categorization
+	b.degraded = nil
 	if err := ValidateCategorization(model, b.config.DefaultCategory); err != nil {
-		return nil, err
+		if !b.config.BestEffort {
+			return nil, err
+		}
+		b.applyBestEffortCategory(model)
+		return model, nil
 	}
 
 	// Apply default category if needed
@@ -171,14 +305,37 @@ func (b *Builder) Build(parsedFiles []*parser.ParsedFile) (*HelpModel, error) {
 	return model, nil
 }
 
+// applyBestEffortCategory moves the uncategorized targets of model to
+// BestEffortCategoryName, noting the fallback in the category documentation
+// and in the builder's degradations.
+func (b *Builder) applyBestEffortCategory(model *HelpModel) {
+	var names []string
+	for _, category := range model.Categories {
+		if category.Name == UncategorizedCategoryName {
+			for _, target := range category.Targets {
+				names = append(names, target.Name)
+			}
+		}
+	}
+	slices.Sort(names)
+	ApplyDefaultCategory(model, BestEffortCategoryName)
+	for i := range model.Categories {
+		if model.Categories[i].Name == BestEffortCategoryName {
+			model.Categories[i].Documentation = append(model.Categories[i].Documentation, bestEffortNote)
+		}
+	}
+	b.degraded = append(b.degraded, fmt.Sprintf("mixed categorization: placed %s in category %q",
+		strings.Join(names, ", "), BestEffortCategoryName))
+}
+
 // shouldIncludeTarget determines if a target should be included in the help output.
 // A target is included if:
-// 1. It has documentation (len(Documentation) > 0), OR
+// 1. It has documentation (len(Documentation) > 0) or a !summary, OR
 // 2. It's in the IncludeTargets list, OR
 // 3. It's .PHONY and IncludeAllPhony is true
 func (b *Builder) shouldIncludeTarget(target *Target) bool {
 	// Include if documented
-	if len(target.Documentation) > 0 {
+	if len(target.Documentation) > 0 || target.ExplicitSummary != "" {
 		return true
 	}
 
@@ -197,6 +354,153 @@ func (b *Builder) shouldIncludeTarget(target *Target) bool {
 	return false
 }
 
+// entryPoint returns the path of the file whose !file documentation
+// introduces the help, following BuilderConfig.EntryPoint. It returns ""
+// when no file is documented.
+func (b *Builder) entryPoint(parsedFiles []*parser.ParsedFile, fileDocMap *orderedmap.Map[string, *FileDoc]) (string, error) {
+	if b.config.EntryPoint == "" {
+		if sourceFiles := fileDocMap.Keys(); len(sourceFiles) > 0 {
+			return sourceFiles[0], nil
+		}
+		return "", nil
+	}
+
+	want := b.config.EntryPoint
+	if !filepath.IsAbs(want) {
+		want = filepath.Join(b.config.BaseDir, want)
+	}
+	want = filepath.Clean(want)
+	for _, file := range parsedFiles {
+		if filepath.Clean(file.Path) == want {
+			return file.Path, nil
+		}
+	}
+	return "", fmt.Errorf("entry point %s is not one of the Makefiles", b.config.EntryPoint)
+}
+
+// inOnlyFiles reports whether a source file passes the OnlyFiles filter.
+func (b *Builder) inOnlyFiles(sourceFile string) bool {
+	if len(b.config.OnlyFiles) == 0 {
+		return true
+	}
+	for _, pattern := range b.config.OnlyFiles {
+		if MatchesFilePattern(pattern, sourceFile, b.config.BaseDir) {
+			return true
+		}
+	}
+	return false
+}
+
+// isExcluded reports whether a target matches ExcludeTargets or a
+// .makehelpignore target pattern, or comes from a file matching ExcludeFiles.
+func (b *Builder) isExcluded(target *Target) bool {
+	if b.config.Ignore.MatchTarget(target.Name) {
+		return true
+	}
+	for _, pattern := range b.config.ExcludeTargets {
+		if matched, _ := path.Match(pattern, target.Name); matched {
+			return true
+		}
+	}
+	return b.isExcludedFile(target.SourceFile)
+}
+
+// isExcludedFile reports whether a source file matches ExcludeFiles.
+func (b *Builder) isExcludedFile(sourceFile string) bool {
+	for _, pattern := range b.config.ExcludeFiles {
+		if MatchesFilePattern(pattern, sourceFile, b.config.BaseDir) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchesFilePattern reports whether a file matches a glob pattern.
+// The pattern is matched against the path relative to baseDir, using
+// path.Match syntax per path segment plus "**" for any number of
+// directories (e.g., "third_party/**"). A pattern without a directory part
+// (e.g., "docker.mk" or "*.mk") also matches the file's base name.
+func MatchesFilePattern(pattern, file, baseDir string) bool {
+	rel := filepath.ToSlash(relativeSourcePath(file, baseDir))
+	if matchPathSegments(strings.Split(pattern, "/"), strings.Split(rel, "/")) {
+		return true
+	}
+	if !strings.Contains(pattern, "/") {
+		matched, _ := path.Match(pattern, filepath.Base(file))
+		return matched
+	}
+	return false
+}
+
+// matchPathSegments matches path segments against pattern segments,
+// where a "**" segment matches zero or more path segments.
+func matchPathSegments(pattern, segments []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(segments); i++ {
+				if matchPathSegments(pattern[1:], segments[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(segments) == 0 {
+			return false
+		}
+		if matched, _ := path.Match(pattern[0], segments[0]); !matched {
+			return false
+		}
+		pattern, segments = pattern[1:], segments[1:]
+	}
+	return len(segments) == 0
+}
+
+// inProfile reports whether a target belongs to the configured profile.
+// Targets without !profile are shown in every profile.
+func (b *Builder) inProfile(target *Target) bool {
+	if b.config.Profile == "" || len(target.Profiles) == 0 {
+		return true
+	}
+	return slices.Contains(target.Profiles, strings.ToLower(b.config.Profile))
+}
+
+// findDefinitionConflicts returns the targets with recipes in more than one
+// file. Targets defined only by double-colon rules are skipped: make runs
+// every double-colon recipe, so spreading them across files is intentional.
+func findDefinitionConflicts(parsedFiles []*parser.ParsedFile) []DefinitionConflict {
+	definitions := make(map[string][]RecipeDefinition)
+	for _, file := range parsedFiles {
+		for _, def := range file.Definitions {
+			if !def.HasRecipe {
+				continue
+			}
+			definitions[def.Name] = append(definitions[def.Name], RecipeDefinition{
+				SourceFile:  file.Path,
+				LineNumber:  def.LineNumber,
+				DoubleColon: def.DoubleColon,
+			})
+		}
+	}
+
+	var conflicts []DefinitionConflict
+	for name, defs := range definitions {
+		files := make(map[string]bool)
+		allDoubleColon := true
+		for _, d := range defs {
+			files[d.SourceFile] = true
+			allDoubleColon = allDoubleColon && d.DoubleColon
+		}
+		if len(files) < 2 || allDoubleColon {
+			continue
+		}
+		conflicts = append(conflicts, DefinitionConflict{Target: name, Definitions: defs})
+	}
+	sort.Slice(conflicts, func(i, j int) bool {
+		return conflicts[i].Target < conflicts[j].Target
+	})
+	return conflicts
+}
+
 // detectImplicitAliases finds targets that are implicit aliases of other targets.
 // A target is an implicit alias if:
 //   - It has no documentation (documented targets are semantically distinct)
@@ -206,13 +510,14 @@ func (b *Builder) shouldIncludeTarget(target *Target) bool {
 //   - That dependency is also .PHONY
 //   - It has no recipe (no commands)
 //
-// Returns a map from alias target name to the target it aliases.
-func (b *Builder) detectImplicitAliases(targetMap map[string]*Target) map[string]string {
-	aliases := make(map[string]string)
+// Returns a map from alias target name to the target it aliases, in
+// discovery order.
+func (b *Builder) detectImplicitAliases(targetMap *orderedmap.Map[string, *Target]) *orderedmap.Map[string, string] {
+	aliases := orderedmap.New[string, string]()
 
-	for targetName, target := range targetMap {
+	for targetName, target := range targetMap.All() {
 		// Skip if target has documentation (documented targets are not implicit aliases)
-		if len(target.Documentation) > 0 {
+		if len(target.Documentation) > 0 || target.ExplicitSummary != "" {
 			continue
 		}
 
@@ -245,7 +550,7 @@ func (b *Builder) detectImplicitAliases(targetMap map[string]*Target) map[string
 		}
 
 		// This target is an implicit alias of its dependency
-		aliases[targetName] = depName
+		aliases.Set(targetName, depName)
 	}
 
 	return aliases
@@ -291,6 +596,8 @@ func (b *Builder) detectImplicitAliases(targetMap map[string]*Target) map[string
 // # Special Cases
 //
 //   - !file directives: Added to model.FileDocs (not associated with targets)
+//   - !owner in a !file block: Sets the FileDoc owner and the owner of the
+//     file's targets that have none
 //   - !category directives: Update currentCategory for subsequent targets
 //   - Duplicate targets: If a target was already processed from another file,
 //     skip it and clear pending state (first definition wins)
@@ -303,10 +610,10 @@ func (b *Builder) detectImplicitAliases(targetMap map[string]*Target) map[string
 func (b *Builder) processFile(
 	file *parser.ParsedFile,
 	model *HelpModel,
-	categoryMap map[string]*Category,
-	targetMap map[string]*Target,
+	categoryMap *orderedmap.Map[string, *Category],
+	targetMap *orderedmap.Map[string, *Target],
 	targetToCategory map[string]string,
-	fileDocMap map[string]*FileDoc,
+	fileDocMap *orderedmap.Map[string, *FileDoc],
 	categoryOrder *int,
 	targetOrder *int,
 	fileOrder *int,
@@ -332,6 +639,19 @@ func (b *Builder) processFile(
 	var pendingVars []Variable
 	var pendingAliases []string
 	var pendingNotAlias bool
+	var pendingTags []string
+	var pendingDeprecated bool
+	var pendingDeprecationMessage string
+	var pendingHidden bool
+	var pendingPlatforms []string
+	var pendingDuration string
+	var pendingProfiles []string
+	var pendingSummary string
+	var pendingDangerous bool
+	var pendingDangerReason string
+	var pendingOwner string
+	var pendingCIWorkflows []string
+	var fileOwner string
 
 	// Process directives in file order
 	directiveIdx := 0
@@ -359,16 +679,15 @@ func (b *Builder) processFile(
 			case parser.DirectiveFile:
 				if directive.Value != "" {
 					// Get or create FileDoc for this file
-					fileDoc, exists := fileDocMap[file.Path]
+					fileDoc, exists := fileDocMap.Get(file.Path)
 					if !exists {
 						fileDoc = &FileDoc{
 							SourceFile:     file.Path,
 							Documentation:  []string{},
 							DiscoveryOrder: *fileOrder,
-							IsEntryPoint:   *fileOrder == 0, // First file is entry point
 						}
 						*fileOrder++
-						fileDocMap[file.Path] = fileDoc
+						fileDocMap.Set(file.Path, fileDoc)
 					}
 
 					// Concatenate multiple !file blocks with blank line separation
@@ -381,6 +700,9 @@ func (b *Builder) processFile(
 			case parser.DirectiveCategory:
 				model.HasCategories = true
 				currentCategory = directive.Value
+				if renamed, ok := b.config.CategoryRename[currentCategory]; ok {
+					currentCategory = renamed
+				}
 
 				// Handle !category _ as reset to uncategorized
 				if currentCategory == "_" {
@@ -390,12 +712,12 @@ func (b *Builder) processFile(
 				}
 
 				// Create category if it doesn't exist
-				if _, exists := categoryMap[currentCategory]; !exists {
-					categoryMap[currentCategory] = &Category{
+				if !categoryMap.Has(currentCategory) {
+					categoryMap.Set(currentCategory, &Category{
 						Name:           currentCategory,
 						Targets:        []Target{},
 						DiscoveryOrder: *categoryOrder,
-					}
+					})
 					*categoryOrder++
 				}
 
@@ -410,6 +732,70 @@ func (b *Builder) processFile(
 
 			case parser.DirectiveNotAlias:
 				pendingNotAlias = true
+
+			case parser.DirectiveTag:
+				pendingTags = append(pendingTags, b.parseTagDirective(directive.Value)...)
+
+			case parser.DirectiveDeprecated:
+				pendingDeprecated = true
+				pendingDeprecationMessage = directive.Value
+
+			case parser.DirectiveHidden:
+				pendingHidden = true
+
+			case parser.DirectiveOS:
+				pendingPlatforms = append(pendingPlatforms, b.parseOSDirective(directive.Value)...)
+
+			case parser.DirectiveDuration:
+				pendingDuration = directive.Value
+
+			case parser.DirectiveProfile:
+				pendingProfiles = append(pendingProfiles, b.parseProfileDirective(directive.Value)...)
+
+			case parser.DirectiveSummary:
+				pendingSummary = directive.Value
+
+			case parser.DirectiveDanger:
+				pendingDangerous = true
+				pendingDangerReason = directive.Value
+
+			case parser.DirectiveOwner:
+				pendingOwner = directive.Value
+
+			case parser.DirectiveFileOwner:
+				fileOwner = directive.Value
+
+			case parser.DirectiveCI:
+				pendingCIWorkflows = append(pendingCIWorkflows, b.parseCIDirective(directive.Value)...)
+
+			case parser.DirectiveUsage:
+				// The first !usage wins, so the entry point overrides includes;
+				// the same goes for the project directives below
+				model.Usage = cmp.Or(model.Usage, directive.Value)
+
+			case parser.DirectiveExample:
+				model.Examples = append(model.Examples, directive.Value)
+
+			case parser.DirectiveTitle:
+				model.Title = cmp.Or(model.Title, directive.Value)
+
+			case parser.DirectiveHomepage:
+				model.Homepage = cmp.Or(model.Homepage, directive.Value)
+
+			case parser.DirectiveRepo:
+				model.Repo = cmp.Or(model.Repo, directive.Value)
+
+			case parser.DirectiveFooter:
+				// Concatenate multiple !footer blocks with blank line separation
+				if len(model.FooterDocs) > 0 {
+					model.FooterDocs = append(model.FooterDocs, "")
+				}
+				if directive.Value != "" {
+					model.FooterDocs = append(model.FooterDocs, directive.Value)
+				}
+
+			case parser.DirectiveFooterDoc:
+				model.FooterDocs = append(model.FooterDocs, directive.Value)
 			}
 		} else {
 			// Process target - associate pending directives with it
@@ -417,10 +803,22 @@ func (b *Builder) processFile(
 			targetIdx++
 
 			// Skip if target already processed from another file
-			if _, exists := targetMap[tl.name]; exists {
+			if targetMap.Has(tl.name) {
 				pendingDocs = nil
 				pendingVars = nil
 				pendingAliases = nil
+				pendingTags = nil
+				pendingDeprecated = false
+				pendingDeprecationMessage = ""
+				pendingHidden = false
+				pendingPlatforms = nil
+				pendingDuration = ""
+				pendingProfiles = nil
+				pendingSummary = ""
+				pendingDangerous = false
+				pendingDangerReason = ""
+				pendingOwner = ""
+				pendingCIWorkflows = nil
 				continue
 			}
 
@@ -433,10 +831,23 @@ func (b *Builder) processFile(
 				DiscoveryOrder: *targetOrder,
 				SourceFile:     file.Path,
 				LineNumber:     tl.line,
+
+				Tags:               pendingTags,
+				Deprecated:         pendingDeprecated,
+				DeprecationMessage: pendingDeprecationMessage,
+				Hidden:             pendingHidden,
+				Platforms:          pendingPlatforms,
+				Duration:           pendingDuration,
+				Profiles:           pendingProfiles,
+				ExplicitSummary:    pendingSummary,
+				Dangerous:          pendingDangerous,
+				DangerReason:       pendingDangerReason,
+				Owner:              pendingOwner,
+				CIWorkflows:        pendingCIWorkflows,
 			}
 			*targetOrder++
 
-			targetMap[tl.name] = target
+			targetMap.Set(tl.name, target)
 			targetToCategory[tl.name] = currentCategory
 
 			// Track targets marked with !notalias
@@ -449,24 +860,92 @@ func (b *Builder) processFile(
 			pendingVars = nil
 			pendingAliases = nil
 			pendingNotAlias = false
+			pendingTags = nil
+			pendingDeprecated = false
+			pendingDeprecationMessage = ""
+			pendingHidden = false
+			pendingPlatforms = nil
+			pendingDuration = ""
+			pendingProfiles = nil
+			pendingSummary = ""
+			pendingDangerous = false
+			pendingDangerReason = ""
+			pendingOwner = ""
+			pendingCIWorkflows = nil
+		}
+	}
+
+	// The file owner covers targets without their own, wherever the
+	// !file block appears in the file
+	if fileOwner != "" {
+		if fileDoc, ok := fileDocMap.Get(file.Path); ok {
+			fileDoc.Owner = fileOwner
+		}
+		for target := range targetMap.Values() {
+			if target.SourceFile == file.Path && target.Owner == "" {
+				target.Owner = fileOwner
+			}
 		}
 	}
 }
 
-// parseVarDirective parses !var directive: NAME - description
-// or just NAME if no description is provided.
+// Markers that may follow a !var name, e.g. "ENV (required) (choices: dev,prod)".
+const (
+	requiredVarMarker = "required"
+	choicesVarMarker  = "choices:"
+)
+
+// parseVarDirective parses !var directive: NAME - description, NAME
+// description, or just NAME if no description is provided. The name is the
+// first word; it may be followed by "(required)" and "(choices: a,b,c)"
+// markers in any order, e.g. "ENV (required) (choices: dev,prod) -
+// Deployment environment". Any other text is the description.
 func (b *Builder) parseVarDirective(value string) Variable {
-	parts := strings.SplitN(value, " - ", 2)
-	if len(parts) == 2 {
-		return Variable{
-			Name:        strings.TrimSpace(parts[0]),
-			Description: strings.TrimSpace(parts[1]),
+	head, description := value, ""
+	if parts := strings.SplitN(value, " - ", 2); len(parts) == 2 {
+		head, description = parts[0], parts[1]
+	}
+
+	head = strings.TrimSpace(head)
+	end := strings.IndexFunc(head, func(r rune) bool { return unicode.IsSpace(r) || r == '(' })
+	if end < 0 {
+		end = len(head)
+	}
+	variable := Variable{Name: head[:end]}
+
+	// Strip recognized markers following the name; an unknown one starts
+	// the description.
+	rest := strings.TrimSpace(head[end:])
+	for strings.HasPrefix(rest, "(") {
+		closing := strings.Index(rest, ")")
+		if closing < 0 {
+			break
+		}
+		marker := strings.TrimSpace(rest[1:closing])
+		if marker == requiredVarMarker {
+			variable.Required = true
+		} else if strings.HasPrefix(marker, choicesVarMarker) {
+			variable.Choices = parseVarChoices(strings.TrimPrefix(marker, choicesVarMarker))
+		} else {
+			break
 		}
+		rest = strings.TrimSpace(rest[closing+1:])
 	}
-	return Variable{
-		Name:        strings.TrimSpace(value),
-		Description: "",
+
+	variable.Description = strings.TrimSpace(rest + " " + strings.TrimSpace(description))
+	return variable
+}
+
+// parseVarChoices splits a comma-separated choice list, dropping empty
+// entries. The result is non-nil even when no choices remain.
+func parseVarChoices(value string) []string {
+	choices := []string{}
+	for _, part := range strings.Split(value, ",") {
+		if choice := strings.TrimSpace(part); choice != "" {
+			choices = append(choices, choice)
+		}
 	}
+	return choices
 }
 
 // parseAliasDirective parses !alias directive: alias1, alias2, ...
@@ -480,3 +959,31 @@ func (b *Builder) parseAliasDirective(value string) []string {
 	}
 	return aliases
 }
+
+// parseTagDirective parses !tag directive: tag1, tag2, ...
+// Tags share the comma-separated syntax of !alias.
+func (b *Builder) parseTagDirective(value string) []string {
+	return b.parseAliasDirective(value)
+}
+
+// parseCIDirective parses !ci directive: .github/workflows/build.yml, ...
+// Workflow paths share the comma-separated syntax of !alias.
+func (b *Builder) parseCIDirective(value string) []string {
+	return b.parseAliasDirective(value)
+}
+
+// parseOSDirective parses !os directive: linux, darwin, ...
+// Platform names are lowercased to match runtime.GOOS.
+func (b *Builder) parseOSDirective(value string) []string {
+	platforms := b.parseAliasDirective(value)
+	for i, platform := range platforms {
+		platforms[i] = strings.ToLower(platform)
+	}
+	return platforms
+}
+
+// parseProfileDirective parses !profile directive: ci, dev, ...
+// Profiles share the lowercased, comma-separated syntax of !os.
+func (b *Builder) parseProfileDirective(value string) []string {
+	return b.parseOSDirective(value)
+}
@@ -288,6 +288,558 @@ func TestBuild_TargetWithAliases(t *testing.T) {
 	assert.Contains(t, target.Aliases, "compile")
 }
 
+func TestBuild_TargetMetadataDirectives(t *testing.T) {
+	t.Parallel()
+	builder := NewBuilder(&BuilderConfig{DefaultGoal: "build"})
+
+	parsedFiles := []*parser.ParsedFile{
+		{
+			Path: "Makefile",
+			Directives: []parser.Directive{
+				{Type: parser.DirectiveDoc, Value: "Build the project.", SourceFile: "Makefile", LineNumber: 1},
+				{Type: parser.DirectiveTag, Value: "ci, fast", SourceFile: "Makefile", LineNumber: 2},
+				{Type: parser.DirectiveDoc, Value: "Old build.", SourceFile: "Makefile", LineNumber: 4},
+				{Type: parser.DirectiveDeprecated, Value: "Use build instead.", SourceFile: "Makefile", LineNumber: 5},
+			},
+			TargetMap: map[string]int{
+				"build":     3,
+				"old-build": 6,
+			},
+		},
+	}
+
+	model, err := builder.Build(parsedFiles)
+	require.NoError(t, err)
+	assert.Equal(t, "build", model.DefaultGoal)
+
+	build := GetTarget(model, "build")
+	require.NotNil(t, build)
+	assert.Equal(t, []string{"ci", "fast"}, build.Tags)
+	assert.False(t, build.Deprecated)
+
+	oldBuild := GetTarget(model, "old-build")
+	require.NotNil(t, oldBuild)
+	assert.Empty(t, oldBuild.Tags)
+	assert.True(t, oldBuild.Deprecated)
+	assert.Equal(t, "Use build instead.", oldBuild.DeprecationMessage)
+}
+
+func TestBuild_HiddenTargets(t *testing.T) {
+	t.Parallel()
+	parsedFiles := []*parser.ParsedFile{
+		{
+			Path: "Makefile",
+			Directives: []parser.Directive{
+				{Type: parser.DirectiveDoc, Value: "Build the project.", SourceFile: "Makefile", LineNumber: 1},
+				{Type: parser.DirectiveHidden, Value: "", SourceFile: "Makefile", LineNumber: 3},
+				{Type: parser.DirectiveDoc, Value: "Internal helper.", SourceFile: "Makefile", LineNumber: 4},
+			},
+			TargetMap: map[string]int{
+				"build":    2,
+				"internal": 5,
+			},
+		},
+	}
+
+	model, err := NewBuilder(&BuilderConfig{}).Build(parsedFiles)
+	require.NoError(t, err)
+	assert.NotNil(t, GetTarget(model, "build"))
+	assert.Nil(t, GetTarget(model, "internal"), "hidden target should be omitted by default")
+
+	model, err = NewBuilder(&BuilderConfig{IncludeHidden: true}).Build(parsedFiles)
+	require.NoError(t, err)
+	internal := GetTarget(model, "internal")
+	require.NotNil(t, internal)
+	assert.True(t, internal.Hidden)
+}
+
+func TestBuild_DangerousTargets(t *testing.T) {
+	t.Parallel()
+	parsedFiles := []*parser.ParsedFile{
+		{
+			Path: "Makefile",
+			Directives: []parser.Directive{
+				{Type: parser.DirectiveDanger, Value: "destroys the production database", SourceFile: "Makefile", LineNumber: 1},
+				{Type: parser.DirectiveDoc, Value: "Drop the database.", SourceFile: "Makefile", LineNumber: 2},
+				{Type: parser.DirectiveDoc, Value: "Build the project.", SourceFile: "Makefile", LineNumber: 4},
+			},
+			TargetMap: map[string]int{
+				"db-drop": 3,
+				"build":   5,
+			},
+		},
+	}
+
+	model, err := NewBuilder(&BuilderConfig{}).Build(parsedFiles)
+	require.NoError(t, err)
+
+	drop := GetTarget(model, "db-drop")
+	require.NotNil(t, drop)
+	assert.True(t, drop.Dangerous)
+	assert.Equal(t, "destroys the production database", drop.DangerReason)
+
+	build := GetTarget(model, "build")
+	require.NotNil(t, build)
+	assert.False(t, build.Dangerous, "!danger applies to the next target only")
+}
+
+func TestBuild_Owners(t *testing.T) {
+	t.Parallel()
+	parsedFiles := []*parser.ParsedFile{
+		{
+			Path: "Makefile",
+			Directives: []parser.Directive{
+				{Type: parser.DirectiveDoc, Value: "Build the project.", SourceFile: "Makefile", LineNumber: 1},
+			},
+			TargetMap: map[string]int{
+				"build": 2,
+			},
+		},
+		{
+			Path: "deploy.mk",
+			Directives: []parser.Directive{
+				{Type: parser.DirectiveFile, Value: "Deployment targets.", SourceFile: "deploy.mk", LineNumber: 1},
+				{Type: parser.DirectiveFileOwner, Value: "platform-team", SourceFile: "deploy.mk", LineNumber: 3},
+				{Type: parser.DirectiveOwner, Value: "release-team (#releases on Slack)", SourceFile: "deploy.mk", LineNumber: 5},
+				{Type: parser.DirectiveDoc, Value: "Deploy the app.", SourceFile: "deploy.mk", LineNumber: 6},
+				{Type: parser.DirectiveDoc, Value: "Push the image.", SourceFile: "deploy.mk", LineNumber: 8},
+			},
+			TargetMap: map[string]int{
+				"deploy": 7,
+				"push":   9,
+			},
+		},
+	}
+
+	model, err := NewBuilder(&BuilderConfig{}).Build(parsedFiles)
+	require.NoError(t, err)
+
+	deploy := GetTarget(model, "deploy")
+	require.NotNil(t, deploy)
+	assert.Equal(t, "release-team (#releases on Slack)", deploy.Owner)
+
+	push := GetTarget(model, "push")
+	require.NotNil(t, push)
+	assert.Equal(t, "platform-team", push.Owner, "targets inherit the file owner")
+
+	build := GetTarget(model, "build")
+	require.NotNil(t, build)
+	assert.Empty(t, build.Owner, "file owners apply to their own file only")
+
+	var fileOwner string
+	for _, fd := range model.FileDocs {
+		if fd.SourceFile == "deploy.mk" {
+			fileOwner = fd.Owner
+		}
+	}
+	assert.Equal(t, "platform-team", fileOwner)
+}
+
+func TestBuild_UsageAndExamples(t *testing.T) {
+	t.Parallel()
+	parsedFiles := []*parser.ParsedFile{
+		{
+			Path: "Makefile",
+			Directives: []parser.Directive{
+				{Type: parser.DirectiveUsage, Value: "make -j8 <target>", SourceFile: "Makefile", LineNumber: 1},
+				{Type: parser.DirectiveExample, Value: "make build", SourceFile: "Makefile", LineNumber: 2},
+				{Type: parser.DirectiveDoc, Value: "Build the project.", SourceFile: "Makefile", LineNumber: 4},
+			},
+			TargetMap: map[string]int{
+				"build": 5,
+			},
+		},
+		{
+			Path: "test.mk",
+			Directives: []parser.Directive{
+				{Type: parser.DirectiveUsage, Value: "make test", SourceFile: "test.mk", LineNumber: 1},
+				{Type: parser.DirectiveExample, Value: "make test RUN=TestParse", SourceFile: "test.mk", LineNumber: 2},
+			},
+		},
+	}
+
+	model, err := NewBuilder(&BuilderConfig{}).Build(parsedFiles)
+	require.NoError(t, err)
+
+	assert.Equal(t, "make -j8 <target>", model.Usage, "the first !usage wins")
+	assert.Equal(t, []string{"make build", "make test RUN=TestParse"}, model.Examples)
+
+	build := GetTarget(model, "build")
+	require.NotNil(t, build)
+	assert.Equal(t, []string{"Build the project."}, build.Documentation)
+}
+
+func TestBuild_ProjectMetadata(t *testing.T) {
+	t.Parallel()
+	parsedFiles := []*parser.ParsedFile{
+		{
+			Path: "Makefile",
+			Directives: []parser.Directive{
+				{Type: parser.DirectiveTitle, Value: "Acme Build System", SourceFile: "Makefile", LineNumber: 1},
+				{Type: parser.DirectiveRepo, Value: "https://github.com/acme/build", SourceFile: "Makefile", LineNumber: 2},
+			},
+		},
+		{
+			Path: "vendor.mk",
+			Directives: []parser.Directive{
+				{Type: parser.DirectiveTitle, Value: "Vendored Targets", SourceFile: "vendor.mk", LineNumber: 1},
+				{Type: parser.DirectiveHomepage, Value: "https://acme.example.com", SourceFile: "vendor.mk", LineNumber: 2},
+			},
+		},
+	}
+
+	model, err := NewBuilder(&BuilderConfig{}).Build(parsedFiles)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Acme Build System", model.Title, "the first !title wins")
+	assert.Equal(t, "https://acme.example.com", model.Homepage)
+	assert.Equal(t, "https://github.com/acme/build", model.Repo)
+}
+
+func TestBuild_FooterDocs(t *testing.T) {
+	t.Parallel()
+	parsedFiles := []*parser.ParsedFile{
+		{
+			Path: "Makefile",
+			Directives: []parser.Directive{
+				{Type: parser.DirectiveFooter, Value: "Run make help-<target> for details.", SourceFile: "Makefile", LineNumber: 1},
+				{Type: parser.DirectiveFooterDoc, Value: "Report issues to #build-infra.", SourceFile: "Makefile", LineNumber: 2},
+				{Type: parser.DirectiveDoc, Value: "Build the project.", SourceFile: "Makefile", LineNumber: 4},
+			},
+			TargetMap: map[string]int{
+				"build": 5,
+			},
+		},
+		{
+			Path: "docs.mk",
+			Directives: []parser.Directive{
+				{Type: parser.DirectiveFooter, Value: "", SourceFile: "docs.mk", LineNumber: 1},
+				{Type: parser.DirectiveFooterDoc, Value: "See docs/build.md.", SourceFile: "docs.mk", LineNumber: 2},
+			},
+		},
+	}
+
+	model, err := NewBuilder(&BuilderConfig{}).Build(parsedFiles)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"Run make help-<target> for details.",
+		"Report issues to #build-infra.",
+		"",
+		"See docs/build.md.",
+	}, model.FooterDocs)
+
+	build := GetTarget(model, "build")
+	require.NotNil(t, build)
+	assert.Equal(t, []string{"Build the project."}, build.Documentation)
+}
+
+func TestBuild_CIWorkflows(t *testing.T) {
+	t.Parallel()
+	parsedFiles := []*parser.ParsedFile{
+		{
+			Path: "Makefile",
+			Directives: []parser.Directive{
+				{Type: parser.DirectiveCI, Value: ".github/workflows/build.yml, .github/workflows/release.yml", SourceFile: "Makefile", LineNumber: 1},
+				{Type: parser.DirectiveCI, Value: ".gitlab-ci.yml", SourceFile: "Makefile", LineNumber: 2},
+				{Type: parser.DirectiveDoc, Value: "Build the project.", SourceFile: "Makefile", LineNumber: 3},
+				{Type: parser.DirectiveDoc, Value: "Run the tests.", SourceFile: "Makefile", LineNumber: 5},
+			},
+			TargetMap: map[string]int{
+				"build": 4,
+				"test":  6,
+			},
+		},
+	}
+
+	model, err := NewBuilder(&BuilderConfig{}).Build(parsedFiles)
+	require.NoError(t, err)
+
+	build := GetTarget(model, "build")
+	require.NotNil(t, build)
+	assert.Equal(t, []string{".github/workflows/build.yml", ".github/workflows/release.yml", ".gitlab-ci.yml"}, build.CIWorkflows)
+
+	test := GetTarget(model, "test")
+	require.NotNil(t, test)
+	assert.Empty(t, test.CIWorkflows)
+}
+
+func TestBuild_PlatformsAndDuration(t *testing.T) {
+	t.Parallel()
+	parsedFiles := []*parser.ParsedFile{
+		{
+			Path: "Makefile",
+			Directives: []parser.Directive{
+				{Type: parser.DirectiveOS, Value: "Linux, darwin", SourceFile: "Makefile", LineNumber: 1},
+				{Type: parser.DirectiveDuration, Value: "~5m", SourceFile: "Makefile", LineNumber: 1},
+				{Type: parser.DirectiveDoc, Value: "Install packages.", SourceFile: "Makefile", LineNumber: 2},
+				{Type: parser.DirectiveDoc, Value: "Build the project.", SourceFile: "Makefile", LineNumber: 4},
+			},
+			TargetMap: map[string]int{
+				"install": 3,
+				"build":   5,
+			},
+		},
+	}
+
+	model, err := NewBuilder(&BuilderConfig{}).Build(parsedFiles)
+	require.NoError(t, err)
+
+	install := GetTarget(model, "install")
+	require.NotNil(t, install)
+	assert.Equal(t, []string{"linux", "darwin"}, install.Platforms)
+	assert.Equal(t, "~5m", install.Duration)
+
+	build := GetTarget(model, "build")
+	require.NotNil(t, build)
+	assert.Empty(t, build.Platforms)
+	assert.Empty(t, build.Duration)
+}
+
+func TestBuild_ExplicitSummary(t *testing.T) {
+	t.Parallel()
+	parsedFiles := []*parser.ParsedFile{
+		{
+			Path: "Makefile",
+			Directives: []parser.Directive{
+				{Type: parser.DirectiveSummary, Value: "Install system packages.", SourceFile: "Makefile", LineNumber: 1},
+				{Type: parser.DirectiveDoc, Value: "Runs apt or brew. Needs sudo.", SourceFile: "Makefile", LineNumber: 2},
+				{Type: parser.DirectiveSummary, Value: "Build the project.", SourceFile: "Makefile", LineNumber: 4},
+				{Type: parser.DirectiveDoc, Value: "Clean up.", SourceFile: "Makefile", LineNumber: 6},
+			},
+			TargetMap: map[string]int{
+				"install": 3,
+				"build":   5,
+				"clean":   7,
+			},
+		},
+	}
+
+	model, err := NewBuilder(&BuilderConfig{}).Build(parsedFiles)
+	require.NoError(t, err)
+
+	install := GetTarget(model, "install")
+	require.NotNil(t, install)
+	assert.Equal(t, "Install system packages.", install.ExplicitSummary)
+	assert.Equal(t, []string{"Install system packages."}, install.Summary)
+
+	build := GetTarget(model, "build")
+	require.NotNil(t, build, "a !summary alone documents a target")
+	assert.Empty(t, build.Documentation)
+	assert.Equal(t, []string{"Build the project."}, build.Summary)
+
+	clean := GetTarget(model, "clean")
+	require.NotNil(t, clean)
+	assert.Empty(t, clean.ExplicitSummary)
+	assert.Equal(t, []string{"Clean up."}, clean.Summary)
+}
+
+func TestBuild_Profiles(t *testing.T) {
+	t.Parallel()
+	parsedFiles := []*parser.ParsedFile{
+		{
+			Path: "Makefile",
+			Directives: []parser.Directive{
+				{Type: parser.DirectiveProfile, Value: "CI", SourceFile: "Makefile", LineNumber: 1},
+				{Type: parser.DirectiveDoc, Value: "Publish coverage.", SourceFile: "Makefile", LineNumber: 2},
+				{Type: parser.DirectiveProfile, Value: "dev", SourceFile: "Makefile", LineNumber: 4},
+				{Type: parser.DirectiveDoc, Value: "Start the dev server.", SourceFile: "Makefile", LineNumber: 5},
+				{Type: parser.DirectiveDoc, Value: "Build the project.", SourceFile: "Makefile", LineNumber: 7},
+			},
+			TargetMap: map[string]int{
+				"coverage": 3,
+				"serve":    6,
+				"build":    8,
+			},
+		},
+	}
+
+	model, err := NewBuilder(&BuilderConfig{}).Build(parsedFiles)
+	require.NoError(t, err)
+	coverage := GetTarget(model, "coverage")
+	require.NotNil(t, coverage)
+	assert.Equal(t, []string{"ci"}, coverage.Profiles)
+	assert.NotNil(t, GetTarget(model, "serve"), "all targets are shown without a profile")
+
+	model, err = NewBuilder(&BuilderConfig{Profile: "ci"}).Build(parsedFiles)
+	require.NoError(t, err)
+	assert.NotNil(t, GetTarget(model, "coverage"))
+	assert.Nil(t, GetTarget(model, "serve"), "targets from other profiles should be omitted")
+	assert.NotNil(t, GetTarget(model, "build"), "untagged targets are shown in every profile")
+
+	model, err = NewBuilder(&BuilderConfig{Profile: "Dev"}).Build(parsedFiles)
+	require.NoError(t, err)
+	assert.Nil(t, GetTarget(model, "coverage"))
+	assert.NotNil(t, GetTarget(model, "serve"), "profile matching is case-insensitive")
+}
+
+func TestBuild_OnlyFiles(t *testing.T) {
+	t.Parallel()
+	parsedFiles := []*parser.ParsedFile{
+		{
+			Path: "/repo/Makefile",
+			Directives: []parser.Directive{
+				{Type: parser.DirectiveFile, Value: "Project build.", SourceFile: "/repo/Makefile", LineNumber: 1},
+				{Type: parser.DirectiveCategory, Value: "Build", SourceFile: "/repo/Makefile", LineNumber: 2},
+				{Type: parser.DirectiveDoc, Value: "Build the project.", SourceFile: "/repo/Makefile", LineNumber: 3},
+			},
+			TargetMap: map[string]int{"build": 4},
+		},
+		{
+			Path: "/repo/make/docker.mk",
+			Directives: []parser.Directive{
+				{Type: parser.DirectiveFile, Value: "Docker helpers.", SourceFile: "/repo/make/docker.mk", LineNumber: 1},
+				{Type: parser.DirectiveCategory, Value: "Docker", SourceFile: "/repo/make/docker.mk", LineNumber: 2},
+				{Type: parser.DirectiveDoc, Value: "Build the image.", SourceFile: "/repo/make/docker.mk", LineNumber: 3},
+			},
+			TargetMap: map[string]int{"image": 4},
+		},
+	}
+
+	model, err := NewBuilder(&BuilderConfig{BaseDir: "/repo", OnlyFiles: []string{"make/*.mk"}}).Build(parsedFiles)
+	require.NoError(t, err)
+	assert.Nil(t, GetTarget(model, "build"))
+	assert.NotNil(t, GetTarget(model, "image"))
+	require.Len(t, model.Categories, 1, "categories left without targets should be dropped")
+	assert.Equal(t, "Docker", model.Categories[0].Name)
+	require.Len(t, model.FileDocs, 1)
+	assert.Equal(t, "/repo/make/docker.mk", model.FileDocs[0].SourceFile)
+}
+
+func TestBuild_EntryPoint(t *testing.T) {
+	t.Parallel()
+	fileDocs := func(path, doc string) *parser.ParsedFile {
+		return &parser.ParsedFile{
+			Path: path,
+			Directives: []parser.Directive{
+				{Type: parser.DirectiveFile, Value: doc, SourceFile: path, LineNumber: 1},
+			},
+		}
+	}
+	parsedFiles := []*parser.ParsedFile{
+		{Path: "/repo/Makefile"},
+		fileDocs("/repo/make/build.mk", "Build rules."),
+		fileDocs("/repo/make/docker.mk", "Docker helpers."),
+	}
+	entryPoints := func(model *HelpModel) []string {
+		var files []string
+		for _, fileDoc := range model.FileDocs {
+			if fileDoc.IsEntryPoint {
+				files = append(files, fileDoc.SourceFile)
+			}
+		}
+		return files
+	}
+
+	// Without a documented main Makefile, the first documented file is used
+	model, err := NewBuilder(&BuilderConfig{BaseDir: "/repo"}).Build(parsedFiles)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/repo/make/build.mk"}, entryPoints(model))
+
+	model, err = NewBuilder(&BuilderConfig{BaseDir: "/repo", EntryPoint: "make/docker.mk"}).Build(parsedFiles)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/repo/make/docker.mk"}, entryPoints(model))
+
+	// Excluding the entry point does not promote another file
+	model, err = NewBuilder(&BuilderConfig{BaseDir: "/repo", ExcludeFiles: []string{"build.mk"}}).Build(parsedFiles)
+	require.NoError(t, err)
+	assert.Empty(t, entryPoints(model))
+
+	_, err = NewBuilder(&BuilderConfig{BaseDir: "/repo", EntryPoint: "make/missing.mk"}).Build(parsedFiles)
+	require.EqualError(t, err, "entry point make/missing.mk is not one of the Makefiles")
+}
+
+func TestBuild_Undocumented(t *testing.T) {
+	t.Parallel()
+	parsedFiles := []*parser.ParsedFile{
+		{
+			Path: "/repo/Makefile",
+			Directives: []parser.Directive{
+				{Type: parser.DirectiveDoc, Value: "Build the project.", SourceFile: "/repo/Makefile", LineNumber: 1},
+			},
+			TargetMap: map[string]int{"build": 2, "clean": 4, "out.txt": 6},
+		},
+		{
+			Path:      "/repo/vendor/tools.mk",
+			TargetMap: map[string]int{"vendor-sync": 1},
+		},
+	}
+	builder := NewBuilder(&BuilderConfig{
+		BaseDir:      "/repo",
+		PhonyTargets: map[string]bool{"build": true, "clean": true, "vendor-sync": true},
+		ExcludeFiles: []string{"vendor/**"},
+	})
+
+	_, err := builder.Build(parsedFiles)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"clean"}, builder.Undocumented(),
+		"file targets and targets from excluded files should not be counted")
+}
+
+func TestBuild_Exclude(t *testing.T) {
+	t.Parallel()
+	parsedFiles := []*parser.ParsedFile{
+		{
+			Path: "/repo/Makefile",
+			Directives: []parser.Directive{
+				{Type: parser.DirectiveCategory, Value: "Build", SourceFile: "/repo/Makefile", LineNumber: 1},
+				{Type: parser.DirectiveDoc, Value: "Build the project.", SourceFile: "/repo/Makefile", LineNumber: 2},
+				{Type: parser.DirectiveDoc, Value: "Internal helper.", SourceFile: "/repo/Makefile", LineNumber: 4},
+			},
+			TargetMap: map[string]int{"build": 3, "internal-sync": 5},
+		},
+		{
+			Path: "/repo/third_party/acme/rules.mk",
+			Directives: []parser.Directive{
+				{Type: parser.DirectiveFile, Value: "Vendored rules.", SourceFile: "/repo/third_party/acme/rules.mk", LineNumber: 1},
+				{Type: parser.DirectiveCategory, Value: "Vendor", SourceFile: "/repo/third_party/acme/rules.mk", LineNumber: 2},
+				{Type: parser.DirectiveDoc, Value: "Vendored target.", SourceFile: "/repo/third_party/acme/rules.mk", LineNumber: 3},
+			},
+			TargetMap: map[string]int{"acme": 4},
+		},
+	}
+
+	model, err := NewBuilder(&BuilderConfig{
+		BaseDir:        "/repo",
+		ExcludeTargets: []string{"internal-*"},
+		ExcludeFiles:   []string{"third_party/**"},
+	}).Build(parsedFiles)
+	require.NoError(t, err)
+	assert.NotNil(t, GetTarget(model, "build"))
+	assert.Nil(t, GetTarget(model, "internal-sync"))
+	assert.Nil(t, GetTarget(model, "acme"))
+	require.Len(t, model.Categories, 1)
+	assert.Equal(t, "Build", model.Categories[0].Name)
+	assert.Empty(t, model.FileDocs)
+}
+
+func TestMatchesFilePattern(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{pattern: "make/docker.mk", path: "/repo/make/docker.mk", want: true},
+		{pattern: "make/*.mk", path: "/repo/make/docker.mk", want: true},
+		{pattern: "docker.mk", path: "/repo/make/docker.mk", want: true},
+		{pattern: "*.mk", path: "/repo/make/docker.mk", want: true},
+		{pattern: "other/*.mk", path: "/repo/make/docker.mk", want: false},
+		{pattern: "Makefile", path: "/repo/Makefile", want: true},
+		{pattern: "*.mk", path: "/repo/Makefile", want: false},
+		{pattern: "third_party/**", path: "/repo/third_party/acme/rules.mk", want: true},
+		{pattern: "third_party/**", path: "/repo/make/docker.mk", want: false},
+		{pattern: "**/vendor.mk", path: "/repo/a/b/vendor.mk", want: true},
+		{pattern: "**/vendor.mk", path: "/repo/vendor.mk", want: true},
+	}
+
+	for _, tt := range tests {
+		if got := MatchesFilePattern(tt.pattern, tt.path, "/repo"); got != tt.want {
+			t.Errorf("MatchesFilePattern(%q, %q) = %v, want %v", tt.pattern, tt.path, got, tt.want)
+		}
+	}
+}
+
 func TestBuild_MixedCategorizationError(t *testing.T) {
 	t.Parallel()
 	config := &BuilderConfig{DefaultCategory: ""}
@@ -322,6 +874,49 @@ func TestBuild_MixedCategorizationError(t *testing.T) {
 	assert.IsType(t, &errors.MixedCategorizationError{}, err)
 }
 
+func TestBuild_MixedCategorizationBestEffort(t *testing.T) {
+	t.Parallel()
+	builder := NewBuilder(&BuilderConfig{BestEffort: true})
+
+	parsedFiles := []*parser.ParsedFile{
+		{
+			Path: "Makefile",
+			Directives: []parser.Directive{
+				{Type: parser.DirectiveCategory, Value: "Build", SourceFile: "Makefile", LineNumber: 1},
+				{Type: parser.DirectiveDoc, Value: "Build the project.", SourceFile: "Makefile", LineNumber: 2},
+			},
+			TargetMap: map[string]int{
+				"build": 3,
+			},
+		},
+		{
+			Path: "include.mk",
+			Directives: []parser.Directive{
+				{Type: parser.DirectiveDoc, Value: "Run tests.", SourceFile: "include.mk", LineNumber: 1},
+			},
+			TargetMap: map[string]int{
+				"test": 2,
+			},
+		},
+	}
+
+	model, err := builder.Build(parsedFiles)
+
+	require.NoError(t, err)
+	require.Len(t, model.Categories, 2)
+	var fallback *Category
+	for i := range model.Categories {
+		if model.Categories[i].Name == BestEffortCategoryName {
+			fallback = &model.Categories[i]
+		}
+	}
+	require.NotNil(t, fallback)
+	require.Len(t, fallback.Targets, 1)
+	assert.Equal(t, "test", fallback.Targets[0].Name)
+	assert.Equal(t, []string{bestEffortNote}, fallback.Documentation)
+	assert.Equal(t, []string{`mixed categorization: placed test in category "Uncategorized"`}, builder.Degradations())
+}
+
 func TestBuild_MixedCategorizationWithDefaultCategory(t *testing.T) {
 	t.Parallel()
 	defaultCategory := "Other"
@@ -541,10 +1136,12 @@ func TestParseVarDirective(t *testing.T) {
 	builder := NewBuilder(&BuilderConfig{DefaultCategory: ""})
 
 	tests := []struct {
-		name     string
-		input    string
-		wantName string
-		wantDesc string
+		name         string
+		input        string
+		wantName     string
+		wantDesc     string
+		wantRequired bool
+		wantChoices  []string
 	}{
 		{
 			name:     "with description",
@@ -570,6 +1167,78 @@ func TestParseVarDirective(t *testing.T) {
 			wantName: "PORT",
 			wantDesc: "The port number - defaults to 8080",
 		},
+		{
+			name:         "required with description",
+			input:        "TOKEN (required) - API token",
+			wantName:     "TOKEN",
+			wantDesc:     "API token",
+			wantRequired: true,
+		},
+		{
+			name:         "required without description",
+			input:        "TOKEN (required)",
+			wantName:     "TOKEN",
+			wantRequired: true,
+		},
+		{
+			name:     "required in description only",
+			input:    "TOKEN - API token (required)",
+			wantName: "TOKEN",
+			wantDesc: "API token (required)",
+		},
+		{
+			name:        "choices",
+			input:       "ENV (choices: dev, staging,prod) - Deployment environment",
+			wantName:    "ENV",
+			wantDesc:    "Deployment environment",
+			wantChoices: []string{"dev", "staging", "prod"},
+		},
+		{
+			name:         "required and choices",
+			input:        "ENV (choices: dev,prod) (required)",
+			wantName:     "ENV",
+			wantRequired: true,
+			wantChoices:  []string{"dev", "prod"},
+		},
+		{
+			name:        "empty choices",
+			input:       "ENV (choices: ) - Deployment environment",
+			wantName:    "ENV",
+			wantDesc:    "Deployment environment",
+			wantChoices: []string{},
+		},
+		{
+			name:     "unknown marker starts the description",
+			input:    "ENV (optional) - Deployment environment",
+			wantName: "ENV",
+			wantDesc: "(optional) Deployment environment",
+		},
+		{
+			name:     "space-separated description",
+			input:    "LDFLAGS Linker flags for build",
+			wantName: "LDFLAGS",
+			wantDesc: "Linker flags for build",
+		},
+		{
+			name:     "space-separated description with parentheses",
+			input:    "PLATFORMS Target platforms (default: linux/amd64)",
+			wantName: "PLATFORMS",
+			wantDesc: "Target platforms (default: linux/amd64)",
+		},
+		{
+			name:         "space-separated description after markers",
+			input:        "TOKEN (required) API token",
+			wantName:     "TOKEN",
+			wantDesc:     "API token",
+			wantRequired: true,
+		},
+		{
+			name:         "marker without space",
+			input:        "TOKEN(required) - API token",
+			wantName:     "TOKEN",
+			wantDesc:     "API token",
+			wantRequired: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -578,6 +1247,8 @@ func TestParseVarDirective(t *testing.T) {
 			result := builder.parseVarDirective(tt.input)
 			assert.Equal(t, tt.wantName, result.Name)
 			assert.Equal(t, tt.wantDesc, result.Description)
+			assert.Equal(t, tt.wantRequired, result.Required)
+			assert.Equal(t, tt.wantChoices, result.Choices)
 		})
 	}
 }
@@ -1488,3 +2159,155 @@ func TestBuild_NotAliasWithDocumentation(t *testing.T) {
 	// b should be tracked as !notalias (even though redundant)
 	assert.True(t, builder.NotAliasTargets()["b"])
 }
+
+func TestBuild_DefinitionConflicts(t *testing.T) {
+	t.Parallel()
+	builder := NewBuilder(&BuilderConfig{})
+
+	parsedFiles := []*parser.ParsedFile{
+		{
+			Path:      "Makefile",
+			TargetMap: map[string]int{"build": 3, "test": 6},
+			Definitions: []parser.TargetDefinition{
+				{Name: "build", LineNumber: 3, HasRecipe: true},
+				{Name: "test", LineNumber: 6, HasRecipe: true},
+				{Name: "clean", LineNumber: 9, DoubleColon: true, HasRecipe: true},
+				{Name: "install", LineNumber: 12, DoubleColon: true, HasRecipe: true},
+			},
+		},
+		{
+			Path:      "make/build.mk",
+			TargetMap: map[string]int{"build": 1, "test": 4},
+			Definitions: []parser.TargetDefinition{
+				{Name: "build", LineNumber: 1, HasRecipe: true},
+				// Adding prerequisites without a recipe is not a conflict
+				{Name: "test", LineNumber: 4},
+				// Double-colon rules may be spread across files
+				{Name: "clean", LineNumber: 7, DoubleColon: true, HasRecipe: true},
+				{Name: "install", LineNumber: 10, HasRecipe: true},
+			},
+		},
+	}
+
+	_, err := builder.Build(parsedFiles)
+	require.NoError(t, err)
+
+	conflicts := builder.DefinitionConflicts()
+	require.Len(t, conflicts, 2)
+	assert.Equal(t, "build", conflicts[0].Target)
+	assert.Equal(t, []RecipeDefinition{
+		{SourceFile: "Makefile", LineNumber: 3},
+		{SourceFile: "make/build.mk", LineNumber: 1},
+	}, conflicts[0].Definitions)
+	assert.False(t, conflicts[0].MixesColons())
+	assert.Equal(t, "install", conflicts[1].Target)
+	assert.True(t, conflicts[1].MixesColons())
+}
+
+func TestBuild_CategoryRename(t *testing.T) {
+	t.Parallel()
+	builder := NewBuilder(&BuilderConfig{
+		CategoryRename: map[string]string{"Bld": "Build", "QA": "Test"},
+	})
+
+	parsedFiles := []*parser.ParsedFile{
+		{
+			Path: "Makefile",
+			Directives: []parser.Directive{
+				{Type: parser.DirectiveCategory, Value: "Build", SourceFile: "Makefile", LineNumber: 1},
+				{Type: parser.DirectiveDoc, Value: "Build the project.", SourceFile: "Makefile", LineNumber: 2},
+				{Type: parser.DirectiveCategory, Value: "Bld", SourceFile: "Makefile", LineNumber: 5},
+				{Type: parser.DirectiveDoc, Value: "Compile sources.", SourceFile: "Makefile", LineNumber: 6},
+				{Type: parser.DirectiveCategory, Value: "QA", SourceFile: "Makefile", LineNumber: 9},
+				{Type: parser.DirectiveDoc, Value: "Run tests.", SourceFile: "Makefile", LineNumber: 10},
+			},
+			TargetMap: map[string]int{"build": 3, "compile": 7, "test": 11},
+		},
+	}
+
+	model, err := builder.Build(parsedFiles)
+	require.NoError(t, err)
+
+	names := make(map[string][]string)
+	for _, category := range model.Categories {
+		for _, target := range category.Targets {
+			names[category.Name] = append(names[category.Name], target.Name)
+		}
+	}
+	assert.Len(t, model.Categories, 2)
+	assert.ElementsMatch(t, []string{"build", "compile"}, names["Build"])
+	assert.Equal(t, []string{"test"}, names["Test"])
+}
+
+func TestBuild_Deterministic(t *testing.T) {
+	t.Parallel()
+	// Aliases, categories, and targets come from several maps; building the
+	// same files repeatedly must give the same model, in discovery order
+	config := &BuilderConfig{
+		PhonyTargets: map[string]bool{
+			"test": true, "t": true, "check": true, "verify": true,
+			"build": true, "b": true, "lint": true, "docs": true,
+		},
+		Dependencies: map[string][]string{
+			"t": {"test"}, "check": {"test"}, "verify": {"test"}, "b": {"build"},
+		},
+		HasRecipe: map[string]bool{"test": true, "build": true, "lint": true, "docs": true},
+	}
+	parsedFiles := []*parser.ParsedFile{
+		{
+			Path: "Makefile",
+			Directives: []parser.Directive{
+				{Type: parser.DirectiveDoc, Value: "Run the tests.", SourceFile: "Makefile", LineNumber: 1},
+				{Type: parser.DirectiveDoc, Value: "Build the project.", SourceFile: "Makefile", LineNumber: 6},
+				{Type: parser.DirectiveDoc, Value: "Lint the code.", SourceFile: "Makefile", LineNumber: 9},
+			},
+			TargetMap: map[string]int{"test": 2, "verify": 3, "t": 4, "check": 5, "build": 7, "b": 8, "lint": 10},
+		},
+		{
+			Path: "docs.mk",
+			Directives: []parser.Directive{
+				{Type: parser.DirectiveDoc, Value: "Build the docs.", SourceFile: "docs.mk", LineNumber: 1},
+			},
+			TargetMap: map[string]int{"docs": 2},
+		},
+	}
+
+	first, err := NewBuilder(config).Build(parsedFiles)
+	require.NoError(t, err)
+	require.Len(t, first.Categories, 1)
+	var names []string
+	for _, target := range first.Categories[0].Targets {
+		names = append(names, target.Name)
+	}
+	assert.Equal(t, []string{"test", "build", "lint", "docs"}, names, "targets are in discovery order")
+	assert.Equal(t, []string{"verify", "t", "check"}, first.Categories[0].Targets[0].Aliases, "implicit aliases are in discovery order")
+
+	for range 50 {
+		model, err := NewBuilder(config).Build(parsedFiles)
+		require.NoError(t, err)
+		require.Equal(t, first, model)
+	}
+}
+
+func TestBuild_DeterministicCategorizationError(t *testing.T) {
+	t.Parallel()
+	parsedFiles := []*parser.ParsedFile{
+		{
+			Path: "Makefile",
+			Directives: []parser.Directive{
+				{Type: parser.DirectiveDoc, Value: "Format the code.", SourceFile: "Makefile", LineNumber: 1},
+				{Type: parser.DirectiveDoc, Value: "Vet the code.", SourceFile: "Makefile", LineNumber: 3},
+				{Type: parser.DirectiveDoc, Value: "Lint the code.", SourceFile: "Makefile", LineNumber: 5},
+				{Type: parser.DirectiveCategory, Value: "Build", SourceFile: "Makefile", LineNumber: 7},
+				{Type: parser.DirectiveDoc, Value: "Build the project.", SourceFile: "Makefile", LineNumber: 8},
+			},
+			TargetMap: map[string]int{"fmt": 2, "vet": 4, "lint": 6, "build": 9},
+		},
+	}
+
+	for range 50 {
+		_, err := NewBuilder(&BuilderConfig{}).Build(parsedFiles)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Uncategorized targets: fmt, vet, lint")
+	}
+}
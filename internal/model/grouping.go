@@ -0,0 +1,79 @@
+package model
+
+import (
+	"path/filepath"
+	"sort"
+
+	"github.com/sdlcforge/make-help/internal/orderedmap"
+)
+
+// GroupByFile regroups the targets of a built HelpModel into one category
+// per source file, replacing the !category grouping. Categories are named
+// by the file's path relative to baseDir.
+//
+// Each included file's !file documentation moves from FileDocs to its
+// category as the section introduction; the entry point's documentation
+// stays in FileDocs as the overall introduction.
+func GroupByFile(helpModel *HelpModel, baseDir string) {
+	fileDocs := make(map[string]FileDoc, len(helpModel.FileDocs))
+	var remainingDocs []FileDoc
+	for _, fileDoc := range helpModel.FileDocs {
+		if fileDoc.IsEntryPoint {
+			remainingDocs = append(remainingDocs, fileDoc)
+		} else {
+			fileDocs[fileDoc.SourceFile] = fileDoc
+		}
+	}
+
+	categoryMap := orderedmap.New[string, *Category]()
+	for _, category := range helpModel.Categories {
+		for _, target := range category.Targets {
+			group, exists := categoryMap.Get(target.SourceFile)
+			if !exists {
+				group = &Category{
+					Name:           relativeSourcePath(target.SourceFile, baseDir),
+					Documentation:  fileDocs[target.SourceFile].Documentation,
+					DiscoveryOrder: target.DiscoveryOrder,
+				}
+				categoryMap.Set(target.SourceFile, group)
+			}
+			group.Targets = append(group.Targets, target)
+			group.DiscoveryOrder = min(group.DiscoveryOrder, target.DiscoveryOrder)
+		}
+	}
+
+	// Files without targets keep their documentation in FileDocs
+	for _, fileDoc := range helpModel.FileDocs {
+		if !fileDoc.IsEntryPoint && !categoryMap.Has(fileDoc.SourceFile) {
+			remainingDocs = append(remainingDocs, fileDoc)
+		}
+	}
+
+	categories := make([]Category, 0, categoryMap.Len())
+	for category := range categoryMap.Values() {
+		categories = append(categories, *category)
+	}
+	sort.Slice(categories, func(i, j int) bool {
+		return categories[i].DiscoveryOrder < categories[j].DiscoveryOrder
+	})
+	for i := range categories {
+		categories[i].DiscoveryOrder = i
+	}
+
+	helpModel.FileDocs = remainingDocs
+	helpModel.Categories = categories
+	helpModel.HasCategories = len(categories) > 0
+}
+
+// relativeSourcePath returns path relative to baseDir, or path unchanged
+// if baseDir is empty or the path cannot be made relative.
+func relativeSourcePath(path, baseDir string) string {
+	if baseDir == "" || path == "" {
+		return path
+	}
+	rel, err := filepath.Rel(baseDir, path)
+	if err != nil {
+		return path
+	}
+	return rel
+}
@@ -0,0 +1,58 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/sdlcforge/make-help/internal/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuild_GroupByFile(t *testing.T) {
+	t.Parallel()
+	parsedFiles := []*parser.ParsedFile{
+		{
+			Path: "/repo/Makefile",
+			Directives: []parser.Directive{
+				{Type: parser.DirectiveFile, Value: "Project build.", SourceFile: "/repo/Makefile", LineNumber: 1},
+				{Type: parser.DirectiveDoc, Value: "Build the project.", SourceFile: "/repo/Makefile", LineNumber: 3},
+			},
+			TargetMap: map[string]int{"build": 4},
+		},
+		{
+			Path: "/repo/mk/docker.mk",
+			Directives: []parser.Directive{
+				{Type: parser.DirectiveFile, Value: "Docker helpers.", SourceFile: "/repo/mk/docker.mk", LineNumber: 1},
+				{Type: parser.DirectiveCategory, Value: "Docker", SourceFile: "/repo/mk/docker.mk", LineNumber: 2},
+				{Type: parser.DirectiveDoc, Value: "Build the image.", SourceFile: "/repo/mk/docker.mk", LineNumber: 3},
+				{Type: parser.DirectiveDoc, Value: "Push the image.", SourceFile: "/repo/mk/docker.mk", LineNumber: 5},
+			},
+			TargetMap: map[string]int{"image": 4, "push": 6},
+		},
+		{
+			Path: "/repo/mk/empty.mk",
+			Directives: []parser.Directive{
+				{Type: parser.DirectiveFile, Value: "No targets here.", SourceFile: "/repo/mk/empty.mk", LineNumber: 1},
+			},
+			TargetMap: map[string]int{},
+		},
+	}
+
+	// Mixed categorization is fine: categories are replaced by files
+	model, err := NewBuilder(&BuilderConfig{GroupByFile: true, BaseDir: "/repo"}).Build(parsedFiles)
+	require.NoError(t, err)
+	assert.True(t, model.HasCategories)
+
+	require.Len(t, model.Categories, 2)
+	assert.Equal(t, "Makefile", model.Categories[0].Name)
+	assert.Empty(t, model.Categories[0].Documentation, "entry point docs stay in FileDocs")
+	assert.Equal(t, "mk/docker.mk", model.Categories[1].Name)
+	assert.Equal(t, []string{"Docker helpers."}, model.Categories[1].Documentation)
+	assert.Len(t, model.Categories[1].Targets, 2)
+
+	var remaining []string
+	for _, fileDoc := range model.FileDocs {
+		remaining = append(remaining, fileDoc.SourceFile)
+	}
+	assert.ElementsMatch(t, []string{"/repo/Makefile", "/repo/mk/empty.mk"}, remaining)
+}
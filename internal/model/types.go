@@ -17,8 +17,13 @@ type FileDoc struct {
 	// DiscoveryOrder tracks when this file was discovered (used for --keep-order-files).
 	DiscoveryOrder int
 
-	// IsEntryPoint is true for the entry point Makefile (the first file).
+	// IsEntryPoint is true for the one file whose documentation introduces
+	// the help (see BuilderConfig.EntryPoint).
 	IsEntryPoint bool
+
+	// Owner is the team responsible for the file, from an !owner directive
+	// in its !file block (e.g., "platform-team (#platform on Slack)").
+	Owner string
 }
 
 // HelpModel represents the complete parsed help documentation from all Makefiles.
@@ -35,6 +40,35 @@ type HelpModel struct {
 	// DefaultCategory is the category name for uncategorized targets
 	// (set via --default-category flag).
 	DefaultCategory string
+
+	// DefaultGoal is the target make runs when invoked without arguments
+	// (.DEFAULT_GOAL). Empty if unknown.
+	DefaultGoal string
+
+	// Usage is the usage line shown at the top of help output, from the
+	// first !usage directive. Empty means the default usage line.
+	Usage string
+
+	// Examples are example invocations shown beneath the usage line, from
+	// !example directives in file order.
+	Examples []string
+
+	// Title names the project in generated documents, from the first !title
+	// directive. Empty means the generic "Makefile Help".
+	Title string
+
+	// Homepage is the project's homepage URL, from the first !homepage
+	// directive.
+	Homepage string
+
+	// Repo is the project's source repository URL, from the first !repo
+	// directive.
+	Repo string
+
+	// FooterDocs contains documentation lines shown after the target
+	// listing, from !footer blocks. Multiple blocks are concatenated with
+	// blank line separation.
+	FooterDocs []string
 }
 
 // Category represents a documentation category containing related targets.
@@ -46,6 +80,10 @@ type Category struct {
 	// Targets contains all targets in this category.
 	Targets []Target
 
+	// Documentation is an optional introduction shown under the category
+	// name. Set when grouping by file, from the file's !file documentation.
+	Documentation []string
+
 	// DiscoveryOrder tracks when this category was first encountered
 	// (used for --keep-order-categories).
 	DiscoveryOrder int
@@ -62,10 +100,15 @@ type Target struct {
 	// Documentation contains the full documentation lines (without ## prefix).
 	Documentation []string
 
-	// Summary is the extracted first sentence (computed from Documentation).
+	// Summary is the extracted first sentence (computed from Documentation),
+	// or ExplicitSummary when set.
 	// Stored as raw documentation lines; formatters parse to RichText as needed.
 	Summary []string
 
+	// ExplicitSummary is the text of a !summary directive, used as Summary
+	// instead of the first sentence of Documentation.
+	ExplicitSummary string
+
 	// Variables contains associated environment variables from !var directives.
 	Variables []Variable
 
@@ -81,6 +124,48 @@ type Target struct {
 
 	// IsPhony indicates whether this target is declared as .PHONY.
 	IsPhony bool
+
+	// Tags contains free-form labels from !tag directives.
+	Tags []string
+
+	// Deprecated is true if the target is marked with !deprecated.
+	Deprecated bool
+
+	// DeprecationMessage is the optional text following !deprecated
+	// (e.g., "Use compile instead.").
+	DeprecationMessage string
+
+	// Hidden is true if the target is marked with !hidden. Hidden targets are
+	// only kept in the model when BuilderConfig.IncludeHidden is set.
+	Hidden bool
+
+	// Platforms lists the operating systems from !os directives, lowercased
+	// (e.g., "linux", "darwin"). Empty means the target runs anywhere.
+	Platforms []string
+
+	// Duration is the free-form run time estimate from !duration (e.g., "~5m").
+	Duration string
+
+	// Profiles lists the audiences from !profile directives, lowercased
+	// (e.g., "ci", "dev"). Empty means the target is shown in every profile.
+	Profiles []string
+
+	// Dangerous is true if the target is marked with !danger. --run asks
+	// for confirmation before running dangerous targets.
+	Dangerous bool
+
+	// DangerReason is the optional text following !danger
+	// (e.g., "destroys the production database").
+	DangerReason string
+
+	// Owner is the team responsible for the target, from !owner or
+	// !maintainer. Targets without one inherit the owner of their file.
+	Owner string
+
+	// CIWorkflows lists the CI workflow files that run the target, from !ci
+	// directives (e.g., ".github/workflows/build.yml"), relative to the main
+	// Makefile directory.
+	CIWorkflows []string
 }
 
 // Variable represents a documented environment variable associated with a target.
@@ -90,4 +175,48 @@ type Variable struct {
 
 	// Description is the full description text from !var directive.
 	Description string
+
+	// Required is set by a "(required)" marker after the name
+	// (e.g., "!var TOKEN (required) - API token"). Run mode and the generated
+	// help file refuse to run the target while a required variable is unset.
+	Required bool
+
+	// Choices lists the allowed values from a "(choices: a,b,c)" marker after
+	// the name. Nil when no choices are declared; empty (non-nil) when the
+	// marker lists no values.
+	Choices []string
+}
+
+// DefinitionConflict is a target whose recipe is defined in more than one
+// file, which usually means a fragment was included twice or two includes
+// claim the same target name.
+type DefinitionConflict struct {
+	// Target is the conflicting target name.
+	Target string
+
+	// Definitions lists the rules with recipes, in discovery order.
+	Definitions []RecipeDefinition
+}
+
+// RecipeDefinition is one rule with a recipe for a conflicting target.
+type RecipeDefinition struct {
+	// SourceFile is the path to the file defining the rule.
+	SourceFile string
+
+	// LineNumber is the 1-based line number of the rule line.
+	LineNumber int
+
+	// DoubleColon is true for "target::" rules.
+	DoubleColon bool
+}
+
+// MixesColons reports whether the conflict mixes single- and double-colon
+// rules, which make rejects outright.
+func (c DefinitionConflict) MixesColons() bool {
+	for _, d := range c.Definitions[1:] {
+		if d.DoubleColon != c.Definitions[0].DoubleColon {
+			return true
+		}
+	}
+	return false
 }
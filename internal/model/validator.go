@@ -12,6 +12,7 @@ import (
 var generatedHelpTargets = map[string]bool{
 	"help":        true,
 	"update-help": true,
+	"help-regen":  true,
 }
 
 // ValidateCategorization ensures that the categorization rules are followed:
@@ -0,0 +1,8 @@
+// Package orderedmap provides a map that iterates in insertion order.
+//
+// Go randomizes map iteration, so aggregations that build output from a map
+// (targets merged from several files, categories created as targets are
+// assigned) can come out in a different order on every run unless each one
+// remembers to sort. A Map keeps the order in which keys were first set, so
+// model construction and lint output are reproducible by default.
+package orderedmap
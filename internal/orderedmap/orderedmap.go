@@ -0,0 +1,67 @@
+package orderedmap
+
+import "iter"
+
+// Map is a map from K to V that iterates in the order keys were first set.
+// The zero value is not usable; create one with New.
+type Map[K comparable, V any] struct {
+	keys   []K
+	values map[K]V
+}
+
+// New returns an empty Map.
+func New[K comparable, V any]() *Map[K, V] {
+	return &Map[K, V]{values: make(map[K]V)}
+}
+
+// Get returns the value of key, and whether key is set.
+func (m *Map[K, V]) Get(key K) (V, bool) {
+	value, ok := m.values[key]
+	return value, ok
+}
+
+// Has reports whether key is set.
+func (m *Map[K, V]) Has(key K) bool {
+	_, ok := m.values[key]
+	return ok
+}
+
+// Set sets the value of key. A key that is already set keeps its position.
+func (m *Map[K, V]) Set(key K, value V) {
+	if _, ok := m.values[key]; !ok {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = value
+}
+
+// Len returns the number of keys.
+func (m *Map[K, V]) Len() int {
+	return len(m.keys)
+}
+
+// Keys returns the keys in insertion order.
+func (m *Map[K, V]) Keys() []K {
+	return append([]K(nil), m.keys...)
+}
+
+// All returns an iterator over the keys and values in insertion order.
+func (m *Map[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, key := range m.keys {
+			if !yield(key, m.values[key]) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator over the values in insertion order.
+func (m *Map[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, key := range m.keys {
+			if !yield(m.values[key]) {
+				return
+			}
+		}
+	}
+}
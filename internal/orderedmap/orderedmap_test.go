@@ -0,0 +1,55 @@
+package orderedmap
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMap_InsertionOrder(t *testing.T) {
+	t.Parallel()
+	m := New[string, int]()
+	for i, key := range []string{"zeta", "alpha", "mu", "beta"} {
+		m.Set(key, i)
+	}
+	m.Set("alpha", 10)
+
+	assert.Equal(t, 4, m.Len())
+	assert.Equal(t, []string{"zeta", "alpha", "mu", "beta"}, m.Keys(), "setting a key again keeps its position")
+	assert.Equal(t, []int{0, 10, 2, 3}, slices.Collect(m.Values()))
+	assert.Equal(t, map[string]int{"zeta": 0, "alpha": 10, "mu": 2, "beta": 3}, maps.Collect(m.All()))
+
+	value, ok := m.Get("mu")
+	assert.True(t, ok)
+	assert.Equal(t, 2, value)
+	assert.True(t, m.Has("beta"))
+	assert.False(t, m.Has("omega"))
+}
+
+func TestMap_StopIteration(t *testing.T) {
+	t.Parallel()
+	m := New[string, int]()
+	m.Set("a", 1)
+	m.Set("b", 2)
+	m.Set("c", 3)
+
+	var seen []string
+	for key := range m.All() {
+		seen = append(seen, key)
+		if key == "b" {
+			break
+		}
+	}
+	assert.Equal(t, []string{"a", "b"}, seen)
+}
+
+func TestMap_KeysIsACopy(t *testing.T) {
+	t.Parallel()
+	m := New[string, int]()
+	m.Set("a", 1)
+	keys := m.Keys()
+	keys[0] = "changed"
+	assert.Equal(t, []string{"a"}, m.Keys())
+}
@@ -27,6 +27,39 @@ func IsTargetLine(line string) bool {
 	return strings.Contains(line, ":")
 }
 
+// ExtractIncludePath returns the first file named by an include directive
+// ("include", "-include", or "sinclude"), or "" if line is not one.
+func ExtractIncludePath(line string) string {
+	fields := strings.Fields(line)
+	if len(fields) < 2 || strings.HasPrefix(line, "\t") {
+		return ""
+	}
+	switch fields[0] {
+	case "include", "-include", "sinclude":
+		return fields[1]
+	default:
+		return ""
+	}
+}
+
+// ExtractDoubleColonTargetName extracts the target name from a double-colon
+// rule line ("clean:: deps"), or returns "" if line is not one.
+// "::=" assignments are not rules.
+func ExtractDoubleColonTargetName(line string) string {
+	if strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") {
+		return ""
+	}
+	idx := strings.Index(line, "::")
+	if idx == -1 || idx != strings.Index(line, ":") || strings.HasPrefix(line[idx+2:], "=") {
+		return ""
+	}
+	fields := strings.Fields(line[:idx])
+	if len(fields) == 0 || strings.Contains(line[:idx], "=") {
+		return ""
+	}
+	return fields[0]
+}
+
 // ExtractTargetName extracts the target name from a target definition line.
 //
 // Handles the following cases:
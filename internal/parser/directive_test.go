@@ -400,3 +400,49 @@ func TestExtractTargetName_RealWorldExamples(t *testing.T) {
 		})
 	}
 }
+
+func TestExtractIncludePath(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		line     string
+		expected string
+	}{
+		{name: "include", line: "include mk/common.mk", expected: "mk/common.mk"},
+		{name: "optional include", line: "-include mk/common.mk", expected: "mk/common.mk"},
+		{name: "sinclude", line: "sinclude common.mk", expected: "common.mk"},
+		{name: "first of several files", line: "include a.mk b.mk", expected: "a.mk"},
+		{name: "include without file", line: "include", expected: ""},
+		{name: "recipe line", line: "\tinclude common.mk", expected: ""},
+		{name: "target", line: "include: build", expected: ""},
+		{name: "variable", line: "INCLUDES := a.mk", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, ExtractIncludePath(tt.line))
+		})
+	}
+}
+
+func TestExtractDoubleColonTargetName(t *testing.T) {
+	tests := []struct {
+		line string
+		want string
+	}{
+		{"clean::", "clean"},
+		{"clean:: deps", "clean"},
+		{"all install::", "all"},
+		{"VAR ::= value", ""},
+		{"build: deps", ""},
+		{"URL = http://host::8080", ""},
+		{"\tclean::", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			assert.Equal(t, tt.want, ExtractDoubleColonTargetName(tt.line))
+		})
+	}
+}
@@ -5,6 +5,8 @@
 //   - !category: Category grouping for targets
 //   - !var: Environment variable documentation
 //   - !alias: Target aliases
+//   - !source: Where the file named by the following include line is
+//     fetched from (see ParsedFile.RemoteIncludes)
 //
 // # Documentation Syntax
 //
@@ -0,0 +1,50 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// FuzzScanContent checks that any Makefile content scans without panicking
+// and that everything found refers to lines of the content.
+func FuzzScanContent(f *testing.F) {
+	// Seeds are the fixture and example Makefiles
+	for _, pattern := range []string{
+		filepath.Join("..", "..", "test", "fixtures", "makefiles", "*.mk"),
+		filepath.Join("..", "..", "examples", "*", "Makefile"),
+	} {
+		seeds, err := filepath.Glob(pattern)
+		if err != nil {
+			f.Fatal(err)
+		}
+		for _, seed := range seeds {
+			content, err := os.ReadFile(seed)
+			if err != nil {
+				f.Fatal(err)
+			}
+			f.Add(string(content))
+		}
+	}
+	f.Add("")
+	f.Add("## !file\n## About.\n\n## !category Build\n## !alias b\n## Build it.\nbuild: deps ## inline\n\tgo build\n")
+
+	f.Fuzz(func(t *testing.T, content string) {
+		result, err := NewScanner().ScanContent(content, "Makefile")
+		if err != nil {
+			return
+		}
+		lineCount := strings.Count(content, "\n") + 1
+		for _, directive := range result.Directives {
+			if directive.LineNumber < 1 || directive.LineNumber > lineCount {
+				t.Fatalf("directive %+v is outside the %d lines of the content", directive, lineCount)
+			}
+		}
+		for name, line := range result.TargetMap {
+			if line < 1 || line > lineCount {
+				t.Fatalf("target %q on line %d is outside the %d lines of the content", name, line, lineCount)
+			}
+		}
+	})
+}
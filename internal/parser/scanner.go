@@ -10,8 +10,11 @@ import (
 // It maintains state to track pending documentation that will be associated
 // with the next target.
 type Scanner struct {
-	currentFile string      // Current file being scanned
-	pendingDocs []Directive // Documentation lines awaiting target association
+	currentFile   string         // Current file being scanned
+	pendingDocs   []Directive    // Documentation lines awaiting target association
+	pendingSource *RemoteInclude // !source annotation awaiting an include line
+	inFileBlock   bool           // Inside the documentation block started by !file
+	inFooter      bool           // Inside the documentation block started by !footer
 }
 
 // NewScanner creates a new Scanner instance.
@@ -40,6 +43,9 @@ func (s *Scanner) ScanContent(content string, path string) (*ParsedFile, error)
 	// Reset scanner state
 	s.currentFile = path
 	s.pendingDocs = []Directive{}
+	s.pendingSource = nil
+	s.inFileBlock = false
+	s.inFooter = false
 
 	result := &ParsedFile{
 		Path:       path,
@@ -49,28 +55,80 @@ func (s *Scanner) ScanContent(content string, path string) (*ParsedFile, error)
 
 	lines := strings.Split(content, "\n")
 
+	// recipeOwner is the index in result.Definitions of the rule that
+	// tab-indented lines belong to, or -1 outside a rule.
+	recipeOwner := -1
+
 	for lineNum, line := range lines {
 		lineNumber := lineNum + 1 // 1-based line numbers
 
+		if strings.HasPrefix(line, "\t") {
+			if recipeOwner >= 0 {
+				result.Definitions[recipeOwner].HasRecipe = true
+			}
+		} else if trimmed := strings.TrimSpace(line); trimmed != "" && !strings.HasPrefix(trimmed, "#") {
+			recipeOwner = -1
+		}
+
+		// !source annotates the include line that follows it
+		if source, ok := parseSourceDirective(line); ok {
+			s.pendingSource = &source
+			continue
+		}
+		if s.pendingSource != nil && !IsDocumentationLine(line) {
+			if includePath := ExtractIncludePath(line); includePath != "" {
+				s.pendingSource.Path = includePath
+				s.pendingSource.LineNumber = lineNumber
+				result.RemoteIncludes = append(result.RemoteIncludes, *s.pendingSource)
+			}
+			s.pendingSource = nil
+		}
+
 		// Check for documentation line
 		if IsDocumentationLine(line) {
 			directive := s.parseDirective(line, lineNumber)
 
-			// !file directives are added immediately and not queued
+			// !file directives, !owner in the block they start, !footer
+			// blocks, and the header and project directives describe the
+			// file and are added immediately rather than queued
+			if directive.Type == DirectiveOwner && s.inFileBlock {
+				directive.Type = DirectiveFileOwner
+			}
+			if directive.Type == DirectiveDoc && s.inFooter {
+				directive.Type = DirectiveFooterDoc
+			}
 			if directive.Type == DirectiveFile {
+				s.inFileBlock, s.inFooter = true, false
+			}
+			if directive.Type == DirectiveFooter {
+				s.inFileBlock, s.inFooter = false, true
+			}
+			switch directive.Type {
+			case DirectiveFile, DirectiveFileOwner, DirectiveUsage, DirectiveExample,
+				DirectiveTitle, DirectiveHomepage, DirectiveRepo, DirectiveFooter, DirectiveFooterDoc:
 				result.Directives = append(result.Directives, directive)
-			} else {
+			default:
 				// Queue for association with next target
 				s.pendingDocs = append(s.pendingDocs, directive)
 			}
 			continue
 		}
+		s.inFileBlock = false
+		s.inFooter = false
 
 		// Check for target definition
 		if IsTargetLine(line) {
 			targetName := ExtractTargetName(line)
+			if targetName == "" {
+				if name := ExtractDoubleColonTargetName(line); name != "" {
+					result.Definitions = append(result.Definitions, newTargetDefinition(line, name, lineNumber, true))
+					recipeOwner = len(result.Definitions) - 1
+				}
+			}
 			if targetName != "" {
 				result.TargetMap[targetName] = lineNumber
+				result.Definitions = append(result.Definitions, newTargetDefinition(line, targetName, lineNumber, false))
+				recipeOwner = len(result.Definitions) - 1
 
 				// Associate pending docs with this target
 				if len(s.pendingDocs) > 0 {
@@ -82,8 +140,17 @@ func (s *Scanner) ScanContent(content string, path string) (*ParsedFile, error)
 		}
 
 		// Non-doc, non-target line clears pending docs
-		// (breaks the association between docs and the next target)
+		// (breaks the association between docs and the next target).
+		// Blank and comment lines separate prose deliberately; anything
+		// else is recorded so lint can report the lost documentation.
 		if len(s.pendingDocs) > 0 {
+			if trimmed := strings.TrimSpace(line); trimmed != "" && !strings.HasPrefix(trimmed, "#") {
+				result.DetachedDocs = append(result.DetachedDocs, DetachedDoc{
+					LineNumber:          s.pendingDocs[0].LineNumber,
+					Line:                line,
+					FollowingLineNumber: lineNumber,
+				})
+			}
 			s.pendingDocs = []Directive{}
 		}
 	}
@@ -91,6 +158,34 @@ func (s *Scanner) ScanContent(content string, path string) (*ParsedFile, error)
 	return result, nil
 }
 
+// newTargetDefinition records a rule line, noting an inline "; recipe".
+func newTargetDefinition(line, name string, lineNumber int, doubleColon bool) TargetDefinition {
+	_, rest, _ := strings.Cut(line, ":")
+	return TargetDefinition{
+		Name:        name,
+		LineNumber:  lineNumber,
+		DoubleColon: doubleColon,
+		HasRecipe:   strings.Contains(rest, ";"),
+	}
+}
+
+// parseSourceDirective parses a "## !source <url> [<checksum>]" line.
+func parseSourceDirective(line string) (RemoteInclude, bool) {
+	value, ok := strings.CutPrefix(line, "## !source ")
+	if !ok {
+		return RemoteInclude{}, false
+	}
+	fields := strings.Fields(value)
+	if len(fields) == 0 {
+		return RemoteInclude{}, false
+	}
+	source := RemoteInclude{URL: fields[0]}
+	if len(fields) > 1 {
+		source.Checksum = fields[1]
+	}
+	return source, true
+}
+
 // parseDirective detects and parses a documentation directive.
 // It identifies the directive type (!file, !category, !var, !alias, or regular doc)
 // and extracts the directive value.
@@ -133,6 +228,74 @@ func (s *Scanner) parseDirective(line string, lineNum int) Directive {
 		// Value is empty; the directive itself is sufficient
 		directive.Value = ""
 
+	case strings.HasPrefix(content, "!tag "):
+		directive.Type = DirectiveTag
+		directive.Value = strings.TrimSpace(strings.TrimPrefix(content, "!tag "))
+
+	case content == "!deprecated" || strings.HasPrefix(content, "!deprecated "):
+		directive.Type = DirectiveDeprecated
+		directive.Value = strings.TrimSpace(strings.TrimPrefix(content, "!deprecated"))
+
+	case content == "!hidden" || strings.HasPrefix(content, "!hidden "):
+		directive.Type = DirectiveHidden
+		directive.Value = ""
+
+	case strings.HasPrefix(content, "!os "):
+		directive.Type = DirectiveOS
+		directive.Value = strings.TrimSpace(strings.TrimPrefix(content, "!os "))
+
+	case strings.HasPrefix(content, "!duration "):
+		directive.Type = DirectiveDuration
+		directive.Value = strings.TrimSpace(strings.TrimPrefix(content, "!duration "))
+
+	case strings.HasPrefix(content, "!profile "):
+		directive.Type = DirectiveProfile
+		directive.Value = strings.TrimSpace(strings.TrimPrefix(content, "!profile "))
+
+	case strings.HasPrefix(content, "!summary "):
+		directive.Type = DirectiveSummary
+		directive.Value = strings.TrimSpace(strings.TrimPrefix(content, "!summary "))
+
+	case strings.HasPrefix(content, "!owner "):
+		directive.Type = DirectiveOwner
+		directive.Value = strings.TrimSpace(strings.TrimPrefix(content, "!owner "))
+
+	case strings.HasPrefix(content, "!maintainer "):
+		directive.Type = DirectiveOwner
+		directive.Value = strings.TrimSpace(strings.TrimPrefix(content, "!maintainer "))
+
+	case strings.HasPrefix(content, "!ci "):
+		directive.Type = DirectiveCI
+		directive.Value = strings.TrimSpace(strings.TrimPrefix(content, "!ci "))
+
+	case strings.HasPrefix(content, "!usage "):
+		directive.Type = DirectiveUsage
+		directive.Value = strings.TrimSpace(strings.TrimPrefix(content, "!usage "))
+
+	case strings.HasPrefix(content, "!example "):
+		directive.Type = DirectiveExample
+		directive.Value = strings.TrimSpace(strings.TrimPrefix(content, "!example "))
+
+	case strings.HasPrefix(content, "!title "):
+		directive.Type = DirectiveTitle
+		directive.Value = strings.TrimSpace(strings.TrimPrefix(content, "!title "))
+
+	case strings.HasPrefix(content, "!homepage "):
+		directive.Type = DirectiveHomepage
+		directive.Value = strings.TrimSpace(strings.TrimPrefix(content, "!homepage "))
+
+	case strings.HasPrefix(content, "!repo "):
+		directive.Type = DirectiveRepo
+		directive.Value = strings.TrimSpace(strings.TrimPrefix(content, "!repo "))
+
+	case content == "!footer" || strings.HasPrefix(content, "!footer "):
+		directive.Type = DirectiveFooter
+		directive.Value = strings.TrimSpace(strings.TrimPrefix(content, "!footer"))
+
+	case content == "!danger" || strings.HasPrefix(content, "!danger "):
+		directive.Type = DirectiveDanger
+		directive.Value = strings.TrimSpace(strings.TrimPrefix(content, "!danger"))
+
 	default:
 		// Regular documentation line
 		directive.Type = DirectiveDoc
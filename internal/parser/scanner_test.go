@@ -46,6 +46,48 @@ build:
 				{Type: DirectiveDoc, Value: "with multiple lines of documentation", SourceFile: "test.mk", LineNumber: 3},
 			},
 		},
+		{
+			name: "file directive with owner",
+			content: `## !file
+## !owner platform-team
+
+## !owner release-team
+deploy:`,
+			expected: []Directive{
+				{Type: DirectiveFile, Value: "", SourceFile: "test.mk", LineNumber: 1},
+				{Type: DirectiveFileOwner, Value: "platform-team", SourceFile: "test.mk", LineNumber: 2},
+				{Type: DirectiveOwner, Value: "release-team", SourceFile: "test.mk", LineNumber: 4},
+			},
+		},
+		{
+			name: "usage and examples",
+			content: `## !usage make -j8 <target>
+## !example make build
+## !example make test RUN=TestParse
+
+.PHONY: build`,
+			expected: []Directive{
+				{Type: DirectiveUsage, Value: "make -j8 <target>", SourceFile: "test.mk", LineNumber: 1},
+				{Type: DirectiveExample, Value: "make build", SourceFile: "test.mk", LineNumber: 2},
+				{Type: DirectiveExample, Value: "make test RUN=TestParse", SourceFile: "test.mk", LineNumber: 3},
+			},
+		},
+		{
+			name: "footer block",
+			content: `## !footer Run make help-<target> for details.
+## Report issues to #build-infra.
+build:
+	echo "building"
+
+## !footer
+## See docs/build.md.`,
+			expected: []Directive{
+				{Type: DirectiveFooter, Value: "Run make help-<target> for details.", SourceFile: "test.mk", LineNumber: 1},
+				{Type: DirectiveFooterDoc, Value: "Report issues to #build-infra.", SourceFile: "test.mk", LineNumber: 2},
+				{Type: DirectiveFooter, Value: "", SourceFile: "test.mk", LineNumber: 6},
+				{Type: DirectiveFooterDoc, Value: "See docs/build.md.", SourceFile: "test.mk", LineNumber: 7},
+			},
+		},
 		{
 			name: "multiple file directives",
 			content: `## !file
@@ -277,6 +319,123 @@ build:
 	}
 }
 
+func TestScanContent_MetadataDirectives(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		content  string
+		expected Directive
+	}{
+		{
+			name:     "tag directive",
+			content:  "## !tag ci, slow\nbuild:",
+			expected: Directive{Type: DirectiveTag, Value: "ci, slow"},
+		},
+		{
+			name:     "deprecated without message",
+			content:  "## !deprecated\nbuild:",
+			expected: Directive{Type: DirectiveDeprecated, Value: ""},
+		},
+		{
+			name:     "deprecated with message",
+			content:  "## !deprecated Use compile instead.\nbuild:",
+			expected: Directive{Type: DirectiveDeprecated, Value: "Use compile instead."},
+		},
+		{
+			name:     "hidden directive",
+			content:  "## !hidden\nbuild:",
+			expected: Directive{Type: DirectiveHidden, Value: ""},
+		},
+		{
+			name:     "os directive",
+			content:  "## !os linux, darwin\nbuild:",
+			expected: Directive{Type: DirectiveOS, Value: "linux, darwin"},
+		},
+		{
+			name:     "duration directive",
+			content:  "## !duration ~5m\nbuild:",
+			expected: Directive{Type: DirectiveDuration, Value: "~5m"},
+		},
+		{
+			name:     "profile directive",
+			content:  "## !profile ci, dev\nbuild:",
+			expected: Directive{Type: DirectiveProfile, Value: "ci, dev"},
+		},
+		{
+			name:     "summary directive",
+			content:  "## !summary Build everything.\nbuild:",
+			expected: Directive{Type: DirectiveSummary, Value: "Build everything."},
+		},
+		{
+			name:     "danger without reason",
+			content:  "## !danger\nbuild:",
+			expected: Directive{Type: DirectiveDanger, Value: ""},
+		},
+		{
+			name:     "danger with reason",
+			content:  "## !danger destroys the production database\nbuild:",
+			expected: Directive{Type: DirectiveDanger, Value: "destroys the production database"},
+		},
+		{
+			name:     "owner directive",
+			content:  "## !owner platform-team (#platform on Slack)\nbuild:",
+			expected: Directive{Type: DirectiveOwner, Value: "platform-team (#platform on Slack)"},
+		},
+		{
+			name:     "ci directive",
+			content:  "## !ci .github/workflows/build.yml\nbuild:",
+			expected: Directive{Type: DirectiveCI, Value: ".github/workflows/build.yml"},
+		},
+		{
+			name:     "usage directive",
+			content:  "## !usage make -j8 <target>\nbuild:",
+			expected: Directive{Type: DirectiveUsage, Value: "make -j8 <target>"},
+		},
+		{
+			name:     "example directive",
+			content:  "## !example make build V=1\nbuild:",
+			expected: Directive{Type: DirectiveExample, Value: "make build V=1"},
+		},
+		{
+			name:     "title directive",
+			content:  "## !title Acme Build System\nbuild:",
+			expected: Directive{Type: DirectiveTitle, Value: "Acme Build System"},
+		},
+		{
+			name:     "homepage directive",
+			content:  "## !homepage https://acme.example.com\nbuild:",
+			expected: Directive{Type: DirectiveHomepage, Value: "https://acme.example.com"},
+		},
+		{
+			name:     "repo directive",
+			content:  "## !repo https://github.com/acme/build\nbuild:",
+			expected: Directive{Type: DirectiveRepo, Value: "https://github.com/acme/build"},
+		},
+		{
+			name:     "maintainer is an owner",
+			content:  "## !maintainer platform-team\nbuild:",
+			expected: Directive{Type: DirectiveOwner, Value: "platform-team"},
+		},
+		{
+			name:     "deprecated prefix is not a directive",
+			content:  "## !deprecatedness\nbuild:",
+			expected: Directive{Type: DirectiveDoc, Value: "!deprecatedness"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			scanner := NewScanner()
+			result, err := scanner.ScanContent(tt.content, "test.mk")
+			require.NoError(t, err)
+			require.Len(t, result.Directives, 1)
+			assert.Equal(t, tt.expected.Type, result.Directives[0].Type)
+			assert.Equal(t, tt.expected.Value, result.Directives[0].Value)
+		})
+	}
+}
+
 func TestScanContent_RegularDocumentation(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -1161,3 +1320,103 @@ func TestScanContent_EdgeCaseWhitespace(t *testing.T) {
 		})
 	}
 }
+
+func TestScanContent_RemoteIncludes(t *testing.T) {
+	t.Parallel()
+	content := `## !source https://example.com/common.mk sha256:abc123
+-include mk/common.mk
+
+## !source https://example.com/unused.mk
+VAR := value
+include mk/local.mk
+
+## !source https://example.com/docker.mk
+## Build the image.
+include mk/docker.mk
+
+## Build it.
+build:
+`
+
+	scanner := NewScanner()
+	result, err := scanner.ScanContent(content, "Makefile")
+	require.NoError(t, err)
+
+	assert.Equal(t, []RemoteInclude{
+		{Path: "mk/common.mk", URL: "https://example.com/common.mk", Checksum: "sha256:abc123", LineNumber: 2},
+		{Path: "mk/docker.mk", URL: "https://example.com/docker.mk", LineNumber: 10},
+	}, result.RemoteIncludes)
+
+	// !source lines are not documentation
+	for _, d := range result.Directives {
+		assert.NotContains(t, d.Value, "!source")
+	}
+	assert.Contains(t, result.TargetMap, "build")
+}
+
+func TestScanContent_Definitions(t *testing.T) {
+	t.Parallel()
+	content := `build: deps
+	go build
+
+# comment between rule and recipe
+test:
+# still part of test's rule
+
+	go test
+
+clean::
+	rm -rf bin
+fmt: ; gofmt -w .
+VAR ::= value
+phony:
+VAR = x
+	not a recipe`
+
+	scanner := NewScanner()
+	result, err := scanner.ScanContent(content, "Makefile")
+	require.NoError(t, err)
+
+	assert.Equal(t, []TargetDefinition{
+		{Name: "build", LineNumber: 1, HasRecipe: true},
+		{Name: "test", LineNumber: 5, HasRecipe: true},
+		{Name: "clean", LineNumber: 10, DoubleColon: true, HasRecipe: true},
+		{Name: "fmt", LineNumber: 12, HasRecipe: true},
+		{Name: "phony", LineNumber: 14},
+	}, result.Definitions)
+
+	// Double-colon rules are not added to TargetMap
+	assert.NotContains(t, result.TargetMap, "clean")
+}
+
+func TestScanContent_DetachedDocs(t *testing.T) {
+	t.Parallel()
+	content := `## Build the project.
+build:
+	go build
+
+## Run the tests.
+test;
+	go test
+
+## Section notes, separated from the rule.
+
+lint:
+
+## Ignored by a comment.
+# plain comment
+fmt:
+
+## The version to release.
+## !category Release
+VERSION = 1.0
+`
+	scanner := NewScanner()
+	result, err := scanner.ScanContent(content, "Makefile")
+	require.NoError(t, err)
+
+	assert.Equal(t, []DetachedDoc{
+		{LineNumber: 5, Line: "test;", FollowingLineNumber: 6},
+		{LineNumber: 17, Line: "VERSION = 1.0", FollowingLineNumber: 19},
+	}, result.DetachedDocs)
+}
@@ -1,5 +1,7 @@
 package parser
 
+import "fmt"
+
 // DirectiveType represents the type of a documentation directive.
 type DirectiveType int
 
@@ -19,6 +21,69 @@ const (
 	// DirectiveNotAlias represents !notalias directive to exclude a target from implicit alias detection.
 	DirectiveNotAlias
 
+	// DirectiveTag represents !tag directive for free-form target tags.
+	DirectiveTag
+
+	// DirectiveDeprecated represents !deprecated directive marking a target as deprecated.
+	DirectiveDeprecated
+
+	// DirectiveHidden represents !hidden directive to omit a target from human-readable help.
+	DirectiveHidden
+
+	// DirectiveOS represents !os directive listing the platforms a target supports.
+	DirectiveOS
+
+	// DirectiveDuration represents !duration directive with a target's expected run time.
+	DirectiveDuration
+
+	// DirectiveProfile represents !profile directive listing the audiences a target is shown to.
+	DirectiveProfile
+
+	// DirectiveSummary represents !summary directive overriding a target's extracted summary.
+	DirectiveSummary
+
+	// DirectiveDanger represents !danger directive marking a target as destructive.
+	DirectiveDanger
+
+	// DirectiveOwner represents !owner (or !maintainer) directive naming the team
+	// responsible for a target.
+	DirectiveOwner
+
+	// DirectiveFileOwner represents !owner directive in a !file block, naming
+	// the team responsible for the whole file.
+	DirectiveFileOwner
+
+	// DirectiveCI represents !ci directive linking a target to the CI workflow
+	// files that run it.
+	DirectiveCI
+
+	// DirectiveUsage represents !usage directive replacing the usage line at
+	// the top of help output.
+	DirectiveUsage
+
+	// DirectiveExample represents !example directive adding an example
+	// invocation beneath the usage line.
+	DirectiveExample
+
+	// DirectiveTitle represents !title directive naming the project in
+	// generated documents.
+	DirectiveTitle
+
+	// DirectiveHomepage represents !homepage directive with the project's
+	// homepage URL.
+	DirectiveHomepage
+
+	// DirectiveRepo represents !repo directive with the project's source
+	// repository URL.
+	DirectiveRepo
+
+	// DirectiveFooter represents !footer directive starting a block of
+	// documentation shown after the target listing.
+	DirectiveFooter
+
+	// DirectiveFooterDoc represents a documentation line in a !footer block.
+	DirectiveFooterDoc
+
 	// DirectiveDoc represents a regular documentation line (not a special directive).
 	DirectiveDoc
 )
@@ -36,6 +101,42 @@ func (d DirectiveType) String() string {
 		return "alias"
 	case DirectiveNotAlias:
 		return "notalias"
+	case DirectiveTag:
+		return "tag"
+	case DirectiveDeprecated:
+		return "deprecated"
+	case DirectiveHidden:
+		return "hidden"
+	case DirectiveOS:
+		return "os"
+	case DirectiveDuration:
+		return "duration"
+	case DirectiveProfile:
+		return "profile"
+	case DirectiveSummary:
+		return "summary"
+	case DirectiveDanger:
+		return "danger"
+	case DirectiveOwner:
+		return "owner"
+	case DirectiveFileOwner:
+		return "file-owner"
+	case DirectiveCI:
+		return "ci"
+	case DirectiveUsage:
+		return "usage"
+	case DirectiveExample:
+		return "example"
+	case DirectiveTitle:
+		return "title"
+	case DirectiveHomepage:
+		return "homepage"
+	case DirectiveRepo:
+		return "repo"
+	case DirectiveFooter:
+		return "footer"
+	case DirectiveFooterDoc:
+		return "footer-doc"
 	case DirectiveDoc:
 		return "doc"
 	default:
@@ -43,6 +144,28 @@ func (d DirectiveType) String() string {
 	}
 }
 
+// MarshalText encodes the directive type by name, so serialized directives
+// (e.g., model dumps) stay valid when new directive types are added.
+func (d DirectiveType) MarshalText() ([]byte, error) {
+	name := d.String()
+	if name == "unknown" {
+		return nil, fmt.Errorf("cannot marshal unknown directive type %d", int(d))
+	}
+	return []byte(name), nil
+}
+
+// UnmarshalText decodes a directive type name written by MarshalText.
+func (d *DirectiveType) UnmarshalText(text []byte) error {
+	// DirectiveDoc is declared last, so this covers every directive type
+	for t := DirectiveFile; t <= DirectiveDoc; t++ {
+		if t.String() == string(text) {
+			*d = t
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown directive type %q", string(text))
+}
+
 // Directive represents a parsed documentation directive from a Makefile.
 type Directive struct {
 	// Type indicates the directive type (!file, !category, !var, !alias, or doc).
@@ -52,6 +175,12 @@ type Directive struct {
 	// For !category: the category name
 	// For !var: "NAME - description"
 	// For !alias: "alias1, alias2, ..."
+	// For !tag: "tag1, tag2, ..."
+	// For !deprecated: the optional deprecation message
+	// For !usage: the usage line
+	// For !example: the example command
+	// For !title, !homepage, !repo: the project title or URL
+	// For !footer: the optional first line of the footer block
 	// For doc: the documentation text
 	Value string
 
@@ -73,4 +202,62 @@ type ParsedFile struct {
 	// TargetMap maps target names to their line numbers.
 	// Used to associate documentation with targets.
 	TargetMap map[string]int
+
+	// RemoteIncludes lists include lines annotated with !source.
+	RemoteIncludes []RemoteInclude
+
+	// Definitions lists every rule line in the file, in order. Unlike
+	// TargetMap it keeps repeated definitions and double-colon rules.
+	Definitions []TargetDefinition
+
+	// DetachedDocs lists documentation blocks dropped because the line
+	// right after them does not define a target.
+	DetachedDocs []DetachedDoc
+}
+
+// DetachedDoc is a documentation block followed directly by a line that is
+// not a target definition (e.g., a variable assignment or a rule missing its
+// colon), so its documentation is not associated with any target.
+type DetachedDoc struct {
+	// LineNumber is the 1-based line number of the first documentation line.
+	LineNumber int
+
+	// Line is the line following the block.
+	Line string
+
+	// FollowingLineNumber is the 1-based line number of Line.
+	FollowingLineNumber int
+}
+
+// TargetDefinition is a rule line defining a target.
+type TargetDefinition struct {
+	// Name is the first target named on the rule line.
+	Name string
+
+	// LineNumber is the 1-based line number of the rule line.
+	LineNumber int
+
+	// DoubleColon is true for "target::" rules.
+	DoubleColon bool
+
+	// HasRecipe is true when the rule has an inline ("; cmd") or
+	// tab-indented recipe.
+	HasRecipe bool
+}
+
+// RemoteInclude is an include line preceded by "## !source <url> [sha256:<hex>]",
+// naming where the included file can be fetched from.
+type RemoteInclude struct {
+	// Path is the included file as written on the include line.
+	Path string
+
+	// URL is the address the file is fetched from.
+	URL string
+
+	// Checksum pins the fetched content, e.g. "sha256:<hex>".
+	// Empty when the source is not pinned.
+	Checksum string
+
+	// LineNumber is the 1-based line number of the include line.
+	LineNumber int
 }
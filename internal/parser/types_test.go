@@ -38,6 +38,61 @@ func TestDirectiveType_String(t *testing.T) {
 			dt:       DirectiveDoc,
 			expected: "doc",
 		},
+		{
+			name:     "tag directive",
+			dt:       DirectiveTag,
+			expected: "tag",
+		},
+		{
+			name:     "deprecated directive",
+			dt:       DirectiveDeprecated,
+			expected: "deprecated",
+		},
+		{
+			name:     "hidden directive",
+			dt:       DirectiveHidden,
+			expected: "hidden",
+		},
+		{
+			name:     "os directive",
+			dt:       DirectiveOS,
+			expected: "os",
+		},
+		{
+			name:     "duration directive",
+			dt:       DirectiveDuration,
+			expected: "duration",
+		},
+		{
+			name:     "profile directive",
+			dt:       DirectiveProfile,
+			expected: "profile",
+		},
+		{
+			name:     "summary directive",
+			dt:       DirectiveSummary,
+			expected: "summary",
+		},
+		{
+			name:     "danger directive",
+			dt:       DirectiveDanger,
+			expected: "danger",
+		},
+		{
+			name:     "owner directive",
+			dt:       DirectiveOwner,
+			expected: "owner",
+		},
+		{
+			name:     "file owner directive",
+			dt:       DirectiveFileOwner,
+			expected: "file-owner",
+		},
+		{
+			name:     "ci directive",
+			dt:       DirectiveCI,
+			expected: "ci",
+		},
 		{
 			name:     "unknown directive",
 			dt:       DirectiveType(999),
@@ -53,3 +108,22 @@ func TestDirectiveType_String(t *testing.T) {
 		})
 	}
 }
+
+func TestDirectiveType_TextRoundTrip(t *testing.T) {
+	t.Parallel()
+	for dt := DirectiveFile; dt <= DirectiveDoc; dt++ {
+		text, err := dt.MarshalText()
+		assert.NoError(t, err)
+		assert.Equal(t, dt.String(), string(text))
+
+		var decoded DirectiveType
+		assert.NoError(t, decoded.UnmarshalText(text))
+		assert.Equal(t, dt, decoded)
+	}
+
+	_, err := DirectiveType(999).MarshalText()
+	assert.Error(t, err)
+
+	var decoded DirectiveType
+	assert.Error(t, decoded.UnmarshalText([]byte("bogus")))
+}
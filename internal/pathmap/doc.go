@@ -0,0 +1,8 @@
+// Package pathmap rewrites the paths make-help sees inside a container to
+// the paths they have on the host, for --container and --path-map.
+//
+// A workspace bind-mounted into a container (docker run -v "$PWD:/workspace")
+// has different paths inside and outside it. Help rendered inside shows
+// source files under /workspace; a Map replaces that prefix with the host
+// directory, or with "." to give paths relative to the workspace.
+package pathmap
@@ -0,0 +1,106 @@
+package pathmap
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/sdlcforge/make-help/internal/model"
+)
+
+// DefaultContainerDir is where the make-help image expects the workspace to
+// be mounted.
+const DefaultContainerDir = "/workspace"
+
+// DefaultSpec maps DefaultContainerDir to paths relative to the workspace.
+const DefaultSpec = "." + ":" + DefaultContainerDir
+
+// Mapping maps the directory Container, as seen inside the container, to the
+// directory Host.
+type Mapping struct {
+	Host      string
+	Container string
+}
+
+// Parse parses a mapping written host:container, as for docker run -v. The
+// container directory must be absolute; the host directory may be relative.
+func Parse(spec string) (Mapping, error) {
+	i := strings.LastIndex(spec, ":")
+	if i <= 0 || i == len(spec)-1 {
+		return Mapping{}, fmt.Errorf("invalid path map %q: expected host:container", spec)
+	}
+	host, container := spec[:i], spec[i+1:]
+	if !path.IsAbs(container) {
+		return Mapping{}, fmt.Errorf("invalid path map %q: container path %s is not absolute", spec, container)
+	}
+	return Mapping{Host: path.Clean(host), Container: path.Clean(container)}, nil
+}
+
+// Map rewrites paths under the container directories of its mappings. The
+// nil Map leaves paths unchanged.
+type Map []Mapping
+
+// New parses specs into a Map.
+func New(specs []string) (Map, error) {
+	var m Map
+	for _, spec := range specs {
+		mapping, err := Parse(spec)
+		if err != nil {
+			return nil, err
+		}
+		m = append(m, mapping)
+	}
+	return m, nil
+}
+
+// Path returns p with its container directory replaced by the host
+// directory. When several container directories contain p, the longest
+// wins, so nested mounts map correctly. Other paths are returned unchanged.
+func (m Map) Path(p string) string {
+	best := -1
+	for i, mapping := range m {
+		if !within(p, mapping.Container) {
+			continue
+		}
+		if best < 0 || len(mapping.Container) > len(m[best].Container) {
+			best = i
+		}
+	}
+	if best < 0 {
+		return p
+	}
+	rest := strings.TrimPrefix(p, m[best].Container)
+	return path.Join(m[best].Host, rest)
+}
+
+// Model returns helpModel with the source files of its file docs and targets
+// mapped. The model is copied rather than changed, since its paths still
+// name files make-help reads; the nil Map returns helpModel itself.
+func (m Map) Model(helpModel *model.HelpModel) *model.HelpModel {
+	if len(m) == 0 {
+		return helpModel
+	}
+	mapped := *helpModel
+	mapped.FileDocs = slices.Clone(helpModel.FileDocs)
+	for i := range mapped.FileDocs {
+		mapped.FileDocs[i].SourceFile = m.Path(mapped.FileDocs[i].SourceFile)
+	}
+	mapped.Categories = slices.Clone(helpModel.Categories)
+	for i := range mapped.Categories {
+		category := &mapped.Categories[i]
+		category.Targets = slices.Clone(category.Targets)
+		for j := range category.Targets {
+			category.Targets[j].SourceFile = m.Path(category.Targets[j].SourceFile)
+		}
+	}
+	return &mapped
+}
+
+// within reports whether p is dir or a path under it.
+func within(p, dir string) bool {
+	if dir == "/" {
+		return path.IsAbs(p)
+	}
+	return p == dir || strings.HasPrefix(p, dir+"/")
+}
@@ -0,0 +1,78 @@
+package pathmap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sdlcforge/make-help/internal/model"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		spec    string
+		want    Mapping
+		wantErr string
+	}{
+		{spec: ".:/workspace", want: Mapping{Host: ".", Container: "/workspace"}},
+		{spec: "/home/dev/app/:/workspace/", want: Mapping{Host: "/home/dev/app", Container: "/workspace"}},
+		{spec: `C:\src\app:/workspace`, want: Mapping{Host: `C:\src\app`, Container: "/workspace"}},
+		{spec: "/workspace", wantErr: "expected host:container"},
+		{spec: ":/workspace", wantErr: "expected host:container"},
+		{spec: "/home/dev/app:", wantErr: "expected host:container"},
+		{spec: "/home/dev/app:workspace", wantErr: "is not absolute"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			t.Parallel()
+			got, err := Parse(tt.spec)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMap_Path(t *testing.T) {
+	t.Parallel()
+	m, err := New([]string{".:/workspace", "/home/dev/lib:/workspace/vendor/lib"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Makefile", m.Path("/workspace/Makefile"))
+	assert.Equal(t, "make/build.mk", m.Path("/workspace/make/build.mk"))
+	assert.Equal(t, ".", m.Path("/workspace"))
+	assert.Equal(t, "/home/dev/lib/common.mk", m.Path("/workspace/vendor/lib/common.mk"))
+	assert.Equal(t, "/workspaces/Makefile", m.Path("/workspaces/Makefile"))
+	assert.Equal(t, "/usr/share/make/rules.mk", m.Path("/usr/share/make/rules.mk"))
+	assert.Equal(t, "", m.Path(""))
+
+	assert.Equal(t, "/workspace/Makefile", Map(nil).Path("/workspace/Makefile"))
+}
+
+func TestMap_Model(t *testing.T) {
+	t.Parallel()
+	helpModel := &model.HelpModel{
+		FileDocs: []model.FileDoc{{SourceFile: "/workspace/Makefile"}},
+		Categories: []model.Category{{
+			Name:    "Build",
+			Targets: []model.Target{{Name: "build", SourceFile: "/workspace/make/build.mk"}},
+		}},
+	}
+
+	m, err := New([]string{"/home/dev/app:/workspace"})
+	require.NoError(t, err)
+	mapped := m.Model(helpModel)
+
+	assert.Equal(t, "/home/dev/app/Makefile", mapped.FileDocs[0].SourceFile)
+	assert.Equal(t, "/home/dev/app/make/build.mk", mapped.Categories[0].Targets[0].SourceFile)
+	// The original still names the files as make-help reads them
+	assert.Equal(t, "/workspace/Makefile", helpModel.FileDocs[0].SourceFile)
+	assert.Equal(t, "/workspace/make/build.mk", helpModel.Categories[0].Targets[0].SourceFile)
+
+	assert.Same(t, helpModel, Map(nil).Model(helpModel))
+}
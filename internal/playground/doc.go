@@ -0,0 +1,9 @@
+// Package playground renders help from Makefile text held in memory,
+// without running make or reading the disk. It backs the WebAssembly build
+// (cmd/make-help-wasm), which lets documentation sites and web playgrounds
+// render help in the browser.
+//
+// Discovery is static, as with --no-exec: includes, targets, and variables
+// computed by make are missed. Like the static discovery package, this
+// package must not depend on os/exec.
+package playground
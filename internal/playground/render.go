@@ -0,0 +1,123 @@
+package playground
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"path"
+
+	"github.com/sdlcforge/make-help/internal/discovery/static"
+	"github.com/sdlcforge/make-help/internal/format"
+	"github.com/sdlcforge/make-help/internal/model"
+	"github.com/sdlcforge/make-help/internal/ordering"
+	"github.com/sdlcforge/make-help/internal/parser"
+	"github.com/sdlcforge/make-help/internal/redact"
+)
+
+// DefaultMakefile is the main Makefile Render reads when Options names none.
+const DefaultMakefile = "Makefile"
+
+// Options configures Render. The zero value renders HTML help for the file
+// named Makefile.
+type Options struct {
+	// Format is the output format: html (default), json, markdown, text,
+	// ndjson, slack, or make.
+	Format string `json:"format"`
+
+	// Makefile is the path of the main Makefile among the files.
+	Makefile string `json:"makefile"`
+
+	// DefaultCategory is the category of targets without one, needed when
+	// only some targets are categorized.
+	DefaultCategory string `json:"defaultCategory"`
+
+	// IncludeAllPhony includes undocumented .PHONY targets.
+	IncludeAllPhony bool `json:"includeAllPhony"`
+
+	// KeepOrderCategories keeps categories in the order they are defined.
+	KeepOrderCategories bool `json:"keepOrderCategories"`
+
+	// KeepOrderTargets keeps targets in the order they are defined.
+	KeepOrderTargets bool `json:"keepOrderTargets"`
+
+	// MarkdownLayout is the Markdown target layout: list (default) or
+	// table.
+	MarkdownLayout string `json:"markdownLayout"`
+
+	// NoRedact turns off masking of secrets in documentation.
+	NoRedact bool `json:"noRedact"`
+}
+
+// Render renders help for the Makefiles in files, which maps slash-separated
+// paths (relative paths are relative to "/") to their content. Includes are
+// followed among files; files that are not included are ignored.
+func Render(files map[string]string, options Options) (string, error) {
+	fsys := static.MemoryFileSystem(files)
+	formatName := cmp.Or(options.Format, "html")
+	makefilePath, _ := fsys.Abs(cmp.Or(options.Makefile, DefaultMakefile))
+
+	makefiles, err := static.DiscoverMakefiles(fsys, makefilePath)
+	if err != nil {
+		return "", err
+	}
+	targets, err := static.DiscoverTargets(fsys, makefilePath)
+	if err != nil {
+		return "", err
+	}
+
+	scanner := parser.NewScanner()
+	var parsedFiles []*parser.ParsedFile
+	for _, makefile := range makefiles {
+		content, err := fsys.ReadFile(makefile)
+		if err != nil {
+			return "", err
+		}
+		parsed, err := scanner.ScanContent(string(content), makefile)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse %s: %w", makefile, err)
+		}
+		parsedFiles = append(parsedFiles, parsed)
+	}
+
+	builder := model.NewBuilder(&model.BuilderConfig{
+		DefaultCategory: options.DefaultCategory,
+		IncludeAllPhony: options.IncludeAllPhony,
+		PhonyTargets:    targets.IsPhony,
+		Dependencies:    targets.Dependencies,
+		HasRecipe:       targets.HasRecipe,
+		DefaultGoal:     targets.DefaultGoal,
+		BaseDir:         path.Dir(makefilePath),
+		// JSON consumers get every target and filter on the hidden flag
+		IncludeHidden: formatName == "json" || formatName == "ndjson",
+	})
+	helpModel, err := builder.Build(parsedFiles)
+	if err != nil {
+		return "", fmt.Errorf("failed to build help model: %w", err)
+	}
+
+	orderingService := ordering.NewService(options.KeepOrderCategories, options.KeepOrderTargets, false, nil)
+	if err := orderingService.ApplyOrdering(helpModel); err != nil {
+		return "", fmt.Errorf("failed to apply ordering: %w", err)
+	}
+
+	if !options.NoRedact {
+		redactor, err := redact.New(nil)
+		if err != nil {
+			return "", err
+		}
+		redactor.RedactModel(helpModel)
+	}
+
+	formatter, err := format.NewFormatter(formatName, &format.FormatterConfig{
+		MakefileDir:    path.Dir(makefilePath),
+		MarkdownLayout: options.MarkdownLayout,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create formatter: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := formatter.RenderHelp(helpModel, &buf); err != nil {
+		return "", fmt.Errorf("failed to render help: %w", err)
+	}
+	return buf.String(), nil
+}
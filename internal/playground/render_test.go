@@ -0,0 +1,126 @@
+package playground
+
+import (
+	"encoding/json"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const renderTestMakefile = `## !file Tasks for the demo project.
+include make/*.mk
+
+## !category Build
+## Build the app.
+build:
+	go build ./...
+`
+
+func TestRender_JSON(t *testing.T) {
+	t.Parallel()
+	files := map[string]string{
+		"Makefile": renderTestMakefile,
+		"make/test.mk": "## !category Test\n" +
+			"## Run the tests.\n" +
+			"## !var TOKEN API token used by the integration tests\n" +
+			"test:\n\tgo test ./...\n",
+	}
+
+	output, err := Render(files, Options{Format: "json"})
+	require.NoError(t, err)
+
+	var help struct {
+		Description string `json:"description"`
+		Categories  []struct {
+			Name    string `json:"name"`
+			Targets []struct {
+				Name    string `json:"name"`
+				Summary string `json:"summary"`
+			} `json:"targets"`
+		} `json:"categories"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(output), &help))
+	assert.Equal(t, "Tasks for the demo project.", help.Description)
+	require.Len(t, help.Categories, 2)
+	assert.Equal(t, "Build", help.Categories[0].Name)
+	assert.Equal(t, "Build the app.", help.Categories[0].Targets[0].Summary)
+	assert.Equal(t, "test", help.Categories[1].Targets[0].Name)
+}
+
+func TestRender_DefaultsToHTML(t *testing.T) {
+	t.Parallel()
+
+	output, err := Render(map[string]string{"Makefile": renderTestMakefile}, Options{})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(output, "<!DOCTYPE html>"), output[:min(len(output), 80)])
+	assert.Contains(t, output, "Build the app.")
+}
+
+func TestRender_MakefileOption(t *testing.T) {
+	t.Parallel()
+	files := map[string]string{
+		"Makefile":          renderTestMakefile,
+		"tools/GNUmakefile": "## Install the tools.\ninstall:\n",
+	}
+
+	output, err := Render(files, Options{Format: "text", Makefile: "tools/GNUmakefile"})
+	require.NoError(t, err)
+	assert.Contains(t, output, "install: Install the tools.")
+	assert.NotContains(t, output, "build")
+}
+
+func TestRender_Errors(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		files   map[string]string
+		options Options
+		want    string
+	}{
+		{"missing Makefile", map[string]string{"other.mk": ""}, Options{}, "failed to read"},
+		{"unknown format", map[string]string{"Makefile": renderTestMakefile}, Options{Format: "pdf"}, "unknown format type: pdf"},
+		{
+			"mixed categorization",
+			map[string]string{"Makefile": "## Test.\ntest:\n\n## !category Build\n## Build.\nbuild:\n"},
+			Options{},
+			"failed to build help model",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := Render(tt.files, tt.options)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestRender_Redacts(t *testing.T) {
+	t.Parallel()
+	files := map[string]string{"Makefile": "## Deploy with password=hunter22.\ndeploy:\n"}
+
+	output, err := Render(files, Options{Format: "text"})
+	require.NoError(t, err)
+	assert.NotContains(t, output, "hunter22")
+
+	output, err = Render(files, Options{Format: "text", NoRedact: true})
+	require.NoError(t, err)
+	assert.Contains(t, output, "hunter22")
+}
+
+// TestNoProcessDependencies keeps the packages linked into the js/wasm build
+// free of os/exec, which cannot start processes there.
+func TestNoProcessDependencies(t *testing.T) {
+	t.Parallel()
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go command not available")
+	}
+
+	output, err := exec.Command("go", "list", "-deps", ".", "../discovery/static").Output()
+	require.NoError(t, err)
+	assert.NotContains(t, strings.Fields(string(output)), "os/exec")
+}
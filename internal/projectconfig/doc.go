@@ -0,0 +1,19 @@
+// Package projectconfig loads per-project make-help settings.
+//
+// Settings live in .make-help.json next to the Makefile and are meant to be
+// committed, so every contributor and CI job renders help the same way.
+// They complement command-line flags: list settings (such as exclude
+// patterns) are merged with the corresponding flags.
+//
+// Example:
+//
+//	{
+//	  "exclude": {
+//	    "targets": ["internal-*"],
+//	    "files": ["third_party/**"]
+//	  },
+//	  "redact": {
+//	    "patterns": ["sk_live_[0-9a-zA-Z]+"]
+//	  }
+//	}
+package projectconfig
@@ -0,0 +1,219 @@
+package projectconfig
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// IgnoreFileName is the name of the ignore file, read from the Makefile directory.
+const IgnoreFileName = ".makehelpignore"
+
+// targetPrefix marks ignore patterns that match target names instead of files.
+const targetPrefix = "target:"
+
+// Ignore holds the patterns from .makehelpignore.
+//
+// The file uses gitignore syntax: one pattern per line, blank lines and lines
+// starting with "#" are skipped, and a leading "!" re-includes what an earlier
+// pattern ignored. File patterns are matched against paths relative to the
+// Makefile directory:
+//   - a pattern without "/" matches a file or directory name at any depth
+//   - a pattern containing "/" is matched from the Makefile directory
+//   - a trailing "/" matches directories only
+//   - "**" matches any number of directories
+//
+// Patterns prefixed with "target:" match target names instead (e.g.,
+// "target:internal-*").
+type Ignore struct {
+	rules []ignoreRule
+}
+
+// ignoreRule is a single parsed line of the ignore file.
+type ignoreRule struct {
+	segments []string
+	negate   bool
+	target   bool
+	anchored bool
+	dirOnly  bool
+}
+
+// IgnorePath returns the ignore file path for the Makefile directory dir.
+func IgnorePath(dir string) string {
+	return filepath.Join(dir, IgnoreFileName)
+}
+
+// LoadIgnore reads the ignore file in dir. A missing file yields an Ignore
+// that matches nothing.
+func LoadIgnore(dir string) (*Ignore, error) {
+	data, err := os.ReadFile(IgnorePath(dir))
+	if os.IsNotExist(err) {
+		return &Ignore{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ignore file: %w", err)
+	}
+
+	ignore, err := ParseIgnore(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ignore file %s: %w", IgnorePath(dir), err)
+	}
+	return ignore, nil
+}
+
+// ParseIgnore parses ignore file content.
+func ParseIgnore(data []byte) (*Ignore, error) {
+	ignore := &Ignore{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		rule := ignoreRule{}
+		if strings.HasPrefix(line, "!") {
+			rule.negate = true
+			line = line[1:]
+		}
+		if strings.HasPrefix(line, targetPrefix) {
+			rule.target = true
+			line = strings.TrimPrefix(line, targetPrefix)
+		} else {
+			if strings.HasSuffix(line, "/") {
+				rule.dirOnly = true
+				line = strings.TrimSuffix(line, "/")
+			}
+			if strings.Contains(line, "/") {
+				rule.anchored = true
+				line = strings.TrimPrefix(line, "/")
+			}
+		}
+		if line == "" {
+			return nil, fmt.Errorf("line %d: empty pattern", lineNumber)
+		}
+
+		rule.segments = []string{line}
+		if !rule.target {
+			rule.segments = strings.Split(line, "/")
+		}
+		for _, segment := range rule.segments {
+			if _, err := path.Match(segment, ""); err != nil {
+				return nil, fmt.Errorf("line %d: invalid pattern %q: %w", lineNumber, line, err)
+			}
+		}
+		ignore.rules = append(ignore.rules, rule)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return ignore, nil
+}
+
+// MatchFile reports whether the file at relPath (relative to the Makefile
+// directory) is ignored. The last matching pattern wins.
+func (ig *Ignore) MatchFile(relPath string) bool {
+	if ig == nil {
+		return false
+	}
+	segments := strings.Split(filepath.ToSlash(filepath.Clean(relPath)), "/")
+	ignored := false
+	for _, rule := range ig.rules {
+		if !rule.target && rule.matchFile(segments) {
+			ignored = !rule.negate
+		}
+	}
+	return ignored
+}
+
+// SkipMakefiles returns makefiles without the files ignore matches, relative
+// to the directory of makefilePath. The main Makefile is always kept.
+func (ig *Ignore) SkipMakefiles(makefiles []string, makefilePath string, verbose bool) []string {
+	baseDir := filepath.Dir(makefilePath)
+	kept := make([]string, 0, len(makefiles))
+	for _, mf := range makefiles {
+		if mf != makefilePath {
+			if rel, err := filepath.Rel(baseDir, mf); err == nil && ig.MatchFile(rel) {
+				if verbose {
+					fmt.Fprintf(os.Stderr, "Ignoring %s (matched %s)\n", mf, IgnoreFileName)
+				}
+				continue
+			}
+		}
+		kept = append(kept, mf)
+	}
+	return kept
+}
+
+// MatchTarget reports whether the target name is ignored. The last matching
+// pattern wins.
+func (ig *Ignore) MatchTarget(name string) bool {
+	if ig == nil {
+		return false
+	}
+	ignored := false
+	for _, rule := range ig.rules {
+		if !rule.target {
+			continue
+		}
+		if matched, _ := path.Match(rule.segments[0], name); matched {
+			ignored = !rule.negate
+		}
+	}
+	return ignored
+}
+
+// matchFile reports whether the rule matches the file or one of its parent
+// directories.
+func (r ignoreRule) matchFile(segments []string) bool {
+	// Every prefix shorter than the full path names a directory.
+	last := len(segments)
+	if r.dirOnly {
+		last--
+	}
+
+	if !r.anchored {
+		for _, segment := range segments[:max(last, 0)] {
+			if matched, _ := path.Match(r.segments[0], segment); matched {
+				return true
+			}
+		}
+		return false
+	}
+
+	for end := 1; end <= last; end++ {
+		if matchSegments(r.segments, segments[:end]) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchSegments matches pattern segments against path segments, where a "**"
+// segment matches zero or more path segments.
+func matchSegments(pattern, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(segments); i++ {
+			if matchSegments(pattern[1:], segments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(segments) == 0 {
+		return false
+	}
+	if matched, _ := path.Match(pattern[0], segments[0]); !matched {
+		return false
+	}
+	return matchSegments(pattern[1:], segments[1:])
+}
@@ -0,0 +1,108 @@
+package projectconfig
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestIgnore_MatchFile(t *testing.T) {
+	ignore, err := ParseIgnore([]byte(`
+# Vendored fragments
+third_party/**
+!third_party/ours.mk
+
+generated.mk
+build/
+/local.mk
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"third_party/acme/rules.mk", true},
+		{"third_party/ours.mk", false},
+		{"generated.mk", true},
+		{"make/generated.mk", true},
+		{"build/tools.mk", true},
+		{"make/build/tools.mk", true},
+		{"build", false},
+		{"local.mk", true},
+		{"make/local.mk", false},
+		{"make/docker.mk", false},
+	}
+	for _, tt := range tests {
+		if got := ignore.MatchFile(tt.path); got != tt.want {
+			t.Errorf("MatchFile(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestIgnore_MatchTarget(t *testing.T) {
+	ignore, err := ParseIgnore([]byte("target:internal-*\n!target:internal-docs\nmake/*.mk\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"internal-sync", true},
+		{"internal-docs", false},
+		{"build", false},
+		{"make", false},
+	}
+	for _, tt := range tests {
+		if got := ignore.MatchTarget(tt.name); got != tt.want {
+			t.Errorf("MatchTarget(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestIgnore_Nil(t *testing.T) {
+	var ignore *Ignore
+	if ignore.MatchFile("Makefile") || ignore.MatchTarget("build") {
+		t.Error("nil Ignore should match nothing")
+	}
+}
+
+func TestIgnore_SkipMakefiles(t *testing.T) {
+	ignore, err := ParseIgnore([]byte("third_party/**\nMakefile\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The main Makefile is never skipped
+	got := ignore.SkipMakefiles([]string{"/p/Makefile", "/p/third_party/rules.mk", "/p/make/build.mk"}, "/p/Makefile", false)
+	want := []string{"/p/Makefile", "/p/make/build.mk"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("SkipMakefiles() = %v, want %v", got, want)
+	}
+}
+
+func TestParseIgnore_InvalidPattern(t *testing.T) {
+	_, err := ParseIgnore([]byte("# ok\nmake/[\n"))
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("expected line 2 error, got %v", err)
+	}
+}
+
+func TestLoad_IgnoreFile(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(IgnorePath(dir), []byte("target:internal-*\n"), 0644); err != nil {
+		t.Fatalf("failed to write %s: %v", IgnoreFileName, err)
+	}
+
+	config, err := Load(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !config.Ignore.MatchTarget("internal-sync") {
+		t.Error("expected Load to read target patterns from the ignore file")
+	}
+}
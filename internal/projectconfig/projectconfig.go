@@ -0,0 +1,187 @@
+package projectconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// FileName is the name of the project config file, read from the Makefile directory.
+const FileName = ".make-help.json"
+
+// Config holds the project settings from .make-help.json.
+type Config struct {
+	// Exclude lists targets and files left out of help output.
+	Exclude Exclude `json:"exclude"`
+
+	// Redact configures secret redaction in rendered documentation.
+	Redact Redact `json:"redact"`
+
+	// HTML sets the sanitization policy of HTML output.
+	HTML HTML `json:"html"`
+
+	// Provenance controls the generation footer of Markdown and HTML output.
+	Provenance Provenance `json:"provenance"`
+
+	// Hooks lists commands run after make-help writes files.
+	Hooks Hooks `json:"hooks"`
+
+	// Categories adjusts category names from !category directives.
+	Categories Categories `json:"categories"`
+
+	// Lint configures which lint checks run.
+	Lint Lint `json:"lint"`
+
+	// Header sets the usage line and examples at the top of help output.
+	Header Header `json:"header"`
+
+	// Usage turns on recording which commands and flags are used.
+	Usage Usage `json:"usage"`
+
+	// Undo configures the journal --undo reverts operations from.
+	Undo Undo `json:"undo"`
+
+	// Ignore holds the patterns from .makehelpignore, read alongside the
+	// JSON settings.
+	Ignore *Ignore `json:"-"`
+}
+
+// Exclude holds glob patterns for targets and files that never appear in help.
+type Exclude struct {
+	// Targets are patterns matched against target names (e.g., "internal-*").
+	Targets []string `json:"targets,omitempty"`
+
+	// Files are patterns matched against source file paths relative to the
+	// Makefile directory; "**" matches any number of directories
+	// (e.g., "third_party/**").
+	Files []string `json:"files,omitempty"`
+}
+
+// Redact holds extra secret patterns, added to the built-in ones.
+type Redact struct {
+	// Patterns are Go regular expressions; a capture group named "secret"
+	// limits masking to that group (e.g., "deploy_key=(?P<secret>\S+)").
+	Patterns []string `json:"patterns,omitempty"`
+}
+
+// HTML holds the HTML output policy. The matching --html-* flags take
+// precedence.
+type HTML struct {
+	// RawHTML is "escape" (default), "strip", or "allow".
+	RawHTML string `json:"rawHTML,omitempty"`
+
+	// LinkRel is the rel attribute of documentation links.
+	LinkRel string `json:"linkRel,omitempty"`
+
+	// LinkTargetBlank opens documentation links in a new tab.
+	LinkTargetBlank bool `json:"linkTargetBlank,omitempty"`
+
+	// Nonce is the CSP nonce of the inline <style> and <script> elements.
+	Nonce string `json:"nonce,omitempty"`
+}
+
+// Provenance holds the generation footer settings. --provenance and
+// --no-provenance override Footer.
+type Provenance struct {
+	// Footer adds the footer.
+	Footer bool `json:"footer,omitempty"`
+
+	// Timestamp includes the generation time; nil means true.
+	Timestamp *bool `json:"timestamp,omitempty"`
+
+	// Commit includes the source commit; nil means true.
+	Commit *bool `json:"commit,omitempty"`
+}
+
+// Hooks holds shell commands run after files are written.
+type Hooks struct {
+	// Post commands run, in order, after help files, --inject files, or
+	// --output files are written; the written paths are appended as
+	// arguments (e.g., "prettier --write", "git add").
+	Post []string `json:"post,omitempty"`
+}
+
+// Categories holds category name adjustments applied while building help.
+type Categories struct {
+	// Rename maps category names as written in !category directives to the
+	// names shown in help (e.g., {"Bld": "Build", "QA": "Test"}), so legacy
+	// annotations can be consolidated without editing every include file.
+	Rename map[string]string `json:"rename,omitempty"`
+}
+
+// Header holds the usage line and example invocations shown at the top of
+// help output. !usage and !example directives in the Makefiles take
+// precedence.
+type Header struct {
+	// Usage replaces the default usage line (e.g., "make -j8 <target>").
+	Usage string `json:"usage,omitempty"`
+
+	// Examples are commands shown beneath the usage line
+	// (e.g., ["make test RUN=TestParse"]).
+	Examples []string `json:"examples,omitempty"`
+}
+
+// Usage holds the local usage statistics settings.
+type Usage struct {
+	// Record counts the commands, flags, and formats of each run in
+	// .make-help-usage.json next to the Makefile.
+	Record bool `json:"record,omitempty"`
+}
+
+// Undo holds the settings of the --undo journal.
+type Undo struct {
+	// History is how many operations the journal keeps. Zero means the
+	// default of 50.
+	History int `json:"history,omitempty"`
+}
+
+// Lint holds lint settings.
+type Lint struct {
+	// Disable lists lint checks that do not run, by name
+	// (e.g., ["imperative-mood", "long-summary"]).
+	Disable []string `json:"disable,omitempty"`
+
+	// RequireOwner lists glob patterns naming the categories whose targets
+	// must have an !owner (e.g., ["Deploy*", "Release"]).
+	RequireOwner []string `json:"requireOwner,omitempty"`
+
+	// Plugins lists executables run as lint checks in addition to the
+	// make-help-check-* executables on PATH. Paths are relative to the
+	// Makefile directory; names without a slash are looked up on PATH.
+	Plugins []string `json:"plugins,omitempty"`
+}
+
+// Path returns the config file path for the Makefile directory dir.
+func Path(dir string) string {
+	return filepath.Join(dir, FileName)
+}
+
+// Load reads the config file and the ignore file in dir. Missing files yield
+// empty settings. Unknown settings are rejected so typos do not go unnoticed.
+func Load(dir string) (*Config, error) {
+	config := &Config{}
+
+	ignore, err := LoadIgnore(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(Path(dir))
+	if os.IsNotExist(err) {
+		config.Ignore = ignore
+		return config, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read project config: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(config); err != nil {
+		return nil, fmt.Errorf("failed to parse project config %s: %w", Path(dir), err)
+	}
+	config.Ignore = ignore
+	return config, nil
+}
@@ -0,0 +1,183 @@
+package projectconfig
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoad_MissingFile(t *testing.T) {
+	config, err := Load(t.TempDir())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(config.Exclude.Targets) != 0 || len(config.Exclude.Files) != 0 {
+		t.Errorf("expected empty config, got %+v", config)
+	}
+}
+
+func TestLoad_Exclude(t *testing.T) {
+	dir := t.TempDir()
+	content := `{
+		"exclude": {"targets": ["internal-*"], "files": ["third_party/**"]},
+		"redact": {"patterns": ["sk_live_\\w+"]}
+	}`
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write %s: %v", FileName, err)
+	}
+
+	config, err := Load(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(config.Exclude.Targets) != 1 || config.Exclude.Targets[0] != "internal-*" {
+		t.Errorf("unexpected exclude.targets: %v", config.Exclude.Targets)
+	}
+	if len(config.Exclude.Files) != 1 || config.Exclude.Files[0] != "third_party/**" {
+		t.Errorf("unexpected exclude.files: %v", config.Exclude.Files)
+	}
+	if len(config.Redact.Patterns) != 1 || config.Redact.Patterns[0] != `sk_live_\w+` {
+		t.Errorf("unexpected redact.patterns: %v", config.Redact.Patterns)
+	}
+}
+
+func TestLoad_UnknownField(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte(`{"exclud": {}}`), 0644); err != nil {
+		t.Fatalf("failed to write %s: %v", FileName, err)
+	}
+
+	_, err := Load(dir)
+	if err == nil || !strings.Contains(err.Error(), `unknown field "exclud"`) {
+		t.Errorf("expected unknown field error, got %v", err)
+	}
+}
+
+func TestLoad_HTML(t *testing.T) {
+	dir := t.TempDir()
+	content := `{"html": {"rawHTML": "strip", "linkRel": "noopener", "linkTargetBlank": true, "nonce": "abc"}}`
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write %s: %v", FileName, err)
+	}
+
+	config, err := Load(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := HTML{RawHTML: "strip", LinkRel: "noopener", LinkTargetBlank: true, Nonce: "abc"}
+	if config.HTML != want {
+		t.Errorf("unexpected html settings: %+v", config.HTML)
+	}
+}
+
+func TestLoad_Provenance(t *testing.T) {
+	dir := t.TempDir()
+	content := `{"provenance": {"footer": true, "timestamp": false}}`
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write %s: %v", FileName, err)
+	}
+
+	config, err := Load(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !config.Provenance.Footer {
+		t.Error("expected footer to be enabled")
+	}
+	if config.Provenance.Timestamp == nil || *config.Provenance.Timestamp {
+		t.Errorf("expected timestamp to be disabled, got %v", config.Provenance.Timestamp)
+	}
+	if config.Provenance.Commit != nil {
+		t.Errorf("expected commit to be unset, got %v", *config.Provenance.Commit)
+	}
+}
+
+func TestLoad_CategoriesRename(t *testing.T) {
+	dir := t.TempDir()
+	content := `{"categories": {"rename": {"Bld": "Build", "QA": "Test"}}}`
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write %s: %v", FileName, err)
+	}
+
+	config, err := Load(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rename := config.Categories.Rename
+	if len(rename) != 2 || rename["Bld"] != "Build" || rename["QA"] != "Test" {
+		t.Errorf("unexpected categories.rename: %v", rename)
+	}
+}
+
+func TestLoad_Undo(t *testing.T) {
+	dir := t.TempDir()
+	content := `{"undo": {"history": 200}}`
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write %s: %v", FileName, err)
+	}
+
+	config, err := Load(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if config.Undo.History != 200 {
+		t.Errorf("undo.history = %d, want 200", config.Undo.History)
+	}
+}
+
+func TestLoad_Lint(t *testing.T) {
+	dir := t.TempDir()
+	content := `{"lint": {"disable": ["imperative-mood"], "requireOwner": ["Deploy*"], "plugins": ["scripts/check-tickets"]}}`
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write %s: %v", FileName, err)
+	}
+
+	config, err := Load(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(config.Lint.Disable) != 1 || config.Lint.Disable[0] != "imperative-mood" {
+		t.Errorf("unexpected lint.disable: %v", config.Lint.Disable)
+	}
+	if len(config.Lint.RequireOwner) != 1 || config.Lint.RequireOwner[0] != "Deploy*" {
+		t.Errorf("unexpected lint.requireOwner: %v", config.Lint.RequireOwner)
+	}
+	if len(config.Lint.Plugins) != 1 || config.Lint.Plugins[0] != "scripts/check-tickets" {
+		t.Errorf("unexpected lint.plugins: %v", config.Lint.Plugins)
+	}
+}
+
+func TestLoad_Header(t *testing.T) {
+	dir := t.TempDir()
+	content := `{"header": {"usage": "make -j8 <target>", "examples": ["make build", "make test V=1"]}}`
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write %s: %v", FileName, err)
+	}
+
+	config, err := Load(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if config.Header.Usage != "make -j8 <target>" {
+		t.Errorf("expected header usage, got %q", config.Header.Usage)
+	}
+	if len(config.Header.Examples) != 2 || config.Header.Examples[1] != "make test V=1" {
+		t.Errorf("expected two header examples, got %v", config.Header.Examples)
+	}
+}
+
+func TestLoad_Usage(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte(`{"usage": {"record": true}}`), 0644); err != nil {
+		t.Fatalf("failed to write %s: %v", FileName, err)
+	}
+
+	config, err := Load(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !config.Usage.Record {
+		t.Error("expected usage.record to be set")
+	}
+}
@@ -0,0 +1,157 @@
+// Package redact masks secrets that appear in documentation before it is
+// rendered, so generated help can be published safely.
+//
+// A Redactor applies a list of regular expressions. When a pattern has a
+// capture group named "secret", only that group is masked (e.g., the value
+// of "password=hunter2" but not the key); otherwise the whole match is.
+package redact
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/sdlcforge/make-help/internal/model"
+)
+
+// Mask replaces redacted text.
+const Mask = "[REDACTED]"
+
+// secretGroup is the capture group name that limits masking to part of a match.
+const secretGroup = "secret"
+
+// secretKeys matches key names whose values are treated as secrets,
+// including prefixed variants such as GITHUB_TOKEN or db.password.
+const secretKeys = `(?i)\b[\w.-]*(?:password|passwd|secret|token|api[_-]?key|access[_-]?key)`
+
+// DefaultPatterns are always applied unless redaction is disabled.
+var DefaultPatterns = []string{
+	// password=..., API_KEY="..." assignments
+	secretKeys + `\s*=\s*(?P<secret>"[^"]*"|'[^']*'|[^\s"';]*[^\s"';.,)])`,
+	// "password": "..." in JSON and YAML snippets
+	secretKeys + `["']?\s*:\s*(?P<secret>"[^"]*"|'[^']*')`,
+	// AWS access key IDs
+	`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`,
+	// GitHub tokens
+	`\bgh[pousr]_[A-Za-z0-9]{36,}\b`,
+	// Slack tokens
+	`\bxox[abprs]-[A-Za-z0-9-]{10,}`,
+	// Bearer tokens in Authorization headers
+	`(?i)\bbearer\s+(?P<secret>[A-Za-z0-9._~+/-]{8,}=*)`,
+	// PEM private keys
+	`-----BEGIN [A-Z ]*PRIVATE KEY-----`,
+}
+
+// Redactor masks text matching secret patterns.
+type Redactor struct {
+	patterns []*regexp.Regexp
+}
+
+// New creates a Redactor for DefaultPatterns plus the given custom patterns.
+func New(custom []string) (*Redactor, error) {
+	r := &Redactor{}
+	for _, pattern := range DefaultPatterns {
+		r.patterns = append(r.patterns, regexp.MustCompile(pattern))
+	}
+	for _, pattern := range custom {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction pattern %q: %w", pattern, err)
+		}
+		r.patterns = append(r.patterns, re)
+	}
+	return r, nil
+}
+
+// Redact returns s with every secret masked. A nil Redactor returns s unchanged.
+func (r *Redactor) Redact(s string) string {
+	if r == nil {
+		return s
+	}
+	for _, re := range r.patterns {
+		s = redactPattern(re, s)
+	}
+	return s
+}
+
+// RedactModel masks secrets in all documentation text of the help model:
+// the usage line and examples, file, category, and footer documentation,
+// target documentation and summaries, and variable descriptions. A nil Redactor leaves the model unchanged.
+func (r *Redactor) RedactModel(helpModel *model.HelpModel) {
+	if r == nil {
+		return
+	}
+	helpModel.Usage = r.Redact(helpModel.Usage)
+	r.redactLines(helpModel.Examples)
+	r.redactLines(helpModel.FooterDocs)
+	for i := range helpModel.FileDocs {
+		r.redactLines(helpModel.FileDocs[i].Documentation)
+	}
+	for i := range helpModel.Categories {
+		category := &helpModel.Categories[i]
+		r.redactLines(category.Documentation)
+		for j := range category.Targets {
+			r.RedactTarget(&category.Targets[j])
+		}
+	}
+}
+
+// RedactTarget masks secrets in a single target's documentation, summary,
+// deprecation message, and variable descriptions.
+func (r *Redactor) RedactTarget(target *model.Target) {
+	if r == nil {
+		return
+	}
+	r.redactLines(target.Documentation)
+	r.redactLines(target.Summary)
+	target.DeprecationMessage = r.Redact(target.DeprecationMessage)
+	target.DangerReason = r.Redact(target.DangerReason)
+	for k := range target.Variables {
+		target.Variables[k].Description = r.Redact(target.Variables[k].Description)
+	}
+}
+
+// redactLines masks secrets in lines in place.
+func (r *Redactor) redactLines(lines []string) {
+	for i, line := range lines {
+		lines[i] = r.Redact(line)
+	}
+}
+
+// redactPattern masks every match of re in s, or only its "secret" group
+// when the pattern has one. Values that reference a variable ("$(TOKEN)",
+// "${TOKEN}") or are placeholders ("<token>") are left alone.
+func redactPattern(re *regexp.Regexp, s string) string {
+	group := re.SubexpIndex(secretGroup)
+	matches := re.FindAllStringSubmatchIndex(s, -1)
+	if matches == nil {
+		return s
+	}
+
+	var sb strings.Builder
+	last := 0
+	for _, match := range matches {
+		start, end := match[0], match[1]
+		if group >= 0 {
+			start, end = match[2*group], match[2*group+1]
+		}
+		if start < 0 || isPlaceholder(s[start:end]) {
+			continue
+		}
+		sb.WriteString(s[last:start])
+		sb.WriteString(Mask)
+		last = end
+	}
+	sb.WriteString(s[last:])
+	return sb.String()
+}
+
+// isPlaceholder reports whether a matched value is a variable reference or
+// a placeholder rather than a literal secret.
+func isPlaceholder(value string) bool {
+	value = strings.Trim(value, `"'`)
+	return value == "" ||
+		strings.HasPrefix(value, "$") ||
+		(strings.HasPrefix(value, "<") && strings.HasSuffix(value, ">")) ||
+		value == Mask
+}
@@ -0,0 +1,9 @@
+// Package remote fetches Makefile fragments named by "## !source" annotations
+// so their documentation can be included in help output.
+//
+// Fetched files are cached by URL under the user cache directory. A source
+// pinned with "sha256:<hex>" is verified on every read, from the network or
+// the cache; a mismatch is an error. The cached copy of an unpinned source
+// is fetched again once it is older than the Fetcher's MaxAge, a day by
+// default. Only https URLs are fetched.
+package remote
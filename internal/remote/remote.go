@@ -0,0 +1,144 @@
+package remote
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sdlcforge/make-help/internal/target"
+)
+
+// checksumPrefix is the only supported checksum algorithm.
+const checksumPrefix = "sha256:"
+
+// maxSize limits how much of a remote file is read.
+const maxSize = 4 << 20
+
+// DefaultMaxAge is how long NewFetcher's cached copies of unpinned sources
+// are used before they are fetched again.
+const DefaultMaxAge = 24 * time.Hour
+
+// Fetcher downloads remote Makefile fragments, caching them on disk.
+type Fetcher struct {
+	// CacheDir holds fetched files. Empty disables caching.
+	CacheDir string
+
+	// Client performs the requests.
+	Client *http.Client
+
+	// MaxAge is how long a cached copy of a source without a checksum is
+	// used before it is fetched again. Zero always fetches such sources.
+	// Pinned sources cannot change, so their cached copies never expire.
+	MaxAge time.Duration
+}
+
+// NewFetcher creates a Fetcher caching under the user cache directory
+// (e.g. ~/.cache/make-help/remote) for DefaultMaxAge. Caching is disabled
+// when there is no user cache directory.
+func NewFetcher() *Fetcher {
+	fetcher := &Fetcher{Client: &http.Client{Timeout: 30 * time.Second}, MaxAge: DefaultMaxAge}
+	if dir, err := os.UserCacheDir(); err == nil {
+		fetcher.CacheDir = filepath.Join(dir, "make-help", "remote")
+	}
+	return fetcher
+}
+
+// Fetch returns the content at rawURL. A cached copy is used when present
+// and, if checksum is set, matching, or if checksum is empty, younger than
+// MaxAge. checksum is "sha256:<hex>" or empty.
+func (f *Fetcher) Fetch(ctx context.Context, rawURL string, checksum string) ([]byte, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid remote source %q: only https URLs are supported", rawURL)
+	}
+	if checksum != "" && !strings.HasPrefix(checksum, checksumPrefix) {
+		return nil, fmt.Errorf("unsupported checksum %q for %s (want sha256:<hex>)", checksum, rawURL)
+	}
+
+	cachePath := f.cachePath(rawURL)
+	if cachePath != "" {
+		info, err := os.Stat(cachePath)
+		if err == nil && (checksum != "" || time.Since(info.ModTime()) < f.MaxAge) {
+			if data, err := os.ReadFile(cachePath); err == nil && verify(data, checksum) == nil {
+				return data, nil
+			}
+		}
+	}
+
+	data, err := f.download(ctx, rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if err := verify(data, checksum); err != nil {
+		return nil, fmt.Errorf("%s: %w", rawURL, err)
+	}
+
+	if cachePath != "" {
+		if err := os.MkdirAll(filepath.Dir(cachePath), 0755); err != nil {
+			return nil, fmt.Errorf("failed to create cache directory: %w", err)
+		}
+		if err := target.AtomicWriteFile(cachePath, data, 0644); err != nil {
+			return nil, fmt.Errorf("failed to cache %s: %w", rawURL, err)
+		}
+	}
+	return data, nil
+}
+
+// download performs the HTTP request for rawURL.
+func (f *Fetcher) download(ctx context.Context, rawURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", rawURL, err)
+	}
+	client := f.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", rawURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch %s: %s", rawURL, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", rawURL, err)
+	}
+	if len(data) > maxSize {
+		return nil, fmt.Errorf("failed to fetch %s: larger than %d bytes", rawURL, maxSize)
+	}
+	return data, nil
+}
+
+// cachePath returns the cache file for rawURL, or "" when caching is disabled.
+func (f *Fetcher) cachePath(rawURL string) string {
+	if f.CacheDir == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(rawURL))
+	return filepath.Join(f.CacheDir, hex.EncodeToString(sum[:]))
+}
+
+// verify checks data against checksum; an empty checksum always passes.
+func verify(data []byte, checksum string) error {
+	if checksum == "" {
+		return nil
+	}
+	sum := sha256.Sum256(data)
+	got := checksumPrefix + hex.EncodeToString(sum[:])
+	if !strings.EqualFold(got, checksum) {
+		return fmt.Errorf("checksum mismatch: got %s, want %s", got, checksum)
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package remote
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const fragment = "## Build the image.\ndocker-build:\n"
+
+// newServer serves fragment at /common.mk and counts the requests.
+func newServer(t *testing.T) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var requests atomic.Int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path != "/common.mk" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(fragment))
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func fragmentChecksum() string {
+	sum := sha256.Sum256([]byte(fragment))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+func TestFetch(t *testing.T) {
+	server, requests := newServer(t)
+	fetcher := &Fetcher{CacheDir: t.TempDir(), Client: server.Client()}
+
+	for _, checksum := range []string{"", fragmentChecksum()} {
+		data, err := fetcher.Fetch(context.Background(), server.URL+"/common.mk", checksum)
+		if err != nil {
+			t.Fatalf("Fetch(checksum %q) error = %v", checksum, err)
+		}
+		if string(data) != fragment {
+			t.Errorf("Fetch(checksum %q) = %q, want %q", checksum, data, fragment)
+		}
+	}
+	// The second fetch is served from the cache
+	if got := requests.Load(); got != 1 {
+		t.Errorf("server got %d requests, want 1", got)
+	}
+}
+
+func TestFetch_MaxAge(t *testing.T) {
+	server, requests := newServer(t)
+	cacheDir := t.TempDir()
+	fetcher := &Fetcher{CacheDir: cacheDir, Client: server.Client(), MaxAge: time.Hour}
+	rawURL := server.URL + "/common.mk"
+
+	for range 2 {
+		if _, err := fetcher.Fetch(context.Background(), rawURL, ""); err != nil {
+			t.Fatalf("Fetch() error = %v", err)
+		}
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("server got %d requests within MaxAge, want 1", got)
+	}
+
+	// An expired copy is fetched again, unless the source is pinned
+	old := time.Now().Add(-2 * time.Hour)
+	if err := os.Chtimes(fetcher.cachePath(rawURL), old, old); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fetcher.Fetch(context.Background(), rawURL, fragmentChecksum()); err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("server got %d requests for a pinned source, want 1", got)
+	}
+	if _, err := fetcher.Fetch(context.Background(), rawURL, ""); err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("server got %d requests after MaxAge, want 2", got)
+	}
+}
+
+func TestFetch_ChecksumMismatch(t *testing.T) {
+	server, _ := newServer(t)
+	fetcher := &Fetcher{CacheDir: t.TempDir(), Client: server.Client()}
+
+	want := "sha256:" + strings.Repeat("0", 64)
+	_, err := fetcher.Fetch(context.Background(), server.URL+"/common.mk", want)
+	if err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Fatalf("expected checksum mismatch, got %v", err)
+	}
+
+	// A cached copy that no longer matches the pin is not trusted either
+	if _, err := fetcher.Fetch(context.Background(), server.URL+"/common.mk", ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := fetcher.Fetch(context.Background(), server.URL+"/common.mk", want); err == nil {
+		t.Error("expected checksum mismatch for cached copy")
+	}
+}
+
+func TestFetch_Errors(t *testing.T) {
+	server, _ := newServer(t)
+	fetcher := &Fetcher{Client: server.Client()}
+
+	tests := []struct {
+		name     string
+		url      string
+		checksum string
+		want     string
+	}{
+		{name: "http URL", url: "http://example.com/common.mk", want: "only https URLs are supported"},
+		{name: "not a URL", url: "common.mk", want: "only https URLs are supported"},
+		{name: "unsupported checksum", url: server.URL + "/common.mk", checksum: "md5:abc", want: "unsupported checksum"},
+		{name: "not found", url: server.URL + "/missing.mk", want: "404 Not Found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := fetcher.Fetch(context.Background(), tt.url, tt.checksum)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Fetch() error = %v, want containing %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package richtext
+
+import (
+	"strings"
+	"testing"
+)
+
+// FuzzRichTextParse checks that any text parses without panicking into
+// segments that hold no more text than the input, and that rendering them
+// back to markdown and parsing again gives the same plain text.
+func FuzzRichTextParse(f *testing.F) {
+	for _, seed := range []string{
+		"",
+		"plain text",
+		"**bold** and *italic* and `code`",
+		"__bold__ and _italic_",
+		"[link](https://example.com) with **bold**",
+		"Build the `bin/app` binary for **linux** (see [docs](docs/build.md)).",
+		"*unclosed **bold* text**",
+		"\x1b[31mred\x1b[0m",
+		"snake_case_name and file_name.go",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, text string) {
+		parser := NewParser()
+		parsed := parser.Parse(text)
+		plain := parsed.PlainText()
+		if len(plain) > len(text) {
+			t.Fatalf("Parse(%q) plain text %q is longer than the input", text, plain)
+		}
+		for _, segment := range parsed {
+			if segment.Type == SegmentLink && !strings.Contains(text, segment.URL) {
+				t.Fatalf("Parse(%q) returned link URL %q not in the input", text, segment.URL)
+			}
+		}
+		_ = parser.Parse(parsed.Markdown())
+	})
+}
@@ -3,6 +3,8 @@ package richtext
 import (
 	"regexp"
 	"sort"
+	"strings"
+	"sync"
 )
 
 const (
@@ -10,12 +12,19 @@ const (
 	MaxInputLength = 10 * 1024
 	// MaxSegmentLength is the maximum length for a single segment (2000 chars)
 	MaxSegmentLength = 2000
+	// maxCacheEntries bounds the parse cache; it holds every summary of a
+	// large project, and is cleared when full so a long-lived parser (e.g.,
+	// in --daemon) keeps at most this many results
+	maxCacheEntries = 16 * 1024
 )
 
 // ansiEscapeRegex matches ANSI escape codes for stripping
 var ansiEscapeRegex = regexp.MustCompile(`\x1b\[[0-9;]*m`)
 
-// Parser parses markdown inline formatting into RichText segments
+// Parser parses markdown inline formatting into RichText segments.
+// Results are cached by input text, since formatters parse the same summary
+// several times; the cache is bounded by maxCacheEntries. A Parser is safe
+// for concurrent use.
 type Parser struct {
 	linkRegex        *regexp.Regexp
 	codeRegex        *regexp.Regexp
@@ -23,6 +32,9 @@ type Parser struct {
 	boldUnderRegex   *regexp.Regexp
 	italicRegex      *regexp.Regexp
 	italicUnderRegex *regexp.Regexp
+
+	mu    sync.Mutex
+	cache map[string]RichText
 }
 
 // NewParser creates a new Parser with pre-compiled regex patterns
@@ -40,6 +52,7 @@ func NewParser() *Parser {
 		italicRegex: regexp.MustCompile(`\*([^*]+)\*`),
 		// Italic underscore: _text_ - single underscores only
 		italicUnderRegex: regexp.MustCompile(`_([^_]+)_`),
+		cache:            make(map[string]RichText),
 	}
 }
 
@@ -52,9 +65,37 @@ type match struct {
 
 // Parse converts a markdown string into RichText segments
 // Processing order: links → code → bold → italic (highest to lowest precedence)
+//
+// The result may be shared with other calls parsing the same text, so
+// callers must not modify it.
 func (p *Parser) Parse(text string) RichText {
+	p.mu.Lock()
+	cached, ok := p.cache[text]
+	p.mu.Unlock()
+	if ok {
+		return cached
+	}
+
+	result := p.parse(text)
+	if len(text) > MaxInputLength {
+		// Not worth keeping the long text as a key
+		return result
+	}
+	p.mu.Lock()
+	if len(p.cache) >= maxCacheEntries {
+		clear(p.cache)
+	}
+	p.cache[text] = result
+	p.mu.Unlock()
+	return result
+}
+
+// parse does the work of Parse without the cache.
+func (p *Parser) parse(text string) RichText {
 	// Strip ANSI escape codes to prevent ANSI injection
-	text = ansiEscapeRegex.ReplaceAllString(text, "")
+	if strings.Contains(text, "\x1b") {
+		text = ansiEscapeRegex.ReplaceAllString(text, "")
+	}
 
 	// Enforce input length limit
 	if len(text) > MaxInputLength {
@@ -208,8 +249,9 @@ func (p *Parser) findItalicMatches(text string, existingMatches []match, delim b
 		}
 
 		if end == -1 {
-			pos = start + 1
-			continue // No closing delimiter found
+			// No later delimiter can be closed either; rescanning from each
+			// one would be quadratic in the number of delimiters
+			break
 		}
 
 		// Check if this match overlaps with existing matches
@@ -1,6 +1,7 @@
 package richtext
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 )
@@ -638,3 +639,34 @@ func richTextEqual(a, b RichText) bool {
 	}
 	return true
 }
+
+func TestParser_Parse_Cached(t *testing.T) {
+	t.Parallel()
+	parser := NewParser()
+
+	first := parser.Parse("**bold** and *italic*")
+	second := parser.Parse("**bold** and *italic*")
+	if !richTextEqual(first, second) {
+		t.Fatalf("Parse() = %+v, then %+v", first, second)
+	}
+	if &first[0] != &second[0] {
+		t.Error("Parse() did not reuse the cached result")
+	}
+}
+
+func TestParser_Parse_CacheBounded(t *testing.T) {
+	t.Parallel()
+	parser := NewParser()
+
+	for i := 0; i <= maxCacheEntries; i++ {
+		parser.Parse(fmt.Sprintf("target %d", i))
+	}
+	if n := len(parser.cache); n > maxCacheEntries {
+		t.Errorf("cache holds %d entries, want at most %d", n, maxCacheEntries)
+	}
+
+	parser.Parse(strings.Repeat("a", MaxInputLength+1))
+	if _, ok := parser.cache[strings.Repeat("a", MaxInputLength+1)]; ok {
+		t.Error("Parse() cached text over MaxInputLength")
+	}
+}
@@ -0,0 +1,8 @@
+// Package runstate records how long targets took when run through
+// make-help --run --record-duration.
+//
+// The state lives in a small JSON file next to the Makefile
+// (.make-help-state.json). It is local, machine-specific data and should be
+// ignored by version control. Help written to the terminal reads it to show
+// "last run" durations; generated files never include it.
+package runstate
@@ -0,0 +1,99 @@
+package runstate
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/sdlcforge/make-help/internal/target"
+)
+
+// FileName is the name of the state file, created in the Makefile directory.
+const FileName = ".make-help-state.json"
+
+// Run describes the most recent recorded run of a target.
+type Run struct {
+	// At is when the run finished.
+	At time.Time `json:"at"`
+
+	// DurationMs is how long the run took, in milliseconds.
+	DurationMs int64 `json:"durationMs"`
+}
+
+// Duration returns the run's duration.
+func (r Run) Duration() time.Duration {
+	return time.Duration(r.DurationMs) * time.Millisecond
+}
+
+// State maps target names to their most recent recorded run.
+type State struct {
+	Targets map[string]Run `json:"targets"`
+}
+
+// Path returns the state file path for the Makefile directory dir.
+func Path(dir string) string {
+	return filepath.Join(dir, FileName)
+}
+
+// Load reads the state file in dir. A missing file yields an empty state.
+func Load(dir string) (*State, error) {
+	state := &State{Targets: make(map[string]Run)}
+
+	data, err := os.ReadFile(Path(dir))
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read run state: %w", err)
+	}
+
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("failed to parse run state %s: %w", Path(dir), err)
+	}
+	if state.Targets == nil {
+		state.Targets = make(map[string]Run)
+	}
+	return state, nil
+}
+
+// LastDurations returns the last recorded duration of each target in dir,
+// rounded to the second. Unreadable state is treated as empty, since it only
+// decorates help output.
+func LastDurations(dir string) map[string]time.Duration {
+	state, err := Load(dir)
+	if err != nil {
+		return nil
+	}
+	durations := make(map[string]time.Duration, len(state.Targets))
+	for name, run := range state.Targets {
+		durations[name] = run.Duration().Round(time.Second)
+	}
+	return durations
+}
+
+// Record stores a finished run of targetName in the state file in dir,
+// replacing any earlier run of the same target.
+func Record(dir, targetName string, at time.Time, duration time.Duration) error {
+	state, err := Load(dir)
+	if err != nil {
+		return err
+	}
+
+	state.Targets[targetName] = Run{
+		At:         at.UTC(),
+		DurationMs: duration.Milliseconds(),
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode run state: %w", err)
+	}
+	data = append(data, '\n')
+
+	if err := target.AtomicWriteFile(Path(dir), data, 0644); err != nil {
+		return fmt.Errorf("failed to write run state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package runstate
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_MissingFile(t *testing.T) {
+	t.Parallel()
+	state, err := Load(t.TempDir())
+	require.NoError(t, err)
+	assert.Empty(t, state.Targets)
+}
+
+func TestRecordAndLastDurations(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	require.NoError(t, Record(dir, "deploy", at, 4*time.Minute+12*time.Second+300*time.Millisecond))
+	require.NoError(t, Record(dir, "build", at, 2*time.Second))
+	require.NoError(t, Record(dir, "build", at, 3*time.Second))
+
+	state, err := Load(dir)
+	require.NoError(t, err)
+	assert.Equal(t, Run{At: at, DurationMs: 252300}, state.Targets["deploy"])
+
+	assert.Equal(t, map[string]time.Duration{
+		"deploy": 4*time.Minute + 12*time.Second,
+		"build":  3 * time.Second,
+	}, LastDurations(dir))
+}
+
+func TestLoad_InvalidFile(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(Path(dir), []byte("{"), 0644))
+
+	_, err := Load(dir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to parse run state")
+	assert.Nil(t, LastDurations(dir))
+}
@@ -0,0 +1,13 @@
+// Package spell finds likely misspellings in documentation text.
+//
+// Each language has an embedded word list (dict/<lang>.txt), ordered by how
+// common each word is so suggestions prefer frequent words. The English list
+// is generated by gen_dict.go from the comments in the Go source tree (see
+// NOTICE) and the build and release terms in terms_en.txt. Projects extend
+// it with their own terms in a .make-help-dict file next to the Makefile.
+//
+// Words are matched case-insensitively and with common English inflections
+// (plurals, -ed, -ing, -er, -ly, ...) removed. Text that is not prose is
+// skipped: inline code, URLs, file paths, $(VARIABLES), and words containing
+// digits, underscores, or inner capitals.
+package spell
@@ -0,0 +1,144 @@
+//go:build ignore
+
+// gen_dict.go generates dict/en.txt, the English word list, from the
+// comments in the Go source tree: words used in at least minFiles files and
+// minCount times in all, ordered from most to least common, followed by the
+// build and release terms in terms_en.txt. The Go source is distributed
+// under a BSD-style license (see NOTICE).
+//
+// Usage: go run gen_dict.go [-goroot dir] > dict/en.txt
+package main
+
+import (
+	"bufio"
+	"cmp"
+	"flag"
+	"fmt"
+	"go/scanner"
+	"go/token"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"slices"
+	"strings"
+)
+
+const (
+	minFiles = 3
+	minCount = 5
+)
+
+// wordRegex matches lowercase words, optionally capitalized at the start
+// of a sentence; identifiers with inner capitals or digits do not match.
+var wordRegex = regexp.MustCompile(`\b[A-Za-z][a-z]+\b`)
+
+func main() {
+	goroot := flag.String("goroot", runtime.GOROOT(), "Go source tree to read comments from")
+	flag.Parse()
+
+	counts := make(map[string]int)
+	files := make(map[string]int)
+	src := filepath.Join(*goroot, "src")
+	err := filepath.WalkDir(src, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if name := entry.Name(); name == "testdata" || name == "vendor" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		seen := make(map[string]bool)
+		for _, word := range commentWords(content) {
+			counts[word]++
+			if !seen[word] {
+				seen[word] = true
+				files[word]++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var words []string
+	for word, count := range counts {
+		if count >= minCount && files[word] >= minFiles {
+			words = append(words, word)
+		}
+	}
+	slices.SortFunc(words, func(a, b string) int {
+		return cmp.Or(cmp.Compare(counts[b], counts[a]), cmp.Compare(a, b))
+	})
+
+	terms, err := os.ReadFile("terms_en.txt")
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, line := range strings.Split(string(terms), "\n") {
+		term := strings.ToLower(strings.TrimSpace(line))
+		if term == "" || strings.HasPrefix(term, "#") || slices.Contains(words, term) {
+			continue
+		}
+		words = append(words, term)
+	}
+
+	version, err := os.ReadFile(filepath.Join(*goroot, "VERSION"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	w := bufio.NewWriter(os.Stdout)
+	fmt.Fprintf(w, "# English word list for spell checking, one lowercased word per line,\n")
+	fmt.Fprintf(w, "# ordered from most to least common.\n")
+	fmt.Fprintf(w, "#\n")
+	fmt.Fprintf(w, "# Generated by gen_dict.go from the comments in the source of %s\n", strings.SplitN(string(version), "\n", 2)[0])
+	fmt.Fprintf(w, "# (Copyright The Go Authors, distributed under a BSD-style license; see\n")
+	fmt.Fprintf(w, "# NOTICE at the root of this repository), followed by terms_en.txt.\n")
+	fmt.Fprintf(w, "# DO NOT EDIT.\n")
+	for _, word := range words {
+		fmt.Fprintln(w, word)
+	}
+	if err := w.Flush(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// commentWords returns the lowercased words in the comments of a Go file.
+func commentWords(content []byte) []string {
+	fset := token.NewFileSet()
+	file := fset.AddFile("", fset.Base(), len(content))
+	var s scanner.Scanner
+	s.Init(file, content, nil, scanner.ScanComments)
+
+	var words []string
+	for {
+		_, tok, lit := s.Scan()
+		if tok == token.EOF {
+			return words
+		}
+		if tok != token.COMMENT {
+			continue
+		}
+		for _, line := range strings.Split(lit, "\n") {
+			// Skip indented code blocks in doc comments
+			if trimmed := strings.TrimLeft(line, "/* "); strings.HasPrefix(trimmed, "\t") {
+				continue
+			}
+			for _, word := range wordRegex.FindAllString(line, -1) {
+				words = append(words, strings.ToLower(word))
+			}
+		}
+	}
+}
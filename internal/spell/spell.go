@@ -0,0 +1,270 @@
+package spell
+
+import (
+	"bufio"
+	"embed"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// ProjectDictFile is the name of the project dictionary, read from the
+// directory of the main Makefile.
+const ProjectDictFile = ".make-help-dict"
+
+// DefaultLanguage is the language used when none is configured.
+const DefaultLanguage = "en"
+
+//go:generate sh -c "go run gen_dict.go > dict/en.txt"
+
+//go:embed dict/*.txt
+var dictionaries embed.FS
+
+// Dictionary is a set of known words.
+type Dictionary struct {
+	// rank maps each lowercased word to its position in the word list;
+	// lower ranks are more common. Project words rank after all others.
+	rank map[string]int
+}
+
+// Languages returns the languages with an embedded word list, sorted.
+func Languages() []string {
+	entries, err := fs.ReadDir(dictionaries, "dict")
+	if err != nil {
+		return nil
+	}
+	var langs []string
+	for _, entry := range entries {
+		langs = append(langs, strings.TrimSuffix(entry.Name(), ".txt"))
+	}
+	slices.Sort(langs)
+	return langs
+}
+
+// Load returns the embedded dictionary for lang.
+func Load(lang string) (*Dictionary, error) {
+	if !slices.Contains(Languages(), lang) {
+		return nil, fmt.Errorf("no dictionary for language %q (available: %s)", lang, strings.Join(Languages(), ", "))
+	}
+	f, err := dictionaries.Open("dict/" + lang + ".txt")
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	d := &Dictionary{rank: make(map[string]int)}
+	if err := d.read(f); err != nil {
+		return nil, fmt.Errorf("failed to read %s dictionary: %w", lang, err)
+	}
+	return d, nil
+}
+
+// AddFile adds the words in a project dictionary file: one word per line,
+// with blank lines and lines starting with # ignored.
+func (d *Dictionary) AddFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := d.read(f); err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return nil
+}
+
+// Add adds words to the dictionary.
+func (d *Dictionary) Add(words ...string) {
+	for _, word := range words {
+		word = strings.ToLower(strings.TrimSpace(word))
+		if _, ok := d.rank[word]; !ok && word != "" {
+			d.rank[word] = len(d.rank)
+		}
+	}
+}
+
+// read adds one word per line from r, skipping blank lines and comments.
+func (d *Dictionary) read(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		d.Add(line)
+	}
+	return scanner.Err()
+}
+
+// Contains reports whether word, or its stem, is in the dictionary.
+func (d *Dictionary) Contains(word string) bool {
+	word = strings.ToLower(word)
+	if _, ok := d.rank[word]; ok {
+		return true
+	}
+	for _, stem := range stems(word) {
+		if _, ok := d.rank[stem]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// inflections maps word endings to the endings their stems may have, so
+// "deploys", "deployed", "deploying", and "deployer" all match "deploy".
+var inflections = []struct {
+	suffix string
+	stems  []string
+}{
+	{"'s", []string{""}},
+	{"n't", []string{""}},
+	{"'re", []string{""}},
+	{"'ll", []string{""}},
+	{"'ve", []string{""}},
+	{"'d", []string{""}},
+	{"'m", []string{""}},
+	{"ies", []string{"y"}},
+	{"ied", []string{"y"}},
+	{"es", []string{"", "e"}},
+	{"s", []string{""}},
+	{"ed", []string{"", "e"}},
+	{"ing", []string{"", "e"}},
+	{"er", []string{"", "e"}},
+	{"ers", []string{"", "e"}},
+	{"ly", []string{"", "le"}},
+	{"able", []string{"", "e"}},
+	{"ment", []string{""}},
+	{"ments", []string{""}},
+	{"ness", []string{""}},
+}
+
+// prefixes are stripped from words that are otherwise unknown, e.g. "redeploy".
+var prefixes = []string{"re", "un", "pre", "non", "sub", "de", "auto", "multi", "over"}
+
+// stems returns the candidate stems of word.
+func stems(word string) []string {
+	var candidates []string
+	for _, inf := range inflections {
+		base, ok := strings.CutSuffix(word, inf.suffix)
+		if !ok || len(base) < 2 {
+			continue
+		}
+		for _, ending := range inf.stems {
+			candidates = append(candidates, base+ending)
+		}
+		// Doubled final consonant: "running" -> "run"
+		if n := len(base); n >= 3 && base[n-1] == base[n-2] {
+			candidates = append(candidates, base[:n-1])
+		}
+	}
+	for _, prefix := range prefixes {
+		if rest, ok := strings.CutPrefix(word, prefix); ok && len(rest) >= 3 {
+			candidates = append(candidates, rest)
+			for _, stem := range stems(rest) {
+				candidates = append(candidates, stem)
+			}
+		}
+	}
+	return candidates
+}
+
+// Suggest returns the most common dictionary word one edit away from word
+// (a deletion, insertion, substitution, or transposition), or "" if there is
+// none.
+func (d *Dictionary) Suggest(word string) string {
+	word = strings.ToLower(word)
+	best, bestRank := "", -1
+	for _, candidate := range edits(word) {
+		if rank, ok := d.rank[candidate]; ok && (bestRank < 0 || rank < bestRank) {
+			best, bestRank = candidate, rank
+		}
+	}
+	return best
+}
+
+// edits returns every string one edit away from word.
+func edits(word string) []string {
+	const letters = "abcdefghijklmnopqrstuvwxyz"
+	var result []string
+	for i := 0; i <= len(word); i++ {
+		left, right := word[:i], word[i:]
+		if right != "" {
+			result = append(result, left+right[1:])
+			for _, c := range letters {
+				result = append(result, left+string(c)+right[1:])
+			}
+		}
+		if len(right) > 1 {
+			result = append(result, left+right[1:2]+right[:1]+right[2:])
+		}
+		for _, c := range letters {
+			result = append(result, left+string(c)+right)
+		}
+	}
+	return result
+}
+
+// Misspelling is a word not found in the dictionary.
+type Misspelling struct {
+	// Word is the word as written.
+	Word string
+
+	// Suggestion is a likely correction, or "" if none was found.
+	Suggestion string
+}
+
+var (
+	// codeSpan matches inline code, which is never checked.
+	codeSpan = regexp.MustCompile("`[^`]*`")
+
+	// nonProse matches URLs, markdown link targets, make variable
+	// references, and tokens containing a path separator or an inner dot.
+	nonProse = regexp.MustCompile(`\S+://\S+|\]\([^)]*\)|\$[({][^)}]*[)}]|\S*/\S*|\S+\.\w\S*`)
+
+	// wordPattern matches candidate words, including inner apostrophes
+	// and digits or underscores so such tokens can be skipped whole.
+	wordPattern = regexp.MustCompile(`[\p{L}\d_]+(?:['’][\p{L}]+)*`)
+)
+
+// Check returns the words of text that are not in the dictionary, in order
+// of appearance and without duplicates.
+func (d *Dictionary) Check(text string) []Misspelling {
+	text = codeSpan.ReplaceAllString(text, " ")
+	text = nonProse.ReplaceAllString(text, " ")
+
+	var result []Misspelling
+	seen := make(map[string]bool)
+	for _, word := range wordPattern.FindAllString(text, -1) {
+		word = strings.ReplaceAll(word, "’", "'")
+		if skipWord(word) || seen[word] || d.Contains(word) {
+			continue
+		}
+		seen[word] = true
+		result = append(result, Misspelling{Word: word, Suggestion: d.Suggest(word)})
+	}
+	return result
+}
+
+// skipWord reports whether word should not be checked: short words,
+// identifiers (digits, underscores, inner capitals), and acronyms.
+func skipWord(word string) bool {
+	runes := []rune(word)
+	if len(runes) < 3 {
+		return true
+	}
+	for i, r := range runes {
+		if unicode.IsDigit(r) || r == '_' {
+			return true
+		}
+		if i > 0 && unicode.IsUpper(r) {
+			return true
+		}
+	}
+	return false
+}
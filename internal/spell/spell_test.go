@@ -0,0 +1,104 @@
+package spell
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+	if _, err := Load(DefaultLanguage); err != nil {
+		t.Fatalf("Load(%q) error = %v", DefaultLanguage, err)
+	}
+	if _, err := Load("xx"); err == nil {
+		t.Error("Load should fail for a language without a dictionary")
+	}
+}
+
+func TestContains(t *testing.T) {
+	t.Parallel()
+	d, err := Load("en")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, word := range []string{"the", "The", "build", "deploys", "deployed", "redeploying", "running", "dependencies", "don't", "makefile's"} {
+		if !d.Contains(word) {
+			t.Errorf("Contains(%q) = false, want true", word)
+		}
+	}
+	for _, word := range []string{"teh", "recieve", "enviroment", "seperate"} {
+		if d.Contains(word) {
+			t.Errorf("Contains(%q) = true, want false", word)
+		}
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	t.Parallel()
+	d, err := Load("en")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]string{
+		"teh":          "the",
+		"recieve":      "receive",
+		"enviroment":   "environment",
+		"depoly":       "deploy",
+		"documentaion": "documentation",
+		"qzxwv":        "",
+	}
+	for word, want := range tests {
+		if got := d.Suggest(word); got != want {
+			t.Errorf("Suggest(%q) = %q, want %q", word, got, want)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+	d, err := Load("en")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	text := "Recieve the `cnfg.yaml` file from https://exmple.com/a.b via $(MAKEFLGS), " +
+		"./scrpts/run.sh, or API_KEYZ and camelCse. Recieve it teh second time."
+	got := d.Check(text)
+	want := []Misspelling{
+		{Word: "Recieve", Suggestion: "receive"},
+		{Word: "teh", Suggestion: "the"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Check() = %+v, want %+v", got, want)
+	}
+}
+
+func TestAddFile(t *testing.T) {
+	t.Parallel()
+	d, err := Load("en")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), ProjectDictFile)
+	if err := os.WriteFile(path, []byte("# project terms\nAcmeCorp\n\nfrobnicate\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.AddFile(path); err != nil {
+		t.Fatalf("AddFile() error = %v", err)
+	}
+
+	if got := d.Check("Frobnicate the widgets for acmecorp."); len(got) != 0 {
+		t.Errorf("Check() = %+v, want no misspellings", got)
+	}
+	if !d.Contains("frobnicates") {
+		t.Error("project words should match inflected forms")
+	}
+	if err := d.AddFile(filepath.Join(t.TempDir(), "missing")); !os.IsNotExist(err) {
+		t.Errorf("AddFile() error = %v, want not-exist error", err)
+	}
+}
@@ -6,23 +6,23 @@
 //  2. Remove markdown formatting (bold, italic, code, links)
 //  3. Remove HTML tags
 //  4. Normalize whitespace
-//  5. Extract first sentence using regex
+//  5. Extract the first sentence
 //
 // # Sentence Extraction
 //
-// The sentence extraction handles edge cases like ellipsis (...) and
-// IP addresses (127.0.0.1) which should not be treated as sentence endings.
+// A sentence ends at '.', '!', or '?' followed by whitespace or the end of
+// the text. A period is not a boundary when it:
+//   - is immediately followed by another character, as in IP addresses
+//     (127.0.0.1) and version numbers (v1.2.3)
+//   - ends an ellipsis (...) followed by more text
+//   - ends a known abbreviation (e.g., i.e., vs., cf., approx., incl., esp.)
 //
-// The regex pattern used is:
+// "etc." is treated as an abbreviation unless the next word is capitalized,
+// since it often ends a sentence. Candidate boundaries are found with the
+// regex [.?!](\s|$) and then filtered by these rules.
 //
-//	^((?:[^.!?]|\.\.\.|\.[^\s])+[.?!])(\s|$)
-//
-// This matches:
-//   - Any character except .!?
-//   - OR ellipsis (...)
-//   - OR period followed by non-whitespace (e.g., IP addresses)
-//   - Ending with a sentence terminator (.!?)
-//   - Followed by whitespace or end of string
+// testdata/sentences.txt holds a regression corpus of documentation lines
+// and their expected summaries.
 //
 // # Performance
 //
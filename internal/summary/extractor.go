@@ -3,6 +3,7 @@ package summary
 import (
 	"regexp"
 	"strings"
+	"unicode"
 
 	"github.com/sdlcforge/make-help/internal/richtext"
 )
@@ -26,9 +27,10 @@ type Extractor struct {
 // NewExtractor creates an Extractor with all regex patterns pre-compiled.
 func NewExtractor() *Extractor {
 	return &Extractor{
-		// Regex from extract-topic: first sentence ending in .!?
-		// Handles: ellipsis (...), IPs (127.0.0.1.), abbreviations
-		sentenceRegex:    regexp.MustCompile(`^((?:[^.!?]|\.\.\.|\.[^\s])+[.?!])(\s|$)`),
+		// Candidate sentence boundaries: .!? followed by whitespace or end of text.
+		// Periods inside IPs and versions (127.0.0.1, v1.2) are never candidates;
+		// sentenceEnd rules out ellipses and abbreviations.
+		sentenceRegex:    regexp.MustCompile(`[.?!](\s|$)`),
 		headerRegex:      regexp.MustCompile(`(?m)^#+\s+`),
 		boldRegex:        regexp.MustCompile(`\*\*([^*]+)\*\*`),
 		italicRegex:      regexp.MustCompile(`\*([^*]+)\*`),
@@ -114,30 +116,46 @@ func (e *Extractor) ExtractPlainText(documentation []string) string {
 
 // stripMarkdownHeaders removes # headers (uses pre-compiled regex)
 func (e *Extractor) stripMarkdownHeaders(text string) string {
+	if !strings.Contains(text, "#") {
+		return text
+	}
 	return e.headerRegex.ReplaceAllString(text, "")
 }
 
 // stripMarkdownFormatting removes **bold**, *italic*, `code`, [links]
 // All regexes are pre-compiled for performance.
 // Order matters: ** before *, __ before _
+// Each regex only runs when its delimiter appears, since ReplaceAllString
+// copies the text even when nothing matches; plain text is returned as is.
 func (e *Extractor) stripMarkdownFormatting(text string) string {
 	// Remove bold/italic (order matters: ** before *, __ before _)
-	text = e.boldRegex.ReplaceAllString(text, "$1")
-	text = e.italicRegex.ReplaceAllString(text, "$1")
-	text = e.boldUnderRegex.ReplaceAllString(text, "$1")
-	text = e.italicUnderRegex.ReplaceAllString(text, "$1")
+	if strings.Contains(text, "*") {
+		text = e.boldRegex.ReplaceAllString(text, "$1")
+		text = e.italicRegex.ReplaceAllString(text, "$1")
+	}
+	if strings.Contains(text, "_") {
+		text = e.boldUnderRegex.ReplaceAllString(text, "$1")
+		text = e.italicUnderRegex.ReplaceAllString(text, "$1")
+	}
 
 	// Remove inline code
-	text = e.codeRegex.ReplaceAllString(text, "$1")
+	if strings.Contains(text, "`") {
+		text = e.codeRegex.ReplaceAllString(text, "$1")
+	}
 
 	// Remove links [text](url) -> text
-	text = e.linkRegex.ReplaceAllString(text, "$1")
+	if strings.Contains(text, "](") {
+		text = e.linkRegex.ReplaceAllString(text, "$1")
+	}
 
 	return text
 }
 
 // stripHTMLTags removes HTML tags (uses pre-compiled regex)
 func (e *Extractor) stripHTMLTags(text string) string {
+	if !strings.Contains(text, "<") {
+		return text
+	}
 	return e.htmlTagRegex.ReplaceAllString(text, "")
 }
 
@@ -147,28 +165,104 @@ func (e *Extractor) normalizeWhitespace(text string) string {
 	text = strings.ReplaceAll(text, "\n", " ")
 
 	// Collapse multiple spaces
-	text = e.whitespaceRegex.ReplaceAllString(text, " ")
+	if hasWhitespaceRun(text) {
+		text = e.whitespaceRegex.ReplaceAllString(text, " ")
+	}
 
 	return strings.TrimSpace(text)
 }
 
-// extractFirstSentence uses regex to extract first sentence.
+// hasWhitespaceRun reports whether text contains whitespace that
+// whitespaceRegex would replace: anything but single spaces.
+func hasWhitespaceRun(text string) bool {
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '\t', '\n', '\f', '\r':
+			return true
+		case ' ':
+			if i+1 < len(text) && text[i+1] == ' ' {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// abbreviations lists lowercased abbreviations whose period does not end a
+// sentence.
+var abbreviations = map[string]bool{
+	"e.g.":    true,
+	"i.e.":    true,
+	"etc.":    true,
+	"vs.":     true,
+	"cf.":     true,
+	"approx.": true,
+	"incl.":   true,
+	"esp.":    true,
+}
+
+// sentenceFinalAbbreviations may also end a sentence, which is assumed when
+// the next word is capitalized ("Caches, logs, etc. Then rebuilds.").
+var sentenceFinalAbbreviations = map[string]bool{
+	"etc.": true,
+}
+
+// extractFirstSentence extracts the first sentence of text.
 // Handles edge cases:
 //   - Ellipsis (...) is NOT a sentence boundary
-//   - IP addresses (127.0.0.1.) are NOT sentence boundaries
+//   - IP addresses (127.0.0.1.) and version numbers (v1.2) are NOT sentence boundaries
+//   - Abbreviations (e.g., i.e., vs.) are NOT sentence boundaries
 //   - Standard punctuation (.!?) followed by space or EOL IS a sentence boundary
 //
 // If no sentence terminator is found, returns the full text.
 func (e *Extractor) extractFirstSentence(text string) string {
-	matches := e.sentenceRegex.FindStringSubmatch(text)
-	if len(matches) > 1 {
-		return strings.TrimSpace(matches[1])
+	if end := e.sentenceEnd(text); end > 0 {
+		return strings.TrimSpace(text[:end])
 	}
 
 	// No sentence ending found, return full text
 	return text
 }
 
+// sentenceEnd returns the index just past the terminator of the first
+// sentence in text, or -1 if text has no sentence boundary.
+func (e *Extractor) sentenceEnd(text string) int {
+	// Candidates are found one at a time, as the first one usually ends
+	// the sentence
+	for offset := 0; offset < len(text); {
+		loc := e.sentenceRegex.FindStringIndex(text[offset:])
+		if loc == nil {
+			break
+		}
+		end := offset + loc[0] + 1
+		offset = end
+		if text[end-1] == '.' && end < len(text) {
+			if strings.HasSuffix(text[:end], "...") || isAbbreviation(text[:end], text[end:]) {
+				continue
+			}
+		}
+		return end
+	}
+	return -1
+}
+
+// isAbbreviation reports whether the word ending before (with its period)
+// is an abbreviation that continues into after.
+func isAbbreviation(before, after string) bool {
+	word := before[strings.LastIndexAny(before, " \t\n(")+1:]
+	word = strings.ToLower(word)
+	if !abbreviations[word] {
+		return false
+	}
+	if sentenceFinalAbbreviations[word] {
+		next := strings.TrimLeft(after, " \t\n")
+		if next != "" && unicode.IsUpper([]rune(next)[0]) {
+			return false
+		}
+	}
+	return true
+}
+
 // extractMatchingPortion finds the portion of originalText that corresponds
 // to the strippedSentence. This allows us to preserve formatting in the output.
 //
@@ -198,10 +292,9 @@ func (e *Extractor) extractMatchingPortion(originalText, strippedSentence string
 		return normalizedOriginal
 	}
 
-	// Use the same regex to find the sentence boundary in the normalized original
-	matches := e.sentenceRegex.FindStringSubmatch(normalizedOriginal)
-	if len(matches) > 1 {
-		return strings.TrimSpace(matches[1])
+	// Use the same rules to find the sentence boundary in the normalized original
+	if end := e.sentenceEnd(normalizedOriginal); end > 0 {
+		return strings.TrimSpace(normalizedOriginal[:end])
 	}
 
 	// Fallback: return the original text
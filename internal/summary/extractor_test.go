@@ -1,7 +1,11 @@
 package summary
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"unsafe"
 )
 
 func TestExtract(t *testing.T) {
@@ -222,17 +226,16 @@ func TestExtract(t *testing.T) {
 		},
 
 		// Edge cases with mixed content
-		// Note: Abbreviations followed by space are treated as sentence boundaries
-		// This is correct behavior per the regex spec - periods followed by space end sentences
+		// Note: Known abbreviations (e.g., i.e.) do not end sentences
 		{
 			name:     "abbreviation like e.g.",
 			docs:     []string{"Use e.g. this example. Another sentence."},
-			expected: "Use e.g.",
+			expected: "Use e.g. this example.",
 		},
 		{
 			name:     "abbreviation like i.e.",
 			docs:     []string{"This means i.e. that is. Continue."},
-			expected: "This means i.e.",
+			expected: "This means i.e. that is.",
 		},
 		{
 			name:     "file extension",
@@ -611,8 +614,8 @@ func TestExtractEdgeCases(t *testing.T) {
 			expected: "Now at 3.14.159.",
 		},
 
-		// Common abbreviations - these are treated as sentence boundaries per spec
-		// The regex spec explicitly allows periods followed by space to end sentences
+		// Titles and initialisms are treated as sentence boundaries; only the
+		// abbreviations in the abbreviations list (e.g., i.e., etc., vs.) are not
 		{
 			name:     "U.S. abbreviation",
 			docs:     []string{"Located in the U.S. Territory is large."},
@@ -636,12 +639,12 @@ func TestExtractEdgeCases(t *testing.T) {
 		{
 			name:     "e.g. abbreviation with space",
 			docs:     []string{"Examples e.g. these cases. More below."},
-			expected: "Examples e.g.",
+			expected: "Examples e.g. these cases.",
 		},
 		{
 			name:     "i.e. abbreviation with space",
 			docs:     []string{"This means i.e. exactly that. Nothing more."},
-			expected: "This means i.e.",
+			expected: "This means i.e. exactly that.",
 		},
 		{
 			name:     "etc. abbreviation",
@@ -826,3 +829,65 @@ func BenchmarkNewExtractor(b *testing.B) {
 		_ = NewExtractor()
 	}
 }
+
+// TestExtractCorpus checks first-sentence extraction against the regression
+// corpus in testdata/sentences.txt.
+func TestExtractCorpus(t *testing.T) {
+	t.Parallel()
+	data, err := os.ReadFile(filepath.Join("testdata", "sentences.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	extractor := NewExtractor()
+	for i, line := range strings.Split(string(data), "\n") {
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		doc, expected, ok := strings.Cut(line, "\t")
+		if !ok {
+			t.Fatalf("testdata/sentences.txt:%d: missing tab separator", i+1)
+		}
+		if result := extractor.ExtractPlainText([]string{doc}); result != expected {
+			t.Errorf("testdata/sentences.txt:%d: ExtractPlainText(%q) = %q, want %q", i+1, doc, result, expected)
+		}
+	}
+}
+
+func TestHasWhitespaceRun(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"", false},
+		{"single spaces only", false},
+		{" leading and trailing ", false},
+		{"two  spaces", true},
+		{"a\ttab", true},
+		{"a\nnewline", true},
+		{"trailing\r", true},
+	}
+
+	for _, tt := range tests {
+		if result := hasWhitespaceRun(tt.input); result != tt.expected {
+			t.Errorf("hasWhitespaceRun(%q) = %v, want %v", tt.input, result, tt.expected)
+		}
+	}
+}
+
+// TestExtractPlainTextSharesDocumentation checks that the summary of plain
+// single-line documentation is a substring of it rather than a copy.
+func TestExtractPlainTextSharesDocumentation(t *testing.T) {
+	t.Parallel()
+	extractor := NewExtractor()
+	doc := "Build the project. Then run the tests."
+
+	result := extractor.ExtractPlainText([]string{doc})
+	if result != "Build the project." {
+		t.Fatalf("ExtractPlainText() = %q, want %q", result, "Build the project.")
+	}
+	if unsafe.StringData(result) != unsafe.StringData(doc) {
+		t.Error("ExtractPlainText() copied the documentation")
+	}
+}
@@ -1,11 +1,13 @@
 package target
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 	"time"
 
@@ -47,11 +49,11 @@ func NewAddService(config *Config, executor discovery.CommandExecutor, verbose b
 //  1. Use explicit --help-file-rel-path if specified (needs include directive)
 //  2. Create make/01-help.mk if include make/*.mk pattern found (no include needed)
 //  3. Otherwise create help.mk in same directory as Makefile (needs include directive)
-func (s *AddService) AddTarget() error {
+func (s *AddService) AddTarget(ctx context.Context) error {
 	makefilePath := s.config.MakefilePath
 
 	// Validate Makefile syntax before modifying
-	if err := s.validateMakefile(makefilePath); err != nil {
+	if err := s.validateMakefile(ctx, makefilePath); err != nil {
 		return fmt.Errorf("makefile validation failed: %w", err)
 	}
 
@@ -88,13 +90,14 @@ func (s *AddService) AddTarget() error {
 }
 
 // validateMakefile runs `make -n` to check for syntax errors.
-func (s *AddService) validateMakefile(makefilePath string) error {
-	return ValidateMakefile(s.executor, makefilePath)
+func (s *AddService) validateMakefile(ctx context.Context, makefilePath string) error {
+	return ValidateMakefile(ctx, s.executor, makefilePath)
 }
 
-// ValidateMakefile runs `make -n` to check for syntax errors.
-func ValidateMakefile(executor discovery.CommandExecutor, makefilePath string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), makeValidationTimeout)
+// ValidateMakefile runs `make -n` to check for syntax errors. Canceling ctx
+// stops make and returns the context's error.
+func ValidateMakefile(ctx context.Context, executor discovery.CommandExecutor, makefilePath string) error {
+	ctx, cancel := context.WithTimeout(ctx, makeValidationTimeout)
 	defer cancel()
 
 	// Run make -n (dry-run) to check syntax without executing recipes
@@ -103,6 +106,9 @@ func ValidateMakefile(executor discovery.CommandExecutor, makefilePath string) e
 		if ctx.Err() == context.DeadlineExceeded {
 			return fmt.Errorf("validation timed out")
 		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
 		return fmt.Errorf("syntax error in Makefile:\n%s", stderr)
 	}
 	return nil
@@ -199,6 +205,19 @@ func determineTargetFileImpl(makefilePath, explicitRelPath string, createDirs bo
 	return targetPath, needsInclude, nil
 }
 
+// IncludeSuffix returns the file suffix included by the Makefile's make/*
+// include pattern (e.g. ".mk"), or ".mk" when there is none.
+func IncludeSuffix(makefilePath string) (string, error) {
+	content, err := os.ReadFile(makefilePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read Makefile: %w", err)
+	}
+	if pattern := findMakeIncludePattern(content); pattern != nil {
+		return pattern.Suffix, nil
+	}
+	return ".mk", nil
+}
+
 // findMakeIncludePattern scans Makefile content for include directives matching make/*
 // Returns nil if no matching pattern found.
 func findMakeIncludePattern(content []byte) *IncludePattern {
@@ -364,6 +383,10 @@ func ExtractCommandLineFromHelpFile(filePath string) (string, error) {
 	return "", nil // No command line found
 }
 
+// IncludeMarker precedes each include directive make-help adds to a
+// Makefile.
+const IncludeMarker = "# make-help:include"
+
 // addIncludeDirective injects an include statement into the Makefile using atomic write.
 func (s *AddService) addIncludeDirective(makefilePath, targetFile string) error {
 	return AddIncludeDirective(makefilePath, targetFile)
@@ -379,11 +402,33 @@ func (s *AddService) addIncludeDirective(makefilePath, targetFile string) error
 // If an include directive for this file already exists (either include or -include),
 // no changes are made.
 func AddIncludeDirective(makefilePath, targetFile string) error {
-	content, err := os.ReadFile(makefilePath)
+	content, newContent, err := IncludeDirectiveContent(makefilePath, targetFile)
 	if err != nil {
 		return err
 	}
+	if bytes.Equal(content, newContent) {
+		return nil
+	}
+
+	// Use atomic write to prevent corruption
+	return AtomicWriteFile(makefilePath, newContent, 0644)
+}
+
+// IncludeDirectiveContent returns the current content of the Makefile and
+// the content AddIncludeDirective would write to include targetFile. They
+// are equal when the Makefile already includes it.
+func IncludeDirectiveContent(makefilePath, targetFile string) (content, newContent []byte, err error) {
+	content, err = os.ReadFile(makefilePath)
+	if err != nil {
+		return nil, nil, err
+	}
+	return content, WithIncludeDirective(content, makefilePath, targetFile), nil
+}
 
+// WithIncludeDirective returns the Makefile content with the include
+// directive for targetFile appended, or content itself when it already
+// includes it.
+func WithIncludeDirective(content []byte, makefilePath, targetFile string) []byte {
 	makefileDir := filepath.Dir(makefilePath)
 
 	// Compute relative path from Makefile directory to target file
@@ -401,7 +446,7 @@ func AddIncludeDirective(makefilePath, targetFile string) error {
 		pattern := findMakeIncludePattern(content)
 		if pattern != nil {
 			// Pattern already exists, no need to add include directive
-			return nil
+			return content
 		}
 
 		// Check if an include directive already exists for make/*.mk pattern
@@ -411,13 +456,11 @@ func AddIncludeDirective(makefilePath, targetFile string) error {
 		patternIncludeRegex := regexp.MustCompile(`(?m)^-?include\s+(?:\./)?make/\*\.mk\s*$`)
 		if patternIncludeRegex.Match(content) {
 			// Pattern include already exists, nothing to do
-			return nil
+			return content
 		}
 
 		// No pattern found, add -include make/*.mk
-		includeDirective := "\n-include make/*.mk\n"
-		newContent := append(content, []byte(includeDirective)...)
-		return AtomicWriteFile(makefilePath, newContent, 0644)
+		return slices.Concat(content, []byte(markedInclude("make/*.mk")))
 	}
 
 	// Target is not in make/ directory - add specific file include
@@ -430,16 +473,17 @@ func AddIncludeDirective(makefilePath, targetFile string) error {
 	existingIncludeRegex := regexp.MustCompile(includePattern)
 	if existingIncludeRegex.Match(content) {
 		// Include directive already exists, nothing to do
-		return nil
+		return content
 	}
 
 	// Use self-referential include pattern that works from any directory
 	// Using -include (optional include) allows users to delete help.mk and regenerate via make
-	includeDirective := fmt.Sprintf("\n-include $(dir $(lastword $(MAKEFILE_LIST)))%s\n", relPath)
-
 	// Append to end of file
-	newContent := append(content, []byte(includeDirective)...)
+	return slices.Concat(content, []byte(markedInclude("$(dir $(lastword $(MAKEFILE_LIST)))"+relPath)))
+}
 
-	// Use atomic write to prevent corruption
-	return AtomicWriteFile(makefilePath, newContent, 0644)
+// markedInclude returns the block WithIncludeDirective appends to include
+// path, headed by IncludeMarker so removal can restore the Makefile exactly.
+func markedInclude(path string) string {
+	return "\n" + IncludeMarker + "\n-include " + path + "\n"
 }
@@ -70,7 +70,7 @@ test:
 	service := NewAddService(config, executor, false)
 
 	// Execute
-	err = service.AddTarget()
+	err = service.AddTarget(context.Background())
 	require.NoError(t, err)
 
 	// Verify make/help.mk was created
@@ -120,7 +120,7 @@ all:
 	service := NewAddService(config, executor, false)
 
 	// Execute
-	err = service.AddTarget()
+	err = service.AddTarget(context.Background())
 	require.NoError(t, err)
 
 	// Verify make directory was created
@@ -176,7 +176,7 @@ func TestAddService_AddTarget_ExplicitTargetFile(t *testing.T) {
 	service := NewAddService(config, executor, false)
 
 	// Execute
-	err = service.AddTarget()
+	err = service.AddTarget(context.Background())
 	require.NoError(t, err)
 
 	// Verify target file was created (absolute path computed from relative)
@@ -248,7 +248,7 @@ func TestAddService_AddTarget_FlagPassThrough(t *testing.T) {
 			service := NewAddService(tt.config, executor, false)
 
 			// Execute
-			err := service.AddTarget()
+			err := service.AddTarget(context.Background())
 			require.NoError(t, err)
 
 			// Read generated content
@@ -286,7 +286,7 @@ func TestAddService_ValidateMakefile_SyntaxError(t *testing.T) {
 	service := NewAddService(config, executor, false)
 
 	// Execute
-	err = service.AddTarget()
+	err = service.AddTarget(context.Background())
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "makefile validation failed")
 }
@@ -312,7 +312,7 @@ func TestAddService_VerboseOutput(t *testing.T) {
 	service := NewAddService(config, executor, true)
 
 	// Execute (should print verbose output to stdout)
-	err = service.AddTarget()
+	err = service.AddTarget(context.Background())
 	require.NoError(t, err)
 }
 
@@ -331,7 +331,7 @@ func TestAddService_DetermineTargetFileReadError(t *testing.T) {
 	service := NewAddService(config, executor, false)
 
 	// Execute should fail when trying to read non-existent Makefile
-	err := service.AddTarget()
+	err := service.AddTarget(context.Background())
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to read Makefile")
 }
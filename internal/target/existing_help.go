@@ -0,0 +1,144 @@
+package target
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Markers around a hand-written help target commented out by
+// --replace-existing-help. Each line between them is the original line
+// prefixed with "# ".
+const (
+	ReplacedHelpBeginMarker = "# make-help:replaced-help-target begin"
+	ReplacedHelpEndMarker   = "# make-help:replaced-help-target end"
+)
+
+// FindHelpTarget returns the file and 1-based line of the rule defining the
+// target name in makefiles, skipping files generated by make-help. Returns
+// an empty file when no Makefile defines it. Only rules whose single target
+// is name are found; "name := value" assignments are not rules.
+func FindHelpTarget(makefiles []string, name string) (string, int, error) {
+	ruleRegex := helpRuleRegex(name)
+	for _, makefile := range makefiles {
+		if isGeneratedByMakeHelp(makefile) {
+			continue
+		}
+		content, err := os.ReadFile(makefile)
+		if err != nil {
+			return "", 0, fmt.Errorf("failed to read %s: %w", makefile, err)
+		}
+
+		scanner := bufio.NewScanner(bytes.NewReader(content))
+		lineNum := 0
+		for scanner.Scan() {
+			lineNum++
+			if ruleRegex.MatchString(scanner.Text()) {
+				return makefile, lineNum, nil
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return "", 0, fmt.Errorf("failed to read %s: %w", makefile, err)
+		}
+	}
+	return "", 0, nil
+}
+
+// helpRuleRegex matches the first line of a rule, single- or double-colon,
+// whose only target is name.
+func helpRuleRegex(name string) *regexp.Regexp {
+	return regexp.MustCompile(`^` + regexp.QuoteMeta(name) + `\s*::?(?:[^=]|$)`)
+}
+
+// ReplaceHelpTarget comments out the hand-written help target whose rule
+// starts at line of makefilePath, using atomic write.
+func ReplaceHelpTarget(makefilePath string, line int) error {
+	content, err := os.ReadFile(makefilePath)
+	if err != nil {
+		return err
+	}
+	newContent, err := CommentOutHelpTarget(content, line)
+	if err != nil {
+		return fmt.Errorf("failed to replace help target in %s: %w", makefilePath, err)
+	}
+	return AtomicWriteFile(makefilePath, newContent, 0644)
+}
+
+// CommentOutHelpTarget returns content with the rule starting at the
+// 1-based line commented out between ReplacedHelpBeginMarker and
+// ReplacedHelpEndMarker. The block includes the "##" documentation lines
+// directly above the rule, which would otherwise document the next target,
+// and the rule's recipe and continuation lines.
+func CommentOutHelpTarget(content []byte, line int) ([]byte, error) {
+	lines := strings.SplitAfter(string(content), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if line < 1 || line > len(lines) {
+		return nil, fmt.Errorf("line %d is out of range", line)
+	}
+
+	start := line - 1
+	for start > 0 && strings.HasPrefix(lines[start-1], "##") {
+		start--
+	}
+	end := line
+	for end < len(lines) {
+		previous := strings.TrimRight(lines[end-1], "\r\n")
+		if !strings.HasPrefix(lines[end], "\t") && !strings.HasSuffix(previous, "\\") {
+			break
+		}
+		end++
+	}
+
+	var buf strings.Builder
+	for _, l := range lines[:start] {
+		buf.WriteString(l)
+	}
+	buf.WriteString(ReplacedHelpBeginMarker + "\n")
+	for _, l := range lines[start:end] {
+		buf.WriteString("# " + l)
+		if !strings.HasSuffix(l, "\n") {
+			buf.WriteString("\n")
+		}
+	}
+	buf.WriteString(ReplacedHelpEndMarker + "\n")
+	for _, l := range lines[end:] {
+		buf.WriteString(l)
+	}
+	return []byte(buf.String()), nil
+}
+
+// restoreReplacedHelpTargets uncomments the help targets that
+// CommentOutHelpTarget commented out in content and drops their markers.
+// Returns true if any were restored. A begin marker without an end marker
+// is left as is.
+func restoreReplacedHelpTargets(content string) (string, bool) {
+	lines := strings.SplitAfter(content, "\n")
+	var buf strings.Builder
+	restored := false
+
+	for i := 0; i < len(lines); i++ {
+		if strings.TrimRight(lines[i], "\r\n") != ReplacedHelpBeginMarker {
+			buf.WriteString(lines[i])
+			continue
+		}
+		end := i + 1
+		for end < len(lines) && strings.TrimRight(lines[end], "\r\n") != ReplacedHelpEndMarker {
+			end++
+		}
+		if end == len(lines) {
+			buf.WriteString(lines[i])
+			continue
+		}
+		for _, l := range lines[i+1 : end] {
+			buf.WriteString(strings.TrimPrefix(l, "# "))
+		}
+		i = end
+		restored = true
+	}
+	return buf.String(), restored
+}
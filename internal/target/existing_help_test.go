@@ -0,0 +1,80 @@
+package target
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindHelpTarget(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(tmpDir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		return path
+	}
+	assignment := write("vars.mk", "help := no\nhelp-build:\n\t@true\n")
+	generated := write("help.mk", "# generated-by: make-help\nhelp:\n\t@true\n")
+	handWritten := write("Makefile", "build:\n\t@true\n\n.PHONY: help\nhelp: ## Show help\n\t@echo help\n")
+	doubleColon := write("usage.mk", "usage::\n\t@echo usage\n")
+
+	tests := []struct {
+		name      string
+		makefiles []string
+		target    string
+		wantFile  string
+		wantLine  int
+	}{
+		{"skips assignments and generated files", []string{assignment, generated, handWritten}, "help", handWritten, 5},
+		{"none defined", []string{assignment, generated}, "help", "", 0},
+		{"double-colon rule", []string{handWritten, doubleColon}, "usage", doubleColon, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			file, line, err := FindHelpTarget(tt.makefiles, tt.target)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantFile, file)
+			assert.Equal(t, tt.wantLine, line)
+		})
+	}
+}
+
+func TestCommentOutHelpTarget(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		content string
+		line    int
+		want    string
+	}{
+		{
+			name:    "documentation and recipe",
+			content: "build:\n\t@true\n\n## Show help.\nhelp:\n\t@echo one\n\t@echo two\n\ntest:\n",
+			line:    5,
+			want: "build:\n\t@true\n\n" + ReplacedHelpBeginMarker + "\n# ## Show help.\n# help:\n# \t@echo one\n# \t@echo two\n" +
+				ReplacedHelpEndMarker + "\n\ntest:\n",
+		},
+		{
+			name:    "continued prerequisites at end of file",
+			content: "help: a \\\n  b\n\t@echo help",
+			line:    1,
+			want:    ReplacedHelpBeginMarker + "\n# help: a \\\n#   b\n# \t@echo help\n" + ReplacedHelpEndMarker + "\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := CommentOutHelpTarget([]byte(tt.content), tt.line)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+
+	_, err := CommentOutHelpTarget([]byte("help:\n"), 2)
+	assert.Error(t, err)
+}
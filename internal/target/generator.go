@@ -19,6 +19,56 @@ type GeneratorConfig struct {
 	DefaultCategory     string
 	IncludeTargets      []string
 	IncludeAllPhony     bool
+	Profile             string
+
+	// GroupBy is "file" when targets are grouped by source file;
+	// any other value means the default grouping by category.
+	GroupBy string
+
+	// OnlyFiles lists the --only-file patterns the model was filtered by.
+	OnlyFiles []string
+
+	// ExcludeTargets and ExcludeFiles list the --exclude-target and
+	// --exclude-file patterns. Patterns from .make-help.json are not
+	// repeated here; regeneration reads the file again.
+	ExcludeTargets []string
+	ExcludeFiles   []string
+
+	// EntryPoint mirrors --entry-point.
+	EntryPoint string
+
+	// ResolveRemote mirrors --resolve-remote.
+	ResolveRemote bool
+
+	// BestEffort mirrors --best-effort.
+	BestEffort bool
+
+	// NoRedact and RedactPatterns mirror --no-redact and --redact-pattern.
+	NoRedact       bool
+	RedactPatterns []string
+
+	// MaxTargetsPerCategory limits the targets listed per category by the help
+	// target; help-full lists them all. Zero disables the limit.
+	MaxTargetsPerCategory int
+
+	// SummaryWidth truncates target summaries in the help listings to at
+	// most this many characters. Zero disables truncation.
+	SummaryWidth int
+
+	// ConsolidateVariables lists variables documented by more than one
+	// target once, in a Variables section of the help listings.
+	ConsolidateVariables bool
+
+	// ShowStats mirrors --show-stats; Undocumented is the number of
+	// undocumented .PHONY targets the help model left out.
+	ShowStats    bool
+	Undocumented int
+
+	// NoIncludedFiles, IncludedFilesPosition, and FileDocsDepth mirror
+	// --no-included-files, --included-files-position, and --file-docs-depth.
+	NoIncludedFiles       bool
+	IncludedFilesPosition string
+	FileDocsDepth         int
 
 	// UseColor controls whether ANSI color codes are embedded in the output
 	UseColor bool
@@ -34,10 +84,19 @@ type GeneratorConfig struct {
 	// If empty, mirrors the original invocation options (minus --makefile-path).
 	UpdateOpts string
 
+	// RegenTarget adds a help-regen target and a rule that re-runs make-help
+	// when any of Makefiles is newer than the help file.
+	RegenTarget bool
+
 	// HelpCategory is the category name for generated help targets (help, update-help).
 	// Defaults to "Help" if empty.
 	HelpCategory string
 
+	// HelpTargetName is the name of the generated help target, for
+	// Makefiles that keep a hand-written help target. Defaults to "help"
+	// if empty.
+	HelpTargetName string
+
 	// Makefiles is the list of discovered Makefiles for dependency tracking
 	Makefiles []string
 
@@ -47,6 +106,10 @@ type GeneratorConfig struct {
 	// MakefileDir is the directory containing the main Makefile (for relative paths)
 	MakefileDir string
 
+	// HelpFileDir is the directory the help file is written to (e.g. make/).
+	// Generated paths are relative to it. Empty means MakefileDir.
+	HelpFileDir string
+
 	// HelpFilename is the basename of the help file (e.g., "help.mk", "00-help.mk")
 	HelpFilename string
 
@@ -65,8 +128,16 @@ func GenerateHelpFile(config *GeneratorConfig) (string, error) {
 	// Create formatter with color configuration
 	// We use the LineRenderer interface to decouple from the concrete MakeFormatter type
 	var renderer format.LineRenderer = format.NewMakeFormatter(&format.FormatterConfig{
-		UseColor:    config.UseColor,
-		MakefileDir: config.MakefileDir,
+		UseColor:              config.UseColor,
+		MakefileDir:           config.MakefileDir,
+		MaxTargetsPerCategory: config.MaxTargetsPerCategory,
+		SummaryWidth:          config.SummaryWidth,
+		ConsolidateVariables:  config.ConsolidateVariables,
+		NoIncludedFiles:       config.NoIncludedFiles,
+		IncludedFilesPosition: config.IncludedFilesPosition,
+		FileDocsDepth:         config.FileDocsDepth,
+		Stats:                 generatorStats(config),
+		FullHelpCommand:       "make " + fullHelpTargetName(config),
 	})
 
 	// Header with new format
@@ -85,7 +156,7 @@ func GenerateHelpFile(config *GeneratorConfig) (string, error) {
 	buf.WriteString("MAKE_HELP_DIR := $(dir $(lastword $(MAKEFILE_LIST)))\n")
 
 	// Makefile dependencies
-	relativeMakefiles := relativizeMakefilePaths(config.Makefiles, config.MakefileDir)
+	relativeMakefiles := relativizeMakefilePaths(config.Makefiles, helpFileDir(config))
 	if len(relativeMakefiles) > 0 {
 		fmt.Fprintf(&buf, "MAKE_HELP_MAKEFILES := %s\n", strings.Join(relativeMakefiles, " "))
 	}
@@ -112,6 +183,13 @@ func GenerateHelpFile(config *GeneratorConfig) (string, error) {
 	buf.WriteString("\n")
 	buf.WriteString(generateRegenerationTarget(config))
 
+	// Required variable checks come after the help targets, so their rules
+	// never become the default goal of a Makefile including help.mk first
+	if checks := generateRequiredVarChecks(config.HelpModel); checks != "" {
+		buf.WriteString("\n")
+		buf.WriteString(strings.TrimSuffix(checks, "\n"))
+	}
+
 	return buf.String(), nil
 }
 
@@ -125,9 +203,7 @@ func generateStaticTargets(config *GeneratorConfig, renderer format.LineRenderer
 		}
 		fmt.Fprintf(buf, "## !category %s\n", helpCategory)
 	}
-	buf.WriteString(".PHONY: help\n")
-	buf.WriteString("## Displays help for available targets.\n")
-	buf.WriteString("help:\n")
+	writeHelpTargetHeader(config, buf)
 
 	// Add timestamp check to warn if help.mk may be stale
 	helpFilename := config.HelpFilename
@@ -154,6 +230,30 @@ func generateStaticTargets(config *GeneratorConfig, renderer format.LineRenderer
 		fmt.Fprintf(buf, "\t@printf '%%b\\n' \"%s\"\n", line)
 	}
 
+	// Generate help-full, listing every target, when help is limited
+	if config.MaxTargetsPerCategory > 0 {
+		fullRenderer := format.NewMakeFormatter(&format.FormatterConfig{
+			UseColor:              config.UseColor,
+			MakefileDir:           config.MakefileDir,
+			SummaryWidth:          config.SummaryWidth,
+			ConsolidateVariables:  config.ConsolidateVariables,
+			NoIncludedFiles:       config.NoIncludedFiles,
+			IncludedFilesPosition: config.IncludedFilesPosition,
+			FileDocsDepth:         config.FileDocsDepth,
+			Stats:                 generatorStats(config),
+		})
+		fullLines, err := fullRenderer.RenderHelpLines(config.HelpModel)
+		if err != nil {
+			return fmt.Errorf("failed to render full help content: %w", err)
+		}
+
+		buf.WriteString("\n")
+		writeFullHelpHeader(config, buf)
+		for _, line := range fullLines {
+			fmt.Fprintf(buf, "\t@printf '%%b\\n' \"%s\"\n", line)
+		}
+	}
+
 	// Generate help-<target> targets for each documented target
 	for _, category := range config.HelpModel.Categories {
 		for _, target := range category.Targets {
@@ -171,13 +271,30 @@ func generateStaticTargets(config *GeneratorConfig, renderer format.LineRenderer
 	return nil
 }
 
+// generatorStats returns the target inventory shown in the help listings,
+// or nil without ShowStats.
+func generatorStats(config *GeneratorConfig) *format.Stats {
+	if !config.ShowStats {
+		return nil
+	}
+	return format.NewStats(config.HelpModel, config.Undocumented)
+}
+
 // generateDynamicTargets generates help targets that execute make-help on the fly
 // with a static no-color fallback.
 func generateDynamicTargets(config *GeneratorConfig, renderer format.LineRenderer, buf *strings.Builder) error {
 	// Create a no-color renderer for the static fallback text
 	noColorRenderer := format.NewMakeFormatter(&format.FormatterConfig{
-		UseColor:    false,
-		MakefileDir: config.MakefileDir,
+		UseColor:              false,
+		MakefileDir:           config.MakefileDir,
+		MaxTargetsPerCategory: config.MaxTargetsPerCategory,
+		SummaryWidth:          config.SummaryWidth,
+		ConsolidateVariables:  config.ConsolidateVariables,
+		NoIncludedFiles:       config.NoIncludedFiles,
+		IncludedFilesPosition: config.IncludedFilesPosition,
+		FileDocsDepth:         config.FileDocsDepth,
+		Stats:                 generatorStats(config),
+		FullHelpCommand:       "make " + fullHelpTargetName(config),
 	})
 
 	// Category directive for help target
@@ -188,31 +305,67 @@ func generateDynamicTargets(config *GeneratorConfig, renderer format.LineRendere
 		}
 		fmt.Fprintf(buf, "## !category %s\n", helpCategory)
 	}
-	buf.WriteString(".PHONY: help\n")
-	buf.WriteString("## Displays help for available targets.\n")
-	buf.WriteString("help:\n")
+	writeHelpTargetHeader(config, buf)
 
 	// Dynamic execution with fallback
-	buf.WriteString("\t@make-help --makefile-path $(MAKE_HELP_DIR)Makefile --output - $(MAKE_HELP_OPTS) 2>/dev/null || \\\n")
-	buf.WriteString("\t npx --yes make-help --makefile-path $(MAKE_HELP_DIR)Makefile --output - $(MAKE_HELP_OPTS) 2>/dev/null || { \\\n")
+	widthFlag := ""
+	if config.SummaryWidth > 0 {
+		widthFlag = fmt.Sprintf(" --summary-width %d", config.SummaryWidth)
+	}
+	varsFlag := ""
+	if config.ConsolidateVariables {
+		varsFlag = " --consolidate-vars"
+	}
+	limitFlag := ""
+	if config.MaxTargetsPerCategory > 0 {
+		limitFlag = fmt.Sprintf(" --max-targets-per-category %d --full-help-target %s", config.MaxTargetsPerCategory, fullHelpTargetName(config))
+	}
+	statsFlag := ""
+	if config.ShowStats {
+		statsFlag = " --show-stats"
+	}
+	filesFlags := ""
+	if config.NoIncludedFiles {
+		filesFlags += " --no-included-files"
+	}
+	if config.IncludedFilesPosition == "bottom" {
+		filesFlags += " --included-files-position bottom"
+	}
+	if config.FileDocsDepth > 0 {
+		filesFlags += fmt.Sprintf(" --file-docs-depth %d", config.FileDocsDepth)
+	}
+	writeDynamicHelpInvocation(buf, config, limitFlag+widthFlag+varsFlag+statsFlag+filesFlags)
 
 	// Generate static fallback lines (always no-color)
 	fallbackLines, err := noColorRenderer.RenderHelpLines(config.HelpModel)
 	if err != nil {
 		return fmt.Errorf("failed to render fallback help content: %w", err)
 	}
-
 	// Insert warning after the usage line and its following blank line
-	fallbackWithWarning := insertDynamicWarning(fallbackLines, config.NoDynamicWarning)
-
-	for i, line := range fallbackWithWarning {
-		if i < len(fallbackWithWarning)-1 {
-			fmt.Fprintf(buf, "\t  printf '%%b\\n' \"%s\"; \\\n", line)
-		} else {
-			fmt.Fprintf(buf, "\t  printf '%%b\\n' \"%s\"; \\\n", line)
+	writeDynamicFallback(buf, insertDynamicWarning(fallbackLines, config.NoDynamicWarning))
+
+	// Generate help-full, listing every target, when help is limited
+	if config.MaxTargetsPerCategory > 0 {
+		fullRenderer := format.NewMakeFormatter(&format.FormatterConfig{
+			UseColor:              false,
+			MakefileDir:           config.MakefileDir,
+			SummaryWidth:          config.SummaryWidth,
+			ConsolidateVariables:  config.ConsolidateVariables,
+			NoIncludedFiles:       config.NoIncludedFiles,
+			IncludedFilesPosition: config.IncludedFilesPosition,
+			FileDocsDepth:         config.FileDocsDepth,
+			Stats:                 generatorStats(config),
+		})
+		fullLines, err := fullRenderer.RenderHelpLines(config.HelpModel)
+		if err != nil {
+			return fmt.Errorf("failed to render full fallback help content: %w", err)
 		}
+
+		buf.WriteString("\n")
+		writeFullHelpHeader(config, buf)
+		writeDynamicHelpInvocation(buf, config, widthFlag+varsFlag+statsFlag+filesFlags)
+		writeDynamicFallback(buf, insertDynamicWarning(fullLines, config.NoDynamicWarning))
 	}
-	buf.WriteString("\t}\n")
 
 	// Generate dynamic help-<target> targets
 	for _, category := range config.HelpModel.Categories {
@@ -222,8 +375,8 @@ func generateDynamicTargets(config *GeneratorConfig, renderer format.LineRendere
 			fmt.Fprintf(buf, "help-%s:\n", target.Name)
 
 			// Dynamic execution
-			fmt.Fprintf(buf, "\t@make-help --makefile-path $(MAKE_HELP_DIR)Makefile --output - --target %s $(MAKE_HELP_OPTS) 2>/dev/null || \\\n", target.Name)
-			fmt.Fprintf(buf, "\t npx --yes make-help --makefile-path $(MAKE_HELP_DIR)Makefile --output - --target %s $(MAKE_HELP_OPTS) 2>/dev/null || { \\\n", target.Name)
+			fmt.Fprintf(buf, "\t@make-help --makefile-path %s --output - --target %s $(MAKE_HELP_OPTS) 2>/dev/null || \\\n", mainMakefileRef(config), target.Name)
+			fmt.Fprintf(buf, "\t npx --yes make-help --makefile-path %s --output - --target %s $(MAKE_HELP_OPTS) 2>/dev/null || { \\\n", mainMakefileRef(config), target.Name)
 
 			// Static fallback for this target (no-color)
 			detailedLines := noColorRenderer.RenderDetailedTargetLines(&target)
@@ -237,6 +390,101 @@ func generateDynamicTargets(config *GeneratorConfig, renderer format.LineRendere
 	return nil
 }
 
+// writeHelpTargetHeader writes the declaration of the help target.
+func writeHelpTargetHeader(config *GeneratorConfig, buf *strings.Builder) {
+	name := helpTargetName(config)
+	fmt.Fprintf(buf, ".PHONY: %s\n", name)
+	buf.WriteString("## Displays help for available targets.\n")
+	fmt.Fprintf(buf, "%s:\n", name)
+}
+
+// helpTargetName returns the name of the generated help target.
+func helpTargetName(config *GeneratorConfig) string {
+	if config.HelpTargetName == "" {
+		return "help"
+	}
+	return config.HelpTargetName
+}
+
+// fullHelpTargetName returns the generated target that lists every target
+// when the help target is limited by --max-targets-per-category: help-full,
+// or <name>-full for a help target renamed with --help-target-name.
+func fullHelpTargetName(config *GeneratorConfig) string {
+	return helpTargetName(config) + "-full"
+}
+
+// writeFullHelpHeader writes the declaration of the help-full target.
+func writeFullHelpHeader(config *GeneratorConfig, buf *strings.Builder) {
+	if config.HelpModel.HasCategories {
+		helpCategory := config.HelpCategory
+		if helpCategory == "" {
+			helpCategory = "Help"
+		}
+		fmt.Fprintf(buf, "## !category %s\n", helpCategory)
+	}
+	name := fullHelpTargetName(config)
+	fmt.Fprintf(buf, ".PHONY: %s\n", name)
+	buf.WriteString("## Displays help for all targets, without the per-category limit.\n")
+	fmt.Fprintf(buf, "%s:\n", name)
+}
+
+// writeDynamicHelpInvocation writes the make-help (then npx) call that opens a
+// dynamic help recipe; extraFlags are passed before $(MAKE_HELP_OPTS).
+func writeDynamicHelpInvocation(buf *strings.Builder, config *GeneratorConfig, extraFlags string) {
+	makefile := mainMakefileRef(config)
+	fmt.Fprintf(buf, "\t@make-help --makefile-path %s --output -%s $(MAKE_HELP_OPTS) 2>/dev/null || \\\n", makefile, extraFlags)
+	fmt.Fprintf(buf, "\t npx --yes make-help --makefile-path %s --output -%s $(MAKE_HELP_OPTS) 2>/dev/null || { \\\n", makefile, extraFlags)
+}
+
+// writeDynamicFallback writes the static lines printed when dynamic
+// execution fails, closing the block opened by writeDynamicHelpInvocation.
+func writeDynamicFallback(buf *strings.Builder, lines []string) {
+	for _, line := range lines {
+		fmt.Fprintf(buf, "\t  printf '%%b\\n' \"%s\"; \\\n", line)
+	}
+	buf.WriteString("\t}\n")
+}
+
+// generateRequiredVarChecks generates a check for each target with required
+// variables: an order-only prerequisite of the target (and its aliases)
+// whose recipe stops make, listing every missing variable, if any required
+// variable is empty. The recipe is expanded after all Makefiles are read,
+// so variables assigned after the help file's include are seen, and runs
+// before the target's own recipe. Dry runs (make -n, as --preview and
+// Makefile validation use) skip the check.
+func generateRequiredVarChecks(helpModel *model.HelpModel) string {
+	var buf strings.Builder
+	for _, category := range helpModel.Categories {
+		for _, target := range category.Targets {
+			var required []string
+			for _, v := range target.Variables {
+				if v.Required {
+					required = append(required, v.Name)
+				}
+			}
+			if len(required) == 0 {
+				continue
+			}
+
+			check := RequiredVarCheckTarget(target.Name)
+			goals := append([]string{target.Name}, target.Aliases...)
+			fmt.Fprintf(&buf, "%s: | %s\n", strings.Join(goals, " "), check)
+			fmt.Fprintf(&buf, "%s:\n", check)
+			buf.WriteString("\t$(if $(findstring n,$(firstword -$(MAKEFLAGS))),,")
+			fmt.Fprintf(&buf, "$(eval MAKE_HELP_MISSING := $(strip $(foreach v,%s,$(if $($(v)),,$(v)))))", strings.Join(required, " "))
+			fmt.Fprintf(&buf, "$(if $(MAKE_HELP_MISSING),$(error make %s: missing required variable(s): $(MAKE_HELP_MISSING))))\n", target.Name)
+			buf.WriteString("\n")
+		}
+	}
+	return buf.String()
+}
+
+// RequiredVarCheckTarget returns the name of the generated target checking
+// the required variables of targetName.
+func RequiredVarCheckTarget(targetName string) string {
+	return "make-help-require-" + targetName
+}
+
 // insertDynamicWarning inserts the dynamic fallback warning after the usage line
 // and its following blank line. If suppressWarning is true, returns lines unchanged.
 func insertDynamicWarning(lines []string, suppressWarning bool) []string {
@@ -304,6 +552,9 @@ func buildRegenerateFlags(config *GeneratorConfig) string {
 	if config.DefaultCategory != "" {
 		flags = append(flags, fmt.Sprintf("--default-category %s", config.DefaultCategory))
 	}
+	if config.BestEffort {
+		flags = append(flags, "--best-effort")
+	}
 
 	// Add include targets
 	if len(config.IncludeTargets) > 0 {
@@ -317,11 +568,83 @@ func buildRegenerateFlags(config *GeneratorConfig) string {
 		flags = append(flags, "--include-all-phony")
 	}
 
+	// Add profile
+	if config.Profile != "" {
+		flags = append(flags, fmt.Sprintf("--profile %s", config.Profile))
+	}
+
+	// Add file filters
+	for _, pattern := range config.OnlyFiles {
+		flags = append(flags, "--only-file "+quoteRecipeArg(pattern))
+	}
+	for _, pattern := range config.ExcludeTargets {
+		flags = append(flags, "--exclude-target "+quoteRecipeArg(pattern))
+	}
+	if config.EntryPoint != "" {
+		flags = append(flags, "--entry-point "+quoteRecipeArg(config.EntryPoint))
+	}
+	for _, pattern := range config.ExcludeFiles {
+		flags = append(flags, "--exclude-file "+quoteRecipeArg(pattern))
+	}
+
+	if config.ResolveRemote {
+		flags = append(flags, "--resolve-remote")
+	}
+
+	// Add redaction settings
+	if config.NoRedact {
+		flags = append(flags, "--no-redact")
+	}
+	for _, pattern := range config.RedactPatterns {
+		flags = append(flags, "--redact-pattern "+quoteRecipeArg(pattern))
+	}
+
+	// Add grouping if not default
+	if config.GroupBy == "file" {
+		flags = append(flags, "--group-by file")
+	}
+
+	// Add per-category limit
+	if config.MaxTargetsPerCategory > 0 {
+		flags = append(flags, fmt.Sprintf("--max-targets-per-category %d", config.MaxTargetsPerCategory))
+	}
+
+	// Add summary width
+	if config.SummaryWidth > 0 {
+		flags = append(flags, fmt.Sprintf("--summary-width %d", config.SummaryWidth))
+	}
+
+	// Add variable consolidation
+	if config.ConsolidateVariables {
+		flags = append(flags, "--consolidate-vars")
+	}
+
+	// Add target inventory
+	if config.ShowStats {
+		flags = append(flags, "--show-stats")
+	}
+
+	// Add included file documentation controls
+	if config.NoIncludedFiles {
+		flags = append(flags, "--no-included-files")
+	}
+	if config.IncludedFilesPosition == "bottom" {
+		flags = append(flags, "--included-files-position bottom")
+	}
+	if config.FileDocsDepth > 0 {
+		flags = append(flags, fmt.Sprintf("--file-docs-depth %d", config.FileDocsDepth))
+	}
+
 	// Add help category if not default
 	if config.HelpCategory != "" && config.HelpCategory != "Help" {
 		flags = append(flags, fmt.Sprintf("--help-category %s", config.HelpCategory))
 	}
 
+	// Add help target name if not default
+	if config.HelpTargetName != "" && config.HelpTargetName != "help" {
+		flags = append(flags, fmt.Sprintf("--help-target-name %s", config.HelpTargetName))
+	}
+
 	// Add dynamic mode flags
 	if config.DynamicMode {
 		flags = append(flags, "--dynamic")
@@ -329,6 +652,9 @@ func buildRegenerateFlags(config *GeneratorConfig) string {
 	if config.NoDynamicWarning {
 		flags = append(flags, "--no-dynamic-warning")
 	}
+	if config.RegenTarget {
+		flags = append(flags, "--regen-target")
+	}
 
 	if len(flags) == 0 {
 		return ""
@@ -336,6 +662,14 @@ func buildRegenerateFlags(config *GeneratorConfig) string {
 	return " " + strings.Join(flags, " ")
 }
 
+// quoteRecipeArg single-quotes s for the shell and escapes "$" for make, so
+// glob and regex patterns reach make-help unchanged when update-help runs.
+func quoteRecipeArg(s string) string {
+	s = strings.ReplaceAll(s, "'", `'\''`)
+	s = strings.ReplaceAll(s, "$", "$$")
+	return "'" + s + "'"
+}
+
 // generateRegenerationTarget creates the update-help target.
 // This is an explicit target users can run to regenerate help.mk.
 // The generated command mirrors the original invocation options (without --makefile-path),
@@ -365,13 +699,60 @@ func generateRegenerationTarget(config *GeneratorConfig) string {
 	buf.WriteString(".PHONY: update-help\n")
 	buf.WriteString("## Regenerates help.mk from source Makefiles.\n")
 	buf.WriteString("update-help:\n")
-	fmt.Fprintf(&buf, "\t@make-help --makefile-path $(MAKE_HELP_DIR)Makefile%s || \\\n", flags)
-	fmt.Fprintf(&buf, "\t npx make-help --makefile-path $(MAKE_HELP_DIR)Makefile%s || \\\n", flags)
-	buf.WriteString("\t echo \"make-help not found; install with 'go install github.com/sdlcforge/make-help/cmd/make-help@latest' or 'npm install -g make-help'\"\n")
+	writeMakeHelpRecipe(&buf, mainMakefileRef(config), flags)
+
+	if config.RegenTarget {
+		buf.WriteString("\n")
+		writeRegenRule(&buf, config, flags)
+	}
 
 	return buf.String()
 }
 
+// writeMakeHelpRecipe writes the recipe that runs make-help on makefile with
+// flags, falling back to npx and then to an install hint.
+func writeMakeHelpRecipe(buf *strings.Builder, makefile string, flags string) {
+	fmt.Fprintf(buf, "\t@make-help --makefile-path %s%s || \\\n", makefile, flags)
+	fmt.Fprintf(buf, "\t npx make-help --makefile-path %s%s || \\\n", makefile, flags)
+	buf.WriteString("\t echo \"make-help not found; install with 'go install github.com/sdlcforge/make-help/cmd/make-help@latest' or 'npm install -g make-help'\"\n")
+}
+
+// writeRegenRule writes the help-regen target and a file rule that makes the
+// help file depend on $(MAKE_HELP_MAKEFILES). Because the help file is
+// included by the Makefile, make remakes it before any build once a source
+// Makefile is newer. The rule is skipped while make-help itself runs make,
+// which would otherwise regenerate recursively.
+func writeRegenRule(buf *strings.Builder, config *GeneratorConfig, flags string) {
+	helpFilename := config.HelpFilename
+	if helpFilename == "" {
+		helpFilename = "help.mk"
+	}
+	helpFile := "$(MAKE_HELP_DIR)" + helpFilename
+
+	buf.WriteString("ifneq ($(MAKE_HELP_GENERATING),1)\n")
+	buf.WriteString(".PHONY: help-regen\n")
+	fmt.Fprintf(buf, "## Regenerates %s if a source Makefile is newer.\n", helpFilename)
+	fmt.Fprintf(buf, "help-regen: %s\n", helpFile)
+	buf.WriteString("\n")
+	fmt.Fprintf(buf, "%s: $(MAKE_HELP_MAKEFILES)\n", helpFile)
+	writeMakeHelpRecipe(buf, mainMakefileRef(config), flags)
+	buf.WriteString("endif\n")
+}
+
+// helpFileDir returns the directory the help file is written to.
+func helpFileDir(config *GeneratorConfig) string {
+	if config.HelpFileDir != "" {
+		return config.HelpFileDir
+	}
+	return config.MakefileDir
+}
+
+// mainMakefileRef returns the main Makefile's path as seen from the help
+// file, e.g. "$(MAKE_HELP_DIR)../Makefile" for make/help.mk.
+func mainMakefileRef(config *GeneratorConfig) string {
+	return relativizeMakefilePaths([]string{filepath.Join(config.MakefileDir, "Makefile")}, helpFileDir(config))[0]
+}
+
 // relativizeMakefilePaths converts absolute Makefile paths to relative paths using $(MAKE_HELP_DIR).
 // This ensures the generated help.mk works regardless of where it's included from.
 func relativizeMakefilePaths(makefiles []string, makefileDir string) []string {
@@ -475,6 +475,106 @@ func TestGenerateHelpFile_ValidMakefile(t *testing.T) {
 	}
 }
 
+func TestGenerateHelpFile_RequiredVariables(t *testing.T) {
+	t.Parallel()
+	// Skip if make is not available
+	if _, err := exec.LookPath("make"); err != nil {
+		t.Skip("make command not available")
+	}
+
+	tmpDir := t.TempDir()
+
+	config := &GeneratorConfig{
+		Makefiles:    []string{filepath.Join(tmpDir, "Makefile")},
+		MakefileDir:  tmpDir,
+		HelpFilename: "help.mk",
+		HelpModel: &model.HelpModel{
+			Categories: []model.Category{
+				{
+					Targets: []model.Target{
+						{
+							Name:          "deploy",
+							Aliases:       []string{"d"},
+							Documentation: []string{"Deploy the application"},
+							Variables: []model.Variable{
+								{Name: "TOKEN", Required: true},
+								{Name: "REGION", Required: true},
+								{Name: "DEBUG"},
+							},
+						},
+						{
+							Name:          "build",
+							Documentation: []string{"Build the application"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	result, err := GenerateHelpFile(config)
+	if err != nil {
+		t.Fatalf("GenerateHelpFile failed: %v", err)
+	}
+
+	if !strings.Contains(result, "deploy d: | make-help-require-deploy\n") {
+		t.Error("Missing required variable check for deploy and its alias")
+	}
+	if strings.Count(result, "MAKE_HELP_MISSING :=") != 1 {
+		t.Error("Expected a required variable check only for deploy")
+	}
+	if strings.Index(result, "make-help-require-deploy") < strings.Index(result, "\nhelp:") {
+		t.Error("Required variable checks must come after the help target, which stays the default goal")
+	}
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "help.mk"), []byte(result), 0644); err != nil {
+		t.Fatalf("Failed to write temp help.mk: %v", err)
+	}
+	// REGION is assigned after the include, so the check must run after
+	// all Makefiles are read
+	makefileContent := "include help.mk\n\nREGION ?=\ndeploy:\n\t@echo deploying\nd: deploy\nbuild:\n\t@echo building\n" +
+		"ifdef DEFAULT_REGION\nREGION := $(DEFAULT_REGION)\nendif\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, "Makefile"), []byte(makefileContent), 0644); err != nil {
+		t.Fatalf("Failed to write temp Makefile: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{name: "all missing", args: []string{"deploy"}, wantErr: "missing required variable(s): TOKEN REGION"},
+		{name: "alias", args: []string{"d", "TOKEN=x"}, wantErr: "missing required variable(s): REGION"},
+		{name: "all set", args: []string{"deploy", "TOKEN=x", "REGION=y"}},
+		{name: "assigned after the include", args: []string{"deploy", "TOKEN=x", "DEFAULT_REGION=y"}},
+		{name: "other target", args: []string{"build"}},
+		{name: "dry run", args: []string{"-n", "deploy"}},
+	}
+
+	// help.mk is included first, so help stays the default goal
+	cmd := exec.Command("make", "-f", "Makefile")
+	cmd.Dir = tmpDir
+	output, err := cmd.CombinedOutput()
+	if err != nil || !strings.Contains(string(output), "Usage:") || strings.Contains(string(output), "deploying") {
+		t.Errorf("make without a goal should run help, got:\n%s", output)
+	}
+
+	for _, tt := range tests {
+		cmd := exec.Command("make", append([]string{"-f", "Makefile"}, tt.args...)...)
+		cmd.Dir = tmpDir
+		output, err := cmd.CombinedOutput()
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("%s: make failed:\n%s", tt.name, output)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(string(output), tt.wantErr) {
+			t.Errorf("%s: expected error containing %q, got:\n%s", tt.name, tt.wantErr, output)
+		}
+	}
+}
+
 func TestRelativizeMakefilePaths(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -585,6 +685,52 @@ func TestBuildRegenerateFlags(t *testing.T) {
 			},
 			expected: " --include-all-phony",
 		},
+		{
+			name: "profile",
+			config: &GeneratorConfig{
+				UseColor: true,
+				Profile:  "dev",
+			},
+			expected: " --profile dev",
+		},
+		{
+			name: "group by file",
+			config: &GeneratorConfig{
+				UseColor: true,
+				GroupBy:  "file",
+			},
+			expected: " --group-by file",
+		},
+		{
+			name: "only files",
+			config: &GeneratorConfig{
+				UseColor:  true,
+				OnlyFiles: []string{"make/docker.mk", "make/k8s-*.mk"},
+			},
+			expected: " --only-file 'make/docker.mk' --only-file 'make/k8s-*.mk'",
+		},
+		{
+			name: "exclude patterns",
+			config: &GeneratorConfig{
+				UseColor:       true,
+				ExcludeTargets: []string{"internal-*"},
+				ExcludeFiles:   []string{"third_party/**"},
+			},
+			expected: " --exclude-target 'internal-*' --exclude-file 'third_party/**'",
+		},
+		{
+			name: "redaction settings",
+			config: &GeneratorConfig{
+				UseColor:       true,
+				RedactPatterns: []string{`sk_live_\w+$`, "it's"},
+			},
+			expected: ` --redact-pattern 'sk_live_\w+$$' --redact-pattern 'it'\''s'`,
+		},
+		{
+			name:     "no redaction",
+			config:   &GeneratorConfig{UseColor: true, NoRedact: true},
+			expected: " --no-redact",
+		},
 		{
 			name: "help category non-default",
 			config: &GeneratorConfig{
@@ -601,6 +747,14 @@ func TestBuildRegenerateFlags(t *testing.T) {
 			},
 			expected: "", // default "Help" should not be in flags
 		},
+		{
+			name: "resolve remote",
+			config: &GeneratorConfig{
+				UseColor:      true,
+				ResolveRemote: true,
+			},
+			expected: " --resolve-remote",
+		},
 		{
 			name: "multiple options",
 			config: &GeneratorConfig{
@@ -681,6 +835,313 @@ func TestGenerateRegenerationTarget_WithFlags(t *testing.T) {
 	}
 }
 
+func TestGenerateRegenerationTarget_RegenTarget(t *testing.T) {
+	t.Parallel()
+	config := &GeneratorConfig{
+		RegenTarget:  true,
+		Makefiles:    []string{"/path/to/Makefile"},
+		MakefileDir:  "/path/to",
+		HelpFilename: "help.mk",
+		HelpModel:    &model.HelpModel{},
+	}
+
+	result := generateRegenerationTarget(config)
+
+	for _, want := range []string{
+		"ifneq ($(MAKE_HELP_GENERATING),1)\n",
+		".PHONY: help-regen\n",
+		"help-regen: $(MAKE_HELP_DIR)help.mk\n",
+		"$(MAKE_HELP_DIR)help.mk: $(MAKE_HELP_MAKEFILES)\n",
+		"\t@make-help --makefile-path $(MAKE_HELP_DIR)Makefile --no-color --regen-target || \\\n",
+		"endif\n",
+	} {
+		if !strings.Contains(result, want) {
+			t.Errorf("missing %q in:\n%s", want, result)
+		}
+	}
+
+	config.RegenTarget = false
+	if result := generateRegenerationTarget(config); strings.Contains(result, "help-regen") {
+		t.Errorf("help-regen generated without RegenTarget:\n%s", result)
+	}
+}
+
+func TestGenerateHelpFile_HelpFileDir(t *testing.T) {
+	t.Parallel()
+	config := &GeneratorConfig{
+		RegenTarget:  true,
+		Makefiles:    []string{"/path/to/Makefile", "/path/to/mk/build.mk"},
+		MakefileDir:  "/path/to",
+		HelpFileDir:  "/path/to/make",
+		HelpFilename: "help.mk",
+		HelpModel:    &model.HelpModel{},
+	}
+
+	result, err := GenerateHelpFile(config)
+	if err != nil {
+		t.Fatalf("GenerateHelpFile() error = %v", err)
+	}
+
+	for _, want := range []string{
+		"MAKE_HELP_MAKEFILES := $(MAKE_HELP_DIR)../Makefile $(MAKE_HELP_DIR)../mk/build.mk\n",
+		"@make-help --makefile-path $(MAKE_HELP_DIR)../Makefile --no-color --regen-target",
+		"$(MAKE_HELP_DIR)help.mk: $(MAKE_HELP_MAKEFILES)\n",
+	} {
+		if !strings.Contains(result, want) {
+			t.Errorf("missing %q in:\n%s", want, result)
+		}
+	}
+}
+
+func TestGenerateHelpFile_MaxTargetsPerCategory(t *testing.T) {
+	t.Parallel()
+	helpModel := &model.HelpModel{
+		Categories: []model.Category{
+			{
+				Targets: []model.Target{
+					{Name: "build", Documentation: []string{"Build the application"}},
+					{Name: "test", Documentation: []string{"Run the tests"}},
+					{Name: "lint", Documentation: []string{"Lint the code"}},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		dynamic    bool
+		wantInvoke string
+	}{
+		{name: "static"},
+		{name: "dynamic", dynamic: true, wantInvoke: "--output - --max-targets-per-category 2 --full-help-target help-full $(MAKE_HELP_OPTS)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result, err := GenerateHelpFile(&GeneratorConfig{
+				HelpModel:             helpModel,
+				DynamicMode:           tt.dynamic,
+				MaxTargetsPerCategory: 2,
+			})
+			if err != nil {
+				t.Fatalf("GenerateHelpFile failed: %v", err)
+			}
+
+			help, full, found := strings.Cut(result, "\nhelp-full:\n")
+			if !found {
+				t.Fatalf("Missing help-full target:\n%s", result)
+			}
+			if !strings.Contains(help, "(+1 more, run make help-full)") {
+				t.Error("help should end the category with a footer pointing to help-full")
+			}
+			if strings.Contains(help, "- lint") {
+				t.Error("help should omit targets beyond the limit")
+			}
+			full, _, _ = strings.Cut(full, "\n\n")
+			if !strings.Contains(full, "- lint") || strings.Contains(full, "more, run") {
+				t.Errorf("help-full should list every target, got:\n%s", full)
+			}
+			if !strings.Contains(result, "--max-targets-per-category 2") {
+				t.Error("Generated file should record the limit for regeneration")
+			}
+			if tt.wantInvoke != "" && !strings.Contains(help, tt.wantInvoke) {
+				t.Errorf("Dynamic help should pass the limit to make-help, got:\n%s", help)
+			}
+		})
+	}
+}
+
+func TestGenerateHelpFile_MaxTargetsPerCategoryRenamedHelp(t *testing.T) {
+	t.Parallel()
+	result, err := GenerateHelpFile(&GeneratorConfig{
+		HelpModel: &model.HelpModel{
+			Categories: []model.Category{
+				{
+					Targets: []model.Target{
+						{Name: "build", Documentation: []string{"Build the application"}},
+						{Name: "test", Documentation: []string{"Run the tests"}},
+					},
+				},
+			},
+		},
+		DynamicMode:           true,
+		HelpTargetName:        "usage",
+		MaxTargetsPerCategory: 1,
+	})
+	if err != nil {
+		t.Fatalf("GenerateHelpFile failed: %v", err)
+	}
+
+	// The full listing follows the renamed help target
+	if !strings.Contains(result, "\nusage-full:\n") || strings.Contains(result, "help-full") {
+		t.Errorf("Expected a usage-full target instead of help-full:\n%s", result)
+	}
+	if !strings.Contains(result, "--full-help-target usage-full") {
+		t.Error("Dynamic help should suggest usage-full")
+	}
+}
+func TestGenerateHelpFile_SummaryWidth(t *testing.T) {
+	t.Parallel()
+	helpModel := &model.HelpModel{
+		Categories: []model.Category{
+			{
+				Targets: []model.Target{
+					{Name: "deploy", Summary: []string{"Deploy the application to the production cluster."}},
+				},
+			},
+		},
+	}
+
+	result, err := GenerateHelpFile(&GeneratorConfig{HelpModel: helpModel, SummaryWidth: 20})
+	if err != nil {
+		t.Fatalf("GenerateHelpFile failed: %v", err)
+	}
+	if !strings.Contains(result, "- deploy: Deploy the…") {
+		t.Errorf("help should truncate the summary, got:\n%s", result)
+	}
+	if !strings.Contains(result, "--summary-width 20") {
+		t.Error("Generated file should record the width for regeneration")
+	}
+
+	result, err = GenerateHelpFile(&GeneratorConfig{HelpModel: helpModel, SummaryWidth: 20, DynamicMode: true})
+	if err != nil {
+		t.Fatalf("GenerateHelpFile failed: %v", err)
+	}
+	if !strings.Contains(result, "--output - --summary-width 20 $(MAKE_HELP_OPTS)") {
+		t.Errorf("Dynamic help should pass the width to make-help, got:\n%s", result)
+	}
+}
+
+func TestGenerateHelpFile_ShowStats(t *testing.T) {
+	t.Parallel()
+	helpModel := &model.HelpModel{
+		Categories: []model.Category{
+			{Targets: []model.Target{{Name: "build", Summary: []string{"Build the project."}}}},
+		},
+	}
+	config := &GeneratorConfig{HelpModel: helpModel, ShowStats: true, Undocumented: 2}
+
+	result, err := GenerateHelpFile(config)
+	if err != nil {
+		t.Fatalf("GenerateHelpFile failed: %v", err)
+	}
+	if !strings.Contains(result, "1 target, 2 undocumented hidden") {
+		t.Errorf("help should end with the target inventory, got:\n%s", result)
+	}
+	if !strings.Contains(result, "--show-stats") {
+		t.Error("Generated file should record --show-stats for regeneration")
+	}
+}
+
+func TestGenerateHelpFile_IncludedFilesControls(t *testing.T) {
+	t.Parallel()
+	helpModel := &model.HelpModel{
+		FileDocs: []model.FileDoc{
+			{SourceFile: "build.mk", Documentation: []string{"Build rules.", "Uses the Go toolchain."}},
+		},
+		Categories: []model.Category{
+			{Targets: []model.Target{{Name: "build", Summary: []string{"Build the project."}}}},
+		},
+	}
+	config := &GeneratorConfig{HelpModel: helpModel, IncludedFilesPosition: "bottom", FileDocsDepth: 1}
+
+	result, err := GenerateHelpFile(config)
+	if err != nil {
+		t.Fatalf("GenerateHelpFile failed: %v", err)
+	}
+	if !strings.Contains(result, "(+1 more line)") {
+		t.Errorf("help should limit included file docs, got:\n%s", result)
+	}
+	if !strings.Contains(result, "--included-files-position bottom --file-docs-depth 1") {
+		t.Error("Generated file should record the included file controls for regeneration")
+	}
+
+	config.DynamicMode = true
+	result, err = GenerateHelpFile(config)
+	if err != nil {
+		t.Fatalf("GenerateHelpFile failed: %v", err)
+	}
+	if !strings.Contains(result, "--output - --included-files-position bottom --file-docs-depth 1 $(MAKE_HELP_OPTS)") {
+		t.Errorf("Dynamic help should pass the included file controls to make-help, got:\n%s", result)
+	}
+}
+
+func TestGenerateHelpFile_ConsolidateVariables(t *testing.T) {
+	t.Parallel()
+	helpModel := &model.HelpModel{
+		Categories: []model.Category{
+			{
+				Targets: []model.Target{
+					{Name: "build", Summary: []string{"Build it."}, Variables: []model.Variable{{Name: "VERBOSE"}}},
+					{Name: "test", Summary: []string{"Test it."}, Variables: []model.Variable{{Name: "VERBOSE"}}},
+				},
+			},
+		},
+	}
+
+	result, err := GenerateHelpFile(&GeneratorConfig{HelpModel: helpModel, ConsolidateVariables: true})
+	if err != nil {
+		t.Fatalf("GenerateHelpFile failed: %v", err)
+	}
+	if !strings.Contains(result, "Used by: build, test") {
+		t.Errorf("help should list shared variables once, got:\n%s", result)
+	}
+	if !strings.Contains(result, "--consolidate-vars") {
+		t.Error("Generated file should record --consolidate-vars for regeneration")
+	}
+
+	result, err = GenerateHelpFile(&GeneratorConfig{HelpModel: helpModel, ConsolidateVariables: true, DynamicMode: true})
+	if err != nil {
+		t.Fatalf("GenerateHelpFile failed: %v", err)
+	}
+	if !strings.Contains(result, "--output - --consolidate-vars $(MAKE_HELP_OPTS)") {
+		t.Errorf("Dynamic help should pass --consolidate-vars to make-help, got:\n%s", result)
+	}
+}
+
+func TestGenerateHelpFile_BestEffort(t *testing.T) {
+	t.Parallel()
+	helpModel := &model.HelpModel{
+		Categories: []model.Category{
+			{Targets: []model.Target{{Name: "build", Summary: []string{"Build it."}}}},
+		},
+	}
+
+	result, err := GenerateHelpFile(&GeneratorConfig{HelpModel: helpModel, BestEffort: true})
+	if err != nil {
+		t.Fatalf("GenerateHelpFile failed: %v", err)
+	}
+	if !strings.Contains(result, "--best-effort") {
+		t.Error("Generated file should record --best-effort for regeneration")
+	}
+}
+
+func TestGenerateHelpFile_HelpTargetName(t *testing.T) {
+	t.Parallel()
+	helpModel := &model.HelpModel{
+		Categories: []model.Category{
+			{Targets: []model.Target{{Name: "build", Summary: []string{"Build it."}}}},
+		},
+	}
+
+	for _, dynamic := range []bool{false, true} {
+		result, err := GenerateHelpFile(&GeneratorConfig{HelpModel: helpModel, HelpTargetName: "usage", DynamicMode: dynamic})
+		if err != nil {
+			t.Fatalf("GenerateHelpFile failed: %v", err)
+		}
+		if !strings.Contains(result, ".PHONY: usage\n## Displays help for available targets.\nusage:\n") {
+			t.Errorf("dynamic=%v: generated help target should be named usage", dynamic)
+		}
+		if strings.Contains(result, "\nhelp:") {
+			t.Errorf("dynamic=%v: generated file should not define help", dynamic)
+		}
+		if !strings.Contains(result, "--help-target-name usage") {
+			t.Errorf("dynamic=%v: generated file should record --help-target-name for regeneration", dynamic)
+		}
+	}
+}
+
 func TestGenerateHelpFile_CustomHelpFilename(t *testing.T) {
 	t.Parallel()
 	config := &GeneratorConfig{
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/sdlcforge/make-help/internal/discovery"
@@ -29,113 +30,340 @@ func NewRemoveService(config *Config, executor discovery.CommandExecutor, verbos
 
 // RemoveTarget removes help target artifacts from the Makefile.
 // It performs the following cleanup steps:
-//  1. Remove include directives for help target files
-//  2. Remove inline help: target and .PHONY: help
-//  3. Delete help target files (make/01-help.mk)
-func (s *RemoveService) RemoveTarget() error {
+//  1. Remove include directives marked by IncludeMarker, restoring the
+//     Makefile byte-for-byte
+//  2. Restore hand-written help targets commented out by
+//     --replace-existing-help, in the Makefile and the help file's sources
+//  3. Remove other include directives for help target files
+//  4. Remove inline help: target and .PHONY: help, unless a hand-written
+//     target was restored
+//  5. Delete generated help files and make/01-help.mk
+//
+// Every change is planned before any file is modified, and changes already
+// made are rolled back if a later one fails.
+func (s *RemoveService) RemoveTarget(ctx context.Context) error {
 	makefilePath := s.config.MakefilePath
 
 	// Validate Makefile syntax before modifying
-	if err := s.validateMakefile(makefilePath); err != nil {
+	if err := ValidateMakefile(ctx, s.executor, makefilePath); err != nil {
 		return fmt.Errorf("makefile validation failed: %w", err)
 	}
 
-	removed := false
-
-	// Remove include directives
-	if err := s.removeIncludeDirectives(makefilePath); err != nil {
-		return err
-	}
-
-	// Remove inline help target
-	inlineRemoved, err := s.removeInlineHelpTarget(makefilePath)
+	changes, err := s.planRemoval(makefilePath)
 	if err != nil {
 		return err
 	}
-	if inlineRemoved {
-		removed = true
+	if len(changes) == 0 {
+		fmt.Printf("No help target found in: %s\n", makefilePath)
+		return nil
 	}
 
-	// Remove help target files
-	filesRemoved, err := s.removeHelpTargetFiles(makefilePath)
-	if err != nil {
+	if err := applyFileChanges(changes); err != nil {
 		return err
 	}
-	if filesRemoved {
-		removed = true
+	if s.verbose {
+		for _, change := range changes {
+			if change.content == nil {
+				fmt.Printf("Removed help target file: %s\n", change.path)
+			} else {
+				fmt.Printf("Updated: %s\n", change.path)
+			}
+		}
 	}
 
-	if removed {
-		fmt.Printf("Successfully removed help target from: %s\n", makefilePath)
-	} else {
-		fmt.Printf("No help target found in: %s\n", makefilePath)
-	}
+	// make-help creates make/ for the help file; drop it if nothing else is
+	// left there
+	_ = os.Remove(filepath.Join(filepath.Dir(makefilePath), "make"))
 
+	fmt.Printf("Successfully removed help target from: %s\n", makefilePath)
 	return nil
 }
 
-// validateMakefile runs `make -n` to check for syntax errors.
-func (s *RemoveService) validateMakefile(makefilePath string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), makeValidationTimeout)
-	defer cancel()
-
-	// Run make -n (dry-run) to check syntax without executing recipes
-	_, stderr, err := s.executor.ExecuteContext(ctx, "make", "-n", "-f", makefilePath)
+// PlannedFiles returns the files RemoveTarget would modify or delete.
+func (s *RemoveService) PlannedFiles() ([]string, error) {
+	changes, err := s.planRemoval(s.config.MakefilePath)
 	if err != nil {
-		if ctx.Err() == context.DeadlineExceeded {
-			return fmt.Errorf("validation timed out")
+		return nil, err
+	}
+	files := make([]string, len(changes))
+	for i, change := range changes {
+		files[i] = change.path
+	}
+	return files, nil
+}
+
+// fileChange is a planned modification of one file: new content, or
+// deletion when content is nil.
+type fileChange struct {
+	path     string
+	content  []byte
+	original []byte
+	perm     os.FileMode
+}
+
+// applyFileChanges applies changes in order. If one fails, the files
+// changed before it are restored to their original content.
+func applyFileChanges(changes []fileChange) error {
+	for i, change := range changes {
+		var err error
+		if change.content == nil {
+			err = os.Remove(change.path)
+		} else {
+			err = AtomicWriteFile(change.path, change.content, change.perm)
+		}
+		if err != nil {
+			for _, done := range changes[:i] {
+				_ = AtomicWriteFile(done.path, done.original, done.perm)
+			}
+			return fmt.Errorf("failed to update %s: %w", change.path, err)
 		}
-		return fmt.Errorf("syntax error in Makefile:\n%s", stderr)
 	}
 	return nil
 }
 
-// removeIncludeDirectives removes include lines for help targets using atomic write.
-// Matches both simple includes (include help.mk) and self-referential includes
-// (include $(dir $(lastword $(MAKEFILE_LIST)))help.mk).
-func (s *RemoveService) removeIncludeDirectives(makefilePath string) error {
+// planRemoval returns the changes that remove make-help's artifacts from
+// the project of makefilePath. Makefile edits come before deletions.
+func (s *RemoveService) planRemoval(makefilePath string) ([]fileChange, error) {
 	content, err := os.ReadFile(makefilePath)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	lines := strings.Split(string(content), "\n")
-	filtered := []string{}
+	helpFiles, err := s.helpTargetFiles(makefilePath, string(content))
+	if err != nil {
+		return nil, err
+	}
+	deleted := make(map[string]bool)
+	for _, helpFile := range helpFiles {
+		deleted[helpFile] = true
+	}
 
-	// Match both patterns (with optional - prefix for silent include):
-	// - include help.mk / -include help.mk
-	// - include $(dir $(lastword $(MAKEFILE_LIST)))help.mk / -include ...
-	includeRegex := regexp.MustCompile(`^-?include\s+(\$\(dir \$\(lastword \$\(MAKEFILE_LIST\)\)\))?.*help.*\.mk`)
-	removed := false
+	var changes []fileChange
 
-	for _, line := range lines {
-		if !includeRegex.MatchString(line) {
-			filtered = append(filtered, line)
-		} else {
-			removed = true
-			if s.verbose {
-				fmt.Printf("Removed include directive: %s\n", line)
+	// Restore replaced help targets in the other Makefiles the help files
+	// were generated from
+	for _, helpFile := range helpFiles {
+		sources, err := helpFileMakefiles(helpFile)
+		if err != nil {
+			return nil, err
+		}
+		for _, source := range sources {
+			if source == filepath.Clean(makefilePath) || slices.ContainsFunc(changes, func(c fileChange) bool { return c.path == source }) {
+				continue
+			}
+			original, err := os.ReadFile(source)
+			if err != nil {
+				continue // Moved or deleted since generation
+			}
+			restored, ok := restoreReplacedHelpTargets(string(original))
+			if !ok {
+				continue
+			}
+			change, err := newFileChange(source, original)
+			if err != nil {
+				return nil, err
 			}
+			change.content = []byte(restored)
+			changes = append(changes, change)
 		}
 	}
 
-	if !removed {
-		return nil // No changes needed
+	newContent := withoutMarkedIncludes(string(content), filepath.Dir(makefilePath), deleted)
+	newContent, restored := restoreReplacedHelpTargets(newContent)
+	newContent, _ = s.withoutIncludeDirectives(newContent)
+	if !restored {
+		// A restored target is the user's own, not an inline help target
+		newContent, _ = s.withoutInlineHelpTarget(newContent)
+	}
+	if newContent != string(content) {
+		change, err := newFileChange(makefilePath, content)
+		if err != nil {
+			return nil, err
+		}
+		change.content = []byte(newContent)
+		changes = append(changes, change)
 	}
 
-	newContent := strings.Join(filtered, "\n")
-	return AtomicWriteFile(makefilePath, []byte(newContent), 0644)
+	for _, helpFile := range helpFiles {
+		original, err := os.ReadFile(helpFile)
+		if err != nil {
+			return nil, err
+		}
+		change, err := newFileChange(helpFile, original)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, change)
+	}
+
+	return changes, nil
 }
 
-// removeInlineHelpTarget removes help target from Makefile using atomic write.
-// Returns true if a help target was found and removed.
-func (s *RemoveService) removeInlineHelpTarget(makefilePath string) (bool, error) {
-	content, err := os.ReadFile(makefilePath)
+// newFileChange returns a deletion of path, which currently holds original.
+// Callers set content to rewrite it instead.
+func newFileChange(path string, original []byte) (fileChange, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileChange{}, err
+	}
+	return fileChange{path: path, original: original, perm: info.Mode().Perm()}, nil
+}
+
+// includeLineRegex matches an include directive and captures its path.
+var includeLineRegex = regexp.MustCompile(`^-?include\s+(.+?)\s*$`)
+
+// helpIncludeRegex matches include lines for help targets, both simple
+// includes (include help.mk) and self-referential includes
+// (include $(dir $(lastword $(MAKEFILE_LIST)))help.mk), with an optional -
+// prefix for silent include.
+var helpIncludeRegex = regexp.MustCompile(`^-?include\s+(\$\(dir \$\(lastword \$\(MAKEFILE_LIST\)\)\))?.*help.*\.mk`)
+
+// helpTargetFiles returns the help files to delete for the Makefile at
+// makefilePath with content: generated files its help include directives
+// point to, a generated help file in make/, and the legacy make/01-help.mk.
+func (s *RemoveService) helpTargetFiles(makefilePath, content string) ([]string, error) {
+	makefileDir := filepath.Dir(makefilePath)
+	var files []string
+	add := func(path string) {
+		path = filepath.Clean(path)
+		if !slices.Contains(files, path) {
+			files = append(files, path)
+		}
+	}
+
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		marked := i > 0 && lines[i-1] == IncludeMarker
+		if !marked && !helpIncludeRegex.MatchString(line) {
+			continue
+		}
+		if path, ok := includedFile(makefileDir, line); ok && isGeneratedByMakeHelp(path) {
+			add(path)
+		}
+	}
+
+	existing, err := FindExistingHelpFile(makefilePath, "")
+	if err != nil {
+		return nil, err
+	}
+	if existing != "" {
+		add(existing)
+	}
+
+	legacyFile := filepath.Join(makefileDir, "make", "01-help.mk")
+	if info, err := os.Stat(legacyFile); err == nil && info.Mode().IsRegular() {
+		add(legacyFile)
+	}
+
+	return files, nil
+}
+
+// includedFile returns the file an include line names, resolved against
+// makefileDir. Returns false for lines that are not includes or that name
+// a pattern, a variable, or several files.
+func includedFile(makefileDir, line string) (string, bool) {
+	match := includeLineRegex.FindStringSubmatch(line)
+	if match == nil {
+		return "", false
+	}
+	path := strings.TrimPrefix(match[1], "$(dir $(lastword $(MAKEFILE_LIST)))")
+	if strings.ContainsAny(path, "*?[$ \t") {
+		return "", false
+	}
+	return filepath.Join(makefileDir, path), true
+}
+
+// helpFileMakefiles returns the Makefiles listed in MAKE_HELP_MAKEFILES of
+// a generated help file.
+func helpFileMakefiles(helpFile string) ([]string, error) {
+	content, err := os.ReadFile(helpFile)
 	if err != nil {
-		return false, err
+		return nil, err
+	}
+
+	var makefiles []string
+	for _, line := range strings.Split(string(content), "\n") {
+		value, ok := strings.CutPrefix(line, "MAKE_HELP_MAKEFILES := ")
+		if !ok {
+			continue
+		}
+		for _, field := range strings.Fields(value) {
+			rel, ok := strings.CutPrefix(field, "$(MAKE_HELP_DIR)")
+			if !ok {
+				makefiles = append(makefiles, filepath.Clean(field))
+				continue
+			}
+			makefiles = append(makefiles, filepath.Join(filepath.Dir(helpFile), filepath.FromSlash(rel)))
+		}
+		break
+	}
+	return makefiles, nil
+}
+
+// markedIncludeRegex matches an include directive added by make-help,
+// capturing its path.
+var markedIncludeRegex = regexp.MustCompile(`(?m)^` + regexp.QuoteMeta(IncludeMarker) + `\n-include ([^\n]*)(?:\n|$)`)
+
+// withoutMarkedIncludes removes the include directives make-help added to
+// content, along with the blank line added before each, so that a Makefile
+// not edited since is restored exactly. A pattern include is kept while it
+// still matches files other than those in deleted.
+func withoutMarkedIncludes(content, makefileDir string, deleted map[string]bool) string {
+	for offset := 0; ; {
+		loc := markedIncludeRegex.FindStringSubmatchIndex(content[offset:])
+		if loc == nil {
+			return content
+		}
+		start, end := offset+loc[0], offset+loc[1]
+		path := content[offset+loc[2] : offset+loc[3]]
+
+		if strings.Contains(path, "*") && patternMatchesOtherFiles(filepath.Join(makefileDir, path), deleted) {
+			offset = end
+			continue
+		}
+
+		if start > 0 && content[start-1] == '\n' && (start == 1 || content[start-2] == '\n') {
+			start--
+		}
+		content = content[:start] + content[end:]
+		offset = start
 	}
+}
 
-	lines := strings.Split(string(content), "\n")
+// patternMatchesOtherFiles reports whether the glob pattern matches a file
+// not in deleted.
+func patternMatchesOtherFiles(pattern string, deleted map[string]bool) bool {
+	matches, _ := filepath.Glob(pattern)
+	for _, match := range matches {
+		if !deleted[filepath.Clean(match)] {
+			return true
+		}
+	}
+	return false
+}
+
+// withoutIncludeDirectives removes include lines for help targets from
+// content. Returns true if any were removed.
+func (s *RemoveService) withoutIncludeDirectives(content string) (string, bool) {
+	lines := strings.Split(content, "\n")
+	filtered := []string{}
+	removed := false
+
+	for _, line := range lines {
+		if !helpIncludeRegex.MatchString(line) {
+			filtered = append(filtered, line)
+		} else {
+			removed = true
+		}
+	}
+
+	return strings.Join(filtered, "\n"), removed
+}
+
+// withoutInlineHelpTarget removes the help target and .PHONY: help from
+// content. Returns true if a help target was found and removed.
+func (s *RemoveService) withoutInlineHelpTarget(content string) (string, bool) {
+	lines := strings.Split(content, "\n")
 	filtered := []string{}
 
 	inHelpTarget := false
@@ -146,9 +374,6 @@ func (s *RemoveService) removeInlineHelpTarget(makefilePath string) (bool, error
 		if strings.HasPrefix(line, "help:") || strings.HasPrefix(line, ".PHONY: help") {
 			inHelpTarget = true
 			removed = true
-			if s.verbose {
-				fmt.Printf("Removing help target starting at: %s\n", line)
-			}
 			continue
 		}
 
@@ -163,31 +388,5 @@ func (s *RemoveService) removeInlineHelpTarget(makefilePath string) (bool, error
 		filtered = append(filtered, line)
 	}
 
-	if !removed {
-		return false, nil // No changes needed
-	}
-
-	newContent := strings.Join(filtered, "\n")
-	return true, AtomicWriteFile(makefilePath, []byte(newContent), 0644)
-}
-
-// removeHelpTargetFiles deletes help target files.
-// Returns true if any files were removed.
-func (s *RemoveService) removeHelpTargetFiles(makefilePath string) (bool, error) {
-	makeDir := filepath.Join(filepath.Dir(makefilePath), "make")
-	helpFile := filepath.Join(makeDir, "01-help.mk")
-
-	removed := false
-
-	if _, err := os.Stat(helpFile); err == nil {
-		if err := os.Remove(helpFile); err != nil {
-			return false, fmt.Errorf("failed to remove %s: %w", helpFile, err)
-		}
-		if s.verbose {
-			fmt.Printf("Removed help target file: %s\n", helpFile)
-		}
-		removed = true
-	}
-
-	return removed, nil
+	return strings.Join(filtered, "\n"), removed
 }
@@ -1,6 +1,7 @@
 package target
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"testing"
@@ -42,7 +43,7 @@ test:
 	service := NewRemoveService(config, executor, false)
 
 	// Execute
-	err = service.RemoveTarget()
+	err = service.RemoveTarget(context.Background())
 	require.NoError(t, err)
 
 	// Verify help target was removed
@@ -103,7 +104,7 @@ help:
 	service := NewRemoveService(config, executor, false)
 
 	// Execute
-	err = service.RemoveTarget()
+	err = service.RemoveTarget(context.Background())
 	require.NoError(t, err)
 
 	// Verify include directive was removed
@@ -155,7 +156,7 @@ test:
 	service := NewRemoveService(config, executor, false)
 
 	// Execute
-	err = service.RemoveTarget()
+	err = service.RemoveTarget(context.Background())
 	require.NoError(t, err)
 
 	// Verify both were removed
@@ -201,7 +202,7 @@ test:
 	service := NewRemoveService(config, executor, false)
 
 	// Execute (should not error)
-	err = service.RemoveTarget()
+	err = service.RemoveTarget(context.Background())
 	require.NoError(t, err)
 
 	// Verify Makefile unchanged
@@ -308,23 +309,13 @@ all:
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			tmpDir := t.TempDir()
-			makefilePath := filepath.Join(tmpDir, "Makefile")
-
-			err := os.WriteFile(makefilePath, []byte(tt.input), 0644)
-			require.NoError(t, err)
-
-			config := &Config{MakefilePath: makefilePath}
+			config := &Config{MakefilePath: "Makefile"}
 			executor := NewMockExecutor()
 			service := NewRemoveService(config, executor, false)
 
-			err = service.removeIncludeDirectives(makefilePath)
-			require.NoError(t, err)
-
-			content, err := os.ReadFile(makefilePath)
-			require.NoError(t, err)
-
-			assert.Equal(t, tt.expectedOutput, string(content))
+			content, changed := service.withoutIncludeDirectives(tt.input)
+			assert.Equal(t, tt.shouldChange, changed)
+			assert.Equal(t, tt.expectedOutput, content)
 		})
 	}
 }
@@ -394,23 +385,13 @@ test:
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			tmpDir := t.TempDir()
-			makefilePath := filepath.Join(tmpDir, "Makefile")
-
-			err := os.WriteFile(makefilePath, []byte(tt.input), 0644)
-			require.NoError(t, err)
-
-			config := &Config{MakefilePath: makefilePath}
+			config := &Config{MakefilePath: "Makefile"}
 			executor := NewMockExecutor()
 			service := NewRemoveService(config, executor, false)
 
-			removed, err := service.removeInlineHelpTarget(makefilePath)
-			require.NoError(t, err)
+			content, removed := service.withoutInlineHelpTarget(tt.input)
 			assert.Equal(t, tt.shouldRemove, removed)
-
-			content, err := os.ReadFile(makefilePath)
-			require.NoError(t, err)
-			assert.Equal(t, tt.expected, string(content))
+			assert.Equal(t, tt.expected, content)
 		})
 	}
 }
@@ -437,13 +418,9 @@ func TestRemoveService_RemoveHelpTargetFiles(t *testing.T) {
 	service := NewRemoveService(config, executor, false)
 
 	// Execute
-	removed, err := service.removeHelpTargetFiles(makefilePath)
+	files, err := service.helpTargetFiles(makefilePath, "all:\n\t@echo test\n")
 	require.NoError(t, err)
-	assert.True(t, removed)
-
-	// Verify file was deleted
-	_, err = os.Stat(helpFile)
-	assert.True(t, os.IsNotExist(err))
+	assert.Equal(t, []string{helpFile}, files)
 }
 
 func TestRemoveService_RemoveHelpTargetFiles_NoFile(t *testing.T) {
@@ -460,9 +437,9 @@ func TestRemoveService_RemoveHelpTargetFiles_NoFile(t *testing.T) {
 	service := NewRemoveService(config, executor, false)
 
 	// Execute (should not error)
-	removed, err := service.removeHelpTargetFiles(makefilePath)
+	files, err := service.helpTargetFiles(makefilePath, "all:\n\t@echo test\n")
 	require.NoError(t, err)
-	assert.False(t, removed)
+	assert.Empty(t, files)
 }
 
 func TestRemoveService_ValidateMakefile_SyntaxError(t *testing.T) {
@@ -481,7 +458,153 @@ func TestRemoveService_ValidateMakefile_SyntaxError(t *testing.T) {
 
 	service := NewRemoveService(config, executor, false)
 
-	err = service.RemoveTarget()
+	err = service.RemoveTarget(context.Background())
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "makefile validation failed")
 }
+
+func TestRemoveService_RemoveTarget_RestoresMakefile(t *testing.T) {
+	t.Parallel()
+	generated := "# generated-by: make-help\n# command: make-help\n\n"
+
+	tests := []struct {
+		name        string
+		files       map[string]string
+		helpFile    string
+		replaceHelp bool
+		wantFiles   map[string]string
+	}{
+		{
+			name:     "self-referential include",
+			files:    map[string]string{"Makefile": "build:\n\t@true\n"},
+			helpFile: "help.mk",
+		},
+		{
+			name:     "pattern include",
+			files:    map[string]string{"Makefile": "build:\n\t@true\n"},
+			helpFile: "make/help.mk",
+		},
+		{
+			name:        "replaced help target",
+			files:       map[string]string{"Makefile": "## Show help\nhelp:\n\t@echo help\n\nbuild:\n\t@true\n"},
+			helpFile:    "help.mk",
+			replaceHelp: true,
+		},
+		{
+			name: "pattern include kept for other fragments",
+			files: map[string]string{
+				"Makefile":     "build:\n\t@true\n",
+				"make/test.mk": "test:\n\t@true\n",
+			},
+			helpFile: "make/help.mk",
+			wantFiles: map[string]string{
+				"Makefile":     "build:\n\t@true\n\n" + IncludeMarker + "\n-include make/*.mk\n",
+				"make/test.mk": "test:\n\t@true\n",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tmpDir := t.TempDir()
+			for name, content := range tt.files {
+				path := filepath.Join(tmpDir, name)
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+				require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+			}
+			makefilePath := filepath.Join(tmpDir, "Makefile")
+			helpFile := filepath.Join(tmpDir, tt.helpFile)
+
+			// Make the changes make-help makes when creating the help file
+			content := []byte(tt.files["Makefile"])
+			if tt.replaceHelp {
+				var err error
+				content, err = CommentOutHelpTarget(content, 2)
+				require.NoError(t, err)
+			}
+			content = WithIncludeDirective(content, makefilePath, helpFile)
+			require.NoError(t, os.WriteFile(makefilePath, content, 0644))
+			require.NoError(t, os.MkdirAll(filepath.Dir(helpFile), 0755))
+			require.NoError(t, os.WriteFile(helpFile, []byte(generated), 0644))
+
+			executor := NewMockExecutor()
+			executor.outputs["make -n -f "+makefilePath] = ""
+			service := NewRemoveService(&Config{MakefilePath: makefilePath}, executor, false)
+			require.NoError(t, service.RemoveTarget(context.Background()))
+
+			wantFiles := tt.wantFiles
+			if wantFiles == nil {
+				wantFiles = tt.files
+			}
+			for name, want := range wantFiles {
+				got, err := os.ReadFile(filepath.Join(tmpDir, name))
+				require.NoError(t, err)
+				assert.Equal(t, want, string(got), name)
+			}
+			assert.NoFileExists(t, helpFile)
+			if wantFiles["make/test.mk"] == "" {
+				assert.NoDirExists(t, filepath.Join(tmpDir, "make"))
+			}
+		})
+	}
+}
+
+func TestRemoveService_RemoveTarget_RestoresIncludedMakefile(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	makefilePath := filepath.Join(tmpDir, "Makefile")
+	includedPath := filepath.Join(tmpDir, "common.mk")
+	helpFile := filepath.Join(tmpDir, "help.mk")
+
+	included := "help:\n\t@echo help\n"
+	replaced, err := CommentOutHelpTarget([]byte(included), 1)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(includedPath, replaced, 0644))
+
+	makefile := "include common.mk\n"
+	require.NoError(t, os.WriteFile(makefilePath, WithIncludeDirective([]byte(makefile), makefilePath, helpFile), 0644))
+	require.NoError(t, os.WriteFile(helpFile, []byte("# generated-by: make-help\n\n"+
+		"MAKE_HELP_DIR := $(dir $(lastword $(MAKEFILE_LIST)))\n"+
+		"MAKE_HELP_MAKEFILES := $(MAKE_HELP_DIR)Makefile $(MAKE_HELP_DIR)common.mk\n"), 0644))
+
+	executor := NewMockExecutor()
+	executor.outputs["make -n -f "+makefilePath] = ""
+	service := NewRemoveService(&Config{MakefilePath: makefilePath}, executor, false)
+	require.NoError(t, service.RemoveTarget(context.Background()))
+
+	content, err := os.ReadFile(makefilePath)
+	require.NoError(t, err)
+	assert.Equal(t, makefile, string(content))
+
+	content, err = os.ReadFile(includedPath)
+	require.NoError(t, err)
+	assert.Equal(t, included, string(content))
+
+	assert.NoFileExists(t, helpFile)
+}
+
+func TestApplyFileChanges_RollsBack(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	makefilePath := filepath.Join(tmpDir, "Makefile")
+	helpFile := filepath.Join(tmpDir, "help.mk")
+	require.NoError(t, os.WriteFile(makefilePath, []byte("original\n"), 0644))
+	require.NoError(t, os.WriteFile(helpFile, []byte("help\n"), 0644))
+
+	err := applyFileChanges([]fileChange{
+		{path: makefilePath, content: []byte("changed\n"), original: []byte("original\n"), perm: 0644},
+		{path: helpFile, original: []byte("help\n"), perm: 0644},
+		{path: filepath.Join(tmpDir, "missing", "help.mk"), original: []byte("help\n"), perm: 0644},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "missing")
+
+	content, err := os.ReadFile(makefilePath)
+	require.NoError(t, err)
+	assert.Equal(t, "original\n", string(content))
+
+	content, err = os.ReadFile(helpFile)
+	require.NoError(t, err)
+	assert.Equal(t, "help\n", string(content))
+}
@@ -0,0 +1,8 @@
+// Package usage records which make-help commands, flags, and formats are
+// run in a project, so a team can see which generated outputs are used.
+//
+// Recording is opt-in, with "usage": {"record": true} in .make-help.json.
+// Counts live in a JSON file next to the Makefile (.make-help-usage.json);
+// only flag names and format names are stored, never flag values, and
+// nothing is sent anywhere.
+package usage
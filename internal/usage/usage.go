@@ -0,0 +1,111 @@
+package usage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/sdlcforge/make-help/internal/target"
+)
+
+// FileName is the name of the usage file, created in the Makefile directory.
+const FileName = ".make-help-usage.json"
+
+// Count is how often something was used, and when it was last used.
+type Count struct {
+	Count    int       `json:"count"`
+	LastUsed time.Time `json:"lastUsed"`
+}
+
+// Stats holds the recorded usage of a project.
+type Stats struct {
+	// Since is when the first use was recorded.
+	Since time.Time `json:"since"`
+
+	// Commands counts runs by command: a mode flag such as "--lint" or
+	// "--inject", or "generate" for the help file and "render" for help
+	// written to stdout or --output.
+	Commands map[string]Count `json:"commands"`
+
+	// Flags counts the flags given, by name (e.g., "--format").
+	Flags map[string]Count `json:"flags"`
+
+	// Formats counts the formats help was rendered in.
+	Formats map[string]Count `json:"formats"`
+}
+
+// Invocation describes one make-help run. Runs that fail are counted too,
+// since a failing --lint is still a use; invalid command lines are not.
+type Invocation struct {
+	Command string
+	Flags   []string
+	Formats []string
+}
+
+// Path returns the usage file path for the Makefile directory dir.
+func Path(dir string) string {
+	return filepath.Join(dir, FileName)
+}
+
+// Load reads the usage file in dir. A missing file yields empty stats.
+func Load(dir string) (*Stats, error) {
+	stats := &Stats{}
+
+	data, err := os.ReadFile(Path(dir))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read usage stats: %w", err)
+	}
+	if err == nil {
+		if err := json.Unmarshal(data, stats); err != nil {
+			return nil, fmt.Errorf("failed to parse usage stats %s: %w", Path(dir), err)
+		}
+	}
+	if stats.Commands == nil {
+		stats.Commands = make(map[string]Count)
+	}
+	if stats.Flags == nil {
+		stats.Flags = make(map[string]Count)
+	}
+	if stats.Formats == nil {
+		stats.Formats = make(map[string]Count)
+	}
+	return stats, nil
+}
+
+// Record adds the invocation at the given time to the usage file in dir.
+// Concurrent runs may lose each other's counts; the file is only a guide.
+func Record(dir string, invocation Invocation, at time.Time) error {
+	stats, err := Load(dir)
+	if err != nil {
+		return err
+	}
+	at = at.UTC()
+	if stats.Since.IsZero() {
+		stats.Since = at
+	}
+	add(stats.Commands, at, invocation.Command)
+	add(stats.Flags, at, invocation.Flags...)
+	add(stats.Formats, at, invocation.Formats...)
+
+	data, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode usage stats: %w", err)
+	}
+	data = append(data, '\n')
+	if err := target.AtomicWriteFile(Path(dir), data, 0644); err != nil {
+		return fmt.Errorf("failed to write usage stats: %w", err)
+	}
+	return nil
+}
+
+// add counts one use of each name at the given time.
+func add(counts map[string]Count, at time.Time, names ...string) {
+	for _, name := range names {
+		count := counts[name]
+		count.Count++
+		count.LastUsed = at
+		counts[name] = count
+	}
+}
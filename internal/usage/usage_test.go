@@ -0,0 +1,61 @@
+package usage
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_MissingFile(t *testing.T) {
+	t.Parallel()
+	stats, err := Load(t.TempDir())
+	require.NoError(t, err)
+	assert.True(t, stats.Since.IsZero())
+	assert.Empty(t, stats.Commands)
+	assert.Empty(t, stats.Flags)
+	assert.Empty(t, stats.Formats)
+}
+
+func TestRecord(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	first := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	second := first.Add(time.Hour)
+
+	require.NoError(t, Record(dir, Invocation{Command: "generate", Formats: []string{"make"}}, first))
+	require.NoError(t, Record(dir, Invocation{
+		Command: "--inject",
+		Flags:   []string{"--inject", "--format"},
+		Formats: []string{"markdown"},
+	}, second))
+	require.NoError(t, Record(dir, Invocation{Command: "generate", Formats: []string{"make"}}, second))
+
+	stats, err := Load(dir)
+	require.NoError(t, err)
+	assert.Equal(t, first, stats.Since)
+	assert.Equal(t, map[string]Count{
+		"generate": {Count: 2, LastUsed: second},
+		"--inject": {Count: 1, LastUsed: second},
+	}, stats.Commands)
+	assert.Equal(t, map[string]Count{
+		"--inject": {Count: 1, LastUsed: second},
+		"--format": {Count: 1, LastUsed: second},
+	}, stats.Flags)
+	assert.Equal(t, map[string]Count{
+		"make":     {Count: 2, LastUsed: second},
+		"markdown": {Count: 1, LastUsed: second},
+	}, stats.Formats)
+}
+
+func TestLoad_InvalidFile(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(Path(dir), []byte("{"), 0644))
+
+	_, err := Load(dir)
+	assert.ErrorContains(t, err, "failed to parse usage stats")
+	assert.ErrorContains(t, Record(dir, Invocation{Command: "generate"}, time.Now()), "failed to parse usage stats")
+}
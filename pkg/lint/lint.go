@@ -0,0 +1,223 @@
+// Package lint runs make-help's documentation checks from other Go
+// programs, such as linters that aggregate many tools or repository health
+// scanners.
+//
+// Load builds the CheckContext of a Makefile with the code of
+// "make-help --lint"; callers that already know the targets may fill in a
+// CheckContext themselves. Run runs the built-in checks and any registered
+// with Register:
+//
+//	lint.MustRegister(lint.Check{Name: "acme-ticket", CheckFunc: checkTicket})
+//
+//	ctx, err := lint.Load(context.Background(), "Makefile", nil)
+//	if err != nil {
+//		return err
+//	}
+//	for _, w := range lint.Run(ctx, nil).Warnings {
+//		fmt.Println(lint.FormatWarning(w))
+//	}
+package lint
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/sdlcforge/make-help/internal/discovery"
+	internallint "github.com/sdlcforge/make-help/internal/lint"
+	"github.com/sdlcforge/make-help/internal/lintload"
+)
+
+var (
+	registryMu sync.Mutex
+	registered []Check
+)
+
+// Register adds check to the checks Checks returns, typically from an init
+// function. The name must be set and not used by a built-in or registered
+// check, and CheckFunc must be set. Warnings the check returns without a
+// CheckName or Severity get the check's name and SeverityWarning.
+func Register(check Check) error {
+	if check.Name == "" {
+		return fmt.Errorf("lint check has no name")
+	}
+	if check.CheckFunc == nil {
+		return fmt.Errorf("lint check %s has no CheckFunc", check.Name)
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if slices.ContainsFunc(internallint.AllChecks(), func(c internallint.Check) bool { return c.Name == check.Name }) ||
+		slices.ContainsFunc(registered, func(c Check) bool { return c.Name == check.Name }) {
+		return fmt.Errorf("lint check %s is already registered", check.Name)
+	}
+
+	name, checkFunc := check.Name, check.CheckFunc
+	check.CheckFunc = func(ctx *CheckContext) []Warning {
+		warnings := checkFunc(ctx)
+		for i := range warnings {
+			if warnings[i].CheckName == "" {
+				warnings[i].CheckName = name
+			}
+			if warnings[i].Severity == "" {
+				warnings[i].Severity = SeverityWarning
+			}
+		}
+		return warnings
+	}
+	registered = append(registered, check)
+	return nil
+}
+
+// MustRegister is like Register but panics if the check cannot be
+// registered.
+func MustRegister(check Check) {
+	if err := Register(check); err != nil {
+		panic(err)
+	}
+}
+
+// Checks returns the built-in checks followed by the registered ones, in
+// registration order. Fixes that rename targets are turned off, as they are
+// without --rename.
+func Checks() []Check {
+	var checks []Check
+	for _, check := range internallint.WithoutRenames(internallint.AllChecks()) {
+		checks = append(checks, newCheck(check))
+	}
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	return append(checks, registered...)
+}
+
+// Checks returns the checks Run runs on ctx by default: those selected by
+// Load followed by the registered ones, or all of Checks for a context not
+// made by Load.
+func (ctx *CheckContext) Checks() []Check {
+	if ctx.checks == nil {
+		return Checks()
+	}
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	return append(slices.Clone(ctx.checks), registered...)
+}
+
+// Without returns checks minus the ones named in disabled. Unknown names are
+// an error.
+func Without(checks []Check, disabled ...string) ([]Check, error) {
+	for _, name := range disabled {
+		if !slices.ContainsFunc(checks, func(c Check) bool { return c.Name == name }) {
+			return nil, fmt.Errorf("unknown lint check: %s", name)
+		}
+	}
+	return slices.DeleteFunc(slices.Clone(checks), func(c Check) bool { return slices.Contains(disabled, c.Name) }), nil
+}
+
+// Run runs checks on ctx in parallel, or ctx.Checks() when checks is nil.
+func Run(ctx *CheckContext, checks []Check) *Result {
+	if checks == nil {
+		checks = ctx.Checks()
+	}
+	native := make([]internallint.Check, 0, len(checks))
+	for _, check := range checks {
+		native = append(native, check.internal(ctx))
+	}
+	lintResult := internallint.Lint(ctx.internal(), native)
+
+	result := &Result{HasWarnings: lintResult.HasWarnings, Files: lintResult.Files}
+	for _, w := range lintResult.Warnings {
+		result.Warnings = append(result.Warnings, newWarning(w))
+	}
+	return result
+}
+
+// Fixes returns the fixes for the fixable warnings of a run of checks.
+func Fixes(checks []Check, warnings []Warning) []Fix {
+	var fixes []Fix
+	for _, w := range warnings {
+		i := slices.IndexFunc(checks, func(c Check) bool { return c.Name == w.CheckName })
+		if !w.Fixable || i < 0 || checks[i].FixFunc == nil {
+			continue
+		}
+		if fix := checks[i].FixFunc(w); fix != nil {
+			fixes = append(fixes, *fix)
+		}
+	}
+	return fixes
+}
+
+// FormatWarning formats a warning as "file:line: severity: message",
+// followed by its context line if any.
+func FormatWarning(w Warning) string {
+	return internallint.FormatWarning(w.internal())
+}
+
+// Options configures Load. The zero value matches
+// "make-help --lint --no-exec --no-plugins": nothing is run.
+type Options struct {
+	// RunMake runs make to list the included files and the targets, as
+	// "make-help --lint" does. make runs the $(shell ...) expressions of
+	// the Makefiles, so only set it for trusted projects. Without it, the
+	// Makefiles are read as written, as --no-exec does.
+	RunMake bool
+
+	// DefaultCategory is the category of targets without one, as
+	// --default-category sets.
+	DefaultCategory string
+
+	// EntryPoint names the file whose !file documentation introduces the
+	// help, as --entry-point does.
+	EntryPoint string
+
+	// SpellLang enables the spelling check with the dictionary of the
+	// language (e.g., "en"), as --spell and --spell-lang do. Empty skips it.
+	SpellLang string
+
+	// RunPlugins runs the lint plugins: the make-help-check-* executables
+	// on PATH and, when RunMake is also set, those configured in the
+	// project's .make-help.json.
+	RunPlugins bool
+}
+
+// Load discovers, parses, and builds the help model of the Makefile at
+// makefilePath and its includes and returns their CheckContext, sharing the
+// implementation of "make-help --lint". The project's .make-help.json and
+// .makehelpignore are applied, and the context's Checks are the built-in
+// checks minus lint.disable.
+//
+// By default Load runs nothing: the Makefiles are read without running
+// make, and the lint plugins are left out. Set options.RunMake to discover
+// the included files and targets with make, and options.RunPlugins to add
+// the lint plugins to the checks, as "make-help --lint" does.
+func Load(ctx context.Context, makefilePath string, options *Options) (*CheckContext, error) {
+	if options == nil {
+		options = &Options{}
+	}
+
+	makefilePath, err := discovery.ResolveMakefilePath(makefilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve Makefile path: %w", err)
+	}
+	if err := discovery.ValidateMakefileExists(makefilePath); err != nil {
+		return nil, err
+	}
+
+	service := discovery.NewService(discovery.NewDefaultExecutor(), false)
+	service.SetNoExec(!options.RunMake)
+	native, checks, err := lintload.Load(ctx, service, makefilePath, &lintload.Options{
+		DefaultCategory:  options.DefaultCategory,
+		EntryPoint:       options.EntryPoint,
+		SpellLang:        options.SpellLang,
+		NoPlugins:        !options.RunPlugins,
+		NoProjectPlugins: !options.RunMake,
+	})
+	if err != nil {
+		return nil, err
+	}
+	checkCtx := newCheckContext(native)
+	for _, check := range checks {
+		checkCtx.checks = append(checkCtx.checks, newCheck(check))
+	}
+	return checkCtx, nil
+}
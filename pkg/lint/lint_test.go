@@ -0,0 +1,229 @@
+package lint
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// resetRegistry restores the registry when the test ends.
+func resetRegistry(t *testing.T) {
+	t.Helper()
+	registryMu.Lock()
+	saved := registered
+	registered = nil
+	registryMu.Unlock()
+	t.Cleanup(func() {
+		registryMu.Lock()
+		registered = saved
+		registryMu.Unlock()
+	})
+}
+
+// checkNames returns the names of checks.
+func checkNames(checks []Check) []string {
+	var names []string
+	for _, c := range checks {
+		names = append(names, c.Name)
+	}
+	return names
+}
+
+// Not parallel: modifies the package registry.
+func TestRegister(t *testing.T) {
+	resetRegistry(t)
+
+	require.NoError(t, Register(Check{Name: "acme-ticket", CheckFunc: func(*CheckContext) []Warning { return nil }}))
+
+	names := checkNames(Checks())
+	assert.Equal(t, "acme-ticket", names[len(names)-1])
+	assert.Contains(t, names, "undocumented-phony")
+}
+
+// Not parallel: modifies the package registry.
+func TestRegister_Errors(t *testing.T) {
+	resetRegistry(t)
+	noop := func(*CheckContext) []Warning { return nil }
+	require.NoError(t, Register(Check{Name: "acme-ticket", CheckFunc: noop}))
+
+	tests := []struct {
+		name  string
+		check Check
+		want  string
+	}{
+		{"no name", Check{CheckFunc: noop}, "has no name"},
+		{"no func", Check{Name: "acme-owner"}, "has no CheckFunc"},
+		{"built-in name", Check{Name: "long-summary", CheckFunc: noop}, "already registered"},
+		{"registered name", Check{Name: "acme-ticket", CheckFunc: noop}, "already registered"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Register(tt.check)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+	assert.Panics(t, func() { MustRegister(Check{Name: "acme-ticket", CheckFunc: noop}) })
+}
+
+// Not parallel: modifies the package registry.
+func TestRun_RegisteredCheckDefaults(t *testing.T) {
+	resetRegistry(t)
+	MustRegister(Check{
+		Name: "acme-ticket",
+		CheckFunc: func(ctx *CheckContext) []Warning {
+			var warnings []Warning
+			for _, category := range ctx.HelpModel.Categories {
+				for _, target := range category.Targets {
+					if !strings.Contains(strings.Join(target.Documentation, " "), "ACME-") {
+						warnings = append(warnings, Warning{
+							File:    target.SourceFile,
+							Line:    target.LineNumber,
+							Message: "target " + target.Name + " does not reference a ticket",
+						})
+					}
+				}
+			}
+			return warnings
+		},
+	})
+
+	ctx := &CheckContext{
+		HelpModel: &HelpModel{Categories: []Category{{
+			Name: "Build",
+			Targets: []Target{
+				{Name: "build", Documentation: []string{"Build the app (ACME-12)."}, Summary: []string{"Build the app (ACME-12)."}, SourceFile: "Makefile", LineNumber: 3},
+				{Name: "test", Documentation: []string{"Run the tests."}, Summary: []string{"Run the tests."}, SourceFile: "Makefile", LineNumber: 7},
+			},
+		}}},
+		MakefilePath: "Makefile",
+		Makefiles:    []string{"Makefile"},
+	}
+
+	result := Run(ctx, nil)
+
+	var custom []Warning
+	for _, w := range result.Warnings {
+		if w.CheckName == "acme-ticket" {
+			custom = append(custom, w)
+		}
+	}
+	require.Len(t, custom, 1)
+	assert.Equal(t, SeverityWarning, custom[0].Severity)
+	assert.Equal(t, "Makefile:7: warning: target test does not reference a ticket", FormatWarning(custom[0]))
+}
+
+func TestWithout(t *testing.T) {
+	t.Parallel()
+
+	checks, err := Without(Checks(), "spelling", "naming")
+	require.NoError(t, err)
+	names := checkNames(checks)
+	assert.NotContains(t, names, "spelling")
+	assert.NotContains(t, names, "naming")
+	assert.Contains(t, names, "long-summary")
+
+	_, err = Without(Checks(), "no-such-check")
+	assert.Error(t, err)
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	makefile := filepath.Join(dir, "Makefile")
+	content := "## !category Build\n" +
+		"## Build the app\n" +
+		"build:\n" +
+		"\tgo build ./...\n" +
+		"\n" +
+		".PHONY: build deploy\n" +
+		"deploy:\n" +
+		"\t./deploy.sh\n"
+	require.NoError(t, os.WriteFile(makefile, []byte(content), 0644))
+
+	ctx, err := Load(context.Background(), makefile, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{makefile}, ctx.Makefiles)
+	assert.True(t, ctx.DocumentedTargets["build"])
+	assert.True(t, ctx.PhonyTargets["deploy"])
+
+	result := Run(ctx, nil)
+	var checks []string
+	for _, w := range result.Warnings {
+		checks = append(checks, w.CheckName)
+	}
+	assert.True(t, slices.Contains(checks, "summary-punctuation"), "got %v", checks)
+	assert.True(t, slices.Contains(checks, "undocumented-phony"), "got %v", checks)
+
+	fixes := Fixes(Checks(), result.Warnings)
+	require.NotEmpty(t, fixes)
+	assert.Equal(t, Fix{File: makefile, Line: 2, Operation: FixReplace, OldContent: "## Build the app", NewContent: "## Build the app."}, fixes[0])
+}
+
+func TestLoad_ProjectConfig(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	makefile := filepath.Join(dir, "Makefile")
+	content := ".PHONY: deploy\n" +
+		"deploy:\n" +
+		"\t./deploy.sh\n"
+	require.NoError(t, os.WriteFile(makefile, []byte(content), 0644))
+	config := `{"lint": {"disable": ["undocumented-phony"]}}`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".make-help.json"), []byte(config), 0644))
+
+	ctx, err := Load(context.Background(), makefile, nil)
+	require.NoError(t, err)
+	assert.NotContains(t, checkNames(ctx.Checks()), "undocumented-phony")
+	assert.Contains(t, checkNames(ctx.Checks()), "long-summary")
+	assert.Empty(t, Run(ctx, nil).Warnings)
+}
+
+func TestLoad_RunMakeAndPlugins(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	makefile := filepath.Join(dir, "Makefile")
+	marker := filepath.Join(dir, "ran")
+	content := "DUMMY := $(shell touch " + marker + ")\n" +
+		"## Build the app.\n" +
+		"build:\n" +
+		"\tgo build ./...\n"
+	require.NoError(t, os.WriteFile(makefile, []byte(content), 0644))
+	plugin := "#!/bin/sh\ncat > /dev/null\necho '{\"warnings\": []}'\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "make-help-check-ticket"), []byte(plugin), 0755))
+	config := `{"lint": {"plugins": ["./make-help-check-ticket"]}}`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".make-help.json"), []byte(config), 0644))
+
+	// By default, neither make nor the plugins run
+	ctx, err := Load(context.Background(), makefile, nil)
+	require.NoError(t, err)
+	assert.True(t, ctx.DocumentedTargets["build"])
+	assert.NotContains(t, checkNames(ctx.Checks()), "ticket")
+	assert.NoFileExists(t, marker)
+
+	// The project's plugins only run along with make
+	ctx, err = Load(context.Background(), makefile, &Options{RunPlugins: true})
+	require.NoError(t, err)
+	assert.NotContains(t, checkNames(ctx.Checks()), "ticket")
+	assert.NoFileExists(t, marker)
+
+	ctx, err = Load(context.Background(), makefile, &Options{RunMake: true, RunPlugins: true})
+	require.NoError(t, err)
+	assert.Contains(t, checkNames(ctx.Checks()), "ticket")
+	assert.FileExists(t, marker)
+}
+
+func TestLoad_MissingMakefile(t *testing.T) {
+	t.Parallel()
+
+	_, err := Load(context.Background(), filepath.Join(t.TempDir(), "Makefile"), nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,544 @@
+package lint
+
+import (
+	internallint "github.com/sdlcforge/make-help/internal/lint"
+	"github.com/sdlcforge/make-help/internal/model"
+)
+
+// Severity is the severity of a warning.
+type Severity string
+
+// Severities of warnings.
+const (
+	// SeverityWarning is a potential issue that should be reviewed.
+	SeverityWarning Severity = "warning"
+
+	// SeverityError is a problem that breaks make or the generated help.
+	SeverityError Severity = "error"
+)
+
+// Warning is a single issue found by a check.
+type Warning struct {
+	// File is the Makefile the issue was found in.
+	File string
+
+	// Line is the line of the issue, or 0 if it has none.
+	Line int
+
+	// Severity is the severity of the issue.
+	Severity Severity
+
+	// CheckName is the name of the check that found the issue.
+	CheckName string
+
+	// Message describes the issue.
+	Message string
+
+	// Context is the line the issue is on, if any.
+	Context string
+
+	// Replacement is the corrected Context line, if the check knows it.
+	Replacement string
+
+	// Fixable is set by Run when the check can fix the issue.
+	Fixable bool
+}
+
+// FixOperation is the kind of change a Fix makes.
+type FixOperation int
+
+// Kinds of fixes.
+const (
+	// FixReplace replaces the line with NewContent.
+	FixReplace FixOperation = iota
+
+	// FixDelete removes the line.
+	FixDelete
+
+	// FixRename renames the target OldContent to NewContent wherever it is
+	// defined or named as a prerequisite.
+	FixRename
+)
+
+// Fix is a change to one line of a Makefile.
+type Fix struct {
+	// File is the absolute path of the Makefile to change.
+	File string
+
+	// Line is the 1-based line to change.
+	Line int
+
+	// Operation is the kind of change.
+	Operation FixOperation
+
+	// OldContent is the expected content of the line, or the target to
+	// rename.
+	OldContent string
+
+	// NewContent is the new content of the line, or the new target name.
+	NewContent string
+}
+
+// CheckFunc examines a CheckContext and returns the warnings found.
+type CheckFunc func(ctx *CheckContext) []Warning
+
+// FixFunc returns the fix for a warning, or nil if it cannot be fixed.
+type FixFunc func(w Warning) *Fix
+
+// Check is a named check with an optional fix.
+type Check struct {
+	// Name identifies the check in warnings and lint.disable.
+	Name string
+
+	// CheckFunc runs the check.
+	CheckFunc CheckFunc
+
+	// FixFunc fixes the warnings of the check. Nil if it has no fixes.
+	FixFunc FixFunc
+
+	// native is the make-help check this one was made from, run directly
+	// rather than through CheckFunc.
+	native *internallint.Check
+}
+
+// TargetLocation is where a target is defined.
+type TargetLocation struct {
+	File string
+	Line int
+}
+
+// CheckContext holds everything checks read about the Makefiles.
+type CheckContext struct {
+	// HelpModel is the documentation of the Makefiles.
+	HelpModel *HelpModel
+
+	// MakefilePath is the main Makefile.
+	MakefilePath string
+
+	// Makefiles lists every Makefile checked, the main one first.
+	Makefiles []string
+
+	// PhonyTargets maps target names to their .PHONY status.
+	PhonyTargets map[string]bool
+
+	// Dependencies maps target names to their prerequisites.
+	Dependencies map[string][]string
+
+	// HasRecipe maps target names to whether they have a recipe.
+	HasRecipe map[string]bool
+
+	// DocumentedTargets is the set of documented target names.
+	DocumentedTargets map[string]bool
+
+	// Aliases is the set of target aliases.
+	Aliases map[string]bool
+
+	// TargetLocations maps target names to where they are defined.
+	TargetLocations map[string]TargetLocation
+
+	// CategoryOrder is the order help lists the categories in. Empty skips
+	// the category order check.
+	CategoryOrder []string
+
+	// OwnerCategories lists glob patterns (e.g., "Deploy*") naming the
+	// categories whose targets must have an owner. Empty skips the
+	// missing-owner check.
+	OwnerCategories []string
+
+	// loaded is the context Load built, which also holds what the built-in
+	// checks read that has no field here, such as the spelling dictionary.
+	loaded *internallint.CheckContext
+
+	// checks are the checks Load selected for Run.
+	checks []Check
+}
+
+// HelpModel is the documentation of the Makefiles, by category.
+type HelpModel struct {
+	// FileDocs is the !file documentation of each Makefile.
+	FileDocs []FileDoc
+
+	// Categories are the categories of the documented targets.
+	Categories []Category
+
+	// HasCategories is true if any !category directive was found.
+	HasCategories bool
+
+	// DefaultCategory is the category of targets without one.
+	DefaultCategory string
+
+	// DefaultGoal is the target make runs without arguments, if known.
+	DefaultGoal string
+
+	// Usage is the !usage line.
+	Usage string
+
+	// Examples are the !example lines.
+	Examples []string
+
+	// Title is the !title of the project.
+	Title string
+
+	// Homepage is the !homepage URL of the project.
+	Homepage string
+
+	// Repo is the !repo URL of the project.
+	Repo string
+
+	// FooterDocs is the !footer documentation.
+	FooterDocs []string
+}
+
+// FileDoc is the !file documentation of a Makefile.
+type FileDoc struct {
+	SourceFile     string
+	Documentation  []string
+	DiscoveryOrder int
+
+	// IsEntryPoint is true for the file whose documentation introduces the
+	// help.
+	IsEntryPoint bool
+
+	// Owner is the !owner of the file.
+	Owner string
+}
+
+// Category is a group of documented targets.
+type Category struct {
+	// Name is the category name. Empty for targets without a category.
+	Name string
+
+	Targets        []Target
+	Documentation  []string
+	DiscoveryOrder int
+}
+
+// Target is a documented target. The fields hold the target's directives.
+type Target struct {
+	Name               string
+	Aliases            []string
+	Documentation      []string
+	Summary            []string
+	ExplicitSummary    string
+	Variables          []Variable
+	DiscoveryOrder     int
+	SourceFile         string
+	LineNumber         int
+	IsPhony            bool
+	Tags               []string
+	Deprecated         bool
+	DeprecationMessage string
+	Hidden             bool
+	Platforms          []string
+	Duration           string
+	Profiles           []string
+	Dangerous          bool
+	DangerReason       string
+	Owner              string
+	CIWorkflows        []string
+}
+
+// Variable is a documented variable of a target.
+type Variable struct {
+	Name        string
+	Description string
+	Required    bool
+	Choices     []string
+}
+
+// Result holds the warnings of a run.
+type Result struct {
+	// Warnings are sorted by file, line, and check.
+	Warnings []Warning
+
+	// HasWarnings is true if any warning was found.
+	HasWarnings bool
+
+	// Files lists the Makefiles checked.
+	Files []string
+}
+
+// The types above are kept apart from the internal ones so that make-help
+// can change those freely; the functions below convert between them.
+
+func newWarning(w internallint.Warning) Warning {
+	return Warning{
+		File:        w.File,
+		Line:        w.Line,
+		Severity:    Severity(w.Severity),
+		CheckName:   w.CheckName,
+		Message:     w.Message,
+		Context:     w.Context,
+		Replacement: w.Replacement,
+		Fixable:     w.Fixable,
+	}
+}
+
+func (w Warning) internal() internallint.Warning {
+	return internallint.Warning{
+		File:        w.File,
+		Line:        w.Line,
+		Severity:    internallint.Severity(w.Severity),
+		CheckName:   w.CheckName,
+		Message:     w.Message,
+		Context:     w.Context,
+		Replacement: w.Replacement,
+		Fixable:     w.Fixable,
+	}
+}
+
+func newFix(fix *internallint.Fix) *Fix {
+	if fix == nil {
+		return nil
+	}
+	return &Fix{
+		File:       fix.File,
+		Line:       fix.Line,
+		Operation:  FixOperation(fix.Operation),
+		OldContent: fix.OldContent,
+		NewContent: fix.NewContent,
+	}
+}
+
+func (fix *Fix) internal() *internallint.Fix {
+	if fix == nil {
+		return nil
+	}
+	return &internallint.Fix{
+		File:       fix.File,
+		Line:       fix.Line,
+		Operation:  internallint.FixOperation(fix.Operation),
+		OldContent: fix.OldContent,
+		NewContent: fix.NewContent,
+	}
+}
+
+// newCheck wraps a make-help check.
+func newCheck(native internallint.Check) Check {
+	check := Check{
+		Name:   native.Name,
+		native: &native,
+		CheckFunc: func(ctx *CheckContext) []Warning {
+			var warnings []Warning
+			for _, w := range native.CheckFunc(ctx.internal()) {
+				warnings = append(warnings, newWarning(w))
+			}
+			return warnings
+		},
+	}
+	if native.FixFunc != nil {
+		check.FixFunc = func(w Warning) *Fix {
+			return newFix(native.FixFunc(w.internal()))
+		}
+	}
+	return check
+}
+
+// internal returns check as a make-help check run on ctx.
+func (check Check) internal(ctx *CheckContext) internallint.Check {
+	if check.native != nil {
+		return *check.native
+	}
+	native := internallint.Check{
+		Name: check.Name,
+		CheckFunc: func(*internallint.CheckContext) []internallint.Warning {
+			var warnings []internallint.Warning
+			for _, w := range check.CheckFunc(ctx) {
+				warnings = append(warnings, w.internal())
+			}
+			return warnings
+		},
+	}
+	if check.FixFunc != nil {
+		native.FixFunc = func(w internallint.Warning) *internallint.Fix {
+			return check.FixFunc(newWarning(w)).internal()
+		}
+	}
+	return native
+}
+
+func newCheckContext(ctx *internallint.CheckContext) *CheckContext {
+	locations := make(map[string]TargetLocation, len(ctx.TargetLocations))
+	for name, location := range ctx.TargetLocations {
+		locations[name] = TargetLocation{File: location.File, Line: location.Line}
+	}
+	return &CheckContext{
+		HelpModel:         newHelpModel(ctx.HelpModel),
+		MakefilePath:      ctx.MakefilePath,
+		Makefiles:         ctx.Makefiles,
+		PhonyTargets:      ctx.PhonyTargets,
+		Dependencies:      ctx.Dependencies,
+		HasRecipe:         ctx.HasRecipe,
+		DocumentedTargets: ctx.DocumentedTargets,
+		Aliases:           ctx.Aliases,
+		TargetLocations:   locations,
+		CategoryOrder:     ctx.CategoryOrder,
+		OwnerCategories:   ctx.OwnerCategories,
+		loaded:            ctx,
+	}
+}
+
+// internal returns the make-help context of ctx: the one Load built, if
+// any, with the fields of ctx in place of its own.
+func (ctx *CheckContext) internal() *internallint.CheckContext {
+	native := &internallint.CheckContext{}
+	if ctx.loaded != nil {
+		copied := *ctx.loaded
+		native = &copied
+	}
+	var locations map[string]internallint.TargetLocation
+	if ctx.TargetLocations != nil {
+		locations = make(map[string]internallint.TargetLocation, len(ctx.TargetLocations))
+		for name, location := range ctx.TargetLocations {
+			locations[name] = internallint.TargetLocation{File: location.File, Line: location.Line}
+		}
+	}
+	native.HelpModel = ctx.HelpModel.internal()
+	native.MakefilePath = ctx.MakefilePath
+	native.Makefiles = ctx.Makefiles
+	native.PhonyTargets = ctx.PhonyTargets
+	native.Dependencies = ctx.Dependencies
+	native.HasRecipe = ctx.HasRecipe
+	native.DocumentedTargets = ctx.DocumentedTargets
+	native.Aliases = ctx.Aliases
+	native.TargetLocations = locations
+	native.CategoryOrder = ctx.CategoryOrder
+	native.OwnerCategories = ctx.OwnerCategories
+	return native
+}
+
+func newHelpModel(m *model.HelpModel) *HelpModel {
+	if m == nil {
+		return nil
+	}
+	helpModel := &HelpModel{
+		HasCategories:   m.HasCategories,
+		DefaultCategory: m.DefaultCategory,
+		DefaultGoal:     m.DefaultGoal,
+		Usage:           m.Usage,
+		Examples:        m.Examples,
+		Title:           m.Title,
+		Homepage:        m.Homepage,
+		Repo:            m.Repo,
+		FooterDocs:      m.FooterDocs,
+	}
+	for _, doc := range m.FileDocs {
+		helpModel.FileDocs = append(helpModel.FileDocs, FileDoc{
+			SourceFile:     doc.SourceFile,
+			Documentation:  doc.Documentation,
+			DiscoveryOrder: doc.DiscoveryOrder,
+			IsEntryPoint:   doc.IsEntryPoint,
+			Owner:          doc.Owner,
+		})
+	}
+	for _, category := range m.Categories {
+		converted := Category{
+			Name:           category.Name,
+			Documentation:  category.Documentation,
+			DiscoveryOrder: category.DiscoveryOrder,
+		}
+		for _, t := range category.Targets {
+			target := Target{
+				Name:               t.Name,
+				Aliases:            t.Aliases,
+				Documentation:      t.Documentation,
+				Summary:            t.Summary,
+				ExplicitSummary:    t.ExplicitSummary,
+				DiscoveryOrder:     t.DiscoveryOrder,
+				SourceFile:         t.SourceFile,
+				LineNumber:         t.LineNumber,
+				IsPhony:            t.IsPhony,
+				Tags:               t.Tags,
+				Deprecated:         t.Deprecated,
+				DeprecationMessage: t.DeprecationMessage,
+				Hidden:             t.Hidden,
+				Platforms:          t.Platforms,
+				Duration:           t.Duration,
+				Profiles:           t.Profiles,
+				Dangerous:          t.Dangerous,
+				DangerReason:       t.DangerReason,
+				Owner:              t.Owner,
+				CIWorkflows:        t.CIWorkflows,
+			}
+			for _, v := range t.Variables {
+				target.Variables = append(target.Variables, Variable{
+					Name:        v.Name,
+					Description: v.Description,
+					Required:    v.Required,
+					Choices:     v.Choices,
+				})
+			}
+			converted.Targets = append(converted.Targets, target)
+		}
+		helpModel.Categories = append(helpModel.Categories, converted)
+	}
+	return helpModel
+}
+
+func (m *HelpModel) internal() *model.HelpModel {
+	if m == nil {
+		return nil
+	}
+	helpModel := &model.HelpModel{
+		HasCategories:   m.HasCategories,
+		DefaultCategory: m.DefaultCategory,
+		DefaultGoal:     m.DefaultGoal,
+		Usage:           m.Usage,
+		Examples:        m.Examples,
+		Title:           m.Title,
+		Homepage:        m.Homepage,
+		Repo:            m.Repo,
+		FooterDocs:      m.FooterDocs,
+	}
+	for _, doc := range m.FileDocs {
+		helpModel.FileDocs = append(helpModel.FileDocs, model.FileDoc{
+			SourceFile:     doc.SourceFile,
+			Documentation:  doc.Documentation,
+			DiscoveryOrder: doc.DiscoveryOrder,
+			IsEntryPoint:   doc.IsEntryPoint,
+			Owner:          doc.Owner,
+		})
+	}
+	for _, category := range m.Categories {
+		converted := model.Category{
+			Name:           category.Name,
+			Documentation:  category.Documentation,
+			DiscoveryOrder: category.DiscoveryOrder,
+		}
+		for _, t := range category.Targets {
+			target := model.Target{
+				Name:               t.Name,
+				Aliases:            t.Aliases,
+				Documentation:      t.Documentation,
+				Summary:            t.Summary,
+				ExplicitSummary:    t.ExplicitSummary,
+				DiscoveryOrder:     t.DiscoveryOrder,
+				SourceFile:         t.SourceFile,
+				LineNumber:         t.LineNumber,
+				IsPhony:            t.IsPhony,
+				Tags:               t.Tags,
+				Deprecated:         t.Deprecated,
+				DeprecationMessage: t.DeprecationMessage,
+				Hidden:             t.Hidden,
+				Platforms:          t.Platforms,
+				Duration:           t.Duration,
+				Profiles:           t.Profiles,
+				Dangerous:          t.Dangerous,
+				DangerReason:       t.DangerReason,
+				Owner:              t.Owner,
+				CIWorkflows:        t.CIWorkflows,
+			}
+			for _, v := range t.Variables {
+				target.Variables = append(target.Variables, model.Variable{
+					Name:        v.Name,
+					Description: v.Description,
+					Required:    v.Required,
+					Choices:     v.Choices,
+				})
+			}
+			converted.Targets = append(converted.Targets, target)
+		}
+		helpModel.Categories = append(helpModel.Categories, converted)
+	}
+	return helpModel
+}
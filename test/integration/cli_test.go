@@ -1096,3 +1096,38 @@ func TestErrorScenario_WriteToStdoutFails(t *testing.T) {
 	// Note: Testing actual stdout write failures would require a more complex
 	// test setup (e.g., closing stdout, which is difficult in integration tests)
 }
+
+func TestRequiredVariables_HelpIncludedFirst(t *testing.T) {
+	if _, err := exec.LookPath("make"); err != nil {
+		t.Skip("make command not available")
+	}
+	binary := buildBinary(t)
+	tmpDir := t.TempDir()
+	content := "include make/help.mk\n\n" +
+		"## !var TOKEN (required) - API token\n" +
+		"## Deploy the application.\n" +
+		"deploy:\n" +
+		"\t@echo deploying\n"
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "Makefile"), []byte(content), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "make"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "make", "help.mk"), nil, 0644))
+
+	cmd := exec.Command(binary, "--no-color")
+	cmd.Dir = tmpDir
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(output))
+
+	// help.mk comes first, so make without a goal still shows the help
+	cmd = exec.Command("make")
+	cmd.Dir = tmpDir
+	output, err = cmd.CombinedOutput()
+	require.NoError(t, err, string(output))
+	assert.Contains(t, string(output), "deploy: Deploy the application.")
+	assert.NotContains(t, string(output), "deploying")
+
+	cmd = exec.Command("make", "deploy")
+	cmd.Dir = tmpDir
+	output, err = cmd.CombinedOutput()
+	require.Error(t, err)
+	assert.Contains(t, string(output), "make deploy: missing required variable(s): TOKEN")
+}
@@ -0,0 +1,74 @@
+//go:build integration
+
+package integration
+
+import (
+	"flag"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the expected output of the real-world fixtures")
+
+// fixturePathPlaceholder replaces the fixture directory in golden output,
+// which would otherwise hold the absolute path of the checkout.
+const fixturePathPlaceholder = "<fixture>"
+
+// TestRealWorldMakefiles runs the full pipeline on each project in
+// test/fixtures/realworld and compares the JSON help with
+// test/fixtures/expected/realworld/<project>.json.
+//
+// The Makefiles are vendored unchanged from open-source projects; NOTICE
+// names the source and license of each:
+//   - nix-kmod: the kbuild Makefile of an out-of-tree kernel module, whose
+//     default goal runs make -C on the kernel build tree.
+//   - pyenv: Docker-driven test targets generated with foreach and
+//     addsuffix, with target-specific variables and static pattern rules.
+//   - cobra: a Go project boilerplate, with $(shell ...) variables, a
+//     $(warning ...) at parse time, and $(info ...) in recipes.
+//
+// They carry no make-help documentation, so the help lists their .PHONY
+// targets (--include-all-phony). Discovery must not run their recipes: the
+// default goals of nix-kmod and pyenv would build a kernel module and
+// Docker images.
+//
+// After an intended change, run
+// "go test -tags=integration ./test/integration -run TestRealWorld -update"
+// and review the diff.
+func TestRealWorldMakefiles(t *testing.T) {
+	binary := buildBinary(t)
+	projectRoot := getProjectRoot(t)
+	projects, err := filepath.Glob(filepath.Join(projectRoot, "test", "fixtures", "realworld", "*"))
+	require.NoError(t, err)
+	require.NotEmpty(t, projects)
+
+	for _, dir := range projects {
+		name := filepath.Base(dir)
+		t.Run(name, func(t *testing.T) {
+			// make resolves includes against the working directory
+			cmd := exec.Command(binary, "--makefile-path", "Makefile", "--format", "json", "--output", "-", "--no-color", "--include-all-phony")
+			cmd.Dir = dir
+			var stderr strings.Builder
+			cmd.Stderr = &stderr
+			output, err := cmd.Output()
+			require.NoError(t, err, "stderr: %s", stderr.String())
+			got := strings.ReplaceAll(string(output), dir, fixturePathPlaceholder)
+
+			goldenPath := filepath.Join(projectRoot, "test", "fixtures", "expected", "realworld", name+".json")
+			if *updateGolden {
+				require.NoError(t, os.MkdirAll(filepath.Dir(goldenPath), 0755))
+				require.NoError(t, os.WriteFile(goldenPath, []byte(got), 0644))
+				return
+			}
+			want, err := os.ReadFile(goldenPath)
+			require.NoError(t, err, "failed to read expected output (run with -update to create it)")
+			assert.Equal(t, string(want), got)
+		})
+	}
+}
@@ -0,0 +1,125 @@
+//go:build perf
+
+// Package perf measures the help pipeline on a generated tree of 100
+// Makefiles with 5,000 targets and checks the results against the budgets
+// in budgets.json:
+//
+//	go test -tags=perf ./test/perf/                          # check budgets
+//	go test -tags=perf ./test/perf/ -args -perf.tolerance=10 # stricter
+//	go test -tags=perf ./test/perf/ -args -perf.update       # record budgets
+//	go test -tags=perf -run=^$ -bench=. ./test/perf/         # benchmarks only
+package perf
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"testing"
+)
+
+var (
+	tolerance     = flag.Float64("perf.tolerance", 20, "Percentage allocations may exceed their budget by")
+	timeTolerance = flag.Float64("perf.time-tolerance", 100, "Percentage latency may exceed its budget by, allowing for slower machines")
+	update        = flag.Bool("perf.update", false, "Record the measurements as the new budgets")
+)
+
+// budgetsFile holds the recorded budget of each stage.
+const budgetsFile = "budgets.json"
+
+// budget is the cost of one run of a stage.
+type budget struct {
+	NsPerOp     int64 `json:"ns_per_op"`
+	AllocsPerOp int64 `json:"allocs_per_op"`
+	BytesPerOp  int64 `json:"bytes_per_op"`
+}
+
+// shared is the fixture all measurements use, generated by TestMain.
+var shared *fixture
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+	dir, err := os.MkdirTemp("", "make-help-perf-*")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	shared = newFixture(&setupTB{}, dir)
+	code := m.Run()
+	_ = os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+func BenchmarkPipeline(b *testing.B) {
+	for _, s := range stages {
+		b.Run(s.name, func(b *testing.B) {
+			s.run(b, shared)
+		})
+	}
+}
+
+func TestBudgets(t *testing.T) {
+	budgets := map[string]budget{}
+	if !*update {
+		data, err := os.ReadFile(budgetsFile)
+		if err != nil {
+			t.Fatalf("failed to read budgets (record them with -perf.update): %v", err)
+		}
+		if err := json.Unmarshal(data, &budgets); err != nil {
+			t.Fatalf("failed to parse %s: %v", budgetsFile, err)
+		}
+	}
+
+	measured := map[string]budget{}
+	for _, s := range stages {
+		result := testing.Benchmark(func(b *testing.B) { s.run(b, shared) })
+		got := budget{NsPerOp: result.NsPerOp(), AllocsPerOp: result.AllocsPerOp(), BytesPerOp: result.AllocedBytesPerOp()}
+		measured[s.name] = got
+		t.Logf("%-16s %12d ns/op %10d allocs/op %12d B/op", s.name, got.NsPerOp, got.AllocsPerOp, got.BytesPerOp)
+
+		if *update {
+			continue
+		}
+		want, ok := budgets[s.name]
+		if !ok {
+			t.Errorf("%s: no budget in %s (record it with -perf.update)", s.name, budgetsFile)
+			continue
+		}
+		checkBudget(t, s.name, "ns/op", got.NsPerOp, want.NsPerOp, *timeTolerance)
+		checkBudget(t, s.name, "allocs/op", got.AllocsPerOp, want.AllocsPerOp, *tolerance)
+		checkBudget(t, s.name, "B/op", got.BytesPerOp, want.BytesPerOp, *tolerance)
+	}
+
+	if *update {
+		data, err := json.MarshalIndent(measured, "", "  ")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(budgetsFile, append(data, '\n'), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// checkBudget reports got exceeding want by more than tolerance percent.
+func checkBudget(t *testing.T, stage, unit string, got, want int64, tolerance float64) {
+	t.Helper()
+	limit := float64(want) * (1 + tolerance/100)
+	if float64(got) > limit {
+		t.Errorf("%s: %d %s exceeds the budget of %d by %.0f%% (tolerance %.0f%%)",
+			stage, got, unit, want, 100*(float64(got)/float64(want)-1), tolerance)
+	}
+}
+
+// setupTB reports fixture setup failures from TestMain, where there is no
+// testing.TB.
+type setupTB struct {
+	testing.TB
+}
+
+func (setupTB) Helper() {}
+
+func (setupTB) Fatal(args ...any) {
+	fmt.Fprintln(os.Stderr, args...)
+	os.Exit(1)
+}
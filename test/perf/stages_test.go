@@ -0,0 +1,202 @@
+//go:build perf
+
+package perf
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sdlcforge/make-help/internal/cli"
+	"github.com/sdlcforge/make-help/internal/discovery"
+	"github.com/sdlcforge/make-help/internal/format"
+	"github.com/sdlcforge/make-help/internal/model"
+	"github.com/sdlcforge/make-help/internal/ordering"
+	"github.com/sdlcforge/make-help/internal/parser"
+	"github.com/sdlcforge/make-help/internal/summary"
+)
+
+// stage is one step of the help pipeline, run by a benchmark.
+type stage struct {
+	name string
+	run  func(b *testing.B, f *fixture)
+}
+
+// fixture holds a generated tree and the output of each pipeline stage for
+// it, so a stage can be measured alone.
+type fixture struct {
+	makefilePath string
+	makefiles    []string
+	targets      *discovery.DiscoverTargetsResult
+	parsed       []*parser.ParsedFile
+}
+
+// newFixture generates a tree in dir and runs discovery and parsing once.
+func newFixture(tb testing.TB, dir string) *fixture {
+	tb.Helper()
+	f := &fixture{makefilePath: generateTree(tb, dir)}
+	service := discovery.NewService(discovery.NewDefaultExecutor(), false)
+
+	var err error
+	if f.makefiles, err = service.DiscoverMakefiles(context.Background(), f.makefilePath); err != nil {
+		tb.Fatal(err)
+	}
+	if f.targets, err = service.DiscoverTargets(context.Background(), f.makefilePath); err != nil {
+		tb.Fatal(err)
+	}
+	if f.parsed, err = parseAll(f.makefiles); err != nil {
+		tb.Fatal(err)
+	}
+	return f
+}
+
+// parseAll scans makefiles.
+func parseAll(makefiles []string) ([]*parser.ParsedFile, error) {
+	scanner := parser.NewScanner()
+	parsed := make([]*parser.ParsedFile, 0, len(makefiles))
+	for _, makefile := range makefiles {
+		file, err := scanner.ScanFile(makefile)
+		if err != nil {
+			return nil, err
+		}
+		parsed = append(parsed, file)
+	}
+	return parsed, nil
+}
+
+// buildModel builds the help model, as generating help does by default.
+func (f *fixture) buildModel() (*model.HelpModel, error) {
+	return model.NewBuilder(&model.BuilderConfig{
+		PhonyTargets: f.targets.IsPhony,
+		Dependencies: f.targets.Dependencies,
+		HasRecipe:    f.targets.HasRecipe,
+		DefaultGoal:  f.targets.DefaultGoal,
+		BaseDir:      filepath.Dir(f.makefilePath),
+	}).Build(f.parsed)
+}
+
+// orderedModel builds and orders the help model.
+func (f *fixture) orderedModel() (*model.HelpModel, error) {
+	helpModel, err := f.buildModel()
+	if err != nil {
+		return nil, err
+	}
+	if err := ordering.NewService(false, false, false, nil).ApplyOrdering(helpModel); err != nil {
+		return nil, err
+	}
+	return helpModel, nil
+}
+
+// summarize extracts the summary of each target again, as the model
+// builder does.
+func summarize(helpModel *model.HelpModel) {
+	extractor := summary.NewExtractor()
+	for i := range helpModel.Categories {
+		for j := range helpModel.Categories[i].Targets {
+			target := &helpModel.Categories[i].Targets[j]
+			target.Summary = []string{extractor.ExtractPlainText(target.Documentation)}
+		}
+	}
+}
+
+// renderStage measures rendering the ordered model in formatType.
+func renderStage(formatType string) func(b *testing.B, f *fixture) {
+	return func(b *testing.B, f *fixture) {
+		helpModel, err := f.orderedModel()
+		if err != nil {
+			b.Fatal(err)
+		}
+		formatter, err := format.NewFormatter(formatType, &format.FormatterConfig{MakefileDir: filepath.Dir(f.makefilePath)})
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if err := formatter.RenderHelp(helpModel, io.Discard); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+// stages are the measured pipeline stages, in pipeline order, followed by
+// the whole pipeline as run by the CLI.
+var stages = []stage{
+	{"discover", func(b *testing.B, f *fixture) {
+		service := discovery.NewService(discovery.NewDefaultExecutor(), false)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := service.DiscoverMakefiles(context.Background(), f.makefilePath); err != nil {
+				b.Fatal(err)
+			}
+			if _, err := service.DiscoverTargets(context.Background(), f.makefilePath); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}},
+	{"parse", func(b *testing.B, f *fixture) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := parseAll(f.makefiles); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}},
+	{"build", func(b *testing.B, f *fixture) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := f.buildModel(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}},
+	{"order", func(b *testing.B, f *fixture) {
+		// Ordering sorts in place; later runs sort the same elements again
+		helpModel, err := f.buildModel()
+		if err != nil {
+			b.Fatal(err)
+		}
+		service := ordering.NewService(false, false, false, nil)
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if err := service.ApplyOrdering(helpModel); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}},
+	{"summarize", func(b *testing.B, f *fixture) {
+		helpModel, err := f.buildModel()
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			summarize(helpModel)
+		}
+	}},
+	{"render-text", renderStage("text")},
+	{"render-make", renderStage("make")},
+	{"render-markdown", renderStage("markdown")},
+	{"render-html", renderStage("html")},
+	{"render-json", renderStage("json")},
+	{"end-to-end", func(b *testing.B, f *fixture) {
+		output := filepath.Join(b.TempDir(), "help.txt")
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			cmd := cli.NewRootCmd()
+			cmd.SetArgs([]string{"--makefile-path", f.makefilePath, "--format", "text", "--output", output, "--no-color"})
+			if err := cmd.Execute(); err != nil {
+				b.Fatal(err)
+			}
+		}
+		b.StopTimer()
+		if _, err := os.Stat(output); err != nil {
+			b.Fatal(err)
+		}
+	}},
+}
@@ -0,0 +1,71 @@
+//go:build perf
+
+package perf
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Size of the synthetic Makefile tree: the main Makefile and its included
+// fragments, with targets spread evenly over them.
+const (
+	treeFiles      = 100
+	treeTargets    = 5000
+	treeCategories = 20
+)
+
+// generateTree writes a Makefile including make/*.mk to dir, with
+// treeFiles files and treeTargets documented targets in treeCategories
+// categories, and returns the Makefile's path. Targets have several
+// documentation lines with inline formatting, and some have variables and
+// aliases, like real-world Makefiles.
+func generateTree(tb testing.TB, dir string) string {
+	tb.Helper()
+	perFile := treeTargets / treeFiles
+	require := func(err error) {
+		if err != nil {
+			tb.Fatal(err)
+		}
+	}
+
+	require(os.MkdirAll(filepath.Join(dir, "make"), 0755))
+	for file := 0; file < treeFiles; file++ {
+		var content strings.Builder
+		fmt.Fprintf(&content, "## !file\n## Tasks of component %d.\n## Generated for performance tests.\n\n", file)
+		var phony []string
+		for i := 0; i < perFile; i++ {
+			n := file*perFile + i
+			name := fmt.Sprintf("task-%04d", n)
+			phony = append(phony, name)
+			if i%10 == 0 {
+				fmt.Fprintf(&content, "## !category Category %02d\n", (n/10)%treeCategories)
+			}
+			if n%5 == 0 {
+				fmt.Fprintf(&content, "## !alias t%d\n", n)
+			}
+			if n%3 == 0 {
+				fmt.Fprintf(&content, "## !var VAR_%d Setting used by `%s`.\n", n%50, name)
+			}
+			fmt.Fprintf(&content, "## Run **task %d** of component %d. See the [docs](https://example.com/%d).\n", n, file, n)
+			fmt.Fprintf(&content, "## It runs the `step-%d` command\n## and writes its output to build/%d.\n", n, n)
+			fmt.Fprintf(&content, "%s:", name)
+			if i > 0 {
+				fmt.Fprintf(&content, " task-%04d", n-1)
+			}
+			fmt.Fprintf(&content, "\n\t@echo %s\n\n", name)
+		}
+		fmt.Fprintf(&content, ".PHONY: %s\n", strings.Join(phony, " "))
+
+		path := filepath.Join(dir, "make", fmt.Sprintf("component-%03d.mk", file))
+		if file == 0 {
+			path = filepath.Join(dir, "Makefile")
+			content.WriteString("\n-include $(dir $(lastword $(MAKEFILE_LIST)))make/*.mk\n")
+		}
+		require(os.WriteFile(path, []byte(content.String()), 0644))
+	}
+	return filepath.Join(dir, "Makefile")
+}